@@ -365,6 +365,9 @@ out:
 // savePeers saves all the known addresses to a file so they can be read back
 // in at next run.
 func (a *AddrManager) savePeers() {
+	if a.peersFile == "" {
+		return
+	}
 	a.mtx.Lock()
 	defer a.mtx.Unlock()
 
@@ -427,6 +430,9 @@ func (a *AddrManager) savePeers() {
 // loadPeers loads the known address from the saved file.  If empty, missing, or
 // malformed file, just don't load anything and start fresh
 func (a *AddrManager) loadPeers() {
+	if a.peersFile == "" {
+		return
+	}
 	a.mtx.Lock()
 	defer a.mtx.Unlock()
 
@@ -1126,11 +1132,20 @@ func (a *AddrManager) GetBestLocalAddress(remoteAddr *wire.NetAddress) *wire.Net
 	return bestAddress
 }
 
-// New returns a new bitcoin address manager.
+// New returns a new bitcoin address manager which keeps known addresses in
+// peers.json in dataDir.
 // Use Start to begin processing asynchronous address updates.
 func New(dataDir string, lookupFunc func(string) ([]net.IP, er.R)) *AddrManager {
+	return NewWithPeersFile(filepath.Join(dataDir, "peers.json"), lookupFunc)
+}
+
+// NewWithPeersFile returns a new bitcoin address manager which saves known
+// addresses to peersFile periodically and on Stop, and loads them back on
+// Start.  If peersFile is empty then known addresses are not kept across
+// restarts.
+func NewWithPeersFile(peersFile string, lookupFunc func(string) ([]net.IP, er.R)) *AddrManager {
 	am := AddrManager{
-		peersFile:      filepath.Join(dataDir, "peers.json"),
+		peersFile:      peersFile,
 		lookupFunc:     lookupFunc,
 		rand:           rand.New(rand.NewSource(time.Now().UnixNano())),
 		quit:           make(chan struct{}),
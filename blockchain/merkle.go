@@ -177,6 +177,41 @@ func GetMerkleBranch(txIndex int, tree []*chainhash.Hash) []*chainhash.Hash {
 	return out
 }
 
+// MerkleBranchRoot computes the merkle root which is implied by a leaf hash,
+// the leaf's index in the tree and the branch of sibling hashes leading from
+// the leaf to the root.  The branch is ordered from the bottom of the tree up,
+// as returned by GetMerkleBranch, and a nil entry means the node has no
+// sibling and was hashed with itself.
+//
+// Only the rightmost node of a level can lack a sibling, so nil is returned if
+// the branch has a nil entry for a node which is a right child or which is
+// left of a node the branch names, as well as if txIndex does not fit in a
+// tree of the branch's depth.
+func MerkleBranchRoot(leaf *chainhash.Hash, txIndex int, branch []*chainhash.Hash) *chainhash.Hash {
+	if txIndex < 0 || len(branch) < bits.Len(uint(txIndex)) {
+		return nil
+	}
+	h := leaf
+	ino := txIndex
+	rightmost := true
+	for _, sibling := range branch {
+		switch {
+		case sibling == nil:
+			if ino&1 != 0 || !rightmost {
+				return nil
+			}
+			h = HashMerkleBranches(h, h)
+		case ino&1 == 0:
+			h = HashMerkleBranches(h, sibling)
+			rightmost = false
+		default:
+			h = HashMerkleBranches(sibling, h)
+		}
+		ino >>= 1
+	}
+	return h
+}
+
 // ExtractWitnessCommitment attempts to locate, and return the witness
 // commitment for a block. The witness commitment is of the form:
 // SHA256(witness root || witness nonce). The function additionally returns a
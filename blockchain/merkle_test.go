@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
 )
 
 // TestMerkle tests the BuildMerkleTreeStore API.
@@ -21,3 +22,60 @@ func TestMerkle(t *testing.T) {
 			"got %v, want %v", calculatedMerkleRoot, wantMerkle)
 	}
 }
+
+// TestMerkleBranchRoot tests that the branches returned by GetMerkleBranch
+// lead MerkleBranchRoot to the merkle root, and that branches which leave out
+// the sibling of a node which is not the rightmost of its level are refused.
+func TestMerkleBranchRoot(t *testing.T) {
+	// Three transactions leave the third without a sibling.
+	txns := btcutil.NewBlock(&Block100000).Transactions()[:3]
+	tree := BuildMerkleTreeStore(txns, false)
+	root := tree[len(tree)-1]
+	for i, tx := range txns {
+		branch := GetMerkleBranch(i, tree)
+		if got := MerkleBranchRoot(tx.Hash(), i, branch); !root.IsEqual(got) {
+			t.Fatalf("got root %v for transaction %d, want %v", got, i,
+				root)
+		}
+	}
+
+	refused := []struct {
+		name   string
+		index  int
+		branch func() []*chainhash.Hash
+	}{{
+		name:  "right child without a sibling",
+		index: 1,
+		branch: func() []*chainhash.Hash {
+			b := GetMerkleBranch(1, tree)
+			b[0] = nil
+			return b
+		},
+	}, {
+		name:  "parent of a left child with a sibling",
+		index: 0,
+		branch: func() []*chainhash.Hash {
+			b := GetMerkleBranch(0, tree)
+			b[1] = nil
+			return b
+		},
+	}, {
+		name:  "index beyond the tree",
+		index: 4,
+		branch: func() []*chainhash.Hash {
+			return GetMerkleBranch(0, tree)
+		},
+	}, {
+		name:  "negative index",
+		index: -1,
+		branch: func() []*chainhash.Hash {
+			return GetMerkleBranch(0, tree)
+		},
+	}}
+	for _, test := range refused {
+		got := MerkleBranchRoot(txns[0].Hash(), test.index, test.branch())
+		if got != nil {
+			t.Fatalf("%s: got root %v, want nil", test.name, got)
+		}
+	}
+}
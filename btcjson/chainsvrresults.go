@@ -256,6 +256,7 @@ type GetNetworkInfoResult struct {
 	Relayfee           float64                  `json:"relayfee"`
 	Incrementalfee     float64                  `json:"incrementalfee"`
 	Localaddresses     []string                 `json:"localaddresses"`
+	Bech32HRP          string                   `json:"bech32hrp"`
 }
 
 type GetRawBlockTemplateResult struct {
@@ -47,6 +47,26 @@ func NewCreateMultisigCmd(nRequired int, keys []string) *CreateMultisigCmd {
 	}
 }
 
+// ConvertAddressCmd defines the convertaddress JSON-RPC command.
+type ConvertAddressCmd struct {
+	Address     string
+	AddressType string
+}
+
+// CreateNewAccountCmd defines the createnewaccount JSON-RPC command.
+type CreateNewAccountCmd struct {
+	Account     string
+	AddressType *string
+}
+
+// DeriveAddressesCmd defines the deriveaddresses JSON-RPC command.
+type DeriveAddressesCmd struct {
+	Seed        string
+	Count       int
+	AddressType *string `jsonrpcdefault:"\"p2wpkh\""`
+	Account     *uint32 `jsonrpcdefault:"0"`
+}
+
 // DumpPrivKeyCmd defines the dumpprivkey JSON-RPC command.
 type DumpPrivKeyCmd struct {
 	Address string
@@ -66,8 +86,107 @@ type GetAddressBalancesCmd struct {
 	ShowZeroBalance *bool
 }
 
+// GetAccountXpubsCmd defines the getaccountxpubs JSON-RPC command.
+type GetAccountXpubsCmd struct {
+	Account *uint32 `jsonrpcdefault:"0"`
+	Slip132 *bool   `jsonrpcdefault:"false"`
+}
+
+// ExportAccountWatchOnlyCmd defines the exportaccountwatchonly JSON-RPC
+// command.
+type ExportAccountWatchOnlyCmd struct {
+	Account *uint32 `jsonrpcdefault:"0"`
+}
+
+// AccountDescriptor describes an account within one key scope, its extended
+// public key and how many external and internal addresses have been issued.
+type AccountDescriptor struct {
+	Scope         string `json:"scope"`
+	AddressType   string `json:"addresstype"`
+	Xpub          string `json:"xpub"`
+	ExternalCount uint32 `json:"externalcount"`
+	InternalCount uint32 `json:"internalcount"`
+}
+
+// AccountDescriptorBundle is an account as exported by exportaccountwatchonly
+// and imported by importdescriptor.
+type AccountDescriptorBundle struct {
+	Account     string              `json:"account"`
+	Descriptors []AccountDescriptor `json:"descriptors"`
+}
+
+// ImportDescriptorCmd defines the importdescriptor JSON-RPC command.
+type ImportDescriptorCmd struct {
+	Bundle  AccountDescriptorBundle
+	Account *string
+	Rescan  *bool `jsonrpcdefault:"true"`
+}
+
+// ListAccountsCmd defines the listaccounts JSON-RPC command.
+type ListAccountsCmd struct {
+	MinConf *int `jsonrpcdefault:"1"`
+}
+
 type GetWalletSeedCmd struct{}
 
+// BackupSeedEncryptedCmd defines the backupseedencrypted JSON-RPC command.
+type BackupSeedEncryptedCmd struct {
+	WalletPassphrase string
+	Passphrase       string
+}
+
+// MarkAddressUsedCmd defines the markaddressused JSON-RPC command.
+type MarkAddressUsedCmd struct {
+	Address string
+}
+
+// MarkAddressUnusedCmd defines the markaddressunused JSON-RPC command.
+type MarkAddressUnusedCmd struct {
+	Address string
+}
+
+// GetTxProofCmd defines the gettxproof JSON-RPC command.
+type GetTxProofCmd struct {
+	Txid string
+}
+
+// GetTxStatusCmd defines the gettxstatus JSON-RPC command.
+type GetTxStatusCmd struct {
+	Txid string
+}
+
+// GetMempoolAncestorsCmd defines the getmempoolancestors JSON-RPC command.
+type GetMempoolAncestorsCmd struct {
+	Txid string
+}
+
+// EstimateConsolidationCmd defines the estimateconsolidation JSON-RPC command.
+type EstimateConsolidationCmd struct {
+	FeeRate *string
+}
+
+// EstimateConfirmationTimeCmd defines the estimateconfirmationtime JSON-RPC
+// command.
+type EstimateConfirmationTimeCmd struct {
+	Txid string
+}
+
+// VerifyPaymentRequestCmd defines the verifypaymentrequest JSON-RPC command.
+type VerifyPaymentRequestCmd struct {
+	PaymentRequest string
+}
+
+// VerifyWalletCmd defines the verifywallet JSON-RPC command.
+type VerifyWalletCmd struct{}
+
+// VerifyTxProofCmd defines the verifytxproof JSON-RPC command.
+type VerifyTxProofCmd struct {
+	Txid      string
+	BlockHash string
+	Index     int
+	Branch    []string
+}
+
 type GetSecretCmd struct {
 	Name string
 }
@@ -81,16 +200,140 @@ type ResyncCmd struct {
 
 type StopResyncCmd struct{}
 
+// CancelRescanCmd defines the cancelrescan JSON-RPC command.
+type CancelRescanCmd struct{}
+
+// RescanAddressCmd defines the rescanaddress JSON-RPC command.
+type RescanAddressCmd struct {
+	Address    string
+	FromHeight *int32
+	ToHeight   *int32
+}
+
+// FreezeAddressCmd defines the freezeaddress JSON-RPC command.
+type FreezeAddressCmd struct {
+	Address string
+}
+
+// UnfreezeAddressCmd defines the unfreezeaddress JSON-RPC command.
+type UnfreezeAddressCmd struct {
+	Address string
+}
+
+// ListFrozenAddressesCmd defines the listfrozenaddresses JSON-RPC command.
+type ListFrozenAddressesCmd struct{}
+
+// GetChangeAddressCmd defines the getchangeaddress JSON-RPC command.
+type GetChangeAddressCmd struct{}
+
+// SetChangeAddressCmd defines the setchangeaddress JSON-RPC command.  An
+// omitted or empty Address unpins the change.
+type SetChangeAddressCmd struct {
+	Address *string
+}
+
+// SetMaintenanceModeCmd defines the setmaintenancemode JSON-RPC command.
+type SetMaintenanceModeCmd struct {
+	Enable bool
+}
+
 // GetBalanceCmd defines the getbalance JSON-RPC command.
 type GetBalanceCmd struct {
 	MinConf *int `jsonrpcdefault:"1"`
+	Verbose *bool
+}
+
+// GetBalanceAtHeightCmd defines the getbalanceatheight JSON-RPC command.
+type GetBalanceAtHeightCmd struct {
+	Height int32
+}
+
+// ExportTaxReportCmd defines the exporttaxreport JSON-RPC command.
+type ExportTaxReportCmd struct{}
+
+// ExportLabelsCmd defines the exportlabels JSON-RPC command.
+type ExportLabelsCmd struct{}
+
+// ImportLabelsCmd defines the importlabels JSON-RPC command.
+type ImportLabelsCmd struct {
+	Labels    []TxLabel
+	Overwrite *bool `jsonrpcdefault:"false"`
+}
+
+// GetAccountStatsCmd defines the getaccountstats JSON-RPC command.
+type GetAccountStatsCmd struct {
+	StartTime *int64 `jsonrpcdefault:"0"`
+	EndTime   *int64 `jsonrpcdefault:"0"`
+}
+
+// BumpFeeCmd defines the bumpfee JSON-RPC command.
+type BumpFeeCmd struct {
+	Txid string
+}
+
+// BumpFeeCPFPCmd defines the bumpfeecpfp JSON-RPC command.
+type BumpFeeCPFPCmd struct {
+	Txid string
+}
+
+// GetBumpInfoCmd defines the getbumpinfo JSON-RPC command.
+type GetBumpInfoCmd struct {
+	Txid string
+}
+
+// GetFeeCmd defines the getfee JSON-RPC command.
+type GetFeeCmd struct {
+	Txid string
+}
+
+// GetFeeSourceCmd defines the getfeesource JSON-RPC command.
+type GetFeeSourceCmd struct{}
+
+// GetFeeStatsCmd defines the getfeestats JSON-RPC command.
+type GetFeeStatsCmd struct {
+	Blocks *int32 `jsonrpcdefault:"1000"`
 }
 
 type GetNetworkStewardVoteCmd struct{}
 
+// GetUtxoAgesCmd defines the getutxoages JSON-RPC command.
+type GetUtxoAgesCmd struct{}
+
+// ListRejectedTxCmd defines the listrejectedtx JSON-RPC command.
+type ListRejectedTxCmd struct{}
+
+// ListPendingTransactionsCmd defines the listpendingtransactions JSON-RPC
+// command.
+type ListPendingTransactionsCmd struct{}
+
+// DumpUtxoSetCmd defines the dumputxoset JSON-RPC command.
+type DumpUtxoSetCmd struct{}
+
+// GetUtxoInfoCmd defines the getutxoinfo JSON-RPC command.
+type GetUtxoInfoCmd struct {
+	Txid string
+	Vout uint32
+}
+
+// ListAuxOutputsCmd defines the listauxoutputs JSON-RPC command.
+type ListAuxOutputsCmd struct{}
+
+// GetStorageStatsCmd defines the getstoragestats JSON-RPC command.
+type GetStorageStatsCmd struct{}
+
+// GetRecoveryStatusCmd defines the getrecoverystatus JSON-RPC command.
+type GetRecoveryStatusCmd struct{}
+
+// GetBlockFilterCmd defines the getblockfilter JSON-RPC command.
+type GetBlockFilterCmd struct {
+	BlockHash string
+}
+
 // GetNewAddressCmd defines the getnewaddress JSON-RPC command.
 type GetNewAddressCmd struct {
-	Legacy *bool
+	Legacy   *bool
+	Account  *string
+	KeyScope *string
 }
 
 // GetReceivedByAddressCmd defines the getreceivedbyaddress JSON-RPC command.
@@ -129,6 +372,12 @@ func NewGetTransactionCmd(txHash string, includeWatchOnly *bool) *GetTransaction
 	}
 }
 
+// ImportAddressCmd defines the importaddress JSON-RPC command.
+type ImportAddressCmd struct {
+	Address string
+	Rescan  *bool `jsonrpcdefault:"true"`
+}
+
 // ImportPrivKeyCmd defines the importprivkey JSON-RPC command.
 type ImportPrivKeyCmd struct {
 	PrivKey string
@@ -251,6 +500,8 @@ type SendFromCmd struct {
 	CommentTo     *string
 	MaxInputs     *int
 	MinHeight     *int
+	AllowSelfSend *bool
+	ChangeInfo    *bool
 }
 
 // NewSendFromCmd returns a new instance which can be used to issue a sendfrom
@@ -282,6 +533,17 @@ type CreateTransactionCmd struct {
 	MaxInputs      *int
 	AutoLock       *string
 	NoSign         *bool
+	AllowSelfSend  *bool
+}
+
+// SendFromUtxosCmd defines the sendfromutxos JSON-RPC command.
+type SendFromUtxosCmd struct {
+	Inputs        []TransactionInput
+	Amounts       map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In BTC
+	FeeRate       *string
+	ChangeAddress *string
+	Comment       *string
+	AllowSelfSend *bool
 }
 
 // SendManyCmd defines the sendmany JSON-RPC command.
@@ -291,6 +553,8 @@ type SendManyCmd struct {
 	MinConf       *int `jsonrpcdefault:"1"`
 	Comment       *string
 	MaxInputs     *int
+	AllowSelfSend *bool
+	ChangeInfo    *bool
 }
 
 // NewSendManyCmd returns a new instance which can be used to issue a sendmany
@@ -307,12 +571,57 @@ func NewSendManyCmd(fromAddresses *[]string, amounts map[string]float64, minConf
 	}
 }
 
+// PauseSyncCmd defines the pausesync JSON-RPC command.
+type PauseSyncCmd struct{}
+
+// ResumeSyncCmd defines the resumesync JSON-RPC command.
+type ResumeSyncCmd struct{}
+
+// SendManyDetailedCmd defines the sendmanydetailed JSON-RPC command.
+type SendManyDetailedCmd struct {
+	Amounts       map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In BTC
+	FromAddresses *[]string
+	MinConf       *int `jsonrpcdefault:"1"`
+	MaxInputs     *int
+	AllowSelfSend *bool
+}
+
+// SpendMaxCmd defines the spendmax JSON-RPC command.
+type SpendMaxCmd struct {
+	Address       string
+	FromAddresses *[]string
+	MinConf       *int `jsonrpcdefault:"1"`
+	AllowSelfSend *bool
+}
+
+// SimulateSendCmd defines the simulatesend JSON-RPC command.  It takes the
+// same parameters as sendmanydetailed.
+type SimulateSendCmd struct {
+	Amounts       map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In BTC
+	FromAddresses *[]string
+	MinConf       *int `jsonrpcdefault:"1"`
+	MaxInputs     *int
+	AllowSelfSend *bool
+}
+
+// GetCoinSelectionPrivacyCmd defines the getcoinselectionprivacy JSON-RPC
+// command.  It takes the same parameters as sendmanydetailed.
+type GetCoinSelectionPrivacyCmd struct {
+	Amounts       map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In BTC
+	FromAddresses *[]string
+	MinConf       *int `jsonrpcdefault:"1"`
+	MaxInputs     *int
+	AllowSelfSend *bool
+}
+
 // SendToAddressCmd defines the sendtoaddress JSON-RPC command.
 type SendToAddressCmd struct {
-	Address   string
-	Amount    float64
-	Comment   *string
-	CommentTo *string
+	Address       string
+	Amount        float64
+	Comment       *string
+	CommentTo     *string
+	AllowSelfSend *bool
+	ChangeInfo    *bool
 }
 
 // NewSendToAddressCmd returns a new instance which can be used to issue a
@@ -427,6 +736,15 @@ func NewWalletPassphraseChangeCmd(oldPassphrase, newPassphrase string) *WalletPa
 	}
 }
 
+// RekeyWalletCmd defines the rekeywallet JSON-RPC command.  N, R and P are the
+// scrypt parameters to derive the key securing the private keys with.
+type RekeyWalletCmd struct {
+	Passphrase string
+	N          *int `jsonrpcdefault:"262144"`
+	R          *int `jsonrpcdefault:"8"`
+	P          *int `jsonrpcdefault:"1"`
+}
+
 type WalletMempoolCmd struct{}
 
 // SetNetworkStewardVoteCmd is the argument to the wallet command setnetworkstewardvote
@@ -442,33 +760,88 @@ func init() {
 	MustRegisterCmd("addmultisigaddress", (*AddMultisigAddressCmd)(nil), flags)
 	MustRegisterCmd("addp2shscript", (*AddP2shScriptCmd)(nil), flags)
 	MustRegisterCmd("addwitnessaddress", (*AddWitnessAddressCmd)(nil), flags)
+	MustRegisterCmd("backupseedencrypted", (*BackupSeedEncryptedCmd)(nil), flags)
+	MustRegisterCmd("convertaddress", (*ConvertAddressCmd)(nil), flags)
 	MustRegisterCmd("createmultisig", (*CreateMultisigCmd)(nil), flags)
+	MustRegisterCmd("createnewaccount", (*CreateNewAccountCmd)(nil), flags)
 	MustRegisterCmd("createtransaction", (*CreateTransactionCmd)(nil), flags)
+	MustRegisterCmd("freezeaddress", (*FreezeAddressCmd)(nil), flags)
+	MustRegisterCmd("getaccountxpubs", (*GetAccountXpubsCmd)(nil), flags)
 	MustRegisterCmd("getaddressbalances", (*GetAddressBalancesCmd)(nil), flags)
+	MustRegisterCmd("pausesync", (*PauseSyncCmd)(nil), flags)
+	MustRegisterCmd("rescanaddress", (*RescanAddressCmd)(nil), flags)
+	MustRegisterCmd("resumesync", (*ResumeSyncCmd)(nil), flags)
 	MustRegisterCmd("resync", (*ResyncCmd)(nil), flags)
 	MustRegisterCmd("stopresync", (*StopResyncCmd)(nil), flags)
+	MustRegisterCmd("cancelrescan", (*CancelRescanCmd)(nil), flags)
+	MustRegisterCmd("deriveaddresses", (*DeriveAddressesCmd)(nil), flags)
+	MustRegisterCmd("dumputxoset", (*DumpUtxoSetCmd)(nil), flags)
 	MustRegisterCmd("dumpprivkey", (*DumpPrivKeyCmd)(nil), flags)
+	MustRegisterCmd("estimateconfirmationtime", (*EstimateConfirmationTimeCmd)(nil), flags)
+	MustRegisterCmd("estimateconsolidation", (*EstimateConsolidationCmd)(nil), flags)
+	MustRegisterCmd("exportaccountwatchonly", (*ExportAccountWatchOnlyCmd)(nil), flags)
+	MustRegisterCmd("exportlabels", (*ExportLabelsCmd)(nil), flags)
+	MustRegisterCmd("exporttaxreport", (*ExportTaxReportCmd)(nil), flags)
+	MustRegisterCmd("getaccountstats", (*GetAccountStatsCmd)(nil), flags)
 	MustRegisterCmd("getbalance", (*GetBalanceCmd)(nil), flags)
+	MustRegisterCmd("getbalanceatheight", (*GetBalanceAtHeightCmd)(nil), flags)
+	MustRegisterCmd("getblockfilter", (*GetBlockFilterCmd)(nil), flags)
+	MustRegisterCmd("bumpfee", (*BumpFeeCmd)(nil), flags)
+	MustRegisterCmd("bumpfeecpfp", (*BumpFeeCPFPCmd)(nil), flags)
+	MustRegisterCmd("getbumpinfo", (*GetBumpInfoCmd)(nil), flags)
+	MustRegisterCmd("getchangeaddress", (*GetChangeAddressCmd)(nil), flags)
+	MustRegisterCmd("getcoinselectionprivacy", (*GetCoinSelectionPrivacyCmd)(nil), flags)
+	MustRegisterCmd("getfee", (*GetFeeCmd)(nil), flags)
+	MustRegisterCmd("getfeesource", (*GetFeeSourceCmd)(nil), flags)
+	MustRegisterCmd("getfeestats", (*GetFeeStatsCmd)(nil), flags)
 	MustRegisterCmd("getnetworkstewardvote", (*GetNetworkStewardVoteCmd)(nil), flags)
 	MustRegisterCmd("getnewaddress", (*GetNewAddressCmd)(nil), flags)
 	MustRegisterCmd("getreceivedbyaddress", (*GetReceivedByAddressCmd)(nil), flags)
+	MustRegisterCmd("getrecoverystatus", (*GetRecoveryStatusCmd)(nil), flags)
 	MustRegisterCmd("gettransaction", (*GetTransactionCmd)(nil), flags)
+	MustRegisterCmd("gettxproof", (*GetTxProofCmd)(nil), flags)
+	MustRegisterCmd("gettxstatus", (*GetTxStatusCmd)(nil), flags)
+	MustRegisterCmd("getmempoolancestors", (*GetMempoolAncestorsCmd)(nil), flags)
 	MustRegisterCmd("getwalletseed", (*GetWalletSeedCmd)(nil), flags)
 	MustRegisterCmd("getsecret", (*GetSecretCmd)(nil), flags)
+	MustRegisterCmd("getstoragestats", (*GetStorageStatsCmd)(nil), flags)
+	MustRegisterCmd("getutxoages", (*GetUtxoAgesCmd)(nil), flags)
+	MustRegisterCmd("getutxoinfo", (*GetUtxoInfoCmd)(nil), flags)
+	MustRegisterCmd("importaddress", (*ImportAddressCmd)(nil), flags)
+	MustRegisterCmd("importdescriptor", (*ImportDescriptorCmd)(nil), flags)
+	MustRegisterCmd("importlabels", (*ImportLabelsCmd)(nil), flags)
 	MustRegisterCmd("importprivkey", (*ImportPrivKeyCmd)(nil), flags)
+	MustRegisterCmd("listaccounts", (*ListAccountsCmd)(nil), flags)
+	MustRegisterCmd("listauxoutputs", (*ListAuxOutputsCmd)(nil), flags)
+	MustRegisterCmd("listfrozenaddresses", (*ListFrozenAddressesCmd)(nil), flags)
 	MustRegisterCmd("listlockunspent", (*ListLockUnspentCmd)(nil), flags)
+	MustRegisterCmd("listpendingtransactions", (*ListPendingTransactionsCmd)(nil), flags)
 	MustRegisterCmd("listreceivedbyaddress", (*ListReceivedByAddressCmd)(nil), flags)
+	MustRegisterCmd("listrejectedtx", (*ListRejectedTxCmd)(nil), flags)
 	MustRegisterCmd("listsinceblock", (*ListSinceBlockCmd)(nil), flags)
 	MustRegisterCmd("listtransactions", (*ListTransactionsCmd)(nil), flags)
 	MustRegisterCmd("listunspent", (*ListUnspentCmd)(nil), flags)
 	MustRegisterCmd("lockunspent", (*LockUnspentCmd)(nil), flags)
+	MustRegisterCmd("markaddressused", (*MarkAddressUsedCmd)(nil), flags)
+	MustRegisterCmd("markaddressunused", (*MarkAddressUnusedCmd)(nil), flags)
+	MustRegisterCmd("rekeywallet", (*RekeyWalletCmd)(nil), flags)
 	MustRegisterCmd("sendfrom", (*SendFromCmd)(nil), flags)
 	MustRegisterCmd("sendmany", (*SendManyCmd)(nil), flags)
+	MustRegisterCmd("sendmanydetailed", (*SendManyDetailedCmd)(nil), flags)
+	MustRegisterCmd("sendfromutxos", (*SendFromUtxosCmd)(nil), flags)
 	MustRegisterCmd("sendtoaddress", (*SendToAddressCmd)(nil), flags)
+	MustRegisterCmd("setchangeaddress", (*SetChangeAddressCmd)(nil), flags)
+	MustRegisterCmd("setmaintenancemode", (*SetMaintenanceModeCmd)(nil), flags)
 	MustRegisterCmd("setnetworkstewardvote", (*SetNetworkStewardVoteCmd)(nil), flags)
 	MustRegisterCmd("settxfee", (*SetTxFeeCmd)(nil), flags)
 	MustRegisterCmd("signmessage", (*SignMessageCmd)(nil), flags)
 	MustRegisterCmd("signrawtransaction", (*SignRawTransactionCmd)(nil), flags)
+	MustRegisterCmd("simulatesend", (*SimulateSendCmd)(nil), flags)
+	MustRegisterCmd("spendmax", (*SpendMaxCmd)(nil), flags)
+	MustRegisterCmd("unfreezeaddress", (*UnfreezeAddressCmd)(nil), flags)
+	MustRegisterCmd("verifypaymentrequest", (*VerifyPaymentRequestCmd)(nil), flags)
+	MustRegisterCmd("verifytxproof", (*VerifyTxProofCmd)(nil), flags)
+	MustRegisterCmd("verifywallet", (*VerifyWalletCmd)(nil), flags)
 	MustRegisterCmd("walletlock", (*WalletLockCmd)(nil), flags)
 	MustRegisterCmd("walletpassphrase", (*WalletPassphraseCmd)(nil), flags)
 	MustRegisterCmd("walletpassphrasechange", (*WalletPassphraseChangeCmd)(nil), flags)
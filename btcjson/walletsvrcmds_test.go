@@ -104,6 +104,17 @@ func TestWalletSvrCmds(t *testing.T) {
 				MinConf: btcjson.Int(1),
 			},
 		},
+		{
+			name: "getbalance optional",
+			newCmd: func() (interface{}, er.R) {
+				return btcjson.NewCmd("getbalance", 6, true)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getbalance","params":[6,true],"id":1}`,
+			unmarshalled: &btcjson.GetBalanceCmd{
+				MinConf: btcjson.Int(6),
+				Verbose: btcjson.Bool(true),
+			},
+		},
 		{
 			name: "getnewaddress",
 			newCmd: func() (interface{}, er.R) {
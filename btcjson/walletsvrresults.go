@@ -38,8 +38,11 @@ type GetTransactionResult struct {
 	WalletConflicts []string                      `json:"walletconflicts"`
 	Time            int64                         `json:"time"`
 	TimeReceived    int64                         `json:"timereceived"`
+	Trusted         bool                          `json:"trusted"`
 	Details         []GetTransactionDetailsResult `json:"details"`
 	Hex             string                        `json:"hex"`
+	Comment         string                        `json:"comment,omitempty"`
+	CommentTo       string                        `json:"to,omitempty"`
 }
 
 // InfoWalletResult models the data returned by the wallet server getinfo
@@ -78,9 +81,10 @@ type NeutrinoQuery struct {
 }
 
 type NeutrinoInfo struct {
-	Peers   []peer.PeerDesc
-	Bans    []NeutrinoBan
-	Queries []NeutrinoQuery
+	Peers      []peer.PeerDesc
+	Bans       []NeutrinoBan
+	Queries    []NeutrinoQuery
+	SyncPaused bool
 }
 
 type WalletStats struct {
@@ -100,6 +104,10 @@ type WalletStats struct {
 
 	// General info
 	BirthdayBlock int32
+
+	// Websocket notifications which were dropped because the client was
+	// slow to read them
+	NotificationsDropped uint64
 }
 
 type WalletInfoResult struct {
@@ -140,6 +148,7 @@ type ListTransactionsResult struct {
 	Vout              uint32   `json:"vout"`
 	WalletConflicts   []string `json:"walletconflicts"`
 	Comment           string   `json:"comment,omitempty"`
+	CommentTo         string   `json:"to,omitempty"`
 	OtherAccount      string   `json:"otheraccount,omitempty"`
 }
 
@@ -173,6 +182,8 @@ type ListUnspentResult struct {
 	Height        int64   `json:"height"`
 	BlockHash     string  `json:"blockHash"`
 	Spendable     bool    `json:"spendable"`
+	Frozen        bool    `json:"frozen,omitempty"`
+	Dust          bool    `json:"dust,omitempty"`
 }
 
 // SignRawTransactionError models the data that contains script verification
@@ -225,6 +236,409 @@ type GetNetworkStewardVoteResult struct {
 	VoteAgainst string `json:"voteagainst,omitempty"`
 }
 
+// GetAccountXpubsResult models the data returned for each key scope by the
+// getaccountxpubs command.
+type GetAccountXpubsResult struct {
+	Scope       string `json:"scope"`
+	AddressType string `json:"addresstype"`
+	Xpub        string `json:"xpub"`
+}
+
+// ListAccountsResult models the data returned for each account by the
+// listaccounts command.
+type ListAccountsResult struct {
+	Name         string  `json:"name"`
+	Account      uint32  `json:"account"`
+	Scope        string  `json:"scope"`
+	AddressType  string  `json:"addresstype"`
+	Balance      float64 `json:"balance"`
+	AddressCount uint32  `json:"addresscount"`
+}
+
+// SendResult models the data returned by the sendfrom, sendmany and
+// sendtoaddress commands.  The change fields are nil if the transaction has no
+// change output.
+type SendResult struct {
+	TxID          string  `json:"txid"`
+	ChangeVout    *uint32 `json:"changevout"`
+	ChangeAddress *string `json:"changeaddress"`
+}
+
+// SendManyDetailedOutput models a recipient output of a sendmanydetailed
+// transaction along with its share of the fee.
+type SendManyDetailedOutput struct {
+	Address string  `json:"address"`
+	Vout    uint32  `json:"vout"`
+	Amount  float64 `json:"amount"`
+	Fee     float64 `json:"fee"`
+}
+
+// SendManyDetailedResult models the data returned by the sendmanydetailed
+// command.  The fee is attributed to the recipient outputs in proportion to
+// their amounts, the change output is not attributed any of it.
+type SendManyDetailedResult struct {
+	TxID          string                   `json:"txid"`
+	Fee           float64                  `json:"fee"`
+	Outputs       []SendManyDetailedOutput `json:"outputs"`
+	ChangeVout    *uint32                  `json:"changevout"`
+	ChangeAddress *string                  `json:"changeaddress"`
+}
+
+// SpendMaxResult models the data returned by the spendmax command.
+type SpendMaxResult struct {
+	TxID   string  `json:"txid"`
+	Amount float64 `json:"amount"`
+	Fee    float64 `json:"fee"`
+}
+
+// SimulateSendInput models an input of a simulatesend transaction.
+type SimulateSendInput struct {
+	TxID    string  `json:"txid"`
+	Vout    uint32  `json:"vout"`
+	Address string  `json:"address"`
+	Amount  float64 `json:"amount"`
+}
+
+// SimulateSendResult models the data returned by the simulatesend command.
+// The transaction is unsigned so its txid changes once it is signed if any of
+// its inputs are not segwit.
+type SimulateSendResult struct {
+	Hex           string              `json:"hex"`
+	TxID          string              `json:"txid"`
+	Inputs        []SimulateSendInput `json:"inputs"`
+	Fee           float64             `json:"fee"`
+	VSize         int64               `json:"vsize"`
+	ChangeVout    *uint32             `json:"changevout"`
+	ChangeAddress *string             `json:"changeaddress"`
+	ChangeAmount  *float64            `json:"changeamount"`
+}
+
+// GetCoinSelectionPrivacyResult models the data returned by the
+// getcoinselectionprivacy command.
+type GetCoinSelectionPrivacyResult struct {
+	Inputs         int      `json:"inputs"`
+	InputAddresses int      `json:"inputaddresses"`
+	HasChange      bool     `json:"haschange"`
+	ChangeRevealed bool     `json:"changerevealed"`
+	ChangeReasons  []string `json:"changereasons"`
+}
+
+// GetBlockFilterResult models the data returned by the getblockfilter command.
+type GetBlockFilterResult struct {
+	Filter string `json:"filter"`
+	Header string `json:"header"`
+}
+
+// GetTxProofResult models the data returned by the gettxproof command.
+type GetTxProofResult struct {
+	TxID        string   `json:"txid"`
+	BlockHash   string   `json:"blockhash"`
+	BlockHeight int32    `json:"blockheight"`
+	Index       int      `json:"index"`
+	Branch      []string `json:"branch"`
+}
+
+// GetTxStatusResult models the data returned by the gettxstatus command.
+type GetTxStatusResult struct {
+	Status        string `json:"status"`
+	Confirmations int32  `json:"confirmations"`
+	BlockHeight   int32  `json:"blockheight"`
+	ConflictedBy  string `json:"conflictedby,omitempty"`
+}
+
+// MempoolAncestorResult models an ancestor returned by the getmempoolancestors
+// command.
+type MempoolAncestorResult struct {
+	TxID  string   `json:"txid"`
+	Size  int      `json:"size"`
+	VSize int      `json:"vsize"`
+	Fee   *float64 `json:"fee,omitempty"`
+}
+
+// GetMempoolAncestorsResult models the data returned by the
+// getmempoolancestors command.
+type GetMempoolAncestorsResult struct {
+	Ancestors []MempoolAncestorResult `json:"ancestors"`
+	Size      int                     `json:"size"`
+	VSize     int                     `json:"vsize"`
+	Fee       *float64                `json:"fee,omitempty"`
+}
+
+// TxLabel is the label of a transaction, as returned by the exportlabels
+// command and given to the importlabels command.
+type TxLabel struct {
+	Txid  string `json:"txid"`
+	Label string `json:"label"`
+}
+
+// ImportLabelsResult models the data returned by the importlabels command.
+type ImportLabelsResult struct {
+	Imported int `json:"imported"`
+	Kept     int `json:"kept"`
+	Unknown  int `json:"unknown"`
+}
+
+// EstimateConfirmationTimeResult models the data returned by the
+// estimateconfirmationtime command.
+type EstimateConfirmationTimeResult struct {
+	FeeRate       float64 `json:"feerate"`
+	Blocks        int32   `json:"blocks"`
+	Seconds       int64   `json:"seconds"`
+	LowConfidence bool    `json:"lowconfidence"`
+}
+
+// EstimateConsolidationResult models the data returned by the
+// estimateconsolidation command.
+type EstimateConsolidationResult struct {
+	Utxos          int     `json:"utxos"`
+	Transactions   int     `json:"transactions"`
+	FeeRate        float64 `json:"feerate"`
+	Fee            float64 `json:"fee"`
+	Amount         float64 `json:"amount"`
+	AmountAfterFee float64 `json:"amountafterfee"`
+}
+
+// GetBalanceResult models the data returned by the getbalance command when
+// verbose is set.
+type GetBalanceResult struct {
+	Balance   float64 `json:"balance"`
+	Maturing  float64 `json:"maturing"`
+	WatchOnly float64 `json:"watchonly"`
+}
+
+// GetBalanceAtHeightResult models the data returned by the getbalanceatheight
+// command.
+type GetBalanceAtHeightResult struct {
+	Height  int32   `json:"height"`
+	Balance float64 `json:"balance"`
+}
+
+// ConvertAddressResult models the data returned by the convertaddress command.
+type ConvertAddressResult struct {
+	Address     string `json:"address"`
+	AddressType string `json:"addresstype"`
+	IsMine      bool   `json:"ismine"`
+}
+
+// DeriveAddressesResult models an address returned by the deriveaddresses
+// command.
+type DeriveAddressesResult struct {
+	Path    string `json:"path"`
+	Address string `json:"address"`
+	PubKey  string `json:"pubkey"`
+}
+
+// TxFeeStat models the fee rate paid by a transaction in the result of the
+// getfeestats command.
+type TxFeeStat struct {
+	TxID           string  `json:"txid"`
+	Height         int32   `json:"height"`
+	FeeRate        float64 `json:"feerate"`
+	ConfirmSeconds int64   `json:"confirmseconds"`
+}
+
+// TaxDisposalResult models a disposal of part of a lot of coins returned by
+// the exporttaxreport command.
+type TaxDisposalResult struct {
+	DateAcquired string   `json:"dateacquired"`
+	AcquiredTxID string   `json:"acquiredtxid"`
+	DateDisposed string   `json:"datedisposed"`
+	DisposedTxID string   `json:"disposedtxid"`
+	Amount       float64  `json:"amount"`
+	CostBasis    *float64 `json:"costbasis"`
+	Proceeds     float64  `json:"proceeds"`
+}
+
+// AccountStatsResult models the data returned for each account by the
+// getaccountstats command.
+type AccountStatsResult struct {
+	Name     string  `json:"name"`
+	Account  uint32  `json:"account"`
+	Scope    string  `json:"scope"`
+	Incoming int     `json:"incoming"`
+	Received float64 `json:"received"`
+	Outgoing int     `json:"outgoing"`
+	Sent     float64 `json:"sent"`
+}
+
+// GetBumpInfoResult models the data returned by the getbumpinfo command.
+type GetBumpInfoResult struct {
+	Replaceable bool    `json:"replaceable"`
+	OwnsInputs  bool    `json:"ownsinputs"`
+	Fee         float64 `json:"fee,omitempty"`
+	MinBumpFee  float64 `json:"minbumpfee"`
+	CanBump     bool    `json:"canbump"`
+	NewFee      float64 `json:"newfee,omitempty"`
+	Reason      string  `json:"reason,omitempty"`
+}
+
+// GetFeeResult models the data returned by the getfee command.
+type GetFeeResult struct {
+	Fee     float64 `json:"fee"`
+	FeeRate float64 `json:"feerate"`
+	Size    int     `json:"size"`
+	VSize   int64   `json:"vsize"`
+}
+
+// GetFeeSourceResult models the data returned by the getfeesource command.
+type GetFeeSourceResult struct {
+	Source     string  `json:"source"`
+	FeeRate    float64 `json:"feerate"`
+	LastUpdate int64   `json:"lastupdate"`
+}
+
+// GetFeeStatsResult models the data returned by the getfeestats command.
+type GetFeeStatsResult struct {
+	Transactions  []TxFeeStat `json:"transactions"`
+	MinFeeRate    float64     `json:"minfeerate"`
+	MedianFeeRate float64     `json:"medianfeerate"`
+	MaxFeeRate    float64     `json:"maxfeerate"`
+}
+
+// UtxoAgeBucket models a bucket of the histogram returned by the getutxoages
+// command.  MaxConfs is unset for the last bucket, which has no upper bound.
+type UtxoAgeBucket struct {
+	MinConfs int32   `json:"minconfs"`
+	MaxConfs *int32  `json:"maxconfs,omitempty"`
+	Count    int     `json:"count"`
+	Amount   float64 `json:"amount"`
+}
+
+// GetUtxoAgesResult models the data returned by the getutxoages command.
+type GetUtxoAgesResult struct {
+	Count   int             `json:"count"`
+	Oldest  int32           `json:"oldest"`
+	Newest  int32           `json:"newest"`
+	Median  int32           `json:"median"`
+	Buckets []UtxoAgeBucket `json:"buckets"`
+}
+
+// ScryptParamsResult models the scrypt parameters returned by the rekeywallet
+// command.
+type ScryptParamsResult struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+// RekeyWalletResult models the data returned by the rekeywallet command.
+type RekeyWalletResult struct {
+	Previous ScryptParamsResult `json:"previous"`
+	Current  ScryptParamsResult `json:"current"`
+}
+
+// ListPendingTransactionsResult models an unconfirmed transaction returned by
+// the listpendingtransactions command.
+type ListPendingTransactionsResult struct {
+	TxID        string   `json:"txid"`
+	Fee         *float64 `json:"fee,omitempty"`
+	FeeRate     *float64 `json:"feerate,omitempty"`
+	AgeSeconds  int64    `json:"ageseconds"`
+	Replaceable bool     `json:"replaceable"`
+}
+
+// DumpUtxoSetResult models one line of the NDJSON stream returned by the
+// dumputxoset command.
+type DumpUtxoSetResult struct {
+	TxID          string  `json:"txid"`
+	Vout          uint32  `json:"vout"`
+	Amount        float64 `json:"amount"`
+	ScriptType    string  `json:"scripttype"`
+	Address       string  `json:"address,omitempty"`
+	Confirmations int64   `json:"confirmations"`
+}
+
+// GetUtxoInfoResult models the data returned by the getutxoinfo command.  The
+// key origin fields are only set for outputs which pay keys that the wallet
+// derived from its seed.
+type GetUtxoInfoResult struct {
+	TxID              string  `json:"txid"`
+	Vout              uint32  `json:"vout"`
+	Known             bool    `json:"known"`
+	Owned             bool    `json:"owned"`
+	Amount            float64 `json:"amount,omitempty"`
+	ScriptPubKey      string  `json:"scriptPubKey,omitempty"`
+	Address           string  `json:"address,omitempty"`
+	DerivationPath    string  `json:"derivationpath,omitempty"`
+	MasterFingerprint string  `json:"masterfingerprint,omitempty"`
+}
+
+// ListAuxOutputsResult models a zero value or unspendable output returned by
+// the listauxoutputs command.
+type ListAuxOutputsResult struct {
+	TxID          string  `json:"txid"`
+	Vout          uint32  `json:"vout"`
+	Amount        float64 `json:"amount"`
+	ScriptPubKey  string  `json:"scriptPubKey"`
+	Data          string  `json:"data,omitempty"`
+	Confirmations int64   `json:"confirmations"`
+}
+
+// ListRejectedTxResult models the data returned by the listrejectedtx command.
+type ListRejectedTxResult struct {
+	TxID   string `json:"txid"`
+	Reason string `json:"reason"`
+	Time   int64  `json:"time"`
+}
+
+// StorageBucketStats models the storage used by a wallet database bucket.
+type StorageBucketStats struct {
+	Name string `json:"name"`
+	Keys int    `json:"keys"`
+	Size int64  `json:"size"`
+}
+
+// GetStorageStatsResult models the data returned by the getstoragestats
+// command.
+type GetStorageStatsResult struct {
+	FileSize     int64                `json:"filesize"`
+	Buckets      []StorageBucketStats `json:"buckets"`
+	Transactions int                  `json:"transactions"`
+	Utxos        int                  `json:"utxos"`
+}
+
+// GetRecoveryStatusResult models the data returned by the getrecoverystatus
+// command.
+type GetRecoveryStatusResult struct {
+	Active              bool    `json:"active"`
+	Name                string  `json:"name,omitempty"`
+	StartHeight         int32   `json:"startheight"`
+	CurrentHeight       int32   `json:"currentheight"`
+	TargetHeight        int32   `json:"targetheight"`
+	Progress            float64 `json:"progress"`
+	AddressesFound      int     `json:"addressesfound"`
+	StartTime           int64   `json:"starttime,omitempty"`
+	EstimatedCompletion int64   `json:"estimatedcompletion,omitempty"`
+}
+
+// PaymentRequestOutput models an output requested by a payment request.
+type PaymentRequestOutput struct {
+	Amount  float64 `json:"amount"`
+	Script  string  `json:"script"`
+	Address string  `json:"address,omitempty"`
+}
+
+// VerifyPaymentRequestResult models the data returned by the
+// verifypaymentrequest command.
+type VerifyPaymentRequestResult struct {
+	Valid      bool                   `json:"valid"`
+	Expired    bool                   `json:"expired"`
+	Error      string                 `json:"error,omitempty"`
+	Merchant   string                 `json:"merchant,omitempty"`
+	Network    string                 `json:"network"`
+	Outputs    []PaymentRequestOutput `json:"outputs"`
+	Memo       string                 `json:"memo,omitempty"`
+	PaymentURL string                 `json:"paymenturl,omitempty"`
+	Time       int64                  `json:"time"`
+	Expires    int64                  `json:"expires"`
+}
+
+// VerifyWalletResult models the data returned by the verifywallet command.
+type VerifyWalletResult struct {
+	Consistent bool     `json:"consistent"`
+	Problems   []string `json:"problems"`
+}
+
 type GetAddressBalancesResult struct {
 	Address string `json:"address"`
 
@@ -240,6 +654,15 @@ type GetAddressBalancesResult struct {
 	Unconfirmed  float64 `json:"unconfirmed"`
 	Sunconfirmed string  `json:"sunconfirmed"`
 
+	Maturing  float64 `json:"maturing"`
+	Smaturing string  `json:"smaturing"`
+
+	WatchOnly  float64 `json:"watchonly"`
+	SwatchOnly string  `json:"swatchonly"`
+
+	Dust  float64 `json:"dust"`
+	Sdust string  `json:"sdust"`
+
 	OutputCount int32 `json:"outputcount"`
 }
 
@@ -23,6 +23,16 @@ const (
 	// NewTxNtfnMethod is the method used to notify that a wallet server has
 	// added a new transaction to the transaction store.
 	NewTxNtfnMethod = "newtx"
+
+	// TxConflictedNtfnMethod is the method used to notify that an unmined
+	// wallet transaction was removed because it conflicts with a mined
+	// transaction.
+	TxConflictedNtfnMethod = "txconflicted"
+
+	// CoinbaseMaturedNtfnMethod is the method used to notify that a
+	// coinbase output credited to the wallet reached maturity and became
+	// spendable.
+	CoinbaseMaturedNtfnMethod = "coinbasematured"
 )
 
 // AccountBalanceNtfn defines the accountbalance JSON-RPC notification.
@@ -83,6 +93,38 @@ func NewNewTxNtfn(account string, details ListTransactionsResult) *NewTxNtfn {
 	}
 }
 
+// TxConflictedNtfn defines the txconflicted JSON-RPC notification.
+type TxConflictedNtfn struct {
+	TxID         string
+	ConflictedBy string
+}
+
+// NewTxConflictedNtfn returns a new instance which can be used to issue a
+// txconflicted JSON-RPC notification.
+func NewTxConflictedNtfn(txID, conflictedBy string) *TxConflictedNtfn {
+	return &TxConflictedNtfn{
+		TxID:         txID,
+		ConflictedBy: conflictedBy,
+	}
+}
+
+// CoinbaseMaturedNtfn defines the coinbasematured JSON-RPC notification.
+type CoinbaseMaturedNtfn struct {
+	TxID   string
+	Vout   uint32
+	Amount float64 // In BTC
+}
+
+// NewCoinbaseMaturedNtfn returns a new instance which can be used to issue a
+// coinbasematured JSON-RPC notification.
+func NewCoinbaseMaturedNtfn(txID string, vout uint32, amount float64) *CoinbaseMaturedNtfn {
+	return &CoinbaseMaturedNtfn{
+		TxID:   txID,
+		Vout:   vout,
+		Amount: amount,
+	}
+}
+
 func init() {
 	// The commands in this file are only usable with a wallet server via
 	// websockets and are notifications.
@@ -92,4 +134,6 @@ func init() {
 	MustRegisterCmd(BtcdConnectedNtfnMethod, (*BtcdConnectedNtfn)(nil), flags)
 	MustRegisterCmd(WalletLockStateNtfnMethod, (*WalletLockStateNtfn)(nil), flags)
 	MustRegisterCmd(NewTxNtfnMethod, (*NewTxNtfn)(nil), flags)
+	MustRegisterCmd(TxConflictedNtfnMethod, (*TxConflictedNtfn)(nil), flags)
+	MustRegisterCmd(CoinbaseMaturedNtfnMethod, (*CoinbaseMaturedNtfn)(nil), flags)
 }
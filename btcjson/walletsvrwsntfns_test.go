@@ -117,6 +117,35 @@ func TestWalletSvrWsNtfns(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "txconflicted",
+			newNtfn: func() (interface{}, er.R) {
+				return btcjson.NewCmd("txconflicted", "123", "456")
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewTxConflictedNtfn("123", "456")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"txconflicted","params":["123","456"],"id":null}`,
+			unmarshalled: &btcjson.TxConflictedNtfn{
+				TxID:         "123",
+				ConflictedBy: "456",
+			},
+		},
+		{
+			name: "coinbasematured",
+			newNtfn: func() (interface{}, er.R) {
+				return btcjson.NewCmd("coinbasematured", "123", 1, 2.5)
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewCoinbaseMaturedNtfn("123", 1, 2.5)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"coinbasematured","params":["123",1,2.5],"id":null}`,
+			unmarshalled: &btcjson.CoinbaseMaturedNtfn{
+				TxID:   "123",
+				Vout:   1,
+				Amount: 2.5,
+			},
+		},
 	}
 
 	t.Logf("Running %d tests", len(tests))
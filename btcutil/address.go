@@ -79,11 +79,16 @@ func encodeSegWitAddress(hrp string, witnessVersion byte, witnessProgram []byte)
 	}
 
 	// Concatenate the witness version and program, and encode the resulting
-	// bytes using bech32 encoding.
+	// bytes using bech32 encoding, or bech32m from witness version 1.
 	combined := make([]byte, len(converted)+1)
 	combined[0] = witnessVersion
 	copy(combined[1:], converted)
-	bech, err := bech32.Encode(hrp, combined)
+	var bech string
+	if witnessVersion == 0 {
+		bech, err = bech32.Encode(hrp, combined)
+	} else {
+		bech, err = bech32.EncodeM(hrp, combined)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -165,15 +170,20 @@ func DecodeAddress(addr string, defaultNet *chaincfg.Params) (Address, er.R) {
 				return nil, err
 			}
 
+			// The HRP is everything before the found '1'.
+			hrp := prefix[:len(prefix)-1]
+
 			// We currently only support P2WPKH and P2WSH, which is
-			// witness version 0.
-			if witnessVer != 0 {
+			// witness version 0, and P2TR which is witness version 1.
+			if witnessVer == 1 {
+				if len(witnessProg) != 32 {
+					return nil, er.E(UnsupportedWitnessProgLenError(len(witnessProg)))
+				}
+				return newAddressTaproot(hrp, witnessProg)
+			} else if witnessVer != 0 {
 				return nil, er.E(UnsupportedWitnessVerError(witnessVer))
 			}
 
-			// The HRP is everything before the found '1'.
-			hrp := prefix[:len(prefix)-1]
-
 			switch len(witnessProg) {
 			case 20:
 				return newAddressWitnessPubKeyHash(hrp, witnessProg)
@@ -225,9 +235,11 @@ func DecodeAddress(addr string, defaultNet *chaincfg.Params) (Address, er.R) {
 
 // decodeSegWitAddress parses a bech32 encoded segwit address string and
 // returns the witness version and witness program byte representation.
+// Witness version 0 addresses must use the bech32 checksum and later versions
+// the bech32m checksum, per BIP 350.
 func decodeSegWitAddress(address string) (byte, []byte, er.R) {
 	// Decode the bech32 encoded address.
-	_, data, err := bech32.Decode(address)
+	_, data, bechVersion, err := bech32.DecodeGeneric(address)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -244,6 +256,14 @@ func decodeSegWitAddress(address string) (byte, []byte, er.R) {
 		return 0, nil, er.Errorf("invalid witness version: %v", version)
 	}
 
+	if version == 0 && bechVersion != bech32.Version0 {
+		return 0, nil, er.Errorf("witness version 0 address must use " +
+			"bech32 encoding")
+	} else if version != 0 && bechVersion != bech32.VersionM {
+		return 0, nil, er.Errorf("witness version %v address must use "+
+			"bech32m encoding", version)
+	}
+
 	// The remaining characters of the address returned are grouped into
 	// words of 5 bits. In order to restore the original witness program
 	// bytes, we'll need to regroup into 8 bit words.
@@ -687,6 +707,90 @@ func (a *AddressWitnessScriptHash) WitnessProgram() []byte {
 	return a.witnessProgram[:]
 }
 
+// AddressTaproot is an Address for a pay-to-taproot (P2TR) output, which is a
+// witness version 1 output paying to a 32 byte x-only public key. See BIP 341
+// and, for the bech32m address encoding, BIP 350:
+// https://github.com/bitcoin/bips/blob/master/bip-0350.mediawiki
+type AddressTaproot struct {
+	hrp            string
+	witnessVersion byte
+	witnessProgram [32]byte
+}
+
+// NewAddressTaproot returns a new AddressTaproot.
+func NewAddressTaproot(witnessProg []byte, net *chaincfg.Params) (*AddressTaproot, er.R) {
+	return newAddressTaproot(net.Bech32HRPSegwit, witnessProg)
+}
+
+// newAddressTaproot is an internal helper function to create an
+// AddressTaproot with a known human-readable part, rather than looking it up
+// through its parameters.
+func newAddressTaproot(hrp string, witnessProg []byte) (*AddressTaproot, er.R) {
+	// Check for valid program length for witness version 1, which is 32
+	// for P2TR.
+	if len(witnessProg) != 32 {
+		return nil, er.New("witness program must be 32 " +
+			"bytes for p2tr")
+	}
+
+	addr := &AddressTaproot{
+		hrp:            strings.ToLower(hrp),
+		witnessVersion: 0x01,
+	}
+
+	copy(addr.witnessProgram[:], witnessProg)
+
+	return addr, nil
+}
+
+// EncodeAddress returns the bech32m string encoding of an AddressTaproot.
+// Part of the Address interface.
+func (a *AddressTaproot) EncodeAddress() string {
+	str, err := encodeSegWitAddress(a.hrp, a.witnessVersion,
+		a.witnessProgram[:])
+	if err != nil {
+		return ""
+	}
+	return str
+}
+
+// ScriptAddress returns the witness program for this address.
+// Part of the Address interface.
+func (a *AddressTaproot) ScriptAddress() []byte {
+	return a.witnessProgram[:]
+}
+
+// IsForNet returns whether or not the AddressTaproot is associated with the
+// passed bitcoin network.
+// Part of the Address interface.
+func (a *AddressTaproot) IsForNet(net *chaincfg.Params) bool {
+	return a.hrp == net.Bech32HRPSegwit
+}
+
+// String returns a human-readable string for the AddressTaproot.
+// This is equivalent to calling EncodeAddress, but is provided so the type
+// can be used as a fmt.Stringer.
+// Part of the Address interface.
+func (a *AddressTaproot) String() string {
+	return a.EncodeAddress()
+}
+
+// Hrp returns the human-readable part of the bech32m encoded AddressTaproot.
+func (a *AddressTaproot) Hrp() string {
+	return a.hrp
+}
+
+// WitnessVersion returns the witness version of the AddressTaproot.
+func (a *AddressTaproot) WitnessVersion() byte {
+	return a.witnessVersion
+}
+
+// WitnessProgram returns the witness program of the AddressTaproot, which is
+// the x-only output key.
+func (a *AddressTaproot) WitnessProgram() []byte {
+	return a.witnessProgram[:]
+}
+
 // AddressNonStandard is an Address representation of a script of any type.
 // It it textually represented as "script:" followed by a base64 representation
 // of the pkScript itself.
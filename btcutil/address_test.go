@@ -628,7 +628,37 @@ func TestAddresses(t *testing.T) {
 			},
 			net: &chaincfg.TestNet3Params,
 		},
-		// Unsupported witness versions (version 0 only supported at this point)
+		{
+			name:    "segwit mainnet p2tr v1",
+			addr:    "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0",
+			encoded: "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0",
+			valid:   true,
+			result: btcutil.TstAddressTaproot(
+				1,
+				[32]byte{
+					0x79, 0xbe, 0x66, 0x7e, 0xf9, 0xdc, 0xbb, 0xac,
+					0x55, 0xa0, 0x62, 0x95, 0xce, 0x87, 0x0b, 0x07,
+					0x02, 0x9b, 0xfc, 0xdb, 0x2d, 0xce, 0x28, 0xd9,
+					0x59, 0xf2, 0x81, 0x5b, 0x16, 0xf8, 0x17, 0x98},
+				chaincfg.MainNetParams.Bech32HRPSegwit),
+			f: func() (btcutil.Address, er.R) {
+				outputKey := []byte{
+					0x79, 0xbe, 0x66, 0x7e, 0xf9, 0xdc, 0xbb, 0xac,
+					0x55, 0xa0, 0x62, 0x95, 0xce, 0x87, 0x0b, 0x07,
+					0x02, 0x9b, 0xfc, 0xdb, 0x2d, 0xce, 0x28, 0xd9,
+					0x59, 0xf2, 0x81, 0x5b, 0x16, 0xf8, 0x17, 0x98}
+				return btcutil.NewAddressTaproot(outputKey, &chaincfg.MainNetParams)
+			},
+			net: &chaincfg.MainNetParams,
+		},
+		{
+			name:  "segwit p2tr with bech32 rather than bech32m checksum",
+			addr:  "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd",
+			valid: false,
+			net:   &chaincfg.MainNetParams,
+		},
+		// Unsupported witness versions (only version 0 and version 1 p2tr
+		// are supported at this point)
 		{
 			name:  "segwit mainnet witness v1",
 			addr:  "bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7k7grplx",
@@ -757,6 +787,8 @@ func TestAddresses(t *testing.T) {
 				saddr = btcutil.TstAddressSegwitSAddr(encoded)
 			case *btcutil.AddressWitnessScriptHash:
 				saddr = btcutil.TstAddressSegwitSAddr(encoded)
+			case *btcutil.AddressTaproot:
+				saddr = btcutil.TstAddressSegwitSAddr(encoded)
 			}
 
 			// Check script address, as well as the Hash160 method for P2PKH and
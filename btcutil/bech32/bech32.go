@@ -15,9 +15,69 @@ const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
 
 var gen = []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
 
+// Version is the checksum variant of a bech32 string.
+type Version int
+
+const (
+	// Version0 is the original bech32 checksum of BIP 173.
+	Version0 Version = iota
+
+	// VersionM is the bech32m checksum of BIP 350, used to encode segwit
+	// addresses of witness version 1 and above.
+	VersionM
+)
+
+// checksumConst returns the constant which the checksum polymod of a string
+// of the given version is xored with.
+func (v Version) checksumConst() int {
+	if v == VersionM {
+		return 0x2bc830a3
+	}
+	return 1
+}
+
 // Decode decodes a bech32 encoded string, returning the human-readable
-// part and the data part excluding the checksum.
+// part and the data part excluding the checksum.  Strings with a bech32m
+// checksum are rejected, use DecodeGeneric to accept either.
 func Decode(bech string) (string, []byte, er.R) {
+	hrp, decoded, err := decode(bech)
+	if err != nil {
+		return "", nil, err
+	}
+	if !bech32VerifyChecksum(hrp, decoded) {
+		moreInfo := ""
+		checksum := bech[len(bech)-6:]
+		expected, err := toChars(bech32Checksum(hrp,
+			decoded[:len(decoded)-6], Version0))
+		if err == nil {
+			moreInfo = fmt.Sprintf("Expected %v, got %v.",
+				expected, checksum)
+		}
+		return "", nil, er.Errorf("checksum failed. " + moreInfo)
+	}
+
+	// We exclude the last 6 bytes, which is the checksum.
+	return hrp, decoded[:len(decoded)-6], nil
+}
+
+// DecodeGeneric decodes a string with either a bech32 or a bech32m checksum,
+// returning the human-readable part, the data part excluding the checksum and
+// the version of the checksum.
+func DecodeGeneric(bech string) (string, []byte, Version, er.R) {
+	hrp, decoded, err := decode(bech)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	version, ok := bech32ChecksumVersion(hrp, decoded)
+	if !ok {
+		return "", nil, 0, er.Errorf("checksum failed")
+	}
+	return hrp, decoded[:len(decoded)-6], version, nil
+}
+
+// decode checks the format of a bech32 or bech32m string and returns the
+// human-readable part and the data part including the unverified checksum.
+func decode(bech string) (string, []byte, er.R) {
 	// The maximum allowed length for a bech32 string is 90. It must also
 	// be at least 8 characters, since it needs a non-empty HRP, a
 	// separator, and a 6 character checksum.
@@ -64,29 +124,25 @@ func Decode(bech string) (string, []byte, er.R) {
 		return "", nil, er.Errorf("failed converting data to bytes: "+
 			"%v", err)
 	}
-
-	if !bech32VerifyChecksum(hrp, decoded) {
-		moreInfo := ""
-		checksum := bech[len(bech)-6:]
-		expected, err := toChars(bech32Checksum(hrp,
-			decoded[:len(decoded)-6]))
-		if err == nil {
-			moreInfo = fmt.Sprintf("Expected %v, got %v.",
-				expected, checksum)
-		}
-		return "", nil, er.Errorf("checksum failed. " + moreInfo)
-	}
-
-	// We exclude the last 6 bytes, which is the checksum.
-	return hrp, decoded[:len(decoded)-6], nil
+	return hrp, decoded, nil
 }
 
 // Encode encodes a byte slice into a bech32 string with the
 // human-readable part hrb. Note that the bytes must each encode 5 bits
 // (base32).
 func Encode(hrp string, data []byte) (string, er.R) {
+	return encode(hrp, data, Version0)
+}
+
+// EncodeM encodes a byte slice into a bech32m string with the human-readable
+// part hrp. Note that the bytes must each encode 5 bits (base32).
+func EncodeM(hrp string, data []byte) (string, er.R) {
+	return encode(hrp, data, VersionM)
+}
+
+func encode(hrp string, data []byte, version Version) (string, er.R) {
 	// Calculate the checksum of the data and append it at the end.
-	checksum := bech32Checksum(hrp, data)
+	checksum := bech32Checksum(hrp, data, version)
 	combined := append(data, checksum...)
 
 	// The resulting bech32 string is the concatenation of the hrp, the
@@ -204,8 +260,9 @@ func ConvertBits(data []byte, fromBits, toBits uint8, pad bool) ([]byte, er.R) {
 	return regrouped, nil
 }
 
-// For more details on the checksum calculation, please refer to BIP 173.
-func bech32Checksum(hrp string, data []byte) []byte {
+// For more details on the checksum calculation, please refer to BIP 173 and,
+// for bech32m, BIP 350.
+func bech32Checksum(hrp string, data []byte, version Version) []byte {
 	// Convert the bytes to list of integers, as this is needed for the
 	// checksum calculation.
 	integers := make([]int, len(data))
@@ -214,7 +271,7 @@ func bech32Checksum(hrp string, data []byte) []byte {
 	}
 	values := append(bech32HrpExpand(hrp), integers...)
 	values = append(values, []int{0, 0, 0, 0, 0, 0}...)
-	polymod := bech32Polymod(values) ^ 1
+	polymod := bech32Polymod(values) ^ version.checksumConst()
 	var res []byte
 	for i := 0; i < 6; i++ {
 		res = append(res, byte((polymod>>uint(5*(5-i)))&31))
@@ -259,3 +316,20 @@ func bech32VerifyChecksum(hrp string, data []byte) bool {
 	concat := append(bech32HrpExpand(hrp), integers...)
 	return bech32Polymod(concat) == 1
 }
+
+// bech32ChecksumVersion returns the version of the checksum at the end of
+// data, or false if it is neither a valid bech32 nor bech32m checksum.
+func bech32ChecksumVersion(hrp string, data []byte) (Version, bool) {
+	integers := make([]int, len(data))
+	for i, b := range data {
+		integers[i] = int(b)
+	}
+	concat := append(bech32HrpExpand(hrp), integers...)
+	switch bech32Polymod(concat) {
+	case Version0.checksumConst():
+		return Version0, true
+	case VersionM.checksumConst():
+		return VersionM, true
+	}
+	return 0, false
+}
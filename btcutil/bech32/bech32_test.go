@@ -67,3 +67,47 @@ func TestBech32(t *testing.T) {
 		}
 	}
 }
+
+func TestBech32m(t *testing.T) {
+	// Valid bech32m strings from BIP 350.
+	tests := []string{
+		"A1LQFN3A",
+		"a1lqfn3a",
+		"an83characterlonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11sg7hg6",
+		"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx",
+		"split1checkupstagehandshakeupstreamerranterredcaperredlc445v",
+		"?1v759aa",
+	}
+
+	for _, str := range tests {
+		hrp, decoded, version, err := bech32.DecodeGeneric(str)
+		if err != nil {
+			t.Errorf("expected string %v to be valid bech32m: %v", str, err)
+			continue
+		}
+		if version != bech32.VersionM {
+			t.Errorf("got version %v for %v, want bech32m", version, str)
+		}
+
+		encoded, err := bech32.EncodeM(hrp, decoded)
+		if err != nil {
+			t.Errorf("encoding failed: %v", err)
+		}
+		if encoded != strings.ToLower(str) {
+			t.Errorf("expected data to encode to %v, but got %v",
+				str, encoded)
+		}
+
+		// A bech32m string is not a valid bech32 string.
+		if _, _, err := bech32.Decode(str); err == nil {
+			t.Errorf("expected bech32 decoding of %v to fail", str)
+		}
+	}
+
+	// And the reverse.
+	_, _, version, err := bech32.DecodeGeneric("A12UEL5L")
+	if err != nil || version != bech32.Version0 {
+		t.Errorf("got version %v, err %v for bech32 string, want bech32",
+			version, err)
+	}
+}
@@ -622,6 +622,32 @@ func (k *ExtendedKey) SetNet(net *chaincfg.Params) {
 	}
 }
 
+// Version returns the 4 version bytes of the extended key, these identify the
+// network and, in the case of SLIP-0132 keys, the script type.
+func (k *ExtendedKey) Version() []byte {
+	return k.version
+}
+
+// CloneWithVersion returns a new extended key cloned from this extended key,
+// but using the provided HD version bytes. The version must be a private HD
+// key ID for an extended private key, and a public HD key ID for an extended
+// public key.
+//
+// This method creates a new copy and therefore does not mutate the original
+// extended key instance.
+//
+// Unlike SetNet(), this method does not check the provided version bytes
+// against the network, which makes it possible to produce the SLIP-0132
+// encoded keys which are used for segwit script types.
+func (k *ExtendedKey) CloneWithVersion(version []byte) (*ExtendedKey, er.R) {
+	if len(version) != 4 {
+		return nil, chaincfg.ErrUnknownHDKeyID.Default()
+	}
+
+	return NewExtendedKey(version, k.key, k.chainCode, k.parentFP,
+		k.depth, k.childNum, k.isPrivate), nil
+}
+
 // zero sets all bytes in the passed slice to zero.  This is used to
 // explicitly clear private key material from memory.
 func zero(b []byte) {
@@ -76,6 +76,17 @@ func TstAddressWitnessScriptHash(version byte, program [32]byte,
 	}
 }
 
+// TstAddressTaproot creates an AddressTaproot, initiating the fields as given.
+func TstAddressTaproot(version byte, program [32]byte,
+	hrp string) *AddressTaproot {
+
+	return &AddressTaproot{
+		hrp:            hrp,
+		witnessVersion: version,
+		witnessProgram: program,
+	}
+}
+
 // TstAddressPubKey makes an AddressPubKey, setting the unexported fields with
 // the parameters.
 func TstAddressPubKey(serializedPubKey []byte, pubKeyFormat PubKeyFormat,
@@ -97,9 +108,9 @@ func TstAddressSAddr(addr string) []byte {
 }
 
 // TstAddressSegwitSAddr returns the expected witness program bytes for
-// bech32 encoded P2WPKH and P2WSH bitcoin addresses.
+// bech32 encoded P2WPKH and P2WSH and bech32m encoded P2TR bitcoin addresses.
 func TstAddressSegwitSAddr(addr string) []byte {
-	_, data, err := bech32.Decode(addr)
+	_, data, _, err := bech32.DecodeGeneric(addr)
 	if err != nil {
 		return []byte{}
 	}
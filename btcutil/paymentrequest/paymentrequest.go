@@ -0,0 +1,409 @@
+// Package paymentrequest implements parsing and verification of BIP0070
+// payment requests.
+//
+// The protocol buffer messages are decoded directly from the wire format as
+// only the few messages defined by BIP0070 are needed.
+package paymentrequest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Err is the error type of all payment request errors.
+var Err er.ErrorType = er.NewErrorType("paymentrequest.Err")
+
+var (
+	// ErrMalformed is returned when a payment request cannot be decoded.
+	ErrMalformed = Err.CodeWithDetail("ErrMalformed",
+		"malformed payment request")
+
+	// ErrUnsigned is returned when verifying a payment request which has
+	// no PKI signature.
+	ErrUnsigned = Err.CodeWithDetail("ErrUnsigned",
+		"payment request is not signed")
+
+	// ErrUnsupportedPki is returned when verifying a payment request which
+	// is signed with an unknown PKI type.
+	ErrUnsupportedPki = Err.CodeWithDetail("ErrUnsupportedPki",
+		"unsupported payment request PKI type")
+
+	// ErrBadCertificate is returned when the certificate chain of a payment
+	// request cannot be verified.
+	ErrBadCertificate = Err.CodeWithDetail("ErrBadCertificate",
+		"payment request certificate chain is not valid")
+
+	// ErrBadSignature is returned when the signature of a payment request
+	// does not match its contents.
+	ErrBadSignature = Err.CodeWithDetail("ErrBadSignature",
+		"payment request signature is not valid")
+)
+
+// PKI types defined by BIP0070.
+const (
+	PkiNone       = "none"
+	PkiX509SHA256 = "x509+sha256"
+	PkiX509SHA1   = "x509+sha1"
+)
+
+// Field numbers of the BIP0070 messages.
+const (
+	fieldOutputAmount = 1
+	fieldOutputScript = 2
+
+	fieldDetailsNetwork      = 1
+	fieldDetailsOutputs      = 2
+	fieldDetailsTime         = 3
+	fieldDetailsExpires      = 4
+	fieldDetailsMemo         = 5
+	fieldDetailsPaymentURL   = 6
+	fieldDetailsMerchantData = 7
+
+	fieldRequestVersion   = 1
+	fieldRequestPkiType   = 2
+	fieldRequestPkiData   = 3
+	fieldRequestDetails   = 4
+	fieldRequestSignature = 5
+
+	fieldX509Certificate = 1
+)
+
+const (
+	defaultDetailsVersion = 1
+	defaultDetailsNetwork = "main"
+
+	// maxPaymentRequestBytes is the largest payment request which will be
+	// parsed, as recommended by BIP0070.
+	maxPaymentRequestBytes = 50000
+)
+
+// Output is an amount which is requested to be paid to a script.
+type Output struct {
+	Amount uint64
+	Script []byte
+}
+
+// PaymentDetails is the BIP0070 PaymentDetails message, times are in seconds
+// since the unix epoch and a zero Expires means that the request never
+// expires.
+type PaymentDetails struct {
+	Network      string
+	Outputs      []Output
+	Time         uint64
+	Expires      uint64
+	Memo         string
+	PaymentURL   string
+	MerchantData []byte
+}
+
+// Expired returns whether the payment request has expired at time now.
+func (d *PaymentDetails) Expired(now time.Time) bool {
+	return d.Expires != 0 && uint64(now.Unix()) > d.Expires
+}
+
+// PaymentRequest is the BIP0070 PaymentRequest message.
+type PaymentRequest struct {
+	DetailsVersion           uint32
+	PkiType                  string
+	PkiData                  []byte
+	SerializedPaymentDetails []byte
+	Signature                []byte
+
+	// unsigned is the request as it was serialized with an empty
+	// signature, which is what the signature covers.
+	unsigned []byte
+}
+
+// forEachField calls f with the number, type and value of each field of the
+// protocol buffer message b.  Varint values are passed in v and length
+// delimited values in data, raw is the whole encoded field.
+func forEachField(b []byte, f func(num protowire.Number, typ protowire.Type,
+	v uint64, data, raw []byte) er.R) er.R {
+
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return ErrMalformed.New("", er.E(protowire.ParseError(n)))
+		}
+		m := protowire.ConsumeFieldValue(num, typ, b[n:])
+		if m < 0 {
+			return ErrMalformed.New("", er.E(protowire.ParseError(m)))
+		}
+		raw, value := b[:n+m], b[n:n+m]
+		b = b[n+m:]
+
+		var v uint64
+		var data []byte
+		switch typ {
+		case protowire.VarintType:
+			v, _ = protowire.ConsumeVarint(value)
+		case protowire.BytesType:
+			data, _ = protowire.ConsumeBytes(value)
+		}
+		if err := f(num, typ, v, data, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parse decodes a serialized payment request.
+func Parse(b []byte) (*PaymentRequest, er.R) {
+	if len(b) > maxPaymentRequestBytes {
+		return nil, ErrMalformed.New("payment request is too large", nil)
+	}
+	r := &PaymentRequest{
+		DetailsVersion: defaultDetailsVersion,
+		PkiType:        PkiNone,
+	}
+	haveDetails := false
+	var unsigned []byte
+	err := forEachField(b, func(num protowire.Number, typ protowire.Type,
+		v uint64, data, raw []byte) er.R {
+
+		switch {
+		case num == fieldRequestVersion && typ == protowire.VarintType:
+			r.DetailsVersion = uint32(v)
+		case num == fieldRequestPkiType && typ == protowire.BytesType:
+			r.PkiType = string(data)
+		case num == fieldRequestPkiData && typ == protowire.BytesType:
+			r.PkiData = data
+		case num == fieldRequestDetails && typ == protowire.BytesType:
+			r.SerializedPaymentDetails = data
+			haveDetails = true
+		case num == fieldRequestSignature && typ == protowire.BytesType:
+			r.Signature = data
+			unsigned = protowire.AppendTag(unsigned, num, typ)
+			unsigned = protowire.AppendBytes(unsigned, nil)
+			return nil
+		}
+		unsigned = append(unsigned, raw...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !haveDetails {
+		return nil, ErrMalformed.New("missing payment details", nil)
+	}
+	r.unsigned = unsigned
+	return r, nil
+}
+
+// Serialize encodes the payment request.  The signature is included if it is
+// non-nil, so setting it to an empty slice gives the data to be signed.
+func (r *PaymentRequest) Serialize() []byte {
+	var b []byte
+	if r.DetailsVersion != 0 {
+		b = protowire.AppendTag(b, fieldRequestVersion, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(r.DetailsVersion))
+	}
+	if r.PkiType != "" {
+		b = protowire.AppendTag(b, fieldRequestPkiType, protowire.BytesType)
+		b = protowire.AppendString(b, r.PkiType)
+	}
+	if r.PkiData != nil {
+		b = protowire.AppendTag(b, fieldRequestPkiData, protowire.BytesType)
+		b = protowire.AppendBytes(b, r.PkiData)
+	}
+	b = protowire.AppendTag(b, fieldRequestDetails, protowire.BytesType)
+	b = protowire.AppendBytes(b, r.SerializedPaymentDetails)
+	if r.Signature != nil {
+		b = protowire.AppendTag(b, fieldRequestSignature, protowire.BytesType)
+		b = protowire.AppendBytes(b, r.Signature)
+	}
+	return b
+}
+
+// Details decodes the payment details of the request.
+func (r *PaymentRequest) Details() (*PaymentDetails, er.R) {
+	d := &PaymentDetails{Network: defaultDetailsNetwork}
+	haveTime := false
+	err := forEachField(r.SerializedPaymentDetails, func(num protowire.Number,
+		typ protowire.Type, v uint64, data, _ []byte) er.R {
+
+		switch {
+		case num == fieldDetailsNetwork && typ == protowire.BytesType:
+			d.Network = string(data)
+		case num == fieldDetailsOutputs && typ == protowire.BytesType:
+			var out Output
+			haveScript := false
+			err := forEachField(data, func(num protowire.Number,
+				typ protowire.Type, v uint64, data, _ []byte) er.R {
+
+				switch {
+				case num == fieldOutputAmount && typ == protowire.VarintType:
+					out.Amount = v
+				case num == fieldOutputScript && typ == protowire.BytesType:
+					out.Script = data
+					haveScript = true
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if !haveScript {
+				return ErrMalformed.New("output is missing its script", nil)
+			}
+			d.Outputs = append(d.Outputs, out)
+		case num == fieldDetailsTime && typ == protowire.VarintType:
+			d.Time = v
+			haveTime = true
+		case num == fieldDetailsExpires && typ == protowire.VarintType:
+			d.Expires = v
+		case num == fieldDetailsMemo && typ == protowire.BytesType:
+			d.Memo = string(data)
+		case num == fieldDetailsPaymentURL && typ == protowire.BytesType:
+			d.PaymentURL = string(data)
+		case num == fieldDetailsMerchantData && typ == protowire.BytesType:
+			d.MerchantData = data
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !haveTime {
+		return nil, ErrMalformed.New("payment details are missing the time", nil)
+	}
+	return d, nil
+}
+
+// Serialize encodes the payment details.
+func (d *PaymentDetails) Serialize() []byte {
+	var b []byte
+	if d.Network != "" {
+		b = protowire.AppendTag(b, fieldDetailsNetwork, protowire.BytesType)
+		b = protowire.AppendString(b, d.Network)
+	}
+	for _, out := range d.Outputs {
+		var o []byte
+		o = protowire.AppendTag(o, fieldOutputAmount, protowire.VarintType)
+		o = protowire.AppendVarint(o, out.Amount)
+		o = protowire.AppendTag(o, fieldOutputScript, protowire.BytesType)
+		o = protowire.AppendBytes(o, out.Script)
+		b = protowire.AppendTag(b, fieldDetailsOutputs, protowire.BytesType)
+		b = protowire.AppendBytes(b, o)
+	}
+	b = protowire.AppendTag(b, fieldDetailsTime, protowire.VarintType)
+	b = protowire.AppendVarint(b, d.Time)
+	if d.Expires != 0 {
+		b = protowire.AppendTag(b, fieldDetailsExpires, protowire.VarintType)
+		b = protowire.AppendVarint(b, d.Expires)
+	}
+	if d.Memo != "" {
+		b = protowire.AppendTag(b, fieldDetailsMemo, protowire.BytesType)
+		b = protowire.AppendString(b, d.Memo)
+	}
+	if d.PaymentURL != "" {
+		b = protowire.AppendTag(b, fieldDetailsPaymentURL, protowire.BytesType)
+		b = protowire.AppendString(b, d.PaymentURL)
+	}
+	if d.MerchantData != nil {
+		b = protowire.AppendTag(b, fieldDetailsMerchantData, protowire.BytesType)
+		b = protowire.AppendBytes(b, d.MerchantData)
+	}
+	return b
+}
+
+// EncodeCertificates encodes a certificate chain, leaf first, as the
+// X509Certificates message used as the PKI data of x509 signed requests.
+func EncodeCertificates(certs [][]byte) []byte {
+	var b []byte
+	for _, cert := range certs {
+		b = protowire.AppendTag(b, fieldX509Certificate, protowire.BytesType)
+		b = protowire.AppendBytes(b, cert)
+	}
+	return b
+}
+
+// Verify checks the certificate chain and the signature of the payment
+// request at time now, returning the certificate which signed it.  The chain
+// is verified against roots, or the system roots if roots is nil.  Expiry of
+// the payment details is not checked, see PaymentDetails.Expired.
+func (r *PaymentRequest) Verify(roots *x509.CertPool, now time.Time) (*x509.Certificate, er.R) {
+	var hash crypto.Hash
+	switch r.PkiType {
+	case PkiNone, "":
+		return nil, ErrUnsigned.Default()
+	case PkiX509SHA256:
+		hash = crypto.SHA256
+	case PkiX509SHA1:
+		hash = crypto.SHA1
+	default:
+		return nil, ErrUnsupportedPki.New(r.PkiType, nil)
+	}
+
+	var certs []*x509.Certificate
+	err := forEachField(r.PkiData, func(num protowire.Number, typ protowire.Type,
+		_ uint64, data, _ []byte) er.R {
+
+		if num != fieldX509Certificate || typ != protowire.BytesType {
+			return nil
+		}
+		cert, errr := x509.ParseCertificate(data)
+		if errr != nil {
+			return ErrBadCertificate.New("", er.E(errr))
+		}
+		certs = append(certs, cert)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, ErrBadCertificate.New("no certificates", nil)
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	leaf := certs[0]
+	if _, errr := leaf.Verify(opts); errr != nil {
+		return nil, ErrBadCertificate.New("", er.E(errr))
+	}
+
+	var algo x509.SignatureAlgorithm
+	switch leaf.PublicKey.(type) {
+	case *rsa.PublicKey:
+		algo = x509.SHA256WithRSA
+		if hash == crypto.SHA1 {
+			algo = x509.SHA1WithRSA
+		}
+	case *ecdsa.PublicKey:
+		algo = x509.ECDSAWithSHA256
+		if hash == crypto.SHA1 {
+			algo = x509.ECDSAWithSHA1
+		}
+	default:
+		return nil, ErrBadCertificate.New("unsupported public key type", nil)
+	}
+	if errr := leaf.CheckSignature(algo, r.unsignedBytes(), r.Signature); errr != nil {
+		return nil, ErrBadSignature.New("", er.E(errr))
+	}
+	return leaf, nil
+}
+
+// unsignedBytes returns the serialization of the request with an empty
+// signature, preserving the original encoding if the request was parsed.
+func (r *PaymentRequest) unsignedBytes() []byte {
+	if r.unsigned != nil {
+		return r.unsigned
+	}
+	unsigned := *r
+	unsigned.Signature = []byte{}
+	return unsigned.Serialize()
+}
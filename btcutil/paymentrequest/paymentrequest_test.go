@@ -0,0 +1,170 @@
+package paymentrequest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// testChain creates a root certificate authority and a merchant certificate
+// signed by it, returning the pool of roots, the merchant certificate chain
+// and the merchant key.
+func testChain(t *testing.T, now time.Time) (*x509.CertPool, [][]byte, *rsa.PrivateKey) {
+	caKey, errr := rsa.GenerateKey(rand.Reader, 2048)
+	if errr != nil {
+		t.Fatalf("unable to generate key: %v", errr)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, errr := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate,
+		&caKey.PublicKey, caKey)
+	if errr != nil {
+		t.Fatalf("unable to create certificate: %v", errr)
+	}
+	ca, errr := x509.ParseCertificate(caDER)
+	if errr != nil {
+		t.Fatalf("unable to parse certificate: %v", errr)
+	}
+
+	key, errr := rsa.GenerateKey(rand.Reader, 2048)
+	if errr != nil {
+		t.Fatalf("unable to generate key: %v", errr)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "merchant.example.com"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, errr := x509.CreateCertificate(rand.Reader, template, ca,
+		&key.PublicKey, caKey)
+	if errr != nil {
+		t.Fatalf("unable to create certificate: %v", errr)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	return roots, [][]byte{der}, key
+}
+
+// signedRequest creates a payment request for details signed by key.
+func signedRequest(t *testing.T, details *PaymentDetails, chain [][]byte,
+	key *rsa.PrivateKey) []byte {
+
+	r := &PaymentRequest{
+		DetailsVersion:           1,
+		PkiType:                  PkiX509SHA256,
+		PkiData:                  EncodeCertificates(chain),
+		SerializedPaymentDetails: details.Serialize(),
+		Signature:                []byte{},
+	}
+	digest := sha256.Sum256(r.Serialize())
+	sig, errr := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if errr != nil {
+		t.Fatalf("unable to sign request: %v", errr)
+	}
+	r.Signature = sig
+	return r.Serialize()
+}
+
+// TestVerify checks that a validly signed payment request verifies and
+// decodes, and that tampered, unsigned and expired requests are detected.
+func TestVerify(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	roots, chain, key := testChain(t, now)
+
+	details := &PaymentDetails{
+		Network: "main",
+		Outputs: []Output{
+			{Amount: 150000000, Script: []byte{0x76, 0xa9, 0x14}},
+			{Amount: 2500, Script: []byte{0x51}},
+		},
+		Time:       uint64(now.Unix()),
+		Expires:    uint64(now.Add(time.Hour).Unix()),
+		Memo:       "Order #42",
+		PaymentURL: "https://merchant.example.com/pay",
+	}
+	b := signedRequest(t, details, chain, key)
+
+	r, err := Parse(b)
+	if err != nil {
+		t.Fatalf("unable to parse request: %v", err)
+	}
+	cert, err := r.Verify(roots, now)
+	if err != nil {
+		t.Fatalf("valid request did not verify: %v", err)
+	}
+	if cert.Subject.CommonName != "merchant.example.com" {
+		t.Fatalf("got signer %q, want merchant.example.com",
+			cert.Subject.CommonName)
+	}
+	got, err := r.Details()
+	if err != nil {
+		t.Fatalf("unable to decode details: %v", err)
+	}
+	if got.Memo != details.Memo || got.Expires != details.Expires ||
+		got.PaymentURL != details.PaymentURL || len(got.Outputs) != 2 ||
+		got.Outputs[0].Amount != 150000000 || got.Outputs[1].Amount != 2500 {
+		t.Fatalf("got details %+v, want %+v", got, details)
+	}
+	if got.Expired(now) {
+		t.Fatalf("request expired before its expiry time")
+	}
+	if !got.Expired(now.Add(2 * time.Hour)) {
+		t.Fatalf("request not expired after its expiry time")
+	}
+
+	// Pay to a different amount without re-signing.
+	tampered := *details
+	tampered.Outputs = []Output{{Amount: 950000000, Script: details.Outputs[0].Script}}
+	r.SerializedPaymentDetails = tampered.Serialize()
+	r, err = Parse(r.Serialize())
+	if err != nil {
+		t.Fatalf("unable to parse tampered request: %v", err)
+	}
+	if _, err := r.Verify(roots, now); !ErrBadSignature.Is(err) {
+		t.Fatalf("got error %v for tampered request, want ErrBadSignature", err)
+	}
+
+	// The chain does not verify against other roots.
+	otherRoots, _, _ := testChain(t, now)
+	r, _ = Parse(b)
+	if _, err := r.Verify(otherRoots, now); !ErrBadCertificate.Is(err) {
+		t.Fatalf("got error %v for untrusted chain, want ErrBadCertificate", err)
+	}
+
+	// Nor after the certificates have expired.
+	if _, err := r.Verify(roots, now.Add(48*time.Hour)); !ErrBadCertificate.Is(err) {
+		t.Fatalf("got error %v for expired chain, want ErrBadCertificate", err)
+	}
+
+	unsigned := &PaymentRequest{
+		PkiType:                  PkiNone,
+		SerializedPaymentDetails: details.Serialize(),
+	}
+	r, err = Parse(unsigned.Serialize())
+	if err != nil {
+		t.Fatalf("unable to parse unsigned request: %v", err)
+	}
+	if _, err := r.Verify(roots, now); !ErrUnsigned.Is(err) {
+		t.Fatalf("got error %v for unsigned request, want ErrUnsigned", err)
+	}
+
+	if _, err := Parse([]byte{0x0a, 0xff}); !ErrMalformed.Is(err) {
+		t.Fatalf("got error %v for malformed request, want ErrMalformed", err)
+	}
+}
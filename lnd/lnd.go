@@ -1369,6 +1369,7 @@ func initNeutrinoBackend(cfg *Config, chainDir string) (*neutrino.ChainService,
 	// required.
 	config := neutrino.Config{
 		DataDir:      chainDir,
+		PeersFile:    filepath.Join(chainDir, "peers.json"),
 		Database:     db,
 		ChainParams:  *cfg.ActiveNetParams.Params,
 		AddPeers:     cfg.NeutrinoMode.AddPeers,
@@ -122,7 +122,9 @@ func checkInputsStandard(tx *btcutil.Tx, utxoView *blockchain.UtxoViewpoint) er.
 				return txRuleError(wire.RejectNonstandard, str)
 			}
 
-		case txscript.NonStandardTy:
+		// Taproot is not a consensus rule of the chain so spends of
+		// taproot outputs are not relayed.
+		case txscript.NonStandardTy, txscript.WitnessV1TaprootTy:
 			str := fmt.Sprintf("transaction input #%d has a "+
 				"non-standard script form", i)
 			return txRuleError(wire.RejectNonstandard, str)
@@ -174,7 +176,9 @@ func checkPkScriptStandard(pkScript []byte, scriptClass txscript.ScriptClass) er
 			return txRuleError(wire.RejectNonstandard, str)
 		}
 
-	case txscript.NonStandardTy:
+	// Until taproot is a consensus rule of the chain a taproot output can
+	// be spent by anyone, so it is not standard.
+	case txscript.NonStandardTy, txscript.WitnessV1TaprootTy:
 		return txRuleError(wire.RejectNonstandard,
 			"non-standard script form")
 	}
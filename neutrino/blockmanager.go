@@ -82,6 +82,9 @@ type donePeerMsg struct {
 	peer *ServerPeer
 }
 
+// resumeSyncMsg signifies to the block handler that the sync was resumed.
+type resumeSyncMsg struct{}
+
 // blockManager provides a concurrency safe block manager for handling all
 // incoming blocks.
 type blockManager struct {
@@ -168,6 +171,11 @@ type blockManager struct {
 	// peerChan is a channel for messages that come from peers
 	peerChan chan interface{}
 
+	// syncGate pauses the sync of block headers and filter headers.
+	// While the sync is paused headers and invs received from peers are
+	// ignored, and once it is resumed headers are requested anew.
+	syncGate syncGate
+
 	// firstPeerSignal is a channel that's sent upon once the main daemon
 	// has made its first peer connection. We use this to ensure we don't
 	// try to perform any queries before we have our first peer.
@@ -507,6 +515,10 @@ func (b *blockManager) cfHandler() {
 	}
 
 waitForHeaders:
+	if !b.syncGate.wait(b.quit) {
+		return
+	}
+
 	// We'll wait until the main header sync is either finished or the
 	// filter headers are lagging at least a checkpoint interval behind the
 	// block headers, before we actually start to sync the set of
@@ -699,6 +711,10 @@ waitForHeaders:
 		b.newFilterHeadersMtx.RUnlock()
 		b.newHeadersSignal.L.Unlock()
 
+		if !b.syncGate.wait(b.quit) {
+			return
+		}
+
 		// At this point, we know that there're a set of new filter
 		// headers to fetch, so we'll grab them now.
 		if err = b.getUncheckpointedCFHeaders(
@@ -1921,6 +1937,13 @@ out:
 		// Now check peer messages and quit channels.
 		select {
 		case m := <-b.peerChan:
+			switch m.(type) {
+			case *invMsg, *headersMsg, *provenHeadersMsg:
+				if b.syncGate.Paused() {
+					log.Tracef("Sync paused, ignoring %T", m)
+					continue
+				}
+			}
 			switch msg := m.(type) {
 			case *newPeerMsg:
 				b.handleNewPeerMsg(candidatePeers, msg.peer)
@@ -1937,6 +1960,15 @@ out:
 			case *donePeerMsg:
 				b.handleDonePeerMsg(candidatePeers, msg.peer)
 
+			case *resumeSyncMsg:
+				// Headers which were requested before the
+				// sync was paused may have been ignored, so
+				// start over with a new request.
+				b.syncPeerMutex.Lock()
+				b.syncPeer = nil
+				b.syncPeerMutex.Unlock()
+				b.startSync(candidatePeers)
+
 			default:
 				log.Warnf("Invalid message type in block "+
 					"handler: %T", msg)
@@ -1950,6 +1982,25 @@ out:
 	log.Trace("Block handler done")
 }
 
+// PauseSync pauses the sync of block headers and filter headers, returning
+// false if it was already paused.
+func (b *blockManager) PauseSync() bool {
+	return b.syncGate.Pause()
+}
+
+// ResumeSync resumes the sync of block headers and filter headers, returning
+// false if it was not paused.
+func (b *blockManager) ResumeSync() bool {
+	if !b.syncGate.Resume() {
+		return false
+	}
+	select {
+	case b.peerChan <- &resumeSyncMsg{}:
+	case <-b.quit:
+	}
+	return true
+}
+
 // SyncPeer returns the current sync peer.
 func (b *blockManager) SyncPeer() *ServerPeer {
 	b.syncPeerMutex.Lock()
@@ -14,4 +14,9 @@ var (
 	// ErrShuttingDown signals that neutrino received a shutdown request.
 	ErrShuttingDown = Err.CodeWithDetail("ErrShuttingDown",
 		"neutrino shutting down")
+
+	// ErrFilterNotSynced signals that the filter header of a block has not
+	// been synced yet, so its filter cannot be verified.
+	ErrFilterNotSynced = Err.CodeWithDetail("ErrFilterNotSynced",
+		"the filter headers are not synced to the block")
 )
@@ -70,6 +70,10 @@ var (
 	// MaxPeers is the maximum number of connections the client maintains.
 	MaxPeers = 125
 
+	// MinProtocolVersion is the lowest protocol version which a peer may
+	// advertise, peers advertising a lower version are disconnected.
+	MinProtocolVersion = protocol.MultipleAddressVersion
+
 	// DisableDNSSeed disables getting initial addresses for Bitcoin nodes
 	// from DNS.
 	DisableDNSSeed = false
@@ -112,6 +116,17 @@ func (ps *peerState) Count() int {
 	return len(ps.outboundPeers) + len(ps.persistentPeers)
 }
 
+// countInbound returns the number of inbound and of outbound peers.
+func (ps *peerState) countInbound() (int, int) {
+	inbound := 0
+	ps.forAllPeers(func(sp *ServerPeer) {
+		if sp.Inbound() {
+			inbound++
+		}
+	})
+	return inbound, ps.Count() - inbound
+}
+
 // forAllOutboundPeers is a helper function that runs closure on all outbound
 // peers known to peerState.
 func (ps *peerState) forAllOutboundPeers(closure func(sp *ServerPeer)) {
@@ -216,6 +231,12 @@ func (sp *ServerPeer) OnVerAck(_ *peer.Peer, msg *wire.MsgVerAck) {
 	sp.pushSendHeadersMsg()
 }
 
+// acceptsProtocolVersion returns whether a peer advertising the protocol
+// version v may stay connected.
+func acceptsProtocolVersion(v int32) bool {
+	return v >= 0 && uint32(v) >= MinProtocolVersion
+}
+
 // OnVersion is invoked when a peer receives a version message and is used to
 // negotiate the protocol version details as well as kickstart communications.
 func (sp *ServerPeer) OnVersion(_ *peer.Peer, msg *wire.MsgVersion) *wire.MsgReject {
@@ -223,6 +244,15 @@ func (sp *ServerPeer) OnVersion(_ *peer.Peer, msg *wire.MsgVersion) *wire.MsgRej
 	// the local clock to keep the network time in sync.
 	sp.server.timeSource.AddTimeSample(sp.Addr(), msg.Timestamp)
 
+	if !acceptsProtocolVersion(msg.ProtocolVersion) {
+		log.Debugf("%v advertises protocol version %d below the minimum "+
+			"%d, disconnecting", sp, msg.ProtocolVersion, MinProtocolVersion)
+
+		sp.Disconnect()
+
+		return nil
+	}
+
 	// If the peer doesn't allow us to relay any transactions to them, then
 	// we won't add them as a peer, as they aren't of much use to us.
 	if msg.DisableRelayTx {
@@ -504,6 +534,12 @@ type Config struct {
 	// information within.
 	DataDir string
 
+	// PeersFile is the file in which the addresses of known peers are
+	// saved on shutdown, and periodically, so that they can be loaded
+	// again on the next startup.  If empty, then known peers are
+	// forgotten on shutdown.
+	PeersFile string
+
 	// Database is an *open* database instance that we'll use to storm
 	// indexes of teh chain.
 	Database walletdb.DB
@@ -552,6 +588,33 @@ type Config struct {
 	// CheckConnectivity is an option to force a CheckConectivity during
 	// NeutrinoDBStore initialization
 	CheckConectivity bool
+
+	// MaxInbound is the maximum number of inbound peers.  If zero, then
+	// inbound peers are only limited by MaxPeers.
+	MaxInbound int
+
+	// MaxOutbound is the maximum number of outbound peers, the connection
+	// manager targets the lesser of this and TargetOutbound.  If zero,
+	// then outbound peers are only limited by MaxPeers.
+	MaxOutbound int
+
+	// BroadcastPeers is the number of peers a new transaction is sent to
+	// at once, the sync peer which it is announced to and as many other
+	// peers which it is pushed to directly.  If zero, only the sync peer.
+	BroadcastPeers int
+}
+
+// OutboundTarget returns the number of outbound peers the connection manager
+// targets, the least of TargetOutbound, MaxPeers and MaxOutbound.
+func (cfg *Config) OutboundTarget() int {
+	target := TargetOutbound
+	if MaxPeers < target {
+		target = MaxPeers
+	}
+	if cfg.MaxOutbound > 0 && cfg.MaxOutbound < target {
+		target = cfg.MaxOutbound
+	}
+	return target
 }
 
 // ChainService is instantiated with functional options
@@ -597,6 +660,10 @@ type ChainService struct {
 	userAgentName    string
 	userAgentVersion string
 
+	maxInbound     int
+	maxOutbound    int
+	broadcastPeers int
+
 	nameResolver func(string) ([]net.IP, er.R)
 	dialer       func(net.Addr) (net.Conn, er.R)
 
@@ -658,7 +725,7 @@ func NewChainService(cfg Config) (*ChainService, er.R) {
 	// When creating the addr manager, we'll check to see if the user has
 	// provided their own resolution function. If so, then we'll use that
 	// instead as this may be routing requests over an anonymizing network.
-	amgr := addrmgr.New(cfg.DataDir, nameResolver)
+	amgr := addrmgr.NewWithPeersFile(cfg.PeersFile, nameResolver)
 	bmConfig := banmgr.Config{
 		DisableBanning: false,
 		IpWhiteList:    []string{},
@@ -683,6 +750,9 @@ func NewChainService(cfg Config) (*ChainService, er.R) {
 		queries:           make(map[uint32]*Query),
 		invListeners:      make(map[chainhash.Hash][]chan *ServerPeer),
 		banMgr:            *banmgr.New(&bmConfig),
+		maxInbound:        cfg.MaxInbound,
+		maxOutbound:       cfg.MaxOutbound,
+		broadcastPeers:    cfg.BroadcastPeers,
 	}
 
 	// We do the same for queryBatch.
@@ -803,9 +873,12 @@ func NewChainService(cfg Config) (*ChainService, er.R) {
 		}
 	}
 
+	if MaxPeers < TargetOutbound {
+		TargetOutbound = MaxPeers
+	}
 	cmgrCfg := &connmgr.Config{
 		RetryDuration:  ConnectionRetryInterval,
-		TargetOutbound: uint32(TargetOutbound),
+		TargetOutbound: uint32(cfg.OutboundTarget()),
 		OnConnection:   s.outboundPeerConnected,
 		Dial:           dialer,
 	}
@@ -814,9 +887,6 @@ func NewChainService(cfg Config) (*ChainService, er.R) {
 	}
 
 	// Create a connection manager.
-	if MaxPeers < TargetOutbound {
-		TargetOutbound = MaxPeers
-	}
 	cmgr, err := connmgr.New(cmgrCfg)
 	if err != nil {
 		return nil, err
@@ -1235,6 +1305,21 @@ func (s *ChainService) handleAddPeerMsg(state *peerState, sp *ServerPeer) bool {
 		return false
 	}
 
+	// Limit the number of inbound and of outbound peers.
+	inbound, outbound := state.countInbound()
+	if sp.Inbound() && s.maxInbound > 0 && inbound >= s.maxInbound {
+		log.Infof("Max inbound peers reached [%d] - disconnecting peer %s",
+			s.maxInbound, sp)
+		sp.Disconnect()
+		return false
+	}
+	if !sp.Inbound() && s.maxOutbound > 0 && outbound >= s.maxOutbound {
+		log.Infof("Max outbound peers reached [%d] - disconnecting peer %s",
+			s.maxOutbound, sp)
+		sp.Disconnect()
+		return false
+	}
+
 	// Add the new peer and start it.
 	log.Debugf("New peer %s", sp)
 	state.outboundGroups[addrmgr.GroupKey(sp.NA())]++
@@ -1505,6 +1590,24 @@ func (s *ChainService) IsCurrent() bool {
 	return s.blockManager.IsFullySynced()
 }
 
+// PauseSync pauses the sync of block headers and filter headers without
+// disconnecting from peers, returning false if it was already paused.
+func (s *ChainService) PauseSync() bool {
+	return s.blockManager.PauseSync()
+}
+
+// ResumeSync resumes the sync of block headers and filter headers, returning
+// false if it was not paused.
+func (s *ChainService) ResumeSync() bool {
+	return s.blockManager.ResumeSync()
+}
+
+// SyncPaused returns whether the sync of block headers and filter headers is
+// paused.
+func (s *ChainService) SyncPaused() bool {
+	return s.blockManager.syncGate.Paused()
+}
+
 // PeerByAddr lets the caller look up a peer address in the service's peer
 // table, if connected to that peer address.
 func (s *ChainService) PeerByAddr(addr string) *ServerPeer {
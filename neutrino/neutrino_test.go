@@ -0,0 +1,58 @@
+package neutrino
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/blockchain"
+	"github.com/pkt-cash/pktd/peer"
+	"github.com/pkt-cash/pktd/wire"
+	"github.com/pkt-cash/pktd/wire/protocol"
+)
+
+// TestOnVersionMinProtocolVersion checks that protocol versions below
+// MinProtocolVersion are refused and that a peer advertising one is
+// disconnected.
+func TestOnVersionMinProtocolVersion(t *testing.T) {
+	defer func(v uint32) { MinProtocolVersion = v }(MinProtocolVersion)
+	MinProtocolVersion = protocol.SendHeadersVersion
+
+	for _, v := range []uint32{
+		protocol.BIP0037Version, protocol.BIP0111Version,
+	} {
+		if acceptsProtocolVersion(int32(v)) {
+			t.Fatalf("version %d below the minimum was accepted", v)
+		}
+	}
+	for _, v := range []uint32{
+		protocol.SendHeadersVersion, protocol.FeeFilterVersion,
+	} {
+		if !acceptsProtocolVersion(int32(v)) {
+			t.Fatalf("version %d was not accepted", v)
+		}
+	}
+
+	s := &ChainService{timeSource: blockchain.NewMedianTime()}
+	sp := newServerPeer(s, false)
+	sp.Peer = peer.NewInboundPeer(&peer.Config{})
+
+	msg := &wire.MsgVersion{
+		ProtocolVersion: int32(protocol.BIP0111Version),
+		Timestamp:       time.Now(),
+	}
+	if reject := sp.OnVersion(nil, msg); reject != nil {
+		t.Fatalf("got reject %v", reject)
+	}
+
+	disconnected := make(chan struct{})
+	go func() {
+		sp.WaitForDisconnect()
+		close(disconnected)
+	}()
+	select {
+	case <-disconnected:
+	case <-time.After(time.Second):
+		t.Fatalf("peer below the minimum protocol version was not " +
+			"disconnected")
+	}
+}
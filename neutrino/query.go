@@ -96,6 +96,10 @@ type queryOptions struct {
 	// and that we should attempt to batch more items with the query such
 	// that they can be cached, avoiding the extra round trip.
 	optimisticBatch optimisticBatchType
+
+	// noBlockCache indicates that a fetched block should be handed to the
+	// caller without being added to the block cache.
+	noBlockCache bool
 }
 
 // optimisticBatchType is a type indicating the kind of batching we want to
@@ -197,6 +201,15 @@ func OptimisticReverseBatch() QueryOption {
 	}
 }
 
+// NoBlockCache allows the caller to tell that a block which is fetched from
+// the network should not be kept in the block cache, this is useful when
+// blocks are scanned once and will not be needed again.
+func NoBlockCache() QueryOption {
+	return func(qo *queryOptions) {
+		qo.noBlockCache = true
+	}
+}
+
 // queryState is an atomically updated per-query state for each query in a
 // batch.
 //
@@ -1381,6 +1394,37 @@ func (s *ChainService) GetCFilter(blockHash chainhash.Hash,
 	}
 }
 
+// GetBlockFilter returns the regular filter of a block along with its filter
+// header.  ErrFilterNotSynced is returned if the filter headers have not been
+// synced up to the block yet, since its filter could not be checked against
+// the header.
+func (s *ChainService) GetBlockFilter(blockHash chainhash.Hash,
+	options ...QueryOption) (*gcs.Filter, *chainhash.Hash, er.R) {
+
+	_, height, err := s.NeutrinoDB.FetchBlockHeader(&blockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, filterHeight, err := s.NeutrinoDB.FilterChainTip()
+	if err != nil {
+		return nil, nil, err
+	}
+	if height > filterHeight {
+		return nil, nil, ErrFilterNotSynced.New(fmt.Sprintf("block [%s] "+
+			"is at height [%d] but filter headers are synced to height [%d]",
+			blockHash, height, filterHeight), nil)
+	}
+	filterHeader, err := s.NeutrinoDB.FetchFilterHeader(&blockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter, err := s.GetCFilter(blockHash, wire.GCSFilterRegular, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return filter, filterHeader, nil
+}
+
 // GetBlock gets a block by requesting it from the network, one peer at a
 // time, until one answers. If the block is found in the cache, it will be
 // returned immediately.
@@ -1504,12 +1548,68 @@ func (s *ChainService) GetBlock0(blockHash chainhash.Hash, height uint32,
 	}
 
 	// Add block to the cache before returning it.
-	_, err = s.BlockCache.Put(*inv, &cache.CacheableBlock{Block: foundBlock})
+	s.putBlockToCache(inv, foundBlock, qo)
+
+	return foundBlock, nil
+}
+
+// putBlockToCache adds a block fetched from the network to the block cache,
+// unless the query asked for it not to be cached.
+func (s *ChainService) putBlockToCache(inv *wire.InvVect, block *btcutil.Block,
+	qo *queryOptions) {
+
+	if qo.noBlockCache {
+		return
+	}
+	_, err := s.BlockCache.Put(*inv, &cache.CacheableBlock{Block: block})
 	if err != nil {
 		log.Warnf("couldn't write block to cache: %v", err)
 	}
+}
 
-	return foundBlock, nil
+// txPeer is a peer which a transaction can be pushed to.
+type txPeer interface {
+	Addr() string
+	QueueMessageWithEncoding(msg wire.Message, doneChan chan<- struct{},
+		encoding wire.MessageEncoding)
+}
+
+// pushTx sends tx to up to n of peers, skipping the peer with the address
+// skip, and returns the number of peers it was sent to.
+func pushTx(tx *wire.MsgTx, peers []txPeer, skip string, n int,
+	encoding wire.MessageEncoding) int {
+
+	pushed := 0
+	for _, p := range peers {
+		if pushed >= n {
+			break
+		}
+		if p.Addr() == skip {
+			continue
+		}
+		p.QueueMessageWithEncoding(tx, nil, encoding)
+		pushed++
+	}
+	return pushed
+}
+
+// pushTxToPeers sends tx directly to BroadcastPeers less one of the connected
+// peers other than the sync peer, which is sent an inv for it instead, and
+// returns the number of peers it was sent to.
+func (s *ChainService) pushTxToPeers(tx *wire.MsgTx,
+	encoding wire.MessageEncoding) int {
+
+	skip := ""
+	if sp := s.blockManager.SyncPeer(); sp != nil {
+		skip = sp.Addr()
+	}
+	var peers []txPeer
+	for _, sp := range s.Peers() {
+		peers = append(peers, sp)
+	}
+	pushed := pushTx(tx, peers, skip, s.broadcastPeers-1, encoding)
+	log.Debugf("Pushed tx [%s] to [%d] peers", tx.TxHash(), pushed)
+	return pushed
 }
 
 // SendTransaction0 sends a transaction to your peers. It returns an error if
@@ -1538,11 +1638,15 @@ func (s *ChainService) GetBlock0(blockHash chainhash.Hash, height uint32,
 //
 // The algorithm used here is as follows:
 // * Send the tx to your syncNode (the one you are primarily using)
+// * If BroadcastPeers is more than one, push the tx straight to that many peers
+//     less one besides the syncNode, skipping the INV, so it propagates sooner
 // * If you receive an INV message from anyone then consider it good
 // * Otherwise, if you receive a reject message from anyone then consider it bad
 // * Otherwise, if you have notificed every one of your peers and received a getdata
 //     request from all of them, then consider it good. This last rule covers the case
-//     when you are connected only to one peer using --connect
+//     when you are connected only to one peer using --connect. Peers which the tx was
+//     pushed to straight never send a getdata, so with BroadcastPeers above one only
+//     an INV can make it good
 //
 // NOTE: If you get an error RejMempool or RejConfirmed, it means your transaction
 //       has already been accepted and you're just getting notified that the node already
@@ -1563,15 +1667,24 @@ func (s *ChainService) SendTransaction0(tx *wire.MsgTx, options ...QueryOption)
 
 	gotInv := false
 	var reject er.R
-	peersGetdatad := 0
+	// Only peers which asked for the tx are counted, those it was pushed
+	// to directly are never asked and give no sign that they took it.
+	peersGetdatad := make(map[string]struct{})
 
 	log.Debugf("Listening for INV [%v] [%s/%v]", iv, iv.Hash, iv.Type)
 
+	// Listen for the inv before the transaction is pushed to any peer so
+	// that an inv relayed straight back is not missed.
+	invCh := s.ListenInvs(txHash)
+	pushed := 0
+	if s.broadcastPeers > 1 {
+		pushed = s.pushTxToPeers(tx, qo.encoding)
+	}
+
 	var doneCh chan<- struct{}
 	stopCh := make(chan struct{})
 	go func() {
 		sch := stopCh
-		invCh := s.ListenInvs(txHash)
 		defer s.StopListenInvs(txHash, invCh)
 	lewp:
 		for {
@@ -1608,12 +1721,17 @@ func (s *ChainService) SendTransaction0(tx *wire.MsgTx, options ...QueryOption)
 			// A peer has replied with a GetData message, so we'll
 			// send them the transaction.
 			case *wire.MsgGetData:
+				asked := false
 				for _, vec := range response.InvList {
 					if vec.Hash == txHash {
 						sp.QueueMessageWithEncoding(tx, nil, qo.encoding)
+						asked = true
 					}
 				}
-				peersGetdatad++
+				if !asked {
+					return false
+				}
+				peersGetdatad[sp.Addr()] = struct{}{}
 				return true
 
 			// A peer has rejected our transaction for whatever
@@ -1643,12 +1761,13 @@ func (s *ChainService) SendTransaction0(tx *wire.MsgTx, options ...QueryOption)
 		return reject
 	}
 
-	log.Debugf("Tx [%s] no rejects and [%d] of [%d] peers sent a getdata",
-		txHash, peersGetdatad, len(s.Peers()))
-	if peersGetdatad >= len(s.Peers()) {
+	log.Debugf("Tx [%s] no rejects and [%d] of [%d] peers sent a getdata, "+
+		"[%d] were sent it directly", txHash, len(peersGetdatad),
+		len(s.Peers()), pushed)
+	if len(peersGetdatad) >= len(s.Peers()) {
 		return nil
 	} else {
 		return er.Errorf("No INV messages and only [%d] of our [%d] peers sent a getData",
-			peersGetdatad, len(s.Peers()))
+			len(peersGetdatad), len(s.Peers()))
 	}
 }
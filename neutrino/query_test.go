@@ -1,17 +1,21 @@
 package neutrino
 
 import (
+	"bytes"
 	"compress/bzip2"
 	"encoding/binary"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/big"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/wire"
 	"github.com/pkt-cash/pktd/wire/protocol"
 
 	"github.com/pkt-cash/pktd/btcutil"
@@ -22,6 +26,8 @@ import (
 	"github.com/pkt-cash/pktd/chaincfg/genesis"
 	"github.com/pkt-cash/pktd/neutrino/cache"
 	"github.com/pkt-cash/pktd/neutrino/cache/lru"
+	"github.com/pkt-cash/pktd/neutrino/headerfs"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
 )
 
 var (
@@ -241,3 +247,179 @@ func TestBigFilterEvictsEverything(t *testing.T) {
 	assertEqual(t, cs.FilterCache.Len(), 1, "")
 	assertEqual(t, getFilter(cs, b3, t), f3, "")
 }
+
+// TestNoBlockCache ensures that blocks fetched with the NoBlockCache option
+// are not kept in the block cache, while other fetched blocks are.
+func TestNoBlockCache(t *testing.T) {
+	blocks, err := loadBlocks(t, blockDataFile, blockDataNet)
+	if err != nil {
+		t.Fatalf("unable to load blocks: %v", err)
+	}
+
+	cs := &ChainService{
+		BlockCache: lru.NewCache(DefaultBlockCacheSize),
+	}
+
+	// Blocks which are scanned during a pruned rescan are discarded.
+	qo := defaultQueryOptions()
+	qo.applyQueryOptions(NoBlockCache())
+	for _, block := range blocks[:10] {
+		inv := wire.NewInvVect(wire.InvTypeWitnessBlock, block.Hash())
+		cs.putBlockToCache(inv, block, qo)
+	}
+	assertEqual(t, cs.BlockCache.Len(), 0, "")
+
+	// Without the option they are cached as usual.
+	qo = defaultQueryOptions()
+	inv := wire.NewInvVect(wire.InvTypeWitnessBlock, blocks[0].Hash())
+	cs.putBlockToCache(inv, blocks[0], qo)
+	assertEqual(t, cs.BlockCache.Len(), 1, "")
+}
+
+// TestGetBlockFilter ensures that GetBlockFilter returns the filter and filter
+// header of a block whose filter header is synced, and ErrFilterNotSynced for
+// a block whose filter header is not.
+func TestGetBlockFilter(t *testing.T) {
+	tempDir, errr := ioutil.TempDir("", "neutrino")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer os.RemoveAll(tempDir)
+	db, err := walletdb.Create("bdb", filepath.Join(tempDir, "neutrino.db"), true)
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+	defer db.Close()
+	store, err := headerfs.NewNeutrinoDBStore(db, &chaincfg.SimNetParams, true)
+	if err != nil {
+		t.Fatalf("unable to create store: %v", err)
+	}
+	cs := &ChainService{
+		NeutrinoDB:  store,
+		FilterCache: lru.NewCache(DefaultFilterCacheSize),
+	}
+
+	// The store is seeded with the genesis block and its filter header.
+	gen := genesis.Block(chaincfg.SimNetParams.GenesisHash)
+	genHash := gen.BlockHash()
+	wantFilter, err := builder.BuildBasicFilter(gen, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantHeader, err := builder.MakeHeaderForFilter(wantFilter, gen.Header.PrevBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs.putFilterToCache(&genHash, wantFilter)
+
+	filter, header, err := cs.GetBlockFilter(genHash)
+	if err != nil {
+		t.Fatalf("unable to get genesis filter: %v", err)
+	}
+	gotBytes, err := filter.NBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantBytes, err := wantFilter.NBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(gotBytes, wantBytes) {
+		t.Fatalf("got filter %x, want %x", gotBytes, wantBytes)
+	}
+	if *header != wantHeader {
+		t.Fatalf("got filter header %v, want %v", header, wantHeader)
+	}
+
+	// A block header without a filter header is not synced.
+	next := wire.BlockHeader{
+		PrevBlock: genHash,
+		Timestamp: gen.Header.Timestamp.Add(time.Minute),
+	}
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) er.R {
+		return store.WriteBlockHeaders(tx, headerfs.BlockHeader{
+			BlockHeader: &next,
+			Height:      1,
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to write block header: %v", err)
+	}
+	if _, _, err := cs.GetBlockFilter(next.BlockHash()); !ErrFilterNotSynced.Is(err) {
+		t.Fatalf("got error %v for an unsynced block, want "+
+			"ErrFilterNotSynced", err)
+	}
+	if _, _, err := cs.GetBlockFilter(chainhash.Hash{1}); err == nil {
+		t.Fatalf("got filter for an unknown block")
+	}
+}
+
+// fakeTxPeer records the messages pushed to it.
+type fakeTxPeer struct {
+	addr string
+	sent []wire.Message
+}
+
+func (p *fakeTxPeer) Addr() string { return p.addr }
+
+func (p *fakeTxPeer) QueueMessageWithEncoding(msg wire.Message,
+	doneChan chan<- struct{}, encoding wire.MessageEncoding) {
+
+	p.sent = append(p.sent, msg)
+}
+
+// TestPushTx checks that a transaction is pushed to the configured number of
+// peers, never to the skipped sync peer, and to all of them when there are too
+// few.
+func TestPushTx(t *testing.T) {
+	tx := wire.NewMsgTx(1)
+	tx.AddTxOut(wire.NewTxOut(1e8, []byte{0x51}))
+
+	newPeers := func(n int) ([]txPeer, []*fakeTxPeer) {
+		var peers []txPeer
+		var fakes []*fakeTxPeer
+		for i := 0; i < n; i++ {
+			p := &fakeTxPeer{addr: fmt.Sprintf("10.0.0.%d:64764", i)}
+			peers = append(peers, p)
+			fakes = append(fakes, p)
+		}
+		return peers, fakes
+	}
+
+	tests := []struct {
+		peers, n, want int
+	}{
+		{peers: 8, n: 3, want: 3},
+		{peers: 8, n: 0, want: 0},
+		{peers: 3, n: 5, want: 2},
+	}
+	for _, test := range tests {
+		peers, fakes := newPeers(test.peers)
+		skip := fakes[0].addr
+		pushed := pushTx(tx, peers, skip, test.n, wire.WitnessEncoding)
+		if pushed != test.want {
+			t.Fatalf("pushed to %d of %d peers with n=%d, want %d",
+				pushed, test.peers, test.n, test.want)
+		}
+		got := 0
+		for _, p := range fakes {
+			switch len(p.sent) {
+			case 0:
+			case 1:
+				if p.addr == skip {
+					t.Fatalf("tx was pushed to the skipped peer")
+				}
+				if p.sent[0] != tx {
+					t.Fatalf("peer %s was sent %v", p.addr, p.sent[0])
+				}
+				got++
+			default:
+				t.Fatalf("peer %s was sent %d messages", p.addr,
+					len(p.sent))
+			}
+		}
+		if got != test.want {
+			t.Fatalf("%d peers were sent the tx, want %d", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package neutrino
+
+import "sync"
+
+// syncGate pauses the sync of block headers and filter headers.  The zero
+// value is not paused.
+type syncGate struct {
+	mtx sync.Mutex
+
+	// resumed is closed when the sync is resumed, it is nil when the sync
+	// is not paused.
+	resumed chan struct{}
+}
+
+// Pause pauses the sync, returning false if it was already paused.
+func (g *syncGate) Pause() bool {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if g.resumed != nil {
+		return false
+	}
+	g.resumed = make(chan struct{})
+	return true
+}
+
+// Resume resumes the sync, returning false if it was not paused.
+func (g *syncGate) Resume() bool {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if g.resumed == nil {
+		return false
+	}
+	close(g.resumed)
+	g.resumed = nil
+	return true
+}
+
+// Paused returns whether the sync is paused.
+func (g *syncGate) Paused() bool {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return g.resumed != nil
+}
+
+// wait blocks while the sync is paused.  It returns false if quit is closed
+// first.
+func (g *syncGate) wait(quit <-chan struct{}) bool {
+	g.mtx.Lock()
+	resumed := g.resumed
+	g.mtx.Unlock()
+	if resumed == nil {
+		return true
+	}
+	select {
+	case <-resumed:
+		return true
+	case <-quit:
+		return false
+	}
+}
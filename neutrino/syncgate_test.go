@@ -0,0 +1,163 @@
+package neutrino
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/blockchain"
+	"github.com/pkt-cash/pktd/peer"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestSyncGate syncs a fake chain, a block per tick, through a syncGate and
+// checks that pausing halts the height and resuming continues it.
+func TestSyncGate(t *testing.T) {
+	var gate syncGate
+	var height int32
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if !gate.wait(quit) {
+				return
+			}
+			atomic.AddInt32(&height, 1)
+			select {
+			case <-time.After(time.Millisecond):
+			case <-quit:
+				return
+			}
+		}
+	}()
+	defer func() {
+		close(quit)
+		<-done
+	}()
+
+	waitForHeight := func(h int32) {
+		deadline := time.After(5 * time.Second)
+		for atomic.LoadInt32(&height) < h {
+			select {
+			case <-deadline:
+				t.Fatalf("height did not reach %d", h)
+			case <-time.After(time.Millisecond):
+			}
+		}
+	}
+	waitForHeight(5)
+
+	if !gate.Pause() || gate.Pause() || !gate.Paused() {
+		t.Fatalf("sync was not paused once")
+	}
+	// A block which was being synced when the sync was paused may still
+	// be added.
+	time.Sleep(10 * time.Millisecond)
+	paused := atomic.LoadInt32(&height)
+	time.Sleep(50 * time.Millisecond)
+	if h := atomic.LoadInt32(&height); h != paused {
+		t.Fatalf("height advanced from %d to %d while paused", paused, h)
+	}
+
+	if !gate.Resume() || gate.Resume() || gate.Paused() {
+		t.Fatalf("sync was not resumed once")
+	}
+	waitForHeight(paused + 5)
+}
+
+// TestBlockManagerPauseSync feeds a fake chain of headers to a running block
+// manager and checks that its height does not advance while the sync is
+// paused and does once it is resumed.
+func TestBlockManagerPauseSync(t *testing.T) {
+	bm, store, cleanUp, err := setupBlockManager()
+	if err != nil {
+		t.Fatalf("unable to set up ChainService: %v", err)
+	}
+	defer cleanUp()
+	bm.server.timeSource = blockchain.NewMedianTime()
+
+	// The seed and the height of the peer are such that no PacketCrypt
+	// proofs are requested for the headers.
+	bm.randomVerificationSeed = 0
+	sp := newServerPeer(bm.server, false)
+	sp.Peer, err = peer.NewOutboundPeer(newPeerConfig(sp), "127.0.0.1:8333")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp.UpdateLastBlockHeight(bm.likelyChainTip)
+
+	bm.wg.Add(1)
+	go bm.blockHandler()
+	defer func() {
+		close(bm.quit)
+		bm.wg.Wait()
+	}()
+
+	// Mine headers on top of the genesis block.
+	prev, _, err := store.BlockChainTip()
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := blockchain.CompactToBig(bm.server.chainParams.PowLimitBits)
+	var chain []*wire.BlockHeader
+	for i := 0; i < 10; i++ {
+		header := &wire.BlockHeader{
+			Version:   1,
+			PrevBlock: prev.BlockHash(),
+			Timestamp: prev.Timestamp.Add(time.Minute),
+			Bits:      bm.server.chainParams.PowLimitBits,
+		}
+		for {
+			hash := header.BlockHash()
+			if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+				break
+			}
+			header.Nonce++
+		}
+		chain = append(chain, header)
+		prev = header
+	}
+	sendHeaders := func(headers []*wire.BlockHeader) {
+		msg := wire.NewMsgHeaders()
+		for _, header := range headers {
+			msg.AddBlockHeader(header)
+		}
+		bm.QueueHeaders(msg, sp)
+	}
+	height := func() uint32 {
+		_, h, err := store.BlockChainTip()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	waitForHeight := func(h uint32) {
+		deadline := time.After(5 * time.Second)
+		for height() < h {
+			select {
+			case <-deadline:
+				t.Fatalf("height is %d, did not reach %d", height(), h)
+			case <-time.After(time.Millisecond):
+			}
+		}
+	}
+
+	sendHeaders(chain[:5])
+	waitForHeight(5)
+
+	if !bm.PauseSync() {
+		t.Fatalf("sync was not paused")
+	}
+	sendHeaders(chain[5:])
+	time.Sleep(50 * time.Millisecond)
+	if h := height(); h != 5 {
+		t.Fatalf("height advanced to %d while paused", h)
+	}
+
+	if !bm.ResumeSync() {
+		t.Fatalf("sync was not resumed")
+	}
+	sendHeaders(chain[5:])
+	waitForHeight(10)
+}
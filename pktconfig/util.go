@@ -8,6 +8,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -101,3 +102,33 @@ func ReadUserPass(filePath string) ([]string, er.R) {
 		return []string{up[0], up[1]}, nil
 	}
 }
+
+type rpcListeners struct {
+	RPCListeners []string `long:"rpclisten"`
+}
+
+// ReadRPCPort reads out the port on which pktd listens for RPC connections
+// from localhost from a config file, returning the empty string if the file
+// does not exist or does not give one.
+func ReadRPCPort(filePath string) (string, er.R) {
+	cfg := rpcListeners{}
+	parser := flags.NewParser(&cfg, flags.IgnoreUnknown)
+	if errr := flags.NewIniParser(parser).ParseFile(filePath); errr != nil {
+		if _, ok := errr.(*os.PathError); ok {
+			return "", nil
+		}
+		return "", er.E(errr)
+	}
+	for _, listener := range cfg.RPCListeners {
+		host, port, errr := net.SplitHostPort(listener)
+		if errr != nil || port == "" {
+			// No port, pktd listens on the default port
+			continue
+		}
+		switch host {
+		case "", "0.0.0.0", "::", "localhost", "127.0.0.1", "::1":
+			return port, nil
+		}
+	}
+	return "", nil
+}
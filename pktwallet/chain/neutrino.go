@@ -22,6 +22,10 @@ type NeutrinoClient struct {
 	CS          *neutrino.ChainService
 	stop        chan struct{}
 	chainParams *chaincfg.Params
+
+	// PruneRescan causes blocks which are fetched while filtering blocks
+	// for a rescan to be discarded rather than kept in the block cache.
+	PruneRescan bool
 }
 
 // NewNeutrinoClient creates a new NeutrinoClient struct with a backing
@@ -176,7 +180,13 @@ func (s *NeutrinoClient) FilterBlocks(
 
 		log.Tracef("Fetching block height=%d hash=%v", blk.Height, blk.Hash)
 
-		block, err := s.CS.GetBlock(blk.Hash, neutrino.Encoding(wire.BaseEncoding))
+		queryOptions := []neutrino.QueryOption{
+			neutrino.Encoding(wire.BaseEncoding),
+		}
+		if s.PruneRescan {
+			queryOptions = append(queryOptions, neutrino.NoBlockCache())
+		}
+		block, err := s.CS.GetBlock(blk.Hash, queryOptions...)
 		if err != nil {
 			return nil, err
 		}
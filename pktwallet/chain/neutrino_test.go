@@ -0,0 +1,166 @@
+package chain_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/gcs/builder"
+	"github.com/pkt-cash/pktd/chaincfg"
+	"github.com/pkt-cash/pktd/chaincfg/genesis"
+	"github.com/pkt-cash/pktd/neutrino"
+	"github.com/pkt-cash/pktd/neutrino/cache"
+	"github.com/pkt-cash/pktd/pktwallet/chain"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	_ "github.com/pkt-cash/pktd/pktwallet/walletdb/bdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/wire"
+	"github.com/pkt-cash/pktd/wire/protocol"
+)
+
+// serveBlock accepts connections on l from peers which are served block when
+// they ask for it and otherwise ignored.
+func serveBlock(l net.Listener, block *wire.MsgBlock) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go serveBlockConn(conn, block)
+	}
+}
+
+// serveBlockConn answers the version handshake of the peer on conn as a full
+// node serving compact filters and then sends it block when it asks for it.
+func serveBlockConn(conn net.Conn, block *wire.MsgBlock) {
+	defer conn.Close()
+	btcnet := chaincfg.SimNetParams.Net
+	pver := protocol.ProtocolVersion
+	hash := block.BlockHash()
+
+	services := protocol.SFNodeNetwork | protocol.SFNodeWitness |
+		protocol.SFNodeCF
+	me := wire.NewNetAddress(conn.LocalAddr().(*net.TCPAddr), services)
+	you := wire.NewNetAddress(conn.RemoteAddr().(*net.TCPAddr), 0)
+	version := wire.NewMsgVersion(me, you, 1, 0)
+	version.Services = services
+	for _, msg := range []wire.Message{version, wire.NewMsgVerAck()} {
+		if err := wire.WriteMessage(conn, msg, pver, btcnet); err != nil {
+			return
+		}
+	}
+	for {
+		msg, _, err := wire.ReadMessage(conn, pver, btcnet)
+		if err != nil {
+			return
+		}
+		getData, ok := msg.(*wire.MsgGetData)
+		if !ok {
+			continue
+		}
+		for _, iv := range getData.InvList {
+			if iv.Hash != hash {
+				continue
+			}
+			if err := wire.WriteMessage(conn, block, pver, btcnet); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// TestFilterBlocksPruneRescan runs FilterBlocks against a node serving the
+// genesis block and checks that the block which it fetches is only kept in
+// the block cache when PruneRescan is not set.
+func TestFilterBlocksPruneRescan(t *testing.T) {
+	l, errr := net.Listen("tcp", "127.0.0.1:0")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer l.Close()
+	gen := genesis.Block(chaincfg.SimNetParams.GenesisHash)
+	go serveBlock(l, gen)
+
+	tempDir, errr := ioutil.TempDir("", "neutrino")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer os.RemoveAll(tempDir)
+	db, err := walletdb.Create("bdb", filepath.Join(tempDir, "neutrino.db"), true)
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+	defer db.Close()
+	cs, err := neutrino.NewChainService(neutrino.Config{
+		DataDir:      tempDir,
+		Database:     db,
+		ChainParams:  chaincfg.SimNetParams,
+		ConnectPeers: []string{l.Addr().String()},
+	})
+	if err != nil {
+		t.Fatalf("unable to create chain service: %v", err)
+	}
+	if err := cs.Start(); err != nil {
+		t.Fatalf("unable to start chain service: %v", err)
+	}
+	defer cs.Stop()
+	deadline := time.Now().Add(10 * time.Second)
+	for cs.ConnectedCount() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("chain service did not connect to the node")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The filter of the genesis block is known, so only the block itself
+	// is fetched from the node.
+	filter, err := builder.BuildBasicFilter(gen, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genHash := gen.BlockHash()
+	_, err = cs.FilterCache.Put(cache.FilterCacheKey{BlockHash: genHash},
+		&cache.CacheableFilter{Filter: filter})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The genesis coinbase pays to an uncompressed pubkey.
+	pkScript := gen.Transactions[0].TxOut[0].PkScript
+	addr, err := btcutil.NewAddressPubKey(pkScript[1:66],
+		&chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &chain.FilterBlocksRequest{
+		Blocks: []wtxmgr.BlockMeta{{
+			Block: wtxmgr.Block{Hash: genHash, Height: 0},
+		}},
+		ImportedAddrs: []btcutil.Address{addr},
+	}
+
+	client := chain.NewNeutrinoClient(&chaincfg.SimNetParams, cs)
+	for _, prune := range []bool{true, false} {
+		client.PruneRescan = prune
+		resp, err := client.FilterBlocks(req)
+		if err != nil {
+			t.Fatalf("prune=%v: unable to filter blocks: %v", prune, err)
+		}
+		if resp == nil || len(resp.RelevantTxns) != 1 {
+			t.Fatalf("prune=%v: got response %v, want the genesis "+
+				"coinbase", prune, resp)
+		}
+		want := 1
+		if prune {
+			want = 0
+		}
+		if n := cs.BlockCache.Len(); n != want {
+			t.Fatalf("prune=%v: %d blocks are cached, want %d", prune,
+				n, want)
+		}
+	}
+}
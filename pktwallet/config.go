@@ -5,12 +5,15 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"math"
 	"net"
 	"os"
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,7 +30,13 @@ import (
 	"github.com/pkt-cash/pktd/pktwallet/internal/cfgutil"
 	"github.com/pkt-cash/pktd/pktwallet/internal/legacy/keystore"
 	"github.com/pkt-cash/pktd/pktwallet/netparams"
+	"github.com/pkt-cash/pktd/pktwallet/rpc/legacyrpc"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
 	"github.com/pkt-cash/pktd/pktwallet/wallet"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txauthor"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/wire/protocol"
 )
 
 const (
@@ -52,6 +61,7 @@ var (
 type config struct {
 	// General application behavior
 	ConfigFile    *cfgutil.ExplicitString `short:"C" long:"configfile" description:"Path to configuration file"`
+	LenientConfig bool                    `long:"lenientconfig" description:"Warn about and skip lines of the configuration file which are unknown options or cannot be parsed rather than failing to start, only read from the command line"`
 	ShowVersion   bool                    `short:"V" long:"version" description:"Display version information and exit"`
 	Create        bool                    `long:"create" description:"Create the wallet if it does not exist"`
 	CreateTemp    bool                    `long:"createtemp" description:"Create a temporary simulation wallet (pass=password) in the data directory indicated; must call with --datadir"`
@@ -62,17 +72,56 @@ type config struct {
 	BtcMainNet    bool                    `long:"btc" description:"Use the test bitcoin main network"`
 	PktMainNet    bool                    `long:"pkt" description:"Use the test pkt.cash main network"`
 	SimNet        bool                    `long:"simnet" description:"Use the simulation test network (default mainnet)"`
+	Force         bool                    `long:"force" description:"Open the wallet even if it was created for a different network than the one selected, this will likely corrupt it"`
+	WalletUpgrade bool                    `long:"walletupgrade" description:"Upgrade the wallet database if it is in an older format, after which older versions of pktwallet may not be able to open it"`
 	NoInitialLoad bool                    `long:"noinitialload" description:"Defer wallet creation/opening on startup and enable loading wallets over RPC"`
 	DebugLevel    string                  `short:"d" long:"debuglevel" description:"Logging level {trace, debug, info, warn, error, critical}"`
 	LogDir        string                  `long:"logdir" description:"Directory to log output."`
 	StatsViz      string                  `long:"statsviz" description:"Enable StatsViz runtime visualization on given port -- NOTE port must be between 1024 and 65535"`
 	Profile       string                  `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65535"`
+	HealthPort    string                  `long:"healthport" description:"Serve /healthz and /readyz health checks over HTTP on given port -- NOTE port must be between 1024 and 65535"`
 
 	// Wallet options
-	WalletPass string `long:"walletpass" default-mask:"-" description:"The public wallet password -- Only required if the wallet was created with one"`
+	WalletPass             string               `long:"walletpass" default-mask:"-" description:"The public wallet password -- Only required if the wallet was created with one"`
+	BlockNotify            string               `long:"blocknotify" description:"Execute command when a block is connected once the wallet is synced, one command at a time (%s in the command is replaced by the block hash)"`
+	SpendUnconfirmedChange bool                 `long:"spendunconfirmedchange" description:"Allow spending unconfirmed change from the wallet's own transactions"`
+	DistrustReplaceable    bool                 `long:"distrustreplaceable" description:"Do not spend or count in the unconfirmed balance any unconfirmed outputs of transactions which signal BIP125 replaceability, even with spendunconfirmedchange"`
+	MaxFeeRate             *cfgutil.FeeRateFlag `long:"maxfeerate" default-mask:"-" description:"Maximum fee rate, either in coins per kilobyte or with a unit such as 10bit/vB or 0.0001PKT/kB, higher fee rates will be reduced to this (default: no limit)"`
+	PrivacySend            bool                 `long:"privacysend" description:"Split the change of sends into an output of the same value as the payments and outputs of common denominations, so that it blends with other payments"`
+	ReportMaxSpendable     bool                 `long:"reportmaxspendable" description:"Include in insufficient funds errors the most which the outputs of the send could pay in total, the spendable balance less the fee"`
+	WarnSelfSend           bool                 `long:"warnselfsend" description:"Refuse sends which pay an address of the wallet itself unless the send sets allowselfsend, to catch pasting an own address by mistake"`
+	AutoCreateAccounts     bool                 `long:"autocreateaccounts" description:"Create accounts which are referenced by name in RPCs such as getnewaddress but do not exist, rather than returning an error"`
+	NoResumeResync         bool                 `long:"noresumeresync" description:"Do not record the progress of resyncs, a resync which is interrupted by a restart is abandoned rather than resumed from the last block it scanned"`
+	MaxConcurrentRescans   int                  `long:"maxconcurrentrescans" description:"Most wallets of this process which may resync at once, the resyncs of other wallets wait in turn for one to finish (default: 0, no limit)"`
+	RecoveryWorkers        int                  `long:"recoveryworkers" description:"Number of blocks which are scanned concurrently while recovering or resyncing the wallet"`
+	RecoveryLogInterval    time.Duration        `long:"recoveryprogressinterval" description:"How often to log the progress of recovering or resyncing the wallet, for example 1m, 0 to not log progress"`
+	MaxReorgDepth          int32                `long:"maxreorgdepth" description:"Deepest chain reorganization which the wallet will roll back, the wallet halts on deeper reorgs"`
+	TrustedConfs           int32                `long:"trustedconfs" description:"Number of confirmations at which gettransaction and listtransactions report a transaction as trusted, 0 to trust unconfirmed transactions"`
+	TxVersion              int32                `long:"txversion" description:"Version of the transactions which the wallet constructs, between 1 and 2"`
+	MinOutput              float64              `long:"minoutput" description:"Minimum amount in coins which a send may pay to an output, smaller outputs are rejected (default: only dust is rejected)"`
+	IgnoreIncomingDust     float64              `long:"ignoreincomingdust" description:"Quarantine outputs worth less than this amount in coins which others send to the wallet, they are flagged as dust in listunspent and are not spent or counted in the balance, so dust cannot be used to link the addresses of the wallet (default: 0, disabled)"`
+	MaxBumpFee             float64              `long:"maxbumpfee" description:"Maximum amount in coins which a single fee bump may add to the fee already paid, by replacement or by spending an output, larger bumps are rejected (default: no limit)"`
+	MinBumpIncrement       float64              `long:"minbumpincrement" description:"Fee rate in atomic units per virtual byte of the replacement which a fee bump by replacement must add to the fee already paid, at least the relay fee rate which BIP125 requires"`
+	AutoBumpAfter          int32                `long:"autobumpafter" description:"Replace unconfirmed wallet transactions which signal replaceability with ones paying a higher fee, up to maxbumpfee, after this many blocks (default: never), each is bumped at most once, only transactions sent with walletrbf signal it"`
+	WalletRBF              bool                 `long:"walletrbf" description:"Signal BIP125 replaceability in the transactions which the wallet sends, so that their fees can be bumped by replacement"`
+	SpendLimitAmount       float64              `long:"spendlimitamount" description:"Maximum amount in coins, including fees, which may be sent within the spend limit window (default: no limit)"`
+	SpendLimitWindow       time.Duration        `long:"spendlimitwindow" description:"Length of the rolling window in which sends are limited to spendlimitamount, for example 24h"`
+	MempoolExpiry          time.Duration        `long:"mempoolexpiry" description:"Drop unconfirmed wallet transactions which have not confirmed after this long, for example 72h, freeing the coins they spend (default: never)"`
+	KeyScopes              []string             `long:"keyscope" description:"Also derive and watch addresses under this key scope, in the form purpose/cointype such as 84/390, which is created when the wallet is next unlocked, may be repeated"`
+	MaxAddressesPerAccount int                  `long:"maxaddressesperaccount" description:"Refuse to issue more than this many receiving addresses from any one account, guarding against a buggy client using up the address indices (default: 0, no limit)"`
+	FinalityDepth          int32                `long:"finalitydepth" description:"Count coins received with fewer than this many confirmations as maturing rather than spendable in getbalance and getaddressbalances, as they may yet be undone by a reorg (default: 0, disabled)"`
+	DBFlushCommits         int                  `long:"dbflushcommits" description:"Commit up to this many wallet database updates together in one transaction rather than one transaction each, each update waits for its batch to be committed (default: 0, no batching)"`
+	DBFlushInterval        time.Duration        `long:"dbflushinterval" description:"Commit each batch of wallet database updates at most this long, for example 50ms, after its first update, batching updates if dbflushcommits is not set (default: 10ms when dbflushcommits is set)"`
+	DBLockMode             string               `long:"dblockmode" description:"How to lock the wallet database file, flock to lock it exclusively or none to open it read only without the lock, for a database on a read-only mount, nothing then stops another process writing it meanwhile"`
+	UTXOCacheSize          int                  `long:"utxocachesize" description:"Keep up to this many unspent outputs in memory for coin selection rather than reading them from the database for each transaction, wallets with more unspent outputs are not cached (default: 0, disabled)"`
+
+	// walletConfig holds the settings of the wallet, parsed from the wallet
+	// options.
+	walletConfig wallet.Config
 
 	// RPC client options
 	RPCConnect       string                  `short:"c" long:"rpcconnect" description:"Hostname/IP and port of pktd RPC server to connect to (default localhost:8334, testnet: localhost:18334, simnet: localhost:18556)"`
+	NoRPCPortDetect  bool                    `long:"norpcportdetect" description:"When rpcconnect is a localhost address without a port, always use the default port of the network rather than the port given by rpclisten in the pktd config file"`
 	CAFile           *cfgutil.ExplicitString `long:"cafile" description:"File containing root certificates to authenticate a TLS connections with pktd"`
 	DisableClientTLS bool                    `long:"noclienttls" description:"nolonger used" hidden:"true"`
 	ClientTLS        bool                    `long:"clienttls" description:"enable tls to the pktd instance"`
@@ -83,12 +132,21 @@ type config struct {
 	ProxyPass        string                  `long:"proxypass" default-mask:"-" description:"Password for proxy server"`
 
 	// SPV client options
-	UseSPV       bool          `long:"usespv" description:"Use SPV mode (default)"`
-	AddPeers     []string      `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
-	ConnectPeers []string      `long:"connect" description:"Connect only to the specified peers at startup"`
-	MaxPeers     int           `long:"maxpeers" description:"Max number of inbound and outbound peers"`
-	BanDuration  time.Duration `long:"banduration" description:"How long to ban misbehaving peers.  Valid time units are {s, m, h}.  Minimum 1 second"`
-	BanThreshold uint32        `long:"banthreshold" description:"Maximum allowed ban score before disconnecting and banning misbehaving peers."`
+	UseSPV             bool                  `long:"usespv" description:"Use SPV mode (default)"`
+	AddPeers           []string              `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
+	ConnectPeers       []string              `long:"connect" description:"Connect only to the specified peers at startup"`
+	MaxPeers           int                   `long:"maxpeers" description:"Max number of inbound and outbound peers"`
+	MaxInbound         int                   `long:"maxinbound" description:"Max number of inbound peers, 0 for no limit other than maxpeers (default: half of maxpeers)"`
+	MaxOutbound        int                   `long:"maxoutbound" description:"Max number of outbound peers, 0 for no limit other than maxpeers (default: half of maxpeers)"`
+	BroadcastPeers     int                   `long:"broadcastpeers" description:"Number of connected peers a new transaction is sent to at once, at most maxpeers"`
+	MinProtocolVersion uint32                `long:"minprotocolversion" description:"Disconnect peers advertising a protocol version lower than this, which must be a known protocol version"`
+	BanDuration        time.Duration         `long:"banduration" description:"How long to ban misbehaving peers.  Valid time units are {s, m, h}.  Minimum 1 second"`
+	BanThreshold       uint32                `long:"banthreshold" description:"Maximum allowed ban score before disconnecting and banning misbehaving peers."`
+	PruneRescan        bool                  `long:"prunerescan" description:"Discard blocks fetched during a rescan after scanning them rather than caching them"`
+	DNSSeeds           []string              `long:"dnsseed" description:"Use this DNS seed for peer discovery instead of the network's default seeds, may be repeated"`
+	NoDNSSeed          bool                  `long:"nodnsseed" description:"Disable DNS peer discovery, peers must be given with addpeer or connect"`
+	NoPersistPeers     bool                  `long:"nopersistpeers" description:"Do not save the addresses of discovered peers to peers.json in the network directory on shutdown nor load them at startup"`
+	NetMagic           *cfgutil.NetMagicFlag `long:"netmagic" default-mask:"-" description:"Use this network magic, 8 hex digits such as 0xd9b4bef9, instead of the network's own so that only peers of a private network using the same magic are connected, not allowed with simnet (default: the network's magic)"`
 
 	// RPC server options
 	//
@@ -102,12 +160,27 @@ type config struct {
 	RPCCert                *cfgutil.ExplicitString `long:"rpccert" description:"File containing the certificate file"`
 	RPCKey                 *cfgutil.ExplicitString `long:"rpckey" description:"File containing the certificate key"`
 	OneTimeTLSKey          bool                    `long:"onetimetlskey" description:"Generate a new TLS certpair at startup, but only write the certificate to disk"`
+	TLSExtraHosts          []string                `long:"tlsextrahost" description:"Add a hostname or IP address to those of the autogenerated TLS certificate, may be repeated"`
 	DisableServerTLS       bool                    `long:"noservertls" description:"Disable TLS for the RPC server"`
 	LegacyRPCListeners     []string                `long:"rpclisten" description:"Listen for legacy RPC connections on this interface/port (default port: 8332, testnet: 18332, simnet: 18554)"`
 	LegacyRPCMaxClients    int64                   `long:"rpcmaxclients" description:"Max number of legacy RPC clients for standard connections"`
 	LegacyRPCMaxWebsockets int64                   `long:"rpcmaxwebsockets" description:"Max number of legacy RPC websocket connections"`
+	LegacyRPCCompress      bool                    `long:"rpccompress" description:"Gzip compress legacy RPC responses for HTTP clients which accept it, websocket connections are unaffected"`
+	WaitForSync            bool                    `long:"waitforsync" description:"Answer legacy RPC balance queries with a syncing error until the wallet has synced to the tip of the chain"`
+	Maintenance            bool                    `long:"maintenance" description:"Start in maintenance mode, refusing legacy RPCs which move funds until it is disabled with setmaintenancemode"`
 	Username               string                  `short:"u" long:"rpcuser" description:"Username for legacy RPC and pktd authentication (if pktdusername is unset)"`
 	Password               string                  `short:"P" long:"rpcpass" default-mask:"-" description:"Password for legacy RPC and pktd authentication (if pktdpassword is unset)"`
+	RPCAuth                []string                `long:"rpcauth" default-mask:"-" description:"Hashed legacy RPC credential in the form user:salt:hash where hash is the hex HMAC-SHA256 of the password keyed with the salt, may be repeated"`
+	RPCMethodTimeout       []string                `long:"rpcmethodtimeout" description:"Time limit for a legacy RPC method in the form method=duration such as resync=10m, requests which take longer are answered with a timeout error, methods which move funds cannot be limited, may be repeated"`
+	RPCAccessLog           string                  `long:"rpcaccesslog" description:"Log each legacy RPC call as a line of JSON, with passphrases and other secrets redacted, to this file or to the main log if set to 'log'"`
+	NotifyQueueSize        int                     `long:"notifyqueuesize" description:"Max number of notifications queued for a legacy RPC websocket client which is slow to read them"`
+	NotifyQueueOverflow    string                  `long:"notifyqueueoverflow" description:"What to do when the notification queue of a websocket client is full, drop to drop the oldest notification or disconnect to disconnect the client"`
+
+	// rpcMethodTimeouts is parsed from RPCMethodTimeout.
+	rpcMethodTimeouts map[string]time.Duration
+
+	// notifyOverflow is parsed from NotifyQueueOverflow.
+	notifyOverflow legacyrpc.NotifyOverflow
 
 	// These exist because btcwallet took it upon themselves to specify a username and password differently from btcd
 	// in case any of these are existing in the wild, they'll be accepted.
@@ -192,6 +265,86 @@ func validLogLevel(logLevel string) bool {
 	return false
 }
 
+// rpcConnectPort returns the port to connect to pktd at rpcConnect on when it
+// does not give one.  If rpcConnect is a localhost address and pktd is
+// configured in pktdConf to listen for RPC connections on a non-standard port
+// then that port is used, otherwise defaultPort.
+func rpcConnectPort(rpcConnect, pktdConf, defaultPort string) (string, er.R) {
+	if _, _, errr := net.SplitHostPort(rpcConnect); errr == nil {
+		return defaultPort, nil
+	}
+	host := strings.TrimSuffix(strings.TrimPrefix(rpcConnect, "["), "]")
+	switch host {
+	case "localhost", "127.0.0.1", "::1":
+	default:
+		return defaultPort, nil
+	}
+	port, err := pktconfig.ReadRPCPort(pktdConf)
+	if err != nil {
+		return "", err
+	}
+	if port == "" {
+		return defaultPort, nil
+	}
+	return port, nil
+}
+
+// parseConfigFile parses the config file at path into the options of parser.
+// If lenient is set the file is parsed one line at a time and the lines which
+// fail to parse, such as unknown options or malformed values, are skipped
+// with a warning for each rather than failing the whole file.
+func parseConfigFile(parser *flags.Parser, path string, lenient bool) ([]string, error) {
+	if !lenient {
+		return nil, flags.NewIniParser(parser).ParseFile(path)
+	}
+	f, errr := os.Open(path)
+	if errr != nil {
+		return nil, errr
+	}
+	defer f.Close()
+
+	var warnings []string
+	skip := func(lineno int, msg string) {
+		warnings = append(warnings, fmt.Sprintf("Skipping line %d of config "+
+			"file %s: %s", lineno, path, msg))
+	}
+	message := func(errr error) string {
+		if ie, ok := errr.(*flags.IniError); ok {
+			return ie.Message
+		}
+		return errr.Error()
+	}
+	section := ""
+	badSection := false
+	scanner := bufio.NewScanner(f)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			// The options of a section which cannot be parsed are
+			// skipped too, they may not belong to this program.
+			errr := flags.NewIniParser(parser).Parse(strings.NewReader(line))
+			badSection = errr != nil
+			if badSection {
+				skip(lineno, message(errr))
+			}
+			section = line
+			continue
+		}
+		if badSection {
+			skip(lineno, "option of section "+section)
+			continue
+		}
+		errr := flags.NewIniParser(parser).Parse(strings.NewReader(section + "\n" + line))
+		if errr != nil {
+			skip(lineno, message(errr))
+		}
+	}
+	return warnings, scanner.Err()
+}
+
 // loadConfig initializes and parses the config using a config file and command
 // line options.
 //
@@ -206,6 +359,7 @@ func validLogLevel(logLevel string) bool {
 // and command line options.  Command line options always take precedence.
 func loadConfig() (*config, []string, er.R) {
 	// Default config.
+	walletDefaults := wallet.DefaultConfig()
 	cfg := config{
 		DebugLevel:             defaultLogLevel,
 		Wallet:                 "wallet.db",
@@ -218,14 +372,27 @@ func loadConfig() (*config, []string, er.R) {
 		RPCCert:                cfgutil.NewExplicitString(defaultRPCCertFile),
 		LegacyRPCMaxClients:    defaultRPCMaxClients,
 		LegacyRPCMaxWebsockets: defaultRPCMaxWebsockets,
+		NotifyQueueSize:        legacyrpc.DefaultNotifyQueueSize,
+		NotifyQueueOverflow:    "drop",
+		DBLockMode:             string(walletDefaults.DBLockMode),
 		DataDir:                cfgutil.NewExplicitString(defaultAppDataDir),
 		UseSPV:                 false,
 		UseRPC:                 false,
 		AddPeers:               []string{},
 		ConnectPeers:           []string{},
 		MaxPeers:               neutrino.MaxPeers,
+		BroadcastPeers:         1,
+		MinProtocolVersion:     neutrino.MinProtocolVersion,
 		BanDuration:            neutrino.BanDuration,
 		BanThreshold:           neutrino.BanThreshold,
+		RecoveryWorkers:        walletDefaults.RecoveryWorkers,
+		MinBumpIncrement:       float64(walletDefaults.MinBumpIncrement) / 1000,
+		RecoveryLogInterval:    walletDefaults.RecoveryProgressInterval,
+		MaxReorgDepth:          walletDefaults.MaxReorgDepth,
+		TrustedConfs:           walletDefaults.TrustedConfs,
+		TxVersion:              walletDefaults.TxVersion,
+		MaxFeeRate:             cfgutil.NewFeeRateFlag("0"),
+		NetMagic:               cfgutil.NewNetMagicFlag(),
 	}
 
 	// Pre-parse the command line options to see if an alternative config
@@ -250,6 +417,7 @@ func loadConfig() (*config, []string, er.R) {
 
 	// Load additional config from file.
 	var configFileError er.R
+	var configFileWarnings []string
 	parser := flags.NewParser(&cfg, flags.Default)
 	configFilePath := preCfg.ConfigFile.Value
 	if preCfg.ConfigFile.ExplicitlySet() {
@@ -280,7 +448,8 @@ func loadConfig() (*config, []string, er.R) {
 		cfg.BtcdPassword = userpass[1]
 	}
 
-	if errr := flags.NewIniParser(parser).ParseFile(configFilePath); errr != nil {
+	configFileWarnings, errr = parseConfigFile(parser, configFilePath, preCfg.LenientConfig)
+	if errr != nil {
 		if _, ok := errr.(*os.PathError); !ok {
 			fmt.Fprintln(os.Stderr, errr)
 			parser.WriteHelp(os.Stderr)
@@ -291,7 +460,9 @@ func loadConfig() (*config, []string, er.R) {
 		if configFileError = pktconfig.CreateDefaultConfigFile(
 			configFilePath, pktconfig.PktwalletSampleConfig); configFileError != nil {
 		} else {
-			configFileError = er.E(flags.NewIniParser(parser).ParseFile(configFilePath))
+			configFileWarnings, errr = parseConfigFile(parser, configFilePath,
+				preCfg.LenientConfig)
+			configFileError = er.E(errr)
 		}
 	}
 
@@ -359,6 +530,16 @@ func loadConfig() (*config, []string, er.R) {
 		return nil, nil, err
 	}
 
+	// Simnet is run against a local pktd, there is no private network to
+	// keep its peers apart from.
+	if cfg.SimNet && cfg.NetMagic.IsSet() {
+		err := er.Errorf("%s: The netmagic option can't be used with "+
+			"simnet", "loadConfig")
+		fmt.Fprintln(os.Stderr, err)
+		parser.WriteHelp(os.Stderr)
+		return nil, nil, err
+	}
+
 	// TODO(cjd): this is trash, but CompactToBig is a util function and it shouldn't
 	// be in blockchain, but it is, and trying to call it from cfg is a dependency
 	// loop. And duplicating the powlimit twice in the config is also trash...
@@ -470,24 +651,302 @@ func loadConfig() (*config, []string, er.R) {
 		return nil, nil, err
 	}
 
+	// Validate health check port number
+	if cfg.HealthPort != "" {
+		healthPort, errr := strconv.Atoi(cfg.HealthPort)
+		if errr != nil || healthPort < 1024 || healthPort > 65535 {
+			err := er.New("The health check port must be between 1024 and 65535")
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+	}
+
+	wcfg := &cfg.walletConfig
+	*wcfg = walletDefaults
+	wcfg.BlockNotify = cfg.BlockNotify
+	wcfg.SpendUnconfirmedChange = cfg.SpendUnconfirmedChange
+	wcfg.DistrustReplaceable = cfg.DistrustReplaceable
+	wcfg.ReportMaxSpendable = cfg.ReportMaxSpendable
+	wcfg.PrivacySend = cfg.PrivacySend
+	wcfg.ResumeRescan = !cfg.NoResumeResync
+	wcfg.IgnoreNetworkMismatch = cfg.Force
+	wcfg.AllowUpgrade = cfg.WalletUpgrade
+
+	if cfg.MempoolExpiry < 0 {
+		err := er.Errorf("The mempoolexpiry option may not be negative: %v",
+			cfg.MempoolExpiry)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.MempoolExpiry = cfg.MempoolExpiry
+
+	if cfg.MaxAddressesPerAccount < 0 ||
+		int64(cfg.MaxAddressesPerAccount) > math.MaxUint32 {
+		err := er.Errorf("The maxaddressesperaccount option must be "+
+			"positive: %d", cfg.MaxAddressesPerAccount)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.MaxAddressesPerAccount = uint32(cfg.MaxAddressesPerAccount)
+
+	if cfg.FinalityDepth < 0 {
+		err := er.Errorf("The finalitydepth option may not be negative: %d",
+			cfg.FinalityDepth)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.FinalityDepth = cfg.FinalityDepth
+
+	if cfg.DBFlushCommits < 0 || cfg.DBFlushInterval < 0 {
+		err := er.Errorf("The dbflushcommits and dbflushinterval options "+
+			"may not be negative: %v, %v", cfg.DBFlushCommits,
+			cfg.DBFlushInterval)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.DBFlushCommits = cfg.DBFlushCommits
+	wcfg.DBFlushInterval = cfg.DBFlushInterval
+
+	switch mode := walletdb.LockMode(cfg.DBLockMode); mode {
+	case walletdb.LockFlock, walletdb.LockNone:
+		wcfg.DBLockMode = mode
+	default:
+		err := er.Errorf("The dblockmode option must be flock or none: %s",
+			cfg.DBLockMode)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+
+	if cfg.UTXOCacheSize < 0 {
+		err := er.Errorf("The utxocachesize option may not be negative: %d",
+			cfg.UTXOCacheSize)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.UTXOCacheSize = cfg.UTXOCacheSize
+
+	if cfg.MaxConcurrentRescans < 0 {
+		err := er.Errorf("The maxconcurrentrescans option may not be negative: %d",
+			cfg.MaxConcurrentRescans)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.MaxConcurrentRescans = cfg.MaxConcurrentRescans
+	wcfg.WarnSelfSend = cfg.WarnSelfSend
+	wcfg.AutoCreateAccounts = cfg.AutoCreateAccounts
+
+	for _, s := range cfg.KeyScopes {
+		scope, err := waddrmgr.ParseKeyScope(s)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+		if _, ok := waddrmgr.ScopeAddrMap[scope]; ok {
+			err := er.Errorf("The keyscope option may not be given "+
+				"the built-in key scope %s", scope.String())
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+		wcfg.ExtraKeyScopes = append(wcfg.ExtraKeyScopes, scope)
+	}
+
+	maxFeeRate, err := cfg.MaxFeeRate.FeeRate()
+	if err == nil && maxFeeRate < 0 {
+		err = er.New("value may not be negative")
+	} else if err == nil && maxFeeRate == 0 {
+		if q, _, _, _ := txrules.SplitFeeRate(cfg.MaxFeeRate.Value); q > 0 {
+			err = er.New("value is smaller than one atomic unit per kilobyte")
+		}
+	}
+	if err != nil {
+		err = er.Errorf("Invalid maxfeerate: %v", err)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.MaxFeeRate = maxFeeRate
+
+	if cfg.RecoveryWorkers < 1 {
+		err := er.Errorf("The recoveryworkers option must be at least 1: %v",
+			cfg.RecoveryWorkers)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.RecoveryWorkers = cfg.RecoveryWorkers
+
+	if cfg.RecoveryLogInterval < 0 {
+		err := er.Errorf("The recoveryprogressinterval option may not be "+
+			"negative: %v", cfg.RecoveryLogInterval)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.RecoveryProgressInterval = cfg.RecoveryLogInterval
+
+	if cfg.MaxReorgDepth < 1 || cfg.MaxReorgDepth > waddrmgr.MaxReorgDepth {
+		err := er.Errorf("The maxreorgdepth option must be between 1 and "+
+			"%d: %v", waddrmgr.MaxReorgDepth, cfg.MaxReorgDepth)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.MaxReorgDepth = cfg.MaxReorgDepth
+
+	if cfg.TrustedConfs < 0 {
+		err := er.Errorf("The trustedconfs option must not be negative: %v",
+			cfg.TrustedConfs)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.TrustedConfs = cfg.TrustedConfs
+
+	if cfg.TxVersion < 1 || cfg.TxVersion > txauthor.MaxTxVersion {
+		err := er.Errorf("The txversion option must be between 1 and %d: %v",
+			txauthor.MaxTxVersion, cfg.TxVersion)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.TxVersion = cfg.TxVersion
+
+	if cfg.MinOutput < 0 {
+		err := er.Errorf("The minoutput option must not be negative: %v",
+			cfg.MinOutput)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	minOutput, err := btcutil.NewAmount(cfg.MinOutput)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.MinOutput = minOutput
+
+	if cfg.IgnoreIncomingDust < 0 {
+		err := er.Errorf("The ignoreincomingdust option must not be negative: %v",
+			cfg.IgnoreIncomingDust)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	ignoreIncomingDust, err := btcutil.NewAmount(cfg.IgnoreIncomingDust)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.IgnoreIncomingDust = ignoreIncomingDust
+
+	if cfg.MaxBumpFee < 0 {
+		err := er.Errorf("The maxbumpfee option must not be negative: %v",
+			cfg.MaxBumpFee)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	maxBumpFee, err := btcutil.NewAmount(cfg.MaxBumpFee)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.MaxBumpFee = maxBumpFee
+
+	minBumpIncrement := btcutil.Amount(math.Round(cfg.MinBumpIncrement * 1000))
+	if minBumpIncrement < txrules.DefaultRelayFeePerKb {
+		err := er.Errorf("The minbumpincrement option must be at least "+
+			"the relay fee rate of %v per virtual byte: %v",
+			float64(txrules.DefaultRelayFeePerKb)/1000, cfg.MinBumpIncrement)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.MinBumpIncrement = minBumpIncrement
+
+	if cfg.AutoBumpAfter < 0 {
+		err := er.Errorf("The autobumpafter option must not be negative: %v",
+			cfg.AutoBumpAfter)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	wcfg.AutoBumpAfter = cfg.AutoBumpAfter
+	wcfg.SignalReplacement = cfg.WalletRBF
+
+	if cfg.SpendLimitAmount < 0 || cfg.SpendLimitWindow < 0 {
+		err := er.Errorf("The spendlimitamount and spendlimitwindow options "+
+			"must not be negative: %v %v", cfg.SpendLimitAmount,
+			cfg.SpendLimitWindow)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	} else if (cfg.SpendLimitAmount > 0) != (cfg.SpendLimitWindow > 0) {
+		err := er.New("The spendlimitamount and spendlimitwindow options " +
+			"must be used together")
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	} else if cfg.SpendLimitAmount > 0 {
+		spendLimit, err := btcutil.NewAmount(cfg.SpendLimitAmount)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+		wcfg.SpendLimitAmount = spendLimit
+		wcfg.SpendLimitWindow = cfg.SpendLimitWindow
+	}
+
 	localhostListeners := map[string]struct{}{
 		"localhost": {},
 		"127.0.0.1": {},
 		"::1":       {},
 	}
 
+	if cfg.NoDNSSeed && len(cfg.DNSSeeds) > 0 {
+		err := er.New("The dnsseed and nodnsseed options may not be " +
+			"used together")
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+
 	if !cfg.UseRPC {
+		// Unless they are given, the inbound and outbound peer limits
+		// split maxpeers between them.
+		if !parser.FindOptionByLongName("maxoutbound").IsSet() {
+			cfg.MaxOutbound = (cfg.MaxPeers + 1) / 2
+		}
+		if !parser.FindOptionByLongName("maxinbound").IsSet() {
+			cfg.MaxInbound = cfg.MaxPeers - (cfg.MaxPeers+1)/2
+		}
+		if cfg.MaxInbound < 0 || cfg.MaxOutbound < 0 {
+			err := er.New("The maxinbound and maxoutbound options may " +
+				"not be negative")
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+		if cfg.BroadcastPeers < 1 || cfg.BroadcastPeers > cfg.MaxPeers {
+			err := er.Errorf("The broadcastpeers option must be between "+
+				"1 and maxpeers (%d)", cfg.MaxPeers)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+		if !protocol.IsKnownVersion(cfg.MinProtocolVersion) {
+			err := er.Errorf("The minprotocolversion option must be a "+
+				"known protocol version, at most %d",
+				protocol.ProtocolVersion)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
 		neutrino.MaxPeers = cfg.MaxPeers
+		neutrino.MinProtocolVersion = cfg.MinProtocolVersion
 		neutrino.BanDuration = cfg.BanDuration
 		neutrino.BanThreshold = cfg.BanThreshold
 	} else {
 		if cfg.RPCConnect == "" {
-			cfg.RPCConnect = net.JoinHostPort("localhost", activeNet.RPCClientPort)
+			cfg.RPCConnect = "localhost"
 		}
 
-		// Add default port to connect flag if missing.
-		cfg.RPCConnect, err = cfgutil.NormalizeAddress(cfg.RPCConnect,
-			activeNet.RPCClientPort)
+		// Add default port to connect flag if missing, or the port which
+		// a local pktd is configured to listen on.
+		rpcPort := activeNet.RPCClientPort
+		if !cfg.NoRPCPortDetect {
+			rpcPort, err = rpcConnectPort(cfg.RPCConnect, pktdDefaultConf,
+				rpcPort)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Unable to read the RPC port "+
+					"from %s: %v\n", pktdDefaultConf, err)
+				return nil, nil, err
+			}
+		}
+		cfg.RPCConnect, err = cfgutil.NormalizeAddress(cfg.RPCConnect, rpcPort)
 		if err != nil {
 			fmt.Fprintf(os.Stderr,
 				"Invalid rpcconnect network address: %v\n", err)
@@ -579,10 +1038,35 @@ func loadConfig() (*config, []string, er.R) {
 		}
 	}
 
+	cfg.rpcMethodTimeouts = make(map[string]time.Duration, len(cfg.RPCMethodTimeout))
+	for _, s := range cfg.RPCMethodTimeout {
+		method, timeout, err := legacyrpc.ParseMethodTimeout(s)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+		cfg.rpcMethodTimeouts[method] = timeout
+	}
+
+	if cfg.NotifyQueueSize < 1 {
+		err := er.Errorf("notifyqueuesize [%d] must be at least 1",
+			cfg.NotifyQueueSize)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	cfg.notifyOverflow, err = legacyrpc.ParseNotifyOverflow(cfg.NotifyQueueOverflow)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+
 	// Expand environment variable and leading ~ for filepaths.
 	cfg.CAFile.Value = cleanAndExpandPath(cfg.CAFile.Value)
 	cfg.RPCCert.Value = cleanAndExpandPath(cfg.RPCCert.Value)
 	cfg.RPCKey.Value = cleanAndExpandPath(cfg.RPCKey.Value)
+	if cfg.RPCAccessLog != "" && cfg.RPCAccessLog != "log" {
+		cfg.RPCAccessLog = cleanAndExpandPath(cfg.RPCAccessLog)
+	}
 
 	if cfg.Username == "" {
 		cfg.Username = cfg.OldUsername
@@ -608,6 +1092,14 @@ func loadConfig() (*config, []string, er.R) {
 	if configFileError != nil {
 		log.Warnf("%v", configFileError)
 	}
+	for _, w := range configFileWarnings {
+		log.Warnf("%s", w)
+	}
+
+	if cfg.walletConfig.MaxFeeRate > 0 {
+		log.Infof("Maximum fee rate [%s]",
+			txrules.FormatFeeRate(cfg.walletConfig.MaxFeeRate))
+	}
 
 	return &cfg, remainingArgs, nil
 }
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/pkt-cash/pktd/pktwallet/netparams"
+)
+
+// TestLoadConfigTxVersion ensures that loadConfig rejects a txversion option
+// which is not a supported transaction version.  The network parameters can
+// only be selected once per process so loadConfig is only called once.
+func TestLoadConfigTxVersion(t *testing.T) {
+	defer func(old []string) { os.Args = old }(os.Args)
+
+	appData, errr := ioutil.TempDir("", "pktwallet-config")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer os.RemoveAll(appData)
+
+	os.Args = []string{"pktwallet", "--appdata=" + appData,
+		"--username=user", "--password=pass", "--noinitialload",
+		"--txversion=3"}
+	if _, _, err := loadConfig(); err == nil {
+		t.Fatalf("loaded config with unsupported txversion 3")
+	}
+}
+
+// TestLoadConfigSimNetMagic ensures that loadConfig refuses the netmagic
+// option on simnet.  The check comes before the network parameters are
+// selected, so it does not use up the one call of TestLoadConfigTxVersion.
+func TestLoadConfigSimNetMagic(t *testing.T) {
+	defer func(old []string) { os.Args = old }(os.Args)
+	defer func(old *netparams.Params) { activeNet = old }(activeNet)
+
+	appData, errr := ioutil.TempDir("", "pktwallet-config")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer os.RemoveAll(appData)
+
+	os.Args = []string{"pktwallet", "--appdata=" + appData,
+		"--username=user", "--password=pass", "--noinitialload",
+		"--simnet", "--netmagic=0x12141c16"}
+	if _, _, err := loadConfig(); err == nil {
+		t.Fatalf("loaded config with netmagic on simnet")
+	}
+}
+
+// TestRPCConnectPort ensures that the port of a local pktd is read from its
+// config file when rpcconnect does not give one.
+func TestRPCConnectPort(t *testing.T) {
+	dir, errr := ioutil.TempDir("", "pktwallet-config")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer os.RemoveAll(dir)
+
+	pktdConf := filepath.Join(dir, "pktd.conf")
+	conf := "[Application Options]\nrpcuser=user\nrpclisten=127.0.0.1:8337\n"
+	if errr := ioutil.WriteFile(pktdConf, []byte(conf), 0600); errr != nil {
+		t.Fatal(errr)
+	}
+
+	tests := []struct {
+		rpcConnect string
+		pktdConf   string
+		want       string
+	}{
+		{"localhost", pktdConf, "8337"},
+		{"::1", pktdConf, "8337"},
+		{"localhost:8334", pktdConf, "8334"},
+		{"example.com", pktdConf, "8334"},
+		{"localhost", filepath.Join(dir, "missing.conf"), "8334"},
+	}
+	for _, test := range tests {
+		port, err := rpcConnectPort(test.rpcConnect, test.pktdConf, "8334")
+		if err != nil {
+			t.Fatalf("%s: %v", test.rpcConnect, err)
+		}
+		if port != test.want {
+			t.Errorf("%s: got port %s, want %s", test.rpcConnect, port,
+				test.want)
+		}
+	}
+}
+
+// TestParseConfigFileLenient ensures that an unknown option in the config file
+// fails parsing unless lenient parsing is set, in which case it is skipped and
+// the other options are still parsed.
+func TestParseConfigFileLenient(t *testing.T) {
+	dir, errr := ioutil.TempDir("", "pktwallet-config")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "pktwallet.conf")
+	conf := "[Application Options]\nnosuchoption=1\nmaxpeers=many\n" +
+		"txversion=1\n"
+	if errr := ioutil.WriteFile(path, []byte(conf), 0600); errr != nil {
+		t.Fatal(errr)
+	}
+
+	var cfg config
+	parser := flags.NewParser(&cfg, flags.Default)
+	if _, errr := parseConfigFile(parser, path, false); errr == nil {
+		t.Fatalf("parsed config file with an unknown option")
+	}
+
+	cfg = config{}
+	parser = flags.NewParser(&cfg, flags.Default)
+	warnings, errr := parseConfigFile(parser, path, true)
+	if errr != nil {
+		t.Fatalf("unable to parse config file leniently: %v", errr)
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("got warnings %v, want the unknown option and the "+
+			"malformed value skipped", warnings)
+	}
+	if cfg.TxVersion != 1 {
+		t.Fatalf("got txversion %d after skipping lines, want 1",
+			cfg.TxVersion)
+	}
+}
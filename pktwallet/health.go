@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/pkt-cash/pktd/pktlog/log"
+	"github.com/pkt-cash/pktd/pktwallet/wallet"
+)
+
+// newHealthHandler returns the handler for the health-check server.  The
+// /healthz endpoint reports that the process is up, while /readyz reports
+// whether a wallet is loaded and synced to the tip of the chain.
+func newHealthHandler(loader *wallet.Loader) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		wal, ok := loader.LoadedWallet()
+		switch {
+		case !ok:
+			http.Error(w, "wallet not loaded", http.StatusServiceUnavailable)
+		case !wal.ChainSynced():
+			http.Error(w, "wallet not synced", http.StatusServiceUnavailable)
+		default:
+			fmt.Fprintln(w, "ready")
+		}
+	})
+	return mux
+}
+
+// startHealthServer serves the health-check endpoints on the given port.
+func startHealthServer(port string, loader *wallet.Loader) {
+	listenAddr := net.JoinHostPort("", port)
+	log.Infof("Health check server listening on %s", listenAddr)
+	go func() {
+		log.Errorf("%v", http.ListenAndServe(listenAddr, newHealthHandler(loader)))
+	}()
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/hdkeychain"
+	"github.com/pkt-cash/pktd/chaincfg"
+	"github.com/pkt-cash/pktd/pktwallet/wallet"
+)
+
+func checkStatus(t *testing.T, srv *httptest.Server, path string, want int) {
+	t.Helper()
+	res, errr := http.Get(srv.URL + path)
+	if errr != nil {
+		t.Fatalf("GET %s: %v", path, errr)
+	}
+	res.Body.Close()
+	if res.StatusCode != want {
+		t.Fatalf("GET %s: got status %d, want %d", path, res.StatusCode, want)
+	}
+}
+
+// TestHealthEndpoints checks the liveness and readiness endpoints before a
+// wallet is loaded, while it is syncing and once it is synced.
+func TestHealthEndpoints(t *testing.T) {
+	dir, errr := ioutil.TempDir("", "health")
+	if errr != nil {
+		t.Fatalf("unable to create temp dir: %v", errr)
+	}
+	defer os.RemoveAll(dir)
+
+	loader := wallet.NewLoader(&chaincfg.TestNet3Params, dir, "wallet.db",
+		true, 250)
+	srv := httptest.NewServer(newHealthHandler(loader))
+	defer srv.Close()
+
+	// No wallet is loaded yet.
+	checkStatus(t, srv, "/healthz", http.StatusOK)
+	checkStatus(t, srv, "/readyz", http.StatusServiceUnavailable)
+
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.MinSeedBytes)
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+	w, err := loader.CreateNewWallet([]byte("public"), []byte("private"),
+		[]byte(hex.EncodeToString(seed)), time.Now(), nil)
+	if err != nil {
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+	defer loader.UnloadWallet()
+
+	// The wallet is loaded but has not caught up with the chain.
+	checkStatus(t, srv, "/healthz", http.StatusOK)
+	checkStatus(t, srv, "/readyz", http.StatusServiceUnavailable)
+
+	w.SetChainSynced(true)
+	checkStatus(t, srv, "/healthz", http.StatusOK)
+	checkStatus(t, srv, "/readyz", http.StatusOK)
+}
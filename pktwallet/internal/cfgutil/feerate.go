@@ -0,0 +1,42 @@
+package cfgutil
+
+import (
+	"errors"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
+)
+
+// FeeRateFlag is a human readable fee rate implementing the flags.Marshaler
+// and flags.Unmarshaler interfaces so it may be used as a config struct field.
+// The syntax is checked when the flag is parsed but coin units are only
+// resolved by FeeRate, because they depend on the network which is selected
+// after the flags are parsed.
+type FeeRateFlag struct {
+	Value string
+}
+
+// NewFeeRateFlag creates a fee rate flag with the provided default value.
+func NewFeeRateFlag(defaultValue string) *FeeRateFlag {
+	return &FeeRateFlag{Value: defaultValue}
+}
+
+// MarshalFlag satisfies the flags.Marshaler interface.
+func (f *FeeRateFlag) MarshalFlag() (string, error) {
+	return f.Value, nil
+}
+
+// UnmarshalFlag satisfies the flags.Unmarshaler interface.
+func (f *FeeRateFlag) UnmarshalFlag(value string) error {
+	if _, _, _, err := txrules.SplitFeeRate(value); err != nil {
+		return errors.New(err.Message())
+	}
+	f.Value = value
+	return nil
+}
+
+// FeeRate returns the fee rate in atomic units per kilobyte.
+func (f *FeeRateFlag) FeeRate() (btcutil.Amount, er.R) {
+	return txrules.ParseFeeRate(f.Value)
+}
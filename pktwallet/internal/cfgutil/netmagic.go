@@ -0,0 +1,51 @@
+package cfgutil
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/pkt-cash/pktd/wire/protocol"
+)
+
+// NetMagicFlag is a network magic, the value which begins every message on the
+// peer to peer network, implementing the flags.Marshaler and
+// flags.Unmarshaler interfaces so it may be used as a config struct field.  It
+// is written as 8 hex digits with an optional 0x prefix, in the same order as
+// the magic constants of the wire protocol such as 0xd9b4bef9.  An empty value
+// means that the magic of the selected network is used.
+type NetMagicFlag struct {
+	Value string
+	Net   protocol.BitcoinNet
+}
+
+// NewNetMagicFlag creates a network magic flag which is not set.
+func NewNetMagicFlag() *NetMagicFlag {
+	return &NetMagicFlag{}
+}
+
+// MarshalFlag satisfies the flags.Marshaler interface.
+func (f *NetMagicFlag) MarshalFlag() (string, error) {
+	return f.Value, nil
+}
+
+// UnmarshalFlag satisfies the flags.Unmarshaler interface.
+func (f *NetMagicFlag) UnmarshalFlag(value string) error {
+	digits := strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	if len(digits) != 8 {
+		return errors.New("network magic [" + value + "] must be 8 hex " +
+			"digits, such as 0xd9b4bef9")
+	}
+	n, errr := strconv.ParseUint(digits, 16, 32)
+	if errr != nil {
+		return errors.New("network magic [" + value + "] is not hex")
+	}
+	f.Value = value
+	f.Net = protocol.BitcoinNet(n)
+	return nil
+}
+
+// IsSet returns whether a network magic was given.
+func (f *NetMagicFlag) IsSet() bool {
+	return f != nil && f.Value != ""
+}
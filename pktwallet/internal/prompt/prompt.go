@@ -253,6 +253,22 @@ func Seed(reader *bufio.Reader, passphrase []byte) ([]byte, *seedwords.Seed, er.
 		}
 		seedStr = strings.TrimSpace(strings.ToLower(seedStr))
 
+		if seedwords.IsBackup(seedStr) {
+			fmt.Println("This is a seed backup encrypted with backupseedencrypted.")
+			for {
+				pass, err := promptPass(reader, "Enter the backup passphrase now", false)
+				if err != nil {
+					return nil, nil, err
+				}
+				fmt.Println("Decrypting your seed...")
+				if seed, err := seedwords.DecryptBackup(seedStr, pass); err != nil {
+					fmt.Println("The seed backup did not decrypt properly, please try again.")
+				} else {
+					return nil, seed, nil
+				}
+			}
+		}
+
 		if seed, err := hex.DecodeString(seedStr); err != nil {
 		} else if len(seed) < hdkeychain.MinSeedBytes {
 		} else if len(seed) > hdkeychain.MaxSeedBytes {
@@ -34,6 +34,7 @@ var helpDescsEnUS = map[string]string{
 	"createtransaction-maxinputs":      "Maximum number of transaction inputs that are allowed",
 	"createtransaction-autolock":       "If specified, all txouts spent for this transaction will be locked under this name",
 	"createtransaction-nosign":         "If specified, create an *unsigned* transaction",
+	"createtransaction-allowselfsend":  "Allow outputs paying addresses of this wallet when warnselfsend is set",
 	"createtransaction--result0":       "The hex encoded transaction result",
 
 	// GetAddressBalancesCmd help.
@@ -48,12 +49,327 @@ var helpDescsEnUS = map[string]string{
 	"getaddressbalancesresult-stotal":          "Total balance (atomic units as base 10 string)",
 	"getaddressbalancesresult-unconfirmed":     "Unconfirmed balance",
 	"getaddressbalancesresult-sunconfirmed":    "Unconfirmed balance (atomic units as base 10 string)",
+	"getaddressbalancesresult-maturing":        "Balance which has enough confirmations to be spendable but fewer than finalitydepth, so is counted apart as it may yet be undone by a reorg",
+	"getaddressbalancesresult-smaturing":       "Balance which has enough confirmations to be spendable but fewer than finalitydepth (atomic units as base 10 string)",
+	"getaddressbalancesresult-watchonly":       "Balance of a watch only taproot address, which cannot yet be spent",
+	"getaddressbalancesresult-swatchonly":      "Balance of a watch only taproot address (atomic units as base 10 string)",
+	"getaddressbalancesresult-dust":            "Dust sent by others which is quarantined by ignoreincomingdust, so is not spendable",
+	"getaddressbalancesresult-sdust":           "Dust sent by others which is quarantined by ignoreincomingdust (atomic units as base 10 string)",
 	"getaddressbalancesresult-address":         "The address which has this balance",
 	"getaddressbalancesresult-outputcount":     "The number of transaction outputs which make up the balance",
 
+	"getaccountxpubs--synopsis":         "Get the extended public keys of an account for each of the wallet's key scopes",
+	"getaccountxpubs-account":           "The account number",
+	"getaccountxpubs-slip132":           "If true then encode each key with the SLIP-0132 version bytes for its script type (e.g. ypub/zpub) rather than the network's standard extended public key version, scopes whose script type has no SLIP-0132 version on the network, such as the segwit scopes of PKT, are left out",
+	"getaccountxpubsresult-scope":       "The key scope which the key belongs to, as a derivation path m/purpose'/cointype'",
+	"getaccountxpubsresult-addresstype": "The script type of addresses derived from the key (p2pkh, p2sh-p2wpkh or p2wpkh)",
+	"getaccountxpubsresult-xpub":        "The account extended public key",
+
+	"listaccounts--synopsis":          "List every account of each of the wallet's key scopes, including the imported account, with its balance and the number of addresses issued",
+	"listaccounts-minconf":            "Minimum number of block confirmations required before an output is counted in the balance",
+	"listaccountsresult-name":         "The name of the account",
+	"listaccountsresult-account":      "The account number",
+	"listaccountsresult-scope":        "The key scope which the account belongs to, as a derivation path m/purpose'/cointype'",
+	"listaccountsresult-addresstype":  "The script type of the account's addresses (p2pkh, p2sh-p2wpkh or p2wpkh)",
+	"listaccountsresult-balance":      "The balance of the account in coins, leaving out dust quarantined by ignoreincomingdust",
+	"listaccountsresult-addresscount": "The number of addresses issued by the account, including change addresses, or imported into it",
+
+	"markaddressused--synopsis":   "Mark a wallet address as used so that a new address will be handed out after it, this fails if the address is further than the gap limit beyond the previous used address",
+	"markaddressused-address":     "The address to mark as used",
+	"markaddressunused--synopsis": "Clear the used flag of a wallet address, this fails if it would leave a gap larger than the gap limit between the used addresses on either side of it",
+	"markaddressunused-address":   "The address to mark as unused",
+
+	"freezeaddress--synopsis":       "Freeze an address, for example because its key is compromised, its outputs are not used as inputs of new transactions and are not counted in the balance until it is unfrozen, the freeze is kept in the wallet database",
+	"freezeaddress-address":         "The address to freeze",
+	"unfreezeaddress--synopsis":     "Unfreeze an address which was frozen with freezeaddress, making its outputs spendable again",
+	"unfreezeaddress-address":       "The address to unfreeze",
+	"listfrozenaddresses--synopsis": "List the addresses which are frozen",
+	"listfrozenaddresses--result0":  "The frozen addresses",
+	"getchangeaddress--synopsis":    "Get the address which the change of sends is pinned to by setchangeaddress",
+	"getchangeaddress--result0":     "The pinned change address, empty if the change is not pinned",
+	"setchangeaddress--synopsis":    "Pin the change of sends to an address of the wallet, in place of choosing a change address for each send, until it is unpinned. A change address given to a send still takes precedence",
+	"setchangeaddress-address":      "The address of the wallet to pay change to, omit or leave empty to unpin the change",
+
+	"gettxproof--synopsis":         "Get the merkle proof that a mined wallet transaction is included in its block, the block is fetched from the chain backend",
+	"gettxproof-txid":              "The hash of the transaction",
+	"gettxproofresult-txid":        "The hash of the transaction",
+	"gettxproofresult-blockhash":   "The hash of the block containing the transaction",
+	"gettxproofresult-blockheight": "The height of the block containing the transaction",
+	"gettxproofresult-index":       "The position of the transaction in the block",
+	"gettxproofresult-branch":      "The merkle branch from the transaction up to the merkle root, an empty string means the node is hashed with itself",
+
+	"gettxstatus--synopsis":           "Get whether a transaction is unknown to the wallet, unconfirmed, confirmed or conflicted, that is removed because a mined transaction spends one of the same outputs",
+	"gettxstatus-txid":                "The hash of the transaction",
+	"gettxstatusresult-status":        "The status of the transaction: unknown, unconfirmed, confirmed or conflicted",
+	"gettxstatusresult-confirmations": "The number of confirmations of a confirmed transaction, 0 otherwise",
+	"gettxstatusresult-blockheight":   "The height of the block containing a confirmed transaction, -1 otherwise",
+	"gettxstatusresult-conflictedby":  "The hash of the mined transaction which conflicts with a conflicted transaction",
+
+	"getmempoolancestors--synopsis":       "List the unconfirmed wallet transactions which an unconfirmed transaction spends the outputs of, directly or through other unconfirmed transactions, with their total size and fee, as a child paying for them must pay for all of them to be mined",
+	"getmempoolancestors-txid":            "The hash of the unconfirmed transaction",
+	"getmempoolancestorsresult-ancestors": "The ancestors, each listed after those which it depends on",
+	"getmempoolancestorsresult-size":      "The total size of the ancestors in bytes",
+	"getmempoolancestorsresult-vsize":     "The total virtual size of the ancestors, which a child paying for them pays its fee rate on",
+	"getmempoolancestorsresult-fee":       "The total fee paid by the ancestors, omitted if the fee of any of them is not known",
+	"mempoolancestorresult-txid":          "The hash of the ancestor",
+	"mempoolancestorresult-size":          "The size of the ancestor in bytes",
+	"mempoolancestorresult-vsize":         "The virtual size of the ancestor",
+	"mempoolancestorresult-fee":           "The fee paid by the ancestor, omitted if any of its inputs do not belong to the wallet",
+
+	"verifytxproof--synopsis": "Verify a merkle proof for a transaction against the merkle root of the block header",
+	"verifytxproof-txid":      "The hash of the transaction",
+	"verifytxproof-blockhash": "The hash of the block which the transaction is claimed to be in",
+	"verifytxproof-index":     "The position of the transaction in the block",
+	"verifytxproof-branch":    "The merkle branch from the transaction up to the merkle root, an empty string means the node is hashed with itself",
+	"verifytxproof--result0":  "Whether the proof is valid for the block",
+
+	"estimateconfirmationtime--synopsis":           "Estimate how many blocks and seconds an unconfirmed wallet transaction will take to confirm based on its fee rate, estimates without fee estimation data from pktd are conservative and flagged as low confidence",
+	"estimateconfirmationtime-txid":                "The hash of the transaction",
+	"estimateconfirmationtimeresult-feerate":       "The fee rate of the transaction in coins per kilobyte of virtual size",
+	"estimateconfirmationtimeresult-blocks":        "The estimated number of blocks until the transaction confirms, zero if it is already mined",
+	"estimateconfirmationtimeresult-seconds":       "The estimated number of seconds until the transaction confirms",
+	"estimateconfirmationtimeresult-lowconfidence": "Whether the estimate is not based on enough fee estimation data to be reliable",
+
+	"estimateconsolidation--synopsis":            "Estimate how many transactions and how much fee it would take to consolidate all of the wallet's spendable outputs into a single output. When there are more outputs than fit in one transaction the outputs of the first transactions are consolidated again",
+	"estimateconsolidation-feerate":              "The fee rate, either in coins per kilobyte or with a unit such as 10bit/vB, default is the relay fee",
+	"estimateconsolidationresult-utxos":          "The number of outputs which would be consolidated",
+	"estimateconsolidationresult-transactions":   "The number of transactions needed",
+	"estimateconsolidationresult-feerate":        "The fee rate used in coins per kilobyte, which is limited by maxfeerate",
+	"estimateconsolidationresult-fee":            "The total fee of all of the transactions in coins",
+	"estimateconsolidationresult-amount":         "The total value of the outputs which would be consolidated in coins",
+	"estimateconsolidationresult-amountafterfee": "The value of the single output which would be left after paying the fee",
+
+	"verifywallet--synopsis":        "Walk the wallet database checking that its records are consistent with one another, e.g. that every unspent output references a known transaction that credit amounts match the transaction outputs and that the balances reconcile",
+	"verifywalletresult-consistent": "Whether no inconsistencies were found",
+	"verifywalletresult-problems":   "A description of each inconsistency found",
+
+	"getbalanceatheight--synopsis":     "Calculate the confirmed balance of the wallet as of a past block by replaying the transactions mined at or before it, heights beyond the wallet's best block give the current confirmed balance",
+	"getbalanceatheight-height":        "The height of the block to calculate the balance at",
+	"getbalanceatheightresult-height":  "The height which the balance was calculated at, this is the wallet's best block if the requested height is beyond it",
+	"getbalanceatheightresult-balance": "The confirmed balance in coins as of the block",
+
+	"verifypaymentrequest--synopsis":        "Parse a BIP0070 payment request and verify its X.509 signature against the system's root certificates, returning the payment details",
+	"verifypaymentrequest-paymentrequest":   "The hex encoded serialized payment request",
+	"verifypaymentrequestresult-valid":      "Whether the request is signed by a trusted certificate chain, the signature matches and the request has not expired",
+	"verifypaymentrequestresult-expired":    "Whether the request has passed its expiry time, this is reported separately from the signature",
+	"verifypaymentrequestresult-error":      "Why the signature or certificate chain is not valid, if it is not",
+	"verifypaymentrequestresult-merchant":   "The common name of the certificate which signed the request",
+	"verifypaymentrequestresult-network":    "The network which the request is for",
+	"verifypaymentrequestresult-outputs":    "The outputs which are requested to be paid",
+	"verifypaymentrequestresult-memo":       "The merchant's memo",
+	"verifypaymentrequestresult-paymenturl": "Where the payment should be sent",
+	"verifypaymentrequestresult-time":       "When the request was created, in seconds since the unix epoch",
+	"verifypaymentrequestresult-expires":    "When the request expires, in seconds since the unix epoch, zero if it does not",
+	"paymentrequestoutput-amount":           "The requested amount in coins",
+	"paymentrequestoutput-script":           "The hex encoded output script to pay",
+	"paymentrequestoutput-address":          "The address of the output script, if it is a standard script",
+
+	"createnewaccount--synopsis":   "Create a new account, in each key scope, with a default type for the addresses which getnewaddress creates for it",
+	"createnewaccount-account":     "The name of the new account",
+	"createnewaccount-addresstype": "The default address type of the account, one of p2pkh (or legacy), p2sh-p2wpkh or p2wpkh (or segwit), if unset then p2wpkh",
+	"createnewaccount--result0":    "The number of the new account",
+
+	"getstoragestats--synopsis":          "Get the size of the wallet database, the size of each of its buckets and the number of transactions and unspent outputs which it holds",
+	"getstoragestatsresult-filesize":     "The size of the wallet database file in bytes",
+	"getstoragestatsresult-buckets":      "The storage used by each top level bucket and the buckets nested directly in them, bucket names which are not printable are hex encoded",
+	"getstoragestatsresult-transactions": "The number of transactions, mined and unmined, which the wallet has recorded",
+	"getstoragestatsresult-utxos":        "The number of unspent outputs belonging to the wallet",
+	"storagebucketstats-name":            "The path of the bucket, with names separated by /",
+	"storagebucketstats-keys":            "The number of keys in the bucket and the buckets nested in it",
+	"storagebucketstats-size":            "The number of bytes in use by the bucket and the buckets nested in it",
+
+	"getrecoverystatus--synopsis":                 "Get the progress of the wallet scanning the chain for its transactions, either catching up to the tip after being restored from seed or a resync, or of the last such recovery if none is running",
+	"getrecoverystatusresult-active":              "Whether the recovery is running",
+	"getrecoverystatusresult-name":                "The name of the recovery, sync when catching up to the tip or else the name of the resync job",
+	"getrecoverystatusresult-startheight":         "The height from which the recovery started scanning",
+	"getrecoverystatusresult-currentheight":       "The height of the last block scanned",
+	"getrecoverystatusresult-targetheight":        "The height at which the recovery completes",
+	"getrecoverystatusresult-progress":            "The fraction of the blocks of the recovery which have been scanned, between 0 and 1",
+	"getrecoverystatusresult-addressesfound":      "The number of addresses of the wallet which were paid in the blocks scanned so far",
+	"getrecoverystatusresult-starttime":           "When the recovery started, in seconds since the unix epoch, zero if the wallet has not recovered since it was opened",
+	"getrecoverystatusresult-estimatedcompletion": "When the recovery is estimated to complete, in seconds since the unix epoch, from the rate at which blocks have been scanned so far, zero if it is not running or not yet known",
+
+	"listrejectedtx--synopsis":    "List the transactions which were most recently rejected when they were broadcast, most recent first, only the last 100 rejections are kept and they are forgotten on restart",
+	"listrejectedtxresult-txid":   "The hash of the rejected transaction",
+	"listrejectedtxresult-reason": "Why the transaction was rejected, from the peer's reject message or the error returned by pktd",
+	"listrejectedtxresult-time":   "When the transaction was rejected, in seconds since the unix epoch",
+
+	"deriveaddresses--synopsis":     "Derive the first external addresses of an account from a seed, in the same way as a wallet created from the seed, so that the derivation can be cross-checked with other implementations. The wallet itself is not used or changed",
+	"deriveaddresses-seed":          "The hex encoded BIP0032 seed",
+	"deriveaddresses-count":         "The number of addresses to derive, at most 10000",
+	"deriveaddresses-addresstype":   "The type of the addresses, which selects the key scope: p2pkh (or legacy) for BIP0044, p2sh-p2wpkh for BIP0049, p2wpkh (or segwit) for BIP0084 or p2tr (or taproot) for BIP0086",
+	"deriveaddresses-account":       "The account number to derive addresses of",
+	"deriveaddressesresult-path":    "The derivation path of the address, m/purpose'/cointype'/account'/0/index",
+	"deriveaddressesresult-address": "The encoded address",
+	"deriveaddressesresult-pubkey":  "The hex encoded compressed public key of the address",
+
+	"convertaddress--synopsis":         "Convert an address of the wallet to the address of another type which pays the same public key, such as from p2pkh to p2wpkh. The wallet must hold the private key of the address, watch-only and script addresses are rejected. Unless the converted address is already the wallet's, its key is imported so that payments to it are seen from the current block on, which needs the wallet to be unlocked. Conversions to p2sh-p2wpkh addresses which are not already the wallet's are rejected",
+	"convertaddress-address":           "The address of the wallet to convert",
+	"convertaddress-addresstype":       "The type of address to convert to, one of p2pkh (or legacy), p2sh-p2wpkh or p2wpkh (or segwit)",
+	"convertaddressresult-address":     "The converted address",
+	"convertaddressresult-addresstype": "The type of the converted address",
+	"convertaddressresult-ismine":      "Whether the converted address is an address of the wallet, which it is once converted",
+
+	"getfee--synopsis": "Get the fee paid by a wallet transaction, mined or not. The values of the outputs which it spends are taken from the wallet, " +
+		"outputs of transactions which the wallet does not have are fetched from pktd when it is the backend and keeps a transaction index, " +
+		"otherwise the fee cannot be known unless the wallet owns or recorded every spent output. " +
+		"Neutrino cannot fetch transactions by hash, so with it only the fees of transactions whose spent outputs the wallet knows are returned",
+	"getfee-txid":          "The hash of the transaction",
+	"getfeeresult-fee":     "The fee paid by the transaction in coins",
+	"getfeeresult-feerate": "The fee rate of the transaction in coins per kilobyte of virtual size",
+	"getfeeresult-size":    "The serialized size of the transaction in bytes",
+	"getfeeresult-vsize":   "The virtual size of the transaction in vbytes",
+
+	"getaccountstats--synopsis": "Get the number of transactions which each account received and sent, and the totals, " +
+		"counting the transactions which the wallet received between starttime and endtime. " +
+		"A transaction which spends from an account is outgoing for it, otherwise one which pays it is incoming",
+	"getaccountstats-starttime":   "Only count transactions received at or after this unix time, 0 for no limit",
+	"getaccountstats-endtime":     "Only count transactions received at or before this unix time, 0 for no limit",
+	"accountstatsresult-name":     "The name of the account",
+	"accountstatsresult-account":  "The account number",
+	"accountstatsresult-scope":    "The key scope which the account belongs to, as a derivation path m/purpose'/cointype'",
+	"accountstatsresult-incoming": "The number of transactions which paid the account without spending from it",
+	"accountstatsresult-received": "The total in coins paid to the account by incoming transactions, and by outgoing transactions which paid it more than they spent from it",
+	"accountstatsresult-outgoing": "The number of transactions which spent from the account",
+	"accountstatsresult-sent":     "The total in coins which left the account in outgoing transactions, including fees but not change",
+
+	"bumpfee--synopsis":             "Replace an unmined wallet transaction which signals BIP125 replaceability with one paying a higher fee, taken out of its change, as autobumpafter does. The fee is doubled, or raised by more if minbumpincrement requires, but by no more than maxbumpfee",
+	"bumpfee-txid":                  "The hash of the transaction",
+	"bumpfee--result0":              "The hash of the replacement",
+	"bumpfeecpfp--synopsis":         "Bump the fee of an unmined wallet transaction by spending its largest output paying the wallet with a child transaction, so the two together pay what bumpfee would raise the fee to. The child pays back to the pinned change address or the address which it spends from and adds no more than maxbumpfee",
+	"bumpfeecpfp-txid":              "The hash of the transaction",
+	"bumpfeecpfp--result0":          "The hash of the child transaction",
+	"getbumpinfo--synopsis":         "Get whether the fee of a wallet transaction can be bumped by replacing it, as autobumpafter does, without changing it",
+	"getbumpinfo-txid":              "The hash of the transaction",
+	"getbumpinforesult-replaceable": "Whether the transaction signals BIP125 replaceability",
+	"getbumpinforesult-ownsinputs":  "Whether every input of the transaction spends an output of the wallet, so the wallet can sign a replacement",
+	"getbumpinforesult-fee":         "The fee paid by the transaction in coins, only known if the wallet owns every input",
+	"getbumpinforesult-minbumpfee":  "The least fee in coins which a replacement must add, the minbumpincrement fee rate, by default the relay fee rate, of its virtual size",
+	"getbumpinforesult-canbump":     "Whether the wallet can bump the fee of the transaction",
+	"getbumpinforesult-newfee":      "The fee in coins which the replacement would pay if the fee can be bumped",
+	"getbumpinforesult-reason":      "Why the fee cannot be bumped",
+
+	"getfeesource--synopsis":        "Get the current fee rate estimate and where it comes from: the fee estimation of pktd, the fee rates paid by the wallet's transactions in recent blocks (neutrino) or the fallback fee rate.",
+	"getfeesource--result0":         "The fee estimate",
+	"getfeesourceresult-source":     "Where the estimate comes from, pktd, neutrino or fallback",
+	"getfeesourceresult-feerate":    "The estimated fee rate in coins per kilobyte",
+	"getfeesourceresult-lastupdate": "The unix time the estimate last changed, when pktd was first seen estimating the current rate or of the newest block observed by neutrino, 0 for the fallback fee rate which does not change",
+
+	"getfeestats--synopsis":           "Get the fee rates paid by transactions which the wallet sent in recent blocks and how long each took to confirm. Only transactions whose inputs all belong to the wallet have a known fee",
+	"getfeestats-blocks":              "The number of most recent blocks to include transactions from",
+	"getfeestatsresult-transactions":  "The fee rate of each transaction",
+	"getfeestatsresult-minfeerate":    "The lowest fee rate paid, in coins per kilobyte of virtual size",
+	"getfeestatsresult-medianfeerate": "The median fee rate paid, in coins per kilobyte of virtual size",
+	"getfeestatsresult-maxfeerate":    "The highest fee rate paid, in coins per kilobyte of virtual size",
+	"txfeestat-txid":                  "The hash of the transaction",
+	"txfeestat-height":                "The height of the block which the transaction was mined in",
+	"txfeestat-feerate":               "The fee rate paid by the transaction, in coins per kilobyte of virtual size",
+	"txfeestat-confirmseconds":        "The number of seconds between the wallet sending the transaction and the time of the block it was mined in, zero if the wallet found it in a block",
+
+	"getutxoages--synopsis":     "Get the distribution of the ages in confirmations of the wallet's unspent outputs, including locked outputs, unconfirmed outputs having no confirmations.",
+	"getutxoagesresult-count":   "The number of unspent outputs",
+	"getutxoagesresult-oldest":  "The confirmations of the oldest unspent output, 0 if there are none",
+	"getutxoagesresult-newest":  "The confirmations of the newest unspent output, 0 if there are none",
+	"getutxoagesresult-median":  "The median confirmations of the unspent outputs, 0 if there are none",
+	"getutxoagesresult-buckets": "The number and value of the unspent outputs in each range of confirmations, youngest first",
+	"utxoagebucket-minconfs":    "The fewest confirmations of the outputs in the bucket",
+	"utxoagebucket-maxconfs":    "The most confirmations of the outputs in the bucket, absent for the last bucket which has no upper bound",
+	"utxoagebucket-count":       "The number of outputs in the bucket",
+	"utxoagebucket-amount":      "The total value of the outputs in the bucket in coins",
+
+	"exporttaxreport--synopsis":      "Export a record of each disposal of coins by the wallet's mined transactions for tax software. Disposals are matched first in first out against the lots which the wallet received, a disposal which spans lots gives a record for each.",
+	"exporttaxreport--result0":       "The disposals, oldest first",
+	"taxdisposalresult-dateacquired": "The date (UTC, RFC 3339) of the block which the lot was received in, empty if the wallet did not see it received",
+	"taxdisposalresult-acquiredtxid": "The hash of the transaction which received the lot, empty if the wallet did not see it received",
+	"taxdisposalresult-datedisposed": "The date (UTC, RFC 3339) of the block which the disposal was mined in",
+	"taxdisposalresult-disposedtxid": "The hash of the transaction which disposed of the lot",
+	"taxdisposalresult-amount":       "The amount of the lot disposed of, including its share of the fee",
+	"taxdisposalresult-costbasis":    "Always null, the wallet does not know the price which was paid for the lot",
+	"taxdisposalresult-proceeds":     "The part of the amount which was paid to others rather than as a fee, in coins",
+
+	"exportlabels--synopsis": "Export the labels of the wallet's transactions, without any key material, for example to keep them in step with another system",
+	"exportlabels--result0":  "The labelled transactions, ordered by hash",
+	"txlabel-txid":           "The hash of the transaction",
+	"txlabel-label":          "The label of the transaction",
+
+	"importlabels--synopsis":      "Label the wallet's transactions with labels in the form given by exportlabels. Labels are merged with those of the wallet, labels of transactions which the wallet does not know are skipped",
+	"importlabels-labels":         "The labelled transactions",
+	"importlabels-overwrite":      "Replace the label of a transaction which already has one rather than keep it",
+	"importlabelsresult-imported": "The number of labels written",
+	"importlabelsresult-kept":     "The number of labels not written because the transaction already has a label and overwrite is not set",
+	"importlabelsresult-unknown":  "The number of labels not written because the wallet does not know the transaction",
+
+	"listpendingtransactions--synopsis":         "List the wallet's unconfirmed transactions, oldest first",
+	"listpendingtransactionsresult-txid":        "The hash of the transaction",
+	"listpendingtransactionsresult-fee":         "The fee paid by the transaction, omitted if any of its inputs do not belong to the wallet",
+	"listpendingtransactionsresult-feerate":     "The fee rate paid by the transaction in coins per kilobyte of virtual size, omitted if the fee is not known",
+	"listpendingtransactionsresult-ageseconds":  "The number of seconds since the wallet first saw the transaction",
+	"listpendingtransactionsresult-replaceable": "Whether the transaction signals that it may be replaced (BIP0125)",
+
+	"dumputxoset--synopsis":           "Dump the wallet's spendable outputs. Over HTTP the response is a stream of NDJSON, one JSON object per line for each output, rather than a JSON-RPC response so that very large UTXO sets need not be held in memory. A final line with an error field is written if the dump fails part way",
+	"dumputxosetresult-txid":          "The hash of the transaction",
+	"dumputxosetresult-vout":          "The index of the output in the transaction",
+	"dumputxosetresult-amount":        "The value of the output in coins",
+	"dumputxosetresult-scripttype":    "The type of the output script",
+	"dumputxosetresult-address":       "The address paid by the output, omitted if the script does not pay to exactly one address",
+	"dumputxosetresult-confirmations": "The number of confirmations of the output",
+
+	// GetUtxoInfoCmd help.
+	"getutxoinfo--synopsis": "Get the address paid by an output and, if the wallet owns it, the origin of its key for signing with an external signer",
+	"getutxoinfo-txid":      "The hash of the transaction",
+	"getutxoinfo-vout":      "The index of the output in the transaction",
+
+	// GetUtxoInfoResult help.
+	"getutxoinforesult-txid":              "The hash of the transaction",
+	"getutxoinforesult-vout":              "The index of the output in the transaction",
+	"getutxoinforesult-known":             "Whether the transaction is known to the wallet, if not then no other information is given",
+	"getutxoinforesult-owned":             "Whether the wallet holds the key for the address paid by the output",
+	"getutxoinforesult-amount":            "The value of the output in coins",
+	"getutxoinforesult-scriptPubKey":      "The output script as a hex string",
+	"getutxoinforesult-address":           "The address paid by the output, omitted if the script does not pay to an address",
+	"getutxoinforesult-derivationpath":    "The derivation path of the key from the master key, omitted unless the wallet derived the key from its seed",
+	"getutxoinforesult-masterfingerprint": "The fingerprint of the master public key as a hex string, omitted with the derivation path",
+
+	"listauxoutputs--synopsis":           "List the zero value and unspendable outputs, such as OP_RETURN data, of the wallet's transactions. These are not counted in the balance or as unspent outputs",
+	"listauxoutputsresult-txid":          "The hash of the transaction",
+	"listauxoutputsresult-vout":          "The index of the output in the transaction",
+	"listauxoutputsresult-amount":        "The value of the output in coins, usually zero",
+	"listauxoutputsresult-scriptPubKey":  "The output script, hex encoded",
+	"listauxoutputsresult-data":          "The data carried by an OP_RETURN output, hex encoded, omitted for other outputs",
+	"listauxoutputsresult-confirmations": "The number of confirmations of the transaction, 0 if it is unmined",
+
+	"getpeerinfo--synopsis":            "Get the details of each peer which the wallet is connected to in neutrino (SPV) mode",
+	"getpeerinfo--result0":             "The connected peers",
+	"getpeerinforesult-id":             "A unique ID of the peer",
+	"getpeerinforesult-addr":           "The IP address and port of the peer",
+	"getpeerinforesult-addrlocal":      "Always empty, the local address is not reported",
+	"getpeerinforesult-services":       "Services bitmask which represents the services supported by the peer",
+	"getpeerinforesult-relaytxes":      "Always false, transaction relay is not reported",
+	"getpeerinforesult-lastsend":       "Time the last message was sent in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-lastrecv":       "Time the last message was received in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-bytessent":      "Total bytes sent",
+	"getpeerinforesult-bytesrecv":      "Total bytes received",
+	"getpeerinforesult-conntime":       "Time the connection was made in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-timeoffset":     "The time offset of the peer",
+	"getpeerinforesult-pingtime":       "Number of microseconds the last ping took",
+	"getpeerinforesult-pingwait":       "Number of microseconds a queued ping has been waiting for a response",
+	"getpeerinforesult-version":        "The protocol version of the peer",
+	"getpeerinforesult-subver":         "The user agent of the peer",
+	"getpeerinforesult-inbound":        "Whether the peer connected to the wallet rather than the wallet to the peer",
+	"getpeerinforesult-startingheight": "The latest block height the peer knew about when the connection was established",
+	"getpeerinforesult-currentheight":  "The height of the latest block which the peer has advertised",
+	"getpeerinforesult-banscore":       "Always 0, the ban score is not reported",
+	"getpeerinforesult-feefilter":      "Always 0, the fee filter of the peer is not reported",
+	"getpeerinforesult-syncnode":       "Always false, the sync peer is not reported",
+
 	"getwalletseed--synopsis": "Get the wallet seed words for this wallet",
 	"getwalletseed--result0":  "The seed words used, along with the wallet passphrase, to create the wallet",
 
+	"backupseedencrypted--synopsis":        "Get the wallet seed encrypted under a backup passphrase, for a backup which cannot be read without it. The seed is encrypted with NaCl secretbox (XSalsa20 and Poly1305) under a key derived from the passphrase with scrypt (N=2^18, r=8, p=1) and a random salt. The wallet may be restored from it by giving it as the seed to --create, with the backup passphrase as the seed passphrase",
+	"backupseedencrypted-walletpassphrase": "The private passphrase of the wallet, which the seed is stored encrypted under",
+	"backupseedencrypted-passphrase":       "The passphrase to encrypt the backup under, needed to restore from it",
+	"backupseedencrypted--result0":         "The encrypted seed backup, hex encoded",
+
 	"getsecret--synopsis": "Get a secret seed which is generated using the wallet's private key, this can be used as a password for another application",
 	"getsecret-name":      "A name which will be used to generate the secret seed, the same seed will always be provided given the same name",
 	"getsecret--result0":  "A 32 byte secret seed in hex form",
@@ -75,9 +391,28 @@ var helpDescsEnUS = map[string]string{
 	"resync-toheight":   "Stop resyncing when this height is reached, default or -1 will use the tip of the chain",
 	"resync-dropdb":     "Clean most of the data out of the wallet transaction store, this is not a real resync, it just drops the wallet and then lets it begin working again",
 
+	// RescanAddressCmd help
+	"rescanaddress--synopsis":  "Rescan the chain for the transactions of a single wallet address, this downloads far fewer blocks than a full resync when only one address needs catching up",
+	"rescanaddress-address":    "The wallet address to rescan for",
+	"rescanaddress-fromheight": "Start rescanning from the specified height, default or -1 will use the height of the chain when the wallet was created",
+	"rescanaddress-toheight":   "Stop rescanning when this height is reached, default or -1 will use the tip of the chain",
+
+	// SetMaintenanceModeCmd help
+	"setmaintenancemode--synopsis": "Turn maintenance mode on or off, while it is on RPCs which move funds (sendtoaddress, sendmany, sendmanydetailed, sendfrom, spendmax, createtransaction, signrawtransaction and sendrawtransaction) are refused with an error and all other RPCs are answered",
+	"setmaintenancemode-enable":    "True to turn maintenance mode on, false to turn it off",
+
 	"stopresync--synopsis": "Stop a re-synchronization job before it's completion",
 	"stopresync--result0":  "The name of the sync job which was stopped",
 
+	"cancelrescan--synopsis": "Stop the running re-synchronization job after the blocks it is scanning, keeping its progress so that it resumes from where it stopped when the wallet is next started. Does nothing if no job is running",
+	"cancelrescan--result0":  "The name of the job which was stopped, empty if none was running",
+
+	// PauseSyncCmd help.
+	"pausesync--synopsis": "Pause the neutrino sync of block headers and filter headers without disconnecting from peers, the sync stays paused until resumesync is called. There is no getsyncstatus command, the paused state is reported by getinfo as NeutrinoInfo.SyncPaused.",
+
+	// ResumeSyncCmd help.
+	"resumesync--synopsis": "Resume the neutrino sync of block headers and filter headers paused by pausesync. getinfo reports NeutrinoInfo.SyncPaused as false once the sync is resumed.",
+
 	// CreateMultisigCmd help.
 	"createmultisig--synopsis": "Generate a multisig address and redeem script.",
 	"createmultisig-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
@@ -93,13 +428,17 @@ var helpDescsEnUS = map[string]string{
 	"dumpprivkey--result0":  "The WIF-encoded private key",
 
 	// GetBalanceCmd help.
-	"getbalance--synopsis":   "Calculates and returns the balance of one or all accounts.",
+	"getbalance--synopsis":   "Calculates and returns the balance of the wallet, leaving out coins with fewer than finalitydepth confirmations, as they may yet be undone by a reorg, and coins paid to watch only addresses.",
 	"getbalance-minconf":     "Minimum number of block confirmations required before an unspent output's value is included in the balance",
-	"getbalance-account":     "DEPRECATED -- The account name to query the balance for, or \"*\" to consider all accounts (default=\"*\")",
-	"getbalance--condition0": "account != \"*\"",
-	"getbalance--condition1": "account = \"*\"",
-	"getbalance--result0":    "The balance of 'account' valued in bitcoin",
-	"getbalance--result1":    "The balance of all accounts valued in bitcoin",
+	"getbalance-verbose":     "If true then return an object with the balance and, apart from it, the amounts which are maturing and watch only",
+	"getbalance--condition0": "verbose=false",
+	"getbalance--condition1": "verbose=true",
+	"getbalance--result0":    "The balance valued in bitcoin",
+
+	// GetBalanceResult help.
+	"getbalanceresult-balance":   "The balance valued in bitcoin",
+	"getbalanceresult-maturing":  "The amount of coins with enough confirmations to be counted but fewer than finalitydepth, valued in bitcoin",
+	"getbalanceresult-watchonly": "The amount paid to watch only taproot addresses, which cannot yet be spent, valued in bitcoin",
 
 	// GetBestBlockHashCmd help.
 	"getbestblockhash--synopsis": "Returns the hash of the newest block in the best chain that wallet has finished syncing with.",
@@ -132,8 +471,9 @@ var helpDescsEnUS = map[string]string{
 
 	// GetNewAddressCmd help.
 	"getnewaddress--synopsis": "Generates and returns a new payment address.",
-	"getnewaddress-account":   "DEPRECATED -- Account name the new address will belong to (default=\"default\")",
-	"getnewaddress-legacy":    "If true then this will create a legacy form address rather than a new segwit address",
+	"getnewaddress-account":   "Account name the new address will belong to, addresses are of the account's default address type unless legacy is given, the account is created if it does not exist when autocreateaccounts is set (default=\"default\")",
+	"getnewaddress-legacy":    "If true then this will create a legacy form address, if false a segwit address, overriding the account's default address type",
+	"getnewaddress-keyscope":  "Key scope (purpose/cointype) to derive the address under, such as one added with the keyscope option, overriding legacy and the account's default address type",
 	"getnewaddress--result0":  "The payment address",
 
 	// GetReceivedByAddressCmd help.
@@ -158,16 +498,19 @@ var helpDescsEnUS = map[string]string{
 	// GetTransactionResult help.
 	"gettransactionresult-amount":          "The total amount this transaction credits to the wallet, valued in bitcoin",
 	"gettransactionresult-fee":             "The total input value minus the total output value, or 0 if 'txid' is not a sent transaction",
-	"gettransactionresult-confirmations":   "The number of block confirmations of the transaction",
+	"gettransactionresult-confirmations":   "The number of block confirmations of the transaction, or -1 if it was removed because it conflicts with a mined transaction",
 	"gettransactionresult-blockhash":       "The hash of the block this transaction is mined in, or the empty string if unmined",
 	"gettransactionresult-blockindex":      "Unset",
 	"gettransactionresult-blocktime":       "The Unix time of the block header this transaction is mined in, or 0 if unmined",
 	"gettransactionresult-txid":            "The transaction hash",
-	"gettransactionresult-walletconflicts": "Unset",
+	"gettransactionresult-walletconflicts": "The hash of the mined transaction which conflicts with this transaction, if it was removed as conflicted",
 	"gettransactionresult-time":            "The earliest Unix time this transaction was known to exist",
 	"gettransactionresult-timereceived":    "The earliest Unix time this transaction was known to exist",
 	"gettransactionresult-details":         "Additional details for each recorded wallet credit and debit",
+	"gettransactionresult-trusted":         "Whether the transaction has at least the number of confirmations set by the trustedconfs option",
 	"gettransactionresult-hex":             "The transaction encoded as a hexadecimal string",
+	"gettransactionresult-comment":         "The comment recorded when the transaction was sent, if any",
+	"gettransactionresult-to":              "The comment about who the transaction was sent to, if any",
 
 	// GetTransactionDetailsResult help.
 	"gettransactiondetailsresult-account":           "DEPRECATED -- Unset",
@@ -179,6 +522,10 @@ var helpDescsEnUS = map[string]string{
 	"gettransactiondetailsresult-involveswatchonly": "Unset",
 
 	// ImportPrivKeyCmd help.
+	"importaddress--synopsis": "Imports a p2tr address to the 'imported' account to watch, its outputs are counted in the balance but cannot yet be spent.",
+	"importaddress-address":   "The bech32m encoded p2tr address",
+	"importaddress-rescan":    "Rescan the blockchain (since the genesis block) for outputs paying to the address",
+
 	"importprivkey--synopsis": "Imports a WIF-encoded private key to the 'imported' account.",
 	"importprivkey-privkey":   "The WIF-encoded private key",
 	"importprivkey-label":     "Unused (must be unset or 'imported')",
@@ -242,9 +589,10 @@ var helpDescsEnUS = map[string]string{
 	"listtransactionsresult-time":               "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-timereceived":       "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-involveswatchonly":  "Unset",
-	"listtransactionsresult-comment":            "Unset",
+	"listtransactionsresult-comment":            "The comment recorded when the transaction was sent, if any",
+	"listtransactionsresult-to":                 "The comment about who the transaction was sent to, if any",
 	"listtransactionsresult-otheraccount":       "Unset",
-	"listtransactionsresult-trusted":            "Unset",
+	"listtransactionsresult-trusted":            "Whether the transaction has at least the number of confirmations set by the trustedconfs option",
 	"listtransactionsresult-bip125-replaceable": "Unset",
 	"listtransactionsresult-abandoned":          "Unset",
 
@@ -271,6 +619,8 @@ var helpDescsEnUS = map[string]string{
 	"listunspentresult-amount":        "The amount of the output valued in bitcoin",
 	"listunspentresult-confirmations": "The number of block confirmations of the transaction",
 	"listunspentresult-spendable":     "Whether the output is entirely controlled by wallet keys/scripts (false for partially controlled multisig outputs or outputs to watch-only addresses)",
+	"listunspentresult-frozen":        "Whether the output pays to a frozen address, frozen outputs are not spendable",
+	"listunspentresult-dust":          "Whether the output is dust sent by others which is quarantined by ignoreincomingdust, quarantined outputs are not spendable",
 	"listunspentresult-blockHash":     "The hash of the block which the transaction was included in",
 	"listunspentresult-height":        "The height of the block which the transaction was included in",
 
@@ -291,10 +641,14 @@ var helpDescsEnUS = map[string]string{
 	"sendfrom-toaddress":     "Address to pay",
 	"sendfrom-amount":        "Amount to send to the payment address valued in bitcoin",
 	"sendfrom-minconf":       "Minimum number of block confirmations required before a transaction output is eligible to be spent",
-	"sendfrom-comment":       "Unused",
-	"sendfrom-commentto":     "Unused",
+	"sendfrom-comment":       "A comment about the transaction, kept only in the wallet and never broadcast",
+	"sendfrom-commentto":     "A comment about who the transaction is sent to, kept only in the wallet and never broadcast",
 	"sendfrom-maxinputs":     "Maximum number of transaction inputs that are allowed",
 	"sendfrom-minheight":     "Only select transactions from this height or above",
+	"sendfrom-allowselfsend": "Allow outputs paying addresses of this wallet when warnselfsend is set",
+	"sendfrom-changeinfo":    "Return an object with the txid and the change output rather than only the txid",
+	"sendfrom--condition0":   "changeinfo=false",
+	"sendfrom--condition1":   "changeinfo=true",
 	"sendfrom--result0":      "The transaction hash of the sent transaction",
 
 	// SendManyCmd help.
@@ -306,19 +660,162 @@ var helpDescsEnUS = map[string]string{
 	"sendmany-amounts--key":   "Address to pay",
 	"sendmany-amounts--value": "Amount to send to the payment address valued in bitcoin",
 	"sendmany-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent",
-	"sendmany-comment":        "Unused",
+	"sendmany-comment":        "A comment about the transaction, kept only in the wallet and never broadcast",
 	"sendmany-maxinputs":      "Maximum number of transaction inputs that are allowed",
+	"sendmany-allowselfsend":  "Allow outputs paying addresses of this wallet when warnselfsend is set",
+	"sendmany-changeinfo":     "Return an object with the txid and the change output rather than only the txid",
+	"sendmany--condition0":    "changeinfo=false",
+	"sendmany--condition1":    "changeinfo=true",
 	"sendmany--result0":       "The transaction hash of the sent transaction",
 
+	// SendFromUtxosCmd help.
+	"sendfromutxos--synopsis": "Authors, signs, and sends a transaction spending exactly the named unspent outputs of the wallet, all of them, to one or more payment addresses.\n" +
+		"Whatever the inputs have beyond the payments and the fee is returned as change. " +
+		"The inputs need not be confirmed, but fail if they are not unspent outputs of the wallet, are locked or frozen, or have too little to pay the outputs and fee.",
+	"sendfromutxos-inputs":         "The unspent outputs to spend",
+	"sendfromutxos-amounts":        "Pairs of payment addresses and the output amount to pay each",
+	"sendfromutxos-amounts--desc":  "JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address",
+	"sendfromutxos-amounts--key":   "Address to pay",
+	"sendfromutxos-amounts--value": "Amount to send to the payment address valued in bitcoin",
+	"sendfromutxos-feerate":        "The fee rate, either in coins per kilobyte or with a unit such as 10bit/vB, default is the relay fee",
+	"sendfromutxos-changeaddress":  "The address to pay the change to, default is the address set with setchangeaddress or else the address of one of the inputs",
+	"sendfromutxos-comment":        "A comment about the transaction, kept only in the wallet and never broadcast",
+	"sendfromutxos-allowselfsend":  "Allow outputs paying addresses of this wallet when warnselfsend is set",
+
+	// SendManyDetailedCmd help.
+	"sendmanydetailed--synopsis": "Authors, signs, and sends a transaction that outputs to many payment addresses, as sendmany does.\n" +
+		"The result describes the fee paid and attributes a share of it to each payment output in proportion to its amount.",
+	"sendmanydetailed-fromaddresses":  "Addresses to use for selecting coins to spend",
+	"sendmanydetailed-amounts":        "Pairs of payment addresses and the output amount to pay each",
+	"sendmanydetailed-amounts--desc":  "JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address",
+	"sendmanydetailed-amounts--key":   "Address to pay",
+	"sendmanydetailed-amounts--value": "Amount to send to the payment address valued in bitcoin",
+	"sendmanydetailed-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent",
+	"sendmanydetailed-maxinputs":      "Maximum number of transaction inputs that are allowed",
+	"sendmanydetailed-allowselfsend":  "Allow outputs paying addresses of this wallet when warnselfsend is set",
+
+	// SendManyDetailedResult help.
+	"sendmanydetailedresult-txid":          "The transaction hash of the sent transaction",
+	"sendmanydetailedresult-fee":           "The total fee paid by the transaction in bitcoin",
+	"sendmanydetailedresult-outputs":       "The payment outputs of the transaction",
+	"sendmanydetailedresult-changevout":    "The output index of the change output, or null if the transaction has no change",
+	"sendmanydetailedresult-changeaddress": "The address which the change was sent to, or null if the transaction has no change",
+
+	// SendManyDetailedOutput help.
+	"sendmanydetailedoutput-address": "The address paid by the output",
+	"sendmanydetailedoutput-vout":    "The output index",
+	"sendmanydetailedoutput-amount":  "The amount paid by the output in bitcoin",
+	"sendmanydetailedoutput-fee":     "The share of the fee attributed to the output in bitcoin, the shares sum to the total fee",
+
+	// GetBlockFilterCmd help.
+	"getblockfilter--synopsis": "Returns the BIP158 regular filter of a block and its filter header, as stored by neutrino.\n" +
+		"Only available with the neutrino backend, an error is returned if the filter headers have not been synced up to the block yet.",
+	"getblockfilter-blockhash": "The hash of the block",
+
+	// GetBlockFilterResult help.
+	"getblockfilterresult-filter": "The serialized filter encoded as hex",
+	"getblockfilterresult-header": "The filter header of the block",
+
+	// ExportAccountWatchOnlyCmd help.
+	"exportaccountwatchonly--synopsis": "Export an account as a bundle of the extended public key, address type and number of issued addresses of each key scope, which importdescriptor imports into a watch-only wallet.",
+	"exportaccountwatchonly-account":   "The account number to export",
+	"exportaccountwatchonly--result0":  "The account bundle",
+
+	// AccountDescriptorBundle help.
+	"accountdescriptorbundle-account":     "The name of the account",
+	"accountdescriptorbundle-descriptors": "The account in each key scope",
+
+	// AccountDescriptor help.
+	"accountdescriptor-scope":         "The key scope (m/purpose'/cointype')",
+	"accountdescriptor-addresstype":   "The type of address derived in the key scope (p2pkh, p2wpkh or p2sh-p2wpkh)",
+	"accountdescriptor-xpub":          "The extended public key of the account",
+	"accountdescriptor-externalcount": "The number of external addresses issued",
+	"accountdescriptor-internalcount": "The number of internal (change) addresses issued",
+
+	// ImportDescriptorCmd help.
+	"importdescriptor--synopsis": "Import an account exported by exportaccountwatchonly, deriving the same addresses to watch. The wallet must be watch only.",
+	"importdescriptor-bundle":    "The account bundle returned by exportaccountwatchonly",
+	"importdescriptor-account":   "The name to give the account, the name in the bundle if unset",
+	"importdescriptor-rescan":    "Resync the chain (since the second block) for the addresses derived",
+
+	// SimulateSendCmd help.
+	"simulatesend--synopsis": "Authors the transaction which sendmanydetailed would send with the same parameters, selecting the same inputs and change, but neither signs nor broadcasts it.\n" +
+		"The result describes the unsigned transaction, the outputs it spends, its change, its fee and its estimated virtual size once signed.",
+	"simulatesend-fromaddresses":  "Addresses to use for selecting coins to spend",
+	"simulatesend-amounts":        "Pairs of payment addresses and the output amount to pay each",
+	"simulatesend-amounts--desc":  "JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address",
+	"simulatesend-amounts--key":   "Address to pay",
+	"simulatesend-amounts--value": "Amount to send to the payment address valued in bitcoin",
+	"simulatesend-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent",
+	"simulatesend-maxinputs":      "Maximum number of transaction inputs that are allowed",
+	"simulatesend-allowselfsend":  "Allow outputs paying addresses of this wallet when warnselfsend is set",
+
+	// SimulateSendResult help.
+	"simulatesendresult-hex":           "The serialized unsigned transaction encoded as hex",
+	"simulatesendresult-txid":          "The hash of the unsigned transaction, which changes once it is signed unless all of its inputs are segwit",
+	"simulatesendresult-inputs":        "The outputs which the transaction spends",
+	"simulatesendresult-fee":           "The total fee paid by the transaction in bitcoin",
+	"simulatesendresult-vsize":         "The estimated virtual size of the transaction once signed",
+	"simulatesendresult-changevout":    "The output index of the change output, or null if the transaction has no change",
+	"simulatesendresult-changeaddress": "The address which the change would be sent to, or null if the transaction has no change",
+	"simulatesendresult-changeamount":  "The amount of the change output in bitcoin, or null if the transaction has no change",
+
+	// SimulateSendInput help.
+	"simulatesendinput-txid":    "The hash of the transaction containing the spent output",
+	"simulatesendinput-vout":    "The index of the spent output",
+	"simulatesendinput-address": "The address paid by the spent output",
+	"simulatesendinput-amount":  "The amount of the spent output in bitcoin",
+
+	// GetCoinSelectionPrivacyCmd help.
+	"getcoinselectionprivacy--synopsis": "Selects the inputs and change which sendmanydetailed would with the same parameters, as simulatesend does, and reports what the transaction would reveal about the wallet.\n" +
+		"Every address spent by the inputs is revealed to belong to the same wallet, so the fewer there are the more private the send.",
+	"getcoinselectionprivacy-fromaddresses":  "Addresses to use for selecting coins to spend",
+	"getcoinselectionprivacy-amounts":        "Pairs of payment addresses and the output amount to pay each",
+	"getcoinselectionprivacy-amounts--desc":  "JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address",
+	"getcoinselectionprivacy-amounts--key":   "Address to pay",
+	"getcoinselectionprivacy-amounts--value": "Amount to send to the payment address valued in bitcoin",
+	"getcoinselectionprivacy-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent",
+	"getcoinselectionprivacy-maxinputs":      "Maximum number of transaction inputs that are allowed",
+	"getcoinselectionprivacy-allowselfsend":  "Allow outputs paying addresses of this wallet when warnselfsend is set",
+
+	// GetCoinSelectionPrivacyResult help.
+	"getcoinselectionprivacyresult-inputs":         "The number of inputs the transaction would have",
+	"getcoinselectionprivacyresult-inputaddresses": "The number of distinct addresses spent by the inputs, which the transaction links together",
+	"getcoinselectionprivacyresult-haschange":      "Whether the transaction would have a change output",
+	"getcoinselectionprivacyresult-changerevealed": "Whether the change output can be told apart from the payments, revealing another address of the wallet",
+	"getcoinselectionprivacyresult-changereasons":  "How the change output can be told apart from the payments",
+
+	// SpendMaxCmd help.
+	"spendmax--synopsis": "Authors, signs, and sends a transaction paying all of the spendable outputs, less the fee, to a single address.\n" +
+		"This sends the most that a single payment can, the transaction has no change output.",
+	"spendmax-address":       "Address to pay",
+	"spendmax-fromaddresses": "Addresses to use for selecting coins to spend, all addresses are used if not specified",
+	"spendmax-minconf":       "Minimum number of block confirmations required before a transaction output is eligible to be spent",
+	"spendmax-allowselfsend": "Allow outputs paying addresses of this wallet when warnselfsend is set",
+
+	// SpendMaxResult help.
+	"spendmaxresult-txid":   "The transaction hash of the sent transaction",
+	"spendmaxresult-amount": "The amount paid to the address in bitcoin",
+	"spendmaxresult-fee":    "The fee paid by the transaction in bitcoin",
+
 	// SendToAddressCmd help.
 	"sendtoaddress--synopsis": "Authors, signs, and sends a transaction that outputs some amount to a payment address.\n" +
 		"Unlike sendfrom, outputs are always chosen from the default account.\n" +
 		"A change output is automatically included to send extra output value back to the original account.",
-	"sendtoaddress-address":   "Address to pay",
-	"sendtoaddress-amount":    "Amount to send to the payment address valued in bitcoin",
-	"sendtoaddress-comment":   "Unused",
-	"sendtoaddress-commentto": "Unused",
-	"sendtoaddress--result0":  "The transaction hash of the sent transaction",
+	"sendtoaddress-address":       "Address to pay",
+	"sendtoaddress-amount":        "Amount to send to the payment address valued in bitcoin",
+	"sendtoaddress-comment":       "A comment about the transaction, kept only in the wallet and never broadcast",
+	"sendtoaddress-commentto":     "A comment about who the transaction is sent to, kept only in the wallet and never broadcast",
+	"sendtoaddress-allowselfsend": "Allow outputs paying addresses of this wallet when warnselfsend is set",
+	"sendtoaddress-changeinfo":    "Return an object with the txid and the change output rather than only the txid",
+	"sendtoaddress--condition0":   "changeinfo=false",
+	"sendtoaddress--condition1":   "changeinfo=true",
+	"sendtoaddress--result0":      "The transaction hash of the sent transaction",
+
+	// SendResult help.
+	"sendresult-txid":          "The transaction hash of the sent transaction",
+	"sendresult-changevout":    "The output index of the change output, or null if the transaction has no change",
+	"sendresult-changeaddress": "The address which the change was sent to, or null if the transaction has no change",
 
 	// SetTxFeeCmd help.
 	"settxfee--synopsis": "Modify the increment used each time more fee is required for an authored transaction.",
@@ -394,6 +891,19 @@ var helpDescsEnUS = map[string]string{
 	"walletpassphrasechange-oldpassphrase": "The old wallet passphrase",
 	"walletpassphrasechange-newpassphrase": "The new wallet passphrase",
 
+	// RekeyWalletCmd help.
+	"rekeywallet--synopsis": "Re-encrypt the keys securing the private keys and seed of the wallet under a key derived from the same passphrase with new scrypt parameters, such as stronger ones than the wallet was created with.\n" +
+		"The wallet must be unlocked. N must be a power of two of at least 16384, r between 8 and 32, p between 1 and 16, and 128*N*r bytes of memory at most 1GiB.",
+	"rekeywallet-passphrase":     "The wallet passphrase",
+	"rekeywallet-n":              "The scrypt CPU and memory cost parameter",
+	"rekeywallet-r":              "The scrypt block size parameter",
+	"rekeywallet-p":              "The scrypt parallelization parameter",
+	"rekeywalletresult-previous": "The scrypt parameters which the wallet was encrypted with before",
+	"rekeywalletresult-current":  "The scrypt parameters which the wallet is now encrypted with",
+	"scryptparamsresult-n":       "The scrypt CPU and memory cost parameter",
+	"scryptparamsresult-r":       "The scrypt block size parameter",
+	"scryptparamsresult-p":       "The scrypt parallelization parameter",
+
 	// WalletMempoolCmd help.
 	"walletmempool--synopsis":    "Show the unconfirmed transactions which are being broadcasted by the wallet",
 	"walletmempoolitem-received": "The time when the transaction was first seen/made",
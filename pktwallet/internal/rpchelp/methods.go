@@ -26,13 +26,51 @@ var Methods = []struct {
 	{"createmultisig", []interface{}{(*btcjson.CreateMultiSigResult)(nil)}},
 	{"createtransaction", returnsString},
 	{"getaddressbalances", []interface{}{(*[]btcjson.GetAddressBalancesResult)(nil)}},
+	{"getaccountxpubs", []interface{}{(*[]btcjson.GetAccountXpubsResult)(nil)}},
+	{"listaccounts", []interface{}{(*[]btcjson.ListAccountsResult)(nil)}},
+	{"gettxproof", []interface{}{(*btcjson.GetTxProofResult)(nil)}},
+	{"gettxstatus", []interface{}{(*btcjson.GetTxStatusResult)(nil)}},
+	{"getmempoolancestors", []interface{}{(*btcjson.GetMempoolAncestorsResult)(nil)}},
+	{"verifytxproof", returnsBool},
+	{"estimateconfirmationtime", []interface{}{(*btcjson.EstimateConfirmationTimeResult)(nil)}},
+	{"estimateconsolidation", []interface{}{(*btcjson.EstimateConsolidationResult)(nil)}},
+	{"verifywallet", []interface{}{(*btcjson.VerifyWalletResult)(nil)}},
+	{"getbalanceatheight", []interface{}{(*btcjson.GetBalanceAtHeightResult)(nil)}},
+	{"verifypaymentrequest", []interface{}{(*btcjson.VerifyPaymentRequestResult)(nil)}},
+	{"createnewaccount", returnsNumber},
+	{"getstoragestats", []interface{}{(*btcjson.GetStorageStatsResult)(nil)}},
+	{"getrecoverystatus", []interface{}{(*btcjson.GetRecoveryStatusResult)(nil)}},
+	{"listrejectedtx", []interface{}{(*[]btcjson.ListRejectedTxResult)(nil)}},
+	{"deriveaddresses", []interface{}{(*[]btcjson.DeriveAddressesResult)(nil)}},
+	{"convertaddress", []interface{}{(*btcjson.ConvertAddressResult)(nil)}},
+	{"getfee", []interface{}{(*btcjson.GetFeeResult)(nil)}},
+	{"bumpfee", returnsString},
+	{"bumpfeecpfp", returnsString},
+	{"getbumpinfo", []interface{}{(*btcjson.GetBumpInfoResult)(nil)}},
+	{"getaccountstats", []interface{}{(*[]btcjson.AccountStatsResult)(nil)}},
+	{"getfeesource", []interface{}{(*btcjson.GetFeeSourceResult)(nil)}},
+	{"getfeestats", []interface{}{(*btcjson.GetFeeStatsResult)(nil)}},
+	{"getutxoages", []interface{}{(*btcjson.GetUtxoAgesResult)(nil)}},
+	{"exporttaxreport", []interface{}{(*[]btcjson.TaxDisposalResult)(nil)}},
+	{"exportlabels", []interface{}{(*[]btcjson.TxLabel)(nil)}},
+	{"importlabels", []interface{}{(*btcjson.ImportLabelsResult)(nil)}},
+	{"dumputxoset", []interface{}{(*btcjson.DumpUtxoSetResult)(nil)}},
+	{"getutxoinfo", []interface{}{(*btcjson.GetUtxoInfoResult)(nil)}},
+	{"listauxoutputs", []interface{}{(*[]btcjson.ListAuxOutputsResult)(nil)}},
+	{"listpendingtransactions", []interface{}{(*[]btcjson.ListPendingTransactionsResult)(nil)}},
 	{"setnetworkstewardvote", []interface{}{(*btcjson.SetNetworkStewardVoteResult)(nil)}},
 	{"getnetworkstewardvote", []interface{}{(*btcjson.GetNetworkStewardVoteResult)(nil)}},
+	{"rescanaddress", nil},
+	{"setmaintenancemode", nil},
 	{"resync", nil},
 	{"stopresync", returnsString},
+	{"cancelrescan", returnsString},
+	{"pausesync", nil},
+	{"getpeerinfo", []interface{}{(*[]btcjson.GetPeerInfoResult)(nil)}},
+	{"resumesync", nil},
 	{"addp2shscript", returnsString},
 	{"dumpprivkey", returnsString},
-	{"getbalance", append(returnsNumber, returnsNumber[0])},
+	{"getbalance", []interface{}{returnsNumber[0], (*btcjson.GetBalanceResult)(nil)}},
 	{"getbestblockhash", returnsString},
 	{"getblockcount", returnsNumber},
 	{"getinfo", []interface{}{(*btcjson.InfoWalletResult)(nil)}},
@@ -40,8 +78,10 @@ var Methods = []struct {
 	{"getreceivedbyaddress", returnsNumber},
 	{"gettransaction", []interface{}{(*btcjson.GetTransactionResult)(nil)}},
 	{"getwalletseed", returnsString},
+	{"backupseedencrypted", returnsString},
 	{"getsecret", returnsString},
 	{"help", append(returnsString, returnsString[0])},
+	{"importaddress", nil},
 	{"importprivkey", nil},
 	{"listlockunspent", []interface{}{(*[]btcjson.TransactionInput)(nil)}},
 	{"listreceivedbyaddress", []interface{}{(*[]btcjson.ListReceivedByAddressResult)(nil)}},
@@ -49,17 +89,33 @@ var Methods = []struct {
 	{"listtransactions", returnsLTRArray},
 	{"listunspent", []interface{}{(*btcjson.ListUnspentResult)(nil)}},
 	{"lockunspent", returnsBool},
-	{"sendfrom", returnsString},
-	{"sendmany", returnsString},
-	{"sendtoaddress", returnsString},
+	{"markaddressused", nil},
+	{"markaddressunused", nil},
+	{"freezeaddress", nil},
+	{"unfreezeaddress", nil},
+	{"listfrozenaddresses", []interface{}{(*[]string)(nil)}},
+	{"getchangeaddress", returnsString},
+	{"setchangeaddress", nil},
+	{"sendfrom", []interface{}{(*string)(nil), (*btcjson.SendResult)(nil)}},
+	{"sendmany", []interface{}{(*string)(nil), (*btcjson.SendResult)(nil)}},
+	{"sendmanydetailed", []interface{}{(*btcjson.SendManyDetailedResult)(nil)}},
+	{"sendfromutxos", []interface{}{(*btcjson.SendResult)(nil)}},
+	{"sendtoaddress", []interface{}{(*string)(nil), (*btcjson.SendResult)(nil)}},
 	{"settxfee", returnsBool},
 	{"signmessage", returnsString},
 	{"signrawtransaction", []interface{}{(*btcjson.SignRawTransactionResult)(nil)}},
+	{"simulatesend", []interface{}{(*btcjson.SimulateSendResult)(nil)}},
+	{"getcoinselectionprivacy", []interface{}{(*btcjson.GetCoinSelectionPrivacyResult)(nil)}},
+	{"getblockfilter", []interface{}{(*btcjson.GetBlockFilterResult)(nil)}},
+	{"spendmax", []interface{}{(*btcjson.SpendMaxResult)(nil)}},
+	{"exportaccountwatchonly", []interface{}{(*btcjson.AccountDescriptorBundle)(nil)}},
+	{"importdescriptor", nil},
 	{"validateaddress", []interface{}{(*btcjson.ValidateAddressWalletResult)(nil)}},
 	{"verifymessage", returnsBool},
 	{"walletlock", nil},
 	{"walletpassphrase", nil},
 	{"walletpassphrasechange", nil},
+	{"rekeywallet", []interface{}{(*btcjson.RekeyWalletResult)(nil)}},
 	{"walletmempool", []interface{}{(*btcjson.WalletMempoolRes)(nil)}},
 	{"exportwatchingwallet", returnsString},
 	{"getbestblock", []interface{}{(*btcjson.GetBestBlockResult)(nil)}},
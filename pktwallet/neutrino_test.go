@@ -0,0 +1,170 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkt-cash/pktd/addrmgr"
+	"github.com/pkt-cash/pktd/neutrino"
+	"github.com/pkt-cash/pktd/pktwallet/internal/cfgutil"
+	"github.com/pkt-cash/pktd/wire/protocol"
+)
+
+// TestNeutrinoDNSSeeds ensures that the dnsseed option replaces the network's
+// DNS seeds in the neutrino config and that nodnsseed removes them.
+func TestNeutrinoDNSSeeds(t *testing.T) {
+	defer func(old *config) { cfg = old }(cfg)
+
+	cfg = &config{}
+	defaults := neutrinoConfig("", nil).ChainParams.DNSSeeds
+	if len(defaults) == 0 || len(defaults) != len(activeNet.Params.DNSSeeds) {
+		t.Fatalf("got %d default seeds, want the network's %d", len(defaults),
+			len(activeNet.Params.DNSSeeds))
+	}
+
+	cfg = &config{DNSSeeds: []string{"seed1.example.com", "seed2.example.com"}}
+	seeds := neutrinoConfig("", nil).ChainParams.DNSSeeds
+	if len(seeds) != 2 {
+		t.Fatalf("got %d seeds, want 2", len(seeds))
+	}
+	for i, seed := range seeds {
+		if seed.Host != cfg.DNSSeeds[i] {
+			t.Fatalf("got seed %s, want %s", seed.Host, cfg.DNSSeeds[i])
+		}
+	}
+	if len(activeNet.Params.DNSSeeds) != len(defaults) {
+		t.Fatalf("network params were modified")
+	}
+
+	cfg = &config{NoDNSSeed: true, AddPeers: []string{"127.0.0.1"}}
+	nc := neutrinoConfig("", nil)
+	if len(nc.ChainParams.DNSSeeds) != 0 {
+		t.Fatalf("got %d seeds with nodnsseed, want none",
+			len(nc.ChainParams.DNSSeeds))
+	}
+	if len(nc.AddPeers) != 1 {
+		t.Fatalf("got %d peers, want 1", len(nc.AddPeers))
+	}
+}
+
+// TestNeutrinoPeerLimits ensures that the maxinbound and maxoutbound options
+// reach the neutrino config and that the outbound connections are capped by
+// both maxoutbound and maxpeers.
+func TestNeutrinoPeerLimits(t *testing.T) {
+	defer func(old *config) { cfg = old }(cfg)
+	defer func(old int) { neutrino.MaxPeers = old }(neutrino.MaxPeers)
+
+	tests := []struct {
+		maxPeers    int
+		maxInbound  int
+		maxOutbound int
+		want        int
+	}{
+		{maxPeers: 20, maxInbound: 3, maxOutbound: 8, want: 8},
+		{maxPeers: 4, maxInbound: 0, maxOutbound: 8, want: 4},
+		{maxPeers: 20, maxInbound: 12, maxOutbound: 0, want: neutrino.TargetOutbound},
+	}
+	for _, test := range tests {
+		cfg = &config{MaxPeers: test.maxPeers, MaxInbound: test.maxInbound,
+			MaxOutbound: test.maxOutbound}
+		neutrino.MaxPeers = cfg.MaxPeers
+		nc := neutrinoConfig("", nil)
+		if nc.MaxInbound != test.maxInbound {
+			t.Fatalf("got max inbound %d, want %d", nc.MaxInbound,
+				test.maxInbound)
+		}
+		if nc.MaxOutbound != test.maxOutbound {
+			t.Fatalf("got max outbound %d, want %d", nc.MaxOutbound,
+				test.maxOutbound)
+		}
+		if n := nc.OutboundTarget(); n != test.want {
+			t.Fatalf("got %d outbound connections with maxpeers %d and "+
+				"maxoutbound %d, want %d", n, test.maxPeers,
+				test.maxOutbound, test.want)
+		}
+	}
+}
+
+// TestNeutrinoNetMagic ensures that the netmagic option replaces the network's
+// magic in the neutrino config and that malformed magics are rejected.
+func TestNeutrinoNetMagic(t *testing.T) {
+	defer func(old *config) { cfg = old }(cfg)
+
+	cfg = &config{NetMagic: cfgutil.NewNetMagicFlag()}
+	if net := neutrinoConfig("", nil).ChainParams.Net; net != activeNet.Params.Net {
+		t.Fatalf("got magic %v without netmagic, want the network's %v",
+			net, activeNet.Params.Net)
+	}
+
+	defaultNet := activeNet.Params.Net
+	for _, value := range []string{"0x0b110907", "0B110907"} {
+		magic := cfgutil.NewNetMagicFlag()
+		if err := magic.UnmarshalFlag(value); err != nil {
+			t.Fatalf("unable to parse magic %s: %v", value, err)
+		}
+		cfg = &config{NetMagic: magic}
+		if net := neutrinoConfig("", nil).ChainParams.Net; net != protocol.BitcoinNet(0x0b110907) {
+			t.Fatalf("got magic %v with netmagic %s, want 0x0b110907",
+				net, value)
+		}
+	}
+	if activeNet.Params.Net != defaultNet {
+		t.Fatalf("network params were modified")
+	}
+
+	for _, value := range []string{"", "0x", "b110907", "0x0b1109070", "0xg0b11090"} {
+		if err := cfgutil.NewNetMagicFlag().UnmarshalFlag(value); err == nil {
+			t.Fatalf("parsed malformed magic %q", value)
+		}
+	}
+}
+
+// TestNeutrinoPersistPeers ensures that the addresses known to neutrino's
+// address manager are written to the network directory on shutdown and loaded
+// again on the next startup, and that they are not kept with nopersistpeers.
+func TestNeutrinoPersistPeers(t *testing.T) {
+	defer func(old *config) { cfg = old }(cfg)
+
+	netDir, errr := ioutil.TempDir("", "persistpeers")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer os.RemoveAll(netDir)
+
+	cfg = &config{NoPersistPeers: true}
+	if nc := neutrinoConfig(netDir, nil); nc.PeersFile != "" {
+		t.Fatalf("got peers file %s with nopersistpeers", nc.PeersFile)
+	}
+	amgr := addrmgr.NewWithPeersFile("", nil)
+	amgr.Start()
+	amgr.AddAddressByIP("173.194.115.66:8333")
+	amgr.Stop()
+	if files, _ := ioutil.ReadDir(netDir); len(files) != 0 {
+		t.Fatalf("got %d files with nopersistpeers, want none", len(files))
+	}
+
+	cfg = &config{}
+	nc := neutrinoConfig(netDir, nil)
+	if nc.PeersFile != filepath.Join(netDir, "peers.json") {
+		t.Fatalf("got peers file %s, want peers.json in %s", nc.PeersFile,
+			netDir)
+	}
+	amgr = addrmgr.NewWithPeersFile(nc.PeersFile, nil)
+	amgr.Start()
+	if err := amgr.AddAddressByIP("173.194.115.66:8333"); err != nil {
+		t.Fatalf("unable to add address: %v", err)
+	}
+	amgr.Stop()
+	if _, errr := os.Stat(nc.PeersFile); errr != nil {
+		t.Fatalf("peers not written on shutdown: %v", errr)
+	}
+
+	amgr = addrmgr.NewWithPeersFile(nc.PeersFile, nil)
+	amgr.Start()
+	defer amgr.Stop()
+	if n := amgr.NumAddresses(); n != 1 {
+		t.Fatalf("got %d addresses after restart, want 1", n)
+	}
+}
@@ -11,15 +11,18 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
 	"sync"
+	"syscall"
 
 	"github.com/pkt-cash/pktd/btcutil/er"
 	"github.com/pkt-cash/pktd/pktconfig/version"
 	"github.com/pkt-cash/pktd/pktlog/log"
 
 	"github.com/arl/statsviz"
+	"github.com/pkt-cash/pktd/chaincfg"
 	"github.com/pkt-cash/pktd/neutrino"
 	"github.com/pkt-cash/pktd/pktwallet/chain"
 	"github.com/pkt-cash/pktd/pktwallet/rpc/legacyrpc"
@@ -97,6 +100,11 @@ func walletMain() er.R {
 	dbDir := networkDir(cfg.AppDataDir.Value, activeNet.Params)
 	// TODO(cjd): noFreelistSync ?
 	loader := wallet.NewLoader(activeNet.Params, dbDir, cfg.Wallet, false, 250)
+	loader.SetConfig(cfg.walletConfig)
+
+	if cfg.HealthPort != "" {
+		startHealthServer(cfg.HealthPort, loader)
+	}
 
 	// Create and start HTTP server to serve wallet client connections.
 	// This will be updated with the wallet and chain server RPC client
@@ -113,6 +121,11 @@ func walletMain() er.R {
 		go rpcClientConnectLoop(legacyRPCServer, loader)
 	}
 
+	if cfg.Maintenance {
+		loader.RunAfterLoad(func(w *wallet.Wallet) {
+			w.SetMaintenanceMode(true)
+		})
+	}
 	loader.RunAfterLoad(func(w *wallet.Wallet) {
 		startWalletRPCServices(w, rpcs, legacyRPCServer)
 	})
@@ -127,8 +140,29 @@ func walletMain() er.R {
 		}
 	}
 
-	ch := make(chan bool)
-	<-ch
+	// Wait for an interrupt or a stop request over RPC, then unload the
+	// wallet so that the database is closed cleanly and any updates which
+	// are waiting for their batch are committed.
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	var stopRequested <-chan struct{}
+	if legacyRPCServer != nil {
+		stopRequested = legacyRPCServer.RequestProcessShutdown()
+	}
+	select {
+	case sig := <-interrupt:
+		log.Infof("Received signal (%s).  Shutting down...", sig)
+	case <-stopRequested:
+		log.Info("Received stop request.  Shutting down...")
+	}
+	// Stop answering requests before the wallet is unloaded, this also
+	// closes the RPC access log.
+	if legacyRPCServer != nil {
+		legacyRPCServer.Stop()
+	}
+	if err := loader.UnloadWallet(); err != nil && !wallet.ErrNotLoaded.Is(err) {
+		log.Errorf("Unable to unload wallet: %v", err)
+	}
 
 	log.Info("Shutdown complete")
 	return nil
@@ -149,15 +183,13 @@ func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Load
 
 	for {
 		var (
-			chainClient chain.Interface
-			err         er.R
+			chainClient  chain.Interface
+			chainService *neutrino.ChainService
+			err          er.R
 		)
 
 		if !cfg.UseRPC {
-			var (
-				chainService *neutrino.ChainService
-				spvdb        walletdb.DB
-			)
+			var spvdb walletdb.DB
 			netDir := networkDir(cfg.AppDataDir.Value, activeNet.Params)
 			spvdb, err = walletdb.Create("bdb",
 				filepath.Join(netDir, "neutrino.db"), false)
@@ -166,20 +198,15 @@ func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Load
 				log.Errorf("Unable to create Neutrino DB: %s", err)
 				continue
 			}
-			cp := cfg.ConnectPeers
 			chainService, err = neutrino.NewChainService(
-				neutrino.Config{
-					DataDir:      netDir,
-					Database:     spvdb,
-					ChainParams:  *activeNet.Params,
-					ConnectPeers: cp,
-					AddPeers:     cfg.AddPeers,
-				})
+				neutrinoConfig(netDir, spvdb))
 			if err != nil {
 				log.Errorf("Couldn't create Neutrino ChainService: %s", err)
 				continue
 			}
-			chainClient = chain.NewNeutrinoClient(activeNet.Params, chainService)
+			neutrinoClient := chain.NewNeutrinoClient(activeNet.Params, chainService)
+			neutrinoClient.PruneRescan = cfg.PruneRescan
+			chainClient = neutrinoClient
 			err = chainClient.Start()
 			if err != nil {
 				log.Errorf("Couldn't start Neutrino client: %s", err)
@@ -216,6 +243,13 @@ func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Load
 
 		chainClient.WaitForShutdown()
 
+		// The chain service belongs to this loop rather than to its
+		// client, it is stopped here so that the addresses of known
+		// peers are saved.
+		if chainService != nil {
+			chainService.Stop()
+		}
+
 		mu.Lock()
 		associateRPCClient = nil
 		mu.Unlock()
@@ -239,6 +273,43 @@ func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Load
 	}
 }
 
+// neutrinoConfig returns the configuration of the neutrino chain service.  The
+// network's DNS seeds are replaced by those of the dnsseed option, or removed
+// if nodnsseed is set, and the network magic is replaced by that of the
+// netmagic option.  Known peers are kept in netDir unless nopersistpeers is
+// set.
+func neutrinoConfig(netDir string, db walletdb.DB) neutrino.Config {
+	params := *activeNet.Params
+	if cfg.NetMagic.IsSet() {
+		params.Net = cfg.NetMagic.Net
+	}
+	if cfg.NoDNSSeed {
+		params.DNSSeeds = nil
+	} else if len(cfg.DNSSeeds) > 0 {
+		params.DNSSeeds = make([]chaincfg.DNSSeed, 0, len(cfg.DNSSeeds))
+		for _, host := range cfg.DNSSeeds {
+			params.DNSSeeds = append(params.DNSSeeds, chaincfg.DNSSeed{
+				Host: host,
+			})
+		}
+	}
+	var peersFile string
+	if !cfg.NoPersistPeers {
+		peersFile = filepath.Join(netDir, "peers.json")
+	}
+	return neutrino.Config{
+		DataDir:        netDir,
+		PeersFile:      peersFile,
+		Database:       db,
+		ChainParams:    params,
+		ConnectPeers:   cfg.ConnectPeers,
+		AddPeers:       cfg.AddPeers,
+		MaxInbound:     cfg.MaxInbound,
+		MaxOutbound:    cfg.MaxOutbound,
+		BroadcastPeers: cfg.BroadcastPeers,
+	}
+}
+
 func readCAFile() []byte {
 	// Read certificate file if TLS is not disabled.
 	var certs []byte
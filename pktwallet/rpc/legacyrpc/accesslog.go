@@ -0,0 +1,126 @@
+package legacyrpc
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/pkt-cash/pktd/btcjson"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktlog/log"
+)
+
+// accessLogEntry is a line of the access log, written for each call to a
+// method.
+type accessLogEntry struct {
+	Time       string        `json:"time"`
+	Method     string        `json:"method"`
+	User       string        `json:"user"`
+	Remote     string        `json:"remote"`
+	DurationMS float64       `json:"durationms"`
+	Status     string        `json:"status"`
+	Code       int           `json:"code,omitempty"`
+	Params     []interface{} `json:"params"`
+}
+
+// redacted replaces the value of a secret parameter in the access log.
+const redacted = "[redacted]"
+
+// secretParamNames are parts of the names of command fields whose values are
+// never written to the access log.
+var secretParamNames = []string{"passphrase", "password", "privkey", "seed",
+	"secret"}
+
+func isSecretParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range secretParamNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactedParams returns the parameters of a request for the access log with
+// those holding secrets redacted.  The parameters of a request which cannot be
+// parsed as a known command are left out entirely, since which of them are
+// secret is not known.
+func redactedParams(req *btcjson.Request) []interface{} {
+	cmd, err := btcjson.UnmarshalCmd(req)
+	if err != nil {
+		return nil
+	}
+	rt := reflect.TypeOf(cmd).Elem()
+	params := make([]interface{}, len(req.Params))
+	for i, p := range req.Params {
+		if i < rt.NumField() && isSecretParam(rt.Field(i).Name) {
+			params[i] = redacted
+		} else {
+			params[i] = p
+		}
+	}
+	return params
+}
+
+// accessLogged wraps a handler to write an entry to the access log when it
+// returns, if access logging is enabled.
+func (s *Server) accessLogged(req *btcjson.Request, user, remote string,
+	f lazyHandler) lazyHandler {
+
+	if s.accessLog == nil {
+		return f
+	}
+	return func() (interface{}, er.R) {
+		start := time.Now()
+		res, jsonErr := f()
+		entry := accessLogEntry{
+			Time:       start.UTC().Format(time.RFC3339Nano),
+			Method:     req.Method,
+			User:       user,
+			Remote:     remote,
+			DurationMS: float64(time.Since(start)) / float64(time.Millisecond),
+			Status:     "ok",
+			Params:     redactedParams(req),
+		}
+		if rpcErr := btcjson.SerializeError(jsonErr); rpcErr != nil {
+			entry.Status = "error"
+			entry.Code = rpcErr.Code
+		}
+		b, errr := jsoniter.Marshal(&entry)
+		if errr != nil {
+			log.Errorf("Unable to marshal access log entry: %v", errr)
+			return res, jsonErr
+		}
+		s.accessLogMtx.Lock()
+		if s.accessLogClosed {
+			s.accessLogMtx.Unlock()
+			return res, jsonErr
+		}
+		_, errr = s.accessLog.Write(append(b, '\n'))
+		s.accessLogMtx.Unlock()
+		if errr != nil {
+			log.Warnf("Unable to write access log: %v", errr)
+		}
+		return res, jsonErr
+	}
+}
+
+// closeAccessLog closes the access log, if it is an io.Closer such as the file
+// opened for --rpcaccesslog, so that the lines written to it are not lost on
+// exit.  Calls which return afterwards are not logged.
+func (s *Server) closeAccessLog() {
+	s.accessLogMtx.Lock()
+	defer s.accessLogMtx.Unlock()
+	if s.accessLogClosed {
+		return
+	}
+	s.accessLogClosed = true
+	if c, ok := s.accessLog.(io.Closer); ok {
+		if errr := c.Close(); errr != nil {
+			log.Errorf("Cannot close access log: %v", errr)
+		}
+	}
+}
@@ -4,11 +4,46 @@
 
 package legacyrpc
 
+import (
+	"io"
+	"time"
+)
+
 // Options contains the required options for running the legacy RPC server.
 type Options struct {
 	Username string
 	Password string
 
+	// RPCAuths are hashed credentials which are accepted in addition to
+	// Username and Password.
+	RPCAuths []*RPCAuth
+
 	MaxPOSTClients      int64
 	MaxWebsocketClients int64
+
+	// Compress enables gzip compression of HTTP POST responses for clients
+	// which send Accept-Encoding: gzip.  Websocket traffic is unaffected.
+	Compress bool
+
+	// WaitForSync refuses requests for balances until the wallet has synced
+	// to the tip of the chain.
+	WaitForSync bool
+
+	// MethodTimeouts limits how long a method may run, requests which take
+	// longer are answered with btcjson.ErrRPCTimeout.
+	MethodTimeouts map[string]time.Duration
+
+	// AccessLog is written a line of JSON for each call to a method, giving
+	// the method, user, remote address, duration and status of the call.
+	// Secret parameters such as passphrases are redacted.  Calls are not
+	// logged when it is nil.  If it is an io.Closer it is closed when the
+	// server is stopped.
+	AccessLog io.Writer
+
+	// NotifyQueueSize is the number of notifications which may be queued
+	// for a websocket client which is slow to read them, when the queue
+	// is full NotifyOverflow says what is done.  Zero means
+	// DefaultNotifyQueueSize.
+	NotifyQueueSize int
+	NotifyOverflow  NotifyOverflow
 }
@@ -42,6 +42,3 @@ func errNotImportedAccount() er.R {
 	return btcjson.ErrRPCWallet.New("imported addresses must belong to the imported account", nil)
 }
 
-func errCommentsUnsupported() er.R {
-	return btcjson.ErrRPCUnimplemented.New("Transaction comments are not yet supported", nil)
-}
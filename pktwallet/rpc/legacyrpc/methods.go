@@ -10,7 +10,9 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,8 +28,12 @@ import (
 	"github.com/pkt-cash/pktd/btcec"
 	"github.com/pkt-cash/pktd/btcjson"
 	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/hdkeychain"
+	"github.com/pkt-cash/pktd/btcutil/paymentrequest"
 	"github.com/pkt-cash/pktd/chaincfg"
 	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/neutrino"
+	"github.com/pkt-cash/pktd/peer"
 	"github.com/pkt-cash/pktd/pktwallet/chain"
 	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
 	"github.com/pkt-cash/pktd/pktwallet/wallet"
@@ -65,11 +71,18 @@ type handlerRPC func(interface{}, *wallet.Wallet, *chain.RPCClient) (interface{}
 
 type handlerNeutrino func(interface{}, *wallet.Wallet, *chain.NeutrinoClient) (interface{}, er.R)
 
+// handlerQuit is a requestHandler for a method which can run long, it stops
+// and returns btcjson.ErrRPCTimeout once quit is closed.  The quit channel is
+// closed when the method's --rpcmethodtimeout passes, it is nil if the method
+// has no timeout.
+type handlerQuit func(interface{}, *wallet.Wallet, <-chan struct{}) (interface{}, er.R)
+
 var rpcHandlers = map[string]struct {
 	handler         requestHandler
 	handlerChain    handlerChain
 	handlerRPC      handlerRPC
 	handlerNeutrino handlerNeutrino
+	handlerQuit     handlerQuit
 
 	// Function variables cannot be compared against anything but nil, so
 	// use a boolean to record whether help generation is necessary.  This
@@ -93,6 +106,7 @@ var rpcHandlers = map[string]struct {
 	"getreceivedbyaddress":   {handler: getReceivedByAddress},
 	"gettransaction":         {handler: getTransaction},
 	"help":                   {handler: helpNoChainRPC, handlerRPC: helpWithChainRPC},
+	"importaddress":          {handler: importAddress},
 	"importprivkey":          {handler: importPrivKey},
 	"listlockunspent":        {handler: listLockUnspent},
 	"listreceivedbyaddress":  {handler: listReceivedByAddress},
@@ -102,6 +116,13 @@ var rpcHandlers = map[string]struct {
 	"lockunspent":            {handler: lockUnspent},
 	"sendfrom":               {handler: sendFrom},
 	"sendmany":               {handler: sendMany},
+	"sendmanydetailed":       {handler: sendManyDetailed},
+	"sendfromutxos":          {handler: sendFromUtxos},
+	"simulatesend":           {handler: simulateSend},
+	"getblockfilter":         {handlerNeutrino: getBlockFilter},
+	"spendmax":               {handler: spendMax},
+	"exportaccountwatchonly": {handler: exportAccountWatchOnly},
+	"importdescriptor":       {handler: importDescriptor},
 	"sendtoaddress":          {handler: sendToAddress},
 	"settxfee":               {handler: setTxFee},
 	"signmessage":            {handler: signMessage},
@@ -117,13 +138,63 @@ var rpcHandlers = map[string]struct {
 	"setnetworkstewardvote": {handler: setNetworkStewardVote},
 	"getnetworkstewardvote": {handler: getNetworkStewardVote},
 	"addp2shscript":         {handler: addP2shScript},
+	"createnewaccount":      {handler: createNewAccount},
 	"createtransaction":     {handler: createTransaction},
-	"resync":                {handler: resync},
+	"rescanaddress":         {handlerQuit: rescanAddress},
+	"resync":                {handlerQuit: resync},
 	"stopresync":            {handler: stopResync},
+	"cancelrescan":          {handler: cancelRescan},
+	"pausesync":             {handlerNeutrino: pauseSync},
+	"resumesync":            {handlerNeutrino: resumeSync},
+	"getpeerinfo":           {handlerNeutrino: getPeerInfo},
 	"getaddressbalances":    {handler: getAddressBalances},
+	"getaccountxpubs":       {handler: getAccountXpubs},
+	"listaccounts":          {handler: listAccounts},
+	"markaddressused":       {handler: markAddressUsed},
+	"markaddressunused":     {handler: markAddressUnused},
+	"freezeaddress":         {handler: freezeAddress},
+	"unfreezeaddress":       {handler: unfreezeAddress},
+	"listfrozenaddresses":   {handler: listFrozenAddresses},
+	"getchangeaddress":      {handler: getChangeAddress},
+	"setchangeaddress":      {handler: setChangeAddress},
+	"gettxproof":            {handler: getTxProof},
+	"gettxstatus":           {handler: getTxStatus},
+	"getmempoolancestors":   {handler: getMempoolAncestors},
+	"verifytxproof":         {handler: verifyTxProof},
 	"getwalletseed":         {handler: getWalletSeed},
+	"backupseedencrypted":   {handler: backupSeedEncrypted},
 	"getsecret":             {handler: getSecret},
 	"walletmempool":         {handler: walletMempool},
+	"verifywallet":          {handler: verifyWallet},
+	"getbalanceatheight":    {handler: getBalanceAtHeight},
+	"verifypaymentrequest":  {handler: verifyPaymentRequest},
+	"getstoragestats":       {handler: getStorageStats},
+	"getrecoverystatus":     {handler: getRecoveryStatus},
+	"listrejectedtx":        {handler: listRejectedTx},
+	"deriveaddresses":       {handler: deriveAddresses},
+	"convertaddress":        {handler: convertAddress},
+	"getfeestats":           {handler: getFeeStats},
+	"getutxoages":           {handler: getUtxoAges},
+	"getfeesource":          {handler: getFeeSource, handlerRPC: getFeeSourceRPC},
+	"getfee": {handler: getFee, handlerRPC: getFeeRPC,
+		handlerNeutrino: getFeeNeutrino},
+	"getbumpinfo":           {handler: getBumpInfo},
+	"bumpfee":               {handler: bumpFee},
+	"bumpfeecpfp":           {handler: bumpFeeCPFP},
+	"getaccountstats":       {handler: getAccountStats},
+	"exporttaxreport":       {handler: exportTaxReport},
+	"exportlabels":          {handler: exportLabels},
+	"importlabels":          {handler: importLabels},
+	"estimateconsolidation": {handler: estimateConsolidation},
+	"listauxoutputs":        {handler: listAuxOutputs},
+	"dumputxoset":           {handlerQuit: dumpUtxoSet},
+	"getutxoinfo":           {handler: getUtxoInfo},
+	"setmaintenancemode":    {handler: setMaintenanceMode},
+	"estimateconfirmationtime": {handler: estimateConfirmationTime,
+		handlerRPC: estimateConfirmationTimeRPC},
+	"listpendingtransactions": {handler: listPendingTransactions},
+	"rekeywallet":             {handler: rekeyWallet},
+	"getcoinselectionprivacy": {handler: getCoinSelectionPrivacy},
 	// This was an extension but the reference implementation added it as
 	// well, but with a different API (no account parameter).  It's listed
 	// here because it hasn't been update to use the reference
@@ -139,22 +210,31 @@ var rpcHandlers = map[string]struct {
 // context.
 type lazyHandler func() (interface{}, er.R)
 
+// quitHandler is a lazyHandler which is given the quit channel of its
+// request, it is made into a lazyHandler by withTimeout.
+type quitHandler func(quit <-chan struct{}) (interface{}, er.R)
+
 // lazyApplyHandler looks up the best request handler func for the method,
 // returning a closure that will execute it with the (required) wallet and
 // (optional) consensus RPC server.  If no handlers are found and the
 // chainClient is not nil, the returned handler performs RPC passthrough.
-func lazyApplyHandler(request *btcjson.Request, w *wallet.Wallet, chainClient chain.Interface) lazyHandler {
+func lazyApplyHandler(request *btcjson.Request, w *wallet.Wallet, chainClient chain.Interface) quitHandler {
 	hndlr, ok := rpcHandlers[request.Method]
 	var err er.R
-	unm := func(f func(interface{}) (interface{}, er.R)) func() (interface{}, er.R) {
-		return func() (interface{}, er.R) {
+	unmQuit := func(f handlerQuit) quitHandler {
+		return func(quit <-chan struct{}) (interface{}, er.R) {
 			if cmd, err := btcjson.UnmarshalCmd(request); err != nil {
 				return nil, btcjson.ErrRPCInvalidRequest.Default()
 			} else {
-				return f(cmd)
+				return f(cmd, w, quit)
 			}
 		}
 	}
+	unm := func(f func(interface{}) (interface{}, er.R)) quitHandler {
+		return unmQuit(func(cmd interface{}, _ *wallet.Wallet, _ <-chan struct{}) (interface{}, er.R) {
+			return f(cmd)
+		})
+	}
 	if w == nil {
 		err = btcjson.ErrRPCMisc.New("The wallet is not loaded", nil)
 	} else if !ok {
@@ -172,6 +252,8 @@ func lazyApplyHandler(request *btcjson.Request, w *wallet.Wallet, chainClient ch
 	if err != nil {
 	} else if hndlr.handler != nil {
 		return unm(func(cmd interface{}) (interface{}, er.R) { return hndlr.handler(cmd, w) })
+	} else if hndlr.handlerQuit != nil {
+		return unmQuit(hndlr.handlerQuit)
 	} else if hndlr.handlerChain != nil {
 		err = btcjson.ErrRPCMisc.New("The wallet is still initializing...", nil)
 	} else if hndlr.handlerNeutrino != nil {
@@ -182,7 +264,7 @@ func lazyApplyHandler(request *btcjson.Request, w *wallet.Wallet, chainClient ch
 	if err == nil {
 		err = btcjson.ErrRPCMisc.New("This RPC is has no handlers (internal error)", nil)
 	}
-	return func() (interface{}, er.R) {
+	return func(<-chan struct{}) (interface{}, er.R) {
 		return nil, err
 	}
 }
@@ -324,6 +406,121 @@ func createMultiSig(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	}, nil
 }
 
+// addressTypeScopes maps the address types which may be given as the default
+// of an account to the key scope which creates them.
+var addressTypeScopes = map[string]waddrmgr.KeyScope{
+	"p2pkh":       waddrmgr.KeyScopeBIP0044,
+	"legacy":      waddrmgr.KeyScopeBIP0044,
+	"p2sh-p2wpkh": waddrmgr.KeyScopeBIP0049Plus,
+	"p2wpkh":      waddrmgr.KeyScopeBIP0084,
+	"segwit":      waddrmgr.KeyScopeBIP0084,
+}
+
+// createNewAccount handles a createnewaccount request by creating a new
+// account with the given name and, optionally, the default type of the
+// addresses which getnewaddress creates for it.
+func createNewAccount(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.CreateNewAccountCmd)
+
+	var addrScope *waddrmgr.KeyScope
+	if cmd.AddressType != nil {
+		scope, ok := addressTypeScopes[*cmd.AddressType]
+		if !ok {
+			return nil, btcjson.ErrRPCInvalidParameter.New("unknown address "+
+				"type ["+*cmd.AddressType+"], expected one of p2pkh, "+
+				"p2sh-p2wpkh or p2wpkh", nil)
+		}
+		addrScope = &scope
+	}
+	return w.NextAccount(cmd.Account, addrScope)
+}
+
+// addressTypes maps the names of the types of key addresses to the type.
+var addressTypes = map[string]waddrmgr.AddressType{
+	"p2pkh":       waddrmgr.PubKeyHash,
+	"legacy":      waddrmgr.PubKeyHash,
+	"p2sh-p2wpkh": waddrmgr.NestedWitnessPubKey,
+	"p2wpkh":      waddrmgr.WitnessPubKey,
+	"segwit":      waddrmgr.WitnessPubKey,
+}
+
+// convertAddress handles a convertaddress request by returning the address of
+// the requested type which pays the same key as an address of the wallet.
+func convertAddress(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.ConvertAddressCmd)
+
+	addrType, ok := addressTypes[cmd.AddressType]
+	if !ok {
+		return nil, btcjson.ErrRPCInvalidParameter.New("unknown address "+
+			"type ["+cmd.AddressType+"], expected one of p2pkh, "+
+			"p2sh-p2wpkh or p2wpkh", nil)
+	}
+	addr, err := decodeAddress(cmd.Address, w.ChainParams())
+	if err != nil {
+		return nil, err
+	}
+	converted, err := w.ConvertAddress(addr, addrType)
+	if wallet.ErrAddressNotConvertible.Is(err) {
+		return nil, btcjson.ErrRPCInvalidAddressOrKey.New(err.Message(), nil)
+	} else if err != nil {
+		return nil, err
+	}
+	isMine, err := w.HaveAddress(converted)
+	if err != nil {
+		return nil, err
+	}
+	return btcjson.ConvertAddressResult{
+		Address:     converted.EncodeAddress(),
+		AddressType: addressTypeName(addrType),
+		IsMine:      isMine,
+	}, nil
+}
+
+// maxDeriveAddresses is the most addresses which deriveaddresses will derive in
+// one request.
+const maxDeriveAddresses = 10000
+
+// deriveAddresses handles a deriveaddresses request by deriving the first
+// addresses of an account from a seed, without reference to the wallet, so
+// that the derivation may be cross-checked by other implementations.
+func deriveAddresses(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.DeriveAddressesCmd)
+
+	seed, errr := hex.DecodeString(cmd.Seed)
+	if errr != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New("invalid seed", er.E(errr))
+	}
+	if cmd.Count < 1 || cmd.Count > maxDeriveAddresses {
+		return nil, btcjson.ErrRPCInvalidParameter.New(fmt.Sprintf("count "+
+			"must be between 1 and %d", maxDeriveAddresses), nil)
+	}
+	// Taproot addresses may be derived, though not given as the type of an
+	// account as the wallet cannot yet spend from them.
+	scope, ok := addressTypeScopes[*cmd.AddressType]
+	if *cmd.AddressType == "p2tr" || *cmd.AddressType == "taproot" {
+		scope, ok = waddrmgr.KeyScopeBIP0086, true
+	}
+	if !ok {
+		return nil, btcjson.ErrRPCInvalidParameter.New("unknown address "+
+			"type ["+*cmd.AddressType+"], expected one of p2pkh, "+
+			"p2sh-p2wpkh, p2wpkh or p2tr", nil)
+	}
+	addrs, err := waddrmgr.DeriveAddresses(seed, scope, *cmd.Account,
+		uint32(cmd.Count), w.ChainParams())
+	if err != nil {
+		return nil, err
+	}
+	results := make([]btcjson.DeriveAddressesResult, 0, len(addrs))
+	for _, a := range addrs {
+		results = append(results, btcjson.DeriveAddressesResult{
+			Path:    a.Path,
+			Address: a.Address,
+			PubKey:  a.PubKey,
+		})
+	}
+	return results, nil
+}
+
 // dumpPrivKey handles a dumpprivkey request with the private key
 // for a single address, or an appropiate error if the wallet
 // is locked.
@@ -367,6 +564,15 @@ func getAddressBalances(icmd interface{}, w *wallet.Wallet) (interface{}, er.R)
 				Unconfirmed:  bal.Unconfirmed.ToBTC(),
 				Sunconfirmed: strconv.FormatInt(int64(bal.Unconfirmed), 10),
 
+				Maturing:  bal.Maturing.ToBTC(),
+				Smaturing: strconv.FormatInt(int64(bal.Maturing), 10),
+
+				WatchOnly:  bal.WatchOnly.ToBTC(),
+				SwatchOnly: strconv.FormatInt(int64(bal.WatchOnly), 10),
+
+				Dust:  bal.Dust.ToBTC(),
+				Sdust: strconv.FormatInt(int64(bal.Dust), 10),
+
 				OutputCount: bal.OutputCount,
 			})
 		}
@@ -374,6 +580,989 @@ func getAddressBalances(icmd interface{}, w *wallet.Wallet) (interface{}, er.R)
 	}
 }
 
+// addressTypeName returns a short name for the script type which is derived by
+// keys of the given address type.
+func addressTypeName(t waddrmgr.AddressType) string {
+	switch t {
+	case waddrmgr.PubKeyHash:
+		return "p2pkh"
+	case waddrmgr.NestedWitnessPubKey:
+		return "p2sh-p2wpkh"
+	case waddrmgr.WitnessPubKey:
+		return "p2wpkh"
+	case waddrmgr.Script:
+		return "p2sh"
+	default:
+		return "unknown"
+	}
+}
+
+// getAccountXpubs handles a getaccountxpubs request by returning the extended
+// public keys of an account for each of the wallet's key scopes.
+func getAccountXpubs(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.GetAccountXpubsCmd)
+	xpubs, err := w.AccountXpubs(*cmd.Account, *cmd.Slip132)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]btcjson.GetAccountXpubsResult, 0, len(xpubs))
+	for _, x := range xpubs {
+		results = append(results, btcjson.GetAccountXpubsResult{
+			Scope:       x.Scope.String(),
+			AddressType: addressTypeName(x.AddressType),
+			Xpub:        x.Xpub.String(),
+		})
+	}
+	return results, nil
+}
+
+// exportAccountWatchOnly handles an exportaccountwatchonly request by returning
+// a bundle of the extended public keys and issued address counts of an account
+// which importdescriptor accepts.
+func exportAccountWatchOnly(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.ExportAccountWatchOnlyCmd)
+	name, descs, err := w.ExportAccountWatchOnly(*cmd.Account)
+	if err != nil {
+		return nil, err
+	}
+	bundle := btcjson.AccountDescriptorBundle{
+		Account:     name,
+		Descriptors: make([]btcjson.AccountDescriptor, 0, len(descs)),
+	}
+	for _, d := range descs {
+		bundle.Descriptors = append(bundle.Descriptors, btcjson.AccountDescriptor{
+			Scope:         d.Scope.String(),
+			AddressType:   addressTypeName(d.AddressType),
+			Xpub:          d.Xpub.String(),
+			ExternalCount: d.ExternalKeyCount,
+			InternalCount: d.InternalKeyCount,
+		})
+	}
+	return bundle, nil
+}
+
+// importDescriptor handles an importdescriptor request by creating a watch-only
+// account from a bundle returned by exportaccountwatchonly and optionally
+// rescanning for the addresses derived.
+func importDescriptor(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.ImportDescriptorCmd)
+	name := cmd.Bundle.Account
+	if cmd.Account != nil {
+		name = *cmd.Account
+	}
+	descs := make([]wallet.AccountDescriptor, 0, len(cmd.Bundle.Descriptors))
+	for _, d := range cmd.Bundle.Descriptors {
+		var desc wallet.AccountDescriptor
+		found := false
+		for _, scope := range waddrmgr.DefaultKeyScopes {
+			if scope.String() == d.Scope {
+				desc.Scope, found = scope, true
+				break
+			}
+		}
+		if !found {
+			return nil, btcjson.ErrRPCInvalidParameter.New(
+				"unknown key scope "+d.Scope, nil)
+		}
+		manager, err := w.Manager.FetchScopedKeyManager(desc.Scope)
+		if err != nil {
+			return nil, err
+		}
+		desc.AddressType = manager.AddrSchema().ExternalAddrType
+		if addressTypeName(desc.AddressType) != d.AddressType {
+			return nil, btcjson.ErrRPCInvalidParameter.New("address type "+
+				d.AddressType+" does not match key scope "+d.Scope, nil)
+		}
+		desc.Xpub, err = hdkeychain.NewKeyFromString(d.Xpub)
+		if err != nil {
+			return nil, btcjson.ErrRPCInvalidAddressOrKey.New(
+				"invalid extended public key", err)
+		}
+		desc.ExternalKeyCount = d.ExternalCount
+		desc.InternalKeyCount = d.InternalCount
+		descs = append(descs, desc)
+	}
+
+	addrs, err := w.ImportAccountWatchOnly(name, descs)
+	switch {
+	case waddrmgr.ErrInvalidAccount.Is(err):
+		return nil, btcjson.ErrRPCWallet.New("", err)
+	case waddrmgr.ErrDuplicateAccount.Is(err):
+		return nil, btcjson.ErrRPCWalletInvalidAccountName.New("", err)
+	case err != nil:
+		return nil, err
+	}
+	if *cmd.Rescan && len(addrs) > 0 {
+		addrStrs := make([]string, 0, len(addrs))
+		for _, a := range addrs {
+			addrStrs = append(addrStrs, a.EncodeAddress())
+		}
+		if err := w.ResyncChain(0, -1, addrStrs, false); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// listAccounts handles a listaccounts request by returning every account of
+// each key scope with its balance and the number of addresses issued.
+func listAccounts(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.ListAccountsCmd)
+	accounts, err := w.ListAccounts(int32(*cmd.MinConf))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]btcjson.ListAccountsResult, 0, len(accounts))
+	for _, a := range accounts {
+		results = append(results, btcjson.ListAccountsResult{
+			Name:        a.AccountName,
+			Account:     a.AccountNumber,
+			Scope:       a.Scope.String(),
+			AddressType: addressTypeName(a.AddressType),
+			Balance:     a.Balance.ToBTC(),
+			AddressCount: a.ExternalKeyCount + a.InternalKeyCount +
+				a.ImportedKeyCount,
+		})
+	}
+	return results, nil
+}
+
+// getAccountStats handles a getaccountstats request by returning the number of
+// transactions which each account received and sent, and their totals, between
+// the start and end times.
+func getAccountStats(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.GetAccountStatsCmd)
+	var start, end time.Time
+	if *cmd.StartTime > 0 {
+		start = time.Unix(*cmd.StartTime, 0)
+	}
+	if *cmd.EndTime > 0 {
+		end = time.Unix(*cmd.EndTime, 0)
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return nil, btcjson.ErrRPCInvalidParameter.New("endtime is before "+
+			"starttime", nil)
+	}
+	stats, err := w.AccountStats(start, end)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]btcjson.AccountStatsResult, 0, len(stats))
+	for _, s := range stats {
+		results = append(results, btcjson.AccountStatsResult{
+			Name:     s.AccountName,
+			Account:  s.AccountNumber,
+			Scope:    s.Scope.String(),
+			Incoming: s.Incoming,
+			Received: s.Received.ToBTC(),
+			Outgoing: s.Outgoing,
+			Sent:     s.Sent.ToBTC(),
+		})
+	}
+	return results, nil
+}
+
+// markAddressUsed handles a markaddressused request by setting the used flag
+// of a wallet address.
+func markAddressUsed(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.MarkAddressUsedCmd)
+	return nil, setAddressUsed(w, cmd.Address, true)
+}
+
+// markAddressUnused handles a markaddressunused request by clearing the used
+// flag of a wallet address.
+func markAddressUnused(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.MarkAddressUnusedCmd)
+	return nil, setAddressUsed(w, cmd.Address, false)
+}
+
+func setAddressUsed(w *wallet.Wallet, address string, used bool) er.R {
+	addr, err := decodeAddress(address, w.ChainParams())
+	if err != nil {
+		return err
+	}
+	err = w.MarkAddressUsed(addr, used)
+	if waddrmgr.ErrAddressNotFound.Is(err) {
+		return btcjson.ErrRPCInvalidAddressOrKey.New("address not found in wallet", err)
+	} else if wallet.ErrGapLimit.Is(err) {
+		return btcjson.ErrRPCInvalidParameter.New("address gap limit exceeded", err)
+	}
+	return err
+}
+
+// freezeAddress handles a freezeaddress request by freezing the outputs paying
+// to an address so that they are not spent.
+func freezeAddress(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.FreezeAddressCmd)
+	addr, err := decodeAddress(cmd.Address, w.ChainParams())
+	if err != nil {
+		return nil, err
+	}
+	return nil, w.FreezeAddress(addr)
+}
+
+// unfreezeAddress handles an unfreezeaddress request by making the outputs
+// paying to a frozen address spendable again.
+func unfreezeAddress(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.UnfreezeAddressCmd)
+	addr, err := decodeAddress(cmd.Address, w.ChainParams())
+	if err != nil {
+		return nil, err
+	}
+	return nil, w.UnfreezeAddress(addr)
+}
+
+// listFrozenAddresses handles a listfrozenaddresses request by returning the
+// addresses which are frozen.
+func listFrozenAddresses(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	addrs, err := w.FrozenAddresses()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		result = append(result, addr.EncodeAddress())
+	}
+	return result, nil
+}
+
+// getChangeAddress handles a getchangeaddress request by returning the address
+// which the change of sends is pinned to, or an empty string if it is not.
+func getChangeAddress(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	addr, err := w.ChangeAddress()
+	if err != nil || addr == nil {
+		return "", err
+	}
+	return addr.EncodeAddress(), nil
+}
+
+// setChangeAddress handles a setchangeaddress request by pinning the change of
+// sends to an address of the wallet, or unpinning it if no address is given.
+func setChangeAddress(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.SetChangeAddressCmd)
+	if cmd.Address == nil || *cmd.Address == "" {
+		return nil, w.SetChangeAddress(nil)
+	}
+	addr, err := decodeAddress(*cmd.Address, w.ChainParams())
+	if err != nil {
+		return nil, err
+	}
+	err = w.SetChangeAddress(addr)
+	if wallet.ErrChangeAddressNotOwned.Is(err) {
+		return nil, btcjson.ErrRPCInvalidAddressOrKey.New(err.Message(), nil)
+	}
+	return nil, err
+}
+
+// getTxProof handles a gettxproof request by returning the merkle branch and
+// position which prove that a wallet transaction is included in its block.
+func getTxProof(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.GetTxProofCmd)
+	txHash, err := chainhash.NewHashFromStr(cmd.Txid)
+	if err != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New(
+			"Transaction hash string decode failed", err)
+	}
+	proof, err := w.TxProof(txHash)
+	if wtxmgr.ErrNoExists.Is(err) {
+		return nil, btcjson.ErrRPCNoTxInfo.New("No information for transaction", err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Nodes which are hashed with themselves have no sibling in the branch,
+	// they are represented by an empty string.
+	branch := make([]string, len(proof.Branch))
+	for i, h := range proof.Branch {
+		if h != nil {
+			branch[i] = h.String()
+		}
+	}
+	return btcjson.GetTxProofResult{
+		TxID:        proof.TxHash.String(),
+		BlockHash:   proof.BlockHash.String(),
+		BlockHeight: proof.BlockHeight,
+		Index:       proof.Index,
+		Branch:      branch,
+	}, nil
+}
+
+// getTxStatus handles a gettxstatus request by returning whether a transaction
+// is unknown to the wallet, unconfirmed, confirmed or conflicted.
+func getTxStatus(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.GetTxStatusCmd)
+	txHash, err := chainhash.NewHashFromStr(cmd.Txid)
+	if err != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New(
+			"Transaction hash string decode failed", err)
+	}
+	status, err := w.TxStatus(txHash)
+	if err != nil {
+		return nil, err
+	}
+	result := btcjson.GetTxStatusResult{
+		Status:        status.State.String(),
+		Confirmations: status.Confirmations,
+		BlockHeight:   status.BlockHeight,
+	}
+	if status.ConflictedBy != nil {
+		result.ConflictedBy = status.ConflictedBy.String()
+	}
+	return result, nil
+}
+
+// verifyTxProof handles a verifytxproof request by checking a merkle proof
+// against the header of the block which it claims to be from.
+func verifyTxProof(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.VerifyTxProofCmd)
+	proof := wallet.TxProof{Index: cmd.Index}
+	txHash, err := chainhash.NewHashFromStr(cmd.Txid)
+	if err != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New(
+			"Transaction hash string decode failed", err)
+	}
+	proof.TxHash = *txHash
+	blockHash, err := chainhash.NewHashFromStr(cmd.BlockHash)
+	if err != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New(
+			"Block hash string decode failed", err)
+	}
+	proof.BlockHash = *blockHash
+	if cmd.Index < 0 {
+		return nil, btcjson.ErrRPCInvalidParameter.New("index must not be negative", nil)
+	}
+	proof.Branch = make([]*chainhash.Hash, len(cmd.Branch))
+	for i, s := range cmd.Branch {
+		if s == "" {
+			continue
+		}
+		h, err := chainhash.NewHashFromStr(s)
+		if err != nil {
+			return nil, btcjson.ErrRPCDecodeHexString.New(
+				"Branch hash string decode failed", err)
+		}
+		proof.Branch[i] = h
+	}
+	return w.VerifyTxProof(&proof)
+}
+
+// estimateConfirmationTime handles an estimateconfirmationtime request when
+// there is no fee estimation data, as with neutrino, by returning a
+// conservative estimate.
+func estimateConfirmationTime(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	return estimateConfirmationTime0(icmd, w, nil)
+}
+
+// estimateConfirmationTimeRPC handles an estimateconfirmationtime request
+// using the fee estimates of pktd.
+func estimateConfirmationTimeRPC(icmd interface{}, w *wallet.Wallet,
+	rpc *chain.RPCClient) (interface{}, er.R) {
+	return estimateConfirmationTime0(icmd, w, rpc)
+}
+
+func estimateConfirmationTime0(icmd interface{}, w *wallet.Wallet,
+	fe wallet.FeeEstimator) (interface{}, er.R) {
+
+	cmd := icmd.(*btcjson.EstimateConfirmationTimeCmd)
+	txHash, err := chainhash.NewHashFromStr(cmd.Txid)
+	if err != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New(
+			"Transaction hash string decode failed", err)
+	}
+	ce, err := w.EstimateConfirmationTime(txHash, fe)
+	if wtxmgr.ErrNoExists.Is(err) {
+		return nil, btcjson.ErrRPCNoTxInfo.New("No information for transaction", err)
+	} else if err != nil {
+		return nil, err
+	}
+	return btcjson.EstimateConfirmationTimeResult{
+		FeeRate:       ce.FeeRate.ToBTC(),
+		Blocks:        ce.Blocks,
+		Seconds:       int64(ce.Duration / time.Second),
+		LowConfidence: ce.LowConfidence,
+	}, nil
+}
+
+// verifyWallet handles a verifywallet request by checking the consistency of
+// the wallet database and reporting any problems found.
+func verifyWallet(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	problems, err := w.VerifyWallet()
+	if err != nil {
+		return nil, err
+	}
+	if problems == nil {
+		problems = []string{}
+	}
+	return btcjson.VerifyWalletResult{
+		Consistent: len(problems) == 0,
+		Problems:   problems,
+	}, nil
+}
+
+// getStorageStats handles a getstoragestats request by returning the size of
+// the wallet database, the size of each of its buckets and the number of
+// transactions and unspent outputs in it.
+func getStorageStats(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	stats, err := w.StorageStats()
+	if err != nil {
+		return nil, err
+	}
+	buckets := make([]btcjson.StorageBucketStats, 0, len(stats.Buckets))
+	for name, b := range stats.Buckets {
+		buckets = append(buckets, btcjson.StorageBucketStats{
+			Name: name,
+			Keys: b.Keys,
+			Size: b.Size,
+		})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Name < buckets[j].Name
+	})
+	return btcjson.GetStorageStatsResult{
+		FileSize:     stats.FileSize,
+		Buckets:      buckets,
+		Transactions: stats.Transactions,
+		Utxos:        stats.Utxos,
+	}, nil
+}
+
+// getRecoveryStatus handles a getrecoverystatus request by returning the
+// progress of the running recovery or resync, or of the last one.
+func getRecoveryStatus(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	st := w.RecoveryStatus()
+	res := btcjson.GetRecoveryStatusResult{
+		Active:         st.Active,
+		Name:           st.Name,
+		StartHeight:    st.StartHeight,
+		CurrentHeight:  st.CurrentHeight,
+		TargetHeight:   st.TargetHeight,
+		Progress:       st.Progress(),
+		AddressesFound: st.AddressesFound,
+	}
+	if !st.Started.IsZero() {
+		res.StartTime = st.Started.Unix()
+	}
+	if st.Active && st.Remaining > 0 {
+		res.EstimatedCompletion = time.Now().Add(st.Remaining).Unix()
+	}
+	return res, nil
+}
+
+// getFeeSource handles a getfeesource request by returning the current fee
+// estimate and whether it comes from observed blocks or the fallback fee rate.
+func getFeeSource(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	return getFeeSource0(w, nil)
+}
+
+// getFeeSourceRPC handles a getfeesource request using the fee estimates of
+// pktd.
+func getFeeSourceRPC(icmd interface{}, w *wallet.Wallet,
+	rpc *chain.RPCClient) (interface{}, er.R) {
+	return getFeeSource0(w, rpc)
+}
+
+func getFeeSource0(w *wallet.Wallet, fe wallet.FeeEstimator) (interface{}, er.R) {
+	est := w.CurrentFeeEstimate(fe)
+	result := btcjson.GetFeeSourceResult{
+		Source:  est.Source.String(),
+		FeeRate: est.FeeRate.ToBTC(),
+	}
+	if !est.Updated.IsZero() {
+		result.LastUpdate = est.Updated.Unix()
+	}
+	return result, nil
+}
+
+// getFee handles a getfee request by returning the fee paid by a wallet
+// transaction, using only the values of spent outputs which the wallet knows.
+func getFee(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	return getFee0(icmd, w, nil, "")
+}
+
+// getFeeRPC handles a getfee request, fetching the transactions spent by the
+// wallet transaction from pktd when the wallet does not have them.
+func getFeeRPC(icmd interface{}, w *wallet.Wallet,
+	rpc *chain.RPCClient) (interface{}, er.R) {
+	return getFee0(icmd, w, rpc, "")
+}
+
+// getFeeNeutrino handles a getfee request with the neutrino backend.  Neutrino
+// can only fetch a transaction from the block which it is in, and the blocks
+// of the transactions spent by a wallet transaction are not known when they
+// are not in the wallet, so only spent outputs which the wallet knows are
+// supported, as with getFee.
+func getFeeNeutrino(icmd interface{}, w *wallet.Wallet,
+	neut *chain.NeutrinoClient) (interface{}, er.R) {
+
+	return getFee0(icmd, w, nil, ", neutrino cannot fetch them, use "+
+		"--userpc with a pktd which keeps a transaction index to get the fee")
+}
+
+// getFee0 returns the fee of a wallet transaction, fetching the transactions
+// which the wallet does not have with tf if it is not nil.  If the values of
+// some spent outputs are not available, hint is added to the error saying so.
+func getFee0(icmd interface{}, w *wallet.Wallet, tf wallet.TxFetcher,
+	hint string) (interface{}, er.R) {
+
+	cmd := icmd.(*btcjson.GetFeeCmd)
+	txHash, err := chainhash.NewHashFromStr(cmd.Txid)
+	if err != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New(
+			"Transaction hash string decode failed", err)
+	}
+	info, err := w.TxFee(txHash, tf)
+	if wtxmgr.ErrNoExists.Is(err) {
+		return nil, btcjson.ErrRPCNoTxInfo.New("No information for transaction", err)
+	} else if wallet.ErrPrevOutsUnavailable.Is(err) {
+		return nil, btcjson.ErrRPCNoTxInfo.New(err.Message()+hint, nil)
+	} else if err != nil {
+		return nil, err
+	}
+	return btcjson.GetFeeResult{
+		Fee:     info.Fee.ToBTC(),
+		FeeRate: info.FeeRate.ToBTC(),
+		Size:    info.Size,
+		VSize:   info.VSize,
+	}, nil
+}
+
+// getBumpInfo handles a getbumpinfo request by returning whether the fee of a
+// wallet transaction can be bumped by replacing it.
+func getBumpInfo(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.GetBumpInfoCmd)
+	txHash, err := chainhash.NewHashFromStr(cmd.Txid)
+	if err != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New(
+			"Transaction hash string decode failed", err)
+	}
+	info, err := w.BumpInfo(txHash)
+	if wtxmgr.ErrNoExists.Is(err) {
+		return nil, btcjson.ErrRPCNoTxInfo.New("No information for transaction", err)
+	} else if err != nil {
+		return nil, err
+	}
+	return btcjson.GetBumpInfoResult{
+		Replaceable: info.Replaceable,
+		OwnsInputs:  info.OwnsInputs,
+		Fee:         info.Fee.ToBTC(),
+		MinBumpFee:  info.MinBumpFee.ToBTC(),
+		CanBump:     info.CanBump,
+		NewFee:      info.NewFee.ToBTC(),
+		Reason:      info.Reason,
+	}, nil
+}
+
+// bumpFee handles a bumpfee request by replacing an unmined wallet
+// transaction with one paying a higher fee and returning the hash of the
+// replacement.
+func bumpFee(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.BumpFeeCmd)
+	return bump(cmd.Txid, w.BumpFee)
+}
+
+// bumpFeeCPFP handles a bumpfeecpfp request by spending an output of an
+// unmined wallet transaction with a child paying a higher fee and returning
+// the hash of the child.
+func bumpFeeCPFP(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.BumpFeeCPFPCmd)
+	return bump(cmd.Txid, w.BumpFeeCPFP)
+}
+
+// bump bumps the fee of the transaction txid with f and returns the hash of
+// the transaction which it published.
+func bump(txid string, f func(*chainhash.Hash) (*wire.MsgTx, er.R)) (interface{}, er.R) {
+	txHash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New(
+			"Transaction hash string decode failed", err)
+	}
+	tx, err := f(txHash)
+	if wtxmgr.ErrNoExists.Is(err) {
+		return nil, btcjson.ErrRPCNoTxInfo.New("No information for transaction", err)
+	} else if err != nil {
+		return nil, err
+	}
+	return tx.TxHash().String(), nil
+}
+
+// getFeeStats handles a getfeestats request by returning the fee rates paid
+// by the transactions which the wallet sent in recent blocks and how long each
+// took to confirm.
+func getFeeStats(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.GetFeeStatsCmd)
+	if *cmd.Blocks < 1 {
+		return nil, btcjson.ErrRPCInvalidParameter.New("blocks must be positive", nil)
+	}
+	stats, err := w.FeeStats(*cmd.Blocks)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]btcjson.TxFeeStat, 0, len(stats.Transactions))
+	for _, s := range stats.Transactions {
+		txs = append(txs, btcjson.TxFeeStat{
+			TxID:           s.Hash.String(),
+			Height:         s.Height,
+			FeeRate:        s.FeeRate.ToBTC(),
+			ConfirmSeconds: int64(s.ConfirmTime / time.Second),
+		})
+	}
+	return btcjson.GetFeeStatsResult{
+		Transactions:  txs,
+		MinFeeRate:    stats.MinFeeRate.ToBTC(),
+		MedianFeeRate: stats.MedianFeeRate.ToBTC(),
+		MaxFeeRate:    stats.MaxFeeRate.ToBTC(),
+	}, nil
+}
+
+// getUtxoAges handles a getutxoages request by returning the distribution of
+// the ages in confirmations of the wallet's unspent outputs.
+func getUtxoAges(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	ages, err := w.UtxoAges()
+	if err != nil {
+		return nil, err
+	}
+	buckets := make([]btcjson.UtxoAgeBucket, 0, len(ages.Buckets))
+	for _, b := range ages.Buckets {
+		bucket := btcjson.UtxoAgeBucket{
+			MinConfs: b.MinConfs,
+			Count:    b.Count,
+			Amount:   b.Amount.ToBTC(),
+		}
+		if b.MaxConfs >= 0 {
+			maxConfs := b.MaxConfs
+			bucket.MaxConfs = &maxConfs
+		}
+		buckets = append(buckets, bucket)
+	}
+	return btcjson.GetUtxoAgesResult{
+		Count:   ages.Count,
+		Oldest:  ages.Oldest,
+		Newest:  ages.Newest,
+		Median:  ages.Median,
+		Buckets: buckets,
+	}, nil
+}
+
+// exportTaxReport handles an exporttaxreport request by returning a record of
+// each disposal of coins by the wallet, matched first in first out against the
+// lots which the wallet received.  Dates are in UTC and the cost basis is left
+// null for tax software to fill in.
+func exportTaxReport(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	disposals, err := w.TaxReport()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]btcjson.TaxDisposalResult, 0, len(disposals))
+	for _, d := range disposals {
+		r := btcjson.TaxDisposalResult{
+			DateDisposed: d.Disposed.UTC().Format(time.RFC3339),
+			DisposedTxID: d.DisposedTx.String(),
+			Amount:       d.Amount.ToBTC(),
+			Proceeds:     d.Proceeds.ToBTC(),
+		}
+		if !d.Acquired.IsZero() {
+			r.DateAcquired = d.Acquired.UTC().Format(time.RFC3339)
+			r.AcquiredTxID = d.AcquiredTx.String()
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// exportLabels handles an exportlabels request by returning the label of each
+// labelled transaction, ordered by transaction hash.
+func exportLabels(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	labels, err := w.TxLabels()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]btcjson.TxLabel, 0, len(labels))
+	for hash, label := range labels {
+		results = append(results, btcjson.TxLabel{
+			Txid:  hash.String(),
+			Label: label,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Txid < results[j].Txid
+	})
+	return results, nil
+}
+
+// importLabels handles an importlabels request by labelling the wallet's
+// transactions with the labels given, as exported by exportlabels.
+func importLabels(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.ImportLabelsCmd)
+
+	labels := make(map[chainhash.Hash]string, len(cmd.Labels))
+	for _, l := range cmd.Labels {
+		hash, err := chainhash.NewHashFromStr(l.Txid)
+		if err != nil {
+			return nil, btcjson.ErrRPCDecodeHexString.New(
+				"invalid txid "+l.Txid, err)
+		}
+		labels[*hash] = l.Label
+	}
+	res, err := w.ImportTxLabels(labels, *cmd.Overwrite)
+	if err != nil {
+		return nil, btcjson.ErrRPCInvalidParameter.New("", err)
+	}
+	return btcjson.ImportLabelsResult{
+		Imported: res.Imported,
+		Kept:     res.Kept,
+		Unknown:  res.Unknown,
+	}, nil
+}
+
+// estimateConsolidation handles an estimateconsolidation request by estimating
+// the transactions and fee needed to consolidate the wallet's spendable outputs
+// into one output.  The fee rate defaults to the relay fee.
+func estimateConsolidation(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.EstimateConsolidationCmd)
+	feeRate := txrules.DefaultRelayFeePerKb
+	if cmd.FeeRate != nil {
+		var err er.R
+		feeRate, err = txrules.ParseFeeRate(*cmd.FeeRate)
+		if err != nil {
+			return nil, btcjson.ErrRPCInvalidParameter.New(err.Message(), nil)
+		}
+		if feeRate < 0 {
+			return nil, btcjson.ErrRPCInvalidParameter.New(
+				"feerate must not be negative", nil)
+		}
+	}
+	ce, err := w.EstimateConsolidation(feeRate)
+	if err != nil {
+		return nil, err
+	}
+	return &btcjson.EstimateConsolidationResult{
+		Utxos:          ce.Inputs,
+		Transactions:   ce.Transactions,
+		FeeRate:        ce.FeeRate.ToBTC(),
+		Fee:            ce.Fee.ToBTC(),
+		Amount:         ce.Amount.ToBTC(),
+		AmountAfterFee: (ce.Amount - ce.Fee).ToBTC(),
+	}, nil
+}
+
+// listPendingTransactions handles a listpendingtransactions request by
+// returning the wallet's unconfirmed transactions, oldest first.
+func listPendingTransactions(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	pending, err := w.PendingTxs()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	results := make([]btcjson.ListPendingTransactionsResult, 0, len(pending))
+	for _, p := range pending {
+		result := btcjson.ListPendingTransactionsResult{
+			TxID:        p.Hash.String(),
+			AgeSeconds:  int64(now.Sub(p.Received) / time.Second),
+			Replaceable: p.Replaceable,
+		}
+		if p.FeeKnown {
+			fee := p.Fee.ToBTC()
+			feeRate := p.FeeRate.ToBTC()
+			result.Fee = &fee
+			result.FeeRate = &feeRate
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// getMempoolAncestors handles a getmempoolancestors request by returning the
+// unconfirmed wallet transactions which an unconfirmed transaction depends on,
+// with their total size and fee.
+func getMempoolAncestors(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.GetMempoolAncestorsCmd)
+	txHash, err := chainhash.NewHashFromStr(cmd.Txid)
+	if err != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New(
+			"Transaction hash string decode failed", err)
+	}
+	res, err := w.MempoolAncestors(txHash)
+	if wallet.ErrTxNotUnmined.Is(err) {
+		return nil, btcjson.ErrRPCInvalidAddressOrKey.New(
+			"Transaction is not an unconfirmed wallet transaction", err)
+	} else if err != nil {
+		return nil, err
+	}
+	result := btcjson.GetMempoolAncestorsResult{
+		Ancestors: make([]btcjson.MempoolAncestorResult, 0, len(res.Ancestors)),
+		Size:      res.Size,
+		VSize:     res.VSize,
+	}
+	for _, a := range res.Ancestors {
+		ar := btcjson.MempoolAncestorResult{
+			TxID:  a.Hash.String(),
+			Size:  a.Size,
+			VSize: a.VSize,
+		}
+		if a.FeeKnown {
+			fee := a.Fee.ToBTC()
+			ar.Fee = &fee
+		}
+		result.Ancestors = append(result.Ancestors, ar)
+	}
+	if res.FeeKnown {
+		fee := res.Fee.ToBTC()
+		result.Fee = &fee
+	}
+	return result, nil
+}
+
+// dumpUtxoSet handles a dumputxoset request by streaming each of the wallet's
+// spendable outputs as a line of NDJSON.  The stream stops after the output
+// which it is writing when quit is closed.
+func dumpUtxoSet(icmd interface{}, w *wallet.Wallet, quit <-chan struct{}) (interface{}, er.R) {
+	return ndjsonStream(func(emit func(interface{}) er.R) er.R {
+		return w.ForEachSnapshotOutput(func(out *wallet.SnapshotOutput) er.R {
+			if timedOut(quit) {
+				return errTimedOut("dumputxoset")
+			}
+			result := btcjson.DumpUtxoSetResult{
+				TxID:          out.OutPoint.Hash.String(),
+				Vout:          out.OutPoint.Index,
+				Amount:        out.Amount.ToBTC(),
+				ScriptType:    out.ScriptClass.String(),
+				Confirmations: int64(out.Confirmations),
+			}
+			if out.Address != nil {
+				result.Address = out.Address.EncodeAddress()
+			}
+			return emit(&result)
+		})
+	}), nil
+}
+
+// getUtxoInfo handles a getutxoinfo request by returning the address paid by
+// an output and, for outputs which the wallet can spend, the derivation path
+// and master key fingerprint which an external signer needs to sign for it.
+func getUtxoInfo(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.GetUtxoInfoCmd)
+	txHash, err := chainhash.NewHashFromStr(cmd.Txid)
+	if err != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New(
+			"Transaction hash string decode failed", err)
+	}
+	info, err := w.UtxoInfo(wire.OutPoint{Hash: *txHash, Index: cmd.Vout})
+	if err != nil {
+		return nil, err
+	}
+	result := &btcjson.GetUtxoInfoResult{
+		TxID:  cmd.Txid,
+		Vout:  cmd.Vout,
+		Known: info.Known,
+		Owned: info.Owned,
+	}
+	if !info.Known {
+		return result, nil
+	}
+	result.Amount = info.Amount.ToBTC()
+	result.ScriptPubKey = hex.EncodeToString(info.PkScript)
+	if info.Address != nil {
+		result.Address = info.Address.EncodeAddress()
+	}
+	if info.HasKeyOrigin {
+		result.DerivationPath = fmt.Sprintf("m/%d'/%d'/%d'/%d/%d",
+			info.KeyScope.Purpose, info.KeyScope.Coin,
+			info.Path.Account, info.Path.Branch, info.Path.Index)
+		result.MasterFingerprint = fmt.Sprintf("%08x",
+			info.MasterFingerprint)
+	}
+	return result, nil
+}
+
+// listAuxOutputs handles a listauxoutputs request by returning the zero value
+// and unspendable outputs of the wallet's transactions, which are not counted
+// as unspent outputs.
+func listAuxOutputs(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	aux, err := w.AuxOutputs()
+	if err != nil {
+		return nil, err
+	}
+	syncHeight := w.Manager.SyncedTo().Height
+	results := make([]btcjson.ListAuxOutputsResult, 0, len(aux))
+	for _, a := range aux {
+		results = append(results, btcjson.ListAuxOutputsResult{
+			TxID:          a.OutPoint.Hash.String(),
+			Vout:          a.OutPoint.Index,
+			Amount:        a.Amount.ToBTC(),
+			ScriptPubKey:  hex.EncodeToString(a.PkScript),
+			Data:          hex.EncodeToString(a.Data),
+			Confirmations: int64(confirms(a.Height, syncHeight)),
+		})
+	}
+	return results, nil
+}
+
+// listRejectedTx handles a listrejectedtx request by returning the
+// transactions which were most recently rejected when broadcast, with the
+// reason given for each.
+func listRejectedTx(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	rejected := w.RejectedTxs()
+	results := make([]btcjson.ListRejectedTxResult, 0, len(rejected))
+	for _, rej := range rejected {
+		results = append(results, btcjson.ListRejectedTxResult{
+			TxID:   rej.Hash.String(),
+			Reason: rej.Reason,
+			Time:   rej.Time.Unix(),
+		})
+	}
+	return results, nil
+}
+
+// verifyPaymentRequest handles a verifypaymentrequest request by verifying
+// the signature of a BIP0070 payment request and decoding its details.  An
+// invalid signature or an expired request is reported in the result rather
+// than as an error.
+func verifyPaymentRequest(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.VerifyPaymentRequestCmd)
+	b, errr := hex.DecodeString(cmd.PaymentRequest)
+	if errr != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New(
+			"Payment request hex string decode failed", er.E(errr))
+	}
+	req, err := paymentrequest.Parse(b)
+	if err != nil {
+		return nil, btcjson.ErrRPCDeserialization.New("", err)
+	}
+	details, err := req.Details()
+	if err != nil {
+		return nil, btcjson.ErrRPCDeserialization.New("", err)
+	}
+
+	now := time.Now()
+	result := btcjson.VerifyPaymentRequestResult{
+		Expired:    details.Expired(now),
+		Network:    details.Network,
+		Outputs:    make([]btcjson.PaymentRequestOutput, 0, len(details.Outputs)),
+		Memo:       details.Memo,
+		PaymentURL: details.PaymentURL,
+		Time:       int64(details.Time),
+		Expires:    int64(details.Expires),
+	}
+	if cert, err := req.Verify(nil, now); err != nil {
+		result.Error = err.Message()
+	} else {
+		result.Merchant = cert.Subject.CommonName
+		result.Valid = !result.Expired
+	}
+	for _, out := range details.Outputs {
+		o := btcjson.PaymentRequestOutput{
+			Amount: btcutil.Amount(out.Amount).ToBTC(),
+			Script: hex.EncodeToString(out.Script),
+		}
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(out.Script, w.ChainParams())
+		if err == nil && len(addrs) == 1 {
+			o.Address = addrs[0].EncodeAddress()
+		}
+		result.Outputs = append(result.Outputs, o)
+	}
+	return result, nil
+}
+
 func getWalletSeed(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	seed := w.Manager.Seed()
 	if seed == nil {
@@ -382,6 +1571,22 @@ func getWalletSeed(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	return seed.Words("english")
 }
 
+// backupSeedEncrypted handles a backupseedencrypted request by returning the
+// wallet seed encrypted under the backup passphrase.
+func backupSeedEncrypted(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.BackupSeedEncryptedCmd)
+	if cmd.Passphrase == "" {
+		return nil, btcjson.ErrRPCInvalidParameter.New("a backup passphrase "+
+			"is required", nil)
+	}
+	backup, err := w.SeedBackup([]byte(cmd.WalletPassphrase),
+		[]byte(cmd.Passphrase))
+	if waddrmgr.ErrWrongPassphrase.Is(err) {
+		return nil, btcjson.ErrRPCWalletPassphraseIncorrect.Default()
+	}
+	return backup, err
+}
+
 func getSecret(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	cmd := icmd.(*btcjson.GetSecretCmd)
 	return w.GetSecret(cmd.Name)
@@ -407,11 +1612,35 @@ func walletMempool(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 // exist.
 func getBalance(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	cmd := icmd.(*btcjson.GetBalanceCmd)
-	if balance, err := w.CalculateBalance(int32(*cmd.MinConf)); err != nil {
+	bal, err := w.CalculateWalletBalance(int32(*cmd.MinConf))
+	if err != nil {
 		return nil, err
-	} else {
-		return balance.ToBTC(), nil
 	}
+	if cmd.Verbose != nil && *cmd.Verbose {
+		return btcjson.GetBalanceResult{
+			Balance:   bal.Balance.ToBTC(),
+			Maturing:  bal.Maturing.ToBTC(),
+			WatchOnly: bal.WatchOnly.ToBTC(),
+		}, nil
+	}
+	return bal.Balance.ToBTC(), nil
+}
+
+// getBalanceAtHeight handles a getbalanceatheight request by returning the
+// confirmed balance of the wallet as of a past block.
+func getBalanceAtHeight(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.GetBalanceAtHeightCmd)
+	if cmd.Height < 0 {
+		return nil, btcjson.ErrRPCInvalidParameter.New("height must not be negative", nil)
+	}
+	balance, height, err := w.BalanceAtHeight(cmd.Height)
+	if err != nil {
+		return nil, err
+	}
+	return btcjson.GetBalanceAtHeightResult{
+		Height:  height,
+		Balance: balance.ToBTC(),
+	}, nil
 }
 
 // getBestBlock handles a getbestblock request by returning a JSON object
@@ -477,7 +1706,7 @@ func getInfo(icmd interface{}, w *wallet.Wallet, chainClient chain.Interface) (i
 		}
 		out.RPCInfo = info
 	} else if neut, ok := chainClient.(*chain.NeutrinoClient); ok {
-		ni := btcjson.NeutrinoInfo{}
+		ni := btcjson.NeutrinoInfo{SyncPaused: neut.CS.SyncPaused()}
 		out.NeutrinoInfo = &ni
 		for _, p := range neut.CS.Peers() {
 			ni.Peers = append(ni.Peers, p.Describe())
@@ -514,17 +1743,72 @@ func getInfo(icmd interface{}, w *wallet.Wallet, chainClient chain.Interface) (i
 }
 
 func decodeAddress(s string, params *chaincfg.Params) (btcutil.Address, er.R) {
+	addr, reason := checkAddress(s, params)
+	if reason != "" {
+		return nil, btcjson.ErrRPCInvalidAddressOrKey.New(reason, nil)
+	}
+	return addr, nil
+}
+
+// checkAddress decodes an address and checks that it is intended for use
+// on the network.  If it is not, the reason why is returned instead.
+func checkAddress(s string, params *chaincfg.Params) (btcutil.Address, string) {
 	addr, err := btcutil.DecodeAddress(s, params)
 	if err != nil {
-		msg := fmt.Sprintf("Invalid address %q: decode failed", s)
-		return nil, btcjson.ErrRPCInvalidAddressOrKey.New(msg, err)
+		if other := addressNet(s); other != nil && other != params {
+			return nil, fmt.Sprintf("Invalid address %q: address of %s, "+
+				"not intended for use on %s", s, other.Name, params.Name)
+		}
+		return nil, fmt.Sprintf("Invalid address %q: decode failed: %s", s,
+			err.Message())
 	}
 	if !addr.IsForNet(params) {
 		msg := fmt.Sprintf("Invalid address %q: not intended for use on %s",
 			addr, params.Name)
-		return nil, btcjson.ErrRPCInvalidAddressOrKey.New(msg, nil)
+		if hrp := segwitHrp(addr); hrp != "" {
+			msg += fmt.Sprintf(" (bech32 prefix %q, expected %q)",
+				hrp, params.Bech32HRPSegwit)
+		}
+		return nil, msg
 	}
-	return addr, nil
+	return addr, ""
+}
+
+// knownNets are the networks which addresses that cannot be decoded for the
+// active network are checked against, to say which network they are for.
+var knownNets = []*chaincfg.Params{
+	&chaincfg.PktMainNetParams,
+	&chaincfg.PktTestNetParams,
+	&chaincfg.MainNetParams,
+	&chaincfg.TestNet3Params,
+	&chaincfg.RegressionNetParams,
+	&chaincfg.SimNetParams,
+}
+
+// addressNet returns the first of the known networks which an address decodes
+// for, or nil if it is not an address of any of them.
+func addressNet(s string) *chaincfg.Params {
+	for _, params := range knownNets {
+		if addr, err := btcutil.DecodeAddress(s, params); err == nil &&
+			addr.IsForNet(params) {
+			return params
+		}
+	}
+	return nil
+}
+
+// segwitHrp returns the bech32 human-readable part of a segwit address, or
+// the empty string if the address is not a segwit address.
+func segwitHrp(addr btcutil.Address) string {
+	switch a := addr.(type) {
+	case *btcutil.AddressWitnessPubKeyHash:
+		return a.Hrp()
+	case *btcutil.AddressWitnessScriptHash:
+		return a.Hrp()
+	case *btcutil.AddressTaproot:
+		return a.Hrp()
+	}
+	return ""
 }
 
 func setNetworkStewardVote(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
@@ -624,6 +1908,34 @@ func importPrivKey(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	return addr, err
 }
 
+// importAddress handles an importaddress request by importing an address to
+// watch.  Only p2tr addresses can be imported this way as the wallet cannot
+// yet spend from them, for other address types use importprivkey.
+func importAddress(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.ImportAddressCmd)
+
+	addr, err := decodeAddress(cmd.Address, w.ChainParams())
+	if err != nil {
+		return nil, err
+	}
+	taprootAddr, ok := addr.(*btcutil.AddressTaproot)
+	if !ok {
+		return nil, btcjson.ErrRPCInvalidAddressOrKey.New("only p2tr "+
+			"addresses can be imported watch only, use importprivkey to "+
+			"import other addresses", nil)
+	}
+
+	err = w.ImportTaprootAddress(taprootAddr, nil, *cmd.Rescan)
+	switch {
+	case waddrmgr.ErrLocked.Is(err):
+		return nil, btcjson.ErrRPCWalletUnlockNeeded.Default()
+	case waddrmgr.ErrDuplicateAddress.Is(err):
+		return nil, btcjson.ErrRPCWallet.New("address is already in the "+
+			"wallet", nil)
+	}
+	return nil, err
+}
+
 // getNewAddress handles a getnewaddress request by returning a new
 // address for an account.  If the account does not exist an appropiate
 // error is returned.
@@ -632,11 +1944,31 @@ func importPrivKey(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 func getNewAddress(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	cmd := icmd.(*btcjson.GetNewAddressCmd)
 
-	scope := waddrmgr.KeyScopeBIP0084
-	if cmd.Legacy != nil && *cmd.Legacy {
-		scope = waddrmgr.KeyScopeBIP0044
+	account := uint32(waddrmgr.DefaultAccountNum)
+	if cmd.Account != nil {
+		var err er.R
+		if account, err = w.AccountNumberOrCreate(*cmd.Account); err != nil {
+			return nil, err
+		}
 	}
-	if addr, err := w.NewAddress(waddrmgr.DefaultAccountNum, scope); err != nil {
+
+	// The legacy flag, when given, overrides the account's address type.
+	scope, err := w.AccountAddressScope(account)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.Legacy != nil {
+		scope = waddrmgr.KeyScopeBIP0084
+		if *cmd.Legacy {
+			scope = waddrmgr.KeyScopeBIP0044
+		}
+	}
+	if cmd.KeyScope != nil {
+		if scope, err = waddrmgr.ParseKeyScope(*cmd.KeyScope); err != nil {
+			return nil, btcjson.ErrRPCInvalidParameter.New(err.Message(), nil)
+		}
+	}
+	if addr, err := w.NewAddress(account, scope); err != nil {
 		return nil, err
 	} else {
 		return addr.EncodeAddress(), nil
@@ -676,7 +2008,7 @@ func getTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 		return nil, err
 	}
 	if details == nil {
-		return nil, btcjson.ErrRPCNoTxInfo.Default()
+		return conflictedTransaction(w, txHash)
 	}
 
 	syncBlock := w.Manager.SyncedTo()
@@ -706,6 +2038,11 @@ func getTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 		ret.BlockTime = details.Block.Time.Unix()
 		ret.Confirmations = int64(confirms(details.Block.Height, syncBlock.Height))
 	}
+	ret.Trusted = ret.Confirmations >= int64(w.Config().TrustedConfs)
+	ret.Comment, ret.CommentTo, err = w.TxComment(*txHash)
+	if err != nil {
+		return nil, err
+	}
 
 	var (
 		debitTotal  btcutil.Amount
@@ -796,6 +2133,34 @@ func getTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	return ret, nil
 }
 
+// conflictedTransaction returns the gettransaction result for a transaction
+// which was removed from the wallet because it conflicts with a mined
+// transaction.  As in the reference implementation, conflicted transactions
+// have negative confirmations.
+func conflictedTransaction(w *wallet.Wallet, txHash *chainhash.Hash) (interface{}, er.R) {
+	rec, conflictedBy, err := wallet.UnstableAPI(w).ConflictedTx(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if rec == nil {
+		return nil, btcjson.ErrRPCNoTxInfo.Default()
+	}
+	var txBuf bytes.Buffer
+	txBuf.Grow(rec.MsgTx.SerializeSize())
+	if err := rec.MsgTx.Serialize(&txBuf); err != nil {
+		return nil, err
+	}
+	return btcjson.GetTransactionResult{
+		TxID:            txHash.String(),
+		Hex:             hex.EncodeToString(txBuf.Bytes()),
+		Time:            rec.Received.Unix(),
+		TimeReceived:    rec.Received.Unix(),
+		Confirmations:   -1,
+		WalletConflicts: []string{conflictedBy.String()},
+		Details:         []btcjson.GetTransactionDetailsResult{},
+	}, nil
+}
+
 // These generators create the following global variables in this package:
 //
 //   var localeHelpDescs map[string]func() map[string]string
@@ -1157,17 +2522,20 @@ func lockUnspent(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 // makeOutputs creates a slice of transaction outputs from a pair of address
 // strings to amounts.  This is used to create the outputs to include in newly
 // created transactions from a JSON object describing the output destinations
-// and amounts.
+// and amounts.  Every address is validated, and if any of them are invalid the
+// error gives the reason for each one.
 func makeOutputs(pairs map[string]btcutil.Amount, vote *waddrmgr.NetworkStewardVote,
 	chainParams *chaincfg.Params) ([]*wire.TxOut, er.R) {
 	outputs := make([]*wire.TxOut, 0, len(pairs))
 	if vote == nil {
 		vote = &waddrmgr.NetworkStewardVote{}
 	}
+	var invalid []string
 	for addrStr, amt := range pairs {
-		addr, err := btcutil.DecodeAddress(addrStr, chainParams)
-		if err != nil {
-			return nil, er.Errorf("cannot decode address: %s", err)
+		addr, reason := checkAddress(addrStr, chainParams)
+		if reason != "" {
+			invalid = append(invalid, reason)
+			continue
 		}
 
 		pkScript, err := txscript.PayToAddrScriptWithVote(addr, vote.VoteFor, vote.VoteAgainst)
@@ -1177,10 +2545,17 @@ func makeOutputs(pairs map[string]btcutil.Amount, vote *waddrmgr.NetworkStewardV
 
 		outputs = append(outputs, wire.NewTxOut(int64(amt), pkScript))
 	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return nil, btcjson.ErrRPCInvalidAddressOrKey.New(
+			strings.Join(invalid, "; "), nil)
+	}
 	return outputs, nil
 }
 
-func sendOutputs(
+// createTxReq builds the request for a transaction paying amounts from the
+// parameters common to the send RPCs.
+func createTxReq(
 	w *wallet.Wallet,
 	amounts map[string]btcutil.Amount,
 	vote *waddrmgr.NetworkStewardVote,
@@ -1191,7 +2566,9 @@ func sendOutputs(
 	changeAddress *string,
 	inputMinHeight int,
 	maxInputs int,
-) (*txauthor.AuthoredTx, er.R) {
+	comment, commentTo *string,
+	allowSelfSend *bool,
+) (wallet.CreateTxReq, er.R) {
 	req := wallet.CreateTxReq{
 		Minconf:        minconf,
 		FeeSatPerKB:    feeSatPerKb,
@@ -1208,68 +2585,124 @@ func sendOutputs(
 		// in this case.
 		req.InputComparator = wallet.PreferOldest
 	}
+	if comment != nil {
+		req.Comment = *comment
+	}
+	if commentTo != nil {
+		req.CommentTo = *commentTo
+	}
+	if allowSelfSend != nil {
+		req.AllowSelfSend = *allowSelfSend
+	}
 	var err er.R
 	req.Outputs, err = makeOutputs(amounts, vote, w.ChainParams())
+	if err != nil {
+		return req, err
+	}
+	if changeAddress != nil {
+		addr, err := decodeAddress(*changeAddress, w.ChainParams())
+		if err != nil {
+			return req, err
+		}
+		req.ChangeAddress = &addr
+	}
+	if fromAddressses != nil && len(*fromAddressses) > 0 {
+		addrs := make([]btcutil.Address, 0, len(*fromAddressses))
+		for _, addrStr := range *fromAddressses {
+			addr, err := decodeAddress(addrStr, w.ChainParams())
+			if err != nil {
+				return req, err
+			}
+			addrs = append(addrs, addr)
+		}
+		req.InputAddresses = &addrs
+	}
+	return req, nil
+}
+
+// sendError converts an error creating a transaction into an RPC error.
+func sendError(err er.R, what string) er.R {
+	if ruleerror.ErrNegativeTxOutValue.Is(err) {
+		return errNeedPositiveAmount()
+	}
+	if waddrmgr.ErrLocked.Is(err) {
+		return btcjson.ErrRPCWalletUnlockNeeded.Default()
+	}
+	if wallet.ErrSelfSend.Is(err) || wallet.ErrInputNotSpendable.Is(err) {
+		return btcjson.ErrRPCInvalidParameter.New(err.Message(), nil)
+	}
+	if btcjson.Err.Is(err) {
+		return err
+	}
+	return btcjson.ErrRPCInternal.New(what+" failed", err)
+}
+
+func sendOutputs(
+	w *wallet.Wallet,
+	amounts map[string]btcutil.Amount,
+	vote *waddrmgr.NetworkStewardVote,
+	fromAddressses *[]string,
+	minconf int32,
+	feeSatPerKb btcutil.Amount,
+	sendMode wallet.SendMode,
+	changeAddress *string,
+	inputMinHeight int,
+	maxInputs int,
+	comment, commentTo *string,
+	allowSelfSend *bool,
+) (*txauthor.AuthoredTx, er.R) {
+	req, err := createTxReq(w, amounts, vote, fromAddressses, minconf,
+		feeSatPerKb, sendMode, changeAddress, inputMinHeight, maxInputs,
+		comment, commentTo, allowSelfSend)
 	if err != nil {
 		return nil, err
 	}
-	if changeAddress != nil {
-		addr, err := btcutil.DecodeAddress(*changeAddress, w.ChainParams())
-		if err != nil {
-			return nil, err
-		}
-		req.ChangeAddress = &addr
-	}
-	if fromAddressses != nil && len(*fromAddressses) > 0 {
-		addrs := make([]btcutil.Address, 0, len(*fromAddressses))
-		for _, addrStr := range *fromAddressses {
-			addr, err := btcutil.DecodeAddress(addrStr, w.ChainParams())
-			if err != nil {
-				return nil, err
-			}
-			addrs = append(addrs, addr)
-		}
-		req.InputAddresses = &addrs
-	}
 	tx, err := w.SendOutputs(req)
 	if err != nil {
-		if ruleerror.ErrNegativeTxOutValue.Is(err) {
-			return nil, errNeedPositiveAmount()
-		}
-		if waddrmgr.ErrLocked.Is(err) {
-			return nil, btcjson.ErrRPCWalletUnlockNeeded.Default()
-		}
-		if btcjson.Err.Is(err) {
-			return nil, err
-		}
-		return nil, btcjson.ErrRPCInternal.New("SendOutputs failed", err)
+		return nil, sendError(err, "SendOutputs")
 	}
 	return tx, nil
 }
 
 // sendPairs creates and sends payment transactions.
-// It returns the transaction hash in string format upon success
+// It returns the transaction hash in string format upon success, or a
+// btcjson.SendResult also describing the change output if changeInfo is set.
 // All errors are returned in btcjson.RPCError format
 func sendPairs(w *wallet.Wallet, amounts map[string]btcutil.Amount,
-	fromAddressses *[]string, minconf int32, feeSatPerKb btcutil.Amount, maxInputs, inputMinHeight int) (string, er.R) {
+	fromAddressses *[]string, minconf int32, feeSatPerKb btcutil.Amount, maxInputs, inputMinHeight int,
+	comment, commentTo *string, allowSelfSend, changeInfo *bool) (interface{}, er.R) {
 
 	vote, err := w.NetworkStewardVote(0, waddrmgr.KeyScopeBIP0044)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	tx, err := sendOutputs(w, amounts, vote, fromAddressses, minconf, feeSatPerKb, wallet.SendModeBcasted, nil, inputMinHeight, maxInputs)
+	tx, err := sendOutputs(w, amounts, vote, fromAddressses, minconf, feeSatPerKb, wallet.SendModeBcasted, nil, inputMinHeight, maxInputs,
+		comment, commentTo, allowSelfSend)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	txHashStr := tx.Tx.TxHash().String()
 	log.Infof("Successfully sent transaction [%s]", log.Txid(txHashStr))
+	if changeInfo != nil && *changeInfo {
+		return sendResult(tx, w.ChainParams()), nil
+	}
 	return txHashStr, nil
 }
 
-func isNilOrEmpty(s *string) bool {
-	return s == nil || *s == ""
+// sendResult describes a sent transaction along with its change output, if it
+// has one, so that callers can chain further transactions from the change.
+func sendResult(tx *txauthor.AuthoredTx, params *chaincfg.Params) *btcjson.SendResult {
+	res := &btcjson.SendResult{TxID: tx.Tx.TxHash().String()}
+	if tx.ChangeIndex < 0 {
+		return res
+	}
+	vout := uint32(tx.ChangeIndex)
+	addr := txscript.PkScriptToAddress(tx.Tx.TxOut[vout].PkScript, params).EncodeAddress()
+	res.ChangeVout = &vout
+	res.ChangeAddress = &addr
+	return res
 }
 
 // sendFrom handles a sendfrom RPC request by creating a new transaction
@@ -1280,12 +2713,6 @@ func isNilOrEmpty(s *string) bool {
 func sendFrom(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	cmd := icmd.(*btcjson.SendFromCmd)
 
-	// Transaction comments are not yet supported.  Error instead of
-	// pretending to save them.
-	if !isNilOrEmpty(cmd.Comment) || !isNilOrEmpty(cmd.CommentTo) {
-		return nil, errCommentsUnsupported()
-	}
-
 	// Check that signed integer parameters are positive.
 	if cmd.Amount < 0 {
 		return nil, errNeedPositiveAmount()
@@ -1313,7 +2740,8 @@ func sendFrom(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 		minHeight = *cmd.MinHeight
 	}
 
-	return sendPairs(w, pairs, cmd.FromAddresses, minConf, txrules.DefaultRelayFeePerKb, maxInputs, minHeight)
+	return sendPairs(w, pairs, cmd.FromAddresses, minConf, txrules.DefaultRelayFeePerKb, maxInputs, minHeight,
+		cmd.Comment, cmd.CommentTo, cmd.AllowSelfSend, cmd.ChangeInfo)
 }
 
 func createTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
@@ -1363,7 +2791,8 @@ func createTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	}
 
 	tx, err := sendOutputs(w, amounts, vote, cmd.FromAddresses, minconf,
-		feeSatPerKb, sendMode, cmd.ChangeAddress, inputMinHeight, maxInputs)
+		feeSatPerKb, sendMode, cmd.ChangeAddress, inputMinHeight, maxInputs,
+		nil, nil, cmd.AllowSelfSend)
 	if err != nil {
 		return "", err
 	}
@@ -1394,7 +2823,46 @@ func stopResync(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	return w.StopResync()
 }
 
-func resync(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+// cancelRescan handles a cancelrescan request by stopping the running rescan
+// job, keeping its progress so that it resumes when the wallet is next opened.
+func cancelRescan(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	return w.CancelRescan(), nil
+}
+
+// timedOut reports whether quit, the quit channel given to a handlerQuit, has
+// been closed.
+func timedOut(quit <-chan struct{}) bool {
+	select {
+	case <-quit:
+		return true
+	default:
+		return false
+	}
+}
+
+// errTimedOut returns the error with which a handlerQuit for method stops once
+// its quit channel is closed.
+func errTimedOut(method string) er.R {
+	return btcjson.ErrRPCTimeout.New(fmt.Sprintf("%s timed out", method), nil)
+}
+
+// stopTimedOutJob stops the rescan job which method has just started if the
+// request timed out while it was starting it, waiting for the rescan lock or
+// the database.  The client has already been told that the method timed out
+// so the job must not go on in the background.
+func stopTimedOutJob(method string, w *wallet.Wallet, quit <-chan struct{}) er.R {
+	if !timedOut(quit) {
+		return nil
+	}
+	if _, err := w.StopResync(); err != nil {
+		log.Warnf("Unable to stop the job of timed out %s: %v", method, err)
+	}
+	return errTimedOut(method)
+}
+
+// resync handles a resync request by starting a rescan job, which is stopped
+// again if the request timed out while the job was being started.
+func resync(icmd interface{}, w *wallet.Wallet, quit <-chan struct{}) (interface{}, er.R) {
 	cmd := icmd.(*btcjson.ResyncCmd)
 	fh := int32(-1)
 	th := int32(-1)
@@ -1408,7 +2876,41 @@ func resync(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	if cmd.Addresses != nil {
 		a = *cmd.Addresses
 	}
-	return nil, w.ResyncChain(fh, th, a, cmd.DropDb != nil && *cmd.DropDb)
+	if err := w.ResyncChain(fh, th, a, cmd.DropDb != nil && *cmd.DropDb); err != nil {
+		return nil, err
+	}
+	return nil, stopTimedOutJob("resync", w, quit)
+}
+
+// rescanAddress handles a rescanaddress request by starting a rescan which
+// only looks for transactions involving a single wallet address, which is
+// stopped again if the request timed out while the rescan was being started.
+func rescanAddress(icmd interface{}, w *wallet.Wallet, quit <-chan struct{}) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.RescanAddressCmd)
+	addr, err := decodeAddress(cmd.Address, w.ChainParams())
+	if err != nil {
+		return nil, err
+	}
+	fh := int32(-1)
+	th := int32(-1)
+	if cmd.FromHeight != nil {
+		fh = *cmd.FromHeight
+	}
+	if cmd.ToHeight != nil {
+		th = *cmd.ToHeight
+	}
+	if err := w.RescanAddress(addr, fh, th); err != nil {
+		return nil, err
+	}
+	return nil, stopTimedOutJob("rescanaddress", w, quit)
+}
+
+// setMaintenanceMode handles a setmaintenancemode request by turning the
+// wallet's maintenance mode on or off.
+func setMaintenanceMode(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.SetMaintenanceModeCmd)
+	w.SetMaintenanceMode(cmd.Enable)
+	return nil, nil
 }
 
 // sendMany handles a sendmany RPC request by creating a new transaction
@@ -1419,12 +2921,6 @@ func resync(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 func sendMany(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	cmd := icmd.(*btcjson.SendManyCmd)
 
-	// Transaction comments are not yet supported.  Error instead of
-	// pretending to save them.
-	if !isNilOrEmpty(cmd.Comment) {
-		return nil, errCommentsUnsupported()
-	}
-
 	// Check that minconf is positive.
 	minConf := int32(*cmd.MinConf)
 	if minConf < 0 {
@@ -1446,7 +2942,387 @@ func sendMany(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 		maxInputs = *cmd.MaxInputs
 	}
 
-	return sendPairs(w, pairs, cmd.FromAddresses, minConf, txrules.DefaultRelayFeePerKb, maxInputs, 0)
+	return sendPairs(w, pairs, cmd.FromAddresses, minConf, txrules.DefaultRelayFeePerKb, maxInputs, 0,
+		cmd.Comment, nil, cmd.AllowSelfSend, cmd.ChangeInfo)
+}
+
+// sendManyDetailed handles a sendmanydetailed RPC request by sending to
+// multiple addresses in one transaction, as sendmany does, and describing the
+// fee paid along with the share of it attributed to each recipient output in
+// proportion to its amount.
+func sendManyDetailed(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.SendManyDetailedCmd)
+
+	minConf := int32(*cmd.MinConf)
+	if minConf < 0 {
+		return nil, errNeedPositiveMinconf()
+	}
+	pairs := make(map[string]btcutil.Amount, len(cmd.Amounts))
+	for k, v := range cmd.Amounts {
+		amt, err := btcutil.NewAmount(v)
+		if err != nil {
+			return nil, err
+		}
+		pairs[k] = amt
+	}
+	maxInputs := -1
+	if cmd.MaxInputs != nil {
+		maxInputs = *cmd.MaxInputs
+	}
+
+	vote, err := w.NetworkStewardVote(0, waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := sendOutputs(w, pairs, vote, cmd.FromAddresses, minConf,
+		txrules.DefaultRelayFeePerKb, wallet.SendModeBcasted, nil, 0, maxInputs,
+		nil, nil, cmd.AllowSelfSend)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Successfully sent transaction [%s]", log.Txid(tx.Tx.TxHash().String()))
+
+	fee := tx.TotalInput
+	var vouts []uint32
+	var values []btcutil.Amount
+	for i, out := range tx.Tx.TxOut {
+		fee -= btcutil.Amount(out.Value)
+		if tx.IsChange(i) {
+			continue
+		}
+		vouts = append(vouts, uint32(i))
+		values = append(values, btcutil.Amount(out.Value))
+	}
+	shares := txrules.AttributeFee(fee, values)
+
+	sent := sendResult(tx, w.ChainParams())
+	res := &btcjson.SendManyDetailedResult{
+		TxID:          sent.TxID,
+		Fee:           fee.ToBTC(),
+		Outputs:       make([]btcjson.SendManyDetailedOutput, 0, len(vouts)),
+		ChangeVout:    sent.ChangeVout,
+		ChangeAddress: sent.ChangeAddress,
+	}
+	for i, vout := range vouts {
+		addr := txscript.PkScriptToAddress(tx.Tx.TxOut[vout].PkScript, w.ChainParams())
+		res.Outputs = append(res.Outputs, btcjson.SendManyDetailedOutput{
+			Address: addr.EncodeAddress(),
+			Vout:    vout,
+			Amount:  values[i].ToBTC(),
+			Fee:     shares[i].ToBTC(),
+		})
+	}
+	return res, nil
+}
+
+// sendFromUtxos handles a sendfromutxos RPC request by sending to one or more
+// addresses from exactly the named unspent outputs, all of which are spent.
+// Whatever they have beyond the payments and the fee is returned as change.
+func sendFromUtxos(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.SendFromUtxosCmd)
+
+	inputs := make([]wire.OutPoint, 0, len(cmd.Inputs))
+	for _, input := range cmd.Inputs {
+		txHash, err := chainhash.NewHashFromStr(input.Txid)
+		if err != nil {
+			return nil, errParse("unable to parse hash", err)
+		}
+		inputs = append(inputs, wire.OutPoint{Hash: *txHash, Index: input.Vout})
+	}
+	pairs := make(map[string]btcutil.Amount, len(cmd.Amounts))
+	for k, v := range cmd.Amounts {
+		amt, err := btcutil.NewAmount(v)
+		if err != nil {
+			return nil, err
+		}
+		pairs[k] = amt
+	}
+	feeRate := txrules.DefaultRelayFeePerKb
+	if cmd.FeeRate != nil {
+		var err er.R
+		feeRate, err = txrules.ParseFeeRate(*cmd.FeeRate)
+		if err != nil {
+			return nil, btcjson.ErrRPCInvalidParameter.New(err.Message(), nil)
+		}
+		if feeRate < 0 {
+			return nil, btcjson.ErrRPCInvalidParameter.New(
+				"feerate must not be negative", nil)
+		}
+	}
+
+	vote, err := w.NetworkStewardVote(0, waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		return nil, err
+	}
+	req, err := createTxReq(w, pairs, vote, nil, 0, feeRate,
+		wallet.SendModeBcasted, cmd.ChangeAddress, 0, -1, cmd.Comment, nil,
+		cmd.AllowSelfSend)
+	if err != nil {
+		return nil, err
+	}
+	req.InputOutPoints = inputs
+	tx, err := w.SendOutputs(req)
+	if err != nil {
+		return nil, sendError(err, "SendOutputs")
+	}
+	log.Infof("Successfully sent transaction [%s]", log.Txid(tx.Tx.TxHash().String()))
+	return sendResult(tx, w.ChainParams()), nil
+}
+
+// pauseSync handles a pausesync request by pausing the neutrino sync of block
+// headers and filter headers, peers stay connected.
+func pauseSync(icmd interface{}, w *wallet.Wallet,
+	neut *chain.NeutrinoClient) (interface{}, er.R) {
+
+	if neut.CS.PauseSync() {
+		log.Infof("Chain sync paused")
+	}
+	return nil, nil
+}
+
+// resumeSync handles a resumesync request by resuming the neutrino sync of
+// block headers and filter headers.
+func resumeSync(icmd interface{}, w *wallet.Wallet,
+	neut *chain.NeutrinoClient) (interface{}, er.R) {
+
+	if neut.CS.ResumeSync() {
+		log.Infof("Chain sync resumed")
+	}
+	return nil, nil
+}
+
+// statsSnapshotter is a peer which reports a snapshot of its state, such as a
+// neutrino.ServerPeer.
+type statsSnapshotter interface {
+	StatsSnapshot() *peer.StatsSnap
+}
+
+// getPeerInfo handles a getpeerinfo request by returning the details of each
+// peer which neutrino is connected to.
+func getPeerInfo(icmd interface{}, w *wallet.Wallet,
+	neut *chain.NeutrinoClient) (interface{}, er.R) {
+
+	serverPeers := neut.CS.Peers()
+	peers := make([]statsSnapshotter, 0, len(serverPeers))
+	for _, sp := range serverPeers {
+		peers = append(peers, sp)
+	}
+	return peerInfo(peers), nil
+}
+
+// peerInfo returns the getpeerinfo result of each peer.  The fields which
+// pktd reports from the state of its sync manager and mempool, such as the ban
+// score, are left zero.
+func peerInfo(peers []statsSnapshotter) []btcjson.GetPeerInfoResult {
+	infos := make([]btcjson.GetPeerInfoResult, 0, len(peers))
+	for _, p := range peers {
+		statsSnap := p.StatsSnapshot()
+		info := btcjson.GetPeerInfoResult{
+			ID:             statsSnap.ID,
+			Addr:           statsSnap.Addr,
+			Services:       fmt.Sprintf("%08d", uint64(statsSnap.Services)),
+			LastSend:       statsSnap.LastSend.Unix(),
+			LastRecv:       statsSnap.LastRecv.Unix(),
+			BytesSent:      statsSnap.BytesSent,
+			BytesRecv:      statsSnap.BytesRecv,
+			ConnTime:       statsSnap.ConnTime.Unix(),
+			PingTime:       float64(statsSnap.LastPingMicros),
+			TimeOffset:     statsSnap.TimeOffset,
+			Version:        statsSnap.Version,
+			SubVer:         statsSnap.UserAgent,
+			Inbound:        statsSnap.Inbound,
+			StartingHeight: statsSnap.StartingHeight,
+			CurrentHeight:  statsSnap.LastBlock,
+		}
+		if statsSnap.LastPingNonce != 0 {
+			wait := float64(time.Since(statsSnap.LastPingTime).Nanoseconds())
+			// We actually want microseconds.
+			info.PingWait = wait / 1000
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// getBlockFilter handles a getblockfilter RPC request by returning the BIP158
+// regular filter of a block and its filter header from neutrino.
+func getBlockFilter(icmd interface{}, w *wallet.Wallet, neut *chain.NeutrinoClient) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.GetBlockFilterCmd)
+
+	blockHash, err := chainhash.NewHashFromStr(cmd.BlockHash)
+	if err != nil {
+		return nil, btcjson.ErrRPCDecodeHexString.New(
+			"Block hash string decode failed", err)
+	}
+	filter, header, err := neut.CS.GetBlockFilter(*blockHash)
+	if err != nil {
+		if neutrino.ErrFilterNotSynced.Is(err) {
+			return nil, btcjson.ErrRPCClientInInitialDownload.New(
+				"Block filter is not synced yet", err)
+		}
+		return nil, btcjson.ErrRPCBlockNotFound.New("Block filter not found", err)
+	}
+	filterBytes, err := filter.NBytes()
+	if err != nil {
+		return nil, err
+	}
+	return &btcjson.GetBlockFilterResult{
+		Filter: hex.EncodeToString(filterBytes),
+		Header: header.String(),
+	}, nil
+}
+
+// simulateSend handles a simulatesend RPC request by creating the transaction
+// which sendmanydetailed would send with the same parameters, selecting inputs
+// and change in the same way, but without signing or broadcasting it.
+func simulateSend(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.SimulateSendCmd)
+
+	minConf := int32(*cmd.MinConf)
+	if minConf < 0 {
+		return nil, errNeedPositiveMinconf()
+	}
+	pairs := make(map[string]btcutil.Amount, len(cmd.Amounts))
+	for k, v := range cmd.Amounts {
+		amt, err := btcutil.NewAmount(v)
+		if err != nil {
+			return nil, err
+		}
+		pairs[k] = amt
+	}
+	maxInputs := -1
+	if cmd.MaxInputs != nil {
+		maxInputs = *cmd.MaxInputs
+	}
+
+	vote, err := w.NetworkStewardVote(0, waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		return nil, err
+	}
+	req, err := createTxReq(w, pairs, vote, cmd.FromAddresses, minConf,
+		txrules.DefaultRelayFeePerKb, wallet.SendModeUnsigned, nil, 0, maxInputs,
+		nil, nil, cmd.AllowSelfSend)
+	if err != nil {
+		return nil, err
+	}
+	sim, err := w.SimulateSend(req)
+	if err != nil {
+		return nil, sendError(err, "SimulateSend")
+	}
+
+	tx := sim.Tx
+	var buf bytes.Buffer
+	buf.Grow(tx.Tx.SerializeSize())
+	if err := tx.Tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	sent := sendResult(tx, w.ChainParams())
+	res := &btcjson.SimulateSendResult{
+		Hex:           hex.EncodeToString(buf.Bytes()),
+		TxID:          sent.TxID,
+		Inputs:        make([]btcjson.SimulateSendInput, 0, len(tx.Tx.TxIn)),
+		Fee:           sim.Fee.ToBTC(),
+		VSize:         int64(sim.VSize),
+		ChangeVout:    sent.ChangeVout,
+		ChangeAddress: sent.ChangeAddress,
+	}
+	if tx.ChangeIndex >= 0 {
+		amt := btcutil.Amount(tx.Tx.TxOut[tx.ChangeIndex].Value).ToBTC()
+		res.ChangeAmount = &amt
+	}
+	for i, in := range tx.Tx.TxIn {
+		add := tx.Tx.Additional[i]
+		addr := txscript.PkScriptToAddress(add.PkScript, w.ChainParams())
+		res.Inputs = append(res.Inputs, btcjson.SimulateSendInput{
+			TxID:    in.PreviousOutPoint.Hash.String(),
+			Vout:    in.PreviousOutPoint.Index,
+			Address: addr.EncodeAddress(),
+			Amount:  btcutil.Amount(*add.Value).ToBTC(),
+		})
+	}
+	return res, nil
+}
+
+// getCoinSelectionPrivacy handles a getcoinselectionprivacy RPC request by
+// selecting the inputs and change which sendmanydetailed would with the same
+// parameters, and reporting what the transaction would reveal about the wallet.
+func getCoinSelectionPrivacy(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.GetCoinSelectionPrivacyCmd)
+
+	minConf := int32(*cmd.MinConf)
+	if minConf < 0 {
+		return nil, errNeedPositiveMinconf()
+	}
+	pairs := make(map[string]btcutil.Amount, len(cmd.Amounts))
+	for k, v := range cmd.Amounts {
+		amt, err := btcutil.NewAmount(v)
+		if err != nil {
+			return nil, err
+		}
+		pairs[k] = amt
+	}
+	maxInputs := -1
+	if cmd.MaxInputs != nil {
+		maxInputs = *cmd.MaxInputs
+	}
+
+	vote, err := w.NetworkStewardVote(0, waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		return nil, err
+	}
+	req, err := createTxReq(w, pairs, vote, cmd.FromAddresses, minConf,
+		txrules.DefaultRelayFeePerKb, wallet.SendModeUnsigned, nil, 0, maxInputs,
+		nil, nil, cmd.AllowSelfSend)
+	if err != nil {
+		return nil, err
+	}
+	privacy, err := w.CoinSelectionPrivacy(req)
+	if err != nil {
+		return nil, sendError(err, "CoinSelectionPrivacy")
+	}
+	reasons := privacy.ChangeReasons
+	if reasons == nil {
+		reasons = []string{}
+	}
+	return &btcjson.GetCoinSelectionPrivacyResult{
+		Inputs:         privacy.Inputs,
+		InputAddresses: privacy.InputAddresses,
+		HasChange:      privacy.HasChange,
+		ChangeRevealed: privacy.ChangeRevealed,
+		ChangeReasons:  reasons,
+	}, nil
+}
+
+// spendMax handles a spendmax RPC request by sending all of the spendable
+// outputs, or those paying the given addresses, to a single address less the
+// fee, which is the most that a single payment can send.
+func spendMax(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.SpendMaxCmd)
+
+	minConf := int32(*cmd.MinConf)
+	if minConf < 0 {
+		return nil, errNeedPositiveMinconf()
+	}
+	vote, err := w.NetworkStewardVote(0, waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		return nil, err
+	}
+	// A zero value output is paid everything left over after the fee.
+	pairs := map[string]btcutil.Amount{cmd.Address: 0}
+	tx, err := sendOutputs(w, pairs, vote, cmd.FromAddresses, minConf,
+		txrules.DefaultRelayFeePerKb, wallet.SendModeBcasted, nil, 0, -1,
+		nil, nil, cmd.AllowSelfSend)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Successfully sent transaction [%s]", log.Txid(tx.Tx.TxHash().String()))
+
+	amount := btcutil.Amount(tx.Tx.TxOut[0].Value)
+	return &btcjson.SpendMaxResult{
+		TxID:   tx.Tx.TxHash().String(),
+		Amount: amount.ToBTC(),
+		Fee:    (tx.TotalInput - amount).ToBTC(),
+	}, nil
 }
 
 // sendToAddress handles a sendtoaddress RPC request by creating a new
@@ -1457,12 +3333,6 @@ func sendMany(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 func sendToAddress(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	cmd := icmd.(*btcjson.SendToAddressCmd)
 
-	// Transaction comments are not yet supported.  Error instead of
-	// pretending to save them.
-	if !isNilOrEmpty(cmd.Comment) || !isNilOrEmpty(cmd.CommentTo) {
-		return nil, errCommentsUnsupported()
-	}
-
 	amt, err := btcutil.NewAmount(cmd.Amount)
 	if err != nil {
 		return nil, err
@@ -1479,7 +3349,8 @@ func sendToAddress(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
 	}
 
 	// sendtoaddress always spends from the default account, this matches bitcoind
-	return sendPairs(w, pairs, nil, 1, txrules.DefaultRelayFeePerKb, -1, 0)
+	return sendPairs(w, pairs, nil, 1, txrules.DefaultRelayFeePerKb, -1, 0,
+		cmd.Comment, cmd.CommentTo, cmd.AllowSelfSend, cmd.ChangeInfo)
 }
 
 // setTxFee sets the transaction fee per kilobyte added to transactions.
@@ -1880,6 +3751,31 @@ func walletPassphraseChange(icmd interface{}, w *wallet.Wallet) (interface{}, er
 	return nil, err
 }
 
+// rekeyWallet handles a rekeywallet request by re-encrypting the keys securing
+// the private keys of the unlocked wallet under a key derived from the same
+// passphrase with the given scrypt parameters.
+func rekeyWallet(icmd interface{}, w *wallet.Wallet) (interface{}, er.R) {
+	cmd := icmd.(*btcjson.RekeyWalletCmd)
+
+	prev := w.Manager.PrivateScryptOptions()
+	opts := waddrmgr.ScryptOptions{N: *cmd.N, R: *cmd.R, P: *cmd.P}
+	err := w.Rekey([]byte(cmd.Passphrase), opts)
+	switch {
+	case wallet.ErrUnsafeScryptParams.Is(err):
+		return nil, btcjson.ErrRPCInvalidParameter.New(err.Message(), nil)
+	case waddrmgr.ErrWrongPassphrase.Is(err):
+		return nil, btcjson.ErrRPCWalletPassphraseIncorrect.Default()
+	case waddrmgr.ErrLocked.Is(err):
+		return nil, btcjson.ErrRPCWalletUnlockNeeded.Default()
+	case err != nil:
+		return nil, err
+	}
+	return &btcjson.RekeyWalletResult{
+		Previous: btcjson.ScryptParamsResult{N: prev.N, R: prev.R, P: prev.P},
+		Current:  btcjson.ScryptParamsResult{N: opts.N, R: opts.R, P: opts.P},
+	}, nil
+}
+
 // decodeHexStr decodes the hex encoding of a string, possibly prepending a
 // leading '0' character if there is an odd number of bytes in the hex string.
 // This is to prevent an error for an invalid hex string when using an odd
@@ -0,0 +1,205 @@
+package legacyrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcjson"
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg"
+	"github.com/pkt-cash/pktd/peer"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txauthor"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestSendResultChange ensures that the result of a send identifies the change
+// output, and reports null change fields when there is no change.
+func TestSendResultChange(t *testing.T) {
+	params := &chaincfg.TestNet3Params
+	payAddr, err := btcutil.NewAddressWitnessPubKeyHash(make([]byte, 20), params)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	changeAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+		[]byte{0: 1, 19: 1}, params)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	payScript, err := txscript.PayToAddrScript(payAddr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+	changeScript, err := txscript.PayToAddrScript(changeAddr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+
+	tx := &txauthor.AuthoredTx{
+		Tx: &wire.MsgTx{
+			Version: 1,
+			TxIn:    []*wire.TxIn{{}},
+			TxOut: []*wire.TxOut{
+				wire.NewTxOut(1000, payScript),
+				wire.NewTxOut(2000, changeScript),
+			},
+		},
+		ChangeIndex: 1,
+	}
+	res := sendResult(tx, params)
+	if res.TxID != tx.Tx.TxHash().String() {
+		t.Fatalf("got txid %v, want %v", res.TxID, tx.Tx.TxHash())
+	}
+	if res.ChangeVout == nil || *res.ChangeVout != 1 {
+		t.Fatalf("got change vout %v, want 1", res.ChangeVout)
+	}
+	if res.ChangeAddress == nil ||
+		*res.ChangeAddress != changeAddr.EncodeAddress() {
+		t.Fatalf("got change address %v, want %v", res.ChangeAddress,
+			changeAddr)
+	}
+
+	// Without change both fields are null.
+	tx.Tx.TxOut = tx.Tx.TxOut[:1]
+	tx.ChangeIndex = -1
+	res = sendResult(tx, params)
+	if res.ChangeVout != nil || res.ChangeAddress != nil {
+		t.Fatalf("expected no change, got vout %v address %v",
+			res.ChangeVout, res.ChangeAddress)
+	}
+	b, errr := json.Marshal(res)
+	if errr != nil {
+		t.Fatalf("unable to marshal result: %v", errr)
+	}
+	var fields map[string]interface{}
+	if errr := json.Unmarshal(b, &fields); errr != nil {
+		t.Fatalf("unable to unmarshal result: %v", errr)
+	}
+	for _, k := range []string{"changevout", "changeaddress"} {
+		if v, ok := fields[k]; !ok || v != nil {
+			t.Fatalf("expected %s to be null in %s", k, b)
+		}
+	}
+}
+
+// fakePeer is a peer which reports a fixed snapshot of its state.
+type fakePeer peer.StatsSnap
+
+func (p *fakePeer) StatsSnapshot() *peer.StatsSnap {
+	snap := peer.StatsSnap(*p)
+	return &snap
+}
+
+// TestPeerInfo ensures that getpeerinfo reports the address, user agent,
+// protocol version, direction, connection time and last advertised block of
+// each peer.
+func TestPeerInfo(t *testing.T) {
+	connTime := time.Unix(1600000000, 0)
+	peers := []statsSnapshotter{
+		&fakePeer{
+			ID:             1,
+			Addr:           "203.0.113.1:64764",
+			UserAgent:      "/pktd:1.6.0/",
+			Version:        70016,
+			Inbound:        false,
+			ConnTime:       connTime,
+			StartingHeight: 1000,
+			LastBlock:      1010,
+		},
+		&fakePeer{
+			ID:             2,
+			Addr:           "[2001:db8::1]:64764",
+			UserAgent:      "/pktd:1.5.1/",
+			Version:        70013,
+			Inbound:        true,
+			ConnTime:       connTime.Add(time.Hour),
+			StartingHeight: 1005,
+			LastBlock:      1011,
+		},
+	}
+	infos := peerInfo(peers)
+	if len(infos) != len(peers) {
+		t.Fatalf("got %d peers, want %d", len(infos), len(peers))
+	}
+	for i, p := range peers {
+		want := p.StatsSnapshot()
+		info := infos[i]
+		if info.ID != want.ID || info.Addr != want.Addr ||
+			info.SubVer != want.UserAgent ||
+			info.Version != want.Version ||
+			info.Inbound != want.Inbound ||
+			info.ConnTime != want.ConnTime.Unix() ||
+			info.StartingHeight != want.StartingHeight ||
+			info.CurrentHeight != want.LastBlock {
+
+			t.Fatalf("got peer info %+v, want %+v", info, *want)
+		}
+		if info.PingWait != 0 {
+			t.Fatalf("got ping wait %v without a ping", info.PingWait)
+		}
+	}
+}
+
+// TestMakeOutputsWrongNetwork ensures that outputs paying addresses of another
+// network are refused with an error giving the reason for each of them.
+func TestMakeOutputsWrongNetwork(t *testing.T) {
+	params := &chaincfg.PktMainNetParams
+	address := func(hash byte, params *chaincfg.Params, witness bool) string {
+		var a btcutil.Address
+		var err er.R
+		if witness {
+			a, err = btcutil.NewAddressWitnessPubKeyHash(
+				[]byte{19: hash}, params)
+		} else {
+			a, err = btcutil.NewAddressPubKeyHash([]byte{19: hash}, params)
+		}
+		if err != nil {
+			t.Fatalf("unable to create address: %v", err)
+		}
+		return a.EncodeAddress()
+	}
+	valid := address(1, params, true)
+	validLegacy := address(2, params, false)
+	wrongLegacy := address(3, &chaincfg.PktTestNetParams, false)
+	wrongWitness := address(4, &chaincfg.PktTestNetParams, true)
+
+	outputs, err := makeOutputs(map[string]btcutil.Amount{
+		valid:       1e8,
+		validLegacy: 1e8,
+	}, nil, params)
+	if err != nil {
+		t.Fatalf("unable to make outputs: %v", err)
+	}
+	if len(outputs) != 2 {
+		t.Fatalf("got %d outputs, want 2", len(outputs))
+	}
+
+	_, err = makeOutputs(map[string]btcutil.Amount{
+		valid:        1e8,
+		wrongLegacy:  1e8,
+		validLegacy:  1e8,
+		wrongWitness: 1e8,
+		"garbage":    1e8,
+	}, nil, params)
+	if !btcjson.ErrRPCInvalidAddressOrKey.Is(err) {
+		t.Fatalf("got error %v, want ErrRPCInvalidAddressOrKey", err)
+	}
+	msg := err.Message()
+	for _, a := range []string{wrongLegacy, wrongWitness, "garbage"} {
+		if !strings.Contains(msg, fmt.Sprintf("Invalid address %q", a)) {
+			t.Fatalf("invalid address %s is not in error %q", a, msg)
+		}
+	}
+	for _, a := range []string{valid, validLegacy} {
+		if strings.Contains(msg, a) {
+			t.Fatalf("valid address %s is in error %q", a, msg)
+		}
+	}
+	if !strings.Contains(msg, "address of pkttest") {
+		t.Fatalf("network of %s is not in error %q", wrongLegacy, msg)
+	}
+}
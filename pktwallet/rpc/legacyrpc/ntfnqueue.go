@@ -0,0 +1,112 @@
+package legacyrpc
+
+import (
+	"sync"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+)
+
+// NotifyOverflow is what is done when the notification queue of a websocket
+// client is full.
+type NotifyOverflow int
+
+const (
+	// NotifyDropOldest drops the oldest queued notification to make room
+	// for the new one.
+	NotifyDropOldest NotifyOverflow = iota
+
+	// NotifyDisconnect disconnects the client.
+	NotifyDisconnect
+)
+
+// ParseNotifyOverflow parses a notification queue overflow policy, either drop
+// to drop the oldest notification or disconnect to disconnect the client.
+func ParseNotifyOverflow(s string) (NotifyOverflow, er.R) {
+	switch s {
+	case "drop":
+		return NotifyDropOldest, nil
+	case "disconnect":
+		return NotifyDisconnect, nil
+	default:
+		return 0, er.Errorf("notifyqueueoverflow [%s] must be either drop "+
+			"or disconnect", s)
+	}
+}
+
+// DefaultNotifyQueueSize is the number of notifications which may be queued
+// for a websocket client unless Options.NotifyQueueSize is set.
+const DefaultNotifyQueueSize = 100
+
+// ntfnQueue holds the marshalled notifications waiting to be sent to a
+// websocket client, so that a slow client does not hold up the wallet.
+type ntfnQueue struct {
+	mu      sync.Mutex
+	items   [][]byte
+	size    int
+	dropped uint64
+	ready   chan struct{}
+}
+
+func newNtfnQueue(size int) *ntfnQueue {
+	if size <= 0 {
+		size = DefaultNotifyQueueSize
+	}
+	return &ntfnQueue{
+		size:  size,
+		ready: make(chan struct{}, 1),
+	}
+}
+
+// push queues a notification.  If the queue is full the oldest notification
+// is dropped to make room when dropOldest is set, otherwise the notification
+// is not queued.  It returns false if the queue was full.
+func (q *ntfnQueue) push(b []byte, dropOldest bool) bool {
+	q.mu.Lock()
+	full := len(q.items) >= q.size
+	if full {
+		q.dropped++
+	}
+	if full && dropOldest {
+		q.items[0] = nil
+		q.items = q.items[1:]
+	}
+	if !full || dropOldest {
+		q.items = append(q.items, b)
+	}
+	q.mu.Unlock()
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	return !full
+}
+
+// pop removes and returns the oldest queued notification, or nil if the queue
+// is empty.
+func (q *ntfnQueue) pop() []byte {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		return nil
+	}
+	b := q.items[0]
+	q.items[0] = nil
+	q.items = q.items[1:]
+	return b
+}
+
+// droppedCount returns the number of notifications which were dropped, or
+// not queued, because the queue was full.
+func (q *ntfnQueue) droppedCount() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.dropped
+}
+
+// len returns the number of queued notifications.
+func (q *ntfnQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
@@ -0,0 +1,53 @@
+package legacyrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+)
+
+// RPCAuth is a hashed RPC credential, it allows a user to log in without the
+// password being stored in the configuration.  The hash is the HMAC-SHA256 of
+// the password keyed with the salt, as used by bitcoind's rpcauth option.
+type RPCAuth struct {
+	Username string
+	Salt     string
+	Hash     []byte
+}
+
+// ParseRPCAuth parses a credential in the form user:salt:hash, the form
+// user:salt$hash which is generated by bitcoind's rpcauth.py is also accepted.
+// The hash is hex encoded.
+func ParseRPCAuth(s string) (*RPCAuth, er.R) {
+	fields := strings.SplitN(s, ":", 3)
+	if len(fields) == 2 {
+		if i := strings.LastIndex(fields[1], "$"); i >= 0 {
+			fields = []string{fields[0], fields[1][:i], fields[1][i+1:]}
+		}
+	}
+	if len(fields) != 3 || fields[0] == "" || fields[1] == "" {
+		return nil, er.Errorf("rpcauth [%s] is not in the form user:salt:hash", s)
+	}
+	hash, errr := hex.DecodeString(fields[2])
+	if errr != nil || len(hash) != sha256.Size {
+		return nil, er.Errorf("rpcauth hash for user [%s] is not a hex "+
+			"encoded sha256 hmac", fields[0])
+	}
+	return &RPCAuth{Username: fields[0], Salt: fields[1], Hash: hash}, nil
+}
+
+// HashRPCPassword returns the HMAC-SHA256 of a password keyed with a salt.
+func HashRPCPassword(salt, password string) []byte {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(password))
+	return mac.Sum(nil)
+}
+
+// Check returns whether a username and password match the credential.
+func (a *RPCAuth) Check(username, password string) bool {
+	hash := HashRPCPassword(a.Salt, password)
+	return hmac.Equal(hash, a.Hash) && username == a.Username
+}
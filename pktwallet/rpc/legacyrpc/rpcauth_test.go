@@ -0,0 +1,76 @@
+package legacyrpc
+
+import (
+	"encoding/hex"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRPCAuth checks hashed credentials against correct and incorrect
+// passwords, both directly and through the server's auth header check.
+func TestRPCAuth(t *testing.T) {
+	hash := hex.EncodeToString(HashRPCPassword("cafebabe", "secret"))
+	for _, s := range []string{
+		"alice:cafebabe:" + hash,
+		"alice:cafebabe$" + hash,
+	} {
+		a, err := ParseRPCAuth(s)
+		if err != nil {
+			t.Fatalf("unable to parse %s: %v", s, err)
+		}
+		if !a.Check("alice", "secret") {
+			t.Fatalf("%s: correct password rejected", s)
+		}
+		if a.Check("alice", "wrong") {
+			t.Fatalf("%s: incorrect password accepted", s)
+		}
+		if a.Check("bob", "secret") {
+			t.Fatalf("%s: incorrect username accepted", s)
+		}
+	}
+
+	for _, s := range []string{
+		"alice",
+		"alice:" + hash,
+		":cafebabe:" + hash,
+		"alice:cafebabe:nothex",
+		"alice:cafebabe:abcd",
+	} {
+		if _, err := ParseRPCAuth(s); err == nil {
+			t.Fatalf("parsed invalid rpcauth %s", s)
+		}
+	}
+
+	bob, err := ParseRPCAuth("bob:deadbeef:" +
+		hex.EncodeToString(HashRPCPassword("deadbeef", "hunter2")))
+	if err != nil {
+		t.Fatalf("unable to parse rpcauth: %v", err)
+	}
+	alice, err := ParseRPCAuth("alice:cafebabe:" + hash)
+	if err != nil {
+		t.Fatalf("unable to parse rpcauth: %v", err)
+	}
+	s := NewServer(&Options{RPCAuths: []*RPCAuth{alice, bob}}, nil, nil)
+	tests := []struct {
+		user, pass string
+		ok         bool
+	}{
+		{"alice", "secret", true},
+		{"bob", "hunter2", true},
+		{"bob", "secret", false},
+		{"alice", "hunter2", false},
+		// Without rpcuser and rpcpass empty credentials must not work.
+		{"", "", false},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.SetBasicAuth(test.user, test.pass)
+		err := s.checkAuthHeader(r)
+		if test.ok && err != nil {
+			t.Fatalf("%s/%s rejected: %v", test.user, test.pass, err)
+		}
+		if !test.ok && err == nil {
+			t.Fatalf("%s/%s accepted", test.user, test.pass)
+		}
+	}
+}
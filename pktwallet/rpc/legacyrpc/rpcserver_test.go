@@ -5,12 +5,24 @@
 package legacyrpc
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkt-cash/pktd/btcjson"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/wallet"
 )
 
 func TestThrottle(t *testing.T) {
@@ -54,3 +66,501 @@ func TestThrottle(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestGzipped(t *testing.T) {
+	body := bytes.Repeat([]byte(`{"result":null,"error":null,"id":1}`), 100)
+	srv := httptest.NewServer(gzipped(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Write(body)
+		}),
+	))
+	defer srv.Close()
+
+	// Disable the transport's own compression so the raw response is seen.
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	get := func(acceptEncoding string) (*http.Response, []byte) {
+		req, err := http.NewRequest("POST", srv.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res, b
+	}
+
+	res, b := get("deflate, gzip;q=0.8")
+	if enc := res.Header.Get("Content-Encoding"); enc != "gzip" {
+		t.Fatalf("got Content-Encoding %q, want gzip", enc)
+	}
+	if len(b) >= len(body) {
+		t.Fatalf("compressed response is %d bytes, not smaller than %d",
+			len(b), len(body))
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err = ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, body) {
+		t.Fatalf("decompressed response does not match the original")
+	}
+
+	for _, acceptEncoding := range []string{"", "deflate", "gzip;q=0"} {
+		res, b := get(acceptEncoding)
+		if enc := res.Header.Get("Content-Encoding"); enc != "" {
+			t.Fatalf("got Content-Encoding %q for Accept-Encoding %q, "+
+				"want none", enc, acceptEncoding)
+		}
+		if !bytes.Equal(b, body) {
+			t.Fatalf("uncompressed response does not match the original")
+		}
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	type line struct {
+		N int `json:"n"`
+	}
+	stream := func(fail bool) ndjsonStream {
+		return func(emit func(interface{}) er.R) er.R {
+			for i := 0; i < 3; i++ {
+				if err := emit(&line{i}); err != nil {
+					return err
+				}
+			}
+			if fail {
+				return er.New("database closed")
+			}
+			return nil
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	writeNDJSON(rec, stream(false))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Fatalf("got Content-Type %q, want application/x-ndjson", ct)
+	}
+	want := "{\"n\":0}\n{\"n\":1}\n{\"n\":2}\n"
+	if rec.Body.String() != want {
+		t.Fatalf("got body %q, want %q", rec.Body.String(), want)
+	}
+
+	rec = httptest.NewRecorder()
+	writeNDJSON(rec, stream(true))
+	want += "{\"error\":\"database closed\"}\n"
+	if rec.Body.String() != want {
+		t.Fatalf("got body %q, want %q", rec.Body.String(), want)
+	}
+
+	values, err := collectNDJSON(stream(false))
+	if err != nil || len(values.([]interface{})) != 3 {
+		t.Fatalf("got values %v, error %v, want 3 values", values, err)
+	}
+	if _, err := collectNDJSON(stream(true)); err == nil {
+		t.Fatalf("collected a failed stream")
+	}
+}
+
+// fakeSyncState is a syncState which is synced once synced is set.
+type fakeSyncState struct {
+	synced bool
+}
+
+func (f *fakeSyncState) ChainSynced() bool { return f.synced }
+
+func TestCheckSynced(t *testing.T) {
+	sync := &fakeSyncState{}
+	for _, method := range []string{"getbalance", "listunspent"} {
+		err := checkSynced(method, sync)
+		if !btcjson.ErrRPCClientInInitialDownload.Is(err) {
+			t.Fatalf("%s: got error %v while syncing, want "+
+				"ErrRPCClientInInitialDownload", method, err)
+		}
+	}
+	// getinfo is how a client sees how far the sync has come, so it is
+	// answered while syncing.
+	for _, method := range []string{"getnewaddress", "getinfo"} {
+		if err := checkSynced(method, sync); err != nil {
+			t.Fatalf("%s refused while syncing: %v", method, err)
+		}
+	}
+
+	sync.synced = true
+	for _, method := range []string{"getbalance", "listunspent"} {
+		if err := checkSynced(method, sync); err != nil {
+			t.Fatalf("%s: got error %v once synced", method, err)
+		}
+	}
+}
+
+// TestCheckMaintenance ensures that turning maintenance mode on with
+// setmaintenancemode refuses sends while queries are still answered, and that
+// turning it off allows sends again.
+func TestCheckMaintenance(t *testing.T) {
+	w := &wallet.Wallet{}
+	sends := []string{"sendtoaddress", "sendmany", "sendfrom", "createtransaction"}
+	for _, method := range sends {
+		if err := checkMaintenance(method, w); err != nil {
+			t.Fatalf("%s: got error %v before maintenance mode", method, err)
+		}
+	}
+
+	_, err := setMaintenanceMode(&btcjson.SetMaintenanceModeCmd{Enable: true}, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, method := range sends {
+		err := checkMaintenance(method, w)
+		if !btcjson.ErrRPCWallet.Is(err) {
+			t.Fatalf("%s: got error %v in maintenance mode, want "+
+				"ErrRPCWallet", method, err)
+		}
+	}
+	for _, method := range []string{"getbalance", "listunspent", "setmaintenancemode"} {
+		if err := checkMaintenance(method, w); err != nil {
+			t.Fatalf("%s refused in maintenance mode: %v", method, err)
+		}
+	}
+
+	_, err = setMaintenanceMode(&btcjson.SetMaintenanceModeCmd{Enable: false}, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, method := range sends {
+		if err := checkMaintenance(method, w); err != nil {
+			t.Fatalf("%s: got error %v after maintenance mode", method, err)
+		}
+	}
+}
+
+// TestWithTimeout ensures that a method which runs longer than its timeout is
+// stopped and answered with ErrRPCTimeout, that a stream which it returns is
+// stopped in the same way, while one which finishes in time is answered with
+// its result, and that methods which move funds cannot be timed out.
+func TestWithTimeout(t *testing.T) {
+	stopped := make(chan struct{})
+	slow := func(quit <-chan struct{}) (interface{}, er.R) {
+		defer close(stopped)
+		<-quit
+		return nil, errTimedOut("resync")
+	}
+	start := time.Now()
+	res, err := withTimeout("resync", 50*time.Millisecond, slow)()
+	if !btcjson.ErrRPCTimeout.Is(err) {
+		t.Fatalf("got result %v error %v, want ErrRPCTimeout", res, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("timed out after %v", elapsed)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("handler still running after it timed out")
+	}
+
+	// A stream is written after its handler returns, it is stopped once
+	// the timeout passes while it is being written.
+	emitted := 0
+	dump := func(quit <-chan struct{}) (interface{}, er.R) {
+		return ndjsonStream(func(emit func(interface{}) er.R) er.R {
+			for {
+				if timedOut(quit) {
+					return errTimedOut("dumputxoset")
+				}
+				if err := emit(emitted); err != nil {
+					return err
+				}
+				emitted++
+				time.Sleep(time.Millisecond)
+			}
+		}), nil
+	}
+	res, err = withTimeout("dumputxoset", 50*time.Millisecond, dump)()
+	if err != nil {
+		t.Fatalf("got error %v, want a stream", err)
+	}
+	if _, err := collectNDJSON(res.(ndjsonStream)); !btcjson.ErrRPCTimeout.Is(err) {
+		t.Fatalf("got error %v after %d lines, want ErrRPCTimeout",
+			err, emitted)
+	}
+
+	fast := func(quit <-chan struct{}) (interface{}, er.R) { return "done", nil }
+	res, err = withTimeout("resync", time.Minute, fast)()
+	if err != nil || res != "done" {
+		t.Fatalf("got result %v error %v, want done", res, err)
+	}
+
+	// A method which moves funds is never stopped, it would still send
+	// after the client was told that it timed out.
+	sent := make(chan struct{})
+	send := func(quit <-chan struct{}) (interface{}, er.R) {
+		time.Sleep(100 * time.Millisecond)
+		if timedOut(quit) {
+			t.Errorf("send was told to stop")
+		}
+		close(sent)
+		return "txid", nil
+	}
+	res, err = withTimeout("sendtoaddress", 10*time.Millisecond, send)()
+	if err != nil || res != "txid" {
+		t.Fatalf("got result %v error %v for a send, want its txid", res, err)
+	}
+	select {
+	case <-sent:
+	default:
+		t.Fatalf("send answered before it completed")
+	}
+
+	method, timeout, err := ParseMethodTimeout("resync=10m")
+	if err != nil || method != "resync" || timeout != 10*time.Minute {
+		t.Fatalf("got %s %v %v parsing resync=10m", method, timeout, err)
+	}
+	for _, s := range []string{"resync", "nosuchmethod=1m", "resync=soon",
+		"resync=0s", "sendtoaddress=1m"} {
+		if _, _, err := ParseMethodTimeout(s); err == nil {
+			t.Fatalf("parsed invalid timeout %s", s)
+		}
+	}
+}
+
+// TestAccessLog checks that a call is written to the access log with the
+// method, user, remote address and status, and that its passphrase is not.
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	s := &Server{accessLog: &buf}
+
+	body := `{"jsonrpc":"1.0","id":1,"method":"walletpassphrase",` +
+		`"params":["hunter2",60]}`
+	r := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	r.RemoteAddr = "192.0.2.1:1234"
+	r.SetBasicAuth("alice", "password")
+	s.postClientRPC(httptest.NewRecorder(), r)
+
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Fatalf("passphrase written to access log: %s", buf.String())
+	}
+	var entry accessLogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unable to parse access log entry %q: %v", buf.String(), err)
+	}
+	if entry.Method != "walletpassphrase" || entry.User != "alice" ||
+		entry.Remote != "192.0.2.1:1234" {
+		t.Fatalf("got access log entry %+v", entry)
+	}
+	// No wallet is loaded so the call fails.
+	if entry.Status != "error" || entry.Code == 0 {
+		t.Fatalf("got status %s and code %d, want an error", entry.Status,
+			entry.Code)
+	}
+	if entry.Time == "" || entry.DurationMS < 0 {
+		t.Fatalf("got time %q and duration %v", entry.Time, entry.DurationMS)
+	}
+	want := []interface{}{redacted, float64(60)}
+	if !reflect.DeepEqual(entry.Params, want) {
+		t.Fatalf("got params %v, want %v", entry.Params, want)
+	}
+}
+
+// closingBuffer is an access log which records whether it was closed.
+type closingBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *closingBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+// TestAccessLogClose checks that the access log is closed when the server is
+// stopped and that calls which return afterwards are not written to it.
+func TestAccessLogClose(t *testing.T) {
+	accessLog := &closingBuffer{}
+	s := &Server{accessLog: accessLog, quit: make(chan struct{})}
+	f := s.accessLogged(&btcjson.Request{Method: "getinfo"}, "alice",
+		"192.0.2.1:1234", func() (interface{}, er.R) { return nil, nil })
+
+	s.Stop()
+	if !accessLog.closed {
+		t.Fatalf("access log not closed when the server stopped")
+	}
+	f()
+	if accessLog.Len() != 0 {
+		t.Fatalf("call written to the closed access log: %s",
+			accessLog.String())
+	}
+}
+
+// TestNotificationQueueOverflow ensures that the notification queue of a slow
+// websocket client is bounded, dropping the oldest notifications or asking for
+// the client to be disconnected as the overflow policy says, and that dropped
+// notifications are counted.
+func TestNotificationQueueOverflow(t *testing.T) {
+	ntfn := func(i int) []byte { return []byte(fmt.Sprint(i)) }
+
+	s := &Server{notifyOverflow: NotifyDropOldest}
+	q := newNtfnQueue(3)
+	for i := 0; i < 5; i++ {
+		if !s.queueNotification(q, ntfn(i)) {
+			t.Fatalf("client disconnected with the drop policy")
+		}
+	}
+	if q.len() != 3 {
+		t.Fatalf("got %d queued notifications, want 3", q.len())
+	}
+	for i := 2; i < 5; i++ {
+		if b := q.pop(); !bytes.Equal(b, ntfn(i)) {
+			t.Fatalf("got notification %s, want %s", b, ntfn(i))
+		}
+	}
+	if b := q.pop(); b != nil {
+		t.Fatalf("got notification %s from an empty queue", b)
+	}
+	if d := s.NotificationsDropped(); d != 2 {
+		t.Fatalf("got %d dropped notifications, want 2", d)
+	}
+
+	s = &Server{notifyOverflow: NotifyDisconnect}
+	q = newNtfnQueue(3)
+	for i := 0; i < 3; i++ {
+		if !s.queueNotification(q, ntfn(i)) {
+			t.Fatalf("client disconnected before its queue was full")
+		}
+	}
+	if s.queueNotification(q, ntfn(3)) {
+		t.Fatalf("client kept with a full queue and the disconnect policy")
+	}
+	if q.len() != 3 {
+		t.Fatalf("got %d queued notifications, want 3", q.len())
+	}
+	if b := q.pop(); !bytes.Equal(b, ntfn(0)) {
+		t.Fatalf("got notification %s, want the oldest", b)
+	}
+	if d := s.NotificationsDropped(); d != 1 {
+		t.Fatalf("got %d dropped notifications, want 1", d)
+	}
+
+	if _, err := ParseNotifyOverflow("block"); err == nil {
+		t.Fatalf("parsed an unknown overflow policy")
+	}
+}
+
+// TestSlowWebsocketClient ensures that notifications for a websocket client
+// which does not read them are dropped, keeping the newest, or that the client
+// is disconnected, as the overflow policy says, and that the notifications
+// dropped for the client are added to the wallet stats.
+func TestSlowWebsocketClient(t *testing.T) {
+	conflicted := func(n int) *wallet.TransactionNotifications {
+		ntfn := &wallet.TransactionNotifications{}
+		for i := 0; i < n; i++ {
+			ntfn.ConflictedTransactions = append(ntfn.ConflictedTransactions,
+				wallet.ConflictedTransaction{
+					Hash:         &chainhash.Hash{byte(i)},
+					ConflictedBy: &chainhash.Hash{},
+				})
+		}
+		return ntfn
+	}
+	notify := func(s *Server, wsc *websocketClient,
+		stats *btcjson.WalletStats) (chan *wallet.TransactionNotifications,
+		chan struct{}) {
+
+		ntfns := make(chan *wallet.TransactionNotifications)
+		done := make(chan struct{})
+		go func() {
+			s.notifyClient(wsc, ntfns, func(f func(*btcjson.WalletStats)) {
+				f(stats)
+			})
+			close(done)
+		}()
+		return ntfns, done
+	}
+
+	// The client reads nothing until both batches are queued.  At most one
+	// notification is being sent and three are queued, so at least two of
+	// the six are dropped.
+	s := &Server{notifyOverflow: NotifyDropOldest, notifyQueueSize: 3}
+	wsc := newWebsocketClient(nil, true, "", "slow")
+	var stats btcjson.WalletStats
+	ntfns, done := notify(s, wsc, &stats)
+	ntfns <- conflicted(6)
+	ntfns <- conflicted(0)
+	dropped := int(s.NotificationsDropped())
+	if dropped < 2 {
+		t.Fatalf("got %d dropped notifications, want at least 2", dropped)
+	}
+	var last []byte
+	for i := 0; i < 6-dropped; i++ {
+		select {
+		case last = <-wsc.responses:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got %d notifications, want %d", i, 6-dropped)
+		}
+	}
+	want := fmt.Sprintf(`"%s"`, &chainhash.Hash{5})
+	if !strings.Contains(string(last), want) {
+		t.Fatalf("got last notification %s, want the newest", last)
+	}
+	close(wsc.stopNtfns)
+	<-done
+	wsc.wg.Wait()
+	if stats.NotificationsDropped != uint64(dropped) {
+		t.Fatalf("got %d dropped notifications in the wallet stats, "+
+			"want %d", stats.NotificationsDropped, dropped)
+	}
+
+	// With the disconnect policy the connection is closed once the queue
+	// is full.
+	conns := make(chan *websocket.Conn, 1)
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+			if err != nil {
+				t.Errorf("unable to upgrade: %v", err)
+				return
+			}
+			conns <- conn
+		}))
+	defer srv.Close()
+	client, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("unable to dial: %v", err)
+	}
+	defer client.Close()
+
+	s = &Server{notifyOverflow: NotifyDisconnect, notifyQueueSize: 1}
+	wsc = newWebsocketClient(<-conns, true, "", "slow")
+	stats = btcjson.WalletStats{}
+	ntfns, done = notify(s, wsc, &stats)
+	ntfns <- conflicted(3)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("slow client was not disconnected")
+	}
+	if _, _, err := client.ReadMessage(); err == nil {
+		t.Fatalf("read from a disconnected client")
+	}
+	close(wsc.quit)
+	close(wsc.stopNtfns)
+	wsc.wg.Wait()
+	if stats.NotificationsDropped != 1 || s.NotificationsDropped() != 1 {
+		t.Fatalf("got %d dropped notifications in the wallet stats and %d "+
+			"in total, want 1", stats.NotificationsDropped,
+			s.NotificationsDropped())
+	}
+}
@@ -4,51 +4,107 @@ package legacyrpc
 
 func helpDescsEnUS() map[string]string {
 	return map[string]string{
-		"addmultisigaddress":      "addmultisigaddress nrequired [\"key\",...]\n\nGenerates and imports a multisig address and redeeming script to the 'imported' account.\n\nArguments:\n1. nrequired (numeric, required)         The number of signatures required to redeem outputs paid to this address\n2. keys      (array of string, required) Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address\n\nResult:\n\"value\" (string) The imported pay-to-script-hash address\n",
-		"createmultisig":          "createmultisig nrequired [\"key\",...]\n\nGenerate a multisig address and redeem script.\n\nArguments:\n1. nrequired (numeric, required)         The number of signatures required to redeem outputs paid to this address\n2. keys      (array of string, required) Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address\n\nResult:\n{\n \"address\": \"value\",      (string) The generated pay-to-script-hash address\n \"redeemScript\": \"value\", (string) The script required to redeem outputs paid to the multisig address\n}                         \n",
-		"createtransaction":       "createtransaction \"toaddress\" amount ([\"fromaddress\",...] electrumformat \"changeaddress\" inputminheight minconf=1 vote maxinputs \"autolock\" nosign)\n\nCreate a transaction but do not send it to the chain\n\nArguments:\n1.  toaddress      (string, required)             The recipient to send the coins to\n2.  amount         (numeric, required)            The amount of coins to send\n3.  fromaddresses  (array of string, optional)    Addresses to use for selecting coins to spend\n4.  electrumformat (boolean, optional)            If true, then the transaction result will be output in electrum incomplete transaction format, useful for signing later\n5.  changeaddress  (string, optional)             Return extra coins to this address, if unspecified then one will be created\n6.  inputminheight (numeric, optional)            The minimum block height to take inputs from (default: 0)\n7.  minconf        (numeric, optional, default=1) Do not spend any outputs which don't have at least this number of confirmations (default 1)\n8.  vote           (boolean, optional)            True if you wish for this transaction to contain a network steward vote\n9.  maxinputs      (numeric, optional)            Maximum number of transaction inputs that are allowed\n10. autolock       (string, optional)             If specified, all txouts spent for this transaction will be locked under this name\n11. nosign         (boolean, optional)            If specified, create an *unsigned* transaction\n\nResult:\n\"value\" (string) The hex encoded transaction result\n",
-		"getaddressbalances":      "getaddressbalances (minconf=1 showzerobalance)\n\nGet balances for each address\n\nArguments:\n1. minconf         (numeric, optional, default=1) Minimum number of confirmations for coins to be considered received\n2. showzerobalance (boolean, optional)            If true then addresses which have been created but carry zero balance will be included\n\nResult:\n[{\n \"address\": \"value\",         (string)  The address which has this balance\n \"total\": n.nnn,             (numeric) Total balance\n \"stotal\": \"value\",          (string)  Total balance (atomic units as base 10 string)\n \"spendable\": n.nnn,         (numeric) Balance which is currently spendable\n \"sspendable\": \"value\",      (string)  Balance which is currently spendable (atomic units as base 10 string)\n \"immaturereward\": n.nnn,    (numeric) Mined coins which have not yet matured\n \"simmaturereward\": \"value\", (string)  Mined coins which have not yet matured (atomic units as base 10 string)\n \"unconfirmed\": n.nnn,       (numeric) Unconfirmed balance\n \"sunconfirmed\": \"value\",    (string)  Unconfirmed balance (atomic units as base 10 string)\n \"outputcount\": n,           (numeric) The number of transaction outputs which make up the balance\n},...]\n",
-		"setnetworkstewardvote":   "setnetworkstewardvote (\"votefor\" \"voteagainst\")\n\nConfigure the wallet to vote for a network steward when making payments (note: payments to segwit addresses cannot vote)\n\nArguments:\n1. votefor     (string, optional) The address to vote for (in the event of an election, this is the address who should win)\n2. voteagainst (string, optional) The address to vote against (if this is the current NS then this will cause a vote for an election)\n\nResult:\n{\n} \n",
-		"getnetworkstewardvote":   "getnetworkstewardvote\n\nFind out how the wallet is currently configured to vote in a network steward election\n\nArguments:\nNone\n\nResult:\n{\n \"votefor\": \"value\",     (string) The address which your wallet is currently voting for\n \"voteagainst\": \"value\", (string) The address which your wallet is currently voting against\n}                        \n",
-		"resync":                  "resync (fromheight toheight [\"address\",...] dropdb)\n\nRe-synchronize the wallet to the chain, scan from the first block to find any missing coins\n\nArguments:\n1. fromheight (numeric, optional)         Start re-syncing to the chain from specified height, default or -1 will use the height of the chain when the wallet was created\n2. toheight   (numeric, optional)         Stop resyncing when this height is reached, default or -1 will use the tip of the chain\n3. addresses  (array of string, optional) If specified, the wallet will ONLY scan the chain for these addresses, not others. If dropdb is specified then it will scan all addresses including these\n4. dropdb     (boolean, optional)         Clean most of the data out of the wallet transaction store, this is not a real resync, it just drops the wallet and then lets it begin working again\n\nResult:\nNothing\n",
-		"stopresync":              "stopresync\n\nStop a re-synchronization job before it's completion\n\nArguments:\nNone\n\nResult:\n\"value\" (string) The name of the sync job which was stopped\n",
-		"addp2shscript":           "addp2shscript \"script\" segwit\n\nImport a p2sh script in order to be able to watch a multisig wallet\n\nArguments:\n1. script (string, required)  The redeem script to import\n2. segwit (boolean, required) If true then this will create a segwit address\n\nResult:\n\"value\" (string) The address corrisponding to this script\n",
-		"dumpprivkey":             "dumpprivkey \"address\"\n\nReturns the private key in WIF encoding that controls some wallet address.\n\nArguments:\n1. address (string, required) The address to return a private key for\n\nResult:\n\"value\" (string) The WIF-encoded private key\n",
-		"getbalance":              "getbalance (minconf=1)\n\nCalculates and returns the balance of one or all accounts.\n\nArguments:\n1. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an unspent output's value is included in the balance\n\nResult (account != \"*\"):\nn.nnn (numeric) The balance of 'account' valued in bitcoin\n\nResult (account = \"*\"):\nn.nnn (numeric) The balance of all accounts valued in bitcoin\n",
-		"getbestblockhash":        "getbestblockhash\n\nReturns the hash of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\n\"value\" (string) The hash of the most recent synced-to block\n",
-		"getblockcount":           "getblockcount\n\nReturns the blockchain height of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\nn.nnn (numeric) The blockchain height of the most recent synced-to block\n",
-		"getinfo":                 "getinfo\n\nReturns a JSON object containing various state info.\n\nArguments:\nNone\n\nResult:\n{\n \"version\": n,          (numeric) The version of the server\n \"protocolversion\": n,  (numeric) The latest supported protocol version\n \"walletversion\": n,    (numeric) The version of the address manager database\n \"balance\": n.nnn,      (numeric) The balance of all accounts calculated with one block confirmation\n \"blocks\": n,           (numeric) The number of blocks processed\n \"timeoffset\": n,       (numeric) The time offset\n \"connections\": n,      (numeric) The number of connected peers\n \"difficulty\": n.nnn,   (numeric) The current target difficulty\n \"testnet\": true|false, (boolean) Whether or not server is using testnet\n \"keypoololdest\": n,    (numeric) Unset\n \"keypoolsize\": n,      (numeric) Unset\n \"unlocked_until\": n,   (numeric) Unset\n \"paytxfee\": n.nnn,     (numeric) The increment used each time more fee is required for an authored transaction\n \"relayfee\": n.nnn,     (numeric) The minimum relay fee for non-free transactions in BTC/KB\n \"errors\": \"value\",     (string)  Any current errors\n}                       \n",
-		"getnewaddress":           "getnewaddress (legacy)\n\nGenerates and returns a new payment address.\n\nArguments:\n1. legacy (boolean, optional) If true then this will create a legacy form address rather than a new segwit address\n\nResult:\n\"value\" (string) The payment address\n",
-		"getreceivedbyaddress":    "getreceivedbyaddress \"address\" (minconf=1)\n\nReturns the total amount received by a single address, including spent outputs.\n\nArguments:\n1. address (string, required)             Payment address which received outputs to include in total\n2. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an output's value is included in the total\n\nResult:\nn.nnn (numeric) The total received amount valued in bitcoin\n",
-		"gettransaction":          "gettransaction \"txid\" (includewatchonly=false)\n\nReturns a JSON object with details regarding a transaction relevant to this wallet.\n\nArguments:\n1. txid             (string, required)                 Hash of the transaction to query\n2. includewatchonly (boolean, optional, default=false) Also consider transactions involving watched addresses\n\nResult:\n{\n \"amount\": n.nnn,                  (numeric)         The total amount this transaction credits to the wallet, valued in bitcoin\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value, or 0 if 'txid' is not a sent transaction\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"txid\": \"value\",                  (string)          The transaction hash\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"details\": [{                     (array of object) Additional details for each recorded wallet credit and debit\n  \"account\": \"value\",              (string)          DEPRECATED -- Unset\n  \"address\": \"value\",              (string)          The address an output was paid to, or the empty string if the output is nonstandard or this detail is regarding a transaction input\n  \"amount\": n.nnn,                 (numeric)         The amount of a received output\n  \"category\": \"value\",             (string)          The kind of detail: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs\n  \"involveswatchonly\": true|false, (boolean)         Unset\n  \"fee\": n.nnn,                    (numeric)         The included fee for a sent transaction\n  \"vout\": n,                       (numeric)         The transaction output index\n },...],                                             \n \"hex\": \"value\",                   (string)          The transaction encoded as a hexadecimal string\n}                                  \n",
-		"getwalletseed":           "getwalletseed\n\nGet the wallet seed words for this wallet\n\nArguments:\nNone\n\nResult:\n\"value\" (string) The seed words used, along with the wallet passphrase, to create the wallet\n",
-		"getsecret":               "getsecret \"name\"\n\nGet a secret seed which is generated using the wallet's private key, this can be used as a password for another application\n\nArguments:\n1. name (string, required) A name which will be used to generate the secret seed, the same seed will always be provided given the same name\n\nResult:\n\"value\" (string) A 32 byte secret seed in hex form\n",
-		"help":                    "help (\"command\")\n\nReturns a list of all commands or help for a specified command.\n\nArguments:\n1. command (string, optional) The command to retrieve help for\n\nResult (no command provided):\n\"value\" (string) List of commands\n\nResult (command specified):\n\"value\" (string) Help for specified command\n",
-		"importprivkey":           "importprivkey \"privkey\" (\"label\" rescan=true legacy=false)\n\nImports a WIF-encoded private key to the 'imported' account.\n\nArguments:\n1. privkey (string, required)                 The WIF-encoded private key\n2. label   (string, optional)                 Unused (must be unset or 'imported')\n3. rescan  (boolean, optional, default=true)  Rescan the blockchain (since the genesis block) for outputs controlled by the imported key\n4. legacy  (boolean, optional, default=false) If true then import as a legacy address, otherwise segwit\n\nResult:\nNothing\n",
-		"listlockunspent":         "listlockunspent\n\nReturns a JSON array of outpoints marked as locked (with lockunspent) for this wallet session.\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n",
-		"listreceivedbyaddress":   "listreceivedbyaddress (minconf=1 includeempty=false includewatchonly=false)\n\nReturns a JSON array of objects listing wallet payment addresses and their total received amounts.\n\nArguments:\n1. minconf          (numeric, optional, default=1)     Minimum number of block confirmations required before a transaction is considered\n2. includeempty     (boolean, optional, default=false) Unused\n3. includewatchonly (boolean, optional, default=false) Unused\n\nResult:\n[{\n \"account\": \"value\",              (string)          DEPRECATED -- Unset\n \"address\": \"value\",              (string)          The payment address\n \"amount\": n.nnn,                 (numeric)         Total amount received by the payment address valued in bitcoin\n \"confirmations\": n,              (numeric)         Number of block confirmations of the most recent transaction relevant to the address\n \"txids\": [\"value\",...],          (array of string) Transaction hashes of all transactions involving this address\n \"involvesWatchonly\": true|false, (boolean)         Unset\n},...]\n",
-		"listsinceblock":          "listsinceblock (\"blockhash\" targetconfirmations=1 includewatchonly=false)\n\nReturns a JSON array of objects listing details of all wallet transactions after some block.\n\nArguments:\n1. blockhash           (string, optional)                 Hash of the parent block of the first block to consider transactions from, or unset to list all transactions\n2. targetconfirmations (numeric, optional, default=1)     Minimum number of block confirmations of the last block in the result object.  Must be 1 or greater.  Note: The transactions array in the result object is not affected by this parameter\n3. includewatchonly    (boolean, optional, default=false) Unused\n\nResult:\n{\n \"transactions\": [{                 (array of object) JSON array of objects containing verbose details of the each transaction\n  \"abandoned\": true|false,          (boolean)         Unset\n  \"account\": \"value\",               (string)          DEPRECATED -- Unset\n  \"address\": \"value\",               (string)          Payment address for a transaction output\n  \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n  \"bip125-replaceable\": \"value\",    (string)          Unset\n  \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n  \"blockindex\": n,                  (numeric)         Unset\n  \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n  \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n  \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n  \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n  \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n  \"involveswatchonly\": true|false,  (boolean)         Unset\n  \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n  \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n  \"trusted\": true|false,            (boolean)         Unset\n  \"txid\": \"value\",                  (string)          The hash of the transaction\n  \"vout\": n,                        (numeric)         The transaction output index\n  \"walletconflicts\": [\"value\",...], (array of string) Unset\n  \"comment\": \"value\",               (string)          Unset\n  \"otheraccount\": \"value\",          (string)          Unset\n },...],                                              \n \"lastblock\": \"value\",              (string)          Hash of the latest-synced block to be used in later calls to listsinceblock\n}                                   \n",
-		"listtransactions":        "listtransactions (count=10 from=0)\n\nReturns a JSON array of objects containing verbose details for wallet transactions.\n\nArguments:\n1. count (numeric, optional, default=10) Maximum number of transactions to create results from\n2. from  (numeric, optional, default=0)  Number of transactions to skip before results are created\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          Unset\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
-		"listunspent":             "listunspent (minconf=1 maxconf=9999999 [\"address\",...])\n\nReturns a JSON array of objects representing unlocked unspent outputs controlled by wallet keys.\n\nArguments:\n1. minconf   (numeric, optional, default=1)       Minimum number of block confirmations required before a transaction output is considered\n2. maxconf   (numeric, optional, default=9999999) Maximum number of block confirmations required before a transaction output is excluded\n3. addresses (array of string, optional)          If set, limits the returned details to unspent outputs received by any of these payment addresses\n\nResult:\n{\n \"txid\": \"value\",         (string)  The transaction hash of the referenced output\n \"vout\": n,               (numeric) The output index of the referenced output\n \"address\": \"value\",      (string)  The payment address that received the output\n \"account\": \"value\",      (string)  The account associated with the receiving payment address\n \"scriptPubKey\": \"value\", (string)  The output script encoded as a hexadecimal string\n \"redeemScript\": \"value\", (string)  Unset\n \"amount\": n.nnn,         (numeric) The amount of the output valued in bitcoin\n \"confirmations\": n,      (numeric) The number of block confirmations of the transaction\n \"height\": n,             (numeric) The height of the block which the transaction was included in\n \"blockHash\": \"value\",    (string)  The hash of the block which the transaction was included in\n \"spendable\": true|false, (boolean) Whether the output is entirely controlled by wallet keys/scripts (false for partially controlled multisig outputs or outputs to watch-only addresses)\n}                         \n",
-		"lockunspent":             "lockunspent unlock [{\"txid\":\"value\",\"vout\":n},...] (\"lockname\")\n\nLocks or unlocks an unspent output.\nLocked outputs are not chosen for transaction inputs of authored transactions and are not included in 'listunspent' results.\nLocked outputs are volatile and are not saved across wallet restarts.\nIf unlock is true and no transaction outputs are specified, all locked outputs are marked unlocked.\n\nArguments:\n1. unlock       (boolean, required)         True to unlock outputs, false to lock\n2. transactions (array of object, required) Transaction outputs to lock or unlock\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n3. lockname (string, optional) Name of the lock to apply, allows groups of locks to be cleared at once\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
-		"sendfrom":                "sendfrom \"toaddress\" amount ([\"fromaddress\",...] minconf=1 \"comment\" \"commentto\" maxinputs minheight)\n\nDEPRECATED -- Authors, signs, and sends a transaction that outputs some amount to a payment address.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. toaddress     (string, required)             Address to pay\n2. amount        (numeric, required)            Amount to send to the payment address valued in bitcoin\n3. fromaddresses (array of string, optional)    Addresses to use for selecting coins to spend\n4. minconf       (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n5. comment       (string, optional)             Unused\n6. commentto     (string, optional)             Unused\n7. maxinputs     (numeric, optional)            Maximum number of transaction inputs that are allowed\n8. minheight     (numeric, optional)            Only select transactions from this height or above\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction\n",
-		"sendmany":                "sendmany {\"address\":amount,...} ([\"fromaddress\",...] minconf=1 \"comment\" maxinputs)\n\nAuthors, signs, and sends a transaction that outputs to many payment addresses.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. amounts (object, required) Pairs of payment addresses and the output amount to pay each\n{\n \"Address to pay\": Amount to send to the payment address valued in bitcoin, (object) JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address\n ...\n}\n2. fromaddresses (array of string, optional)    Addresses to use for selecting coins to spend\n3. minconf       (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n4. comment       (string, optional)             Unused\n5. maxinputs     (numeric, optional)            Maximum number of transaction inputs that are allowed\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction\n",
-		"sendtoaddress":           "sendtoaddress \"address\" amount (\"comment\" \"commentto\")\n\nAuthors, signs, and sends a transaction that outputs some amount to a payment address.\nUnlike sendfrom, outputs are always chosen from the default account.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. address   (string, required)  Address to pay\n2. amount    (numeric, required) Amount to send to the payment address valued in bitcoin\n3. comment   (string, optional)  Unused\n4. commentto (string, optional)  Unused\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction\n",
-		"settxfee":                "settxfee amount\n\nModify the increment used each time more fee is required for an authored transaction.\n\nArguments:\n1. amount (numeric, required) The new fee increment valued in bitcoin\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
-		"signmessage":             "signmessage \"address\" \"message\"\n\nSigns a message using the private key of a payment address.\n\nArguments:\n1. address (string, required) Payment address of private key used to sign the message with\n2. message (string, required) Message to sign\n\nResult:\n\"value\" (string) The signed message encoded as a base64 string\n",
-		"signrawtransaction":      "signrawtransaction \"rawtx\" ([{\"txid\":\"value\",\"vout\":n,\"scriptpubkey\":\"value\",\"redeemscript\":\"value\"},...] [\"privkey\",...] flags=\"ALL\")\n\nSigns transaction inputs using private keys from this wallet and request.\nThe valid flags options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.\n\nArguments:\n1. rawtx    (string, required)                Unsigned or partially unsigned transaction to sign encoded as a hexadecimal string\n2. inputs   (array of object, optional)       Additional data regarding inputs that this wallet may not be tracking\n3. privkeys (array of string, optional)       Additional WIF-encoded private keys to use when creating signatures\n4. flags    (string, optional, default=\"ALL\") Sighash flags\n\nResult:\n{\n \"hex\": \"value\",         (string)          The resulting transaction encoded as a hexadecimal string\n \"complete\": true|false, (boolean)         Whether all input signatures have been created\n \"errors\": [{            (array of object) Script verification errors (if exists)\n  \"txid\": \"value\",       (string)          The transaction hash of the referenced previous output\n  \"vout\": n,             (numeric)         The output index of the referenced previous output\n  \"scriptSig\": \"value\",  (string)          The hex-encoded signature script\n  \"sequence\": n,         (numeric)         Script sequence number\n  \"error\": \"value\",      (string)          Verification or signing error related to the input\n },...],                                   \n}                        \n",
-		"validateaddress":         "validateaddress \"address\"\n\nVerify that an address is valid.\nExtra details are returned if the address is controlled by this wallet.\nThe following fields are valid only when the address is controlled by this wallet (ismine=true): isscript, pubkey, iscompressed, account, addresses, hex, script, and sigsrequired.\nThe following fields are only valid when address has an associated public key: pubkey, iscompressed.\nThe following fields are only valid when address is a pay-to-script-hash address: addresses, hex, and script.\nIf the address is a multisig address controlled by this wallet, the multisig fields will be left unset if the wallet is locked since the redeem script cannot be decrypted.\n\nArguments:\n1. address (string, required) Address to validate\n\nResult:\n{\n \"isvalid\": true|false,      (boolean)         Whether or not the address is valid\n \"address\": \"value\",         (string)          The payment address (only when isvalid is true)\n \"ismine\": true|false,       (boolean)         Whether this address is controlled by the wallet (only when isvalid is true)\n \"iswatchonly\": true|false,  (boolean)         Unset\n \"isscript\": true|false,     (boolean)         Whether the payment address is a pay-to-script-hash address (only when isvalid is true)\n \"pubkey\": \"value\",          (string)          The associated public key of the payment address, if any (only when isvalid is true)\n \"iscompressed\": true|false, (boolean)         Whether the address was created by hashing a compressed public key, if any (only when isvalid is true)\n \"account\": \"value\",         (string)          The account this payment address belongs to (only when isvalid is true)\n \"addresses\": [\"value\",...], (array of string) All associated payment addresses of the script if address is a multisig address (only when isvalid is true)\n \"hex\": \"value\",             (string)          The redeem script \n \"script\": \"value\",          (string)          The class of redeem script for a multisig address\n \"sigsrequired\": n,          (numeric)         The number of required signatures to redeem outputs to the multisig address\n}                            \n",
-		"verifymessage":           "verifymessage \"address\" \"signature\" \"message\"\n\nVerify a message was signed with the associated private key of some address.\n\nArguments:\n1. address   (string, required) Address used to sign message\n2. signature (string, required) The signature to verify\n3. message   (string, required) The message to verify\n\nResult:\ntrue|false (boolean) Whether the message was signed with the private key of 'address'\n",
-		"walletlock":              "walletlock\n\nLock the wallet.\n\nArguments:\nNone\n\nResult:\nNothing\n",
-		"walletpassphrase":        "walletpassphrase \"passphrase\" timeout\n\nUnlock the wallet.\n\nArguments:\n1. passphrase (string, required)  The wallet passphrase\n2. timeout    (numeric, required) The number of seconds to wait before the wallet automatically locks\n\nResult:\nNothing\n",
-		"walletpassphrasechange":  "walletpassphrasechange \"oldpassphrase\" \"newpassphrase\"\n\nChange the wallet passphrase.\n\nArguments:\n1. oldpassphrase (string, required) The old wallet passphrase\n2. newpassphrase (string, required) The new wallet passphrase\n\nResult:\nNothing\n",
-		"walletmempool":           "walletmempool\n\nShow the unconfirmed transactions which are being broadcasted by the wallet\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\",     (string) Transaction id\n \"received\": \"value\", (string) The time when the transaction was first seen/made\n},...]\n",
-		"exportwatchingwallet":    "exportwatchingwallet (\"account\" download=false)\n\nCreates and returns a duplicate of the wallet database without any private keys to be used as a watching-only wallet.\n\nArguments:\n1. account  (string, optional)                 Unused (must be unset or \"*\")\n2. download (boolean, optional, default=false) Unused\n\nResult:\n\"value\" (string) The watching-only database encoded as a base64 string\n",
-		"getbestblock":            "getbestblock\n\nReturns the hash and height of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\n{\n \"hash\": \"value\", (string)  The hash of the block\n \"height\": n,     (numeric) The blockchain height of the block\n}                 \n",
-		"getunconfirmedbalance":   "getunconfirmedbalance (\"account\")\n\nCalculates the unspent output value of all unmined transaction outputs for an account.\n\nArguments:\n1. account (string, optional) The account to query the unconfirmed balance for (default=\"default\")\n\nResult:\nn.nnn (numeric) Total amount of all unmined unspent outputs of the account valued in bitcoin.\n",
-		"listaddresstransactions": "listaddresstransactions [\"address\",...] (\"account\")\n\nReturns a JSON array of objects containing verbose details for wallet transactions pertaining some addresses.\n\nArguments:\n1. addresses (array of string, required) Addresses to filter transaction results by\n2. account   (string, optional)          Unused (must be unset or \"*\")\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          Unset\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
-		"listalltransactions":     "listalltransactions (\"account\")\n\nReturns a JSON array of objects in the same format as 'listtransactions' without limiting the number of returned objects.\n\nArguments:\n1. account (string, optional) Unused (must be unset or \"*\")\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          Unset\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
-		"walletislocked":          "walletislocked\n\nReturns whether or not the wallet is locked.\n\nArguments:\nNone\n\nResult:\ntrue|false (boolean) Whether the wallet is locked\n",
+		"addmultisigaddress":       "addmultisigaddress nrequired [\"key\",...]\n\nGenerates and imports a multisig address and redeeming script to the 'imported' account.\n\nArguments:\n1. nrequired (numeric, required)         The number of signatures required to redeem outputs paid to this address\n2. keys      (array of string, required) Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address\n\nResult:\n\"value\" (string) The imported pay-to-script-hash address\n",
+		"createmultisig":           "createmultisig nrequired [\"key\",...]\n\nGenerate a multisig address and redeem script.\n\nArguments:\n1. nrequired (numeric, required)         The number of signatures required to redeem outputs paid to this address\n2. keys      (array of string, required) Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address\n\nResult:\n{\n \"address\": \"value\",      (string) The generated pay-to-script-hash address\n \"redeemScript\": \"value\", (string) The script required to redeem outputs paid to the multisig address\n}                         \n",
+		"createtransaction":        "createtransaction \"toaddress\" amount ([\"fromaddress\",...] electrumformat \"changeaddress\" inputminheight minconf=1 vote maxinputs \"autolock\" nosign allowselfsend)\n\nCreate a transaction but do not send it to the chain\n\nArguments:\n1.  toaddress      (string, required)             The recipient to send the coins to\n2.  amount         (numeric, required)            The amount of coins to send\n3.  fromaddresses  (array of string, optional)    Addresses to use for selecting coins to spend\n4.  electrumformat (boolean, optional)            If true, then the transaction result will be output in electrum incomplete transaction format, useful for signing later\n5.  changeaddress  (string, optional)             Return extra coins to this address, if unspecified then one will be created\n6.  inputminheight (numeric, optional)            The minimum block height to take inputs from (default: 0)\n7.  minconf        (numeric, optional, default=1) Do not spend any outputs which don't have at least this number of confirmations (default 1)\n8.  vote           (boolean, optional)            True if you wish for this transaction to contain a network steward vote\n9.  maxinputs      (numeric, optional)            Maximum number of transaction inputs that are allowed\n10. autolock       (string, optional)             If specified, all txouts spent for this transaction will be locked under this name\n11. nosign         (boolean, optional)            If specified, create an *unsigned* transaction\n12. allowselfsend  (boolean, optional)            Allow outputs paying addresses of this wallet when warnselfsend is set\n\nResult:\n\"value\" (string) The hex encoded transaction result\n",
+		"getaddressbalances":       "getaddressbalances (minconf=1 showzerobalance)\n\nGet balances for each address\n\nArguments:\n1. minconf         (numeric, optional, default=1) Minimum number of confirmations for coins to be considered received\n2. showzerobalance (boolean, optional)            If true then addresses which have been created but carry zero balance will be included\n\nResult:\n[{\n \"address\": \"value\",         (string)  The address which has this balance\n \"total\": n.nnn,             (numeric) Total balance\n \"stotal\": \"value\",          (string)  Total balance (atomic units as base 10 string)\n \"spendable\": n.nnn,         (numeric) Balance which is currently spendable\n \"sspendable\": \"value\",      (string)  Balance which is currently spendable (atomic units as base 10 string)\n \"immaturereward\": n.nnn,    (numeric) Mined coins which have not yet matured\n \"simmaturereward\": \"value\", (string)  Mined coins which have not yet matured (atomic units as base 10 string)\n \"unconfirmed\": n.nnn,       (numeric) Unconfirmed balance\n \"sunconfirmed\": \"value\",    (string)  Unconfirmed balance (atomic units as base 10 string)\n \"maturing\": n.nnn,          (numeric) Balance which has enough confirmations to be spendable but fewer than finalitydepth, so is counted apart as it may yet be undone by a reorg\n \"smaturing\": \"value\",       (string)  Balance which has enough confirmations to be spendable but fewer than finalitydepth (atomic units as base 10 string)\n \"watchonly\": n.nnn,         (numeric) Balance of a watch only taproot address, which cannot yet be spent\n \"swatchonly\": \"value\",      (string)  Balance of a watch only taproot address (atomic units as base 10 string)\n \"dust\": n.nnn,              (numeric) Dust sent by others which is quarantined by ignoreincomingdust, so is not spendable\n \"sdust\": \"value\",           (string)  Dust sent by others which is quarantined by ignoreincomingdust (atomic units as base 10 string)\n \"outputcount\": n,           (numeric) The number of transaction outputs which make up the balance\n},...]\n",
+		"getaccountxpubs":          "getaccountxpubs (account=0 slip132=false)\n\nGet the extended public keys of an account for each of the wallet's key scopes\n\nArguments:\n1. account (numeric, optional, default=0)     The account number\n2. slip132 (boolean, optional, default=false) If true then encode each key with the SLIP-0132 version bytes for its script type (e.g. ypub/zpub) rather than the network's standard extended public key version, scopes whose script type has no SLIP-0132 version on the network, such as the segwit scopes of PKT, are left out\n\nResult:\n[{\n \"scope\": \"value\",       (string) The key scope which the key belongs to, as a derivation path m/purpose'/cointype'\n \"addresstype\": \"value\", (string) The script type of addresses derived from the key (p2pkh, p2sh-p2wpkh or p2wpkh)\n \"xpub\": \"value\",        (string) The account extended public key\n},...]\n",
+		"listaccounts":             "listaccounts (minconf=1)\n\nList every account of each of the wallet's key scopes, including the imported account, with its balance and the number of addresses issued\n\nArguments:\n1. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an output is counted in the balance\n\nResult:\n[{\n \"name\": \"value\",        (string)  The name of the account\n \"account\": n,           (numeric) The account number\n \"scope\": \"value\",       (string)  The key scope which the account belongs to, as a derivation path m/purpose'/cointype'\n \"addresstype\": \"value\", (string)  The script type of the account's addresses (p2pkh, p2sh-p2wpkh or p2wpkh)\n \"balance\": n.nnn,       (numeric) The balance of the account in coins, leaving out dust quarantined by ignoreincomingdust\n \"addresscount\": n,      (numeric) The number of addresses issued by the account, including change addresses, or imported into it\n},...]\n",
+		"gettxproof":               "gettxproof \"txid\"\n\nGet the merkle proof that a mined wallet transaction is included in its block, the block is fetched from the chain backend\n\nArguments:\n1. txid (string, required) The hash of the transaction\n\nResult:\n{\n \"txid\": \"value\",         (string)          The hash of the transaction\n \"blockhash\": \"value\",    (string)          The hash of the block containing the transaction\n \"blockheight\": n,        (numeric)         The height of the block containing the transaction\n \"index\": n,              (numeric)         The position of the transaction in the block\n \"branch\": [\"value\",...], (array of string) The merkle branch from the transaction up to the merkle root, an empty string means the node is hashed with itself\n}                         \n",
+		"gettxstatus":              "gettxstatus \"txid\"\n\nGet whether a transaction is unknown to the wallet, unconfirmed, confirmed or conflicted, that is removed because a mined transaction spends one of the same outputs\n\nArguments:\n1. txid (string, required) The hash of the transaction\n\nResult:\n{\n \"status\": \"value\",       (string)  The status of the transaction: unknown, unconfirmed, confirmed or conflicted\n \"confirmations\": n,      (numeric) The number of confirmations of a confirmed transaction, 0 otherwise\n \"blockheight\": n,        (numeric) The height of the block containing a confirmed transaction, -1 otherwise\n \"conflictedby\": \"value\", (string)  The hash of the mined transaction which conflicts with a conflicted transaction\n}                         \n",
+		"getmempoolancestors":      "getmempoolancestors \"txid\"\n\nList the unconfirmed wallet transactions which an unconfirmed transaction spends the outputs of, directly or through other unconfirmed transactions, with their total size and fee, as a child paying for them must pay for all of them to be mined\n\nArguments:\n1. txid (string, required) The hash of the unconfirmed transaction\n\nResult:\n{\n \"ancestors\": [{   (array of object) The ancestors, each listed after those which it depends on\n  \"txid\": \"value\", (string)          The hash of the ancestor\n  \"size\": n,       (numeric)         The size of the ancestor in bytes\n  \"vsize\": n,      (numeric)         The virtual size of the ancestor\n  \"fee\": n.nnn,    (numeric)         The fee paid by the ancestor, omitted if any of its inputs do not belong to the wallet\n },...],                             \n \"size\": n,        (numeric)         The total size of the ancestors in bytes\n \"vsize\": n,       (numeric)         The total virtual size of the ancestors, which a child paying for them pays its fee rate on\n \"fee\": n.nnn,     (numeric)         The total fee paid by the ancestors, omitted if the fee of any of them is not known\n}                  \n",
+		"verifytxproof":            "verifytxproof \"txid\" \"blockhash\" index [\"branch\",...]\n\nVerify a merkle proof for a transaction against the merkle root of the block header\n\nArguments:\n1. txid      (string, required)          The hash of the transaction\n2. blockhash (string, required)          The hash of the block which the transaction is claimed to be in\n3. index     (numeric, required)         The position of the transaction in the block\n4. branch    (array of string, required) The merkle branch from the transaction up to the merkle root, an empty string means the node is hashed with itself\n\nResult:\ntrue|false (boolean) Whether the proof is valid for the block\n",
+		"estimateconfirmationtime": "estimateconfirmationtime \"txid\"\n\nEstimate how many blocks and seconds an unconfirmed wallet transaction will take to confirm based on its fee rate, estimates without fee estimation data from pktd are conservative and flagged as low confidence\n\nArguments:\n1. txid (string, required) The hash of the transaction\n\nResult:\n{\n \"feerate\": n.nnn,            (numeric) The fee rate of the transaction in coins per kilobyte of virtual size\n \"blocks\": n,                 (numeric) The estimated number of blocks until the transaction confirms, zero if it is already mined\n \"seconds\": n,                (numeric) The estimated number of seconds until the transaction confirms\n \"lowconfidence\": true|false, (boolean) Whether the estimate is not based on enough fee estimation data to be reliable\n}                             \n",
+		"estimateconsolidation":    "estimateconsolidation (\"feerate\")\n\nEstimate how many transactions and how much fee it would take to consolidate all of the wallet's spendable outputs into a single output. When there are more outputs than fit in one transaction the outputs of the first transactions are consolidated again\n\nArguments:\n1. feerate (string, optional) The fee rate, either in coins per kilobyte or with a unit such as 10bit/vB, default is the relay fee\n\nResult:\n{\n \"utxos\": n,              (numeric) The number of outputs which would be consolidated\n \"transactions\": n,       (numeric) The number of transactions needed\n \"feerate\": n.nnn,        (numeric) The fee rate used in coins per kilobyte, which is limited by maxfeerate\n \"fee\": n.nnn,            (numeric) The total fee of all of the transactions in coins\n \"amount\": n.nnn,         (numeric) The total value of the outputs which would be consolidated in coins\n \"amountafterfee\": n.nnn, (numeric) The value of the single output which would be left after paying the fee\n}                         \n",
+		"verifywallet":             "verifywallet\n\nWalk the wallet database checking that its records are consistent with one another, e.g. that every unspent output references a known transaction that credit amounts match the transaction outputs and that the balances reconcile\n\nArguments:\nNone\n\nResult:\n{\n \"consistent\": true|false,  (boolean)         Whether no inconsistencies were found\n \"problems\": [\"value\",...], (array of string) A description of each inconsistency found\n}                           \n",
+		"getbalanceatheight":       "getbalanceatheight height\n\nCalculate the confirmed balance of the wallet as of a past block by replaying the transactions mined at or before it, heights beyond the wallet's best block give the current confirmed balance\n\nArguments:\n1. height (numeric, required) The height of the block to calculate the balance at\n\nResult:\n{\n \"height\": n,      (numeric) The height which the balance was calculated at, this is the wallet's best block if the requested height is beyond it\n \"balance\": n.nnn, (numeric) The confirmed balance in coins as of the block\n}                  \n",
+		"verifypaymentrequest":     "verifypaymentrequest \"paymentrequest\"\n\nParse a BIP0070 payment request and verify its X.509 signature against the system's root certificates, returning the payment details\n\nArguments:\n1. paymentrequest (string, required) The hex encoded serialized payment request\n\nResult:\n{\n \"valid\": true|false,   (boolean)         Whether the request is signed by a trusted certificate chain, the signature matches and the request has not expired\n \"expired\": true|false, (boolean)         Whether the request has passed its expiry time, this is reported separately from the signature\n \"error\": \"value\",      (string)          Why the signature or certificate chain is not valid, if it is not\n \"merchant\": \"value\",   (string)          The common name of the certificate which signed the request\n \"network\": \"value\",    (string)          The network which the request is for\n \"outputs\": [{          (array of object) The outputs which are requested to be paid\n  \"amount\": n.nnn,      (numeric)         The requested amount in coins\n  \"script\": \"value\",    (string)          The hex encoded output script to pay\n  \"address\": \"value\",   (string)          The address of the output script, if it is a standard script\n },...],                                  \n \"memo\": \"value\",       (string)          The merchant's memo\n \"paymenturl\": \"value\", (string)          Where the payment should be sent\n \"time\": n,             (numeric)         When the request was created, in seconds since the unix epoch\n \"expires\": n,          (numeric)         When the request expires, in seconds since the unix epoch, zero if it does not\n}                       \n",
+		"createnewaccount":         "createnewaccount \"account\" (\"addresstype\")\n\nCreate a new account, in each key scope, with a default type for the addresses which getnewaddress creates for it\n\nArguments:\n1. account     (string, required) The name of the new account\n2. addresstype (string, optional) The default address type of the account, one of p2pkh (or legacy), p2sh-p2wpkh or p2wpkh (or segwit), if unset then p2wpkh\n\nResult:\nn.nnn (numeric) The number of the new account\n",
+		"getstoragestats":          "getstoragestats\n\nGet the size of the wallet database, the size of each of its buckets and the number of transactions and unspent outputs which it holds\n\nArguments:\nNone\n\nResult:\n{\n \"filesize\": n,     (numeric)         The size of the wallet database file in bytes\n \"buckets\": [{      (array of object) The storage used by each top level bucket and the buckets nested directly in them, bucket names which are not printable are hex encoded\n  \"name\": \"value\",  (string)          The path of the bucket, with names separated by /\n  \"keys\": n,        (numeric)         The number of keys in the bucket and the buckets nested in it\n  \"size\": n,        (numeric)         The number of bytes in use by the bucket and the buckets nested in it\n },...],                              \n \"transactions\": n, (numeric)         The number of transactions, mined and unmined, which the wallet has recorded\n \"utxos\": n,        (numeric)         The number of unspent outputs belonging to the wallet\n}                   \n",
+		"getrecoverystatus":        "getrecoverystatus\n\nGet the progress of the wallet scanning the chain for its transactions, either catching up to the tip after being restored from seed or a resync, or of the last such recovery if none is running\n\nArguments:\nNone\n\nResult:\n{\n \"active\": true|false,     (boolean) Whether the recovery is running\n \"name\": \"value\",          (string)  The name of the recovery, sync when catching up to the tip or else the name of the resync job\n \"startheight\": n,         (numeric) The height from which the recovery started scanning\n \"currentheight\": n,       (numeric) The height of the last block scanned\n \"targetheight\": n,        (numeric) The height at which the recovery completes\n \"progress\": n.nnn,        (numeric) The fraction of the blocks of the recovery which have been scanned, between 0 and 1\n \"addressesfound\": n,      (numeric) The number of addresses of the wallet which were paid in the blocks scanned so far\n \"starttime\": n,           (numeric) When the recovery started, in seconds since the unix epoch, zero if the wallet has not recovered since it was opened\n \"estimatedcompletion\": n, (numeric) When the recovery is estimated to complete, in seconds since the unix epoch, from the rate at which blocks have been scanned so far, zero if it is not running or not yet known\n}                          \n",
+		"listrejectedtx":           "listrejectedtx\n\nList the transactions which were most recently rejected when they were broadcast, most recent first, only the last 100 rejections are kept and they are forgotten on restart\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\",   (string)  The hash of the rejected transaction\n \"reason\": \"value\", (string)  Why the transaction was rejected, from the peer's reject message or the error returned by pktd\n \"time\": n,         (numeric) When the transaction was rejected, in seconds since the unix epoch\n},...]\n",
+		"deriveaddresses":          "deriveaddresses \"seed\" count (addresstype=\"p2wpkh\" account=0)\n\nDerive the first external addresses of an account from a seed, in the same way as a wallet created from the seed, so that the derivation can be cross-checked with other implementations. The wallet itself is not used or changed\n\nArguments:\n1. seed        (string, required)                   The hex encoded BIP0032 seed\n2. count       (numeric, required)                  The number of addresses to derive, at most 10000\n3. addresstype (string, optional, default=\"p2wpkh\") The type of the addresses, which selects the key scope: p2pkh (or legacy) for BIP0044, p2sh-p2wpkh for BIP0049, p2wpkh (or segwit) for BIP0084 or p2tr (or taproot) for BIP0086\n4. account     (numeric, optional, default=0)       The account number to derive addresses of\n\nResult:\n[{\n \"path\": \"value\",    (string) The derivation path of the address, m/purpose'/cointype'/account'/0/index\n \"address\": \"value\", (string) The encoded address\n \"pubkey\": \"value\",  (string) The hex encoded compressed public key of the address\n},...]\n",
+		"convertaddress":           "convertaddress \"address\" \"addresstype\"\n\nConvert an address of the wallet to the address of another type which pays the same public key, such as from p2pkh to p2wpkh. The wallet must hold the private key of the address, watch-only and script addresses are rejected. Unless the converted address is already the wallet's, its key is imported so that payments to it are seen from the current block on, which needs the wallet to be unlocked. Conversions to p2sh-p2wpkh addresses which are not already the wallet's are rejected\n\nArguments:\n1. address     (string, required) The address of the wallet to convert\n2. addresstype (string, required) The type of address to convert to, one of p2pkh (or legacy), p2sh-p2wpkh or p2wpkh (or segwit)\n\nResult:\n{\n \"address\": \"value\",     (string)  The converted address\n \"addresstype\": \"value\", (string)  The type of the converted address\n \"ismine\": true|false,   (boolean) Whether the converted address is an address of the wallet, which it is once converted\n}                        \n",
+		"getfee":                   "getfee \"txid\"\n\nGet the fee paid by a wallet transaction, mined or not. The values of the outputs which it spends are taken from the wallet, outputs of transactions which the wallet does not have are fetched from pktd when it is the backend and keeps a transaction index, otherwise the fee cannot be known unless the wallet owns or recorded every spent output. Neutrino cannot fetch transactions by hash, so with it only the fees of transactions whose spent outputs the wallet knows are returned\n\nArguments:\n1. txid (string, required) The hash of the transaction\n\nResult:\n{\n \"fee\": n.nnn,     (numeric) The fee paid by the transaction in coins\n \"feerate\": n.nnn, (numeric) The fee rate of the transaction in coins per kilobyte of virtual size\n \"size\": n,        (numeric) The serialized size of the transaction in bytes\n \"vsize\": n,       (numeric) The virtual size of the transaction in vbytes\n}                  \n",
+		"bumpfee":                  "bumpfee \"txid\"\n\nReplace an unmined wallet transaction which signals BIP125 replaceability with one paying a higher fee, taken out of its change, as autobumpafter does. The fee is doubled, or raised by more if minbumpincrement requires, but by no more than maxbumpfee\n\nArguments:\n1. txid (string, required) The hash of the transaction\n\nResult:\n\"value\" (string) The hash of the replacement\n",
+		"bumpfeecpfp":              "bumpfeecpfp \"txid\"\n\nBump the fee of an unmined wallet transaction by spending its largest output paying the wallet with a child transaction, so the two together pay what bumpfee would raise the fee to. The child pays back to the pinned change address or the address which it spends from and adds no more than maxbumpfee\n\nArguments:\n1. txid (string, required) The hash of the transaction\n\nResult:\n\"value\" (string) The hash of the child transaction\n",
+		"getbumpinfo":              "getbumpinfo \"txid\"\n\nGet whether the fee of a wallet transaction can be bumped by replacing it, as autobumpafter does, without changing it\n\nArguments:\n1. txid (string, required) The hash of the transaction\n\nResult:\n{\n \"replaceable\": true|false, (boolean) Whether the transaction signals BIP125 replaceability\n \"ownsinputs\": true|false,  (boolean) Whether every input of the transaction spends an output of the wallet, so the wallet can sign a replacement\n \"fee\": n.nnn,              (numeric) The fee paid by the transaction in coins, only known if the wallet owns every input\n \"minbumpfee\": n.nnn,       (numeric) The least fee in coins which a replacement must add, the minbumpincrement fee rate, by default the relay fee rate, of its virtual size\n \"canbump\": true|false,     (boolean) Whether the wallet can bump the fee of the transaction\n \"newfee\": n.nnn,           (numeric) The fee in coins which the replacement would pay if the fee can be bumped\n \"reason\": \"value\",         (string)  Why the fee cannot be bumped\n}                           \n",
+		"getaccountstats":          "getaccountstats (starttime=0 endtime=0)\n\nGet the number of transactions which each account received and sent, and the totals, counting the transactions which the wallet received between starttime and endtime. A transaction which spends from an account is outgoing for it, otherwise one which pays it is incoming\n\nArguments:\n1. starttime (numeric, optional, default=0) Only count transactions received at or after this unix time, 0 for no limit\n2. endtime   (numeric, optional, default=0) Only count transactions received at or before this unix time, 0 for no limit\n\nResult:\n[{\n \"name\": \"value\",   (string)  The name of the account\n \"account\": n,      (numeric) The account number\n \"scope\": \"value\",  (string)  The key scope which the account belongs to, as a derivation path m/purpose'/cointype'\n \"incoming\": n,     (numeric) The number of transactions which paid the account without spending from it\n \"received\": n.nnn, (numeric) The total in coins paid to the account by incoming transactions, and by outgoing transactions which paid it more than they spent from it\n \"outgoing\": n,     (numeric) The number of transactions which spent from the account\n \"sent\": n.nnn,     (numeric) The total in coins which left the account in outgoing transactions, including fees but not change\n},...]\n",
+		"getfeesource":             "getfeesource\n\nGet the current fee rate estimate and where it comes from: the fee estimation of pktd, the fee rates paid by the wallet's transactions in recent blocks (neutrino) or the fallback fee rate.\n\nArguments:\nNone\n\nResult:\n{\n \"source\": \"value\", (string)  Where the estimate comes from, pktd, neutrino or fallback\n \"feerate\": n.nnn,  (numeric) The estimated fee rate in coins per kilobyte\n \"lastupdate\": n,   (numeric) The unix time the estimate last changed, when pktd was first seen estimating the current rate or of the newest block observed by neutrino, 0 for the fallback fee rate which does not change\n}                   \n",
+		"getfeestats":              "getfeestats (blocks=1000)\n\nGet the fee rates paid by transactions which the wallet sent in recent blocks and how long each took to confirm. Only transactions whose inputs all belong to the wallet have a known fee\n\nArguments:\n1. blocks (numeric, optional, default=1000) The number of most recent blocks to include transactions from\n\nResult:\n{\n \"transactions\": [{      (array of object) The fee rate of each transaction\n  \"txid\": \"value\",       (string)          The hash of the transaction\n  \"height\": n,           (numeric)         The height of the block which the transaction was mined in\n  \"feerate\": n.nnn,      (numeric)         The fee rate paid by the transaction, in coins per kilobyte of virtual size\n  \"confirmseconds\": n,   (numeric)         The number of seconds between the wallet sending the transaction and the time of the block it was mined in, zero if the wallet found it in a block\n },...],                                   \n \"minfeerate\": n.nnn,    (numeric)         The lowest fee rate paid, in coins per kilobyte of virtual size\n \"medianfeerate\": n.nnn, (numeric)         The median fee rate paid, in coins per kilobyte of virtual size\n \"maxfeerate\": n.nnn,    (numeric)         The highest fee rate paid, in coins per kilobyte of virtual size\n}                        \n",
+		"getutxoages":              "getutxoages\n\nGet the distribution of the ages in confirmations of the wallet's unspent outputs, including locked outputs, unconfirmed outputs having no confirmations.\n\nArguments:\nNone\n\nResult:\n{\n \"count\": n,       (numeric)         The number of unspent outputs\n \"oldest\": n,      (numeric)         The confirmations of the oldest unspent output, 0 if there are none\n \"newest\": n,      (numeric)         The confirmations of the newest unspent output, 0 if there are none\n \"median\": n,      (numeric)         The median confirmations of the unspent outputs, 0 if there are none\n \"buckets\": [{     (array of object) The number and value of the unspent outputs in each range of confirmations, youngest first\n  \"minconfs\": n,   (numeric)         The fewest confirmations of the outputs in the bucket\n  \"maxconfs\": n,   (numeric)         The most confirmations of the outputs in the bucket, absent for the last bucket which has no upper bound\n  \"count\": n,      (numeric)         The number of outputs in the bucket\n  \"amount\": n.nnn, (numeric)         The total value of the outputs in the bucket in coins\n },...],                             \n}                  \n",
+		"exporttaxreport":          "exporttaxreport\n\nExport a record of each disposal of coins by the wallet's mined transactions for tax software. Disposals are matched first in first out against the lots which the wallet received, a disposal which spans lots gives a record for each.\n\nArguments:\nNone\n\nResult:\n[{\n \"dateacquired\": \"value\", (string)  The date (UTC, RFC 3339) of the block which the lot was received in, empty if the wallet did not see it received\n \"acquiredtxid\": \"value\", (string)  The hash of the transaction which received the lot, empty if the wallet did not see it received\n \"datedisposed\": \"value\", (string)  The date (UTC, RFC 3339) of the block which the disposal was mined in\n \"disposedtxid\": \"value\", (string)  The hash of the transaction which disposed of the lot\n \"amount\": n.nnn,         (numeric) The amount of the lot disposed of, including its share of the fee\n \"costbasis\": n.nnn,      (numeric) Always null, the wallet does not know the price which was paid for the lot\n \"proceeds\": n.nnn,       (numeric) The part of the amount which was paid to others rather than as a fee, in coins\n},...]\n",
+		"exportlabels":             "exportlabels\n\nExport the labels of the wallet's transactions, without any key material, for example to keep them in step with another system\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\",  (string) The hash of the transaction\n \"label\": \"value\", (string) The label of the transaction\n},...]\n",
+		"importlabels":             "importlabels [{\"txid\":\"value\",\"label\":\"value\"},...] (overwrite=false)\n\nLabel the wallet's transactions with labels in the form given by exportlabels. Labels are merged with those of the wallet, labels of transactions which the wallet does not know are skipped\n\nArguments:\n1. labels (array of object, required) The labelled transactions\n[{\n \"txid\": \"value\",  (string) The hash of the transaction\n \"label\": \"value\", (string) The label of the transaction\n},...]\n2. overwrite (boolean, optional, default=false) Replace the label of a transaction which already has one rather than keep it\n\nResult:\n{\n \"imported\": n, (numeric) The number of labels written\n \"kept\": n,     (numeric) The number of labels not written because the transaction already has a label and overwrite is not set\n \"unknown\": n,  (numeric) The number of labels not written because the wallet does not know the transaction\n}               \n",
+		"dumputxoset":              "dumputxoset\n\nDump the wallet's spendable outputs. Over HTTP the response is a stream of NDJSON, one JSON object per line for each output, rather than a JSON-RPC response so that very large UTXO sets need not be held in memory. A final line with an error field is written if the dump fails part way\n\nArguments:\nNone\n\nResult:\n{\n \"txid\": \"value\",       (string)  The hash of the transaction\n \"vout\": n,             (numeric) The index of the output in the transaction\n \"amount\": n.nnn,       (numeric) The value of the output in coins\n \"scripttype\": \"value\", (string)  The type of the output script\n \"address\": \"value\",    (string)  The address paid by the output, omitted if the script does not pay to exactly one address\n \"confirmations\": n,    (numeric) The number of confirmations of the output\n}                       \n",
+		"getutxoinfo":              "getutxoinfo \"txid\" vout\n\nGet the address paid by an output and, if the wallet owns it, the origin of its key for signing with an external signer\n\nArguments:\n1. txid (string, required)  The hash of the transaction\n2. vout (numeric, required) The index of the output in the transaction\n\nResult:\n{\n \"txid\": \"value\",              (string)  The hash of the transaction\n \"vout\": n,                    (numeric) The index of the output in the transaction\n \"known\": true|false,          (boolean) Whether the transaction is known to the wallet, if not then no other information is given\n \"owned\": true|false,          (boolean) Whether the wallet holds the key for the address paid by the output\n \"amount\": n.nnn,              (numeric) The value of the output in coins\n \"scriptPubKey\": \"value\",      (string)  The output script as a hex string\n \"address\": \"value\",           (string)  The address paid by the output, omitted if the script does not pay to an address\n \"derivationpath\": \"value\",    (string)  The derivation path of the key from the master key, omitted unless the wallet derived the key from its seed\n \"masterfingerprint\": \"value\", (string)  The fingerprint of the master public key as a hex string, omitted with the derivation path\n}                              \n",
+		"listauxoutputs":           "listauxoutputs\n\nList the zero value and unspendable outputs, such as OP_RETURN data, of the wallet's transactions. These are not counted in the balance or as unspent outputs\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\",         (string)  The hash of the transaction\n \"vout\": n,               (numeric) The index of the output in the transaction\n \"amount\": n.nnn,         (numeric) The value of the output in coins, usually zero\n \"scriptPubKey\": \"value\", (string)  The output script, hex encoded\n \"data\": \"value\",         (string)  The data carried by an OP_RETURN output, hex encoded, omitted for other outputs\n \"confirmations\": n,      (numeric) The number of confirmations of the transaction, 0 if it is unmined\n},...]\n",
+		"listpendingtransactions":  "listpendingtransactions\n\nList the wallet's unconfirmed transactions, oldest first\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\",           (string)  The hash of the transaction\n \"fee\": n.nnn,              (numeric) The fee paid by the transaction, omitted if any of its inputs do not belong to the wallet\n \"feerate\": n.nnn,          (numeric) The fee rate paid by the transaction in coins per kilobyte of virtual size, omitted if the fee is not known\n \"ageseconds\": n,           (numeric) The number of seconds since the wallet first saw the transaction\n \"replaceable\": true|false, (boolean) Whether the transaction signals that it may be replaced (BIP0125)\n},...]\n",
+		"setnetworkstewardvote":    "setnetworkstewardvote (\"votefor\" \"voteagainst\")\n\nConfigure the wallet to vote for a network steward when making payments (note: payments to segwit addresses cannot vote)\n\nArguments:\n1. votefor     (string, optional) The address to vote for (in the event of an election, this is the address who should win)\n2. voteagainst (string, optional) The address to vote against (if this is the current NS then this will cause a vote for an election)\n\nResult:\n{\n} \n",
+		"getnetworkstewardvote":    "getnetworkstewardvote\n\nFind out how the wallet is currently configured to vote in a network steward election\n\nArguments:\nNone\n\nResult:\n{\n \"votefor\": \"value\",     (string) The address which your wallet is currently voting for\n \"voteagainst\": \"value\", (string) The address which your wallet is currently voting against\n}                        \n",
+		"rescanaddress":            "rescanaddress \"address\" (fromheight toheight)\n\nRescan the chain for the transactions of a single wallet address, this downloads far fewer blocks than a full resync when only one address needs catching up\n\nArguments:\n1. address    (string, required)  The wallet address to rescan for\n2. fromheight (numeric, optional) Start rescanning from the specified height, default or -1 will use the height of the chain when the wallet was created\n3. toheight   (numeric, optional) Stop rescanning when this height is reached, default or -1 will use the tip of the chain\n\nResult:\nNothing\n",
+		"setmaintenancemode":       "setmaintenancemode enable\n\nTurn maintenance mode on or off, while it is on RPCs which move funds (sendtoaddress, sendmany, sendmanydetailed, sendfrom, spendmax, createtransaction, signrawtransaction and sendrawtransaction) are refused with an error and all other RPCs are answered\n\nArguments:\n1. enable (boolean, required) True to turn maintenance mode on, false to turn it off\n\nResult:\nNothing\n",
+		"resync":                   "resync (fromheight toheight [\"address\",...] dropdb)\n\nRe-synchronize the wallet to the chain, scan from the first block to find any missing coins\n\nArguments:\n1. fromheight (numeric, optional)         Start re-syncing to the chain from specified height, default or -1 will use the height of the chain when the wallet was created\n2. toheight   (numeric, optional)         Stop resyncing when this height is reached, default or -1 will use the tip of the chain\n3. addresses  (array of string, optional) If specified, the wallet will ONLY scan the chain for these addresses, not others. If dropdb is specified then it will scan all addresses including these\n4. dropdb     (boolean, optional)         Clean most of the data out of the wallet transaction store, this is not a real resync, it just drops the wallet and then lets it begin working again\n\nResult:\nNothing\n",
+		"stopresync":               "stopresync\n\nStop a re-synchronization job before it's completion\n\nArguments:\nNone\n\nResult:\n\"value\" (string) The name of the sync job which was stopped\n",
+		"cancelrescan":             "cancelrescan\n\nStop the running re-synchronization job after the blocks it is scanning, keeping its progress so that it resumes from where it stopped when the wallet is next started. Does nothing if no job is running\n\nArguments:\nNone\n\nResult:\n\"value\" (string) The name of the job which was stopped, empty if none was running\n",
+		"pausesync":                "pausesync\n\nPause the neutrino sync of block headers and filter headers without disconnecting from peers, the sync stays paused until resumesync is called. There is no getsyncstatus command, the paused state is reported by getinfo as NeutrinoInfo.SyncPaused.\n\nArguments:\nNone\n\nResult:\nNothing\n",
+		"getpeerinfo":              "getpeerinfo\n\nGet the details of each peer which the wallet is connected to in neutrino (SPV) mode\n\nArguments:\nNone\n\nResult:\n[{\n \"id\": n,                 (numeric) A unique ID of the peer\n \"addr\": \"value\",         (string)  The IP address and port of the peer\n \"addrlocal\": \"value\",    (string)  Always empty, the local address is not reported\n \"services\": \"value\",     (string)  Services bitmask which represents the services supported by the peer\n \"relaytxes\": true|false, (boolean) Always false, transaction relay is not reported\n \"lastsend\": n,           (numeric) Time the last message was sent in seconds since 1 Jan 1970 GMT\n \"lastrecv\": n,           (numeric) Time the last message was received in seconds since 1 Jan 1970 GMT\n \"bytessent\": n,          (numeric) Total bytes sent\n \"bytesrecv\": n,          (numeric) Total bytes received\n \"conntime\": n,           (numeric) Time the connection was made in seconds since 1 Jan 1970 GMT\n \"timeoffset\": n,         (numeric) The time offset of the peer\n \"pingtime\": n.nnn,       (numeric) Number of microseconds the last ping took\n \"pingwait\": n.nnn,       (numeric) Number of microseconds a queued ping has been waiting for a response\n \"version\": n,            (numeric) The protocol version of the peer\n \"subver\": \"value\",       (string)  The user agent of the peer\n \"inbound\": true|false,   (boolean) Whether the peer connected to the wallet rather than the wallet to the peer\n \"startingheight\": n,     (numeric) The latest block height the peer knew about when the connection was established\n \"currentheight\": n,      (numeric) The height of the latest block which the peer has advertised\n \"banscore\": n,           (numeric) Always 0, the ban score is not reported\n \"feefilter\": n,          (numeric) Always 0, the fee filter of the peer is not reported\n \"syncnode\": true|false,  (boolean) Always false, the sync peer is not reported\n},...]\n",
+		"resumesync":               "resumesync\n\nResume the neutrino sync of block headers and filter headers paused by pausesync. getinfo reports NeutrinoInfo.SyncPaused as false once the sync is resumed.\n\nArguments:\nNone\n\nResult:\nNothing\n",
+		"addp2shscript":            "addp2shscript \"script\" segwit\n\nImport a p2sh script in order to be able to watch a multisig wallet\n\nArguments:\n1. script (string, required)  The redeem script to import\n2. segwit (boolean, required) If true then this will create a segwit address\n\nResult:\n\"value\" (string) The address corrisponding to this script\n",
+		"dumpprivkey":              "dumpprivkey \"address\"\n\nReturns the private key in WIF encoding that controls some wallet address.\n\nArguments:\n1. address (string, required) The address to return a private key for\n\nResult:\n\"value\" (string) The WIF-encoded private key\n",
+		"getbalance":               "getbalance (minconf=1 verbose)\n\nCalculates and returns the balance of the wallet, leaving out coins with fewer than finalitydepth confirmations, as they may yet be undone by a reorg, and coins paid to watch only addresses.\n\nArguments:\n1. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an unspent output's value is included in the balance\n2. verbose (boolean, optional)            If true then return an object with the balance and, apart from it, the amounts which are maturing and watch only\n\nResult (verbose=false):\nn.nnn (numeric) The balance valued in bitcoin\n\nResult (verbose=true):\n{\n \"balance\": n.nnn,   (numeric) The balance valued in bitcoin\n \"maturing\": n.nnn,  (numeric) The amount of coins with enough confirmations to be counted but fewer than finalitydepth, valued in bitcoin\n \"watchonly\": n.nnn, (numeric) The amount paid to watch only taproot addresses, which cannot yet be spent, valued in bitcoin\n}                    \n",
+		"getbestblockhash":         "getbestblockhash\n\nReturns the hash of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\n\"value\" (string) The hash of the most recent synced-to block\n",
+		"getblockcount":            "getblockcount\n\nReturns the blockchain height of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\nn.nnn (numeric) The blockchain height of the most recent synced-to block\n",
+		"getinfo":                  "getinfo\n\nReturns a JSON object containing various state info.\n\nArguments:\nNone\n\nResult:\n{\n \"version\": n,          (numeric) The version of the server\n \"protocolversion\": n,  (numeric) The latest supported protocol version\n \"walletversion\": n,    (numeric) The version of the address manager database\n \"balance\": n.nnn,      (numeric) The balance of all accounts calculated with one block confirmation\n \"blocks\": n,           (numeric) The number of blocks processed\n \"timeoffset\": n,       (numeric) The time offset\n \"connections\": n,      (numeric) The number of connected peers\n \"difficulty\": n.nnn,   (numeric) The current target difficulty\n \"testnet\": true|false, (boolean) Whether or not server is using testnet\n \"keypoololdest\": n,    (numeric) Unset\n \"keypoolsize\": n,      (numeric) Unset\n \"unlocked_until\": n,   (numeric) Unset\n \"paytxfee\": n.nnn,     (numeric) The increment used each time more fee is required for an authored transaction\n \"relayfee\": n.nnn,     (numeric) The minimum relay fee for non-free transactions in BTC/KB\n \"errors\": \"value\",     (string)  Any current errors\n}                       \n",
+		"getnewaddress":            "getnewaddress (legacy \"account\" \"keyscope\")\n\nGenerates and returns a new payment address.\n\nArguments:\n1. legacy   (boolean, optional) If true then this will create a legacy form address, if false a segwit address, overriding the account's default address type\n2. account  (string, optional)  Account name the new address will belong to, addresses are of the account's default address type unless legacy is given, the account is created if it does not exist when autocreateaccounts is set (default=\"default\")\n3. keyscope (string, optional)  Key scope (purpose/cointype) to derive the address under, such as one added with the keyscope option, overriding legacy and the account's default address type\n\nResult:\n\"value\" (string) The payment address\n",
+		"getreceivedbyaddress":     "getreceivedbyaddress \"address\" (minconf=1)\n\nReturns the total amount received by a single address, including spent outputs.\n\nArguments:\n1. address (string, required)             Payment address which received outputs to include in total\n2. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an output's value is included in the total\n\nResult:\nn.nnn (numeric) The total received amount valued in bitcoin\n",
+		"gettransaction":           "gettransaction \"txid\" (includewatchonly=false)\n\nReturns a JSON object with details regarding a transaction relevant to this wallet.\n\nArguments:\n1. txid             (string, required)                 Hash of the transaction to query\n2. includewatchonly (boolean, optional, default=false) Also consider transactions involving watched addresses\n\nResult:\n{\n \"amount\": n.nnn,                  (numeric)         The total amount this transaction credits to the wallet, valued in bitcoin\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value, or 0 if 'txid' is not a sent transaction\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction, or -1 if it was removed because it conflicts with a mined transaction\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"txid\": \"value\",                  (string)          The transaction hash\n \"walletconflicts\": [\"value\",...], (array of string) The hash of the mined transaction which conflicts with this transaction, if it was removed as conflicted\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Whether the transaction has at least the number of confirmations set by the trustedconfs option\n \"details\": [{                     (array of object) Additional details for each recorded wallet credit and debit\n  \"account\": \"value\",              (string)          DEPRECATED -- Unset\n  \"address\": \"value\",              (string)          The address an output was paid to, or the empty string if the output is nonstandard or this detail is regarding a transaction input\n  \"amount\": n.nnn,                 (numeric)         The amount of a received output\n  \"category\": \"value\",             (string)          The kind of detail: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs\n  \"involveswatchonly\": true|false, (boolean)         Unset\n  \"fee\": n.nnn,                    (numeric)         The included fee for a sent transaction\n  \"vout\": n,                       (numeric)         The transaction output index\n },...],                                             \n \"hex\": \"value\",                   (string)          The transaction encoded as a hexadecimal string\n \"comment\": \"value\",               (string)          The comment recorded when the transaction was sent, if any\n \"to\": \"value\",                    (string)          The comment about who the transaction was sent to, if any\n}                                  \n",
+		"getwalletseed":            "getwalletseed\n\nGet the wallet seed words for this wallet\n\nArguments:\nNone\n\nResult:\n\"value\" (string) The seed words used, along with the wallet passphrase, to create the wallet\n",
+		"backupseedencrypted":      "backupseedencrypted \"walletpassphrase\" \"passphrase\"\n\nGet the wallet seed encrypted under a backup passphrase, for a backup which cannot be read without it. The seed is encrypted with NaCl secretbox (XSalsa20 and Poly1305) under a key derived from the passphrase with scrypt (N=2^18, r=8, p=1) and a random salt. The wallet may be restored from it by giving it as the seed to --create, with the backup passphrase as the seed passphrase\n\nArguments:\n1. walletpassphrase (string, required) The private passphrase of the wallet, which the seed is stored encrypted under\n2. passphrase       (string, required) The passphrase to encrypt the backup under, needed to restore from it\n\nResult:\n\"value\" (string) The encrypted seed backup, hex encoded\n",
+		"getsecret":                "getsecret \"name\"\n\nGet a secret seed which is generated using the wallet's private key, this can be used as a password for another application\n\nArguments:\n1. name (string, required) A name which will be used to generate the secret seed, the same seed will always be provided given the same name\n\nResult:\n\"value\" (string) A 32 byte secret seed in hex form\n",
+		"help":                     "help (\"command\")\n\nReturns a list of all commands or help for a specified command.\n\nArguments:\n1. command (string, optional) The command to retrieve help for\n\nResult (no command provided):\n\"value\" (string) List of commands\n\nResult (command specified):\n\"value\" (string) Help for specified command\n",
+		"importaddress":            "importaddress \"address\" (rescan=true)\n\nImports a p2tr address to the 'imported' account to watch, its outputs are counted in the balance but cannot yet be spent.\n\nArguments:\n1. address (string, required)                The bech32m encoded p2tr address\n2. rescan  (boolean, optional, default=true) Rescan the blockchain (since the genesis block) for outputs paying to the address\n\nResult:\nNothing\n",
+		"importprivkey":            "importprivkey \"privkey\" (\"label\" rescan=true legacy=false)\n\nImports a WIF-encoded private key to the 'imported' account.\n\nArguments:\n1. privkey (string, required)                 The WIF-encoded private key\n2. label   (string, optional)                 Unused (must be unset or 'imported')\n3. rescan  (boolean, optional, default=true)  Rescan the blockchain (since the genesis block) for outputs controlled by the imported key\n4. legacy  (boolean, optional, default=false) If true then import as a legacy address, otherwise segwit\n\nResult:\nNothing\n",
+		"listlockunspent":          "listlockunspent\n\nReturns a JSON array of outpoints marked as locked (with lockunspent) for this wallet session.\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n",
+		"listreceivedbyaddress":    "listreceivedbyaddress (minconf=1 includeempty=false includewatchonly=false)\n\nReturns a JSON array of objects listing wallet payment addresses and their total received amounts.\n\nArguments:\n1. minconf          (numeric, optional, default=1)     Minimum number of block confirmations required before a transaction is considered\n2. includeempty     (boolean, optional, default=false) Unused\n3. includewatchonly (boolean, optional, default=false) Unused\n\nResult:\n[{\n \"account\": \"value\",              (string)          DEPRECATED -- Unset\n \"address\": \"value\",              (string)          The payment address\n \"amount\": n.nnn,                 (numeric)         Total amount received by the payment address valued in bitcoin\n \"confirmations\": n,              (numeric)         Number of block confirmations of the most recent transaction relevant to the address\n \"txids\": [\"value\",...],          (array of string) Transaction hashes of all transactions involving this address\n \"involvesWatchonly\": true|false, (boolean)         Unset\n},...]\n",
+		"listsinceblock":           "listsinceblock (\"blockhash\" targetconfirmations=1 includewatchonly=false)\n\nReturns a JSON array of objects listing details of all wallet transactions after some block.\n\nArguments:\n1. blockhash           (string, optional)                 Hash of the parent block of the first block to consider transactions from, or unset to list all transactions\n2. targetconfirmations (numeric, optional, default=1)     Minimum number of block confirmations of the last block in the result object.  Must be 1 or greater.  Note: The transactions array in the result object is not affected by this parameter\n3. includewatchonly    (boolean, optional, default=false) Unused\n\nResult:\n{\n \"transactions\": [{                 (array of object) JSON array of objects containing verbose details of the each transaction\n  \"abandoned\": true|false,          (boolean)         Unset\n  \"account\": \"value\",               (string)          DEPRECATED -- Unset\n  \"address\": \"value\",               (string)          Payment address for a transaction output\n  \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n  \"bip125-replaceable\": \"value\",    (string)          Unset\n  \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n  \"blockindex\": n,                  (numeric)         Unset\n  \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n  \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n  \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n  \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n  \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n  \"involveswatchonly\": true|false,  (boolean)         Unset\n  \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n  \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n  \"trusted\": true|false,            (boolean)         Whether the transaction has at least the number of confirmations set by the trustedconfs option\n  \"txid\": \"value\",                  (string)          The hash of the transaction\n  \"vout\": n,                        (numeric)         The transaction output index\n  \"walletconflicts\": [\"value\",...], (array of string) Unset\n  \"comment\": \"value\",               (string)          The comment recorded when the transaction was sent, if any\n  \"to\": \"value\",                    (string)          The comment about who the transaction was sent to, if any\n  \"otheraccount\": \"value\",          (string)          Unset\n },...],                                              \n \"lastblock\": \"value\",              (string)          Hash of the latest-synced block to be used in later calls to listsinceblock\n}                                   \n",
+		"listtransactions":         "listtransactions (count=10 from=0)\n\nReturns a JSON array of objects containing verbose details for wallet transactions.\n\nArguments:\n1. count (numeric, optional, default=10) Maximum number of transactions to create results from\n2. from  (numeric, optional, default=0)  Number of transactions to skip before results are created\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Whether the transaction has at least the number of confirmations set by the trustedconfs option\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          The comment recorded when the transaction was sent, if any\n \"to\": \"value\",                    (string)          The comment about who the transaction was sent to, if any\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
+		"listunspent":              "listunspent (minconf=1 maxconf=9999999 [\"address\",...])\n\nReturns a JSON array of objects representing unlocked unspent outputs controlled by wallet keys.\n\nArguments:\n1. minconf   (numeric, optional, default=1)       Minimum number of block confirmations required before a transaction output is considered\n2. maxconf   (numeric, optional, default=9999999) Maximum number of block confirmations required before a transaction output is excluded\n3. addresses (array of string, optional)          If set, limits the returned details to unspent outputs received by any of these payment addresses\n\nResult:\n{\n \"txid\": \"value\",         (string)  The transaction hash of the referenced output\n \"vout\": n,               (numeric) The output index of the referenced output\n \"address\": \"value\",      (string)  The payment address that received the output\n \"account\": \"value\",      (string)  The account associated with the receiving payment address\n \"scriptPubKey\": \"value\", (string)  The output script encoded as a hexadecimal string\n \"redeemScript\": \"value\", (string)  Unset\n \"amount\": n.nnn,         (numeric) The amount of the output valued in bitcoin\n \"confirmations\": n,      (numeric) The number of block confirmations of the transaction\n \"height\": n,             (numeric) The height of the block which the transaction was included in\n \"blockHash\": \"value\",    (string)  The hash of the block which the transaction was included in\n \"spendable\": true|false, (boolean) Whether the output is entirely controlled by wallet keys/scripts (false for partially controlled multisig outputs or outputs to watch-only addresses)\n \"frozen\": true|false,    (boolean) Whether the output pays to a frozen address, frozen outputs are not spendable\n \"dust\": true|false,      (boolean) Whether the output is dust sent by others which is quarantined by ignoreincomingdust, quarantined outputs are not spendable\n}                         \n",
+		"lockunspent":              "lockunspent unlock [{\"txid\":\"value\",\"vout\":n},...] (\"lockname\")\n\nLocks or unlocks an unspent output.\nLocked outputs are not chosen for transaction inputs of authored transactions and are not included in 'listunspent' results.\nLocked outputs are volatile and are not saved across wallet restarts.\nIf unlock is true and no transaction outputs are specified, all locked outputs are marked unlocked.\n\nArguments:\n1. unlock       (boolean, required)         True to unlock outputs, false to lock\n2. transactions (array of object, required) Transaction outputs to lock or unlock\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n3. lockname (string, optional) Name of the lock to apply, allows groups of locks to be cleared at once\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
+		"markaddressused":          "markaddressused \"address\"\n\nMark a wallet address as used so that a new address will be handed out after it, this fails if the address is further than the gap limit beyond the previous used address\n\nArguments:\n1. address (string, required) The address to mark as used\n\nResult:\nNothing\n",
+		"markaddressunused":        "markaddressunused \"address\"\n\nClear the used flag of a wallet address, this fails if it would leave a gap larger than the gap limit between the used addresses on either side of it\n\nArguments:\n1. address (string, required) The address to mark as unused\n\nResult:\nNothing\n",
+		"freezeaddress":            "freezeaddress \"address\"\n\nFreeze an address, for example because its key is compromised, its outputs are not used as inputs of new transactions and are not counted in the balance until it is unfrozen, the freeze is kept in the wallet database\n\nArguments:\n1. address (string, required) The address to freeze\n\nResult:\nNothing\n",
+		"unfreezeaddress":          "unfreezeaddress \"address\"\n\nUnfreeze an address which was frozen with freezeaddress, making its outputs spendable again\n\nArguments:\n1. address (string, required) The address to unfreeze\n\nResult:\nNothing\n",
+		"listfrozenaddresses":      "listfrozenaddresses\n\nList the addresses which are frozen\n\nArguments:\nNone\n\nResult:\n[\"value\",...] (array of string) The frozen addresses\n",
+		"getchangeaddress":         "getchangeaddress\n\nGet the address which the change of sends is pinned to by setchangeaddress\n\nArguments:\nNone\n\nResult:\n\"value\" (string) The pinned change address, empty if the change is not pinned\n",
+		"setchangeaddress":         "setchangeaddress (\"address\")\n\nPin the change of sends to an address of the wallet, in place of choosing a change address for each send, until it is unpinned. A change address given to a send still takes precedence\n\nArguments:\n1. address (string, optional) The address of the wallet to pay change to, omit or leave empty to unpin the change\n\nResult:\nNothing\n",
+		"sendfrom":                 "sendfrom \"toaddress\" amount ([\"fromaddress\",...] minconf=1 \"comment\" \"commentto\" maxinputs minheight allowselfsend changeinfo)\n\nDEPRECATED -- Authors, signs, and sends a transaction that outputs some amount to a payment address.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1.  toaddress     (string, required)             Address to pay\n2.  amount        (numeric, required)            Amount to send to the payment address valued in bitcoin\n3.  fromaddresses (array of string, optional)    Addresses to use for selecting coins to spend\n4.  minconf       (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n5.  comment       (string, optional)             A comment about the transaction, kept only in the wallet and never broadcast\n6.  commentto     (string, optional)             A comment about who the transaction is sent to, kept only in the wallet and never broadcast\n7.  maxinputs     (numeric, optional)            Maximum number of transaction inputs that are allowed\n8.  minheight     (numeric, optional)            Only select transactions from this height or above\n9.  allowselfsend (boolean, optional)            Allow outputs paying addresses of this wallet when warnselfsend is set\n10. changeinfo    (boolean, optional)            Return an object with the txid and the change output rather than only the txid\n\nResult (changeinfo=false):\n\"value\" (string) The transaction hash of the sent transaction\n\nResult (changeinfo=true):\n{\n \"txid\": \"value\",          (string)  The transaction hash of the sent transaction\n \"changevout\": n,          (numeric) The output index of the change output, or null if the transaction has no change\n \"changeaddress\": \"value\", (string)  The address which the change was sent to, or null if the transaction has no change\n}                          \n",
+		"sendmany":                 "sendmany {\"address\":amount,...} ([\"fromaddress\",...] minconf=1 \"comment\" maxinputs allowselfsend changeinfo)\n\nAuthors, signs, and sends a transaction that outputs to many payment addresses.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. amounts (object, required) Pairs of payment addresses and the output amount to pay each\n{\n \"Address to pay\": Amount to send to the payment address valued in bitcoin, (object) JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address\n ...\n}\n2. fromaddresses (array of string, optional)    Addresses to use for selecting coins to spend\n3. minconf       (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n4. comment       (string, optional)             A comment about the transaction, kept only in the wallet and never broadcast\n5. maxinputs     (numeric, optional)            Maximum number of transaction inputs that are allowed\n6. allowselfsend (boolean, optional)            Allow outputs paying addresses of this wallet when warnselfsend is set\n7. changeinfo    (boolean, optional)            Return an object with the txid and the change output rather than only the txid\n\nResult (changeinfo=false):\n\"value\" (string) The transaction hash of the sent transaction\n\nResult (changeinfo=true):\n{\n \"txid\": \"value\",          (string)  The transaction hash of the sent transaction\n \"changevout\": n,          (numeric) The output index of the change output, or null if the transaction has no change\n \"changeaddress\": \"value\", (string)  The address which the change was sent to, or null if the transaction has no change\n}                          \n",
+		"sendmanydetailed":         "sendmanydetailed {\"address\":amount,...} ([\"fromaddress\",...] minconf=1 maxinputs allowselfsend)\n\nAuthors, signs, and sends a transaction that outputs to many payment addresses, as sendmany does.\nThe result describes the fee paid and attributes a share of it to each payment output in proportion to its amount.\n\nArguments:\n1. amounts (object, required) Pairs of payment addresses and the output amount to pay each\n{\n \"Address to pay\": Amount to send to the payment address valued in bitcoin, (object) JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address\n ...\n}\n2. fromaddresses (array of string, optional)    Addresses to use for selecting coins to spend\n3. minconf       (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n4. maxinputs     (numeric, optional)            Maximum number of transaction inputs that are allowed\n5. allowselfsend (boolean, optional)            Allow outputs paying addresses of this wallet when warnselfsend is set\n\nResult:\n{\n \"txid\": \"value\",          (string)          The transaction hash of the sent transaction\n \"fee\": n.nnn,             (numeric)         The total fee paid by the transaction in bitcoin\n \"outputs\": [{             (array of object) The payment outputs of the transaction\n  \"address\": \"value\",      (string)          The address paid by the output\n  \"vout\": n,               (numeric)         The output index\n  \"amount\": n.nnn,         (numeric)         The amount paid by the output in bitcoin\n  \"fee\": n.nnn,            (numeric)         The share of the fee attributed to the output in bitcoin, the shares sum to the total fee\n },...],                                     \n \"changevout\": n,          (numeric)         The output index of the change output, or null if the transaction has no change\n \"changeaddress\": \"value\", (string)          The address which the change was sent to, or null if the transaction has no change\n}                          \n",
+		"sendfromutxos":            "sendfromutxos [{\"txid\":\"value\",\"vout\":n},...] {\"address\":amount,...} (\"feerate\" \"changeaddress\" \"comment\" allowselfsend)\n\nAuthors, signs, and sends a transaction spending exactly the named unspent outputs of the wallet, all of them, to one or more payment addresses.\nWhatever the inputs have beyond the payments and the fee is returned as change. The inputs need not be confirmed, but fail if they are not unspent outputs of the wallet, are locked or frozen, or have too little to pay the outputs and fee.\n\nArguments:\n1. inputs (array of object, required) The unspent outputs to spend\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n2. amounts (object, required) Pairs of payment addresses and the output amount to pay each\n{\n \"Address to pay\": Amount to send to the payment address valued in bitcoin, (object) JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address\n ...\n}\n3. feerate       (string, optional)  The fee rate, either in coins per kilobyte or with a unit such as 10bit/vB, default is the relay fee\n4. changeaddress (string, optional)  The address to pay the change to, default is the address set with setchangeaddress or else the address of one of the inputs\n5. comment       (string, optional)  A comment about the transaction, kept only in the wallet and never broadcast\n6. allowselfsend (boolean, optional) Allow outputs paying addresses of this wallet when warnselfsend is set\n\nResult:\n{\n \"txid\": \"value\",          (string)  The transaction hash of the sent transaction\n \"changevout\": n,          (numeric) The output index of the change output, or null if the transaction has no change\n \"changeaddress\": \"value\", (string)  The address which the change was sent to, or null if the transaction has no change\n}                          \n",
+		"sendtoaddress":            "sendtoaddress \"address\" amount (\"comment\" \"commentto\" allowselfsend changeinfo)\n\nAuthors, signs, and sends a transaction that outputs some amount to a payment address.\nUnlike sendfrom, outputs are always chosen from the default account.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. address       (string, required)  Address to pay\n2. amount        (numeric, required) Amount to send to the payment address valued in bitcoin\n3. comment       (string, optional)  A comment about the transaction, kept only in the wallet and never broadcast\n4. commentto     (string, optional)  A comment about who the transaction is sent to, kept only in the wallet and never broadcast\n5. allowselfsend (boolean, optional) Allow outputs paying addresses of this wallet when warnselfsend is set\n6. changeinfo    (boolean, optional) Return an object with the txid and the change output rather than only the txid\n\nResult (changeinfo=false):\n\"value\" (string) The transaction hash of the sent transaction\n\nResult (changeinfo=true):\n{\n \"txid\": \"value\",          (string)  The transaction hash of the sent transaction\n \"changevout\": n,          (numeric) The output index of the change output, or null if the transaction has no change\n \"changeaddress\": \"value\", (string)  The address which the change was sent to, or null if the transaction has no change\n}                          \n",
+		"settxfee":                 "settxfee amount\n\nModify the increment used each time more fee is required for an authored transaction.\n\nArguments:\n1. amount (numeric, required) The new fee increment valued in bitcoin\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
+		"signmessage":              "signmessage \"address\" \"message\"\n\nSigns a message using the private key of a payment address.\n\nArguments:\n1. address (string, required) Payment address of private key used to sign the message with\n2. message (string, required) Message to sign\n\nResult:\n\"value\" (string) The signed message encoded as a base64 string\n",
+		"signrawtransaction":       "signrawtransaction \"rawtx\" ([{\"txid\":\"value\",\"vout\":n,\"scriptpubkey\":\"value\",\"redeemscript\":\"value\"},...] [\"privkey\",...] flags=\"ALL\")\n\nSigns transaction inputs using private keys from this wallet and request.\nThe valid flags options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.\n\nArguments:\n1. rawtx    (string, required)                Unsigned or partially unsigned transaction to sign encoded as a hexadecimal string\n2. inputs   (array of object, optional)       Additional data regarding inputs that this wallet may not be tracking\n3. privkeys (array of string, optional)       Additional WIF-encoded private keys to use when creating signatures\n4. flags    (string, optional, default=\"ALL\") Sighash flags\n\nResult:\n{\n \"hex\": \"value\",         (string)          The resulting transaction encoded as a hexadecimal string\n \"complete\": true|false, (boolean)         Whether all input signatures have been created\n \"errors\": [{            (array of object) Script verification errors (if exists)\n  \"txid\": \"value\",       (string)          The transaction hash of the referenced previous output\n  \"vout\": n,             (numeric)         The output index of the referenced previous output\n  \"scriptSig\": \"value\",  (string)          The hex-encoded signature script\n  \"sequence\": n,         (numeric)         Script sequence number\n  \"error\": \"value\",      (string)          Verification or signing error related to the input\n },...],                                   \n}                        \n",
+		"simulatesend":             "simulatesend {\"address\":amount,...} ([\"fromaddress\",...] minconf=1 maxinputs allowselfsend)\n\nAuthors the transaction which sendmanydetailed would send with the same parameters, selecting the same inputs and change, but neither signs nor broadcasts it.\nThe result describes the unsigned transaction, the outputs it spends, its change, its fee and its estimated virtual size once signed.\n\nArguments:\n1. amounts (object, required) Pairs of payment addresses and the output amount to pay each\n{\n \"Address to pay\": Amount to send to the payment address valued in bitcoin, (object) JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address\n ...\n}\n2. fromaddresses (array of string, optional)    Addresses to use for selecting coins to spend\n3. minconf       (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n4. maxinputs     (numeric, optional)            Maximum number of transaction inputs that are allowed\n5. allowselfsend (boolean, optional)            Allow outputs paying addresses of this wallet when warnselfsend is set\n\nResult:\n{\n \"hex\": \"value\",           (string)          The serialized unsigned transaction encoded as hex\n \"txid\": \"value\",          (string)          The hash of the unsigned transaction, which changes once it is signed unless all of its inputs are segwit\n \"inputs\": [{              (array of object) The outputs which the transaction spends\n  \"txid\": \"value\",         (string)          The hash of the transaction containing the spent output\n  \"vout\": n,               (numeric)         The index of the spent output\n  \"address\": \"value\",      (string)          The address paid by the spent output\n  \"amount\": n.nnn,         (numeric)         The amount of the spent output in bitcoin\n },...],                                     \n \"fee\": n.nnn,             (numeric)         The total fee paid by the transaction in bitcoin\n \"vsize\": n,               (numeric)         The estimated virtual size of the transaction once signed\n \"changevout\": n,          (numeric)         The output index of the change output, or null if the transaction has no change\n \"changeaddress\": \"value\", (string)          The address which the change would be sent to, or null if the transaction has no change\n \"changeamount\": n.nnn,    (numeric)         The amount of the change output in bitcoin, or null if the transaction has no change\n}                          \n",
+		"getcoinselectionprivacy":  "getcoinselectionprivacy {\"address\":amount,...} ([\"fromaddress\",...] minconf=1 maxinputs allowselfsend)\n\nSelects the inputs and change which sendmanydetailed would with the same parameters, as simulatesend does, and reports what the transaction would reveal about the wallet.\nEvery address spent by the inputs is revealed to belong to the same wallet, so the fewer there are the more private the send.\n\nArguments:\n1. amounts (object, required) Pairs of payment addresses and the output amount to pay each\n{\n \"Address to pay\": Amount to send to the payment address valued in bitcoin, (object) JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address\n ...\n}\n2. fromaddresses (array of string, optional)    Addresses to use for selecting coins to spend\n3. minconf       (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n4. maxinputs     (numeric, optional)            Maximum number of transaction inputs that are allowed\n5. allowselfsend (boolean, optional)            Allow outputs paying addresses of this wallet when warnselfsend is set\n\nResult:\n{\n \"inputs\": n,                    (numeric)         The number of inputs the transaction would have\n \"inputaddresses\": n,            (numeric)         The number of distinct addresses spent by the inputs, which the transaction links together\n \"haschange\": true|false,        (boolean)         Whether the transaction would have a change output\n \"changerevealed\": true|false,   (boolean)         Whether the change output can be told apart from the payments, revealing another address of the wallet\n \"changereasons\": [\"value\",...], (array of string) How the change output can be told apart from the payments\n}                                \n",
+		"getblockfilter":           "getblockfilter \"blockhash\"\n\nReturns the BIP158 regular filter of a block and its filter header, as stored by neutrino.\nOnly available with the neutrino backend, an error is returned if the filter headers have not been synced up to the block yet.\n\nArguments:\n1. blockhash (string, required) The hash of the block\n\nResult:\n{\n \"filter\": \"value\", (string) The serialized filter encoded as hex\n \"header\": \"value\", (string) The filter header of the block\n}                   \n",
+		"spendmax":                 "spendmax \"address\" ([\"fromaddress\",...] minconf=1 allowselfsend)\n\nAuthors, signs, and sends a transaction paying all of the spendable outputs, less the fee, to a single address.\nThis sends the most that a single payment can, the transaction has no change output.\n\nArguments:\n1. address       (string, required)             Address to pay\n2. fromaddresses (array of string, optional)    Addresses to use for selecting coins to spend, all addresses are used if not specified\n3. minconf       (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n4. allowselfsend (boolean, optional)            Allow outputs paying addresses of this wallet when warnselfsend is set\n\nResult:\n{\n \"txid\": \"value\", (string)  The transaction hash of the sent transaction\n \"amount\": n.nnn, (numeric) The amount paid to the address in bitcoin\n \"fee\": n.nnn,    (numeric) The fee paid by the transaction in bitcoin\n}                 \n",
+		"exportaccountwatchonly":   "exportaccountwatchonly (account=0)\n\nExport an account as a bundle of the extended public key, address type and number of issued addresses of each key scope, which importdescriptor imports into a watch-only wallet.\n\nArguments:\n1. account (numeric, optional, default=0) The account number to export\n\nResult:\n{\n \"account\": \"value\",      (string)          The name of the account\n \"descriptors\": [{        (array of object) The account in each key scope\n  \"scope\": \"value\",       (string)          The key scope (m/purpose'/cointype')\n  \"addresstype\": \"value\", (string)          The type of address derived in the key scope (p2pkh, p2wpkh or p2sh-p2wpkh)\n  \"xpub\": \"value\",        (string)          The extended public key of the account\n  \"externalcount\": n,     (numeric)         The number of external addresses issued\n  \"internalcount\": n,     (numeric)         The number of internal (change) addresses issued\n },...],                                    \n}                         \n",
+		"importdescriptor":         "importdescriptor {\"account\":\"value\",\"descriptors\":[{\"scope\":\"value\",\"addresstype\":\"value\",\"xpub\":\"value\",\"externalcount\":n,\"internalcount\":n},...]} (\"account\" rescan=true)\n\nImport an account exported by exportaccountwatchonly, deriving the same addresses to watch. The wallet must be watch only.\n\nArguments:\n1. bundle (object, required) The account bundle returned by exportaccountwatchonly\n{\n \"account\": \"value\",      (string)          The name of the account\n \"descriptors\": [{        (array of object) The account in each key scope\n  \"scope\": \"value\",       (string)          The key scope (m/purpose'/cointype')\n  \"addresstype\": \"value\", (string)          The type of address derived in the key scope (p2pkh, p2wpkh or p2sh-p2wpkh)\n  \"xpub\": \"value\",        (string)          The extended public key of the account\n  \"externalcount\": n,     (numeric)         The number of external addresses issued\n  \"internalcount\": n,     (numeric)         The number of internal (change) addresses issued\n },...],                                    \n}                         \n2. account (string, optional)                The name to give the account, the name in the bundle if unset\n3. rescan  (boolean, optional, default=true) Resync the chain (since the second block) for the addresses derived\n\nResult:\nNothing\n",
+		"validateaddress":          "validateaddress \"address\"\n\nVerify that an address is valid.\nExtra details are returned if the address is controlled by this wallet.\nThe following fields are valid only when the address is controlled by this wallet (ismine=true): isscript, pubkey, iscompressed, account, addresses, hex, script, and sigsrequired.\nThe following fields are only valid when address has an associated public key: pubkey, iscompressed.\nThe following fields are only valid when address is a pay-to-script-hash address: addresses, hex, and script.\nIf the address is a multisig address controlled by this wallet, the multisig fields will be left unset if the wallet is locked since the redeem script cannot be decrypted.\n\nArguments:\n1. address (string, required) Address to validate\n\nResult:\n{\n \"isvalid\": true|false,      (boolean)         Whether or not the address is valid\n \"address\": \"value\",         (string)          The payment address (only when isvalid is true)\n \"ismine\": true|false,       (boolean)         Whether this address is controlled by the wallet (only when isvalid is true)\n \"iswatchonly\": true|false,  (boolean)         Unset\n \"isscript\": true|false,     (boolean)         Whether the payment address is a pay-to-script-hash address (only when isvalid is true)\n \"pubkey\": \"value\",          (string)          The associated public key of the payment address, if any (only when isvalid is true)\n \"iscompressed\": true|false, (boolean)         Whether the address was created by hashing a compressed public key, if any (only when isvalid is true)\n \"account\": \"value\",         (string)          The account this payment address belongs to (only when isvalid is true)\n \"addresses\": [\"value\",...], (array of string) All associated payment addresses of the script if address is a multisig address (only when isvalid is true)\n \"hex\": \"value\",             (string)          The redeem script \n \"script\": \"value\",          (string)          The class of redeem script for a multisig address\n \"sigsrequired\": n,          (numeric)         The number of required signatures to redeem outputs to the multisig address\n}                            \n",
+		"verifymessage":            "verifymessage \"address\" \"signature\" \"message\"\n\nVerify a message was signed with the associated private key of some address.\n\nArguments:\n1. address   (string, required) Address used to sign message\n2. signature (string, required) The signature to verify\n3. message   (string, required) The message to verify\n\nResult:\ntrue|false (boolean) Whether the message was signed with the private key of 'address'\n",
+		"walletlock":               "walletlock\n\nLock the wallet.\n\nArguments:\nNone\n\nResult:\nNothing\n",
+		"walletpassphrase":         "walletpassphrase \"passphrase\" timeout\n\nUnlock the wallet.\n\nArguments:\n1. passphrase (string, required)  The wallet passphrase\n2. timeout    (numeric, required) The number of seconds to wait before the wallet automatically locks\n\nResult:\nNothing\n",
+		"walletpassphrasechange":   "walletpassphrasechange \"oldpassphrase\" \"newpassphrase\"\n\nChange the wallet passphrase.\n\nArguments:\n1. oldpassphrase (string, required) The old wallet passphrase\n2. newpassphrase (string, required) The new wallet passphrase\n\nResult:\nNothing\n",
+		"rekeywallet":              "rekeywallet \"passphrase\" (n=262144 r=8 p=1)\n\nRe-encrypt the keys securing the private keys and seed of the wallet under a key derived from the same passphrase with new scrypt parameters, such as stronger ones than the wallet was created with.\nThe wallet must be unlocked. N must be a power of two of at least 16384, r between 8 and 32, p between 1 and 16, and 128*N*r bytes of memory at most 1GiB.\n\nArguments:\n1. passphrase (string, required)                  The wallet passphrase\n2. n          (numeric, optional, default=262144) The scrypt CPU and memory cost parameter\n3. r          (numeric, optional, default=8)      The scrypt block size parameter\n4. p          (numeric, optional, default=1)      The scrypt parallelization parameter\n\nResult:\n{\n \"previous\": { (object)  The scrypt parameters which the wallet was encrypted with before\n  \"n\": n,      (numeric) The scrypt CPU and memory cost parameter\n  \"r\": n,      (numeric) The scrypt block size parameter\n  \"p\": n,      (numeric) The scrypt parallelization parameter\n },                      \n \"current\": {  (object)  The scrypt parameters which the wallet is now encrypted with\n  \"n\": n,      (numeric) The scrypt CPU and memory cost parameter\n  \"r\": n,      (numeric) The scrypt block size parameter\n  \"p\": n,      (numeric) The scrypt parallelization parameter\n },                      \n}              \n",
+		"walletmempool":            "walletmempool\n\nShow the unconfirmed transactions which are being broadcasted by the wallet\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\",     (string) Transaction id\n \"received\": \"value\", (string) The time when the transaction was first seen/made\n},...]\n",
+		"exportwatchingwallet":     "exportwatchingwallet (\"account\" download=false)\n\nCreates and returns a duplicate of the wallet database without any private keys to be used as a watching-only wallet.\n\nArguments:\n1. account  (string, optional)                 Unused (must be unset or \"*\")\n2. download (boolean, optional, default=false) Unused\n\nResult:\n\"value\" (string) The watching-only database encoded as a base64 string\n",
+		"getbestblock":             "getbestblock\n\nReturns the hash and height of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\n{\n \"hash\": \"value\", (string)  The hash of the block\n \"height\": n,     (numeric) The blockchain height of the block\n}                 \n",
+		"getunconfirmedbalance":    "getunconfirmedbalance (\"account\")\n\nCalculates the unspent output value of all unmined transaction outputs for an account.\n\nArguments:\n1. account (string, optional) The account to query the unconfirmed balance for (default=\"default\")\n\nResult:\nn.nnn (numeric) Total amount of all unmined unspent outputs of the account valued in bitcoin.\n",
+		"listaddresstransactions":  "listaddresstransactions [\"address\",...] (\"account\")\n\nReturns a JSON array of objects containing verbose details for wallet transactions pertaining some addresses.\n\nArguments:\n1. addresses (array of string, required) Addresses to filter transaction results by\n2. account   (string, optional)          Unused (must be unset or \"*\")\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Whether the transaction has at least the number of confirmations set by the trustedconfs option\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          The comment recorded when the transaction was sent, if any\n \"to\": \"value\",                    (string)          The comment about who the transaction was sent to, if any\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
+		"listalltransactions":      "listalltransactions (\"account\")\n\nReturns a JSON array of objects in the same format as 'listtransactions' without limiting the number of returned objects.\n\nArguments:\n1. account (string, optional) Unused (must be unset or \"*\")\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Whether the transaction has at least the number of confirmations set by the trustedconfs option\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          The comment recorded when the transaction was sent, if any\n \"to\": \"value\",                    (string)          The comment about who the transaction was sent to, if any\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
+		"walletislocked":           "walletislocked\n\nReturns whether or not the wallet is locked.\n\nArguments:\nNone\n\nResult:\ntrue|false (boolean) Whether the wallet is locked\n",
 	}
 }
 
@@ -56,4 +112,4 @@ var localeHelpDescs = map[string]func() map[string]string{
 	"en_US": helpDescsEnUS,
 }
 
-var requestUsages = "addmultisigaddress nrequired [\"key\",...]\ncreatemultisig nrequired [\"key\",...]\ncreatetransaction \"toaddress\" amount ([\"fromaddress\",...] electrumformat \"changeaddress\" inputminheight minconf=1 vote maxinputs \"autolock\" nosign)\ngetaddressbalances (minconf=1 showzerobalance)\nsetnetworkstewardvote (\"votefor\" \"voteagainst\")\ngetnetworkstewardvote\nresync (fromheight toheight [\"address\",...] dropdb)\nstopresync\naddp2shscript \"script\" segwit\ndumpprivkey \"address\"\ngetbalance (minconf=1)\ngetbestblockhash\ngetblockcount\ngetinfo\ngetnewaddress (legacy)\ngetreceivedbyaddress \"address\" (minconf=1)\ngettransaction \"txid\" (includewatchonly=false)\ngetwalletseed\ngetsecret \"name\"\nhelp (\"command\")\nimportprivkey \"privkey\" (\"label\" rescan=true legacy=false)\nlistlockunspent\nlistreceivedbyaddress (minconf=1 includeempty=false includewatchonly=false)\nlistsinceblock (\"blockhash\" targetconfirmations=1 includewatchonly=false)\nlisttransactions (count=10 from=0)\nlistunspent (minconf=1 maxconf=9999999 [\"address\",...])\nlockunspent unlock [{\"txid\":\"value\",\"vout\":n},...] (\"lockname\")\nsendfrom \"toaddress\" amount ([\"fromaddress\",...] minconf=1 \"comment\" \"commentto\" maxinputs minheight)\nsendmany {\"address\":amount,...} ([\"fromaddress\",...] minconf=1 \"comment\" maxinputs)\nsendtoaddress \"address\" amount (\"comment\" \"commentto\")\nsettxfee amount\nsignmessage \"address\" \"message\"\nsignrawtransaction \"rawtx\" ([{\"txid\":\"value\",\"vout\":n,\"scriptpubkey\":\"value\",\"redeemscript\":\"value\"},...] [\"privkey\",...] flags=\"ALL\")\nvalidateaddress \"address\"\nverifymessage \"address\" \"signature\" \"message\"\nwalletlock\nwalletpassphrase \"passphrase\" timeout\nwalletpassphrasechange \"oldpassphrase\" \"newpassphrase\"\nwalletmempool\nexportwatchingwallet (\"account\" download=false)\ngetbestblock\ngetunconfirmedbalance (\"account\")\nlistaddresstransactions [\"address\",...] (\"account\")\nlistalltransactions (\"account\")\nwalletislocked"
+var requestUsages = "addmultisigaddress nrequired [\"key\",...]\ncreatemultisig nrequired [\"key\",...]\ncreatetransaction \"toaddress\" amount ([\"fromaddress\",...] electrumformat \"changeaddress\" inputminheight minconf=1 vote maxinputs \"autolock\" nosign allowselfsend)\ngetaddressbalances (minconf=1 showzerobalance)\ngetaccountxpubs (account=0 slip132=false)\nlistaccounts (minconf=1)\ngettxproof \"txid\"\ngettxstatus \"txid\"\ngetmempoolancestors \"txid\"\nverifytxproof \"txid\" \"blockhash\" index [\"branch\",...]\nestimateconfirmationtime \"txid\"\nestimateconsolidation (\"feerate\")\nverifywallet\ngetbalanceatheight height\nverifypaymentrequest \"paymentrequest\"\ncreatenewaccount \"account\" (\"addresstype\")\ngetstoragestats\ngetrecoverystatus\nlistrejectedtx\nderiveaddresses \"seed\" count (addresstype=\"p2wpkh\" account=0)\nconvertaddress \"address\" \"addresstype\"\ngetfee \"txid\"\nbumpfee \"txid\"\nbumpfeecpfp \"txid\"\ngetbumpinfo \"txid\"\ngetaccountstats (starttime=0 endtime=0)\ngetfeesource\ngetfeestats (blocks=1000)\ngetutxoages\nexporttaxreport\nexportlabels\nimportlabels [{\"txid\":\"value\",\"label\":\"value\"},...] (overwrite=false)\ndumputxoset\ngetutxoinfo \"txid\" vout\nlistauxoutputs\nlistpendingtransactions\nsetnetworkstewardvote (\"votefor\" \"voteagainst\")\ngetnetworkstewardvote\nrescanaddress \"address\" (fromheight toheight)\nsetmaintenancemode enable\nresync (fromheight toheight [\"address\",...] dropdb)\nstopresync\ncancelrescan\npausesync\ngetpeerinfo\nresumesync\naddp2shscript \"script\" segwit\ndumpprivkey \"address\"\ngetbalance (minconf=1 verbose)\ngetbestblockhash\ngetblockcount\ngetinfo\ngetnewaddress (legacy \"account\" \"keyscope\")\ngetreceivedbyaddress \"address\" (minconf=1)\ngettransaction \"txid\" (includewatchonly=false)\ngetwalletseed\nbackupseedencrypted \"walletpassphrase\" \"passphrase\"\ngetsecret \"name\"\nhelp (\"command\")\nimportaddress \"address\" (rescan=true)\nimportprivkey \"privkey\" (\"label\" rescan=true legacy=false)\nlistlockunspent\nlistreceivedbyaddress (minconf=1 includeempty=false includewatchonly=false)\nlistsinceblock (\"blockhash\" targetconfirmations=1 includewatchonly=false)\nlisttransactions (count=10 from=0)\nlistunspent (minconf=1 maxconf=9999999 [\"address\",...])\nlockunspent unlock [{\"txid\":\"value\",\"vout\":n},...] (\"lockname\")\nmarkaddressused \"address\"\nmarkaddressunused \"address\"\nfreezeaddress \"address\"\nunfreezeaddress \"address\"\nlistfrozenaddresses\ngetchangeaddress\nsetchangeaddress (\"address\")\nsendfrom \"toaddress\" amount ([\"fromaddress\",...] minconf=1 \"comment\" \"commentto\" maxinputs minheight allowselfsend changeinfo)\nsendmany {\"address\":amount,...} ([\"fromaddress\",...] minconf=1 \"comment\" maxinputs allowselfsend changeinfo)\nsendmanydetailed {\"address\":amount,...} ([\"fromaddress\",...] minconf=1 maxinputs allowselfsend)\nsendfromutxos [{\"txid\":\"value\",\"vout\":n},...] {\"address\":amount,...} (\"feerate\" \"changeaddress\" \"comment\" allowselfsend)\nsendtoaddress \"address\" amount (\"comment\" \"commentto\" allowselfsend changeinfo)\nsettxfee amount\nsignmessage \"address\" \"message\"\nsignrawtransaction \"rawtx\" ([{\"txid\":\"value\",\"vout\":n,\"scriptpubkey\":\"value\",\"redeemscript\":\"value\"},...] [\"privkey\",...] flags=\"ALL\")\nsimulatesend {\"address\":amount,...} ([\"fromaddress\",...] minconf=1 maxinputs allowselfsend)\ngetcoinselectionprivacy {\"address\":amount,...} ([\"fromaddress\",...] minconf=1 maxinputs allowselfsend)\ngetblockfilter \"blockhash\"\nspendmax \"address\" ([\"fromaddress\",...] minconf=1 allowselfsend)\nexportaccountwatchonly (account=0)\nimportdescriptor {\"account\":\"value\",\"descriptors\":[{\"scope\":\"value\",\"addresstype\":\"value\",\"xpub\":\"value\",\"externalcount\":n,\"internalcount\":n},...]} (\"account\" rescan=true)\nvalidateaddress \"address\"\nverifymessage \"address\" \"signature\" \"message\"\nwalletlock\nwalletpassphrase \"passphrase\" timeout\nwalletpassphrasechange \"oldpassphrase\" \"newpassphrase\"\nrekeywallet \"passphrase\" (n=262144 r=8 p=1)\nwalletmempool\nexportwatchingwallet (\"account\" download=false)\ngetbestblock\ngetunconfirmedbalance (\"account\")\nlistaddresstransactions [\"address\",...] (\"account\")\nlistalltransactions (\"account\")\nwalletislocked"
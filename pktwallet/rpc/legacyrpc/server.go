@@ -5,6 +5,7 @@
 package legacyrpc
 
 import (
+	"compress/gzip"
 	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/base64"
@@ -13,6 +14,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -32,20 +34,24 @@ import (
 type websocketClient struct {
 	conn          *websocket.Conn
 	authenticated bool
+	user          string
 	remoteAddr    string
 	allRequests   chan []byte
 	responses     chan []byte
+	stopNtfns     chan struct{} // closed when no more requests are read
 	quit          chan struct{} // closed on disconnect
 	wg            sync.WaitGroup
 }
 
-func newWebsocketClient(c *websocket.Conn, authenticated bool, remoteAddr string) *websocketClient {
+func newWebsocketClient(c *websocket.Conn, authenticated bool, user, remoteAddr string) *websocketClient {
 	return &websocketClient{
 		conn:          c,
 		authenticated: authenticated,
+		user:          user,
 		remoteAddr:    remoteAddr,
 		allRequests:   make(chan []byte),
 		responses:     make(chan []byte),
+		stopNtfns:     make(chan struct{}),
 		quit:          make(chan struct{}),
 	}
 }
@@ -62,6 +68,11 @@ func (c *websocketClient) send(b []byte) er.R {
 // Server holds the items the RPC server may need to access (auth,
 // config, shutdown, etc.)
 type Server struct {
+	// ntfnsDropped is the number of notifications dropped because the
+	// queue of a websocket client was full.  It is accessed atomically and
+	// comes first so that it is 64-bit aligned.
+	ntfnsDropped uint64
+
 	httpServer   http.Server
 	wallet       *wallet.Wallet
 	walletLoader *wallet.Loader
@@ -70,11 +81,23 @@ type Server struct {
 
 	listeners []net.Listener
 	authsha   [sha256.Size]byte
+	plainAuth bool
+	rpcAuths  []*RPCAuth
 	upgrader  websocket.Upgrader
 
 	maxPostClients      int64 // Max concurrent HTTP POST clients.
 	maxWebsocketClients int64 // Max concurrent websocket clients.
 
+	waitForSync    bool
+	methodTimeouts map[string]time.Duration
+
+	notifyQueueSize int
+	notifyOverflow  NotifyOverflow
+
+	accessLog       io.Writer
+	accessLogMtx    sync.Mutex
+	accessLogClosed bool
+
 	wg      sync.WaitGroup
 	quit    chan struct{}
 	quitMtx sync.Mutex
@@ -105,10 +128,17 @@ func NewServer(opts *Options, walletLoader *wallet.Loader, listeners []net.Liste
 		walletLoader:        walletLoader,
 		maxPostClients:      opts.MaxPOSTClients,
 		maxWebsocketClients: opts.MaxWebsocketClients,
+		waitForSync:         opts.WaitForSync,
+		methodTimeouts:      opts.MethodTimeouts,
+		notifyQueueSize:     opts.NotifyQueueSize,
+		notifyOverflow:      opts.NotifyOverflow,
+		accessLog:           opts.AccessLog,
 		listeners:           listeners,
 		// A hash of the HTTP basic auth string is used for a constant
 		// time comparison.
-		authsha: sha256.Sum256(httpBasicAuth(opts.Username, opts.Password)),
+		authsha:   sha256.Sum256(httpBasicAuth(opts.Username, opts.Password)),
+		plainAuth: opts.Username != "" && opts.Password != "",
+		rpcAuths:  opts.RPCAuths,
 		upgrader: websocket.Upgrader{
 			// Allow all origins.
 			CheckOrigin: func(r *http.Request) bool { return true },
@@ -117,7 +147,7 @@ func NewServer(opts *Options, walletLoader *wallet.Loader, listeners []net.Liste
 		requestShutdownChan: make(chan struct{}, 1),
 	}
 
-	serveMux.Handle("/", throttledFn(opts.MaxPOSTClients,
+	postHandler := throttledFn(opts.MaxPOSTClients,
 		func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Connection", "close")
 			w.Header().Set("Content-Type", "application/json")
@@ -137,15 +167,21 @@ func NewServer(opts *Options, walletLoader *wallet.Loader, listeners []net.Liste
 			server.wg.Add(1)
 			server.postClientRPC(w, r)
 			server.wg.Done()
-		}))
+		})
+	if opts.Compress {
+		postHandler = gzipped(postHandler)
+	}
+	serveMux.Handle("/", postHandler)
 
 	serveMux.Handle("/ws", throttledFn(opts.MaxWebsocketClients,
 		func(w http.ResponseWriter, r *http.Request) {
 			authenticated := false
+			user := ""
 			err := server.checkAuthHeader(r)
 			if ErrNoAuth.Is(err) {
 			} else if err == nil {
 				authenticated = true
+				user, _, _ = r.BasicAuth()
 			} else {
 				// If auth was supplied but incorrect, rather than simply
 				// being missing, immediately terminate the connection.
@@ -161,7 +197,7 @@ func NewServer(opts *Options, walletLoader *wallet.Loader, listeners []net.Liste
 					r.RemoteAddr, er.E(errr))
 				return
 			}
-			wsc := newWebsocketClient(conn, authenticated, r.RemoteAddr)
+			wsc := newWebsocketClient(conn, authenticated, user, r.RemoteAddr)
 			server.websocketClientRPC(wsc)
 		}))
 
@@ -260,6 +296,8 @@ func (s *Server) Stop() {
 
 	// Wait for all remaining goroutines to exit.
 	s.wg.Wait()
+
+	s.closeAccessLog()
 }
 
 // SetChainServer sets the chain server client component needed to run a fully
@@ -290,7 +328,154 @@ func (s *Server) handlerClosure(request *btcjson.Request) lazyHandler {
 	}
 	s.handlerMu.Unlock()
 
-	return lazyApplyHandler(request, wallet, chainClient)
+	if s.waitForSync && wallet != nil {
+		if err := checkSynced(request.Method, wallet); err != nil {
+			return func() (interface{}, er.R) { return nil, err }
+		}
+	}
+	if wallet != nil {
+		if err := checkMaintenance(request.Method, wallet); err != nil {
+			return func() (interface{}, er.R) { return nil, err }
+		}
+	}
+	return withTimeout(request.Method, s.methodTimeouts[request.Method],
+		lazyApplyHandler(request, wallet, chainClient))
+}
+
+// fundsMovingMethods are the methods which spend, or sign spends of, the
+// wallet's outputs.  These are refused while the wallet is in maintenance
+// mode, all other methods are still answered.
+var fundsMovingMethods = map[string]struct{}{
+	"bumpfee":            {},
+	"bumpfeecpfp":        {},
+	"createtransaction":  {},
+	"sendfrom":           {},
+	"sendfromutxos":      {},
+	"sendmany":           {},
+	"sendmanydetailed":   {},
+	"sendrawtransaction": {},
+	"sendtoaddress":      {},
+	"signrawtransaction": {},
+	"spendmax":           {},
+}
+
+// maintenanceState reports whether the wallet is in maintenance mode, it is
+// implemented by *wallet.Wallet.
+type maintenanceState interface {
+	MaintenanceMode() bool
+}
+
+// checkMaintenance returns an error if method moves funds and the wallet is in
+// maintenance mode.
+func checkMaintenance(method string, m maintenanceState) er.R {
+	if _, ok := fundsMovingMethods[method]; !ok || !m.MaintenanceMode() {
+		return nil
+	}
+	return btcjson.ErrRPCWallet.New("the wallet is in maintenance mode, "+
+		"funds cannot be moved until it is disabled with "+
+		"setmaintenancemode false", nil)
+}
+
+// syncGatedMethods are the methods which report balances.  When the server
+// waits for sync these are refused until the wallet has synced, rather than
+// answered with balances which are missing recent transactions.
+var syncGatedMethods = map[string]struct{}{
+	"getaddressbalances":    {},
+	"getbalance":            {},
+	"getbalanceatheight":    {},
+	"getreceivedbyaddress":  {},
+	"getunconfirmedbalance": {},
+	"listaccounts":          {},
+	"listunspent":           {},
+}
+
+// syncState reports whether the wallet has synced to the tip of the chain, it
+// is implemented by *wallet.Wallet.
+type syncState interface {
+	ChainSynced() bool
+}
+
+// checkSynced returns an error if method reports balances and the wallet has
+// not yet synced to the tip of the chain.
+func checkSynced(method string, sync syncState) er.R {
+	if _, ok := syncGatedMethods[method]; !ok || sync.ChainSynced() {
+		return nil
+	}
+	return btcjson.ErrRPCClientInInitialDownload.New("the wallet is syncing "+
+		"with the chain, balances are not available until it has synced", nil)
+}
+
+// withTimeout returns a handler which runs f and answers with
+// btcjson.ErrRPCTimeout if it has not returned within timeout.  The quit
+// channel given to f is closed when the timeout passes so that f stops, a
+// handler which does not watch quit is abandoned and its result is discarded
+// when it returns.  If f returns an ndjsonStream, the stream is written under
+// the same timeout.  A timeout of zero leaves f unlimited, as do the
+// fundsMovingMethods, which would go on to send after the client was told that
+// they timed out.
+func withTimeout(method string, timeout time.Duration, f quitHandler) lazyHandler {
+	if _, ok := fundsMovingMethods[method]; ok || timeout <= 0 {
+		return func() (interface{}, er.R) { return f(nil) }
+	}
+	return func() (interface{}, er.R) {
+		type result struct {
+			res interface{}
+			err er.R
+		}
+		quit := make(chan struct{})
+		timer := time.AfterFunc(timeout, func() { close(quit) })
+		done := make(chan result, 1)
+		go func() {
+			res, err := f(quit)
+			done <- result{res, err}
+		}()
+		select {
+		case r := <-done:
+			stream, ok := r.res.(ndjsonStream)
+			if !ok || r.err != nil {
+				timer.Stop()
+				return r.res, r.err
+			}
+			return ndjsonStream(func(emit func(interface{}) er.R) er.R {
+				defer timer.Stop()
+				return stream(emit)
+			}), nil
+		case <-quit:
+			log.Warnf("RPC method [%s] timed out after %v", method, timeout)
+			return nil, btcjson.ErrRPCTimeout.New(fmt.Sprintf(
+				"%s timed out after %v", method, timeout), nil)
+		}
+	}
+}
+
+// ParseMethodTimeout parses a method timeout in the form method=duration, such
+// as resync=10m.  The method must be one which is handled by the wallet, and
+// not one of the fundsMovingMethods, and the duration must be positive.
+func ParseMethodTimeout(s string) (string, time.Duration, er.R) {
+	i := strings.Index(s, "=")
+	if i < 0 {
+		return "", 0, er.Errorf("rpcmethodtimeout [%s] is not in the "+
+			"form method=duration", s)
+	}
+	method := s[:i]
+	if _, ok := rpcHandlers[method]; !ok {
+		return "", 0, er.Errorf("rpcmethodtimeout [%s]: unknown method "+
+			"[%s]", s, method)
+	}
+	if _, ok := fundsMovingMethods[method]; ok {
+		return "", 0, er.Errorf("rpcmethodtimeout [%s]: [%s] moves funds "+
+			"and cannot be timed out, a timed out call would still "+
+			"complete after the client was told that it failed", s, method)
+	}
+	timeout, errr := time.ParseDuration(s[i+1:])
+	if errr != nil {
+		return "", 0, er.Errorf("rpcmethodtimeout [%s]: %v", s, errr)
+	}
+	if timeout <= 0 {
+		return "", 0, er.Errorf("rpcmethodtimeout [%s]: the timeout "+
+			"must be positive", s)
+	}
+	return method, timeout, nil
 }
 
 // ErrNoAuth represents an error where authentication could not succeed
@@ -312,10 +497,24 @@ func (s *Server) checkAuthHeader(r *http.Request) er.R {
 
 	authsha := sha256.Sum256([]byte(authhdr[0]))
 	cmp := subtle.ConstantTimeCompare(authsha[:], s.authsha[:])
-	if cmp != 1 {
-		return er.New("bad auth")
+	if cmp == 1 && s.plainAuth {
+		return nil
 	}
-	return nil
+	if user, pass, ok := r.BasicAuth(); ok && s.checkRPCAuth(user, pass) {
+		return nil
+	}
+	return er.New("bad auth")
+}
+
+// checkRPCAuth checks a username and password against the hashed rpcauth
+// credentials.
+func (s *Server) checkRPCAuth(username, password string) bool {
+	for _, a := range s.rpcAuths {
+		if a.Check(username, password) {
+			return true
+		}
+	}
+	return false
 }
 
 // throttledFn wraps an http.HandlerFunc with throttling of concurrent active
@@ -343,6 +542,58 @@ func throttled(threshold int64, h http.Handler) http.Handler {
 	})
 }
 
+// gzipResponseWriter is an http.ResponseWriter which compresses the body of
+// the response with gzip.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz *gzip.Writer
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	return w.gz.Write(b)
+}
+
+// acceptsGzip returns true if the client has said, by the Accept-Encoding
+// header, that it will accept a gzip encoded response.
+func acceptsGzip(r *http.Request) bool {
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		params := strings.Split(enc, ";")
+		if strings.TrimSpace(params[0]) != "gzip" {
+			continue
+		}
+		for _, p := range params[1:] {
+			p = strings.Replace(p, " ", "", -1)
+			if p == "q=0" || strings.HasPrefix(p, "q=0.") &&
+				strings.Trim(p[len("q=0."):], "0") == "" {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// gzipped wraps an http.Handler with gzip compression of the response for
+// clients which accept it.  Websocket connections must not be wrapped as
+// they hijack the underlying connection.
+func gzipped(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		defer func() {
+			if errr := gz.Close(); errr != nil {
+				log.Warnf("Unable to finish compressed response: %v", errr)
+			}
+		}()
+		h.ServeHTTP(&gzipResponseWriter{ResponseWriter: w, gz: gz}, r)
+	})
+}
+
 // idPointer returns a pointer to the passed ID, or nil if the interface is nil.
 // Interface pointers are usually a red flag of doing something incorrectly,
 // but this is only implemented here to work around an oddity with btcjson,
@@ -370,7 +621,22 @@ func (s *Server) invalidAuth(req *btcjson.Request) bool {
 	login := authCmd.Username + ":" + authCmd.Passphrase
 	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
 	authSha := sha256.Sum256([]byte(auth))
-	return subtle.ConstantTimeCompare(authSha[:], s.authsha[:]) != 1
+	if subtle.ConstantTimeCompare(authSha[:], s.authsha[:]) == 1 && s.plainAuth {
+		return false
+	}
+	return !s.checkRPCAuth(authCmd.Username, authCmd.Passphrase)
+}
+
+// authUsername returns the username of a websocket authenticate request.
+func authUsername(req *btcjson.Request) string {
+	cmd, err := btcjson.UnmarshalCmd(req)
+	if err != nil {
+		return ""
+	}
+	if authCmd, ok := cmd.(*btcjson.AuthenticateCmd); ok {
+		return authCmd.Username
+	}
+	return ""
 }
 
 func (s *Server) websocketClientRead(wsc *websocketClient) {
@@ -432,6 +698,7 @@ out:
 					break out
 				}
 				wsc.authenticated = true
+				wsc.user = authUsername(&req)
 				resp := makeResponse(req.ID, nil, nil)
 				// Expected to never fail.
 				mresp, errr := jsoniter.Marshal(resp)
@@ -467,10 +734,14 @@ out:
 
 			default:
 				req := req // Copy for the closure
-				f := s.handlerClosure(&req)
+				f := s.accessLogged(&req, wsc.user, wsc.remoteAddr,
+					s.handlerClosure(&req))
 				wsc.wg.Add(1)
 				go func() {
 					resp, jsonErr := f()
+					if stream, ok := resp.(ndjsonStream); ok && jsonErr == nil {
+						resp, jsonErr = collectNDJSON(stream)
+					}
 					mresp, err := btcjson.MarshalResponse(req.ID, resp, jsonErr)
 					if err != nil {
 						log.Errorf("Unable to marshal response: %v", err)
@@ -486,7 +757,9 @@ out:
 		}
 	}
 
-	// allow client to disconnect after all handler goroutines are done
+	// allow client to disconnect after all handler goroutines and the
+	// notification goroutine are done
+	close(wsc.stopNtfns)
 	wsc.wg.Wait()
 	close(wsc.responses)
 	s.wg.Done()
@@ -524,6 +797,132 @@ out:
 	s.wg.Done()
 }
 
+// NotificationsDropped returns the number of notifications which were not
+// sent because the notification queue of a websocket client was full, for all
+// clients.  The notifications dropped for the clients of a wallet are counted
+// in its NotificationsDropped stat.
+func (s *Server) NotificationsDropped() uint64 {
+	return atomic.LoadUint64(&s.ntfnsDropped)
+}
+
+// queueNotification queues a notification for a websocket client, applying
+// the overflow policy if its queue is full.  It returns false if the client
+// must be disconnected.
+func (s *Server) queueNotification(q *ntfnQueue, ntfn []byte) bool {
+	dropOldest := s.notifyOverflow == NotifyDropOldest
+	if q.push(ntfn, dropOldest) {
+		return true
+	}
+	atomic.AddUint64(&s.ntfnsDropped, 1)
+	return dropOldest
+}
+
+// websocketClientNotify sends notifications of changes to the wallet to a
+// websocket client until the client stops making requests.  Currently only
+// txconflicted notifications are sent, for unmined transactions which were
+// removed because they conflict with a mined transaction, and coinbasematured
+// notifications, for coinbase outputs which became spendable.
+func (s *Server) websocketClientNotify(wsc *websocketClient, w *wallet.Wallet) {
+	ntfns := w.NtfnServer.TransactionNotifications()
+	defer ntfns.Done()
+	s.notifyClient(wsc, ntfns.C, w.UpdateStats)
+	wsc.wg.Done()
+}
+
+// notifyClient sends the notifications for the wallet changes received from
+// ntfns to a websocket client until the client stops making requests.
+//
+// Notifications are queued for the client so that a client which is slow to
+// read them does not hold up the wallet.  When the queue is full the oldest
+// notification is dropped, or the client is disconnected, as notifyOverflow
+// says.  Notifications dropped for the client are added to the
+// NotificationsDropped wallet stat with updateStats.
+func (s *Server) notifyClient(wsc *websocketClient,
+	ntfns <-chan *wallet.TransactionNotifications,
+	updateStats func(func(ws *btcjson.WalletStats))) {
+
+	queue := newNtfnQueue(s.notifyQueueSize)
+	wsc.wg.Add(1)
+	go s.websocketClientNotifySend(wsc, queue)
+
+	queueNtfn := func(ntfn interface{}) bool {
+		mntfn, err := btcjson.MarshalCmd(nil, ntfn)
+		if err != nil {
+			log.Errorf("Unable to marshal notification: %v", err)
+			return true
+		}
+		if s.queueNotification(queue, mntfn) {
+			return true
+		}
+		log.Warnf("Disconnecting websocket client %s which has not read "+
+			"its last %d notifications", wsc.remoteAddr, queue.len())
+		if err := wsc.conn.Close(); err != nil {
+			log.Warnf("Cannot close websocket client %s: %v",
+				wsc.remoteAddr, err)
+		}
+		return false
+	}
+	var dropped uint64
+	noteDropped := func() {
+		d := queue.droppedCount()
+		if d == dropped {
+			return
+		}
+		updateStats(func(ws *btcjson.WalletStats) {
+			ws.NotificationsDropped += d - dropped
+		})
+		if s.notifyOverflow == NotifyDropOldest {
+			log.Warnf("Dropped %d notifications for slow websocket "+
+				"client %s, %d dropped for it in total", d-dropped,
+				wsc.remoteAddr, d)
+		}
+		dropped = d
+	}
+	defer noteDropped()
+out:
+	for {
+		select {
+		case n := <-ntfns:
+			for _, c := range n.ConflictedTransactions {
+				ntfn := btcjson.NewTxConflictedNtfn(c.Hash.String(),
+					c.ConflictedBy.String())
+				if !queueNtfn(ntfn) {
+					break out
+				}
+			}
+			for _, m := range n.MaturedCoinbaseOutputs {
+				ntfn := btcjson.NewCoinbaseMaturedNtfn(m.OutPoint.Hash.String(),
+					m.OutPoint.Index, m.Amount.ToBTC())
+				if !queueNtfn(ntfn) {
+					break out
+				}
+			}
+			noteDropped()
+
+		case <-wsc.stopNtfns:
+			break out
+		}
+	}
+}
+
+// websocketClientNotifySend sends the notifications queued for a websocket
+// client until the client stops making requests.
+func (s *Server) websocketClientNotifySend(wsc *websocketClient, queue *ntfnQueue) {
+	defer wsc.wg.Done()
+	for {
+		select {
+		case <-queue.ready:
+		case <-wsc.stopNtfns:
+			return
+		}
+		for ntfn := queue.pop(); ntfn != nil; ntfn = queue.pop() {
+			if err := wsc.send(ntfn); err != nil {
+				return
+			}
+		}
+	}
+}
+
 // websocketClientRPC starts the goroutines to serve JSON-RPC requests over a
 // websocket connection for a single client.
 func (s *Server) websocketClientRPC(wsc *websocketClient) {
@@ -535,6 +934,18 @@ func (s *Server) websocketClientRPC(wsc *websocketClient) {
 		log.Warnf("Cannot remove read deadline: %v", err)
 	}
 
+	// Notifications are only sent for a wallet which is loaded when the
+	// client connects.  The notifier is added to the waitgroup before the
+	// read loop starts, as the read loop closes the responses once the
+	// waitgroup is done.
+	s.handlerMu.Lock()
+	w := s.wallet
+	s.handlerMu.Unlock()
+	if w != nil {
+		wsc.wg.Add(1)
+		go s.websocketClientNotify(wsc, w)
+	}
+
 	// WebsocketClientRead is intentionally not run with the waitgroup
 	// so it is ignored during shutdown.  This is to prevent a hang during
 	// shutdown where the goroutine is blocked on a read of the
@@ -599,7 +1010,14 @@ func (s *Server) postClientRPC(w http.ResponseWriter, r *http.Request) {
 		stop = true
 		res = "pktwallet stopping"
 	default:
-		res, jsonErr = s.handlerClosure(&req)()
+		user, _, _ := r.BasicAuth()
+		res, jsonErr = s.accessLogged(&req, user, r.RemoteAddr,
+			s.handlerClosure(&req))()
+	}
+
+	if stream, ok := res.(ndjsonStream); ok && jsonErr == nil {
+		writeNDJSON(w, stream)
+		return
 	}
 
 	// Marshal and send.
@@ -619,6 +1037,53 @@ func (s *Server) postClientRPC(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ndjsonStream is the result of a handler whose result may be too large to
+// build in memory.  It is called with a function which writes out one value at
+// a time, HTTP POST clients receive each value as a line of JSON (NDJSON)
+// rather than a JSON-RPC response.
+type ndjsonStream func(emit func(interface{}) er.R) er.R
+
+// writeNDJSON writes each value of the stream to w as a line of JSON.  The
+// response status has been sent by the time that the stream fails so a final
+// line holding only an error field is written instead.
+func writeNDJSON(w http.ResponseWriter, stream ndjsonStream) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	err := stream(func(v interface{}) er.R {
+		b, errr := jsoniter.Marshal(v)
+		if errr != nil {
+			return er.E(errr)
+		}
+		if _, errr := w.Write(append(b, '\n')); errr != nil {
+			return er.E(errr)
+		}
+		return nil
+	})
+	if err == nil {
+		return
+	}
+	log.Warnf("Unable to stream response to client: %v", err)
+	b, errr := jsoniter.Marshal(struct {
+		Error string `json:"error"`
+	}{err.Message()})
+	if errr == nil {
+		_, _ = w.Write(append(b, '\n'))
+	}
+}
+
+// collectNDJSON gathers the values of a stream into a slice for websocket
+// clients, which can only be sent a single response.
+func collectNDJSON(stream ndjsonStream) (interface{}, er.R) {
+	values := make([]interface{}, 0)
+	err := stream(func(v interface{}) er.R {
+		values = append(values, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
 func (s *Server) requestProcessShutdown() {
 	select {
 	case s.requestShutdownChan <- struct{}{}:
@@ -72,7 +72,7 @@ func generateRPCKeyPair(writeKey bool) (tls.Certificate, er.R) {
 	// Generate cert pair.
 	org := "pktwallet autogenerated cert"
 	validUntil := time.Now().Add(time.Hour * 24 * 365 * 10)
-	cert, key, err := btcutil.NewTLSCertPair(org, validUntil, nil)
+	cert, key, err := btcutil.NewTLSCertPair(org, validUntil, cfg.TLSExtraHosts)
 	if err != nil {
 		return tls.Certificate{}, err
 	}
@@ -155,8 +155,17 @@ func startRPCServers(walletLoader *wallet.Loader) (*grpc.Server, *legacyrpc.Serv
 		}
 	}
 
-	if cfg.Username == "" || cfg.Password == "" {
-		log.Info("Legacy RPC server disabled (requires username and password)")
+	var rpcAuths []*legacyrpc.RPCAuth
+	for _, s := range cfg.RPCAuth {
+		a, err := legacyrpc.ParseRPCAuth(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		rpcAuths = append(rpcAuths, a)
+	}
+
+	if (cfg.Username == "" || cfg.Password == "") && len(rpcAuths) == 0 {
+		log.Info("Legacy RPC server disabled (requires username and password or rpcauth)")
 	} else if len(cfg.LegacyRPCListeners) != 0 {
 		listeners := makeListeners(cfg.LegacyRPCListeners, legacyListen)
 		if len(listeners) == 0 {
@@ -166,8 +175,26 @@ func startRPCServers(walletLoader *wallet.Loader) (*grpc.Server, *legacyrpc.Serv
 		opts := legacyrpc.Options{
 			Username:            cfg.Username,
 			Password:            cfg.Password,
+			RPCAuths:            rpcAuths,
 			MaxPOSTClients:      cfg.LegacyRPCMaxClients,
 			MaxWebsocketClients: cfg.LegacyRPCMaxWebsockets,
+			Compress:            cfg.LegacyRPCCompress,
+			WaitForSync:         cfg.WaitForSync,
+			MethodTimeouts:      cfg.rpcMethodTimeouts,
+			NotifyQueueSize:     cfg.NotifyQueueSize,
+			NotifyOverflow:      cfg.notifyOverflow,
+		}
+		switch cfg.RPCAccessLog {
+		case "":
+		case "log":
+			opts.AccessLog = logWriter{}
+		default:
+			f, errr := os.OpenFile(cfg.RPCAccessLog,
+				os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+			if errr != nil {
+				return nil, nil, er.E(errr)
+			}
+			opts.AccessLog = f
 		}
 		legacyServer = legacyrpc.NewServer(&opts, walletLoader, listeners)
 	}
@@ -180,6 +207,14 @@ func startRPCServers(walletLoader *wallet.Loader) (*grpc.Server, *legacyrpc.Serv
 	return server, legacyServer, nil
 }
 
+// logWriter writes the lines written to it to the main log.
+type logWriter struct{}
+
+func (logWriter) Write(p []byte) (int, error) {
+	log.Infof("RPC %s", strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
 type listenFunc func(net string, laddr string) (net.Listener, er.R)
 
 // makeListeners splits the normalized listen addresses into IPv4 and IPv6
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkt-cash/pktd/pktwallet/internal/cfgutil"
+)
+
+// TestGenerateRPCKeyPairExtraHosts checks that the hosts of --tlsextrahost are
+// among the names of the generated certificate.
+func TestGenerateRPCKeyPairExtraHosts(t *testing.T) {
+	dir, errr := ioutil.TempDir("", "rpccert")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(old *config) { cfg = old }(cfg)
+	cfg = &config{
+		RPCCert:       cfgutil.NewExplicitString(filepath.Join(dir, "rpc.cert")),
+		RPCKey:        cfgutil.NewExplicitString(filepath.Join(dir, "rpc.key")),
+		TLSExtraHosts: []string{"wallet.example.com", "192.0.2.7"},
+	}
+	keyPair, err := generateRPCKeyPair(true)
+	if err != nil {
+		t.Fatalf("unable to generate key pair: %v", err)
+	}
+	cert, errr := x509.ParseCertificate(keyPair.Certificate[0])
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	if err := cert.VerifyHostname("wallet.example.com"); err != nil {
+		t.Fatalf("extra hostname not in certificate: %v", err)
+	}
+	if err := cert.VerifyHostname("192.0.2.7"); err != nil {
+		t.Fatalf("extra IP address not in certificate: %v", err)
+	}
+	if err := cert.VerifyHostname("localhost"); err != nil {
+		t.Fatalf("localhost not in certificate: %v", err)
+	}
+	if err := cert.VerifyHostname("other.example.com"); err == nil {
+		t.Fatalf("certificate valid for a host which was not added")
+	}
+}
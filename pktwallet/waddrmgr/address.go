@@ -13,6 +13,7 @@ import (
 	"github.com/pkt-cash/pktd/btcutil"
 	"github.com/pkt-cash/pktd/btcutil/er"
 	"github.com/pkt-cash/pktd/btcutil/hdkeychain"
+	"github.com/pkt-cash/pktd/chaincfg"
 	"github.com/pkt-cash/pktd/pktwallet/internal/zero"
 	"github.com/pkt-cash/pktd/pktwallet/walletdb"
 	"github.com/pkt-cash/pktd/txscript"
@@ -52,6 +53,11 @@ const (
 
 	// WitnessScript represents a p2wsh (pay-to-witness-script-hash) address.
 	WitnessScript
+
+	// TaprootPubKey represents a p2tr (pay-to-taproot) address of a key with
+	// no script tree, as in BIP0086. Derived keys are the internal key of
+	// the output while imported keys are the output key itself.
+	TaprootPubKey
 )
 
 // ManagedAddress is an interface that provides acces to information regarding
@@ -343,20 +349,10 @@ func (a *managedAddress) DerivationInfo() (KeyScope, DerivationPath, bool) {
 	return a.manager.Scope(), a.derivationPath, true
 }
 
-// newManagedAddressWithoutPrivKey returns a new managed address based on the
-// passed account, public key, and whether or not the public key should be
-// compressed.
-func newManagedAddressWithoutPrivKey(m *ScopedKeyManager,
-	derivationPath DerivationPath, pubKey *btcec.PublicKey, compressed bool,
-	addrType AddressType) (*managedAddress, er.R) {
-
-	// Create a pay-to-pubkey-hash address from the public key.
-	var pubKeyHash []byte
-	if compressed {
-		pubKeyHash = btcutil.Hash160(pubKey.SerializeCompressed())
-	} else {
-		pubKeyHash = btcutil.Hash160(pubKey.SerializeUncompressed())
-	}
+// pubKeyHashAddress returns the address of the given type which pays to a
+// public key hash.
+func pubKeyHashAddress(pubKeyHash []byte, addrType AddressType,
+	params *chaincfg.Params) (btcutil.Address, er.R) {
 
 	var address btcutil.Address
 	var err er.R
@@ -371,7 +367,7 @@ func newManagedAddressWithoutPrivKey(m *ScopedKeyManager,
 
 		// First, we'll generate a normal p2wkh address from the pubkey hash.
 		witAddr, err := btcutil.NewAddressWitnessPubKeyHash(
-			pubKeyHash, m.rootManager.chainParams,
+			pubKeyHash, params,
 		)
 		if err != nil {
 			return nil, err
@@ -389,7 +385,7 @@ func newManagedAddressWithoutPrivKey(m *ScopedKeyManager,
 		// witnessProgram as the sigScript, then present the proper
 		// <sig, pubkey> pair as the witness.
 		address, err = btcutil.NewAddressScriptHash(
-			witnessProgram, m.rootManager.chainParams,
+			witnessProgram, params,
 		)
 		if err != nil {
 			return nil, err
@@ -397,7 +393,7 @@ func newManagedAddressWithoutPrivKey(m *ScopedKeyManager,
 
 	case PubKeyHash:
 		address, err = btcutil.NewAddressPubKeyHash(
-			pubKeyHash, m.rootManager.chainParams,
+			pubKeyHash, params,
 		)
 		if err != nil {
 			return nil, err
@@ -405,7 +401,7 @@ func newManagedAddressWithoutPrivKey(m *ScopedKeyManager,
 
 	case WitnessPubKey:
 		address, err = btcutil.NewAddressWitnessPubKeyHash(
-			pubKeyHash, m.rootManager.chainParams,
+			pubKeyHash, params,
 		)
 		if err != nil {
 			return nil, err
@@ -413,13 +409,55 @@ func newManagedAddressWithoutPrivKey(m *ScopedKeyManager,
 
 	case WitnessScript:
 		address, err = btcutil.NewAddressWitnessScriptHash(
-			pubKeyHash, m.rootManager.chainParams,
+			pubKeyHash, params,
 		)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	return address, nil
+}
+
+// pubKeyAddress returns the address of the given type which pays to a
+// public key, either by its hash or, for taproot, as the internal key of the
+// output.
+func pubKeyAddress(pubKey *btcec.PublicKey, compressed bool,
+	addrType AddressType, params *chaincfg.Params) (btcutil.Address, er.R) {
+
+	if addrType == TaprootPubKey {
+		outputKey, err := txscript.ComputeTaprootKeyNoScript(pubKey)
+		if err != nil {
+			return nil, err
+		}
+		return btcutil.NewAddressTaproot(
+			outputKey.SerializeCompressed()[1:], params,
+		)
+	}
+
+	// Create a pay-to-pubkey-hash address from the public key.
+	var pubKeyHash []byte
+	if compressed {
+		pubKeyHash = btcutil.Hash160(pubKey.SerializeCompressed())
+	} else {
+		pubKeyHash = btcutil.Hash160(pubKey.SerializeUncompressed())
+	}
+	return pubKeyHashAddress(pubKeyHash, addrType, params)
+}
+
+// newManagedAddressWithoutPrivKey returns a new managed address based on the
+// passed account, public key, and whether or not the public key should be
+// compressed.
+func newManagedAddressWithoutPrivKey(m *ScopedKeyManager,
+	derivationPath DerivationPath, pubKey *btcec.PublicKey, compressed bool,
+	addrType AddressType) (*managedAddress, er.R) {
+
+	address, err := pubKeyAddress(pubKey, compressed, addrType,
+		m.rootManager.chainParams)
+	if err != nil {
+		return nil, err
+	}
+
 	return &managedAddress{
 		manager:          m,
 		address:          address,
@@ -434,6 +472,30 @@ func newManagedAddressWithoutPrivKey(m *ScopedKeyManager,
 	}, nil
 }
 
+// newManagedTaprootAddress returns a new managed address of an imported
+// taproot output key.  As the internal key is not known the address is watch
+// only.
+func newManagedTaprootAddress(m *ScopedKeyManager,
+	derivationPath DerivationPath, outputKey *btcec.PublicKey) (*managedAddress, er.R) {
+
+	address, err := btcutil.NewAddressTaproot(
+		outputKey.SerializeCompressed()[1:], m.rootManager.chainParams,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &managedAddress{
+		manager:        m,
+		address:        address,
+		derivationPath: derivationPath,
+		imported:       true,
+		addrType:       TaprootPubKey,
+		compressed:     true,
+		pubKey:         outputKey,
+	}, nil
+}
+
 // newManagedAddress returns a new managed address based on the passed account,
 // private key, and whether or not the public key is compressed.  The managed
 // address will have access to the private and public keys.
@@ -229,6 +229,13 @@ var (
 	// addresses hash if the address has been used or not.
 	usedAddrBucketName = []byte("usedaddrs")
 
+	// acctAddrScopeBucketName is the name of the bucket which stores the
+	// key scope, and so the address type, of new addresses for accounts
+	// which have a default set.
+	//
+	// account number => scope
+	acctAddrScopeBucketName = []byte("acctaddrscope")
+
 	// meta is used to store meta-data about the address manager
 	// e.g. last account number
 	metaBucketName = []byte("meta")
@@ -1505,6 +1512,28 @@ func markAddressUsed(ns walletdb.ReadWriteBucket, scope *KeyScope,
 	return nil
 }
 
+// markAddressUnused clears the used flag of the provided address id in the
+// database.
+func markAddressUnused(ns walletdb.ReadWriteBucket, scope *KeyScope,
+	addressID []byte) er.R {
+
+	scopedBucket, err := fetchWriteScopeBucket(ns, scope)
+	if err != nil {
+		return err
+	}
+
+	bucket := scopedBucket.NestedReadWriteBucket(usedAddrBucketName)
+
+	addrHash := sha256.Sum256(addressID)
+	err = bucket.Delete(addrHash[:])
+	if err != nil {
+		str := fmt.Sprintf("failed to mark address unused %x", addressID)
+		return managerError(ErrDatabase, str, err)
+	}
+
+	return nil
+}
+
 // fetchAddress loads address information for the provided address id from the
 // database.  The returned value is one of the address rows for the specific
 // address type.  The caller should use type assertions to ascertain the type.
@@ -2213,6 +2242,45 @@ func putBirthdayBlockVerification(ns walletdb.ReadWriteBucket, verified bool) er
 	return nil
 }
 
+// putAccountAddrScope stores the key scope of new addresses for an account.
+func putAccountAddrScope(ns walletdb.ReadWriteBucket, account uint32,
+	scope *KeyScope) er.R {
+
+	bucket, err := ns.CreateBucketIfNotExists(acctAddrScopeBucketName)
+	if err != nil {
+		str := "failed to create account address scope bucket"
+		return managerError(ErrDatabase, str, err)
+	}
+	scopeBytes := scopeToBytes(scope)
+	if err := bucket.Put(uint32ToBytes(account), scopeBytes[:]); err != nil {
+		str := fmt.Sprintf("failed to store address scope of account %d",
+			account)
+		return managerError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// fetchAccountAddrScope returns the key scope of new addresses for an account,
+// or nil if the account has no default.
+func fetchAccountAddrScope(ns walletdb.ReadBucket, account uint32) (*KeyScope, er.R) {
+	bucket := ns.NestedReadBucket(acctAddrScopeBucketName)
+	if bucket == nil {
+		return nil, nil
+	}
+	scopeBytes := bucket.Get(uint32ToBytes(account))
+	if scopeBytes == nil {
+		return nil, nil
+	}
+	if len(scopeBytes) != scopeKeySize {
+		str := fmt.Sprintf("malformed address scope of account %d", account)
+		return nil, managerError(ErrDatabase, str, nil)
+	}
+	return &KeyScope{
+		Purpose: binary.LittleEndian.Uint32(scopeBytes[:]),
+		Coin:    binary.LittleEndian.Uint32(scopeBytes[4:]),
+	}, nil
+}
+
 // managerExists returns whether or not the manager has already been created
 // in the given database namespace.
 func managerExists(ns walletdb.ReadBucket) bool {
@@ -0,0 +1,80 @@
+package waddrmgr
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/btcutil/hdkeychain"
+	"github.com/pkt-cash/pktd/chaincfg"
+)
+
+// DerivedAddress is an address derived from a seed by DeriveAddresses.
+type DerivedAddress struct {
+	// Path is the derivation path of the address in the form
+	// m/<purpose>'/<coin type>'/<account>'/<branch>/<index>.
+	Path string
+
+	// Address is the encoded address.
+	Address string
+
+	// PubKey is the hex encoded compressed public key of the address.
+	PubKey string
+}
+
+// DeriveAddresses derives the first count external addresses of an account in
+// a key scope from a seed, in the same way as the address manager does for a
+// wallet created with the seed, without the need of a wallet.  The addresses
+// are of the external address type of the scope, which must be one of the
+// scopes of ScopeAddrMap.
+func DeriveAddresses(seed []byte, scope KeyScope, account, count uint32,
+	params *chaincfg.Params) ([]DerivedAddress, er.R) {
+
+	schema, ok := ScopeAddrMap[scope]
+	if !ok {
+		return nil, managerError(ErrScopeNotFound,
+			fmt.Sprintf("scope %v has no known address schema", scope), nil)
+	}
+	rootKey, err := hdkeychain.NewMaster(seed, params)
+	if err != nil {
+		return nil, managerError(ErrKeyChain,
+			"failed to derive master extended key", err)
+	}
+	coinTypeKey, err := deriveCoinTypeKey(rootKey, scope)
+	if err != nil {
+		return nil, err
+	}
+	acctKey, err := deriveAccountKey(coinTypeKey, account)
+	if err != nil {
+		return nil, err
+	}
+	branchKey, err := acctKey.DeriveNonStandard(ExternalBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]DerivedAddress, 0, count)
+	for i := uint32(0); i < count; i++ {
+		key, err := branchKey.DeriveNonStandard(i)
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := key.ECPubKey()
+		if err != nil {
+			return nil, err
+		}
+		pubKeyBytes := pubKey.SerializeCompressed()
+		addr, err := pubKeyAddress(pubKey, true, schema.ExternalAddrType,
+			params)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, DerivedAddress{
+			Path: fmt.Sprintf("m/%d'/%d'/%d'/%d/%d", scope.Purpose,
+				scope.Coin, account, ExternalBranch, i),
+			Address: addr.EncodeAddress(),
+			PubKey:  hex.EncodeToString(pubKeyBytes),
+		})
+	}
+	return addrs, nil
+}
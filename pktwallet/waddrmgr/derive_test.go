@@ -0,0 +1,65 @@
+package waddrmgr
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/pkt-cash/pktd/chaincfg"
+)
+
+// TestDeriveAddresses checks the derived addresses against the BIP0044,
+// BIP0049, BIP0084 and BIP0086 test vectors for the mnemonic "abandon abandon abandon
+// abandon abandon abandon abandon abandon abandon abandon abandon about".
+func TestDeriveAddresses(t *testing.T) {
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453" +
+		"ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea66" +
+		"90f20ad3d8d48b2d2ce9e38e4")
+
+	tests := []struct {
+		scope KeyScope
+		addrs []string
+	}{
+		{KeyScopeBIP0044, []string{
+			"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA",
+			"1Ak8PffB2meyfYnbXZR9EGfLfFZVpzJvQP",
+		}},
+		{KeyScopeBIP0049Plus, []string{
+			"37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf",
+			"3LtMnn87fqUeHBUG414p9CWwnoV6E2pNKS",
+		}},
+		{KeyScopeBIP0084, []string{
+			"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+			"bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g",
+		}},
+		{KeyScopeBIP0086, []string{
+			"bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr",
+			"bc1p4qhjn9zdvkux4e44uhx8tc55attvtyu358kutcqkudyccelu0was9fqzwh",
+		}},
+	}
+	for _, test := range tests {
+		addrs, err := DeriveAddresses(seed, test.scope, 0,
+			uint32(len(test.addrs)), &chaincfg.MainNetParams)
+		if err != nil {
+			t.Fatalf("%v: unable to derive addresses: %v", test.scope, err)
+		}
+		if len(addrs) != len(test.addrs) {
+			t.Fatalf("%v: got %d addresses, want %d", test.scope,
+				len(addrs), len(test.addrs))
+		}
+		for i, addr := range addrs {
+			if addr.Address != test.addrs[i] {
+				t.Fatalf("%v: got address %d %s, want %s", test.scope, i,
+					addr.Address, test.addrs[i])
+			}
+		}
+	}
+
+	addrs, _ := DeriveAddresses(seed, KeyScopeBIP0084, 0, 1,
+		&chaincfg.MainNetParams)
+	if addrs[0].Path != "m/84'/0'/0'/0/0" {
+		t.Fatalf("got path %s, want m/84'/0'/0'/0/0", addrs[0].Path)
+	}
+	if addrs[0].PubKey != "0330d54fd0dd420a6e5f8d3624f5f3482cae350f79d5f0753bf5beef9c2d91af3c" {
+		t.Fatalf("got public key %s", addrs[0].PubKey)
+	}
+}
@@ -7,6 +7,7 @@ package waddrmgr
 import (
 	"crypto/rand"
 	"crypto/sha512"
+	"encoding/binary"
 	"fmt"
 	"sync"
 	"time"
@@ -173,6 +174,10 @@ type AccountProperties struct {
 	ExternalKeyCount uint32
 	InternalKeyCount uint32
 	ImportedKeyCount uint32
+
+	// AccountPubKey is the account's extended public key, it is nil for
+	// the imported account.
+	AccountPubKey *hdkeychain.ExtendedKey
 }
 
 // unlockDeriveInfo houses the information needed to derive a private key for a
@@ -545,6 +550,23 @@ func (m *Manager) NewScopedKeyManager(ns walletdb.ReadWriteBucket, scope KeyScop
 	return m.scopedManagers[scope], nil
 }
 
+// SetAccountAddrScope sets the key scope, and so the address type, of new
+// addresses for an account which does not specify one.
+func (m *Manager) SetAccountAddrScope(ns walletdb.ReadWriteBucket, account uint32,
+	scope KeyScope) er.R {
+
+	if _, err := m.FetchScopedKeyManager(scope); err != nil {
+		return err
+	}
+	return putAccountAddrScope(ns, account, &scope)
+}
+
+// AccountAddrScope returns the key scope of new addresses for an account, or
+// nil if no default has been set for the account.
+func (m *Manager) AccountAddrScope(ns walletdb.ReadBucket, account uint32) (*KeyScope, er.R) {
+	return fetchAccountAddrScope(ns, account)
+}
+
 // FetchScopedKeyManager attempts to fetch an active scoped manager according to
 // its registered scope. If the manger is found, then a nil error is returned
 // along with the active scoped manager. Otherwise, a nil manager and a non-nil
@@ -588,6 +610,43 @@ func (m *Manager) NeuterRootKey(ns walletdb.ReadWriteBucket) er.R {
 	return ns.NestedReadWriteBucket(mainBucketName).Delete(masterHDPrivName)
 }
 
+// MasterFingerprint returns the fingerprint of the master HD public key, the
+// first four bytes of the hash160 of the key as a big endian number, which
+// identifies the root of the derivation paths of the wallet's keys for
+// external signers.  The master HD public key is not secret so the manager
+// does not need to be unlocked.
+func (m *Manager) MasterFingerprint(ns walletdb.ReadBucket) (uint32, er.R) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	_, masterRootPubEnc, err := fetchMasterHDKeys(ns)
+	if err != nil {
+		return 0, err
+	}
+	if masterRootPubEnc == nil {
+		str := "the master HD public key is not known"
+		return 0, managerError(ErrWatchingOnly, str, nil)
+	}
+	masterRootPub, err := m.cryptoKeyPub.Decrypt(masterRootPubEnc)
+	if err != nil {
+		str := "failed to decrypt master HD public key"
+		return 0, managerError(ErrCrypto, str, err)
+	}
+	rootKey, err := hdkeychain.NewKeyFromString(string(masterRootPub))
+	zero.Bytes(masterRootPub)
+	if err != nil {
+		str := "failed to parse master HD public key"
+		return 0, managerError(ErrKeyChain, str, err)
+	}
+	pubKey, err := rootKey.ECPubKey()
+	if err != nil {
+		str := "failed to get master public key"
+		return 0, managerError(ErrKeyChain, str, err)
+	}
+	fingerprint := btcutil.Hash160(pubKey.SerializeCompressed())[:4]
+	return binary.BigEndian.Uint32(fingerprint), nil
+}
+
 // Address returns a managed address given the passed address if it is known to
 // the address manager. A managed address differs from the passed address in
 // that it also potentially contains extra information needed to sign
@@ -642,6 +701,22 @@ func (m *Manager) MarkUsed(ns walletdb.ReadWriteBucket, address btcutil.Address)
 	return managerError(ErrAddressNotFound, str, nil)
 }
 
+// MarkUnused clears the used flag for the provided address.
+func (m *Manager) MarkUnused(ns walletdb.ReadWriteBucket, address btcutil.Address) er.R {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for _, scopedMgr := range m.scopedManagers {
+		if _, err := scopedMgr.Address(ns, address); err != nil {
+			continue
+		}
+		return scopedMgr.MarkUnused(ns, address)
+	}
+
+	str := fmt.Sprintf("unable to find key for addr %v", address)
+	return managerError(ErrAddressNotFound, str, nil)
+}
+
 // AddrAccount returns the account to which the given address belongs. We also
 // return the scoped manager that owns the addr+account combo.
 func (m *Manager) AddrAccount(ns walletdb.ReadBucket,
@@ -891,6 +966,16 @@ func (m *Manager) ChangePassphrase(ns walletdb.ReadWriteBucket, oldPassphrase,
 	return nil
 }
 
+// PrivateScryptOptions returns the scrypt parameters which the key securing
+// the private keys is derived from the private passphrase with.
+func (m *Manager) PrivateScryptOptions() ScryptOptions {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	params := m.masterKeyPriv.Parameters
+	return ScryptOptions{N: params.N, R: params.R, P: params.P}
+}
+
 // ConvertToWatchingOnly converts the current address manager to a locked
 // watching-only address manager.
 //
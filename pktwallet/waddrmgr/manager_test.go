@@ -2454,3 +2454,44 @@ func TestNewRawAccount(t *testing.T) {
 			accountTargetAddr.AddrHash())
 	}
 }
+
+// TestSlip132PubKeyVersion checks the SLIP-0132 version bytes selected for
+// each address type on networks with and without registered versions.
+func TestSlip132PubKeyVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		addrType AddressType
+		params   *chaincfg.Params
+		version  []byte
+		err      bool
+	}{
+		{"mainnet p2pkh", PubKeyHash, &chaincfg.MainNetParams,
+			[]byte{0x04, 0x88, 0xb2, 0x1e}, false},
+		{"mainnet p2sh-p2wpkh", NestedWitnessPubKey, &chaincfg.MainNetParams,
+			[]byte{0x04, 0x9d, 0x7c, 0xb2}, false},
+		{"mainnet p2wpkh", WitnessPubKey, &chaincfg.MainNetParams,
+			[]byte{0x04, 0xb2, 0x47, 0x46}, false},
+		{"testnet p2wpkh", WitnessPubKey, &chaincfg.TestNet3Params,
+			[]byte{0x04, 0x5f, 0x1c, 0xf6}, false},
+		{"pkt p2pkh", PubKeyHash, &chaincfg.PktMainNetParams,
+			chaincfg.PktMainNetParams.HDPublicKeyID[:], false},
+		{"pkt p2wpkh", WitnessPubKey, &chaincfg.PktMainNetParams, nil, true},
+	}
+	for _, test := range tests {
+		version, err := Slip132PubKeyVersion(test.addrType, test.params)
+		if test.err {
+			if err == nil {
+				t.Errorf("%s: expected error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(version, test.version) {
+			t.Errorf("%s: got version %x, want %x", test.name,
+				version, test.version)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package waddrmgr
 import (
 	"crypto/sha256"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/pkt-cash/pktd/btcutil/er"
@@ -73,6 +75,45 @@ func (k *KeyScope) String() string {
 	return fmt.Sprintf("m/%v'/%v'", k.Purpose, k.Coin)
 }
 
+// ParseKeyScope parses a key scope in the form purpose/cointype, such as
+// 84/390.  A leading m/ and a ' or h marking either component as hardened are
+// accepted, both are always hardened.  Each component must be below
+// hdkeychain.HardenedKeyStart.
+func ParseKeyScope(s string) (KeyScope, er.R) {
+	fields := strings.Split(strings.TrimPrefix(s, "m/"), "/")
+	if len(fields) != 2 {
+		return KeyScope{}, er.Errorf("key scope [%s] is not in the form "+
+			"purpose/cointype", s)
+	}
+	var components [2]uint32
+	for i, f := range fields {
+		if strings.HasSuffix(f, "'") || strings.HasSuffix(f, "h") {
+			f = f[:len(f)-1]
+		}
+		n, errr := strconv.ParseUint(f, 10, 32)
+		if errr != nil || n >= hdkeychain.HardenedKeyStart {
+			return KeyScope{}, er.Errorf("key scope [%s]: [%s] is not "+
+				"a number below %d", s, fields[i],
+				uint32(hdkeychain.HardenedKeyStart))
+		}
+		components[i] = uint32(n)
+	}
+	return KeyScope{Purpose: components[0], Coin: components[1]}, nil
+}
+
+// SchemaForScope returns the address schema of a scope which is not one of
+// ScopeAddrMap.  A scope with the purpose of one of the default scopes takes
+// its schema, so 44/390 derives p2pkh addresses, and any other purpose derives
+// p2wpkh addresses.
+func SchemaForScope(scope KeyScope) ScopeAddrSchema {
+	for _, s := range DefaultKeyScopes {
+		if s.Purpose == scope.Purpose {
+			return ScopeAddrMap[s]
+		}
+	}
+	return ScopeAddrMap[KeyScopeBIP0084]
+}
+
 // ScopeAddrSchema is the address schema of a particular KeyScope. This will be
 // persisted within the database, and will be consulted when deriving any keys
 // for a particular scope to know how to encode the public keys as addresses.
@@ -115,6 +156,14 @@ var (
 		Coin:    0,
 	}
 
+	// KeyScopeBIP0086 is the key scope for BIP0086 derivation of p2tr
+	// addresses. It is not a default scope as taproot outputs can only be
+	// watched and not yet spent, it is created when it is first used.
+	KeyScopeBIP0086 = KeyScope{
+		Purpose: 86,
+		Coin:    0,
+	}
+
 	// DefaultKeyScopes is the set of default key scopes that will be
 	// created by the root manager upon initial creation.
 	DefaultKeyScopes = []KeyScope{
@@ -139,6 +188,10 @@ var (
 			InternalAddrType: PubKeyHash,
 			ExternalAddrType: PubKeyHash,
 		},
+		KeyScopeBIP0086: {
+			ExternalAddrType: TaprootPubKey,
+			InternalAddrType: TaprootPubKey,
+		},
 	}
 )
 
@@ -544,6 +597,7 @@ func (s *ScopedKeyManager) AccountProperties(ns walletdb.ReadBucket,
 		props.AccountName = acctInfo.acctName
 		props.ExternalKeyCount = acctInfo.nextExternalIndex
 		props.InternalKeyCount = acctInfo.nextInternalIndex
+		props.AccountPubKey = acctInfo.acctKeyPub
 	} else {
 		props.AccountName = ImportedAddrAccountName // reserved, nonchangable
 
@@ -642,6 +696,11 @@ func (s *ScopedKeyManager) importedAddressRowToManaged(row *dbImportedAddressRow
 		Account: row.account,
 	}
 
+	// Imported taproot keys are output keys, imported from an address.
+	if s.addrSchema.ExternalAddrType == TaprootPubKey {
+		return newManagedTaprootAddress(s, derivationPath, pubKey)
+	}
+
 	compressed := len(pubBytes) == btcec.PubKeyBytesLenCompressed
 	ma, err := newManagedAddressWithoutPrivKey(
 		s, derivationPath, pubKey, compressed,
@@ -1370,6 +1429,58 @@ func (s *ScopedKeyManager) NewAccount(ns walletdb.ReadWriteBucket, name string)
 	return account, nil
 }
 
+// NewAccountWatchingOnly creates and returns a new account stored in the
+// manager from the given extended public key, so that its addresses may be
+// derived and watched without any private key.  This requires a watching-only
+// manager, since an account without a private key could not be unlocked along
+// with the others.  If an account with the same name already exists,
+// ErrDuplicateAccount will be returned.
+func (s *ScopedKeyManager) NewAccountWatchingOnly(ns walletdb.ReadWriteBucket,
+	name string, acctKeyPub *hdkeychain.ExtendedKey) (uint32, er.R) {
+
+	if !s.rootManager.WatchOnly() {
+		str := "watch-only accounts require a watching-only wallet"
+		return 0, managerError(ErrInvalidAccount, str, nil)
+	}
+	if acctKeyPub.IsPrivate() {
+		str := "account key is not an extended public key"
+		return 0, managerError(ErrInvalidKeyType, str, nil)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if err := ValidateAccountName(name); err != nil {
+		return 0, err
+	}
+	if _, err := s.lookupAccount(ns, name); err == nil {
+		str := "account with the same name already exists"
+		return 0, managerError(ErrDuplicateAccount, str, err)
+	}
+
+	account, err := fetchLastAccount(ns, &s.scope)
+	if err != nil {
+		return 0, err
+	}
+	account++
+
+	acctPubEnc, err := s.rootManager.cryptoKeyPub.Encrypt(
+		[]byte(acctKeyPub.String()),
+	)
+	if err != nil {
+		str := "failed to  encrypt public key for account"
+		return 0, managerError(ErrCrypto, str, err)
+	}
+	err = putAccountInfo(ns, &s.scope, account, acctPubEnc, nil, 0, 0, name)
+	if err != nil {
+		return 0, err
+	}
+	if err := putLastAccount(ns, &s.scope, account); err != nil {
+		return 0, err
+	}
+	return account, nil
+}
+
 // newAccount is a helper function that derives a new precise account number,
 // and creates a mapping from the passed name to the account number in the
 // database.
@@ -1540,6 +1651,14 @@ func (s *ScopedKeyManager) RenameAccount(ns walletdb.ReadWriteBucket,
 func (s *ScopedKeyManager) ImportPrivateKey(ns walletdb.ReadWriteBucket,
 	wif *btcutil.WIF, bs *BlockStamp) (ManagedPubKeyAddress, er.R) {
 
+	// Imported taproot keys are taken as output keys, which a private key
+	// is not.
+	if s.addrSchema.ExternalAddrType == TaprootPubKey {
+		str := fmt.Sprintf("private keys cannot be imported into the "+
+			"taproot scope %v", s.scope)
+		return nil, managerError(ErrInvalidKeyType, str, nil)
+	}
+
 	// Ensure the address is intended for network the address manager is
 	// associated with.
 	if !wif.IsForNet(s.rootManager.chainParams) {
@@ -1646,6 +1765,88 @@ func (s *ScopedKeyManager) ImportPrivateKey(ns walletdb.ReadWriteBucket,
 	return managedAddr, nil
 }
 
+// ImportTaprootAddress imports a p2tr address into the imported account of
+// the address manager, so that its outputs are tracked.  As only the output
+// key of the address is known, the address is watch only.
+//
+// This function will return an error if the address manager is not for the
+// taproot scope, or not for the same network as the address.  It will also
+// return an error if the address already exists.
+func (s *ScopedKeyManager) ImportTaprootAddress(ns walletdb.ReadWriteBucket,
+	addr *btcutil.AddressTaproot, bs *BlockStamp) (ManagedPubKeyAddress, er.R) {
+
+	if s.addrSchema.ExternalAddrType != TaprootPubKey {
+		str := fmt.Sprintf("scope %v is not a taproot scope", s.scope)
+		return nil, managerError(ErrInvalidKeyType, str, nil)
+	}
+	if !addr.IsForNet(s.rootManager.chainParams) {
+		str := fmt.Sprintf("address is not for the same network the "+
+			"address manager is configured for (%s)",
+			s.rootManager.chainParams.Name)
+		return nil, managerError(ErrWrongNet, str, nil)
+	}
+
+	// The output key is an x-only key, which is taken to have an even y
+	// coordinate.
+	outputKey := addr.WitnessProgram()
+	serializedPubKey := append([]byte{0x02}, outputKey...)
+	pubKey, err := btcec.ParsePubKey(serializedPubKey, btcec.S256())
+	if err != nil {
+		str := fmt.Sprintf("invalid taproot output key %x", outputKey)
+		return nil, managerError(ErrInvalidKeyType, str, err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.existsAddress(ns, outputKey) {
+		str := fmt.Sprintf("address for output key %x already exists",
+			outputKey)
+		return nil, managerError(ErrDuplicateAddress, str, nil)
+	}
+
+	encryptedPubKey, err := s.rootManager.cryptoKeyPub.Encrypt(
+		serializedPubKey,
+	)
+	if err != nil {
+		str := fmt.Sprintf("failed to encrypt output key %x", outputKey)
+		return nil, managerError(ErrCrypto, str, err)
+	}
+
+	// The start block needs to be updated when the newly imported address
+	// is before the current one.
+	s.rootManager.mtx.Lock()
+	updateStartBlock := bs.Height < s.rootManager.syncState.startBlock.Height
+	s.rootManager.mtx.Unlock()
+
+	err = putImportedAddress(
+		ns, &s.scope, outputKey, ImportedAddrAccount, ssNone,
+		encryptedPubKey, nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateStartBlock {
+		if err := putStartBlock(ns, bs); err != nil {
+			return nil, err
+		}
+		s.rootManager.mtx.Lock()
+		s.rootManager.syncState.startBlock = *bs
+		s.rootManager.mtx.Unlock()
+	}
+
+	managedAddr, err := newManagedTaprootAddress(s, DerivationPath{
+		Account: ImportedAddrAccount,
+	}, pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	s.addrs[addrKey(managedAddr.Address().ScriptAddress())] = managedAddr
+	return managedAddr, nil
+}
+
 func (s *ScopedKeyManager) ImportWitnessScript(ns walletdb.ReadWriteBucket,
 	script []byte, bs *BlockStamp) (ManagedScriptAddress, er.R) {
 
@@ -1896,6 +2097,23 @@ func (s *ScopedKeyManager) MarkUsed(ns walletdb.ReadWriteBucket,
 	return nil
 }
 
+// MarkUnused clears the used flag for the provided address.
+func (s *ScopedKeyManager) MarkUnused(ns walletdb.ReadWriteBucket,
+	address btcutil.Address) er.R {
+
+	addressID := address.ScriptAddress()
+	err := markAddressUnused(ns, &s.scope, addressID)
+	if err != nil {
+		return maybeConvertDbError(err)
+	}
+
+	// Clear caches which might have stale entries for the address
+	s.mtx.Lock()
+	delete(s.addrs, addrKey(addressID))
+	s.mtx.Unlock()
+	return nil
+}
+
 // ChainParams returns the chain parameters for this address manager.
 func (s *ScopedKeyManager) ChainParams() *chaincfg.Params {
 	// NOTE: No need for mutex here since the net field does not change
@@ -0,0 +1,73 @@
+package waddrmgr
+
+import (
+	"bytes"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/btcutil/hdkeychain"
+	"github.com/pkt-cash/pktd/chaincfg"
+)
+
+// SLIP-0132 registered extended public key version bytes, see
+// https://github.com/satoshilabs/slips/blob/master/slip-0132.md
+var (
+	slip132Xpub = []byte{0x04, 0x88, 0xb2, 0x1e} // xpub, p2pkh mainnet
+	slip132Ypub = []byte{0x04, 0x9d, 0x7c, 0xb2} // ypub, p2wpkh-in-p2sh mainnet
+	slip132Zpub = []byte{0x04, 0xb2, 0x47, 0x46} // zpub, p2wpkh mainnet
+	slip132Tpub = []byte{0x04, 0x35, 0x87, 0xcf} // tpub, p2pkh testnet
+	slip132Upub = []byte{0x04, 0x4a, 0x52, 0x62} // upub, p2wpkh-in-p2sh testnet
+	slip132Vpub = []byte{0x04, 0x5f, 0x1c, 0xf6} // vpub, p2wpkh testnet
+)
+
+// Slip132PubKeyVersion returns the SLIP-0132 extended public key version bytes
+// for keys which derive addresses of the given type.  Legacy p2pkh keys always
+// use the network's standard HD public key ID, segwit types are only defined
+// for networks which use the bitcoin mainnet or testnet HD key IDs and any
+// other network results in ErrWrongNet.
+func Slip132PubKeyVersion(addrType AddressType, params *chaincfg.Params) ([]byte, er.R) {
+	var testnet bool
+	switch {
+	case bytes.Equal(params.HDPublicKeyID[:], slip132Xpub):
+	case bytes.Equal(params.HDPublicKeyID[:], slip132Tpub):
+		testnet = true
+	default:
+		if addrType == PubKeyHash {
+			return params.HDPublicKeyID[:], nil
+		}
+		return nil, ErrWrongNet.New("no SLIP-0132 version bytes are registered "+
+			"for network "+params.Name, nil)
+	}
+
+	switch addrType {
+	case PubKeyHash:
+		return params.HDPublicKeyID[:], nil
+	case NestedWitnessPubKey:
+		if testnet {
+			return slip132Upub, nil
+		}
+		return slip132Ypub, nil
+	case WitnessPubKey:
+		if testnet {
+			return slip132Vpub, nil
+		}
+		return slip132Zpub, nil
+	}
+	return nil, ErrInvalidKeyType.New("no SLIP-0132 version bytes for "+
+		"address type", nil)
+}
+
+// Slip132PubKey re-encodes an extended public key with the SLIP-0132 version
+// bytes which correspond to the given address type.
+func Slip132PubKey(key *hdkeychain.ExtendedKey, addrType AddressType,
+	params *chaincfg.Params) (*hdkeychain.ExtendedKey, er.R) {
+
+	if key.IsPrivate() {
+		return nil, ErrInvalidKeyType.New("SLIP-0132 encoding is only "+
+			"supported for extended public keys", nil)
+	}
+	version, err := Slip132PubKeyVersion(addrType, params)
+	if err != nil {
+		return nil, err
+	}
+	return key.CloneWithVersion(version)
+}
@@ -0,0 +1,171 @@
+package wallet
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// AccountStats is the activity of an account over a time range.
+type AccountStats struct {
+	Scope         waddrmgr.KeyScope
+	AccountNumber uint32
+	AccountName   string
+
+	// Incoming is the number of transactions which pay the account
+	// without spending from it, Received is the total which they pay it.
+	Incoming int
+	Received btcutil.Amount
+
+	// Outgoing is the number of transactions which spend from the
+	// account, Sent is the total which left the account in them,
+	// including fees but not change paid back to the account.  If one
+	// of them pays the account more than it spends from it, as when it
+	// also spends outputs of others, the excess is counted as Received.
+	Outgoing int
+	Sent     btcutil.Amount
+}
+
+// AccountStats returns the activity of each account which sent or received
+// coins in a transaction received by the wallet between start and end.  A zero
+// start or end leaves the range open on that side.  Accounts are ordered by
+// scope and then by account number.
+func (w *Wallet) AccountStats(start, end time.Time) ([]AccountStats, er.R) {
+	type scopedAccount struct {
+		scope   waddrmgr.KeyScope
+		account uint32
+	}
+	type flow struct {
+		in, out btcutil.Amount
+	}
+	stats := make(map[scopedAccount]*AccountStats)
+	var out []AccountStats
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+
+		// account returns the wallet account which pkScript pays, ok is
+		// false if it pays none.
+		account := func(pkScript []byte) (scopedAccount, bool) {
+			_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript,
+				w.chainParams)
+			if err != nil || len(addrs) == 0 {
+				return scopedAccount{}, false
+			}
+			manager, acct, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
+			if err != nil {
+				return scopedAccount{}, false
+			}
+			return scopedAccount{manager.Scope(), acct}, true
+		}
+
+		err := w.TxStore.RangeTransactions(txmgrNs, 0, -1,
+			func(details []wtxmgr.TxDetails) (bool, er.R) {
+				for i := range details {
+					d := &details[i]
+					if !start.IsZero() && d.Received.Before(start) ||
+						!end.IsZero() && d.Received.After(end) {
+						continue
+					}
+					flows := make(map[scopedAccount]*flow)
+					flowOf := func(sa scopedAccount) *flow {
+						f, ok := flows[sa]
+						if !ok {
+							f = &flow{}
+							flows[sa] = f
+						}
+						return f
+					}
+					for _, deb := range d.Debits {
+						prevOut, err := w.spentOutput(txmgrNs,
+							&d.MsgTx.TxIn[deb.Index].PreviousOutPoint)
+						if err != nil {
+							return false, err
+						}
+						if prevOut == nil {
+							continue
+						}
+						if sa, ok := account(prevOut.PkScript); ok {
+							flowOf(sa).out += deb.Amount
+						}
+					}
+					for _, cred := range d.Credits {
+						txOut := d.MsgTx.TxOut[cred.Index]
+						if sa, ok := account(txOut.PkScript); ok {
+							flowOf(sa).in += cred.Amount
+						}
+					}
+
+					for sa, f := range flows {
+						s, ok := stats[sa]
+						if !ok {
+							s = &AccountStats{
+								Scope:         sa.scope,
+								AccountNumber: sa.account,
+							}
+							stats[sa] = s
+						}
+						if f.out > 0 {
+							s.Outgoing++
+							if f.out >= f.in {
+								s.Sent += f.out - f.in
+							} else {
+								s.Received += f.in - f.out
+							}
+						} else {
+							s.Incoming++
+							s.Received += f.in
+						}
+					}
+				}
+				return false, nil
+			})
+		if err != nil {
+			return err
+		}
+
+		for _, s := range stats {
+			manager, err := w.Manager.FetchScopedKeyManager(s.Scope)
+			if err != nil {
+				return err
+			}
+			s.AccountName, err = manager.AccountName(addrmgrNs, s.AccountNumber)
+			if err != nil {
+				return err
+			}
+			out = append(out, *s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i].Scope, out[j].Scope
+		if a != b {
+			return a.Purpose < b.Purpose ||
+				a.Purpose == b.Purpose && a.Coin < b.Coin
+		}
+		return out[i].AccountNumber < out[j].AccountNumber
+	})
+	return out, nil
+}
+
+// spentOutput returns the wallet output which is spent by an input, or nil if
+// the transaction which created it is not known to the wallet.
+func (w *Wallet) spentOutput(txmgrNs walletdb.ReadBucket,
+	prevOut *wire.OutPoint) (*wire.TxOut, er.R) {
+
+	prev, err := w.TxStore.TxDetails(txmgrNs, &prevOut.Hash)
+	if err != nil || prev == nil || int(prevOut.Index) >= len(prev.MsgTx.TxOut) {
+		return nil, err
+	}
+	return prev.MsgTx.TxOut[prevOut.Index], nil
+}
@@ -0,0 +1,137 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestAccountStats replays a history of payments to two accounts, a transfer
+// between them and a spend which pays an account more than it spends, and
+// checks the activity of each account over the whole history and over part of
+// it.
+func TestAccountStats(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	savings, err := w.NextAccount("savings", nil)
+	if err != nil {
+		t.Fatalf("unable to create account: %v", err)
+	}
+	pkScriptOf := func(account uint32) []byte {
+		addr, err := w.NewAddress(account, waddrmgr.KeyScopeBIP0084)
+		if err != nil {
+			t.Fatalf("unable to get new address: %v", err)
+		}
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pkScript
+	}
+	defaultScript := pkScriptOf(waddrmgr.DefaultAccountNum)
+	savingsScript := pkScriptOf(savings)
+
+	base := time.Unix(1600000000, 0)
+	blockTime := time.Unix(1387737310, 0)
+	day := 24 * time.Hour
+
+	// Both accounts are paid from outside the wallet.
+	toDefault := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, defaultScript)},
+	}
+	insertTestTxAt(t, w, toDefault, 90, base, blockTime, 0)
+	toSavings := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Index: 1}}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(5e7, savingsScript)},
+	}
+	insertTestTxAt(t, w, toSavings, 91, base.Add(day), blockTime, 0)
+
+	// The default account moves some of its coins to savings, paying a
+	// fee of 1000 and taking its change.
+	transfer := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: toDefault.TxHash()},
+		}},
+		TxOut: []*wire.TxOut{
+			wire.NewTxOut(3e7, savingsScript),
+			wire.NewTxOut(7e7-1000, defaultScript),
+		},
+	}
+	insertTestTxAt(t, w, transfer, 92, base.Add(2*day), blockTime, 0, 1)
+
+	// Savings spends its first payment along with an output from outside
+	// the wallet and is paid more back, which is counted as received.
+	join := &wire.MsgTx{
+		TxIn: []*wire.TxIn{
+			{PreviousOutPoint: wire.OutPoint{Hash: toSavings.TxHash()}},
+			{PreviousOutPoint: wire.OutPoint{Index: 2}},
+		},
+		TxOut: []*wire.TxOut{wire.NewTxOut(9e7, savingsScript)},
+	}
+	insertTestTxAt(t, w, join, 93, base.Add(3*day), blockTime, 0)
+
+	check := func(desc string, start, end time.Time, want []AccountStats) {
+		t.Helper()
+		stats, err := w.AccountStats(start, end)
+		if err != nil {
+			t.Fatalf("unable to get account stats: %v", err)
+		}
+		if len(stats) != len(want) {
+			t.Fatalf("%s: got stats of %d accounts, want %d: %+v", desc,
+				len(stats), len(want), stats)
+		}
+		for i, s := range stats {
+			want[i].Scope = waddrmgr.KeyScopeBIP0084
+			if s != want[i] {
+				t.Fatalf("%s: got stats %+v, want %+v", desc, s, want[i])
+			}
+		}
+	}
+
+	check("whole history", time.Time{}, time.Time{}, []AccountStats{{
+		AccountNumber: waddrmgr.DefaultAccountNum,
+		AccountName:   "default",
+		Incoming:      1,
+		Received:      1e8,
+		Outgoing:      1,
+		Sent:          3e7 + 1000,
+	}, {
+		AccountNumber: savings,
+		AccountName:   "savings",
+		Incoming:      2,
+		Received:      8e7 + 4e7,
+		Outgoing:      1,
+	}})
+
+	check("after the first payment", base.Add(day/2), time.Time{},
+		[]AccountStats{{
+			AccountNumber: waddrmgr.DefaultAccountNum,
+			AccountName:   "default",
+			Outgoing:      1,
+			Sent:          3e7 + 1000,
+		}, {
+			AccountNumber: savings,
+			AccountName:   "savings",
+			Incoming:      2,
+			Received:      8e7 + 4e7,
+			Outgoing:      1,
+		}})
+
+	check("first two days", time.Time{}, base.Add(day),
+		[]AccountStats{{
+			AccountNumber: waddrmgr.DefaultAccountNum,
+			AccountName:   "default",
+			Incoming:      1,
+			Received:      1e8,
+		}, {
+			AccountNumber: savings,
+			AccountName:   "savings",
+			Incoming:      1,
+			Received:      5e7,
+		}})
+}
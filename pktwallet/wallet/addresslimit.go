@@ -0,0 +1,34 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// ErrAddressLimit is returned when issuing an address would take an account
+// over MaxAddressesPerAccount.
+var ErrAddressLimit = Err.CodeWithDetail("ErrAddressLimit",
+	"account has issued the most addresses allowed")
+
+// checkAddressLimit returns ErrAddressLimit if the account has already issued
+// MaxAddressesPerAccount receiving addresses.
+func (w *Wallet) checkAddressLimit(manager *waddrmgr.ScopedKeyManager,
+	addrmgrNs walletdb.ReadBucket, account uint32) er.R {
+
+	if w.cfg.MaxAddressesPerAccount == 0 {
+		return nil
+	}
+	props, err := manager.AccountProperties(addrmgrNs, account)
+	if err != nil {
+		return err
+	}
+	if props.ExternalKeyCount >= w.cfg.MaxAddressesPerAccount {
+		return ErrAddressLimit.New(fmt.Sprintf("account [%s] has issued "+
+			"[%d] addresses, the limit set by maxaddressesperaccount",
+			props.AccountName, props.ExternalKeyCount), nil)
+	}
+	return nil
+}
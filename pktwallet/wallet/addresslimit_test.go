@@ -0,0 +1,32 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+)
+
+// TestMaxAddressesPerAccount checks that addresses are issued up to the limit
+// and that issuing one more is rejected.
+func TestMaxAddressesPerAccount(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.cfg.MaxAddressesPerAccount = 3
+
+	for i := 0; i < 3; i++ {
+		if _, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084); err != nil {
+			t.Fatalf("unable to issue address %d: %v", i, err)
+		}
+	}
+	_, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if !ErrAddressLimit.Is(err) {
+		t.Fatalf("got error %v issuing an address over the limit, want "+
+			"ErrAddressLimit", err)
+	}
+
+	// The limit is per account and key scope.
+	if _, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0044); err != nil {
+		t.Fatalf("unable to issue address of another scope: %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktlog/log"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+)
+
+// AccountNumberOrCreate returns the number of the account named name like
+// AccountNumber, but if there is no such account and AutoCreateAccounts is
+// set then the account is created in the default key scopes.
+func (w *Wallet) AccountNumberOrCreate(name string) (uint32, er.R) {
+	account, err := w.AccountNumber(name)
+	if !w.cfg.AutoCreateAccounts || !waddrmgr.ErrAccountNotFound.Is(err) {
+		return account, err
+	}
+	account, err = w.NextAccount(name, nil)
+	if waddrmgr.ErrDuplicateAccount.Is(err) {
+		// Created by a concurrent request.
+		return w.AccountNumber(name)
+	} else if err != nil {
+		return 0, err
+	}
+	log.Infof("Created account [%s] (number [%d]) on first use", name, account)
+	return account, nil
+}
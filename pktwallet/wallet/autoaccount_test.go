@@ -0,0 +1,49 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+)
+
+// TestAutoCreateAccounts checks that an unknown account name is an error
+// unless AutoCreateAccounts is set, in which case the account is created once
+// and then found.
+func TestAutoCreateAccounts(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.cfg.AutoCreateAccounts = false
+	if _, err := w.AccountNumberOrCreate("payroll"); !waddrmgr.ErrAccountNotFound.Is(err) {
+		t.Fatalf("got error %v referencing an unknown account, want "+
+			"ErrAccountNotFound", err)
+	}
+	if _, err := w.AccountNumber("payroll"); !waddrmgr.ErrAccountNotFound.Is(err) {
+		t.Fatalf("account was created with AutoCreateAccounts unset: %v", err)
+	}
+
+	w.cfg.AutoCreateAccounts = true
+	account, err := w.AccountNumberOrCreate("payroll")
+	if err != nil {
+		t.Fatalf("unable to create account on first use: %v", err)
+	}
+	if account == waddrmgr.DefaultAccountNum {
+		t.Fatalf("got the default account for a new account name")
+	}
+	if n, err := w.AccountNumber("payroll"); err != nil || n != account {
+		t.Fatalf("got account %d (%v) after creating it, want %d", n,
+			err, account)
+	}
+	if n, err := w.AccountNumberOrCreate("payroll"); err != nil || n != account {
+		t.Fatalf("got account %d (%v) referencing it again, want %d", n,
+			err, account)
+	}
+	if _, err := w.NewAddress(account, waddrmgr.KeyScopeBIP0084); err != nil {
+		t.Fatalf("unable to get address of created account: %v", err)
+	}
+	if n, err := w.AccountNumberOrCreate("default"); err != nil ||
+		n != waddrmgr.DefaultAccountNum {
+
+		t.Fatalf("got account %d (%v) for the default account", n, err)
+	}
+}
@@ -0,0 +1,71 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// AuxOutput is an output of a wallet transaction which carries no spendable
+// value, such as an OP_RETURN data output.  These are never credited to the
+// wallet so they do not count towards the balance or the unspent outputs but
+// they are kept with the transaction for auditing.
+type AuxOutput struct {
+	OutPoint wire.OutPoint
+
+	// Height is the height of the block which mined the transaction, or -1
+	// if it is unmined.
+	Height   int32
+	Amount   btcutil.Amount
+	PkScript []byte
+
+	// Data is the data pushed by an OP_RETURN script, or nil for other
+	// scripts.
+	Data []byte
+}
+
+// isAuxOutput returns true if the output has no value or can never be spent,
+// in which case it must not be credited to the wallet.
+func isAuxOutput(out *wire.TxOut) bool {
+	return out.Value == 0 || txscript.IsUnspendable(out.PkScript)
+}
+
+// AuxOutputs returns the zero value and unspendable outputs of the wallet's
+// transactions, mined transactions first in block order.
+func (w *Wallet) AuxOutputs() ([]AuxOutput, er.R) {
+	var aux []AuxOutput
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		return w.TxStore.RangeTransactions(txmgrNs, 0, -1,
+			func(details []wtxmgr.TxDetails) (bool, er.R) {
+				for i := range details {
+					d := &details[i]
+					for index, out := range d.MsgTx.TxOut {
+						if !isAuxOutput(out) {
+							continue
+						}
+						a := AuxOutput{
+							OutPoint: wire.OutPoint{Hash: d.Hash, Index: uint32(index)},
+							Height:   d.Block.Height,
+							Amount:   btcutil.Amount(out.Value),
+							PkScript: out.PkScript,
+						}
+						if txscript.GetScriptClass(out.PkScript) == txscript.NullDataTy {
+							pushes, err := txscript.PushedData(out.PkScript)
+							if err == nil {
+								for _, push := range pushes {
+									a.Data = append(a.Data, push...)
+								}
+							}
+						}
+						aux = append(aux, a)
+					}
+				}
+				return false, nil
+			})
+	})
+	return aux, err
+}
@@ -0,0 +1,89 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestAuxOutputs ensures that the OP_RETURN and zero value outputs of a
+// payment to the wallet are not credited but are listed by AuxOutputs.
+func TestAuxOutputs(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nullData, err := txscript.NullDataScript([]byte("audit me"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := wire.NewMsgTx(1)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 0}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(1e8, pkScript))
+	tx.AddTxOut(wire.NewTxOut(0, nullData))
+	tx.AddTxOut(wire.NewTxOut(0, pkScript))
+	var b bytes.Buffer
+	if err := tx.Serialize(&b); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := wtxmgr.NewTxRecord(b.Bytes(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: chainhash.Hash{1}, Height: 1},
+		Time:  time.Now(),
+	}
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		return w.addRelevantTx(dbtx, rec, block)
+	})
+	if err != nil {
+		t.Fatalf("unable to add transaction: %v", err)
+	}
+
+	balance, err := w.CalculateBalance(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != 1e8 {
+		t.Fatalf("got balance %v, want 1 coin", balance)
+	}
+	unspent, err := w.ListUnspent(0, 999999, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unspent) != 1 || unspent[0].Vout != 0 {
+		t.Fatalf("got %d unspent outputs, want only output 0", len(unspent))
+	}
+
+	aux, err := w.AuxOutputs()
+	if err != nil {
+		t.Fatalf("unable to list aux outputs: %v", err)
+	}
+	if len(aux) != 2 {
+		t.Fatalf("got %d aux outputs, want 2", len(aux))
+	}
+	if aux[0].OutPoint.Hash != tx.TxHash() || aux[0].OutPoint.Index != 1 ||
+		aux[0].Height != 1 || string(aux[0].Data) != "audit me" {
+		t.Fatalf("got OP_RETURN aux output %+v", aux[0])
+	}
+	if aux[1].OutPoint.Index != 2 || aux[1].Amount != 0 || aux[1].Data != nil {
+		t.Fatalf("got zero value aux output %+v", aux[1])
+	}
+}
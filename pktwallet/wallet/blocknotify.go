@@ -0,0 +1,71 @@
+package wallet
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktlog/log"
+)
+
+// blockNotifyBacklog is the most blocks which may wait for the BlockNotify
+// command to be run for them, blocks connected while the backlog is full are
+// not notified.
+const blockNotifyBacklog = 16
+
+// queueBlockNotify queues the BlockNotify command to be run for the given block
+// hash.  It must only be called once the block is committed to the database,
+// and nothing is queued until the wallet is synced to the chain so that the
+// command is not run for every block of a catch up.
+func (w *Wallet) queueBlockNotify(hash *chainhash.Hash) {
+	if w.cfg.BlockNotify == "" || !w.ChainSynced() {
+		return
+	}
+	select {
+	case w.blockNotifyRequests <- *hash:
+	default:
+		log.Warnf("Skipping blocknotify for block [%s], [%d] commands are "+
+			"already waiting to run", hash, blockNotifyBacklog)
+	}
+}
+
+// blockNotifier runs the BlockNotify command for each queued block, one at a
+// time, so that a slow or hung command holds up neither the sync loop nor any
+// more than one process.  A command which is still running when the wallet
+// shuts down is killed.
+func (w *Wallet) blockNotifier() {
+	quit := w.quitChan()
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-quit
+		cancel()
+	}()
+out:
+	for {
+		select {
+		case hash := <-w.blockNotifyRequests:
+			w.runBlockNotify(ctx, &hash)
+		case <-quit:
+			break out
+		}
+	}
+	w.wg.Done()
+}
+
+// runBlockNotify runs the BlockNotify command for the given block hash and
+// waits for it to exit.
+func (w *Wallet) runBlockNotify(ctx context.Context, hash *chainhash.Hash) {
+	cmdLine := strings.ReplaceAll(w.cfg.BlockNotify, "%s", hash.String())
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", cmdLine)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", cmdLine)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		log.Warnf("blocknotify command [%s] failed: %v [%s]",
+			cmdLine, err, strings.TrimSpace(string(out)))
+	}
+}
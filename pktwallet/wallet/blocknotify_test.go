@@ -0,0 +1,86 @@
+package wallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+)
+
+// TestBlockNotify ensures that the blocknotify command is only queued once the
+// wallet is synced, that the backlog of queued blocks is bounded, and that the
+// commands are run in order with the hash of each block substituted in.
+func TestBlockNotify(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("blocknotify test requires a posix shell")
+	}
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	dir, errr := ioutil.TempDir("", "blocknotify")
+	if errr != nil {
+		t.Fatalf("unable to create temp dir: %v", errr)
+	}
+	defer os.RemoveAll(dir)
+	outFile := filepath.Join(dir, "hashes")
+
+	w.cfg.BlockNotify = "echo %s >> " + outFile
+
+	hashes := make([]chainhash.Hash, blockNotifyBacklog+1)
+	for i := range hashes {
+		hashes[i] = chainhash.DoubleHashH([]byte{byte(i)})
+	}
+
+	// Nothing is queued while the wallet catches up.
+	w.SetChainSynced(false)
+	w.queueBlockNotify(&hashes[0])
+	if n := len(w.blockNotifyRequests); n != 0 {
+		t.Fatalf("got %d queued blocks before the wallet is synced", n)
+	}
+
+	// Blocks beyond the backlog are dropped while the notifier is not
+	// running.
+	w.SetChainSynced(true)
+	for i := range hashes {
+		w.queueBlockNotify(&hashes[i])
+	}
+	if n := len(w.blockNotifyRequests); n != blockNotifyBacklog {
+		t.Fatalf("got %d queued blocks, want the backlog of %d", n,
+			blockNotifyBacklog)
+	}
+
+	// The wallet was started before the command was set, so the notifier
+	// is started here.
+	w.wg.Add(1)
+	go w.blockNotifier()
+
+	want := make([]string, blockNotifyBacklog)
+	for i := range want {
+		want[i] = hashes[i].String()
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		out, errr := ioutil.ReadFile(outFile)
+		got := strings.Fields(string(out))
+		if errr == nil && len(got) == len(want) {
+			for i := range got {
+				if got[i] != want[i] {
+					t.Fatalf("blocknotify command %d got hash %v, "+
+						"want %v", i, got[i], want[i])
+				}
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d blocknotify commands run, want %d",
+				len(got), len(want))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
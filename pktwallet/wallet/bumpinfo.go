@@ -0,0 +1,71 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// BumpInfo is whether the fee of a wallet transaction can be bumped by
+// replacing it, as AutoBumpAfter does.
+type BumpInfo struct {
+	// Replaceable is whether the transaction signals BIP125
+	// replaceability.
+	Replaceable bool
+
+	// OwnsInputs is whether every input spends an output of the wallet,
+	// so that the wallet knows the fee and can sign a replacement.  Fee is
+	// only set if it is.
+	OwnsInputs bool
+	Fee        btcutil.Amount
+
+	// MinBumpFee is the least which a replacement must add to the fee,
+	// MinBumpIncrement of the size of the replacement.
+	MinBumpFee btcutil.Amount
+
+	// CanBump is whether the wallet can bump the fee, if so NewFee is the
+	// fee which the replacement would pay, otherwise Reason says why not.
+	CanBump bool
+	NewFee  btcutil.Amount
+	Reason  string
+}
+
+// BumpInfo returns whether the fee of a wallet transaction can be bumped by
+// replacing it and what the replacement would pay.  The transaction is not
+// changed.
+func (w *Wallet) BumpInfo(txHash *chainhash.Hash) (*BumpInfo, er.R) {
+	info := &BumpInfo{}
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		details, err := w.TxStore.TxDetails(txmgrNs, txHash)
+		if err != nil {
+			return err
+		}
+		if details == nil {
+			return wtxmgr.ErrNoExists.New("transaction not found in wallet", nil)
+		}
+
+		info.Replaceable = wtxmgr.SignalsReplacement(&details.MsgTx)
+		info.Fee, info.OwnsInputs = txFee(details)
+		info.MinBumpFee = w.minBumpFee(virtualSize(&details.MsgTx))
+
+		plan, err := w.planBump(addrmgrNs, details)
+		switch {
+		case ErrCannotBump.Is(err) || ErrBumpFeeTooHigh.Is(err):
+			info.Reason = err.Message()
+		case err != nil:
+			return err
+		default:
+			info.CanBump = true
+			info.NewFee = plan.newFee
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
@@ -0,0 +1,91 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+	"github.com/pkt-cash/pktd/wire/constants"
+)
+
+// TestBumpInfo checks the bump information of an unmined replaceable spend of
+// a wallet output and of one which does not signal replaceability.
+func TestBumpInfo(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	incoming := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{
+			wire.NewTxOut(1e8, pkScript),
+			wire.NewTxOut(1e8, pkScript),
+		},
+	}
+	insertTestTx(t, w, incoming, 90, 0, 1)
+
+	spend := func(index uint32, sequence uint32) *wire.MsgTx {
+		tx := &wire.MsgTx{
+			Version: 1,
+			TxIn: []*wire.TxIn{{
+				PreviousOutPoint: wire.OutPoint{Hash: incoming.TxHash(), Index: index},
+				Sequence:         sequence,
+			}},
+			TxOut: []*wire.TxOut{
+				wire.NewTxOut(3e7, []byte{0x51}),
+				wire.NewTxOut(7e7-1000, pkScript),
+			},
+		}
+		insertTestTx(t, w, tx, -1, 1)
+		return tx
+	}
+
+	replaceable := spend(0, constants.MaxTxInSequenceNum-2)
+	hash := replaceable.TxHash()
+	info, err := w.BumpInfo(&hash)
+	if err != nil {
+		t.Fatalf("unable to get bump info: %v", err)
+	}
+	minBump := txrules.FeeForSerializeSize(txrules.DefaultRelayFeePerKb,
+		virtualSize(replaceable))
+	if !info.Replaceable || !info.OwnsInputs || info.Fee != 1000 ||
+		info.MinBumpFee != minBump || !info.CanBump || info.NewFee != 2000 {
+
+		t.Fatalf("got bump info %+v for a replaceable spend paying 1000, "+
+			"want bumpable to 2000 with a minimum bump of %v", info, minBump)
+	}
+
+	final := spend(1, constants.MaxTxInSequenceNum)
+	hash = final.TxHash()
+	info, err = w.BumpInfo(&hash)
+	if err != nil {
+		t.Fatalf("unable to get bump info: %v", err)
+	}
+	if info.Replaceable || !info.OwnsInputs || info.Fee != 1000 ||
+		info.CanBump || info.Reason == "" {
+
+		t.Fatalf("got bump info %+v for a spend which is not replaceable",
+			info)
+	}
+
+	// A bump larger than MaxBumpFee allows cannot be made.
+	w.cfg.MaxBumpFee = minBump - 1
+	hash = replaceable.TxHash()
+	info, err = w.BumpInfo(&hash)
+	if err != nil {
+		t.Fatalf("unable to get bump info: %v", err)
+	}
+	if info.CanBump || info.Reason == "" {
+		t.Fatalf("got bump info %+v with a maximum bump fee below the "+
+			"minimum bump", info)
+	}
+}
@@ -84,6 +84,7 @@ func (w *Wallet) _rollbackBlock(dbtx walletdb.ReadWriteTx, bs waddrmgr.BlockStam
 		return err
 	}
 
+	w.utxoCache.invalidate()
 	err = w.TxStore.RollbackOne(txmgrNs, bs.Height)
 	if err != nil && !wtxmgr.ErrNoExists.Is(err) {
 		return err
@@ -103,14 +104,22 @@ func (w *Wallet) addRelevantTx(dbtx walletdb.ReadWriteTx, rec *wtxmgr.TxRecord,
 	// relevant.  This assumption will not hold true when SPV support is
 	// added, but until then, simply insert the transaction because there
 	// should either be one or more relevant inputs or outputs.
+	w.utxoCache.invalidate()
 	err := w.TxStore.InsertTx2(txmgrNs, rec, block)
 	if err != nil {
+		w.NtfnServer.conflicted = nil
 		return err
 	}
+	w.NtfnServer.notifyConflictedTransactions(dbtx)
 
 	// Check every output to determine whether it is controlled by a wallet
 	// key.  If so, mark the output as a credit.
 	for i, output := range rec.MsgTx.TxOut {
+		if isAuxOutput(output) {
+			// Zero value and unspendable outputs are not coins, they
+			// are listed by AuxOutputs instead.
+			continue
+		}
 		_, addrs, _, err := txscript.ExtractPkScriptAddrs(output.PkScript,
 			w.chainParams)
 		if err != nil {
@@ -128,6 +137,9 @@ func (w *Wallet) addRelevantTx(dbtx walletdb.ReadWriteTx, rec *wtxmgr.TxRecord,
 				if err != nil {
 					return err
 				}
+				if block != nil {
+					w.recovery.foundAddress(addr)
+				}
 				txOutAmt := btcutil.Amount(rec.MsgTx.TxOut[i].Value)
 				if !isNew {
 					// don't log when we see the same money again
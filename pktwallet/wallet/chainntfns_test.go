@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"bytes"
 	"reflect"
 	"testing"
 	"time"
@@ -10,8 +11,11 @@ import (
 	"github.com/pkt-cash/pktd/chaincfg/chainhash"
 	"github.com/pkt-cash/pktd/chaincfg/genesis"
 	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
 	_ "github.com/pkt-cash/pktd/pktwallet/walletdb/bdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
 	"github.com/pkt-cash/pktd/wire"
+	"github.com/pkt-cash/pktd/wire/constants"
 )
 
 const (
@@ -303,3 +307,168 @@ func TestBirthdaySanityCheckHigherEstimate(t *testing.T) {
 			"%v vs %v", birthdayStore.syncedTo, birthdayBlock)
 	}
 }
+
+// TestConflictedTransaction ensures that when a mined transaction double spends
+// the input of an unmined wallet transaction, the unmined transaction and its
+// descendants are removed from the balance, recorded as conflicted and
+// notified to clients.
+func TestConflictedTransaction(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	// Receive a coin, then spend it to ourselves and spend the change
+	// again, both unmined.
+	incoming := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+	}
+	insertTestTx(t, w, incoming, 100, 0)
+	op := wire.OutPoint{Hash: incoming.TxHash()}
+	spend := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: op}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(9e7, []byte{0x51})},
+	}
+	insertTestTx(t, w, spend, -1, 0)
+	child := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: spend.TxHash()}}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(8e7, []byte{0x51})},
+	}
+	insertTestTx(t, w, child, -1, 0)
+
+	if balance, err := w.CalculateBalance(0); err != nil || balance != 8e7 {
+		t.Fatalf("got balance %v (%v), want 0.8 coins", balance, err)
+	}
+
+	// A block then confirms a different spend of the coin.
+	doubleSpend := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: op, Sequence: 1}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(9e7, []byte{0x52})},
+	}
+	var b bytes.Buffer
+	if err := doubleSpend.Serialize(&b); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := wtxmgr.NewTxRecord(b.Bytes(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: chainhash.Hash{1}, Height: 101},
+		Time:  time.Now(),
+	}
+
+	client := w.NtfnServer.TransactionNotifications()
+	defer client.Done()
+	errs := make(chan er.R, 1)
+	go func() {
+		errs <- walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+			return w.addRelevantTx(dbtx, rec, block)
+		})
+	}()
+
+	var n *TransactionNotifications
+	select {
+	case n = <-client.C:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no notification of the conflicted transactions")
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("unable to add double spend: %v", err)
+	}
+	want := []chainhash.Hash{spend.TxHash(), child.TxHash()}
+	if len(n.ConflictedTransactions) != len(want) {
+		t.Fatalf("got %d conflicted transactions, want %d",
+			len(n.ConflictedTransactions), len(want))
+	}
+	for i, c := range n.ConflictedTransactions {
+		if *c.Hash != want[i] || *c.ConflictedBy != doubleSpend.TxHash() {
+			t.Fatalf("got conflicted transaction %v by %v, want %v "+
+				"by %v", c.Hash, c.ConflictedBy, want[i],
+				doubleSpend.TxHash())
+		}
+	}
+	if len(n.UnminedTransactionHashes) != 0 {
+		t.Fatalf("got unmined transactions %v, want none",
+			n.UnminedTransactionHashes)
+	}
+
+	if balance, err := w.CalculateBalance(0); err != nil || balance != 0 {
+		t.Fatalf("got balance %v (%v), want 0", balance, err)
+	}
+	for _, hash := range want {
+		rec, conflictedBy, err := UnstableAPI(w).ConflictedTx(&hash)
+		if err != nil {
+			t.Fatalf("unable to fetch conflicted transaction: %v", err)
+		}
+		if rec == nil || rec.Hash != hash ||
+			*conflictedBy != doubleSpend.TxHash() {
+
+			t.Fatalf("transaction %v is not recorded as conflicted "+
+				"by %v", hash, doubleSpend.TxHash())
+		}
+	}
+	if rec, _, _ := UnstableAPI(w).ConflictedTx(&op.Hash); rec != nil {
+		t.Fatalf("mined transaction is recorded as conflicted")
+	}
+}
+
+// TestMaturedCoinbaseOutputs ensures that a coinbase output credited to the
+// wallet is notified once the block in which it reaches maturity is attached,
+// and not before.
+func TestMaturedCoinbaseOutputs(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	const minedAt = 100
+	coinbase := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Index: constants.MaxPrevOutIndex},
+		}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(5e8, []byte{0x51})},
+	}
+	insertTestTx(t, w, coinbase, minedAt, 0)
+
+	client := w.NtfnServer.TransactionNotifications()
+	defer client.Done()
+	attach := func(height int32) *TransactionNotifications {
+		t.Helper()
+		go func() {
+			_ = walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+				w.NtfnServer.notifyAttachedBlock(dbtx, &wtxmgr.BlockMeta{
+					Block: wtxmgr.Block{
+						Hash:   chainhash.Hash{byte(height)},
+						Height: height,
+					},
+					Time: time.Now(),
+				})
+				return nil
+			})
+		}()
+		select {
+		case n := <-client.C:
+			return n
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no notification of the block at height %d", height)
+		}
+		return nil
+	}
+
+	maturity := int32(w.chainParams.CoinbaseMaturity)
+	if n := attach(minedAt + maturity - 2); len(n.MaturedCoinbaseOutputs) != 0 {
+		t.Fatalf("got matured outputs %v before maturity",
+			n.MaturedCoinbaseOutputs)
+	}
+	n := attach(minedAt + maturity - 1)
+	want := MaturedCoinbaseOutput{
+		OutPoint: wire.OutPoint{Hash: coinbase.TxHash()},
+		Amount:   5e8,
+	}
+	if len(n.MaturedCoinbaseOutputs) != 1 || n.MaturedCoinbaseOutputs[0] != want {
+		t.Fatalf("got matured outputs %v, want %v",
+			n.MaturedCoinbaseOutputs, want)
+	}
+	if n := attach(minedAt + maturity); len(n.MaturedCoinbaseOutputs) != 0 {
+		t.Fatalf("got matured outputs %v after maturity",
+			n.MaturedCoinbaseOutputs)
+	}
+}
@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/txscript"
+)
+
+// ErrChangeAddressNotOwned is returned when pinning the change to an address
+// which does not belong to the wallet.
+var ErrChangeAddressNotOwned = Err.CodeWithDetail("ErrChangeAddressNotOwned",
+	"change address does not belong to the wallet")
+
+// SetChangeAddress pins the change of the transactions which the wallet
+// creates to addr, in place of a change address chosen for each transaction,
+// until it is unpinned by calling SetChangeAddress with a nil addr.  A change
+// address given explicitly for a send still takes precedence.  The address
+// must belong to the wallet.  The pin is recorded in the wallet database so it
+// remains in place when the wallet is restarted.
+func (w *Wallet) SetChangeAddress(addr btcutil.Address) er.R {
+	return walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+		if addr == nil {
+			return w.TxStore.DeleteChangeScript(txmgrNs)
+		}
+		addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+		if _, err := w.Manager.Address(addrmgrNs, addr); err != nil {
+			if waddrmgr.ErrAddressNotFound.Is(err) {
+				return ErrChangeAddressNotOwned.New(fmt.Sprintf(
+					"address [%s] is not in the wallet",
+					addr.EncodeAddress()), nil)
+			}
+			return err
+		}
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return err
+		}
+		return w.TxStore.PutChangeScript(txmgrNs, pkScript)
+	})
+}
+
+// ChangeAddress returns the address which the change is pinned to by
+// SetChangeAddress, or nil if it is not pinned.
+func (w *Wallet) ChangeAddress() (btcutil.Address, er.R) {
+	var addr btcutil.Address
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		addr = w.pinnedChangeAddress(dbtx.ReadBucket(wtxmgrNamespaceKey))
+		return nil
+	})
+	return addr, err
+}
+
+// pinnedChangeAddress returns the address which the change is pinned to, or
+// nil if it is not pinned.
+func (w *Wallet) pinnedChangeAddress(txmgrNs walletdb.ReadBucket) btcutil.Address {
+	pkScript := w.TxStore.ChangeScript(txmgrNs)
+	if pkScript == nil {
+		return nil
+	}
+	return txscript.PkScriptToAddress(pkScript, w.chainParams)
+}
@@ -0,0 +1,86 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestChangeAddress checks that sends made while the change address is pinned
+// return their change to it, and that only addresses of the wallet may be
+// pinned.
+func TestChangeAddress(t *testing.T) {
+	w, _, _, cleanup := fundedTestWallet(t, 1e8, 1e8, 1e8)
+	defer cleanup()
+
+	if pinned, err := w.ChangeAddress(); err != nil || pinned != nil {
+		t.Fatalf("got pinned change address %v (%v) before pinning",
+			pinned, err)
+	}
+	foreign, err := btcutil.NewAddressWitnessPubKeyHash(make([]byte, 20),
+		w.chainParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetChangeAddress(foreign); !ErrChangeAddressNotOwned.Is(err) {
+		t.Fatalf("got error %v pinning an address not in the wallet", err)
+	}
+
+	change, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	changeScript, err := txscript.PayToAddrScript(change)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetChangeAddress(change); err != nil {
+		t.Fatalf("unable to pin change address: %v", err)
+	}
+	pinned, err := w.ChangeAddress()
+	if err != nil || pinned == nil || pinned.String() != change.String() {
+		t.Fatalf("got pinned change address %v (%v), want %v", pinned,
+			err, change)
+	}
+
+	send := func() *wire.TxOut {
+		tx, err := w.SendOutputs(CreateTxReq{
+			Outputs:     []*wire.TxOut{wire.NewTxOut(5e7, []byte{0x51})},
+			Minconf:     1,
+			FeeSatPerKB: 1000,
+			MaxInputs:   -1,
+			SendMode:    SendModeBcasted,
+		})
+		if err != nil {
+			t.Fatalf("unable to send: %v", err)
+		}
+		for i, out := range tx.Tx.TxOut {
+			if tx.IsChange(i) {
+				return out
+			}
+		}
+		t.Fatalf("send has no change output")
+		return nil
+	}
+	for i := 0; i < 2; i++ {
+		if out := send(); !bytes.Equal(out.PkScript, changeScript) {
+			t.Fatalf("send %d paid change to %x, want the pinned %x",
+				i, out.PkScript, changeScript)
+		}
+	}
+
+	if err := w.SetChangeAddress(nil); err != nil {
+		t.Fatalf("unable to unpin change address: %v", err)
+	}
+	if pinned, err := w.ChangeAddress(); err != nil || pinned != nil {
+		t.Fatalf("got pinned change address %v (%v) after unpinning",
+			pinned, err)
+	}
+	if out := send(); bytes.Equal(out.PkScript, changeScript) {
+		t.Fatalf("send paid change to the unpinned address")
+	}
+}
@@ -0,0 +1,86 @@
+package wallet
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txauthor"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// ErrInputNotSpendable is returned when an input named by
+// CreateTxReq.InputOutPoints is not an unspent output which the wallet can
+// spend.
+var ErrInputNotSpendable = Err.CodeWithDetail("ErrInputNotSpendable",
+	"the named input cannot be spent by the wallet")
+
+// namedOutputs returns the unspent outputs named by outPoints, in the same
+// order, for a transaction which spends exactly those.  Each must be an
+// unspent output of the wallet which is neither locked, frozen nor an immature
+// coinbase output, otherwise ErrInputNotSpendable is returned.  Unlike the
+// outputs chosen by findEligibleOutputs, they need not be confirmed and may be
+// quarantined dust, as the caller named them.
+func (w *Wallet) namedOutputs(txmgrNs walletdb.ReadBucket,
+	outPoints []wire.OutPoint, bs *waddrmgr.BlockStamp) ([]*wtxmgr.Credit, er.R) {
+
+	if len(outPoints) == 0 {
+		return nil, ErrInputNotSpendable.New("no inputs were named", nil)
+	}
+	index := make(map[wire.OutPoint]int, len(outPoints))
+	for i, op := range outPoints {
+		if _, ok := index[op]; ok {
+			return nil, ErrInputNotSpendable.New(fmt.Sprintf("input [%s] "+
+				"is named more than once", op), nil)
+		}
+		index[op] = i
+	}
+
+	credits := make([]*wtxmgr.Credit, len(outPoints))
+	err := w.forEachUnspentOutput(txmgrNs, func(_ []byte, c *wtxmgr.Credit) er.R {
+		if i, ok := index[c.OutPoint]; ok {
+			credits[i] = c
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, c := range credits {
+		op := outPoints[i]
+		switch {
+		case c == nil:
+			return nil, ErrInputNotSpendable.New(fmt.Sprintf("input [%s] is "+
+				"not an unspent output of the wallet", op), nil)
+		case txscript.GetScriptClass(c.PkScript) == txscript.WitnessV1TaprootTy:
+			return nil, ErrInputNotSpendable.New(fmt.Sprintf("input [%s] is "+
+				"a taproot output which cannot be signed for", op), nil)
+		case c.FromCoinBase && !confirmed(int32(w.chainParams.CoinbaseMaturity),
+			c.Height, bs.Height):
+			return nil, ErrInputNotSpendable.New(fmt.Sprintf("input [%s] is "+
+				"an immature coinbase output", op), nil)
+		case w.LockedOutpoint(op):
+			return nil, ErrInputNotSpendable.New(fmt.Sprintf("input [%s] is "+
+				"locked", op), nil)
+		case w.TxStore.IsFrozenScript(txmgrNs, c.PkScript):
+			return nil, ErrInputNotSpendable.New(fmt.Sprintf("input [%s] "+
+				"pays a frozen address", op), nil)
+		}
+	}
+	return credits, nil
+}
+
+// makeAllInputsSource returns an input source which spends every credit,
+// whatever the target amount.
+func makeAllInputsSource(credits []*wtxmgr.Credit) txauthor.InputSource {
+	source := makeInputSource(credits)
+	return func(btcutil.Amount) (btcutil.Amount, []*wire.TxIn, []wire.TxInAdditional, er.R) {
+		return source(btcutil.Amount(math.MaxInt64))
+	}
+}
@@ -0,0 +1,87 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestNamedInputs ensures that a transaction with InputOutPoints spends
+// exactly the named outputs, and that inputs which are too small or not
+// unspent outputs of the wallet are rejected.
+func TestNamedInputs(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.CurrentAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get current address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+	var outPoints []wire.OutPoint
+	for i, value := range []int64{1e8, 2e8, 3e8} {
+		tx := &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Index: uint32(i)}}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(value, pkScript)},
+		}
+		insertTestTx(t, w, tx, 100, 0)
+		outPoints = append(outPoints, wire.OutPoint{Hash: tx.TxHash()})
+	}
+
+	send := func(value int64, inputs ...wire.OutPoint) (*wire.MsgTx, er.R) {
+		tx, err := w.SendOutputs(CreateTxReq{
+			Outputs:        []*wire.TxOut{wire.NewTxOut(value, []byte{0x51})},
+			FeeSatPerKB:    1000,
+			MaxInputs:      -1,
+			SendMode:       SendModeUnsigned,
+			InputOutPoints: inputs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return tx.Tx, nil
+	}
+
+	// The largest output alone would pay, but both named outputs are
+	// spent and the rest returned as change.
+	tx, err := send(1.5e8, outPoints[0], outPoints[2])
+	if err != nil {
+		t.Fatalf("unable to send from named inputs: %v", err)
+	}
+	if len(tx.TxIn) != 2 || tx.TxIn[0].PreviousOutPoint != outPoints[0] ||
+		tx.TxIn[1].PreviousOutPoint != outPoints[2] {
+
+		t.Fatalf("got inputs %v, want only %v and %v", tx.TxIn,
+			outPoints[0], outPoints[2])
+	}
+	if len(tx.TxOut) != 2 {
+		t.Fatalf("got %d outputs, want the payment and change", len(tx.TxOut))
+	}
+
+	_, err = send(1.5e8, outPoints[0])
+	if !InsufficientFundsError.Is(err) {
+		t.Fatalf("got error %v paying more than the named input, want "+
+			"InsufficientFundsError", err)
+	}
+	if !strings.Contains(err.String(), "short of paying") {
+		t.Fatalf("got error %v, want it to report the shortfall", err)
+	}
+
+	unknown := wire.OutPoint{Hash: chainhash.Hash{1}}
+	if _, err := send(1e7, outPoints[1], unknown); !ErrInputNotSpendable.Is(err) {
+		t.Fatalf("got error %v naming an output which is not the "+
+			"wallet's, want ErrInputNotSpendable", err)
+	}
+	if _, err := send(1e7, outPoints[1], outPoints[1]); !ErrInputNotSpendable.Is(err) {
+		t.Fatalf("got error %v naming an input twice, want "+
+			"ErrInputNotSpendable", err)
+	}
+}
@@ -0,0 +1,31 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// CommentTransaction records a comment about a transaction and a comment about
+// who it was sent to, replacing any which were recorded before.  Comments are
+// only kept in the wallet database, they are never broadcast.
+func (w *Wallet) CommentTransaction(hash chainhash.Hash, comment, commentTo string) er.R {
+	return walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+		return w.TxStore.PutTxComment(txmgrNs, hash, comment, commentTo)
+	})
+}
+
+// TxComment returns the comments recorded for a transaction, both are empty if
+// the transaction has none.
+func (w *Wallet) TxComment(hash chainhash.Hash) (string, string, er.R) {
+	var comment, commentTo string
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		var err er.R
+		comment, commentTo, err = wtxmgr.FetchTxComment(
+			dbtx.ReadBucket(wtxmgrNamespaceKey), hash)
+		return err
+	})
+	return comment, commentTo, err
+}
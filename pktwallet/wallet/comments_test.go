@@ -0,0 +1,79 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/hdkeychain"
+	"github.com/pkt-cash/pktd/chaincfg"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestCommentTransaction ensures that the comments of a transaction are kept
+// when the wallet is reopened and are included in transaction listings.
+func TestCommentTransaction(t *testing.T) {
+	dir, errr := ioutil.TempDir("", "test_wallet")
+	if errr != nil {
+		t.Fatalf("Failed to create db dir: %v", errr)
+	}
+	defer os.RemoveAll(dir)
+
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.MinSeedBytes)
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+	pubPass := []byte("hello")
+	loader := NewLoader(&chaincfg.TestNet3Params, dir, "wallet.db", true, 250)
+	w, err := loader.CreateNewWallet(pubPass, []byte("world"),
+		[]byte(hex.EncodeToString(seed)), time.Now(), nil)
+	if err != nil {
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+
+	tx := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+	}
+	insertTestTx(t, w, tx, 100, 0)
+	const comment, commentTo = "rent for june", "landlord"
+	if err := w.CommentTransaction(tx.TxHash(), comment, commentTo); err != nil {
+		t.Fatalf("unable to comment transaction: %v", err)
+	}
+
+	if err := loader.UnloadWallet(); err != nil {
+		t.Fatalf("unable to unload wallet: %v", err)
+	}
+	w, err = loader.OpenExistingWallet(pubPass, false)
+	if err != nil {
+		t.Fatalf("unable to open wallet: %v", err)
+	}
+	defer loader.UnloadWallet()
+
+	c, to, err := w.TxComment(tx.TxHash())
+	if err != nil {
+		t.Fatalf("unable to fetch comments: %v", err)
+	}
+	if c != comment || to != commentTo {
+		t.Fatalf("got comments %q and %q, want %q and %q", c, to,
+			comment, commentTo)
+	}
+	txs, err := w.ListTransactions(0, 10)
+	if err != nil {
+		t.Fatalf("unable to list transactions: %v", err)
+	}
+	if len(txs) != 1 || txs[0].Comment != comment || txs[0].CommentTo != commentTo {
+		t.Fatalf("got listed transactions %+v, want one with comments "+
+			"%q and %q", txs, comment, commentTo)
+	}
+
+	// A transaction without comments has none.
+	c, to, err = w.TxComment(chainhash.Hash{1})
+	if err != nil || c != "" || to != "" {
+		t.Fatalf("got comments %q and %q (%v) for an uncommented "+
+			"transaction", c, to, err)
+	}
+}
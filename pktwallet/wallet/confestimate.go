@@ -0,0 +1,155 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// FeeEstimator provides the fee rate, in coins per kilobyte, which a
+// transaction must pay to confirm within a number of blocks.  It is
+// implemented by chain.RPCClient.
+type FeeEstimator interface {
+	EstimateFee(numBlocks int64) (float64, er.R)
+}
+
+// confirmationTargets are the confirmation targets, in blocks, for which fee
+// estimates are requested.
+var confirmationTargets = []int32{1, 2, 3, 4, 6, 10, 15, 25}
+
+// conservativeConfirmationBlocks is the number of blocks which a transaction
+// paying at least the relay fee is assumed to confirm within when there is no
+// fee estimation data, as is the case with the neutrino backend.
+const conservativeConfirmationBlocks = 6
+
+// ErrUnknownFee is returned when the fee of a transaction cannot be computed
+// because some of its inputs do not belong to the wallet.
+var ErrUnknownFee = Err.CodeWithDetail("ErrUnknownFee",
+	"fee of transaction is unknown because not all inputs belong to the wallet")
+
+// ConfirmationEstimate is an estimate of how long it will take for a
+// transaction to confirm.  An estimate is low confidence when it is not based
+// on fee estimation data or when the transaction pays less than all estimates.
+type ConfirmationEstimate struct {
+	FeeRate       btcutil.Amount
+	Blocks        int32
+	Duration      time.Duration
+	LowConfidence bool
+}
+
+// feeEstimate is the fee rate estimated to confirm within a number of blocks.
+type feeEstimate struct {
+	blocks  int32
+	feeRate btcutil.Amount
+}
+
+// estimateConfirmation computes the number of blocks and time which a
+// transaction paying feeRate is expected to take to confirm.  The estimates
+// must be ordered by increasing number of blocks, if there are none then a
+// conservative low confidence estimate is made.
+func estimateConfirmation(feeRate btcutil.Amount, estimates []feeEstimate,
+	blockTime time.Duration) *ConfirmationEstimate {
+
+	ce := &ConfirmationEstimate{FeeRate: feeRate}
+	if len(estimates) == 0 {
+		ce.Blocks = conservativeConfirmationBlocks
+		ce.LowConfidence = true
+	} else {
+		for _, e := range estimates {
+			if feeRate >= e.feeRate {
+				ce.Blocks = e.blocks
+				break
+			}
+		}
+		if ce.Blocks == 0 {
+			// Paying less than every estimate, it will take at least
+			// as long as the longest target.
+			ce.Blocks = estimates[len(estimates)-1].blocks
+			ce.LowConfidence = true
+		}
+	}
+	if feeRate < txrules.DefaultRelayFeePerKb {
+		ce.LowConfidence = true
+	}
+	ce.Duration = time.Duration(ce.Blocks) * blockTime
+	return ce
+}
+
+// txFee returns the fee which a wallet transaction pays.  The fee is only
+// known if every input of the transaction spends a wallet output, otherwise
+// false is returned.
+func txFee(details *wtxmgr.TxDetails) (btcutil.Amount, bool) {
+	if len(details.Debits) != len(details.MsgTx.TxIn) {
+		return 0, false
+	}
+	fee := btcutil.Amount(0)
+	for _, deb := range details.Debits {
+		fee += deb.Amount
+	}
+	for _, out := range details.MsgTx.TxOut {
+		fee -= btcutil.Amount(out.Value)
+	}
+	return fee, true
+}
+
+// txFeeRate returns the fee rate, in atomic units per kilobyte of virtual size,
+// which a wallet transaction pays, or false if its fee is not known.
+func txFeeRate(details *wtxmgr.TxDetails) (btcutil.Amount, bool) {
+	fee, ok := txFee(details)
+	if !ok {
+		return 0, false
+	}
+	return fee * 1000 / btcutil.Amount(virtualSize(&details.MsgTx)), true
+}
+
+// EstimateConfirmationTime estimates how long an unmined wallet transaction
+// will take to confirm based on its fee rate.  If fe is nil or it has no data
+// then a conservative low confidence estimate is returned.  A mined
+// transaction is estimated at zero blocks.
+func (w *Wallet) EstimateConfirmationTime(txHash *chainhash.Hash,
+	fe FeeEstimator) (*ConfirmationEstimate, er.R) {
+
+	var details *wtxmgr.TxDetails
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		var err er.R
+		details, err = w.TxStore.TxDetails(txmgrNs, txHash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, wtxmgr.ErrNoExists.New("transaction not found in wallet", nil)
+	}
+	if details.Block.Height >= 0 {
+		return &ConfirmationEstimate{}, nil
+	}
+	feeRate, ok := txFeeRate(details)
+	if !ok {
+		return nil, ErrUnknownFee.Default()
+	}
+
+	var estimates []feeEstimate
+	if fe != nil {
+		for _, blocks := range confirmationTargets {
+			coinsPerKb, err := fe.EstimateFee(int64(blocks))
+			if err != nil || coinsPerKb <= 0 {
+				// Not enough data for this target.
+				continue
+			}
+			rate, err := btcutil.NewAmount(coinsPerKb)
+			if err != nil {
+				return nil, err
+			}
+			estimates = append(estimates, feeEstimate{blocks: blocks, feeRate: rate})
+		}
+	}
+	return estimateConfirmation(feeRate, estimates,
+		w.chainParams.TargetTimePerBlock), nil
+}
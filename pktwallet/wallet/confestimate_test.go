@@ -0,0 +1,130 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestEstimateConfirmation checks the confirmation estimate computed from a
+// fee rate and the fee estimator's state.
+func TestEstimateConfirmation(t *testing.T) {
+	estimates := []feeEstimate{
+		{blocks: 1, feeRate: 50000},
+		{blocks: 3, feeRate: 20000},
+		{blocks: 6, feeRate: 5000},
+		{blocks: 25, feeRate: 2000},
+	}
+	tests := []struct {
+		name          string
+		feeRate       btcutil.Amount
+		estimates     []feeEstimate
+		blocks        int32
+		lowConfidence bool
+	}{
+		{"above all estimates", 60000, estimates, 1, false},
+		{"exactly an estimate", 20000, estimates, 3, false},
+		{"between estimates", 10000, estimates, 6, false},
+		{"below all estimates", 1500, estimates, 25, true},
+		{"no estimator data", 10000, nil, conservativeConfirmationBlocks, true},
+		{"below relay fee", 500, nil, conservativeConfirmationBlocks, true},
+	}
+	for _, test := range tests {
+		ce := estimateConfirmation(test.feeRate, test.estimates, time.Minute)
+		if ce.Blocks != test.blocks {
+			t.Errorf("%s: got %d blocks, want %d", test.name, ce.Blocks,
+				test.blocks)
+		}
+		if ce.Duration != time.Duration(test.blocks)*time.Minute {
+			t.Errorf("%s: got duration %v, want %v", test.name,
+				ce.Duration, time.Duration(test.blocks)*time.Minute)
+		}
+		if ce.LowConfidence != test.lowConfidence {
+			t.Errorf("%s: got low confidence %v, want %v", test.name,
+				ce.LowConfidence, test.lowConfidence)
+		}
+	}
+}
+
+// mockFeeEstimator serves fee estimates, in coins per kilobyte, for a fixed
+// set of targets and fails for all others.
+type mockFeeEstimator map[int64]float64
+
+func (m mockFeeEstimator) EstimateFee(numBlocks int64) (float64, er.R) {
+	if rate, ok := m[numBlocks]; ok {
+		return rate, nil
+	}
+	return -1, er.New("insufficient data")
+}
+
+// TestEstimateConfirmationTime ensures that the fee rate of an unmined wallet
+// transaction is compared against the estimator's data.
+func TestEstimateConfirmationTime(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	incomingTx := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+	}
+	addUtxo(t, w, incomingTx)
+
+	// Pay 10000 units per kilobyte.
+	spend := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: incomingTx.TxHash()},
+		}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(0, []byte{0x51})},
+	}
+	wantRate := btcutil.Amount(10000)
+	spend.TxOut[0].Value = 1e8 - int64(wantRate)*int64(virtualSize(spend))/1000
+	var b bytes.Buffer
+	if err := spend.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+	rec, err := wtxmgr.NewTxRecord(b.Bytes(), time.Now())
+	if err != nil {
+		t.Fatalf("unable to create tx record: %v", err)
+	}
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		return w.TxStore.InsertTx(tx.ReadWriteBucket(wtxmgrNamespaceKey), rec, nil)
+	})
+	if err != nil {
+		t.Fatalf("unable to insert tx: %v", err)
+	}
+	txHash := spend.TxHash()
+
+	fe := mockFeeEstimator{1: 0.0005, 2: 0.0002, 6: 0.00008, 25: 0.00002}
+	ce, err := w.EstimateConfirmationTime(&txHash, fe)
+	if err != nil {
+		t.Fatalf("unable to estimate confirmation time: %v", err)
+	}
+	if ce.FeeRate != wantRate {
+		t.Fatalf("got fee rate %v, want %v", ce.FeeRate, wantRate)
+	}
+	if ce.Blocks != 6 || ce.LowConfidence {
+		t.Fatalf("got %d blocks low confidence %v, want 6 blocks high "+
+			"confidence", ce.Blocks, ce.LowConfidence)
+	}
+	if ce.Duration != 6*w.chainParams.TargetTimePerBlock {
+		t.Fatalf("got duration %v, want %v", ce.Duration,
+			6*w.chainParams.TargetTimePerBlock)
+	}
+
+	// Without an estimator, as with neutrino, the estimate is flagged.
+	ce, err = w.EstimateConfirmationTime(&txHash, nil)
+	if err != nil {
+		t.Fatalf("unable to estimate confirmation time: %v", err)
+	}
+	if ce.Blocks != conservativeConfirmationBlocks || !ce.LowConfidence {
+		t.Fatalf("got %d blocks low confidence %v, want %d blocks low "+
+			"confidence", ce.Blocks, ce.LowConfidence,
+			conservativeConfirmationBlocks)
+	}
+}
@@ -0,0 +1,238 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txauthor"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/workqueue"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// Config holds the settings of a wallet.  It is given to the Loader, which
+// passes it to each wallet that it creates or opens, and it must not be
+// changed once the wallet is open.  DefaultConfig returns the settings which
+// are used when none are given.
+type Config struct {
+	// BlockNotify is a shell command which is executed every time a block
+	// is connected once the wallet is synced, any occurrence of %s in the
+	// command is replaced with the block hash.  The commands are run one at
+	// a time after the block is committed.  An empty string disables the
+	// feature.
+	BlockNotify string
+
+	// SpendUnconfirmedChange allows coin selection to use unconfirmed
+	// change outputs of transactions which were made by this wallet,
+	// regardless of the requested minconf.  Unconfirmed coins received
+	// from others are never used.
+	SpendUnconfirmedChange bool
+
+	// DistrustReplaceable excludes unconfirmed outputs of transactions
+	// which signal BIP125 replaceability from coin selection and from the
+	// unconfirmed balance, since they may be replaced by a transaction
+	// which does not pay the wallet.  This takes precedence over
+	// SpendUnconfirmedChange.
+	DistrustReplaceable bool
+
+	// MaxFeeRate is the highest fee rate, in atomic units per kilobyte,
+	// which will be used for any transaction.  A requested fee rate which
+	// is higher than this is clamped to it.  Zero means there is no
+	// ceiling.
+	MaxFeeRate btcutil.Amount
+
+	// ReportMaxSpendable adds to insufficient funds errors the most which
+	// the requested outputs could pay in total, so that the caller can
+	// reduce them.
+	ReportMaxSpendable bool
+
+	// PrivacySend splits the change of each send so that it blends with
+	// ordinary payments rather than standing out as the one output of an
+	// odd value.  When all of the payments are of one value the first
+	// change output is of that value too, and the rest of the change is
+	// split into outputs of common denominations, 1, 2 or 5 times a power
+	// of ten coins, as far as it allows.  Each output split from the
+	// change pays a new change address of the wallet.
+	PrivacySend bool
+
+	// WarnSelfSend refuses sends which pay an address of the wallet unless
+	// the send sets AllowSelfSend.  Sending to the wallet is sometimes
+	// intended, to consolidate coins, and sometimes a mistake such as
+	// pasting the wrong address.
+	WarnSelfSend bool
+
+	// MinOutput is the smallest value which a send may pay to an output,
+	// for recipients which reject tiny payments even when they are not
+	// dust.  Zero means that only dust outputs are rejected.
+	MinOutput btcutil.Amount
+
+	// IgnoreIncomingDust quarantines outputs worth less than this which
+	// were sent to the wallet by others.  Such dust can be sent to link
+	// the addresses of the wallet when it is spent together with other
+	// outputs, so quarantined outputs are not selected as inputs and are
+	// not counted in the balance, but they are still listed, flagged as
+	// dust.  Outputs of transactions which spend outputs of the wallet and
+	// coinbase outputs are never quarantined.  Zero disables the
+	// quarantine.
+	IgnoreIncomingDust btcutil.Amount
+
+	// TxVersion is the version of the transactions which the wallet
+	// constructs, it must be between 1 and txauthor.MaxTxVersion.
+	TxVersion int32
+
+	// MaxBumpFee is the most which a single fee bump, either by replacing
+	// a transaction (RBF) or by spending one of its outputs (CPFP), may
+	// add to the fee already paid.  Zero means that bumps are not limited.
+	MaxBumpFee btcutil.Amount
+
+	// MinBumpIncrement is the fee rate, per kilobyte of the replacement,
+	// which a replacement must add to the fee of the transaction it
+	// replaces.  BIP125 requires at least the relay fee rate.
+	MinBumpIncrement btcutil.Amount
+
+	// AutoBumpAfter is the number of blocks after which an unmined wallet
+	// transaction which signals BIP125 replaceability is replaced by one
+	// paying a higher fee.  Zero means that transactions are never bumped
+	// automatically.
+	AutoBumpAfter int32
+
+	// SignalReplacement makes the transactions which the wallet sends
+	// signal BIP125 replaceability, without it they are final and their
+	// fees cannot be bumped by replacement.
+	SignalReplacement bool
+
+	// SpendLimitAmount is the most which may be sent out of the wallet,
+	// including fees, within any SpendLimitWindow.  Spending is only
+	// limited if both are non-zero.  Only transactions which the wallet
+	// broadcasts are counted.
+	SpendLimitAmount btcutil.Amount
+
+	// SpendLimitWindow is the length of the rolling window in which
+	// spending is limited to SpendLimitAmount.
+	SpendLimitWindow time.Duration
+
+	// MempoolExpiry is how long an unmined transaction may go without
+	// confirming before it is dropped from the wallet, freeing the outputs
+	// which it spends.  Zero means that unmined transactions never expire.
+	// An expired transaction which is later mined is added back when its
+	// block is processed.
+	MempoolExpiry time.Duration
+
+	// AutoCreateAccounts creates accounts which are referenced by name but
+	// do not exist, for integrations which use an account before creating
+	// it, rather than failing with ErrAccountNotFound.  It is off by
+	// default because a mistyped account name then silently creates a new
+	// account.
+	AutoCreateAccounts bool
+
+	// ExtraKeyScopes are key scopes, beyond the default scopes, which the
+	// wallet derives and watches addresses under.  Deriving the coin type
+	// key of a scope needs the private root key, so a scope is created
+	// the first time that the wallet is unlocked.  The address schema of
+	// each is given by waddrmgr.SchemaForScope.
+	ExtraKeyScopes []waddrmgr.KeyScope
+
+	// MaxAddressesPerAccount is the most receiving addresses which may be
+	// issued by each account of each key scope, a guard against a buggy
+	// client using up the address indices.  Zero means no limit.
+	MaxAddressesPerAccount uint32
+
+	// AddressGapLimit is the maximum distance between two used addresses
+	// on the same branch which MarkAddressUsed and MarkAddressUnused will
+	// allow.  Address discovery during recovery stops once it has seen
+	// this many consecutive unused addresses, so any larger gap would hide
+	// the funds beyond it.
+	AddressGapLimit uint32
+
+	// FinalityDepth is the number of confirmations below which a
+	// transaction is still considered provisional, as it may yet be undone
+	// by a reorg.  Coins which are received by such a transaction are
+	// counted as maturing rather than spendable in balances.  Zero
+	// disables this.
+	FinalityDepth int32
+
+	// TrustedConfs is the number of confirmations at which
+	// listtransactions and gettransaction report a transaction as trusted.
+	TrustedConfs int32
+
+	// UTXOCacheSize is the most unspent outputs which are kept in memory
+	// for coin selection.  A wallet with more unspent outputs reads them
+	// from the database for every transaction it creates, as it does when
+	// this is zero.
+	UTXOCacheSize int
+
+	// DBFlushCommits and DBFlushInterval batch the updates to the wallet
+	// database, committing up to DBFlushCommits of them in one transaction,
+	// each batch collected for at most DBFlushInterval, see
+	// walletdb.FlushBatcher.  Each update has a transaction of its own when
+	// both are zero.  They are applied by the Loader when it opens the
+	// database.
+	DBFlushCommits  int
+	DBFlushInterval time.Duration
+
+	// DBLockMode is how the Loader locks the wallet database file when it
+	// opens an existing wallet.  With walletdb.LockNone the database is
+	// opened read only, without the exclusive lock.
+	DBLockMode walletdb.LockMode
+
+	// ResumeRescan persists the progress of rescan jobs so that one which
+	// is interrupted by a restart resumes from the last block it scanned
+	// rather than being lost.  Jobs which drop the transaction history are
+	// not persisted since they complete in a single step.
+	ResumeRescan bool
+
+	// MaxConcurrentRescans is the most wallets of the process which may
+	// resync at once, the resyncs of any others wait in turn for one to
+	// finish.  Zero means there is no limit.
+	MaxConcurrentRescans int
+
+	// RecoveryWorkers is the number of blocks which are fetched and
+	// filtered concurrently while the wallet is syncing, resyncing or
+	// recovering.  Results are always applied to the wallet in block order
+	// so the outcome does not depend on the number of workers.
+	RecoveryWorkers int
+
+	// RecoveryProgressInterval is how often the progress of a recovery is
+	// logged, zero means progress is not logged.
+	RecoveryProgressInterval time.Duration
+
+	// MaxReorgDepth is the deepest chain reorganization which the wallet
+	// will roll back.  A deeper reorg is more likely a misbehaving chain
+	// backend than a real event so rather than rewinding the wallet halts.
+	// It cannot be greater than waddrmgr.MaxReorgDepth because older block
+	// hashes are not kept.
+	MaxReorgDepth int32
+
+	// IgnoreNetworkMismatch allows a wallet to be opened for a network
+	// other than the one which it was created for.  Doing so will likely
+	// corrupt the wallet.
+	IgnoreNetworkMismatch bool
+
+	// AllowUpgrade allows the database of a wallet in an older format to
+	// be upgraded when it is opened, after which older versions of the
+	// wallet may not be able to open it.
+	AllowUpgrade bool
+}
+
+// DefaultConfig returns the default settings of a wallet.  Databases in an
+// older format are upgraded, as they always were before the setting existed.
+func DefaultConfig() Config {
+	return Config{
+		TxVersion:                txauthor.MaxTxVersion,
+		MinBumpIncrement:         txrules.DefaultRelayFeePerKb,
+		AddressGapLimit:          20,
+		TrustedConfs:             1,
+		ResumeRescan:             true,
+		RecoveryWorkers:          workqueue.DefaultWorkerCount,
+		RecoveryProgressInterval: 30 * time.Second,
+		MaxReorgDepth:            waddrmgr.MaxReorgDepth,
+		AllowUpgrade:             true,
+		DBLockMode:               walletdb.LockFlock,
+	}
+}
+
+// Config returns the settings which the wallet was opened with.
+func (w *Wallet) Config() Config {
+	return w.cfg
+}
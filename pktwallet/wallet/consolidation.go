@@ -0,0 +1,118 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/internal/txsizes"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// ConsolidationEstimate is the cost of consolidating all of the wallet's
+// spendable outputs into a single output.
+type ConsolidationEstimate struct {
+	// Inputs is the number of outputs which would be consolidated.
+	Inputs int
+
+	// Transactions is the number of transactions needed.  When there are
+	// more inputs than fit in one transaction the outputs of the first
+	// transactions are consolidated again, so this counts every round.
+	Transactions int
+
+	FeeRate btcutil.Amount
+	Fee     btcutil.Amount
+	Amount  btcutil.Amount
+}
+
+// consolidationInputs counts inputs of each of the types which affect the size
+// of a transaction.
+type consolidationInputs struct {
+	p2pkh, p2wpkh, nested int
+}
+
+func (in *consolidationInputs) count() int {
+	return in.p2pkh + in.p2wpkh + in.nested
+}
+
+// take removes up to max inputs, legacy inputs first.
+func (in *consolidationInputs) take(max int) consolidationInputs {
+	var out consolidationInputs
+	for _, c := range []struct{ from, to *int }{
+		{&in.p2pkh, &out.p2pkh},
+		{&in.p2wpkh, &out.p2wpkh},
+		{&in.nested, &out.nested},
+	} {
+		n := *c.from
+		if n > max-out.count() {
+			n = max - out.count()
+		}
+		*c.from -= n
+		*c.to = n
+	}
+	return out
+}
+
+// estimateConsolidation computes the number of transactions and the total fee
+// needed to consolidate the inputs into one P2WPKH output at feeRate.  Each
+// round fills transactions with as many inputs as the limits allow, legacy
+// inputs first since they limit a transaction to MaxInputsPerTxLegacy inputs,
+// and then consolidates the outputs of that round until one output is left.
+func estimateConsolidation(in consolidationInputs,
+	feeRate btcutil.Amount) (int, btcutil.Amount) {
+
+	txOuts := []*wire.TxOut{wire.NewTxOut(0, make([]byte, txsizes.P2WPKHPkScriptSize))}
+	txs := 0
+	fee := btcutil.Amount(0)
+	for in.count() > 1 {
+		outputs := 0
+		for in.count() > 1 {
+			max := MaxInputsPerTx
+			if in.p2pkh > 0 {
+				max = MaxInputsPerTxLegacy
+			}
+			tx := in.take(max)
+			size := txsizes.EstimateVirtualSize(tx.p2pkh, tx.p2wpkh,
+				tx.nested, txOuts, false)
+			fee += txrules.FeeForSerializeSize(feeRate, size)
+			outputs++
+		}
+		// A single input left over is carried into the next round
+		// rather than spent alone.
+		txs += outputs
+		in.p2wpkh += outputs
+	}
+	return txs, fee
+}
+
+// EstimateConsolidation estimates the number of transactions and the total fee
+// needed to consolidate all of the wallet's spendable outputs into a single
+// output paying feeRate, in atomic units per kilobyte.  Outputs which the
+// wallet cannot sign for are not counted.
+func (w *Wallet) EstimateConsolidation(feeRate btcutil.Amount) (*ConsolidationEstimate, er.R) {
+	feeRate = w.clampFeeRate(feeRate)
+	var in consolidationInputs
+	ce := &ConsolidationEstimate{FeeRate: feeRate}
+	err := w.ForEachSnapshotOutput(func(out *SnapshotOutput) er.R {
+		switch out.ScriptClass {
+		case txscript.PubKeyHashTy:
+			in.p2pkh++
+		case txscript.WitnessV0PubKeyHashTy:
+			in.p2wpkh++
+		case txscript.ScriptHashTy:
+			// As in coin selection, P2SH outputs are assumed to be
+			// nested P2WPKH.
+			in.nested++
+		default:
+			return nil
+		}
+		ce.Amount += out.Amount
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	ce.Inputs = in.count()
+	ce.Transactions, ce.Fee = estimateConsolidation(in, feeRate)
+	return ce, nil
+}
@@ -0,0 +1,70 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/internal/txsizes"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestEstimateConsolidation seeds more outputs than fit in one transaction and
+// checks that the estimate consolidates them in two transactions and then
+// consolidates the outputs of those.
+func TestEstimateConsolidation(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	setSyncedTo(t, w, 100)
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const utxos = MaxInputsPerTx + 40
+	tx := &wire.MsgTx{TxIn: []*wire.TxIn{{}}}
+	credits := make([]uint32, 0, utxos)
+	for i := 0; i < utxos; i++ {
+		tx.TxOut = append(tx.TxOut, wire.NewTxOut(1e6, pkScript))
+		credits = append(credits, uint32(i))
+	}
+	insertTestTx(t, w, tx, 90, credits...)
+
+	const feeRate = 1000
+	ce, err := w.EstimateConsolidation(feeRate)
+	if err != nil {
+		t.Fatalf("unable to estimate consolidation: %v", err)
+	}
+	feeFor := func(p2wpkh int) btcutil.Amount {
+		txOuts := []*wire.TxOut{wire.NewTxOut(0, make([]byte, txsizes.P2WPKHPkScriptSize))}
+		return txrules.FeeForSerializeSize(feeRate,
+			txsizes.EstimateVirtualSize(0, p2wpkh, 0, txOuts, false))
+	}
+	wantFee := feeFor(MaxInputsPerTx) + feeFor(40) + feeFor(2)
+	if ce.Inputs != utxos || ce.Transactions != 3 || ce.Fee != wantFee ||
+		ce.Amount != utxos*1e6 {
+		t.Fatalf("got estimate %+v, want %d inputs in 3 transactions "+
+			"paying %v", ce, utxos, wantFee)
+	}
+
+	// Legacy inputs limit a transaction to fewer inputs and a single input
+	// left over is carried into the next round.
+	txs, _ := estimateConsolidation(consolidationInputs{
+		p2pkh:  MaxInputsPerTxLegacy,
+		p2wpkh: MaxInputsPerTx + 1,
+	}, feeRate)
+	if txs != 3 {
+		t.Fatalf("got %d transactions for legacy inputs, want 3", txs)
+	}
+	if txs, fee := estimateConsolidation(consolidationInputs{p2wpkh: 1}, feeRate); txs != 0 || fee != 0 {
+		t.Fatalf("got %d transactions paying %v for a single input, want none",
+			txs, fee)
+	}
+}
@@ -0,0 +1,160 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktlog/log"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/txscript"
+)
+
+// ErrAddressNotConvertible is returned when converting an address which is not
+// a key address of the wallet, or whose key cannot make the requested type of
+// address.
+var ErrAddressNotConvertible = Err.CodeWithDetail("ErrAddressNotConvertible",
+	"the address cannot be converted")
+
+// convertedScopes are the key scopes into which the key of a converted address
+// is imported, by the type of the address converted to.  Keys imported into
+// the nested witness scope cannot be found by their address, so conversions
+// to p2sh-p2wpkh are only made when the result is already the wallet's.
+var convertedScopes = map[waddrmgr.AddressType]waddrmgr.KeyScope{
+	waddrmgr.PubKeyHash:    waddrmgr.KeyScopeBIP0044,
+	waddrmgr.WitnessPubKey: waddrmgr.KeyScopeBIP0084,
+}
+
+// ConvertAddress returns the address of type to which pays the same public key
+// as addr, such as the p2wpkh address of the key of a p2pkh address.  The
+// wallet must hold the private key of addr, watching-only wallets and
+// addresses which are not the wallet's or which pay a script are rejected with
+// ErrAddressNotConvertible.  Unless the returned address is already one of
+// the wallet's, the key is imported into the key scope of its type so that
+// payments to it are seen from the current block on, this needs the wallet to
+// be unlocked.  Conversions to p2sh-p2wpkh addresses which are not already the
+// wallet's are rejected with ErrAddressNotConvertible.
+func (w *Wallet) ConvertAddress(addr btcutil.Address,
+	to waddrmgr.AddressType) (btcutil.Address, er.R) {
+
+	converted, pka, err := w.convertAddress(addr, to)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.importConverted(pka, converted, to); err != nil {
+		return nil, err
+	}
+	return converted, nil
+}
+
+// importConverted imports the key of pka into the key scope of addresses of
+// type to unless converted, its address of that type, is already stored there,
+// and watches converted.
+func (w *Wallet) importConverted(pka waddrmgr.ManagedPubKeyAddress,
+	converted btcutil.Address, to waddrmgr.AddressType) er.R {
+
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		scope, ok := convertedScopes[to]
+		if !ok {
+			ma, err := w.Manager.Address(addrmgrNs, converted)
+			if err == nil && ma.Address().EncodeAddress() == converted.EncodeAddress() {
+				return nil
+			}
+			return ErrAddressNotConvertible.New(fmt.Sprintf("address "+
+				"[%s] is not an address of the wallet and cannot be "+
+				"imported", converted.EncodeAddress()), nil)
+		}
+		manager, err := w.Manager.FetchScopedKeyManager(scope)
+		if err != nil {
+			return err
+		}
+		if _, err := manager.Address(addrmgrNs, converted); err == nil {
+			return nil
+		}
+		privKey, err := pka.PrivKey()
+		if err != nil {
+			return err
+		}
+		wif, err := btcutil.NewWIF(privKey, w.chainParams, pka.Compressed())
+		if err != nil {
+			return err
+		}
+		bs := w.Manager.SyncedTo()
+		if _, err := manager.ImportPrivateKey(addrmgrNs, wif, &bs); err != nil {
+			return err
+		}
+		log.Infof("Imported the key of converted address [%s]",
+			converted.EncodeAddress())
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	w.watch.WatchAddr(converted)
+	return nil
+}
+
+// convertAddress returns the address of type to which pays the same public key
+// as addr, and the wallet's address of that key.
+func (w *Wallet) convertAddress(addr btcutil.Address,
+	to waddrmgr.AddressType) (btcutil.Address, waddrmgr.ManagedPubKeyAddress, er.R) {
+
+	if w.Manager.WatchOnly() {
+		return nil, nil, ErrAddressNotConvertible.New("the wallet is "+
+			"watching-only and holds no private keys", nil)
+	}
+	var pka waddrmgr.ManagedPubKeyAddress
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		ma, err := w.Manager.Address(tx.ReadBucket(waddrmgrNamespaceKey), addr)
+		if waddrmgr.ErrAddressNotFound.Is(err) {
+			return ErrAddressNotConvertible.New(fmt.Sprintf("address "+
+				"[%s] is not in the wallet", addr.EncodeAddress()), nil)
+		} else if err != nil {
+			return err
+		}
+		var ok bool
+		if pka, ok = ma.(waddrmgr.ManagedPubKeyAddress); !ok {
+			return ErrAddressNotConvertible.New(fmt.Sprintf("address "+
+				"[%s] pays a script rather than a key",
+				addr.EncodeAddress()), nil)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if to == waddrmgr.PubKeyHash {
+		hash := btcutil.Hash160(pka.PubKey().SerializeCompressed())
+		if !pka.Compressed() {
+			hash = btcutil.Hash160(pka.PubKey().SerializeUncompressed())
+		}
+		pkh, err := btcutil.NewAddressPubKeyHash(hash, w.chainParams)
+		return pkh, pka, err
+	}
+	if to != waddrmgr.WitnessPubKey && to != waddrmgr.NestedWitnessPubKey {
+		return nil, nil, ErrAddressNotConvertible.New(fmt.Sprintf("cannot "+
+			"convert to address type [%d]", to), nil)
+	}
+	if !pka.Compressed() {
+		return nil, nil, ErrAddressNotConvertible.New(fmt.Sprintf("address "+
+			"[%s] pays an uncompressed key, which segwit addresses "+
+			"cannot", addr.EncodeAddress()), nil)
+	}
+	hash := btcutil.Hash160(pka.PubKey().SerializeCompressed())
+	wpkh, err := btcutil.NewAddressWitnessPubKeyHash(hash, w.chainParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	if to == waddrmgr.WitnessPubKey {
+		return wpkh, pka, nil
+	}
+	script, err := txscript.PayToAddrScript(wpkh)
+	if err != nil {
+		return nil, nil, err
+	}
+	sh, err := btcutil.NewAddressScriptHash(script, w.chainParams)
+	return sh, pka, err
+}
@@ -0,0 +1,155 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// TestConvertAddress ensures that an address of the wallet converts between
+// p2pkh and p2wpkh addresses of the same key, and that addresses which are not
+// the wallet's or which pay a script are rejected.
+func TestConvertAddress(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	legacy, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pubKey, err := w.PubKeyForAddress(legacy)
+	if err != nil {
+		t.Fatalf("unable to get public key: %v", err)
+	}
+	want, err := btcutil.NewAddressWitnessPubKeyHash(
+		btcutil.Hash160(pubKey.SerializeCompressed()), w.chainParams)
+	if err != nil {
+		t.Fatalf("unable to create p2wpkh address: %v", err)
+	}
+
+	segwit, err := w.ConvertAddress(legacy, waddrmgr.WitnessPubKey)
+	if err != nil {
+		t.Fatalf("unable to convert p2pkh address: %v", err)
+	}
+	if segwit.EncodeAddress() != want.EncodeAddress() {
+		t.Fatalf("got p2wpkh address %s, want %s", segwit, want)
+	}
+	back, err := w.ConvertAddress(segwit, waddrmgr.PubKeyHash)
+	if err != nil {
+		t.Fatalf("unable to convert p2wpkh address: %v", err)
+	}
+	if back.EncodeAddress() != legacy.EncodeAddress() {
+		t.Fatalf("got p2pkh address %s converting back, want %s", back,
+			legacy)
+	}
+
+	// An address of a segwit key scope converts to p2pkh and back.
+	owned, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	p2pkh, err := w.ConvertAddress(owned, waddrmgr.PubKeyHash)
+	if err != nil {
+		t.Fatalf("unable to convert p2wpkh address: %v", err)
+	}
+	if _, ok := p2pkh.(*btcutil.AddressPubKeyHash); !ok {
+		t.Fatalf("got %T converting to p2pkh", p2pkh)
+	}
+	again, err := w.ConvertAddress(p2pkh, waddrmgr.WitnessPubKey)
+	if err != nil {
+		t.Fatalf("unable to convert p2pkh address: %v", err)
+	}
+	if again.EncodeAddress() != owned.EncodeAddress() {
+		t.Fatalf("got %s converting %s back, want %s", again, p2pkh, owned)
+	}
+
+	unowned, err := btcutil.NewAddressPubKeyHash(make([]byte, 20), w.chainParams)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	if _, err := w.ConvertAddress(unowned, waddrmgr.WitnessPubKey); !ErrAddressNotConvertible.Is(err) {
+		t.Fatalf("got error %v converting an address which is not the "+
+			"wallet's, want ErrAddressNotConvertible", err)
+	}
+	script, err := w.ImportP2SHRedeemScript([]byte{0x51})
+	if err != nil {
+		t.Fatalf("unable to import script: %v", err)
+	}
+	if _, err := w.ConvertAddress(script, waddrmgr.WitnessPubKey); !ErrAddressNotConvertible.Is(err) {
+		t.Fatalf("got error %v converting a script address, want "+
+			"ErrAddressNotConvertible", err)
+	}
+}
+
+// TestConvertAddressImports ensures that the key of a converted address which
+// is not already stored in the key scope of its type is imported there, so
+// that the address is watched when the wallet is next opened, and that
+// conversions to p2sh-p2wpkh addresses which are not the wallet's are
+// rejected.
+func TestConvertAddressImports(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	legacy, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	active := func() map[string]bool {
+		addrs := make(map[string]bool)
+		err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+			return w.Manager.ForEachActiveAddress(
+				tx.ReadBucket(waddrmgrNamespaceKey),
+				func(addr btcutil.Address) er.R {
+					addrs[addr.EncodeAddress()] = true
+					return nil
+				})
+		})
+		if err != nil {
+			t.Fatalf("unable to list addresses: %v", err)
+		}
+		return addrs
+	}
+
+	w.Lock()
+	if !w.Locked() {
+		t.Fatalf("wallet is not locked")
+	}
+	if _, err := w.ConvertAddress(legacy, waddrmgr.WitnessPubKey); err == nil {
+		t.Fatalf("converted an address needing an import while locked")
+	}
+	if err := w.Unlock([]byte("world"), nil); err != nil {
+		t.Fatalf("unable to unlock wallet: %v", err)
+	}
+	segwit, err := w.ConvertAddress(legacy, waddrmgr.WitnessPubKey)
+	if err != nil {
+		t.Fatalf("unable to convert p2pkh address: %v", err)
+	}
+	if !active()[segwit.EncodeAddress()] {
+		t.Fatalf("converted address %s is not among the wallet's "+
+			"addresses", segwit)
+	}
+	// Converting again finds the imported key.
+	if _, err := w.ConvertAddress(legacy, waddrmgr.WitnessPubKey); err != nil {
+		t.Fatalf("unable to convert p2pkh address again: %v", err)
+	}
+
+	_, err = w.ConvertAddress(legacy, waddrmgr.NestedWitnessPubKey)
+	if !ErrAddressNotConvertible.Is(err) {
+		t.Fatalf("got error %v converting to a p2sh-p2wpkh address which "+
+			"is not the wallet's, want ErrAddressNotConvertible", err)
+	}
+	nested, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0049Plus)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	same, err := w.ConvertAddress(nested, waddrmgr.NestedWitnessPubKey)
+	if err != nil {
+		t.Fatalf("unable to convert p2sh-p2wpkh address to itself: %v", err)
+	}
+	if same.EncodeAddress() != nested.EncodeAddress() {
+		t.Fatalf("got %s converting %s to its own type", same, nested)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/pkt-cash/pktd/pktlog/log"
 	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
 	"github.com/pkt-cash/pktd/pktwallet/wallet/enough"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/internal/txsizes"
 	"github.com/pkt-cash/pktd/pktwallet/wallet/txauthor"
 	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
 	"github.com/pkt-cash/pktd/pktwallet/walletdb"
@@ -35,6 +36,43 @@ const MaxInputsPerTx = 1460
 // at least one legacy non-segwit input
 const MaxInputsPerTxLegacy = 499
 
+// clampFeeRate limits a fee rate to MaxFeeRate, logging a warning if the rate
+// had to be reduced.
+func (w *Wallet) clampFeeRate(feeSatPerKb btcutil.Amount) btcutil.Amount {
+	if w.cfg.MaxFeeRate > 0 && feeSatPerKb > w.cfg.MaxFeeRate {
+		log.Warnf("Fee rate [%s] exceeds the maximum fee rate, using [%s]",
+			txrules.FormatFeeRate(feeSatPerKb), txrules.FormatFeeRate(w.cfg.MaxFeeRate))
+		return w.cfg.MaxFeeRate
+	}
+	return feeSatPerKb
+}
+
+// maxSpendable is the value of the eligible credits less the fee for a
+// transaction spending all of them to outputs, estimated in the same way as
+// txauthor does so that outputs paying exactly this much can be funded.
+func maxSpendable(credits []*wtxmgr.Credit, outputs []*wire.TxOut,
+	feeSatPerKb btcutil.Amount) btcutil.Amount {
+
+	var nested, p2wpkh, p2pkh int
+	total := btcutil.Amount(0)
+	for _, c := range credits {
+		total += c.Amount
+		switch {
+		case txscript.IsPayToScriptHash(c.PkScript):
+			nested++
+		case txscript.IsPayToWitnessPubKeyHash(c.PkScript):
+			p2wpkh++
+		default:
+			p2pkh++
+		}
+	}
+	size := txsizes.EstimateVirtualSize(p2pkh, p2wpkh, nested, outputs, true)
+	if max := total - txrules.FeeForSerializeSize(feeSatPerKb, size); max > 0 {
+		return max
+	}
+	return 0
+}
+
 var InsufficientFundsError = er.GenericErrorType.CodeWithDetail("InsufficientFundsError",
 	"insufficient funds available to construct transaction")
 
@@ -120,6 +158,8 @@ func (s secretSource) GetScript(addr btcutil.Address) ([]byte, er.R) {
 // input scripts added and SHOULD NOT be broadcasted.
 func (w *Wallet) txToOutputs(txr CreateTxReq) (tx *txauthor.AuthoredTx, err er.R) {
 
+	txr.FeeSatPerKB = w.clampFeeRate(txr.FeeSatPerKB)
+
 	chainClient, err := w.requireChainClient()
 	if err != nil {
 		return nil, err
@@ -139,16 +179,26 @@ func (w *Wallet) txToOutputs(txr CreateTxReq) (tx *txauthor.AuthoredTx, err er.R
 		return nil, err
 	}
 
-	isEnough := enough.MkIsEnough(txr.Outputs, txr.FeeSatPerKB)
-	t0 := time.Now()
-	eligibleOuts, visits, err := w.findEligibleOutputs(
-		dbtx, isEnough, txr.InputAddresses, txr.Minconf, bs,
-		txr.InputMinHeight, txr.InputComparator, txr.MaxInputs)
-	if err != nil {
-		return nil, err
+	var eligibleOuts eligibleOutputs
+	if txr.InputOutPoints != nil {
+		eligibleOuts.credits, err = w.namedOutputs(
+			dbtx.ReadBucket(wtxmgrNamespaceKey), txr.InputOutPoints, bs)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		isEnough := enough.MkIsEnough(txr.Outputs, txr.FeeSatPerKB)
+		t0 := time.Now()
+		var visits int
+		eligibleOuts, visits, err = w.findEligibleOutputs(
+			dbtx, isEnough, txr.InputAddresses, txr.Minconf, bs,
+			txr.InputMinHeight, txr.InputComparator, txr.MaxInputs)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("findEligibleOutputs() completed in [%s], visited [%d] utxos",
+			time.Since(t0).String(), visits)
 	}
-	log.Infof("findEligibleOutputs() completed in [%s], visited [%d] utxos",
-		time.Since(t0).String(), visits)
 
 	addrStr := "<all>"
 	if txr.InputAddresses != nil {
@@ -167,6 +217,9 @@ func (w *Wallet) txToOutputs(txr CreateTxReq) (tx *txauthor.AuthoredTx, err er.R
 	}
 
 	inputSource := makeInputSource(eligibleOuts.credits)
+	if txr.InputOutPoints != nil {
+		inputSource = makeAllInputsSource(eligibleOuts.credits)
+	}
 	changeSource := func() ([]byte, er.R) {
 		// Derive the change output script.  As a hack to allow
 		// spending from the imported account, change addresses are
@@ -175,6 +228,10 @@ func (w *Wallet) txToOutputs(txr CreateTxReq) (tx *txauthor.AuthoredTx, err er.R
 		var err er.R
 		if txr.ChangeAddress != nil {
 			changeAddr = *txr.ChangeAddress
+		} else if pinned := w.pinnedChangeAddress(
+			dbtx.ReadBucket(wtxmgrNamespaceKey)); pinned != nil {
+
+			changeAddr = pinned
 		} else {
 			for _, c := range eligibleOuts.credits {
 				_, addrs, _, _ := txscript.ExtractPkScriptAddrs(c.PkScript, w.chainParams)
@@ -191,11 +248,23 @@ func (w *Wallet) txToOutputs(txr CreateTxReq) (tx *txauthor.AuthoredTx, err er.R
 		}
 		return txscript.PayToAddrScript(changeAddr)
 	}
-	tx, err = txauthor.NewUnsignedTransaction(
-		txr.Outputs, txr.FeeSatPerKB, inputSource, changeSource, txr.MaxInputs > -1)
+	tx, err = txauthor.NewUnsignedTransaction(txr.Outputs, txr.FeeSatPerKB,
+		inputSource, changeSource, txr.MaxInputs > -1 && txr.InputOutPoints == nil)
 	if err != nil {
 		if !txauthor.ImpossibleTxError.Is(err) {
 			return nil, err
+		} else if txr.InputOutPoints != nil {
+			var have, want btcutil.Amount
+			for _, c := range eligibleOuts.credits {
+				have += c.Amount
+			}
+			for _, out := range txr.Outputs {
+				want += btcutil.Amount(out.Value)
+			}
+			return nil, InsufficientFundsError.New(fmt.Sprintf("the named "+
+				"inputs have [%s] which is [%s] short of paying [%s] and the fee",
+				have, want-maxSpendable(eligibleOuts.credits, txr.Outputs,
+					txr.FeeSatPerKB), want), err)
 		} else if eligibleOuts.unusedCount > 0 {
 			return nil, TooManyInputsError.New(
 				fmt.Sprintf("additional [%d] transactions containing [%f] coins",
@@ -206,30 +275,62 @@ func (w *Wallet) txToOutputs(txr CreateTxReq) (tx *txauthor.AuthoredTx, err er.R
 					"to spend from these you need to specify minconf=0",
 					eligibleOuts.unconfirmedAmt.ToBTC(), eligibleOuts.unconfirmedCount), err)
 		} else {
+			msg := "wallet does not have enough balance"
 			if txr.InputAddresses != nil {
-				return nil, InsufficientFundsError.New(
-					fmt.Sprintf("address(es) [%s] do not have enough balance", addrStr), err)
-			} else {
-				return nil, InsufficientFundsError.New("wallet does not have enough balance", err)
+				msg = fmt.Sprintf("address(es) [%s] do not have enough balance", addrStr)
+			}
+			if w.cfg.ReportMaxSpendable {
+				msg += fmt.Sprintf(", the outputs can pay at most [%s] in total",
+					maxSpendable(eligibleOuts.credits, txr.Outputs, txr.FeeSatPerKB))
 			}
+			return nil, InsufficientFundsError.New(msg, err)
 		}
 	}
 
+	// The version does not affect the serialize size, so it is set once the
+	// transaction is constructed.
+	tx.Tx.Version = w.cfg.TxVersion
+
 	// Randomize change position, if change exists, before signing.  This
 	// doesn't affect the serialize size, so the change amount will still
-	// be valid.
-	if tx.ChangeIndex >= 0 {
+	// be valid.  A privacy send first splits the change, paying for the
+	// extra outputs from it, and then shuffles all of the outputs.
+	var splitAddrs []btcutil.Address
+	if tx.ChangeIndex >= 0 && w.cfg.PrivacySend {
+		newScripts := func(n int) ([][]byte, er.R) {
+			addrs, scripts, err := w.newChangeScripts(addrmgrNs, n)
+			splitAddrs = addrs
+			return scripts, err
+		}
+		if err := splitPrivacyChange(tx, txr.FeeSatPerKB, newScripts); err != nil {
+			return nil, err
+		}
+		shuffleOutputs(tx)
+	} else if tx.ChangeIndex >= 0 {
 		tx.RandomizeChangePosition()
 	}
+	if w.cfg.SignalReplacement {
+		signalReplacement(tx.Tx)
+	}
+
+	// Expired time locked outputs may be chosen and any may be named, the
+	// locks are applied before signing, or returning a dry run, so that a
+	// spend which cannot be mined yet is refused rather than broadcast.
+	locks, err := txauthor.ApplyTimeLocks(tx.Tx,
+		secretSource{w.Manager, addrmgrNs}, w.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	err = w.checkTimeLocks(dbtx.ReadBucket(wtxmgrNamespaceKey), tx.Tx, locks)
+	if err != nil {
+		return nil, err
+	}
 
 	// If a dry run was requested, we return now before adding the input
 	// scripts, and don't commit the database transaction. The DB will be
 	// rolled back when this method returns to ensure the dry run didn't
 	// alter the DB in any way.
 	if txr.SendMode == SendModeUnsigned {
-		if err := dbtx.Commit(); err != nil {
-			return nil, err
-		}
 		return tx, nil
 	}
 
@@ -243,7 +344,10 @@ func (w *Wallet) txToOutputs(txr CreateTxReq) (tx *txauthor.AuthoredTx, err er.R
 		return nil, err
 	}
 
-	if txr.SendMode != SendModeBcasted {
+	// A transaction which is not broadcast leaves the database untouched,
+	// unless addresses were derived for its split change, which must be
+	// kept in case it is broadcast later.
+	if txr.SendMode != SendModeBcasted && len(splitAddrs) == 0 {
 		return tx, nil
 	}
 
@@ -263,6 +367,7 @@ func (w *Wallet) txToOutputs(txr CreateTxReq) (tx *txauthor.AuthoredTx, err er.R
 		}
 		w.watch.WatchAddrs(addrs)
 	}
+	w.watch.WatchAddrs(splitAddrs)
 
 	return tx, nil
 }
@@ -410,6 +515,7 @@ func (w *Wallet) findEligibleOutputs(
 		return out, 0, err
 	}
 	txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+	addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
 
 	haveAmounts := make(map[string]*amountCount)
 	var winner *amountCount
@@ -419,7 +525,7 @@ func (w *Wallet) findEligibleOutputs(
 	log.Debugf("Looking for unspents to build transaction")
 
 	var visits int
-	if err := w.TxStore.ForEachUnspentOutput(txmgrNs, nil, func(key []byte, output *wtxmgr.Credit) er.R {
+	if err := w.forEachUnspentOutput(txmgrNs, func(key []byte, output *wtxmgr.Credit) er.R {
 
 		visits += 1
 
@@ -432,6 +538,11 @@ func (w *Wallet) findEligibleOutputs(
 			return nil
 		}
 
+		// Taproot outputs are watch only, they cannot yet be signed for.
+		if sc == txscript.WitnessV1TaprootTy {
+			return nil
+		}
+
 		if output.Height >= 0 && output.Height < int32(inputMinHeight) {
 			log.Debugf("Skipping output %s at height %d because it is below minimum %d",
 				output.String(), output.Height, inputMinHeight)
@@ -452,11 +563,20 @@ func (w *Wallet) findEligibleOutputs(
 			}
 		}
 
+		if w.cfg.DistrustReplaceable && output.Replaceable {
+			log.Debugf("Skipping unconfirmed output [%s] of replaceable transaction",
+				output.OutPoint.String())
+			out.unconfirmedCount++
+			out.unconfirmedAmt += output.Amount
+			return nil
+		}
+
 		if minconf > 0 {
 			// Only include this output if it meets the required number of
 			// confirmations.  Coinbase transactions must have have reached
 			// maturity before their outputs may be spent.
-			if !confirmed(minconf, output.Height, bs.Height) {
+			if !confirmed(minconf, output.Height, bs.Height) &&
+				!(w.cfg.SpendUnconfirmedChange && output.OwnChange) {
 				log.Debugf("Skipping unconfirmed output [%s] at height %d [cur height: %d]",
 					output.OutPoint.String(), output.Height, bs.Height)
 				out.unconfirmedCount++
@@ -470,6 +590,29 @@ func (w *Wallet) findEligibleOutputs(
 			return nil
 		}
 
+		// As are outputs paying to frozen addresses.
+		if w.TxStore.IsFrozenScript(txmgrNs, output.PkScript) {
+			return nil
+		}
+
+		// And quarantined dust.
+		if dust, err := w.isIncomingDust(txmgrNs, output); err != nil {
+			return err
+		} else if dust {
+			log.Debugf("Skipping incoming dust output [%s] of [%s]",
+				output.OutPoint.String(), output.Amount.String())
+			return nil
+		}
+
+		// And time locked outputs which cannot be spent yet.
+		if (sc == txscript.ScriptHashTy || sc == txscript.WitnessV0ScriptHashTy) &&
+			!w.creditTimeLockExpired(addrmgrNs, output, bs) {
+
+			log.Debugf("Skipping time locked output [%s]",
+				output.OutPoint.String())
+			return nil
+		}
+
 		// If there is an unspent which references a block header which doesn't
 		// actually exist we've got some trouble. Lets make sure before we try to
 		// spend it.
@@ -555,6 +698,7 @@ func (w *Wallet) findEligibleOutputs(
 
 	if len(burnedOutputs) > 0 {
 		wtxmgrBucket := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+		w.utxoCache.invalidate()
 		log.Infof("Deleting [%d] burned coins", len(burnedOutputs))
 		for _, op := range burnedOutputs {
 			if err := wtxmgr.DeleteRawUnspent(wtxmgrBucket, op); err != nil {
@@ -635,7 +779,8 @@ func (w *Wallet) findEligibleOutputs(
 }
 
 // addrMgrWithChangeSource returns the address manager bucket and a change
-// source function that returns change addresses from said address manager.
+// source function that returns change addresses from said address manager, or
+// the pinned change address if there is one.
 func (w *Wallet) addrMgrWithChangeSource(dbtx walletdb.ReadWriteTx,
 	account uint32) (walletdb.ReadWriteBucket, txauthor.ChangeSource) {
 
@@ -645,6 +790,11 @@ func (w *Wallet) addrMgrWithChangeSource(dbtx walletdb.ReadWriteTx,
 		// scope responsible for P2WPKH addresses to do so. As a hack to
 		// allow spending from the imported account, change addresses
 		// are created from account 0.
+		if pkScript := w.TxStore.ChangeScript(
+			dbtx.ReadBucket(wtxmgrNamespaceKey)); pkScript != nil {
+
+			return pkScript, nil
+		}
 		var changeAddr btcutil.Address
 		var err er.R
 		changeKeyScope := waddrmgr.KeyScopeBIP0084
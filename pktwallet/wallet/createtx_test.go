@@ -17,11 +17,16 @@ import (
 	"github.com/pkt-cash/pktd/chaincfg"
 	"github.com/pkt-cash/pktd/chaincfg/chainhash"
 	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/enough"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/internal/txsizes"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
 	"github.com/pkt-cash/pktd/pktwallet/walletdb"
 	_ "github.com/pkt-cash/pktd/pktwallet/walletdb/bdb"
 	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
 	"github.com/pkt-cash/pktd/txscript"
 	"github.com/pkt-cash/pktd/wire"
+	"github.com/pkt-cash/pktd/wire/constants"
+	"strings"
 )
 
 var (
@@ -259,3 +264,342 @@ func addUtxo(t *testing.T, w *Wallet, incomingTx *wire.MsgTx) {
 		t.Fatalf("failed inserting tx: %v", err)
 	}
 }
+
+// TestSpendUnconfirmedChange checks that unconfirmed change from the wallet's
+// own transactions is only eligible for coin selection when
+// SpendUnconfirmedChange is set, and that unconfirmed coins from others never
+// are.
+func TestSpendUnconfirmedChange(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.CurrentAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get current address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+
+	// A confirmed output which our own transaction spends.
+	incomingTx := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(100000, pkScript)},
+	}
+	addUtxo(t, w, incomingTx)
+
+	ownTx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: incomingTx.TxHash()},
+		}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(90000, pkScript)},
+	}
+	foreignTx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{0x01}},
+		}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(80000, pkScript)},
+	}
+	for _, tx := range []*wire.MsgTx{ownTx, foreignTx} {
+		rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+		if err != nil {
+			t.Fatalf("unable to create tx record: %v", err)
+		}
+		if err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+			ns := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+			if err := w.TxStore.InsertTx(ns, rec, nil); err != nil {
+				return err
+			}
+			return w.TxStore.AddCredit(ns, rec, nil, 0, true)
+		}); err != nil {
+			t.Fatalf("failed inserting unmined tx: %v", err)
+		}
+	}
+
+	findEligible := func() eligibleOutputs {
+		var out eligibleOutputs
+		bs, err := w.chainClient.BlockStamp()
+		if err != nil {
+			t.Fatalf("unable to get blockstamp: %v", err)
+		}
+		// Ask for more than the wallet holds so that every output is
+		// visited regardless of iteration order.
+		isEnough := enough.MkIsEnough(
+			[]*wire.TxOut{wire.NewTxOut(1e9, pkScript)}, 1000)
+		if err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+			var err er.R
+			out, _, err = w.findEligibleOutputs(dbtx, isEnough, nil, 1, bs, 0, nil, -1)
+			return err
+		}); err != nil {
+			t.Fatalf("findEligibleOutputs failed: %v", err)
+		}
+		return out
+	}
+
+	w.cfg.SpendUnconfirmedChange = false
+	out := findEligible()
+	if len(out.credits) != 0 {
+		t.Fatalf("expected no eligible credits, got %d", len(out.credits))
+	}
+	if out.unconfirmedCount != 2 {
+		t.Fatalf("expected 2 unconfirmed credits, got %d", out.unconfirmedCount)
+	}
+
+	w.cfg.SpendUnconfirmedChange = true
+	out = findEligible()
+	if len(out.credits) != 1 {
+		t.Fatalf("expected 1 eligible credit, got %d", len(out.credits))
+	}
+	if want := (wire.OutPoint{Hash: ownTx.TxHash()}); out.credits[0].OutPoint != want {
+		t.Fatalf("expected own change %v to be eligible, got %v",
+			want, out.credits[0].OutPoint)
+	}
+	if out.unconfirmedCount != 1 {
+		t.Fatalf("expected third party coins to remain excluded, "+
+			"got %d unconfirmed", out.unconfirmedCount)
+	}
+}
+
+// TestDistrustReplaceable checks that unconfirmed coins received in a BIP125
+// replaceable transaction are excluded from coin selection and the unconfirmed
+// balance when DistrustReplaceable is set, while non-replaceable ones are not.
+func TestDistrustReplaceable(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.CurrentAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get current address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+
+	replaceableTx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{0x01}},
+			Sequence:         0,
+		}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(70000, pkScript)},
+	}
+	finalTx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{0x02}},
+			Sequence:         constants.MaxTxInSequenceNum,
+		}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(50000, pkScript)},
+	}
+	for _, tx := range []*wire.MsgTx{replaceableTx, finalTx} {
+		rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+		if err != nil {
+			t.Fatalf("unable to create tx record: %v", err)
+		}
+		if err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+			ns := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+			if err := w.TxStore.InsertTx(ns, rec, nil); err != nil {
+				return err
+			}
+			return w.TxStore.AddCredit(ns, rec, nil, 0, false)
+		}); err != nil {
+			t.Fatalf("failed inserting unmined tx: %v", err)
+		}
+	}
+
+	findEligible := func() eligibleOutputs {
+		var out eligibleOutputs
+		bs, err := w.chainClient.BlockStamp()
+		if err != nil {
+			t.Fatalf("unable to get blockstamp: %v", err)
+		}
+		// Ask for more than the wallet holds so that every output is
+		// visited regardless of iteration order.
+		isEnough := enough.MkIsEnough(
+			[]*wire.TxOut{wire.NewTxOut(1e9, pkScript)}, 1000)
+		if err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+			var err er.R
+			out, _, err = w.findEligibleOutputs(dbtx, isEnough, nil, 0, bs, 0, nil, -1)
+			return err
+		}); err != nil {
+			t.Fatalf("findEligibleOutputs failed: %v", err)
+		}
+		return out
+	}
+
+	w.cfg.SpendUnconfirmedChange = true
+
+	w.cfg.DistrustReplaceable = false
+	if out := findEligible(); len(out.credits) != 2 {
+		t.Fatalf("expected 2 eligible credits, got %d", len(out.credits))
+	}
+	if bal, err := w.CalculateBalance(0); err != nil {
+		t.Fatalf("unable to calculate balance: %v", err)
+	} else if bal != 120000 {
+		t.Fatalf("expected balance 120000, got %v", int64(bal))
+	}
+
+	w.cfg.DistrustReplaceable = true
+	out := findEligible()
+	if len(out.credits) != 1 {
+		t.Fatalf("expected 1 eligible credit, got %d", len(out.credits))
+	}
+	if want := (wire.OutPoint{Hash: finalTx.TxHash()}); out.credits[0].OutPoint != want {
+		t.Fatalf("expected non-replaceable %v to be eligible, got %v",
+			want, out.credits[0].OutPoint)
+	}
+	if bal, err := w.CalculateBalance(0); err != nil {
+		t.Fatalf("unable to calculate balance: %v", err)
+	} else if bal != 50000 {
+		t.Fatalf("expected balance 50000, got %v", int64(bal))
+	}
+}
+
+// TestClampFeeRate checks that fee rates above MaxFeeRate are clamped to it
+// and that no ceiling applies when it is unset.
+func TestClampFeeRate(t *testing.T) {
+	w := &Wallet{cfg: DefaultConfig()}
+	if fee := w.clampFeeRate(1e6); fee != 1e6 {
+		t.Fatalf("expected unclamped fee rate 1000000, got %d", fee)
+	}
+
+	w.cfg.MaxFeeRate = 5000
+	if fee := w.clampFeeRate(1e6); fee != 5000 {
+		t.Fatalf("expected fee rate to be clamped to 5000, got %d", fee)
+	}
+	if fee := w.clampFeeRate(1000); fee != 1000 {
+		t.Fatalf("expected fee rate below ceiling to be kept, got %d", fee)
+	}
+}
+
+// TestMinOutput ensures that a send paying less than MinOutput to an output is
+// rejected and that one paying at least MinOutput is created.
+func TestMinOutput(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.cfg.MinOutput = 50000
+
+	addr, err := w.CurrentAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get current address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+	addUtxo(t, w, &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, pkScript)},
+	})
+
+	send := func(value int64) er.R {
+		_, err := w.SendOutputs(CreateTxReq{
+			Outputs:     []*wire.TxOut{wire.NewTxOut(value, pkScript)},
+			Minconf:     1,
+			FeeSatPerKB: 1000,
+			SendMode:    SendModeUnsigned,
+		})
+		return err
+	}
+	if err := send(49999); !ErrOutputBelowMin.Is(err) {
+		t.Fatalf("got error %v for an output below the minimum, want "+
+			"ErrOutputBelowMin", err)
+	}
+	for _, value := range []int64{50000, 60000} {
+		if err := send(value); err != nil {
+			t.Fatalf("unable to send %d: %v", value, err)
+		}
+	}
+}
+
+// TestTxVersion ensures that the transactions which the wallet creates carry
+// the version of its Config.
+func TestTxVersion(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.CurrentAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get current address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+	addUtxo(t, w, &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, pkScript)},
+	})
+
+	for _, version := range []int32{1, 2} {
+		w.cfg.TxVersion = version
+		tx, err := w.SendOutputs(CreateTxReq{
+			Outputs:     []*wire.TxOut{wire.NewTxOut(1e6, pkScript)},
+			Minconf:     1,
+			FeeSatPerKB: 1000,
+			SendMode:    SendModeUnsigned,
+		})
+		if err != nil {
+			t.Fatalf("unable to create transaction: %v", err)
+		}
+		if tx.Tx.Version != version {
+			t.Fatalf("got transaction version %d, want %d",
+				tx.Tx.Version, version)
+		}
+	}
+}
+
+// TestReportMaxSpendable ensures that with ReportMaxSpendable an insufficient
+// funds error carries the most which the outputs can pay, and that a send of
+// exactly that much succeeds.
+func TestReportMaxSpendable(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.cfg.ReportMaxSpendable = true
+
+	addr, err := w.CurrentAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get current address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+	for i, value := range []int64{1e8, 2e8} {
+		insertTestTx(t, w, &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Index: uint32(i)}}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(value, pkScript)},
+		}, 100, 0)
+	}
+
+	send := func(value int64) er.R {
+		_, err := w.SendOutputs(CreateTxReq{
+			Outputs:     []*wire.TxOut{wire.NewTxOut(value, []byte{0x51})},
+			Minconf:     1,
+			FeeSatPerKB: 1000,
+			MaxInputs:   -1,
+			SendMode:    SendModeUnsigned,
+		})
+		return err
+	}
+	txOuts := []*wire.TxOut{wire.NewTxOut(0, []byte{0x51})}
+	max := 3e8 - txrules.FeeForSerializeSize(1000,
+		txsizes.EstimateVirtualSize(0, 2, 0, txOuts, true))
+
+	err = send(4e8)
+	if !InsufficientFundsError.Is(err) {
+		t.Fatalf("got error %v, want InsufficientFundsError", err)
+	}
+	if want := "at most [" + max.String() + "]"; !strings.Contains(err.String(), want) {
+		t.Fatalf("got error %v, want it to contain %q", err, want)
+	}
+	if err := send(int64(max) + 1); !InsufficientFundsError.Is(err) {
+		t.Fatalf("got error %v sending more than the max, want "+
+			"InsufficientFundsError", err)
+	}
+	if err := send(int64(max)); err != nil {
+		t.Fatalf("unable to send the max of %v: %v", max, err)
+	}
+}
@@ -0,0 +1,21 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// isIncomingDust returns whether c is quarantined by IgnoreIncomingDust.
+func (w *Wallet) isIncomingDust(txmgrNs walletdb.ReadBucket, c *wtxmgr.Credit) (bool, er.R) {
+	if w.cfg.IgnoreIncomingDust <= 0 || c.Amount >= w.cfg.IgnoreIncomingDust ||
+		c.FromCoinBase || c.OwnChange {
+
+		return false, nil
+	}
+	details, err := w.TxStore.TxDetails(txmgrNs, &c.OutPoint.Hash)
+	if err != nil {
+		return false, err
+	}
+	return details == nil || len(details.Debits) == 0, nil
+}
@@ -0,0 +1,89 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestIgnoreIncomingDust ensures that dust sent to the wallet is flagged in
+// listunspent, left out of the wallet, address and account balances and never
+// selected as an input while IgnoreIncomingDust is set.
+func TestIgnoreIncomingDust(t *testing.T) {
+	w, _, txs, cleanup := fundedTestWallet(t, 1e8, 5e4)
+	defer cleanup()
+	dust := txs[1]
+
+	w.cfg.IgnoreIncomingDust = 1e5
+	setSyncedTo(t, w, 100)
+
+	balance, err := w.CalculateBalance(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != 1e8 {
+		t.Fatalf("got balance %v, want the dust to be excluded", balance)
+	}
+	bals, err := w.CalculateAddressBalances(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bals) != 1 {
+		t.Fatalf("got balances of %d addresses, want 1", len(bals))
+	}
+	for _, bal := range bals {
+		if bal.Total != 1e8+5e4 || bal.Spendable != 1e8 || bal.Dust != 5e4 {
+			t.Fatalf("got address balance %+v, want the dust counted "+
+				"apart from the spendable balance", bal)
+		}
+	}
+	accounts, err := w.ListAccounts(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range accounts {
+		if a.Scope == waddrmgr.KeyScopeBIP0084 && a.AccountNumber == 0 &&
+			a.Balance != 1e8 {
+
+			t.Fatalf("got account balance %v, want the dust to be "+
+				"excluded", a.Balance)
+		}
+	}
+	unspent, err := w.ListUnspent(0, 999999, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unspent) != 2 {
+		t.Fatalf("got %d unspent outputs, want 2", len(unspent))
+	}
+	for _, u := range unspent {
+		isDust := u.TxID == dust.TxHash().String()
+		if u.Dust != isDust || u.Spendable == isDust {
+			t.Fatalf("output %s:%d: got dust %v and spendable %v",
+				u.TxID, u.Vout, u.Dust, u.Spendable)
+		}
+	}
+
+	// The payment can only be made by also spending the dust.
+	req := CreateTxReq{
+		Outputs:     []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+		Minconf:     1,
+		FeeSatPerKB: 1000,
+		MaxInputs:   -1,
+		SendMode:    SendModeUnsigned,
+	}
+	if _, err := w.CreateSimpleTx(req); !InsufficientFundsError.Is(err) {
+		t.Fatalf("got error %v spending the quarantined dust, want "+
+			"InsufficientFundsError", err)
+	}
+
+	w.cfg.IgnoreIncomingDust = 0
+	tx, err := w.CreateSimpleTx(req)
+	if err != nil {
+		t.Fatalf("unable to spend the dust without the quarantine: %v", err)
+	}
+	if len(tx.Tx.TxIn) != 2 {
+		t.Fatalf("got %d inputs, want 2", len(tx.Tx.TxIn))
+	}
+}
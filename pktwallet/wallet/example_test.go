@@ -17,6 +17,22 @@ var defaultDBTimeout = 10 * time.Second //nolint:unused
 
 // testWallet creates a test wallet and unlocks it.
 func testWallet(t *testing.T) (*Wallet, func()) {
+	return testWalletWithParams(t, &chaincfg.TestNet3Params)
+}
+
+// testWalletWithParams creates a test wallet for the given network and
+// unlocks it.
+func testWalletWithParams(t *testing.T, params *chaincfg.Params) (*Wallet, func()) {
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.MinSeedBytes)
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+	return testWalletWithSeed(t, params, seed)
+}
+
+// testWalletWithSeed creates a test wallet for the given network from the
+// given seed and unlocks it.
+func testWalletWithSeed(t *testing.T, params *chaincfg.Params, seed []byte) (*Wallet, func()) {
 	// Set up a wallet.
 	dir, errr := ioutil.TempDir("", "test_wallet")
 	if errr != nil {
@@ -29,16 +45,11 @@ func testWallet(t *testing.T) (*Wallet, func()) {
 		}
 	}
 
-	seed, err := hdkeychain.GenerateSeed(hdkeychain.MinSeedBytes)
-	if err != nil {
-		t.Fatalf("unable to create seed: %v", err)
-	}
-
 	pubPass := []byte("hello")
 	privPass := []byte("world")
 
 	loader := NewLoader(
-		&chaincfg.TestNet3Params, dir, "wallet.db", true, 250,
+		params, dir, "wallet.db", true, 250,
 	)
 	w, err := loader.CreateNewWallet(pubPass, privPass,
 		[]byte(hex.EncodeToString(seed)), time.Now(), nil)
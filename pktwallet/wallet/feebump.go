@@ -0,0 +1,483 @@
+package wallet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktlog/log"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txauthor"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+	"github.com/pkt-cash/pktd/wire/constants"
+)
+
+// replaceableSequence is the sequence of the inputs of a transaction which
+// signals BIP125 replaceability.  It is low enough to signal while leaving
+// the locktime enforced.
+const replaceableSequence = constants.MaxTxInSequenceNum - 2
+
+// signalReplacement makes tx signal BIP125 replaceability.  Inputs whose
+// sequence already signals, such as those with relative time locks, are left
+// alone.
+func signalReplacement(tx *wire.MsgTx) {
+	for _, txIn := range tx.TxIn {
+		if txIn.Sequence > replaceableSequence {
+			txIn.Sequence = replaceableSequence
+		}
+	}
+}
+
+// ErrBumpFeeTooHigh is returned when a fee bump would add more than
+// MaxBumpFee to the fee already paid.
+var ErrBumpFeeTooHigh = Err.CodeWithDetail("ErrBumpFeeTooHigh",
+	"fee bump exceeds the maximum bump fee")
+
+// ErrBumpFeeTooLow is returned when a replacement would add less than
+// MinBumpIncrement to the fee of the transaction it replaces.
+var ErrBumpFeeTooLow = Err.CodeWithDetail("ErrBumpFeeTooLow",
+	"fee bump is less than the minimum bump increment")
+
+// ErrCannotBump is returned when the fee of a transaction cannot be bumped.
+var ErrCannotBump = Err.CodeWithDetail("ErrCannotBump",
+	"the fee of the transaction cannot be bumped")
+
+// checkBumpFee returns ErrBumpFeeTooHigh if a bump from oldFee to newFee adds
+// more than MaxBumpFee.  Every fee bump must be checked with it before the
+// bumping transaction is signed.
+func (w *Wallet) checkBumpFee(oldFee, newFee btcutil.Amount) er.R {
+	if w.cfg.MaxBumpFee == 0 || newFee-oldFee <= w.cfg.MaxBumpFee {
+		return nil
+	}
+	return ErrBumpFeeTooHigh.New(fmt.Sprintf("bumping the fee from [%s] to "+
+		"[%s] adds [%s] which is more than the maximum bump fee of [%s]",
+		oldFee, newFee, newFee-oldFee, w.cfg.MaxBumpFee), nil)
+}
+
+// minBumpFee returns the least which a replacement of vsize virtual bytes must
+// add to the fee of the transaction it replaces.
+func (w *Wallet) minBumpFee(vsize int) btcutil.Amount {
+	return txrules.FeeForSerializeSize(w.cfg.MinBumpIncrement, vsize)
+}
+
+// checkBumpIncrement returns ErrBumpFeeTooLow if a replacement of vsize virtual
+// bytes raising the fee from oldFee to newFee adds less than MinBumpIncrement.
+// Every replacement must be checked with it once it is signed, as its size is
+// then known.
+func (w *Wallet) checkBumpIncrement(oldFee, newFee btcutil.Amount, vsize int) er.R {
+	if min := w.minBumpFee(vsize); newFee-oldFee < min {
+		return ErrBumpFeeTooLow.New(fmt.Sprintf("bumping the fee from "+
+			"[%s] to [%s] adds [%s] which is less than the [%s] which "+
+			"the minimum bump increment requires", oldFee, newFee,
+			newFee-oldFee, min), nil)
+	}
+	return nil
+}
+
+// bumpedFee returns the fee which a replacement of vsize virtual bytes pays
+// for a transaction paying oldFee.  This is twice the old fee, or more if
+// needed to add MinBumpIncrement, but adds no more than MaxBumpFee.
+func (w *Wallet) bumpedFee(oldFee btcutil.Amount, vsize int) (btcutil.Amount, er.R) {
+	minFee := oldFee + w.minBumpFee(vsize)
+	if err := w.checkBumpFee(oldFee, minFee); err != nil {
+		return 0, err
+	}
+	fee := 2 * oldFee
+	if fee < minFee {
+		fee = minFee
+	}
+	if w.cfg.MaxBumpFee > 0 && fee-oldFee > w.cfg.MaxBumpFee {
+		fee = oldFee + w.cfg.MaxBumpFee
+	}
+	return fee, nil
+}
+
+// bumpPlan is how the fee of a transaction is bumped by replacing it.
+type bumpPlan struct {
+	oldFee btcutil.Amount
+	newFee btcutil.Amount
+
+	// change is the index of the output which pays the higher fee.
+	change int
+}
+
+// planBump checks that the fee of an unmined wallet transaction can be bumped
+// by replacing it and returns how, otherwise it returns ErrCannotBump or
+// ErrBumpFeeTooHigh saying why not.
+func (w *Wallet) planBump(addrmgrNs walletdb.ReadBucket,
+	details *wtxmgr.TxDetails) (*bumpPlan, er.R) {
+
+	if details.Block.Height >= 0 {
+		return nil, ErrCannotBump.New("the transaction is mined", nil)
+	}
+	if !wtxmgr.SignalsReplacement(&details.MsgTx) {
+		return nil, ErrCannotBump.New("the transaction does not signal "+
+			"replaceability", nil)
+	}
+	oldFee, ok := txFee(details)
+	if !ok {
+		return nil, ErrCannotBump.New("the transaction spends outputs "+
+			"which are not the wallet's", nil)
+	}
+	newFee, err := w.bumpedFee(oldFee, virtualSize(&details.MsgTx))
+	if err != nil {
+		return nil, err
+	}
+
+	change := -1
+	for i, out := range details.MsgTx.TxOut {
+		if change >= 0 && out.Value <= details.MsgTx.TxOut[change].Value {
+			continue
+		}
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			out.PkScript, w.chainParams)
+		if err != nil || len(addrs) != 1 {
+			continue
+		}
+		if _, err := w.Manager.Address(addrmgrNs, addrs[0]); err == nil {
+			change = i
+		}
+	}
+	if change < 0 {
+		return nil, ErrCannotBump.New("the transaction has no output "+
+			"paying the wallet to pay the higher fee", nil)
+	}
+	changeOut := details.MsgTx.TxOut[change]
+	value := changeOut.Value - int64(newFee-oldFee)
+	if value < 0 || txrules.IsDustAmount(btcutil.Amount(value),
+		len(changeOut.PkScript), txrules.DefaultRelayFeePerKb) {
+
+		return nil, ErrCannotBump.New(fmt.Sprintf("the change output is "+
+			"too small to pay the higher fee of [%s]", newFee), nil)
+	}
+	return &bumpPlan{oldFee: oldFee, newFee: newFee, change: change}, nil
+}
+
+// replaceByFee replaces an unmined wallet transaction which signals BIP125
+// replaceability with one which spends the same inputs and pays the same
+// outputs, except that the higher fee is taken out of the largest output which
+// pays the wallet, normally its change.  The replacement is checked as a send
+// is, it must pay no output below MinOutput and the fee which it adds counts
+// against the spend limit.  It is signed and published, and the replaced
+// transaction is removed from the wallet unless publishing fails.
+func (w *Wallet) replaceByFee(details *wtxmgr.TxDetails) (*wire.MsgTx, er.R) {
+	tx := &wire.MsgTx{
+		Version:  details.MsgTx.Version,
+		LockTime: details.MsgTx.LockTime,
+	}
+	var plan *bumpPlan
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+
+		var err er.R
+		plan, err = w.planBump(addrmgrNs, details)
+		if err != nil {
+			return err
+		}
+		for _, out := range details.MsgTx.TxOut {
+			tx.TxOut = append(tx.TxOut, wire.NewTxOut(out.Value, out.PkScript))
+		}
+		tx.TxOut[plan.change].Value -= int64(plan.newFee - plan.oldFee)
+
+		for _, in := range details.MsgTx.TxIn {
+			prevOut := in.PreviousOutPoint
+			prev, err := w.TxStore.TxDetails(txmgrNs, &prevOut.Hash)
+			if err != nil {
+				return err
+			}
+			if prev == nil || int(prevOut.Index) >= len(prev.MsgTx.TxOut) {
+				return ErrCannotBump.New(fmt.Sprintf("the spent output "+
+					"[%s] is not known", prevOut), nil)
+			}
+			spent := prev.MsgTx.TxOut[prevOut.Index]
+			value := spent.Value
+			tx.TxIn = append(tx.TxIn, &wire.TxIn{
+				PreviousOutPoint: prevOut,
+				Sequence:         in.Sequence,
+			})
+			tx.Additional = append(tx.Additional, wire.TxInAdditional{
+				PkScript: spent.PkScript,
+				Value:    &value,
+			})
+		}
+		return txauthor.AddAllInputScripts(tx, secretSource{w.Manager, addrmgrNs})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := w.checkSendOutputs(tx.TxOut); err != nil {
+		return nil, err
+	}
+	if err := w.checkBumpIncrement(plan.oldFee, plan.newFee, virtualSize(tx)); err != nil {
+		return nil, err
+	}
+	if err := validateMsgTx1(tx); err != nil {
+		return nil, err
+	}
+
+	// Only the higher fee is counted, the rest of what the replacement
+	// sends was counted when the replaced transaction was sent.
+	now := time.Now()
+	txHash := tx.TxHash()
+	if err := w.reserveSpend(&txHash, plan.newFee-plan.oldFee, now); err != nil {
+		return nil, err
+	}
+	replaced := details.TxRecord
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+		w.utxoCache.invalidate()
+		return w.TxStore.RemoveUnminedTx(txmgrNs, &replaced)
+	})
+	if err != nil {
+		if err := w.releaseSpend(&txHash, now); err != nil {
+			log.Warnf("Unable to release spend of [%s]: [%s]",
+				txHash, err.String())
+		}
+		return nil, err
+	}
+	if _, err := w.ReliablyPublishTransaction(tx, details.Label); err != nil {
+		if err := w.releaseSpend(&txHash, now); err != nil {
+			log.Warnf("Unable to release spend of [%s]: [%s]",
+				txHash, err.String())
+		}
+		// Keep the replaced transaction, it may still be mined.
+		restoreErr := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+			return w.addRelevantTx(dbtx, &replaced, nil)
+		})
+		if restoreErr != nil {
+			log.Warnf("Unable to restore transaction [%s] which failed to "+
+				"be replaced: [%s]", replaced.Hash, restoreErr.String())
+		}
+		return nil, err
+	}
+	log.Infof("Replaced transaction [%s] with [%s] raising the fee from [%s] "+
+		"to [%s]", replaced.Hash, tx.TxHash(), plan.oldFee, plan.newFee)
+	return tx, nil
+}
+
+// txDetails returns the details of a wallet transaction, or ErrNoExists if
+// the wallet has no record of it.
+func (w *Wallet) txDetails(txHash *chainhash.Hash) (*wtxmgr.TxDetails, er.R) {
+	var details *wtxmgr.TxDetails
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		var err er.R
+		details, err = w.TxStore.TxDetails(
+			dbtx.ReadBucket(wtxmgrNamespaceKey), txHash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, wtxmgr.ErrNoExists.New("transaction not found in wallet", nil)
+	}
+	return details, nil
+}
+
+// BumpFee replaces an unmined wallet transaction which signals BIP125
+// replaceability with one paying a higher fee, as autobumpafter does, and
+// returns the replacement.  The fee is raised as bumpedFee says and so adds no
+// more than MaxBumpFee.
+func (w *Wallet) BumpFee(txHash *chainhash.Hash) (*wire.MsgTx, er.R) {
+	details, err := w.txDetails(txHash)
+	if err != nil {
+		return nil, err
+	}
+	return w.replaceByFee(details)
+}
+
+// BumpFeeCPFP bumps the fee of an unmined wallet transaction by publishing a
+// child which spends the largest unspent output of it paying the wallet, so
+// that miners must mine the transaction to collect the child's fee.  The child
+// pays the output back to the pinned change address if there is one, else to
+// the address which it spends from.  The child's fee is chosen so that the
+// two together pay what bumpedFee says a replacement of their combined size
+// would, so it adds no more than MaxBumpFee.  The child is checked as a send
+// is and its fee counts against the spend limit.
+func (w *Wallet) BumpFeeCPFP(txHash *chainhash.Hash) (*wire.MsgTx, er.R) {
+	details, err := w.txDetails(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if details.Block.Height >= 0 {
+		return nil, ErrCannotBump.New("the transaction is mined", nil)
+	}
+	parentFee, ok := txFee(details)
+	if !ok {
+		return nil, ErrCannotBump.New("the fee of the transaction is not "+
+			"known as it spends outputs which are not the wallet's", nil)
+	}
+
+	tx := &wire.MsgTx{Version: details.MsgTx.Version}
+	var childFee btcutil.Amount
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+
+		spend := -1
+		for _, c := range details.Credits {
+			out := details.MsgTx.TxOut[c.Index]
+			if c.Spent || w.TxStore.IsFrozenScript(txmgrNs, out.PkScript) ||
+				txscript.GetScriptClass(out.PkScript) ==
+					txscript.WitnessV1TaprootTy {
+
+				continue
+			}
+			if spend < 0 || out.Value > details.MsgTx.TxOut[spend].Value {
+				spend = int(c.Index)
+			}
+		}
+		if spend < 0 {
+			return ErrCannotBump.New("the transaction has no unspent "+
+				"output paying the wallet for a child to spend", nil)
+		}
+		spent := details.MsgTx.TxOut[spend]
+		pkScript := spent.PkScript
+		if pinned := w.TxStore.ChangeScript(txmgrNs); pinned != nil {
+			pkScript = pinned
+		}
+		value := spent.Value
+		tx.TxIn = []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: details.Hash, Index: uint32(spend)},
+			Sequence:         constants.MaxTxInSequenceNum,
+		}}
+		tx.TxOut = []*wire.TxOut{wire.NewTxOut(value, pkScript)}
+		tx.Additional = []wire.TxInAdditional{{PkScript: spent.PkScript, Value: &value}}
+		if w.cfg.SignalReplacement {
+			signalReplacement(tx)
+		}
+
+		// Sign once to learn the size of the child, then again once
+		// its fee is taken out of the output.
+		secrets := secretSource{w.Manager, addrmgrNs}
+		if err := txauthor.AddAllInputScripts(tx, secrets); err != nil {
+			return err
+		}
+		size := virtualSize(&details.MsgTx) + virtualSize(tx)
+		newFee, err := w.bumpedFee(parentFee, size)
+		if err != nil {
+			return err
+		}
+		childFee = newFee - parentFee
+		tx.TxOut[0].Value -= int64(childFee)
+		if tx.TxOut[0].Value < 0 || txrules.IsDustAmount(
+			btcutil.Amount(tx.TxOut[0].Value), len(pkScript),
+			txrules.DefaultRelayFeePerKb) {
+
+			return ErrCannotBump.New(fmt.Sprintf("the output [%s] is too "+
+				"small to pay the child's fee of [%s]",
+				tx.TxIn[0].PreviousOutPoint, childFee), nil)
+		}
+		return txauthor.AddAllInputScripts(tx, secrets)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := w.checkSendOutputs(tx.TxOut); err != nil {
+		return nil, err
+	}
+	if err := validateMsgTx1(tx); err != nil {
+		return nil, err
+	}
+
+	// Only the child's fee is counted, what it pays goes back to the
+	// wallet.
+	now := time.Now()
+	childHash := tx.TxHash()
+	if err := w.reserveSpend(&childHash, childFee, now); err != nil {
+		return nil, err
+	}
+	if _, err := w.ReliablyPublishTransaction(tx, ""); err != nil {
+		if err := w.releaseSpend(&childHash, now); err != nil {
+			log.Warnf("Unable to release spend of [%s]: [%s]",
+				childHash, err.String())
+		}
+		return nil, err
+	}
+	log.Infof("Bumped the fee of transaction [%s] from [%s] to [%s] with "+
+		"child [%s]", details.Hash, parentFee, parentFee+childFee, childHash)
+	return tx, nil
+}
+
+// autoBumpState is what checkAutoBump knows of an unmined transaction.
+type autoBumpState struct {
+	// firstSeen is the height at which the transaction was first seen.
+	firstSeen int32
+
+	// bumped is whether the transaction is itself an automatic
+	// replacement.  Replacements are not bumped again, so the fee of a
+	// transaction is raised automatically at most once and by no more
+	// than MaxBumpFee in all.
+	bumped bool
+}
+
+// checkAutoBump replaces each unmined transaction which signals BIP125
+// replaceability and has not been mined within AutoBumpAfter blocks of being
+// first seen with one paying a higher fee.  A transaction is bumped at most
+// once, its replacement is left to be mined or bumped by hand.  Transactions
+// are only checked once per block, and what is known of them is kept in
+// memory so the window starts again when the wallet is restarted.  Nothing is
+// bumped in maintenance mode, as a bump moves funds.
+func (w *Wallet) checkAutoBump() {
+	if w.cfg.AutoBumpAfter <= 0 || w.MaintenanceMode() {
+		return
+	}
+	height := w.Manager.SyncedTo().Height
+	if height == w.autoBumpHeight {
+		return
+	}
+	w.autoBumpHeight = height
+
+	var candidates []wtxmgr.TxDetails
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		return w.TxStore.RangeTransactions(txmgrNs, -1, -1,
+			func(details []wtxmgr.TxDetails) (bool, er.R) {
+				for _, d := range details {
+					if !wtxmgr.SignalsReplacement(&d.MsgTx) {
+						continue
+					}
+					if _, ok := txFee(&d); ok {
+						candidates = append(candidates, d)
+					}
+				}
+				return false, nil
+			})
+	})
+	if err != nil {
+		log.Warnf("Error checking transactions to bump [%s]", err.String())
+		return
+	}
+
+	seen := make(map[chainhash.Hash]autoBumpState, len(candidates))
+	for i := range candidates {
+		d := &candidates[i]
+		state, ok := w.autoBumpSeen[d.Hash]
+		if !ok {
+			state = autoBumpState{firstSeen: height}
+		}
+		seen[d.Hash] = state
+		if state.bumped || height-state.firstSeen < w.cfg.AutoBumpAfter {
+			continue
+		}
+		// The window starts again whether or not the bump succeeds, so
+		// a transaction which cannot be bumped is not retried every
+		// block.
+		seen[d.Hash] = autoBumpState{firstSeen: height}
+		tx, err := w.replaceByFee(d)
+		if err != nil {
+			log.Warnf("Unable to bump the fee of transaction [%s] "+
+				"unconfirmed for [%d] blocks: [%s]", d.Hash,
+				height-state.firstSeen, err.String())
+			continue
+		}
+		seen[tx.TxHash()] = autoBumpState{firstSeen: height, bumped: true}
+	}
+	w.autoBumpSeen = seen
+}
@@ -0,0 +1,321 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txauthor"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+	"github.com/pkt-cash/pktd/wire/constants"
+)
+
+// TestCheckBumpFee ensures that a fee bump adding more than MaxBumpFee is
+// rejected and that bumps are not limited when it is zero.
+func TestCheckBumpFee(t *testing.T) {
+	w := &Wallet{cfg: DefaultConfig()}
+	if err := w.checkBumpFee(1000, 1e8); err != nil {
+		t.Fatalf("bump rejected without a maximum: %v", err)
+	}
+
+	w.cfg.MaxBumpFee = 5000
+	if err := w.checkBumpFee(1000, 6000); err != nil {
+		t.Fatalf("bump of exactly the maximum rejected: %v", err)
+	}
+	if err := w.checkBumpFee(1000, 6001); !ErrBumpFeeTooHigh.Is(err) {
+		t.Fatalf("got error %v for a bump over the maximum, want "+
+			"ErrBumpFeeTooHigh", err)
+	}
+}
+
+// TestCheckBumpIncrement ensures that a replacement adding less than
+// MinBumpIncrement of its size to the fee is rejected, that one adding exactly
+// that much or more is accepted, and that bumpedFee adds at least as much.
+func TestCheckBumpIncrement(t *testing.T) {
+	w := &Wallet{cfg: DefaultConfig()}
+
+	// 5 units per virtual byte of a 200 byte replacement is 1000.
+	w.cfg.MinBumpIncrement = 5000
+	if err := w.checkBumpIncrement(1000, 1999, 200); !ErrBumpFeeTooLow.Is(err) {
+		t.Fatalf("got error %v for a bump below the increment, want "+
+			"ErrBumpFeeTooLow", err)
+	}
+	if err := w.checkBumpIncrement(1000, 2000, 200); err != nil {
+		t.Fatalf("bump of exactly the increment rejected: %v", err)
+	}
+	if err := w.checkBumpIncrement(1000, 5000, 200); err != nil {
+		t.Fatalf("bump above the increment rejected: %v", err)
+	}
+
+	// Doubling the fee would not add the increment, so it is added.
+	w.cfg.MinBumpIncrement = 20000
+	fee, err := w.bumpedFee(1000, 200)
+	if err != nil {
+		t.Fatalf("unable to bump fee: %v", err)
+	}
+	if fee != 5000 {
+		t.Fatalf("got bumped fee %v, want %v", fee, btcutil.Amount(5000))
+	}
+	if err := w.checkBumpIncrement(1000, fee, 200); err != nil {
+		t.Fatalf("bumped fee rejected: %v", err)
+	}
+
+	// A bump which MaxBumpFee keeps below the increment cannot be made.
+	w.cfg.MaxBumpFee = 3999
+	if _, err := w.bumpedFee(1000, 200); !ErrBumpFeeTooHigh.Is(err) {
+		t.Fatalf("got error %v bumping by less than the increment, want "+
+			"ErrBumpFeeTooHigh", err)
+	}
+}
+
+// replaceableSpend records an output of 1e8 paying the wallet mined at height
+// 90, with the wallet synced to height 100, and publishes a spend of it which
+// signals replaceability, paying 3e7 away and the rest less a fee of 1000 as
+// change.  Nothing which the chain client was asked to broadcast is kept.
+func replaceableSpend(t *testing.T, w *Wallet,
+	chainClient *broadcastChainClient) *wire.MsgTx {
+
+	setSyncedTo(t, w, 100)
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	changeAddr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, _ := txscript.PayToAddrScript(addr)
+	changeScript, _ := txscript.PayToAddrScript(changeAddr)
+	incoming := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, pkScript)},
+	}
+	insertTestTx(t, w, incoming, 90, 0)
+
+	value := int64(1e8)
+	spend := &wire.MsgTx{
+		Version: 1,
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: incoming.TxHash()},
+			Sequence:         constants.MaxTxInSequenceNum - 2,
+		}},
+		TxOut: []*wire.TxOut{
+			wire.NewTxOut(3e7, []byte{0x51}),
+			wire.NewTxOut(7e7-1000, changeScript),
+		},
+		Additional: []wire.TxInAdditional{{PkScript: pkScript, Value: &value}},
+	}
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+		return txauthor.AddAllInputScripts(spend, secretSource{w.Manager, addrmgrNs})
+	})
+	if err != nil {
+		t.Fatalf("unable to sign spend: %v", err)
+	}
+	if err := w.PublishTransaction(spend, ""); err != nil {
+		t.Fatalf("unable to publish spend: %v", err)
+	}
+	chainClient.sent = nil
+	return spend
+}
+
+// TestAutoBump ensures that a replaceable transaction which is unconfirmed
+// for AutoBumpAfter blocks is replaced once by a transaction paying a higher
+// fee out of the wallet's output, that the replaced transaction is dropped,
+// that the replacement is not bumped again and that nothing is bumped in
+// maintenance mode.
+func TestAutoBump(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+	chainClient := &broadcastChainClient{}
+	w.chainClient = chainClient
+
+	w.cfg.AutoBumpAfter = 3
+
+	spend := replaceableSpend(t, w, chainClient)
+	for height := int32(100); height < 103; height++ {
+		setSyncedTo(t, w, height)
+		w.checkAutoBump()
+		if len(chainClient.sent) != 0 {
+			t.Fatalf("transaction bumped at height %d, before the window "+
+				"passed", height)
+		}
+	}
+	setSyncedTo(t, w, 103)
+	w.SetMaintenanceMode(true)
+	w.checkAutoBump()
+	if len(chainClient.sent) != 0 {
+		t.Fatalf("transaction bumped in maintenance mode")
+	}
+	w.SetMaintenanceMode(false)
+	w.checkAutoBump()
+	setSyncedTo(t, w, 104)
+	w.checkAutoBump()
+	if len(chainClient.sent) != 1 {
+		t.Fatalf("got %d bumps, want 1", len(chainClient.sent))
+	}
+
+	bump := chainClient.sent[0]
+	if bump.TxIn[0].PreviousOutPoint != spend.TxIn[0].PreviousOutPoint ||
+		bump.TxOut[0].Value != 3e7 || bump.TxOut[1].Value != 7e7-2000 {
+		t.Fatalf("got replacement %v, want the change reduced by the "+
+			"old fee", bump)
+	}
+	pending, err := w.PendingTxs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Hash != bump.TxHash() ||
+		pending[0].Fee != 2000 {
+		t.Fatalf("got pending transactions %+v, want only the replacement "+
+			"paying 2000", pending)
+	}
+
+	// The replacement is not bumped again once another window passes.
+	for height := int32(105); height < 112; height++ {
+		setSyncedTo(t, w, height)
+		w.checkAutoBump()
+	}
+	if len(chainClient.sent) != 1 {
+		t.Fatalf("got %d bumps after a second window, want 1",
+			len(chainClient.sent))
+	}
+}
+
+// TestReplaceByFeeChecks ensures that a replacement is refused if the fee
+// which it adds would exceed the spend limit or its change would fall below
+// MinOutput, leaving the replaced transaction, and that the added fee is
+// counted against the spend limit once it is replaced.
+func TestReplaceByFeeChecks(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+	chainClient := &broadcastChainClient{}
+	w.chainClient = chainClient
+
+	spend := replaceableSpend(t, w, chainClient)
+	details := func() *wtxmgr.TxDetails {
+		var d *wtxmgr.TxDetails
+		err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+			var err er.R
+			txHash := spend.TxHash()
+			d, err = w.TxStore.TxDetails(dbtx.ReadBucket(wtxmgrNamespaceKey),
+				&txHash)
+			return err
+		})
+		if err != nil || d == nil {
+			t.Fatalf("unable to get the spend: %v", err)
+		}
+		return d
+	}
+
+	w.cfg.SpendLimitAmount, w.cfg.SpendLimitWindow = 999, time.Hour
+	if _, err := w.replaceByFee(details()); !ErrSpendLimit.Is(err) {
+		t.Fatalf("got error %v bumping past the spend limit, want "+
+			"ErrSpendLimit", err)
+	}
+	w.cfg.SpendLimitAmount = 1000
+	w.cfg.MinOutput = 7e7
+	if _, err := w.replaceByFee(details()); !ErrOutputBelowMin.Is(err) {
+		t.Fatalf("got error %v reducing the change below the minimum "+
+			"output, want ErrOutputBelowMin", err)
+	}
+	if len(chainClient.sent) != 0 {
+		t.Fatalf("broadcast %d refused replacements", len(chainClient.sent))
+	}
+
+	w.cfg.MinOutput = 0
+	if _, err := w.replaceByFee(details()); err != nil {
+		t.Fatalf("unable to replace: %v", err)
+	}
+	var spent btcutil.Amount
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		spent = w.TxStore.SpentSince(dbtx.ReadBucket(wtxmgrNamespaceKey),
+			time.Now().Add(-time.Hour))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spent != 1000 {
+		t.Fatalf("got %v spent, want the added fee of 1000", spent)
+	}
+}
+
+// TestSendSignalsReplacement ensures that transactions which the wallet sends
+// signal replaceability with SignalReplacement and are final without it.
+func TestSendSignalsReplacement(t *testing.T) {
+	w, _, txs, cleanup := fundedTestWallet(t, 1e8)
+	defer cleanup()
+	pkScript := txs[0].TxOut[0].PkScript
+
+	for _, signal := range []bool{false, true} {
+		w.cfg.SignalReplacement = signal
+		tx, err := w.SendOutputs(CreateTxReq{
+			Outputs:     []*wire.TxOut{wire.NewTxOut(5e7, pkScript)},
+			Minconf:     1,
+			FeeSatPerKB: 1000,
+			MaxInputs:   -1,
+			SendMode:    SendModeSigned,
+		})
+		if err != nil {
+			t.Fatalf("unable to send: %v", err)
+		}
+		if got := wtxmgr.SignalsReplacement(tx.Tx); got != signal {
+			t.Fatalf("got replaceable %v with SignalReplacement %v",
+				got, signal)
+		}
+	}
+}
+
+// TestBumpFeeCPFP ensures that a child spending the change of an unmined
+// transaction is published paying what a replacement would add, that the fee
+// which it adds is limited by MaxBumpFee, and that a bump needing more than
+// MaxBumpFee is refused.
+func TestBumpFeeCPFP(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+	chainClient := &broadcastChainClient{}
+	w.chainClient = chainClient
+
+	spend := replaceableSpend(t, w, chainClient)
+	spendHash := spend.TxHash()
+
+	w.cfg.MaxBumpFee = 100
+	if _, err := w.BumpFeeCPFP(&spendHash); !ErrBumpFeeTooHigh.Is(err) {
+		t.Fatalf("got error %v for a bump over the maximum, want "+
+			"ErrBumpFeeTooHigh", err)
+	}
+	if len(chainClient.sent) != 0 {
+		t.Fatalf("broadcast %d refused children", len(chainClient.sent))
+	}
+
+	// Doubling the fee of 1000 would add 1000, the maximum keeps it to
+	// 600.
+	w.cfg.MaxBumpFee = 600
+	child, err := w.BumpFeeCPFP(&spendHash)
+	if err != nil {
+		t.Fatalf("unable to bump fee: %v", err)
+	}
+	if len(chainClient.sent) != 1 || chainClient.sent[0].TxHash() != child.TxHash() {
+		t.Fatalf("the child was not broadcast")
+	}
+	if len(child.TxIn) != 1 || child.TxIn[0].PreviousOutPoint !=
+		(wire.OutPoint{Hash: spendHash, Index: 1}) {
+		t.Fatalf("got child spending %v, want it to spend the change",
+			child.TxIn)
+	}
+	if len(child.TxOut) != 1 || child.TxOut[0].Value != 7e7-1000-600 {
+		t.Fatalf("got child %v, want it to pay the change less 600", child)
+	}
+
+	if _, err := w.BumpFeeCPFP(&chainhash.Hash{1}); !wtxmgr.ErrNoExists.Is(err) {
+		t.Fatalf("got error %v bumping an unknown transaction, want "+
+			"ErrNoExists", err)
+	}
+}
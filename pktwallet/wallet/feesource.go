@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/pktlog/log"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
+)
+
+// FeeSource is where a fee estimate comes from.
+type FeeSource int
+
+const (
+	// FeeSourceFallback is the configured fallback fee rate, used when
+	// there is no dynamic estimate.
+	FeeSourceFallback FeeSource = iota
+
+	// FeeSourcePktd is the fee estimation of the pktd backend.
+	FeeSourcePktd
+
+	// FeeSourceNeutrino is the median fee rate paid by the wallet's
+	// transactions in recent blocks, observed by the neutrino backend
+	// which has no fee estimation of its own.
+	FeeSourceNeutrino
+)
+
+// String returns the name of the fee source.
+func (s FeeSource) String() string {
+	switch s {
+	case FeeSourcePktd:
+		return "pktd"
+	case FeeSourceNeutrino:
+		return "neutrino"
+	default:
+		return "fallback"
+	}
+}
+
+// observedFeeBlocks is the number of recent blocks whose wallet transactions
+// are observed for a fee estimate when there is no fee estimator.
+const observedFeeBlocks = 1000
+
+// FeeEstimate is the current fee rate estimate and where it comes from.
+// Updated is the time the estimate last changed: when the wallet first saw
+// pktd estimate the current rate, or the time of the newest block with a
+// transaction observed by neutrino.  It is zero for the fallback fee rate which
+// does not change.
+type FeeEstimate struct {
+	Source  FeeSource
+	FeeRate btcutil.Amount
+	Updated time.Time
+}
+
+// CurrentFeeEstimate returns the fee rate, in atomic units per kilobyte, which
+// is estimated to confirm a transaction within a few blocks.  The estimate is
+// from fe if it is not nil, otherwise from the wallet's transactions in recent
+// blocks, and if neither can estimate then the fallback fee rate is returned.
+func (w *Wallet) CurrentFeeEstimate(fe FeeEstimator) *FeeEstimate {
+	if fe != nil {
+		coinsPerKb, err := fe.EstimateFee(conservativeConfirmationBlocks)
+		if err != nil {
+			log.Debugf("Unable to estimate fee: %v", err)
+		} else if rate, err := btcutil.NewAmount(coinsPerKb); err == nil && rate > 0 {
+			return &FeeEstimate{
+				Source:  FeeSourcePktd,
+				FeeRate: rate,
+				Updated: w.pktdFeeChanged(rate),
+			}
+		}
+	} else if stats, err := w.FeeStats(observedFeeBlocks); err != nil {
+		log.Debugf("Unable to get fee stats: %v", err)
+	} else if len(stats.Transactions) > 0 {
+		var updated time.Time
+		for _, tx := range stats.Transactions {
+			if tx.Time.After(updated) {
+				updated = tx.Time
+			}
+		}
+		return &FeeEstimate{
+			Source:  FeeSourceNeutrino,
+			FeeRate: stats.MedianFeeRate,
+			Updated: updated,
+		}
+	}
+	return &FeeEstimate{
+		Source:  FeeSourceFallback,
+		FeeRate: txrules.DefaultRelayFeePerKb,
+	}
+}
+
+// pktdFeeChanged records rate as the latest fee rate estimated by pktd and
+// returns the time that the estimate changed to it.
+func (w *Wallet) pktdFeeChanged(rate btcutil.Amount) time.Time {
+	w.pktdFeeMtx.Lock()
+	defer w.pktdFeeMtx.Unlock()
+	if rate != w.pktdFeeRate || w.pktdFeeUpdated.IsZero() {
+		w.pktdFeeRate = rate
+		w.pktdFeeUpdated = time.Now()
+	}
+	return w.pktdFeeUpdated
+}
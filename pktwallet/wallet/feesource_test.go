@@ -0,0 +1,84 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestCurrentFeeEstimate checks that the fallback fee rate is the source when
+// there is no dynamic estimate and that pktd is the source when it estimates.
+func TestCurrentFeeEstimate(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	tests := []struct {
+		name   string
+		fe     FeeEstimator
+		source FeeSource
+		rate   btcutil.Amount
+	}{
+		{"no estimator or transactions", nil, FeeSourceFallback, txrules.DefaultRelayFeePerKb},
+		{"estimator without data", mockFeeEstimator{}, FeeSourceFallback, txrules.DefaultRelayFeePerKb},
+		{"estimator", mockFeeEstimator{conservativeConfirmationBlocks: 0.0002}, FeeSourcePktd, 20000},
+	}
+	for _, test := range tests {
+		fe := w.CurrentFeeEstimate(test.fe)
+		if fe.Source != test.source || fe.FeeRate != test.rate {
+			t.Fatalf("%s: got %v fee rate %v, want %v fee rate %v",
+				test.name, fe.Source, fe.FeeRate, test.source, test.rate)
+		}
+		if fe.Updated.IsZero() != (test.source == FeeSourceFallback) {
+			t.Fatalf("%s: got update time %v for %v fee rate", test.name,
+				fe.Updated, fe.Source)
+		}
+	}
+}
+
+// TestFeeEstimateUpdated checks that the update time of a pktd estimate only
+// moves when the estimated rate changes and that a neutrino estimate was
+// updated at the time of the newest block it observed.
+func TestFeeEstimateUpdated(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	fe := mockFeeEstimator{conservativeConfirmationBlocks: 0.0002}
+	first := w.CurrentFeeEstimate(fe).Updated
+	then := first.Add(-time.Hour)
+	w.pktdFeeUpdated = then
+	if got := w.CurrentFeeEstimate(fe).Updated; !got.Equal(then) {
+		t.Fatalf("unchanged estimate was updated at %v, want %v", got, then)
+	}
+	fe[conservativeConfirmationBlocks] = 0.0003
+	if got := w.CurrentFeeEstimate(fe).Updated; got.Before(first) {
+		t.Fatalf("changed estimate was updated at %v, before %v", got,
+			first)
+	}
+
+	// Receive coins and spend them in blocks an hour apart, the spend is
+	// the only transaction with a known fee.
+	setSyncedTo(t, w, 100)
+	prev := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+	}
+	blockTime := time.Unix(1600000000, 0)
+	insertTestTxAt(t, w, prev, 80, blockTime, blockTime, 0)
+	spend := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: prev.TxHash()},
+		}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8-1000, []byte{0x52})},
+	}
+	blockTime = blockTime.Add(time.Hour)
+	insertTestTxAt(t, w, spend, 90, blockTime, blockTime)
+
+	est := w.CurrentFeeEstimate(nil)
+	if est.Source != FeeSourceNeutrino || !est.Updated.Equal(blockTime) {
+		t.Fatalf("got %v estimate updated at %v, want neutrino updated "+
+			"at %v", est.Source, est.Updated, blockTime)
+	}
+}
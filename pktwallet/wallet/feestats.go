@@ -0,0 +1,93 @@
+package wallet
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// TxFeeStat is the fee rate paid by a mined wallet transaction and how long
+// it took to confirm.
+type TxFeeStat struct {
+	Hash    chainhash.Hash
+	Height  int32
+	Time    time.Time
+	FeeRate btcutil.Amount
+
+	// ConfirmTime is the time between the transaction being received by
+	// the wallet and the time of the block which it was mined in.  It is
+	// zero for transactions which the wallet found in a block.
+	ConfirmTime time.Duration
+}
+
+// FeeStats are the fee rates paid by the wallet's recently mined
+// transactions.  Fee rates are in atomic units per kilobyte of virtual size.
+type FeeStats struct {
+	Transactions  []TxFeeStat
+	MinFeeRate    btcutil.Amount
+	MedianFeeRate btcutil.Amount
+	MaxFeeRate    btcutil.Amount
+}
+
+// FeeStats returns the fee rates paid by wallet transactions which were mined
+// in the last blocks blocks and how long each took to confirm.  Only
+// transactions which spend wallet outputs in every input have a known fee, so
+// only transactions sent by the wallet are included.
+func (w *Wallet) FeeStats(blocks int32) (*FeeStats, er.R) {
+	tip := w.Manager.SyncedTo().Height
+	begin := tip - blocks + 1
+	if begin < 0 {
+		begin = 0
+	}
+	stats := &FeeStats{}
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		return w.TxStore.RangeTransactions(txmgrNs, begin, tip,
+			func(details []wtxmgr.TxDetails) (bool, er.R) {
+				for i := range details {
+					d := &details[i]
+					feeRate, ok := txFeeRate(d)
+					if !ok {
+						continue
+					}
+					confirmTime := d.Block.Time.Sub(d.Received)
+					if confirmTime < 0 {
+						confirmTime = 0
+					}
+					stats.Transactions = append(stats.Transactions, TxFeeStat{
+						Hash:        d.Hash,
+						Height:      d.Block.Height,
+						Time:        d.Block.Time,
+						FeeRate:     feeRate,
+						ConfirmTime: confirmTime,
+					})
+				}
+				return false, nil
+			})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(stats.Transactions)
+	if n == 0 {
+		return stats, nil
+	}
+	rates := make([]btcutil.Amount, 0, n)
+	for _, s := range stats.Transactions {
+		rates = append(rates, s.FeeRate)
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i] < rates[j] })
+	stats.MinFeeRate = rates[0]
+	stats.MaxFeeRate = rates[n-1]
+	stats.MedianFeeRate = rates[n/2]
+	if n%2 == 0 {
+		stats.MedianFeeRate = (rates[n/2-1] + rates[n/2]) / 2
+	}
+	return stats, nil
+}
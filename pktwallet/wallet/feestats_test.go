@@ -0,0 +1,92 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestFeeStats seeds a history of transactions sent by the wallet with known
+// fees and confirmation times and checks the computed fee stats.
+func TestFeeStats(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	setSyncedTo(t, w, 300)
+
+	// Receive 100 coins at height 100, the fee of this is not known.
+	prev := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(100e8, []byte{0x51})},
+	}
+	insertTestTx(t, w, prev, 100, 0)
+
+	// Then send a chain of transactions, each spending the change of the
+	// last, paying the given fee and confirming after the given time.
+	sends := []struct {
+		height  int32
+		fee     int64
+		confirm time.Duration
+	}{
+		{150, 10000, time.Minute},
+		{210, 5000, 10 * time.Minute},
+		{250, 20000, 30 * time.Minute},
+		{290, 1000, 2 * time.Hour},
+	}
+	var rates []btcutil.Amount
+	blockTime := time.Unix(1600000000, 0)
+	for _, send := range sends {
+		tx := &wire.MsgTx{
+			TxIn: []*wire.TxIn{{
+				PreviousOutPoint: wire.OutPoint{Hash: prev.TxHash()},
+			}},
+			TxOut: []*wire.TxOut{
+				wire.NewTxOut(prev.TxOut[0].Value-1e8-send.fee, []byte{0x51}),
+				wire.NewTxOut(1e8, []byte{0x52}),
+			},
+		}
+		insertTestTxAt(t, w, tx, send.height, blockTime.Add(-send.confirm),
+			blockTime, 0)
+		rates = append(rates, btcutil.Amount(send.fee*1000/
+			int64(virtualSize(tx))))
+		prev = tx
+	}
+
+	// The last 100 blocks hold the last three sends.
+	stats, err := w.FeeStats(100)
+	if err != nil {
+		t.Fatalf("unable to compute fee stats: %v", err)
+	}
+	if len(stats.Transactions) != 3 {
+		t.Fatalf("got %d transactions, want 3", len(stats.Transactions))
+	}
+	for i, s := range stats.Transactions {
+		want := sends[i+1]
+		if s.Height != want.height || s.FeeRate != rates[i+1] ||
+			s.ConfirmTime != want.confirm {
+			t.Fatalf("got stat %+v, want height %d fee rate %v confirm "+
+				"time %v", s, want.height, rates[i+1], want.confirm)
+		}
+	}
+	if stats.MinFeeRate != rates[3] || stats.MaxFeeRate != rates[2] ||
+		stats.MedianFeeRate != rates[1] {
+		t.Fatalf("got min/median/max %v/%v/%v, want %v/%v/%v",
+			stats.MinFeeRate, stats.MedianFeeRate, stats.MaxFeeRate,
+			rates[3], rates[1], rates[2])
+	}
+
+	// All four sends have an even count, so the median is the mean of the
+	// middle two.
+	stats, err = w.FeeStats(1000)
+	if err != nil {
+		t.Fatalf("unable to compute fee stats: %v", err)
+	}
+	if len(stats.Transactions) != 4 {
+		t.Fatalf("got %d transactions, want 4", len(stats.Transactions))
+	}
+	if want := (rates[0] + rates[1]) / 2; stats.MedianFeeRate != want {
+		t.Fatalf("got median %v, want %v", stats.MedianFeeRate, want)
+	}
+}
@@ -0,0 +1,95 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestFinalityDepth checks that coins received one confirmation short of the
+// finality depth are counted as maturing and those received at the finality
+// depth as spendable.
+func TestFinalityDepth(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.cfg.FinalityDepth = 6
+
+	setSyncedTo(t, w, 100)
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Mined at height 95 the transaction has 6 confirmations, at height 96
+	// it has 5.
+	final := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, pkScript)},
+	}
+	insertTestTx(t, w, final, 95, 0)
+	provisional := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(2e8, pkScript)},
+	}
+	insertTestTx(t, w, provisional, 96, 0)
+
+	bals, err := w.CalculateAddressBalances(1, false)
+	if err != nil {
+		t.Fatalf("unable to calculate balances: %v", err)
+	}
+	if len(bals) != 1 {
+		t.Fatalf("got balances of %d addresses, want 1", len(bals))
+	}
+	for _, bal := range bals {
+		if bal.Spendable != btcutil.Amount(1e8) {
+			t.Fatalf("got spendable balance %v, want 1 coin", bal.Spendable)
+		}
+		if bal.Maturing != btcutil.Amount(2e8) {
+			t.Fatalf("got maturing balance %v, want 2 coins", bal.Maturing)
+		}
+		if bal.Total != btcutil.Amount(3e8) {
+			t.Fatalf("got total balance %v, want 3 coins", bal.Total)
+		}
+	}
+
+	// The wallet balance leaves the maturing coins out and returns them
+	// apart.
+	wbal, err := w.CalculateWalletBalance(1)
+	if err != nil {
+		t.Fatalf("unable to calculate balance: %v", err)
+	}
+	if wbal.Balance != btcutil.Amount(1e8) || wbal.Maturing != btcutil.Amount(2e8) {
+		t.Fatalf("got balance %v and maturing %v, want 1 coin and 2 coins",
+			wbal.Balance, wbal.Maturing)
+	}
+
+	// Without a finality depth both are spendable.
+	w.cfg.FinalityDepth = 0
+	bals, err = w.CalculateAddressBalances(1, false)
+	if err != nil {
+		t.Fatalf("unable to calculate balances: %v", err)
+	}
+	for _, bal := range bals {
+		if bal.Spendable != btcutil.Amount(3e8) || bal.Maturing != 0 {
+			t.Fatalf("got spendable balance %v and maturing balance %v "+
+				"without a finality depth", bal.Spendable, bal.Maturing)
+		}
+	}
+	wbal, err = w.CalculateWalletBalance(1)
+	if err != nil {
+		t.Fatalf("unable to calculate balance: %v", err)
+	}
+	if wbal.Balance != btcutil.Amount(3e8) || wbal.Maturing != 0 {
+		t.Fatalf("got balance %v and maturing %v without a finality depth",
+			wbal.Balance, wbal.Maturing)
+	}
+}
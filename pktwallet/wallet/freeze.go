@@ -0,0 +1,48 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/txscript"
+)
+
+// FreezeAddress freezes the outputs paying to addr, for example because its
+// key is known to be compromised.  Frozen outputs are not selected as inputs
+// of new transactions and are not counted in the balance, but they are still
+// listed, flagged as frozen.  The freeze is recorded in the wallet database so
+// it remains in place when the wallet is restarted or resynced.
+func (w *Wallet) FreezeAddress(addr btcutil.Address) er.R {
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return err
+	}
+	return walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		return w.TxStore.FreezeScript(dbtx.ReadWriteBucket(wtxmgrNamespaceKey), pkScript)
+	})
+}
+
+// UnfreezeAddress makes the outputs paying to addr spendable again after they
+// were frozen with FreezeAddress.
+func (w *Wallet) UnfreezeAddress(addr btcutil.Address) er.R {
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return err
+	}
+	return walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		return w.TxStore.UnfreezeScript(dbtx.ReadWriteBucket(wtxmgrNamespaceKey), pkScript)
+	})
+}
+
+// FrozenAddresses returns the addresses which are frozen.
+func (w *Wallet) FrozenAddresses() ([]btcutil.Address, er.R) {
+	var addrs []btcutil.Address
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		return w.TxStore.ForEachFrozenScript(txmgrNs, func(pkScript []byte) er.R {
+			addrs = append(addrs, txscript.PkScriptToAddress(pkScript, w.chainParams))
+			return nil
+		})
+	})
+	return addrs, err
+}
@@ -0,0 +1,105 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestFreezeAddress ensures that freezing an address removes its outputs from
+// the spendable balance and flags them in listunspent, and that unfreezing it
+// restores them.
+func TestFreezeAddress(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	setSyncedTo(t, w, 100)
+
+	frozen, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	other, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	frozenScript, err := txscript.PayToAddrScript(frozen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherScript, err := txscript.PayToAddrScript(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{
+			wire.NewTxOut(3e8, frozenScript),
+			wire.NewTxOut(1e8, otherScript),
+		},
+	}
+	insertTestTx(t, w, tx, 90, 0, 1)
+
+	checkBalance := func(want int64) {
+		t.Helper()
+		for _, confs := range []int32{0, 1} {
+			balance, err := w.CalculateBalance(confs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if int64(balance) != want {
+				t.Fatalf("got balance %v with %d confirmations, want %v",
+					balance, confs, want)
+			}
+		}
+	}
+	checkBalance(4e8)
+
+	if err := w.FreezeAddress(frozen); err != nil {
+		t.Fatalf("unable to freeze address: %v", err)
+	}
+	checkBalance(1e8)
+	addrs, err := w.FrozenAddresses()
+	if err != nil {
+		t.Fatalf("unable to list frozen addresses: %v", err)
+	}
+	if len(addrs) != 1 || addrs[0].EncodeAddress() != frozen.EncodeAddress() {
+		t.Fatalf("got frozen addresses %v, want %v", addrs, frozen)
+	}
+	unspent, err := w.ListUnspent(0, 999999, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unspent) != 2 {
+		t.Fatalf("got %d unspent outputs, want 2", len(unspent))
+	}
+	for _, u := range unspent {
+		isFrozen := u.Address == frozen.EncodeAddress()
+		if u.Frozen != isFrozen || u.Spendable == isFrozen {
+			t.Fatalf("output %d paying to %s: got frozen %v and "+
+				"spendable %v", u.Vout, u.Address, u.Frozen, u.Spendable)
+		}
+	}
+	var snapshot int
+	err = w.ForEachSnapshotOutput(func(out *SnapshotOutput) er.R {
+		snapshot++
+		if out.Address.EncodeAddress() == frozen.EncodeAddress() {
+			t.Fatalf("frozen output %v is in the snapshot", out.OutPoint)
+		}
+		return nil
+	})
+	if err != nil || snapshot != 1 {
+		t.Fatalf("got %d outputs in the snapshot (%v), want 1", snapshot, err)
+	}
+
+	if err := w.UnfreezeAddress(frozen); err != nil {
+		t.Fatalf("unable to unfreeze address: %v", err)
+	}
+	checkBalance(4e8)
+	if addrs, err := w.FrozenAddresses(); err != nil || len(addrs) != 0 {
+		t.Fatalf("got frozen addresses %v (%v), want none", addrs, err)
+	}
+}
@@ -95,7 +95,7 @@ func DropTransactionHistory(db walletdb.DB, keepLabels bool) er.R {
 }
 
 // fetchAllLabels returns a map of hex-encoded txid to label.
-func fetchAllLabels(tx walletdb.ReadWriteTx) (map[chainhash.Hash]string,
+func fetchAllLabels(tx walletdb.ReadTx) (map[chainhash.Hash]string,
 	er.R) {
 
 	// Get our top level bucket, if it does not exist we just exit.
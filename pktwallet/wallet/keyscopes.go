@@ -0,0 +1,32 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktlog/log"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// createExtraKeyScopes creates each of ExtraKeyScopes which the wallet does
+// not yet have.  The address manager must be unlocked.
+func (w *Wallet) createExtraKeyScopes() er.R {
+	for _, scope := range w.cfg.ExtraKeyScopes {
+		_, err := w.Manager.FetchScopedKeyManager(scope)
+		if err == nil {
+			continue
+		} else if !waddrmgr.ErrScopeNotFound.Is(err) {
+			return err
+		}
+		err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+			_, err := w.Manager.NewScopedKeyManager(
+				tx.ReadWriteBucket(waddrmgrNamespaceKey), scope,
+				waddrmgr.SchemaForScope(scope))
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		log.Infof("Created key scope %v", scope.String())
+	}
+	return nil
+}
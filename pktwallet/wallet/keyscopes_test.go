@@ -0,0 +1,74 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/chaincfg"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+)
+
+// TestExtraKeyScopes registers extra key scopes, checks that they are created
+// when the wallet is unlocked and that the addresses derived under them match
+// the test vectors of the mnemonic "abandon abandon abandon abandon abandon
+// abandon abandon abandon abandon abandon abandon about".
+func TestExtraKeyScopes(t *testing.T) {
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453" +
+		"ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea66" +
+		"90f20ad3d8d48b2d2ce9e38e4")
+
+	tests := []struct {
+		scope string
+		addrs []string
+	}{
+		{"84'/1'", []string{
+			"tb1q6rz28mcfaxtmd6v789l9rrlrusdprr9pqcpvkl",
+			"tb1qd7spv5q28348xl4myc8zmh983w5jx32cjhkn97",
+		}},
+		{"m/44h/1h", []string{
+			"mkpZhYtJu2r87Js3pDiWJDmPte2NRZ8bJV",
+			"mzpbWabUQm1w8ijuJnAof5eiSTep27deVH",
+		}},
+	}
+	w, cleanup := testWalletWithSeed(t, &chaincfg.TestNet3Params, seed)
+	defer cleanup()
+
+	for _, test := range tests {
+		scope, err := waddrmgr.ParseKeyScope(test.scope)
+		if err != nil {
+			t.Fatalf("unable to parse key scope %s: %v", test.scope, err)
+		}
+		w.cfg.ExtraKeyScopes = append(w.cfg.ExtraKeyScopes, scope)
+	}
+
+	// The scopes are created when the wallet is next unlocked.
+	w.Lock()
+	w.Locked()
+	if err := w.Unlock([]byte("world"), time.After(10*time.Minute)); err != nil {
+		t.Fatalf("unable to unlock wallet: %v", err)
+	}
+
+	for i, test := range tests {
+		scope := w.cfg.ExtraKeyScopes[i]
+		if _, err := w.Manager.FetchScopedKeyManager(scope); err != nil {
+			t.Fatalf("%v: key scope was not created: %v", scope, err)
+		}
+		for _, want := range test.addrs {
+			addr, err := w.NewAddress(0, scope)
+			if err != nil {
+				t.Fatalf("%v: unable to derive address: %v", scope, err)
+			}
+			if addr.EncodeAddress() != want {
+				t.Fatalf("%v: got address %s, want %s", scope,
+					addr.EncodeAddress(), want)
+			}
+		}
+	}
+
+	for _, s := range []string{"84", "84/1/0", "84/x", "2147483648/0", "84''/0"} {
+		if _, err := waddrmgr.ParseKeyScope(s); err == nil {
+			t.Fatalf("parsed invalid key scope %s", s)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// ImportLabelsResult counts what became of each label given to
+// ImportTxLabels.
+type ImportLabelsResult struct {
+	// Imported is the number of labels written.
+	Imported int
+
+	// Kept is the number of labels not written because the transaction
+	// already has a label and overwrite was not set.
+	Kept int
+
+	// Unknown is the number of labels not written because the transaction
+	// is not known to the wallet.
+	Unknown int
+}
+
+// TxLabels returns the label of each labelled transaction, keyed by the
+// transaction hash.
+func (w *Wallet) TxLabels() (map[chainhash.Hash]string, er.R) {
+	var labels map[chainhash.Hash]string
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		var err er.R
+		labels, err = fetchAllLabels(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		labels = make(map[chainhash.Hash]string)
+	}
+	return labels, nil
+}
+
+// ImportTxLabels labels transactions known to the wallet with the labels
+// given, as exported by TxLabels.  The labels are merged with those of the
+// wallet, a transaction which already has a label keeps it unless overwrite is
+// set.  No labels are written if any of them is invalid.
+func (w *Wallet) ImportTxLabels(labels map[chainhash.Hash]string,
+	overwrite bool) (*ImportLabelsResult, er.R) {
+
+	for hash, label := range labels {
+		if len(label) == 0 {
+			return nil, wtxmgr.ErrEmptyLabel.New(hash.String(), nil)
+		}
+		if len(label) > wtxmgr.TxLabelLimit {
+			return nil, wtxmgr.ErrLabelTooLong.New(hash.String(), nil)
+		}
+	}
+
+	var res ImportLabelsResult
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		txmgrNs := tx.ReadWriteBucket(wtxmgrNamespaceKey)
+		res = ImportLabelsResult{}
+		for hash, label := range labels {
+			hash := hash
+			details, err := w.TxStore.TxDetails(txmgrNs, &hash)
+			if err != nil {
+				return err
+			}
+			if details == nil {
+				res.Unknown++
+				continue
+			}
+			_, err = wtxmgr.FetchTxLabel(txmgrNs, hash)
+			switch {
+			case wtxmgr.ErrNoLabelBucket.Is(err):
+			case wtxmgr.ErrTxLabelNotFound.Is(err):
+			case err == nil:
+				if !overwrite {
+					res.Kept++
+					continue
+				}
+			default:
+				return err
+			}
+			if err := w.TxStore.PutTxLabel(txmgrNs, hash, label); err != nil {
+				return err
+			}
+			res.Imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,93 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestImportTxLabels checks that imported labels are merged with the labels
+// of the wallet, keeping existing labels unless overwrite is set, and that
+// the labels are exported again as imported.
+func TestImportTxLabels(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	var hashes []chainhash.Hash
+	for i := 0; i < 3; i++ {
+		tx := &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(int64(i+1)*1e8, []byte{0x51})},
+		}
+		insertTestTx(t, w, tx, 100, 0)
+		hashes = append(hashes, tx.TxHash())
+	}
+	if err := w.LabelTransaction(hashes[0], "wallet label", false); err != nil {
+		t.Fatalf("unable to label transaction: %v", err)
+	}
+
+	check := func(want map[chainhash.Hash]string) {
+		t.Helper()
+		labels, err := w.TxLabels()
+		if err != nil {
+			t.Fatalf("unable to export labels: %v", err)
+		}
+		if len(labels) != len(want) {
+			t.Fatalf("got %d labels, want %d", len(labels), len(want))
+		}
+		for hash, label := range want {
+			if labels[hash] != label {
+				t.Fatalf("got label %q of %v, want %q", labels[hash],
+					hash, label)
+			}
+		}
+	}
+
+	unknown := chainhash.DoubleHashH([]byte("unknown"))
+	imported := map[chainhash.Hash]string{
+		hashes[0]: "external label 0",
+		hashes[1]: "external label 1",
+		unknown:   "external label of unknown tx",
+	}
+
+	// Merging keeps the label of the first transaction.
+	res, err := w.ImportTxLabels(imported, false)
+	if err != nil {
+		t.Fatalf("unable to import labels: %v", err)
+	}
+	if *res != (ImportLabelsResult{Imported: 1, Kept: 1, Unknown: 1}) {
+		t.Fatalf("got result %+v of merge", *res)
+	}
+	check(map[chainhash.Hash]string{
+		hashes[0]: "wallet label",
+		hashes[1]: "external label 1",
+	})
+
+	// Overwriting replaces it, and leaves the unlabelled third transaction
+	// alone.
+	res, err = w.ImportTxLabels(imported, true)
+	if err != nil {
+		t.Fatalf("unable to import labels: %v", err)
+	}
+	if *res != (ImportLabelsResult{Imported: 2, Unknown: 1}) {
+		t.Fatalf("got result %+v of overwrite", *res)
+	}
+	check(map[chainhash.Hash]string{
+		hashes[0]: "external label 0",
+		hashes[1]: "external label 1",
+	})
+
+	// An invalid label rejects the whole import.
+	_, err = w.ImportTxLabels(map[chainhash.Hash]string{
+		hashes[2]: "label",
+		hashes[1]: "",
+	}, true)
+	if err == nil {
+		t.Fatalf("imported an empty label")
+	}
+	check(map[chainhash.Hash]string{
+		hashes[0]: "external label 0",
+		hashes[1]: "external label 1",
+	})
+}
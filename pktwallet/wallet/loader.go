@@ -54,6 +54,7 @@ type Loader struct {
 	dbDirPath      string
 	walletName     string
 	recoveryWindow uint32
+	cfg            Config
 	wallet         *Wallet
 	db             walletdb.DB
 	mu             sync.Mutex
@@ -61,7 +62,8 @@ type Loader struct {
 
 // NewLoader constructs a Loader with an optional recovery window. If the
 // recovery window is non-zero, the wallet will attempt to recovery addresses
-// starting from the last SyncedTo height.
+// starting from the last SyncedTo height.  Wallets are loaded with the
+// settings of DefaultConfig unless others are given with SetConfig.
 func NewLoader(chainParams *chaincfg.Params, dbDirPath, walletName string,
 	noFreelistSync bool, recoveryWindow uint32) *Loader {
 
@@ -71,9 +73,18 @@ func NewLoader(chainParams *chaincfg.Params, dbDirPath, walletName string,
 		walletName:     walletName,
 		dbDirPath:      dbDirPath,
 		recoveryWindow: recoveryWindow,
+		cfg:            DefaultConfig(),
 	}
 }
 
+// SetConfig sets the settings of the wallets which the loader creates or opens
+// from now on.
+func (l *Loader) SetConfig(cfg Config) {
+	l.mu.Lock()
+	l.cfg = cfg
+	l.mu.Unlock()
+}
+
 // onLoaded executes each added callback and prevents loader from loading any
 // additional wallets.  Requires mutex to be locked.
 func (l *Loader) onLoaded(w *Wallet, db walletdb.DB) {
@@ -142,6 +153,7 @@ func (l *Loader) CreateNewWallet(pubPassphrase, privPassphrase []byte,
 	if err != nil {
 		return nil, err
 	}
+	db = batchFlushes(db, &l.cfg)
 
 	// Initialize the newly created database for the wallet before opening.
 	err = Create(db, pubPassphrase, privPassphrase, seedInput, seedBirthday, seed, l.chainParams)
@@ -150,7 +162,7 @@ func (l *Loader) CreateNewWallet(pubPassphrase, privPassphrase []byte,
 	}
 
 	// Open the newly-created wallet.
-	w, err := Open(db, pubPassphrase, nil, l.chainParams, l.recoveryWindow)
+	w, err := Open(db, pubPassphrase, nil, l.chainParams, l.recoveryWindow, l.cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +172,15 @@ func (l *Loader) CreateNewWallet(pubPassphrase, privPassphrase []byte,
 	return w, nil
 }
 
+// batchFlushes returns db wrapped in a walletdb.FlushBatcher if cfg batches
+// updates.
+func batchFlushes(db walletdb.DB, cfg *Config) walletdb.DB {
+	if cfg.DBFlushCommits <= 0 && cfg.DBFlushInterval <= 0 {
+		return db
+	}
+	return walletdb.NewFlushBatcher(db, cfg.DBFlushCommits, cfg.DBFlushInterval)
+}
+
 func noConsole() ([]byte, er.R) {
 	return nil, er.New("db upgrade requires console access for additional input")
 }
@@ -183,11 +204,12 @@ func (l *Loader) OpenExistingWallet(pubPassphrase []byte, canConsolePrompt bool)
 
 	// Open the database using the boltdb backend.
 	dbPath := WalletDbPath(l.dbDirPath, l.walletName)
-	db, err := walletdb.Open("bdb", dbPath, false)
+	db, err := walletdb.OpenLockMode("bdb", dbPath, false, l.cfg.DBLockMode)
 	if err != nil {
 		log.Errorf("Failed to open database: %v", err)
 		return nil, err
 	}
+	db = batchFlushes(db, &l.cfg)
 
 	var cbs *waddrmgr.OpenCallbacks
 	if canConsolePrompt {
@@ -201,7 +223,7 @@ func (l *Loader) OpenExistingWallet(pubPassphrase []byte, canConsolePrompt bool)
 			ObtainPrivatePass: noConsole,
 		}
 	}
-	w, err := Open(db, pubPassphrase, cbs, l.chainParams, l.recoveryWindow)
+	w, err := Open(db, pubPassphrase, cbs, l.chainParams, l.recoveryWindow, l.cfg)
 	if err != nil {
 		// If opening the wallet fails (e.g. because of wrong
 		// passphrase), we must close the backing database to
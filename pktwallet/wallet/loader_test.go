@@ -0,0 +1,227 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/btcutil/hdkeychain"
+	"github.com/pkt-cash/pktd/chaincfg"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb/migration"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// TestOpenWrongNetwork ensures that a wallet created for one network is not
+// opened for another unless IgnoreNetworkMismatch is set.
+func TestOpenWrongNetwork(t *testing.T) {
+	dir, errr := ioutil.TempDir("", "test_wallet")
+	if errr != nil {
+		t.Fatalf("Failed to create db dir: %v", errr)
+	}
+	defer os.RemoveAll(dir)
+
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.MinSeedBytes)
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+	pubPass := []byte("hello")
+	loader := NewLoader(&chaincfg.TestNet3Params, dir, "wallet.db", true, 250)
+	_, err = loader.CreateNewWallet(pubPass, []byte("world"),
+		[]byte(hex.EncodeToString(seed)), time.Now(), nil)
+	if err != nil {
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+	if err := loader.UnloadWallet(); err != nil {
+		t.Fatalf("unable to unload wallet: %v", err)
+	}
+
+	// The same network opens.
+	if _, err := loader.OpenExistingWallet(pubPass, false); err != nil {
+		t.Fatalf("unable to open wallet: %v", err)
+	}
+	if err := loader.UnloadWallet(); err != nil {
+		t.Fatalf("unable to unload wallet: %v", err)
+	}
+
+	loader = NewLoader(&chaincfg.MainNetParams, dir, "wallet.db", true, 250)
+	_, err = loader.OpenExistingWallet(pubPass, false)
+	if !ErrWrongNetwork.Is(err) {
+		t.Fatalf("got error %v, want ErrWrongNetwork", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.IgnoreNetworkMismatch = true
+	loader.SetConfig(cfg)
+	if _, err := loader.OpenExistingWallet(pubPass, false); err != nil {
+		t.Fatalf("unable to force open wallet: %v", err)
+	}
+	if err := loader.UnloadWallet(); err != nil {
+		t.Fatalf("unable to unload wallet: %v", err)
+	}
+}
+
+// TestOpenUpgrade ensures that a wallet in an older format is only upgraded
+// when it is opened if Config.AllowUpgrade is set.
+func TestOpenUpgrade(t *testing.T) {
+	dir, errr := ioutil.TempDir("", "test_wallet")
+	if errr != nil {
+		t.Fatalf("Failed to create db dir: %v", errr)
+	}
+	defer os.RemoveAll(dir)
+
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.MinSeedBytes)
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+	pubPass := []byte("hello")
+	loader := NewLoader(&chaincfg.TestNet3Params, dir, "wallet.db", true, 250)
+	_, err = loader.CreateNewWallet(pubPass, []byte("world"),
+		[]byte(hex.EncodeToString(seed)), time.Now(), nil)
+	if err != nil {
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+	if err := loader.UnloadWallet(); err != nil {
+		t.Fatalf("unable to unload wallet: %v", err)
+	}
+
+	// version returns the version of the address manager, first setting
+	// it to set if that is non-zero.
+	version := func(set uint32) uint32 {
+		db, err := walletdb.Open("bdb", filepath.Join(dir, "wallet.db"), true)
+		if err != nil {
+			t.Fatalf("unable to open db: %v", err)
+		}
+		defer db.Close()
+		var current uint32
+		err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) er.R {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			mgr := waddrmgr.NewMigrationManager(ns)
+			if set != 0 {
+				if err := mgr.SetVersion(ns, set); err != nil {
+					return err
+				}
+			}
+			var err er.R
+			current, err = mgr.CurrentVersion(ns)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("unable to access version: %v", err)
+		}
+		return current
+	}
+
+	// Put the address manager back one version, as if the wallet was
+	// created by an older pktwallet.
+	latest := version(0)
+	version(latest - 1)
+
+	cfg := DefaultConfig()
+	cfg.AllowUpgrade = false
+	loader.SetConfig(cfg)
+	_, err = loader.OpenExistingWallet(pubPass, false)
+	if !ErrUpgradeRequired.Is(err) {
+		t.Fatalf("got error %v, want ErrUpgradeRequired", err)
+	}
+	if v := version(0); v != latest-1 {
+		t.Fatalf("got version %d after refusing to open, want %d", v,
+			latest-1)
+	}
+
+	cfg.AllowUpgrade = true
+	loader.SetConfig(cfg)
+	if _, err := loader.OpenExistingWallet(pubPass, false); err != nil {
+		t.Fatalf("unable to open wallet with upgrade: %v", err)
+	}
+	if err := loader.UnloadWallet(); err != nil {
+		t.Fatalf("unable to unload wallet: %v", err)
+	}
+	if v := version(0); v != latest {
+		t.Fatalf("got version %d after upgrade, want %d", v, latest)
+	}
+}
+
+// TestOpenNewerVersion ensures that a wallet written by a newer pktwallet is
+// refused, naming the version needed, and is left untouched.
+func TestOpenNewerVersion(t *testing.T) {
+	dir, errr := ioutil.TempDir("", "test_wallet")
+	if errr != nil {
+		t.Fatalf("Failed to create db dir: %v", errr)
+	}
+	defer os.RemoveAll(dir)
+
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.MinSeedBytes)
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+	pubPass := []byte("hello")
+	loader := NewLoader(&chaincfg.TestNet3Params, dir, "wallet.db", true, 250)
+	_, err = loader.CreateNewWallet(pubPass, []byte("world"),
+		[]byte(hex.EncodeToString(seed)), time.Now(), nil)
+	if err != nil {
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+	if err := loader.UnloadWallet(); err != nil {
+		t.Fatalf("unable to unload wallet: %v", err)
+	}
+
+	// version returns the version of the transaction manager, first
+	// setting it to set if that is non-zero.
+	version := func(set uint32) uint32 {
+		db, err := walletdb.Open("bdb", filepath.Join(dir, "wallet.db"), true)
+		if err != nil {
+			t.Fatalf("unable to open db: %v", err)
+		}
+		defer db.Close()
+		var current uint32
+		err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) er.R {
+			ns := tx.ReadWriteBucket(wtxmgrNamespaceKey)
+			mgr := wtxmgr.NewMigrationManager(ns)
+			if set != 0 {
+				if err := mgr.SetVersion(ns, set); err != nil {
+					return err
+				}
+			}
+			var err er.R
+			current, err = mgr.CurrentVersion(ns)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("unable to access version: %v", err)
+		}
+		return current
+	}
+
+	// Put the transaction manager forward one version, as if the wallet
+	// was last opened by a newer pktwallet.
+	future := version(0) + 1
+	version(future)
+
+	for _, allow := range []bool{false, true} {
+		cfg := DefaultConfig()
+		cfg.AllowUpgrade = allow
+		loader.SetConfig(cfg)
+		_, err = loader.OpenExistingWallet(pubPass, false)
+		if !migration.ErrReversion.Is(err) {
+			t.Fatalf("got error %v with AllowUpgrade %v, want "+
+				"ErrReversion", err, allow)
+		}
+		want := fmt.Sprintf("version which understands version [%d]", future)
+		if !strings.Contains(err.Message(), want) {
+			t.Fatalf("got error %q, want it to name version %d",
+				err.Message(), future)
+		}
+		if v := version(0); v != future {
+			t.Fatalf("got version %d after refusing to open, want %d",
+				v, future)
+		}
+	}
+}
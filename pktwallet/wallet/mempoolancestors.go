@@ -0,0 +1,97 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// ErrTxNotUnmined is returned when the mempool ancestors are requested for a
+// transaction which is not an unmined wallet transaction.
+var ErrTxNotUnmined = Err.CodeWithDetail("ErrTxNotUnmined",
+	"transaction is not an unmined wallet transaction")
+
+// MempoolAncestor is an unmined wallet transaction which another one depends
+// on.  FeeKnown is false if it spends outputs which are not the wallet's, in
+// which case Fee is zero.
+type MempoolAncestor struct {
+	Hash     chainhash.Hash
+	Size     int
+	VSize    int
+	FeeKnown bool
+	Fee      btcutil.Amount
+}
+
+// MempoolAncestors are the unmined wallet transactions which a transaction
+// depends on, each listed after those which it depends on itself, with their
+// total size, virtual size and fee.  FeeKnown is false if the fee of any of them is not
+// known, Fee is then the total of those which are.
+type MempoolAncestors struct {
+	Ancestors []MempoolAncestor
+	Size      int
+	VSize     int
+	FeeKnown  bool
+	Fee       btcutil.Amount
+}
+
+// MempoolAncestors returns the unmined wallet transactions which an unmined
+// wallet transaction spends the outputs of, directly or through other unmined
+// transactions.  These must be mined along with it, so a child paying for
+// them needs to pay for their total size.
+func (w *Wallet) MempoolAncestors(txHash *chainhash.Hash) (*MempoolAncestors, er.R) {
+	res := &MempoolAncestors{FeeKnown: true}
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		details, err := w.TxStore.TxDetails(txmgrNs, txHash)
+		if err != nil {
+			return err
+		}
+		if details == nil || details.Block.Height >= 0 {
+			return ErrTxNotUnmined.New(fmt.Sprintf("transaction [%s]",
+				txHash), nil)
+		}
+
+		visited := map[chainhash.Hash]bool{*txHash: true}
+		var visit func(details *wtxmgr.TxDetails) er.R
+		visit = func(details *wtxmgr.TxDetails) er.R {
+			for _, in := range details.MsgTx.TxIn {
+				prevHash := in.PreviousOutPoint.Hash
+				if visited[prevHash] {
+					continue
+				}
+				visited[prevHash] = true
+				prev, err := w.TxStore.TxDetails(txmgrNs, &prevHash)
+				if err != nil {
+					return err
+				}
+				if prev == nil || prev.Block.Height >= 0 {
+					continue
+				}
+				if err := visit(prev); err != nil {
+					return err
+				}
+				a := MempoolAncestor{
+					Hash:  prevHash,
+					Size:  prev.MsgTx.SerializeSize(),
+					VSize: virtualSize(&prev.MsgTx),
+				}
+				a.Fee, a.FeeKnown = txFee(prev)
+				res.Ancestors = append(res.Ancestors, a)
+				res.Size += a.Size
+				res.VSize += a.VSize
+				res.Fee += a.Fee
+				res.FeeKnown = res.FeeKnown && a.FeeKnown
+			}
+			return nil
+		}
+		return visit(details)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
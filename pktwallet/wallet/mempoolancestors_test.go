@@ -0,0 +1,114 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestMempoolAncestors checks the ancestors of a chain of unmined transactions
+// and their total size and fee.
+func TestMempoolAncestors(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spend := func(value int64, outputs int, prevs ...*wire.MsgTx) *wire.MsgTx {
+		tx := &wire.MsgTx{}
+		for _, prev := range prevs {
+			tx.TxIn = append(tx.TxIn, &wire.TxIn{
+				PreviousOutPoint: wire.OutPoint{Hash: prev.TxHash()},
+			})
+		}
+		for i := 0; i < outputs; i++ {
+			tx.TxOut = append(tx.TxOut, wire.NewTxOut(value, pkScript))
+		}
+		return tx
+	}
+
+	// A mined coin is spent by a chain of unmined transactions, b, c and
+	// d.  An unmined transaction with a foreign input, e, is spent along
+	// with d by f.
+	a := spend(1e8, 1, &wire.MsgTx{})
+	insertTestTx(t, w, a, 100, 0)
+	b := spend(9e7, 1, a)
+	insertTestTx(t, w, b, -1, 0)
+	c := spend(8e7, 1, b)
+	insertTestTx(t, w, c, -1, 0)
+	d := spend(7e7, 1, c)
+	insertTestTx(t, w, d, -1, 0)
+	e := spend(5e7, 1, &wire.MsgTx{Version: 2})
+	insertTestTx(t, w, e, -1, 0)
+	f := spend(1e8, 1, d, e)
+	insertTestTx(t, w, f, -1, 0)
+
+	hash := d.TxHash()
+	res, err := w.MempoolAncestors(&hash)
+	if err != nil {
+		t.Fatalf("unable to get ancestors: %v", err)
+	}
+	want := []chainhash.Hash{b.TxHash(), c.TxHash()}
+	if len(res.Ancestors) != len(want) {
+		t.Fatalf("got %d ancestors, want %d", len(res.Ancestors), len(want))
+	}
+	for i, anc := range res.Ancestors {
+		if anc.Hash != want[i] {
+			t.Fatalf("got ancestor %d %v, want %v", i, anc.Hash, want[i])
+		}
+		if !anc.FeeKnown || anc.Fee != 1e7 {
+			t.Fatalf("got fee %v known %v of ancestor %d, want 0.1 coins",
+				anc.Fee, anc.FeeKnown, i)
+		}
+	}
+	if size := b.SerializeSize() + c.SerializeSize(); res.Size != size {
+		t.Fatalf("got total size %d, want %d", res.Size, size)
+	}
+	if vsize := virtualSize(b) + virtualSize(c); res.VSize != vsize {
+		t.Fatalf("got total virtual size %d, want %d", res.VSize, vsize)
+	}
+	if !res.FeeKnown || res.Fee != btcutil.Amount(2e7) {
+		t.Fatalf("got total fee %v known %v, want 0.2 coins", res.Fee,
+			res.FeeKnown)
+	}
+
+	// The fee of e is not known.
+	hash = f.TxHash()
+	res, err = w.MempoolAncestors(&hash)
+	if err != nil {
+		t.Fatalf("unable to get ancestors: %v", err)
+	}
+	if len(res.Ancestors) != 4 || res.FeeKnown || res.Fee != 3e7 {
+		t.Fatalf("got %d ancestors with total fee %v known %v, want 4 "+
+			"with 0.3 coins not known", len(res.Ancestors), res.Fee,
+			res.FeeKnown)
+	}
+
+	// A transaction with no unmined ancestors has none.
+	hash = b.TxHash()
+	res, err = w.MempoolAncestors(&hash)
+	if err != nil {
+		t.Fatalf("unable to get ancestors: %v", err)
+	}
+	if len(res.Ancestors) != 0 || res.Size != 0 || res.Fee != 0 {
+		t.Fatalf("got ancestors %+v of a child of a mined transaction", res)
+	}
+
+	// Mined and unknown transactions are refused.
+	for _, tx := range []*wire.MsgTx{a, {}} {
+		hash = tx.TxHash()
+		if _, err := w.MempoolAncestors(&hash); !ErrTxNotUnmined.Is(err) {
+			t.Fatalf("got error %v for %v, want ErrTxNotUnmined", err, hash)
+		}
+	}
+}
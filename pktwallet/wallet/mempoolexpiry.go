@@ -0,0 +1,51 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktlog/log"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// mempoolExpiryInterval is how often unmined transactions are checked for
+// expiry.
+const mempoolExpiryInterval = time.Minute
+
+// checkMempoolExpiry expires unmined transactions if they have not been
+// checked within the last mempoolExpiryInterval.
+func (w *Wallet) checkMempoolExpiry() {
+	if w.cfg.MempoolExpiry <= 0 || time.Since(w.lastMempoolExpiry) < mempoolExpiryInterval {
+		return
+	}
+	w.lastMempoolExpiry = time.Now()
+	if err := w.expireUnminedTxs(w.lastMempoolExpiry); err != nil {
+		log.Warnf("Error expiring unmined transactions [%s]", err.String())
+	}
+}
+
+// expireUnminedTxs drops every unmined transaction which was received more
+// than MempoolExpiry before now, along with any transactions spending its
+// outputs, and notifies clients of the dropped transactions.
+func (w *Wallet) expireUnminedTxs(now time.Time) er.R {
+	var expired []chainhash.Hash
+	err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+		var err er.R
+		w.utxoCache.invalidate()
+		expired, err = w.TxStore.ExpireUnminedTxs(txmgrNs, now.Add(-w.cfg.MempoolExpiry))
+		return err
+	})
+	if err != nil || len(expired) == 0 {
+		return err
+	}
+	hashes := make([]*chainhash.Hash, len(expired))
+	for i := range expired {
+		hashes[i] = &expired[i]
+	}
+	return walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		w.NtfnServer.notifyExpiredTransactions(dbtx, hashes)
+		return nil
+	})
+}
@@ -0,0 +1,106 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestMempoolExpiry ensures that unmined transactions older than
+// MempoolExpiry are dropped, freeing their inputs, and that clients are
+// notified of them.
+func TestMempoolExpiry(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.cfg.MempoolExpiry = time.Hour
+
+	now := time.Now()
+	spend := func(seq uint32, received time.Time) (wire.OutPoint, chainhash.Hash) {
+		incomingTx := &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{Sequence: seq}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+		}
+		addUtxo(t, w, incomingTx)
+		op := wire.OutPoint{Hash: incomingTx.TxHash()}
+		tx := &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{PreviousOutPoint: op}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(9e7, []byte{0x52})},
+		}
+		var b bytes.Buffer
+		if err := tx.Serialize(&b); err != nil {
+			t.Fatalf("unable to serialize tx: %v", err)
+		}
+		rec, err := wtxmgr.NewTxRecord(b.Bytes(), received)
+		if err != nil {
+			t.Fatalf("unable to create tx record: %v", err)
+		}
+		err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+			return w.TxStore.InsertTx(dbtx.ReadWriteBucket(wtxmgrNamespaceKey), rec, nil)
+		})
+		if err != nil {
+			t.Fatalf("unable to insert tx: %v", err)
+		}
+		return op, tx.TxHash()
+	}
+	oldInput, oldHash := spend(1, now.Add(-2*time.Hour))
+	newInput, newHash := spend(2, now.Add(-10*time.Minute))
+
+	unspent := func() map[wire.OutPoint]bool {
+		m := make(map[wire.OutPoint]bool)
+		err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+			credits, err := w.TxStore.GetUnspentOutputs(dbtx.ReadBucket(wtxmgrNamespaceKey))
+			for _, c := range credits {
+				m[c.OutPoint] = true
+			}
+			return err
+		})
+		if err != nil {
+			t.Fatalf("unable to fetch unspent outputs: %v", err)
+		}
+		return m
+	}
+	if u := unspent(); u[oldInput] || u[newInput] {
+		t.Fatalf("inputs of unmined transactions are unspent")
+	}
+
+	client := w.NtfnServer.TransactionNotifications()
+	defer client.Done()
+	errs := make(chan er.R, 1)
+	go func() { errs <- w.expireUnminedTxs(now) }()
+
+	select {
+	case n := <-client.C:
+		if len(n.ExpiredTransactions) != 1 || *n.ExpiredTransactions[0] != oldHash {
+			t.Fatalf("got expired transactions %v, want [%v]",
+				n.ExpiredTransactions, oldHash)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no notification of expired transaction")
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("unable to expire unmined transactions: %v", err)
+	}
+
+	var unmined []*chainhash.Hash
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		var err er.R
+		unmined, err = w.TxStore.UnminedTxHashes(dbtx.ReadBucket(wtxmgrNamespaceKey))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to fetch unmined transactions: %v", err)
+	}
+	if len(unmined) != 1 || *unmined[0] != newHash {
+		t.Fatalf("got unmined transactions %v, want [%v]", unmined, newHash)
+	}
+	if u := unspent(); !u[oldInput] || u[newInput] {
+		t.Fatalf("only the input of the expired transaction should be freed")
+	}
+}
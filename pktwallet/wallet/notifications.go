@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"sync"
 
+	"github.com/pkt-cash/pktd/blockchain"
 	"github.com/pkt-cash/pktd/btcutil"
 	"github.com/pkt-cash/pktd/btcutil/er"
 	"github.com/pkt-cash/pktd/chaincfg/chainhash"
@@ -16,6 +17,7 @@ import (
 	"github.com/pkt-cash/pktd/pktwallet/walletdb"
 	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
 	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
 )
 
 // TODO: It would be good to send errors during notification creation to the rpc
@@ -35,6 +37,7 @@ import (
 type NotificationServer struct {
 	transactions  []chan *TransactionNotifications
 	currentTxNtfn *TransactionNotifications // coalesce this since wallet does not add mined txs together
+	conflicted    []ConflictedTransaction   // removed by the mined tx being added
 	mu            sync.Mutex                // Only protects registered client channels
 	wallet        *Wallet                   // smells like hacks
 }
@@ -231,6 +234,104 @@ func (s *NotificationServer) notifyUnminedTransaction(dbtx walletdb.ReadTx, deta
 	}
 }
 
+// notifyExpiredTransactions notifies clients of unmined transactions which
+// were dropped because they did not confirm within MempoolExpiry.
+func (s *NotificationServer) notifyExpiredTransactions(dbtx walletdb.ReadTx, expired []*chainhash.Hash) {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	clients := s.transactions
+	if len(clients) == 0 {
+		return
+	}
+
+	unminedHashes, err := s.wallet.TxStore.UnminedTxHashes(dbtx.ReadBucket(wtxmgrNamespaceKey))
+	if err != nil {
+		log.Errorf("Cannot fetch unmined transaction hashes: %v", err)
+		return
+	}
+	n := &TransactionNotifications{
+		UnminedTransactionHashes: unminedHashes,
+		ExpiredTransactions:      expired,
+	}
+	for _, c := range clients {
+		c <- n
+	}
+}
+
+// addConflictedTransaction records an unmined transaction which conflicts with
+// a mined transaction, to be notified by notifyConflictedTransactions once the
+// mined transaction has been added.
+func (s *NotificationServer) addConflictedTransaction(hash, conflictedBy *chainhash.Hash) {
+	h, by := *hash, *conflictedBy
+	s.conflicted = append(s.conflicted, ConflictedTransaction{
+		Hash:         &h,
+		ConflictedBy: &by,
+	})
+}
+
+// maturedCoinbaseOutputs returns the unspent coinbase outputs credited to the
+// wallet which become spendable once the block at height is attached, that is
+// those mined CoinbaseMaturity-1 blocks earlier.
+func (w *Wallet) maturedCoinbaseOutputs(txmgrNs walletdb.ReadBucket,
+	height int32) ([]MaturedCoinbaseOutput, er.R) {
+
+	minedAt := height - int32(w.chainParams.CoinbaseMaturity) + 1
+	if minedAt < 0 || minedAt > height {
+		return nil, nil
+	}
+	var matured []MaturedCoinbaseOutput
+	err := w.TxStore.RangeTransactions(txmgrNs, minedAt, minedAt,
+		func(details []wtxmgr.TxDetails) (bool, er.R) {
+			for i := range details {
+				d := &details[i]
+				if !blockchain.IsCoinBaseTx(&d.MsgTx) {
+					continue
+				}
+				for _, c := range d.Credits {
+					if c.Spent {
+						continue
+					}
+					matured = append(matured, MaturedCoinbaseOutput{
+						OutPoint: wire.OutPoint{Hash: d.Hash, Index: c.Index},
+						Amount:   c.Amount,
+					})
+				}
+			}
+			return false, nil
+		})
+	return matured, err
+}
+
+// notifyConflictedTransactions notifies clients of unmined transactions which
+// were removed because they conflict with a mined transaction.
+func (s *NotificationServer) notifyConflictedTransactions(dbtx walletdb.ReadTx) {
+	conflicted := s.conflicted
+	s.conflicted = nil
+	if len(conflicted) == 0 {
+		return
+	}
+
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	clients := s.transactions
+	if len(clients) == 0 {
+		return
+	}
+
+	unminedHashes, err := s.wallet.TxStore.UnminedTxHashes(dbtx.ReadBucket(wtxmgrNamespaceKey))
+	if err != nil {
+		log.Errorf("Cannot fetch unmined transaction hashes: %v", err)
+		return
+	}
+	n := &TransactionNotifications{
+		UnminedTransactionHashes: unminedHashes,
+		ConflictedTransactions:   conflicted,
+	}
+	for _, c := range clients {
+		c <- n
+	}
+}
+
 func (s *NotificationServer) notifyDetachedBlock(hash *chainhash.Hash) {
 	if s.currentTxNtfn == nil {
 		s.currentTxNtfn = &TransactionNotifications{}
@@ -271,6 +372,13 @@ func (s *NotificationServer) notifyAttachedBlock(dbtx walletdb.ReadTx, block *wt
 			Timestamp: block.Time.Unix(),
 		})
 	}
+	matured, err := s.wallet.maturedCoinbaseOutputs(
+		dbtx.ReadBucket(wtxmgrNamespaceKey), block.Height)
+	if err != nil {
+		log.Errorf("Cannot fetch matured coinbase outputs: %v", err)
+	}
+	s.currentTxNtfn.MaturedCoinbaseOutputs = append(
+		s.currentTxNtfn.MaturedCoinbaseOutputs, matured...)
 
 	// For now (until notification coalescing isn't necessary) just use
 	// chain length to determine if this is the new best block.
@@ -330,8 +438,12 @@ func (s *NotificationServer) notifyAttachedBlock(dbtx walletdb.ReadTx, block *wt
 // sorted in the order mined.
 //
 // All newly added unmined transactions are included.  Removed unmined
-// transactions are not explicitly included.  Instead, the hashes of all
-// transactions still unmined are included.
+// transactions are not explicitly included, except for those which expired
+// after MempoolExpiry or which conflict with a mined transaction.  Instead, the
+// hashes of all transactions still unmined are included.
+//
+// Coinbase outputs credited to the wallet which became spendable in an
+// attached block are included in MaturedCoinbaseOutputs.
 //
 // If any transactions were involved, each affected account's new total balance
 // is included.
@@ -343,9 +455,27 @@ type TransactionNotifications struct {
 	DetachedBlocks           []*chainhash.Hash
 	UnminedTransactions      []TransactionSummary
 	UnminedTransactionHashes []*chainhash.Hash
+	ExpiredTransactions      []*chainhash.Hash
+	ConflictedTransactions   []ConflictedTransaction
+	MaturedCoinbaseOutputs   []MaturedCoinbaseOutput
 	NewBalances              []AccountBalance
 }
 
+// MaturedCoinbaseOutput is an unspent coinbase output credited to the wallet
+// which reached coinbase maturity and so became spendable.
+type MaturedCoinbaseOutput struct {
+	OutPoint wire.OutPoint
+	Amount   btcutil.Amount
+}
+
+// ConflictedTransaction is an unmined transaction which was removed because a
+// mined transaction, ConflictedBy, double spends one of its inputs or an input
+// of a transaction which it spends from.
+type ConflictedTransaction struct {
+	Hash         *chainhash.Hash
+	ConflictedBy *chainhash.Hash
+}
+
 // Block contains the properties and all relevant transactions of an attached
 // block.
 type Block struct {
@@ -0,0 +1,59 @@
+package wallet
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// PendingTx is an unconfirmed wallet transaction.
+type PendingTx struct {
+	Hash     chainhash.Hash
+	Received time.Time
+
+	// FeeKnown is false if the transaction spends outputs which are not
+	// the wallet's, in which case Fee and FeeRate are zero.  FeeRate is
+	// in atomic units per kilobyte of virtual size.
+	FeeKnown bool
+	Fee      btcutil.Amount
+	FeeRate  btcutil.Amount
+
+	// Replaceable is true if the transaction opts in to replacement
+	// under BIP125.
+	Replaceable bool
+}
+
+// PendingTxs returns the wallet's unconfirmed transactions, oldest first.
+func (w *Wallet) PendingTxs() ([]PendingTx, er.R) {
+	var pending []PendingTx
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		return w.TxStore.RangeTransactions(txmgrNs, -1, -1,
+			func(details []wtxmgr.TxDetails) (bool, er.R) {
+				for i := range details {
+					d := &details[i]
+					p := PendingTx{
+						Hash:        d.Hash,
+						Received:    d.Received,
+						Replaceable: wtxmgr.SignalsReplacement(&d.MsgTx),
+					}
+					p.Fee, p.FeeKnown = txFee(d)
+					p.FeeRate, _ = txFeeRate(d)
+					pending = append(pending, p)
+				}
+				return false, nil
+			})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].Received.Before(pending[j].Received)
+	})
+	return pending, nil
+}
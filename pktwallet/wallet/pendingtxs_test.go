@@ -0,0 +1,75 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/wire"
+	"github.com/pkt-cash/pktd/wire/constants"
+)
+
+// TestPendingTxs seeds a few unconfirmed transactions and checks the fee,
+// fee rate, received time and replaceability reported for each.
+func TestPendingTxs(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	// A mined receive, which is not pending.
+	prev := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(100e8, []byte{0x51})},
+	}
+	insertTestTx(t, w, prev, 100, 0)
+
+	// An unmined receive from a foreign input, whose fee is not known.
+	now := time.Unix(1600000000, 0)
+	recv := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Index: 7},
+			Sequence:         constants.MaxTxInSequenceNum,
+		}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(5e8, []byte{0x51})},
+	}
+	insertTestTxAt(t, w, recv, -1, now.Add(-time.Hour), time.Time{}, 0)
+
+	// An unmined send which spends the mined receive and signals
+	// replacement.
+	send := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: prev.TxHash()},
+			Sequence:         constants.MaxTxInSequenceNum - 2,
+		}},
+		TxOut: []*wire.TxOut{
+			wire.NewTxOut(99e8-20000, []byte{0x51}),
+			wire.NewTxOut(1e8, []byte{0x52}),
+		},
+	}
+	insertTestTxAt(t, w, send, -1, now.Add(-2*time.Hour), time.Time{}, 0)
+
+	pending, err := w.PendingTxs()
+	if err != nil {
+		t.Fatalf("unable to list pending transactions: %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("got %d pending transactions, want 2", len(pending))
+	}
+
+	// Oldest first.
+	p := pending[0]
+	wantRate := btcutil.Amount(20000 * 1000 / int64(virtualSize(send)))
+	if p.Hash != send.TxHash() || !p.Received.Equal(now.Add(-2*time.Hour)) ||
+		!p.FeeKnown || p.Fee != 20000 || p.FeeRate != wantRate ||
+		!p.Replaceable {
+
+		t.Fatalf("got pending send %+v, want fee 20000 rate %v "+
+			"replaceable", p, wantRate)
+	}
+	p = pending[1]
+	if p.Hash != recv.TxHash() || !p.Received.Equal(now.Add(-time.Hour)) ||
+		p.FeeKnown || p.Fee != 0 || p.FeeRate != 0 || p.Replaceable {
+
+		t.Fatalf("got pending receive %+v, want unknown fee and not "+
+			"replaceable", p)
+	}
+}
@@ -0,0 +1,198 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/internal/txsizes"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txauthor"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// maxPrivacyChangeOutputs is the most outputs which the change of a privacy
+// send is split into, besides the output holding what is left over.
+const maxPrivacyChangeOutputs = 4
+
+// minDenominationExp is the exponent of the smallest common denomination,
+// 1/1000th of a coin.
+const minDenominationExp = -3
+
+// denomination returns the largest common denomination which is no more than
+// amt, or zero if amt is less than the smallest.
+func denomination(amt btcutil.Amount) btcutil.Amount {
+	coin := btcutil.UnitsPerCoin()
+	best := btcutil.Amount(0)
+	for exp := minDenominationExp; ; exp++ {
+		unit := coin
+		for i := exp; i < 0; i++ {
+			unit /= 10
+		}
+		for i := 0; i < exp; i++ {
+			unit *= 10
+		}
+		if unit > amt {
+			return best
+		}
+		for _, m := range []btcutil.Amount{1, 2, 5} {
+			if d := unit * m; d <= amt {
+				best = d
+			}
+		}
+	}
+}
+
+// equalPaymentValue returns the value of the payments of tx if they are all of
+// one value, or zero.
+func equalPaymentValue(tx *txauthor.AuthoredTx) btcutil.Amount {
+	value := btcutil.Amount(0)
+	for i, out := range tx.Tx.TxOut {
+		if i == tx.ChangeIndex {
+			continue
+		}
+		if value != 0 && btcutil.Amount(out.Value) != value {
+			return 0
+		}
+		value = btcutil.Amount(out.Value)
+	}
+	return value
+}
+
+// splitPrivacyChange splits the change output of tx as described for
+// PrivacySend.  Each output added is paid for from the change at feeSatPerKb,
+// and an output is only added if the change left over is not dust.  When the
+// change is within dust of a denomination it is rounded down to it, the
+// remainder going to the fee.  The change output stays at ChangeIndex and the
+// outputs split from it pay the fresh P2WPKH scripts from newScripts, so that
+// they cannot be linked as change by their script, their indexes are added to
+// SplitChange.
+func splitPrivacyChange(tx *txauthor.AuthoredTx, feeSatPerKb btcutil.Amount,
+	newScripts func(n int) ([][]byte, er.R)) er.R {
+
+	if tx.ChangeIndex < 0 {
+		return nil
+	}
+	var nested, p2wpkh, p2pkh int
+	for _, add := range tx.Tx.Additional {
+		switch {
+		case txscript.IsPayToScriptHash(add.PkScript):
+			nested++
+		case txscript.IsPayToWitnessPubKeyHash(add.PkScript):
+			p2wpkh++
+		default:
+			p2pkh++
+		}
+	}
+	change := tx.Tx.TxOut[tx.ChangeIndex]
+	isDust := func(amt btcutil.Amount) bool {
+		return txrules.IsDustAmount(amt, len(change.PkScript),
+			txrules.DefaultRelayFeePerKb)
+	}
+	// fee is what the transaction pays now, outputs are only added if the
+	// change can cover the fee for the larger transaction.
+	fee := tx.TotalInput
+	for _, out := range tx.Tx.TxOut {
+		fee -= btcutil.Amount(out.Value)
+	}
+
+	// The outputs are sized with placeholder scripts and only given their
+	// scripts once it is known how many there are, so that no address is
+	// used up for an output which is not added.
+	var split []*wire.TxOut
+	equal := equalPaymentValue(tx)
+	for len(split) < maxPrivacyChangeOutputs {
+		remaining := btcutil.Amount(change.Value)
+		next := equal
+		if next == 0 || next > remaining {
+			next = denomination(remaining)
+		}
+		if next == 0 {
+			break
+		}
+		if isDust(remaining - next) {
+			change.Value = int64(next)
+			break
+		}
+		out := wire.NewTxOut(int64(next), make([]byte, txsizes.P2WPKHPkScriptSize))
+		size := txsizes.EstimateVirtualSize(p2pkh, p2wpkh, nested,
+			append(tx.Tx.TxOut[:len(tx.Tx.TxOut):len(tx.Tx.TxOut)], out), false)
+		extra := txrules.FeeForSerializeSize(feeSatPerKb, size) - fee
+		if extra < 0 {
+			extra = 0
+		}
+		left := remaining - next - extra
+		if left >= 0 && !isDust(left) {
+			tx.SplitChange = append(tx.SplitChange, len(tx.Tx.TxOut))
+			tx.Tx.TxOut = append(tx.Tx.TxOut, out)
+			split = append(split, out)
+			change.Value = int64(left)
+			fee += extra
+		} else if equal == 0 {
+			break
+		}
+		// The payment value is only matched once, whether or not the
+		// change could afford it.
+		equal = 0
+	}
+	if len(split) == 0 {
+		return nil
+	}
+	scripts, err := newScripts(len(split))
+	if err != nil {
+		return err
+	}
+	for i, out := range split {
+		out.PkScript = scripts[i]
+	}
+	return nil
+}
+
+// newChangeScripts derives n new change addresses of the default account for
+// the outputs split from the change of a privacy send, returning the addresses
+// and the scripts paying them.  The addresses are taken from the internal
+// branch so that they never count against the receiving addresses.
+func (w *Wallet) newChangeScripts(addrmgrNs walletdb.ReadWriteBucket,
+	n int) ([]btcutil.Address, [][]byte, er.R) {
+
+	manager, err := w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		return nil, nil, err
+	}
+	mas, err := manager.NextInternalAddresses(addrmgrNs, 0, uint32(n))
+	if err != nil {
+		return nil, nil, err
+	}
+	addrs := make([]btcutil.Address, 0, n)
+	scripts := make([][]byte, 0, n)
+	for _, ma := range mas {
+		script, err := txscript.PayToAddrScript(ma.Address())
+		if err != nil {
+			return nil, nil, err
+		}
+		addrs = append(addrs, ma.Address())
+		scripts = append(scripts, script)
+	}
+	return addrs, scripts, nil
+}
+
+// shuffleOutputs moves each output of tx to a random position, keeping
+// ChangeIndex and SplitChange on the change outputs.
+func shuffleOutputs(tx *txauthor.AuthoredTx) {
+	for i := range tx.Tx.TxOut {
+		r := txauthor.RandomizeOutputPosition(tx.Tx.TxOut, i)
+		swap := func(idx int) int {
+			if idx == r {
+				return i
+			} else if idx == i {
+				return r
+			}
+			return idx
+		}
+		tx.ChangeIndex = swap(tx.ChangeIndex)
+		for j, idx := range tx.SplitChange {
+			tx.SplitChange[j] = swap(idx)
+		}
+	}
+}
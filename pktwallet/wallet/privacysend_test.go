@@ -0,0 +1,163 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/internal/txsizes"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txrules"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestPrivacySend ensures that with PrivacySend the change of a send is split
+// into an output matching the payment and outputs of common denominations,
+// each paying a different change address of the wallet, when it is large
+// enough, and is left alone when it is not.  A send which is not signed keeps
+// none of the addresses.
+func TestPrivacySend(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.cfg.PrivacySend = true
+
+	addr, err := w.CurrentAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get current address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+	coin := btcutil.UnitsPerCoin()
+	insertTestTx(t, w, &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(int64(10*coin), pkScript)},
+	}, 100, 0)
+
+	keyCounts := func() (uint32, uint32) {
+		var props *waddrmgr.AccountProperties
+		err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+			manager, err := w.Manager.FetchScopedKeyManager(
+				waddrmgr.KeyScopeBIP0084)
+			if err != nil {
+				return err
+			}
+			props, err = manager.AccountProperties(
+				dbtx.ReadBucket(waddrmgrNamespaceKey), 0)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("unable to read account properties: %v", err)
+		}
+		return props.ExternalKeyCount, props.InternalKeyCount
+	}
+
+	send := func(value btcutil.Amount) []btcutil.Amount {
+		tx, err := w.SendOutputs(CreateTxReq{
+			Outputs:     []*wire.TxOut{wire.NewTxOut(int64(value), []byte{0x51})},
+			Minconf:     1,
+			FeeSatPerKB: 1000,
+			MaxInputs:   -1,
+			SendMode:    SendModeSigned,
+		})
+		if err != nil {
+			t.Fatalf("unable to send %v: %v", value, err)
+		}
+		fee := tx.TotalInput
+		var change []btcutil.Amount
+		scripts := make(map[string]struct{})
+		for i, out := range tx.Tx.TxOut {
+			fee -= btcutil.Amount(out.Value)
+			if !tx.IsChange(i) {
+				if out.Value != int64(value) {
+					t.Fatalf("got payment of %v, want %v", out.Value, value)
+				}
+				continue
+			}
+			change = append(change, btcutil.Amount(out.Value))
+			if _, ok := scripts[string(out.PkScript)]; ok {
+				t.Fatalf("change output %d reuses the script of "+
+					"another change output", i)
+			}
+			scripts[string(out.PkScript)] = struct{}{}
+			a := txscript.PkScriptToAddress(out.PkScript, w.chainParams)
+			if mine, err := w.HaveAddress(a); err != nil || !mine {
+				t.Fatalf("change output %d pays %v which is not "+
+					"the wallet's", i, a)
+			}
+		}
+		if len(tx.SplitChange) != len(change)-1 {
+			t.Fatalf("got split change %v for %d change outputs",
+				tx.SplitChange, len(change))
+		}
+		size := txsizes.EstimateVirtualSize(0, 1, 0, tx.Tx.TxOut, false)
+		if min := txrules.FeeForSerializeSize(1000, size); fee < min {
+			t.Fatalf("got fee %v for %d outputs, want at least %v", fee,
+				len(tx.Tx.TxOut), min)
+		}
+		return change
+	}
+
+	// The change matches the payment once and is then split into the
+	// largest denominations which it covers, up to the limit, with the
+	// remainder left in the last output.
+	payment := coin * 12345 / 10000
+	change := send(payment)
+	if len(change) != maxPrivacyChangeOutputs+1 {
+		t.Fatalf("got %d change outputs %v, want %d", len(change), change,
+			maxPrivacyChangeOutputs+1)
+	}
+	want := map[btcutil.Amount]bool{
+		payment: true, 5 * coin: true, 2 * coin: true, coin / 2: true,
+	}
+	remainders := 0
+	for _, v := range change {
+		if want[v] {
+			delete(want, v)
+		} else {
+			remainders++
+		}
+	}
+	if len(want) != 0 || remainders != 1 {
+		t.Fatalf("got change outputs %v, missing %v", change, want)
+	}
+
+	// A dry run splits the change in the same way, but the addresses which
+	// it derives are not kept.
+	external, internal := keyCounts()
+	tx, err := w.SendOutputs(CreateTxReq{
+		Outputs:     []*wire.TxOut{wire.NewTxOut(int64(payment), []byte{0x51})},
+		Minconf:     1,
+		FeeSatPerKB: 1000,
+		MaxInputs:   -1,
+		SendMode:    SendModeUnsigned,
+	})
+	if err != nil {
+		t.Fatalf("unable to create unsigned send: %v", err)
+	}
+	if len(tx.SplitChange) != maxPrivacyChangeOutputs {
+		t.Fatalf("got split change %v for unsigned send, want %d outputs",
+			tx.SplitChange, maxPrivacyChangeOutputs)
+	}
+	if e, i := keyCounts(); e != external || i != internal {
+		t.Fatalf("unsigned send changed key counts from %d/%d to %d/%d",
+			external, internal, e, i)
+	}
+
+	// Change below the smallest denomination is not split.
+	if change := send(10*coin - coin/2000); len(change) != 1 {
+		t.Fatalf("got %d change outputs %v for small change, want 1",
+			len(change), change)
+	}
+
+	if d := denomination(coin*7/10 + 1); d != coin/2 {
+		t.Fatalf("got denomination %v, want %v", d, coin/2)
+	}
+	if d := denomination(coin/1000 - 1); d != 0 {
+		t.Fatalf("got denomination %v below the smallest, want 0", d)
+	}
+}
@@ -0,0 +1,164 @@
+package wallet
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/pktlog/log"
+)
+
+// recoveryMinBlocks is how far behind the chain the wallet must be when it
+// catches up for the catch up to be reported as a recovery, smaller gaps are
+// closed before any progress would be worth reporting.
+const recoveryMinBlocks = 100
+
+// RecoveryStatus is the progress of the wallet scanning the chain for its
+// transactions, either catching up to the tip after being restored from seed
+// or opened after a long time, or a resync.
+type RecoveryStatus struct {
+	// Active is set while the recovery is running, once it completes or
+	// is stopped the status of the last recovery remains.
+	Active bool
+	Name   string
+
+	StartHeight   int32
+	CurrentHeight int32
+	TargetHeight  int32
+
+	// AddressesFound is the number of addresses of the wallet which were
+	// paid in the blocks scanned so far.
+	AddressesFound int
+
+	Started time.Time
+
+	// Remaining estimates how much longer the recovery will take from the
+	// rate at which blocks have been scanned so far, it is zero until
+	// some blocks have been scanned.
+	Remaining time.Duration
+}
+
+// Progress returns the fraction of the blocks of the recovery which have been
+// scanned, between 0 and 1.
+func (s RecoveryStatus) Progress() float64 {
+	if s.TargetHeight <= s.StartHeight {
+		return 1
+	}
+	p := float64(s.CurrentHeight-s.StartHeight) /
+		float64(s.TargetHeight-s.StartHeight)
+	if p > 1 {
+		return 1
+	}
+	return p
+}
+
+// recoveryTracker holds the status of the current or last recovery.
+type recoveryTracker struct {
+	// interval is how often the progress is logged, zero means progress
+	// is not logged.
+	interval time.Duration
+
+	mtx     sync.Mutex
+	status  RecoveryStatus
+	found   map[string]struct{}
+	lastLog time.Time
+}
+
+// begin starts recovery name, which scans the blocks from start to target,
+// unless it is already running, so that the addresses found in the blocks
+// which it scans next are counted.
+func (t *recoveryTracker) begin(name string, start, target int32) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.begin0(name, start, target)
+}
+
+func (t *recoveryTracker) begin0(name string, start, target int32) {
+	if t.status.Active && t.status.Name == name {
+		return
+	}
+	now := time.Now()
+	t.status = RecoveryStatus{
+		Active:        true,
+		Name:          name,
+		StartHeight:   start,
+		CurrentHeight: start,
+		TargetHeight:  target,
+		Started:       now,
+	}
+	t.found = make(map[string]struct{})
+	t.lastLog = now
+	log.Infof("Recovery [%s] scanning blocks [%d] to [%d]", name, start, target)
+}
+
+// advance records that recovery name has scanned the blocks up to height of
+// those from start to target, beginning it if it is not running.  It logs the
+// progress every interval and when the recovery completes.
+func (t *recoveryTracker) advance(name string, start, height, target int32) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.begin0(name, start, target)
+	now := time.Now()
+	s := &t.status
+	s.CurrentHeight = height
+	s.TargetHeight = target
+	s.Remaining = 0
+	if scanned := height - s.StartHeight; scanned > 0 && target > height {
+		perBlock := now.Sub(s.Started) / time.Duration(scanned)
+		s.Remaining = perBlock * time.Duration(target-height)
+	}
+	if height >= target {
+		s.Active = false
+		log.Infof("Recovery [%s] complete at block [%d] after [%s], "+
+			"found [%d] addresses", name, height,
+			now.Sub(s.Started).Round(time.Second), s.AddressesFound)
+		return
+	}
+	if t.interval > 0 && now.Sub(t.lastLog) >= t.interval {
+		t.lastLog = now
+		log.Infof("Recovery [%s] at block [%d] of [%d] (%.1f%%), found [%d] "+
+			"addresses, about [%s] remaining", name, height, target,
+			s.Progress()*100, s.AddressesFound, s.Remaining.Round(time.Second))
+	}
+}
+
+// stop marks recovery name as no longer running, if it is the current one.
+func (t *recoveryTracker) stop(name string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.status.Active && t.status.Name == name {
+		t.status.Active = false
+		t.status.Remaining = 0
+	}
+}
+
+// foundAddress records that addr was paid in a block scanned by the running
+// recovery, if any.
+func (t *recoveryTracker) foundAddress(addr btcutil.Address) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if !t.status.Active {
+		return
+	}
+	if _, ok := t.found[addr.String()]; !ok {
+		t.found[addr.String()] = struct{}{}
+		t.status.AddressesFound++
+	}
+}
+
+// running returns the name of the recovery which is running, ok is false if
+// none is.
+func (t *recoveryTracker) running() (name string, ok bool) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.status.Name, t.status.Active
+}
+
+// RecoveryStatus returns the progress of the running recovery, or of the last
+// one if none is running.  The zero status is returned if the wallet has not
+// recovered since it was opened.
+func (w *Wallet) RecoveryStatus() RecoveryStatus {
+	w.recovery.mtx.Lock()
+	defer w.recovery.mtx.Unlock()
+	return w.recovery.status
+}
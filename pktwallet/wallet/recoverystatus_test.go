@@ -0,0 +1,91 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestRecoveryStatus ensures that the recovery status advances as a resync
+// scans the chain, counting each address which was paid once, and that it is
+// no longer active once the resync completes.
+func TestRecoveryStatus(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	const chainHeight = 250
+	c := newRescanChainClient()
+	c.addBlocks(0, chainHeight, 0)
+	pay := func(height int32) {
+		addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+		if err != nil {
+			t.Fatalf("unable to get new address: %v", err)
+		}
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			t.Fatalf("unable to create pkScript: %v", err)
+		}
+		for _, h := range []int32{height, height + 10} {
+			c.txns[h] = append(c.txns[h], &wire.MsgTx{
+				Version: 1,
+				TxIn: []*wire.TxIn{{
+					PreviousOutPoint: wire.OutPoint{Index: uint32(h)},
+				}},
+				TxOut: []*wire.TxOut{wire.NewTxOut(1e8, pkScript)},
+			})
+		}
+	}
+	pay(20)
+	pay(150)
+	w.chainClient = c
+
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return w.Manager.SetSyncedTo(ns, &waddrmgr.BlockStamp{
+			Height:    chainHeight,
+			Hash:      c.hashes[chainHeight],
+			Timestamp: c.headers[c.hashes[chainHeight]].Timestamp,
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to set synced to: %v", err)
+	}
+
+	if st := w.RecoveryStatus(); st.Active || !st.Started.IsZero() {
+		t.Fatalf("got status %+v before recovering, want none", st)
+	}
+	if err := w.ResyncChain(0, -1, nil, false); err != nil {
+		t.Fatalf("unable to start resync: %v", err)
+	}
+
+	for _, want := range []struct {
+		height int32
+		found  int
+		active bool
+	}{
+		{101, 1, true},
+		{201, 2, true},
+		{chainHeight, 2, false},
+	} {
+		w.rescan()
+		st := w.RecoveryStatus()
+		if st.CurrentHeight != want.height || st.AddressesFound != want.found ||
+			st.Active != want.active {
+
+			t.Fatalf("got status %+v, want height %d with %d addresses "+
+				"found and active %v", st, want.height, want.found,
+				want.active)
+		}
+		if st.TargetHeight != chainHeight || st.StartHeight != 1 {
+			t.Fatalf("got status %+v, want blocks 1 to %d", st,
+				chainHeight)
+		}
+	}
+	if p := w.RecoveryStatus().Progress(); p != 1 {
+		t.Fatalf("got progress %v after the resync completed, want 1", p)
+	}
+}
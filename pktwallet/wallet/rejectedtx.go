@@ -0,0 +1,68 @@
+package wallet
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+)
+
+// maxRejectedTxs is the number of rejected transactions which are remembered,
+// once it is reached the oldest rejection is forgotten for each new one.
+const maxRejectedTxs = 100
+
+// RejectedTx is a transaction which the backend refused to broadcast.
+type RejectedTx struct {
+	Hash   chainhash.Hash
+	Reason string
+	Time   time.Time
+}
+
+// rejectedTxs is a ring buffer of the most recently rejected transactions.
+type rejectedTxs struct {
+	mtx  sync.Mutex
+	txs  []RejectedTx
+	next int
+}
+
+// add records a rejected transaction, replacing the oldest if the buffer is
+// full.
+func (r *rejectedTxs) add(rej RejectedTx) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if len(r.txs) < maxRejectedTxs {
+		r.txs = append(r.txs, rej)
+		return
+	}
+	r.txs[r.next] = rej
+	r.next = (r.next + 1) % maxRejectedTxs
+}
+
+// list returns the rejected transactions, most recent first.
+func (r *rejectedTxs) list() []RejectedTx {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	out := make([]RejectedTx, 0, len(r.txs))
+	for i := len(r.txs) - 1; i >= 0; i-- {
+		out = append(out, r.txs[(r.next+i)%len(r.txs)])
+	}
+	return out
+}
+
+// recordRejectedTx remembers that the backend rejected a transaction, the
+// reason is the message of the error which it returned.
+func (w *Wallet) recordRejectedTx(txid chainhash.Hash, err er.R) {
+	w.rejectedTxs.add(RejectedTx{
+		Hash:   txid,
+		Reason: err.Message(),
+		Time:   time.Now(),
+	})
+}
+
+// RejectedTxs returns the transactions which were most recently rejected when
+// they were broadcast, most recent first, along with the reason which the
+// backend gave for rejecting them.
+func (w *Wallet) RejectedTxs() []RejectedTx {
+	return w.rejectedTxs.list()
+}
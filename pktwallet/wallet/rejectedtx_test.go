@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/neutrino/pushtx"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// rejectChainClient is a mock chain client which rejects every transaction.
+type rejectChainClient struct {
+	mockChainClient
+}
+
+func (c *rejectChainClient) SendRawTransaction(*wire.MsgTx, bool) (
+	*chainhash.Hash, er.R) {
+	return nil, pushtx.RejInsufficientFee.New("min relay fee not met", nil)
+}
+
+// TestRejectedTxs ensures that a transaction which is rejected when it is
+// broadcast is listed with the reason and that only the most recent
+// rejections are kept.
+func TestRejectedTxs(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+	w.chainClient = &rejectChainClient{}
+
+	tx := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+	}
+	if err := w.PublishTransaction(tx, ""); !pushtx.RejInsufficientFee.Is(err) {
+		t.Fatalf("got error %v, want RejInsufficientFee", err)
+	}
+	rejected := w.RejectedTxs()
+	if len(rejected) != 1 {
+		t.Fatalf("got %d rejected transactions, want 1", len(rejected))
+	}
+	if rejected[0].Hash != tx.TxHash() {
+		t.Fatalf("got rejected transaction %v, want %v", rejected[0].Hash,
+			tx.TxHash())
+	}
+	if !strings.Contains(rejected[0].Reason, "min relay fee not met") {
+		t.Fatalf("got reason %q, want the peer's reason", rejected[0].Reason)
+	}
+
+	// Reject more transactions than are kept, the oldest are dropped.
+	for i := 0; i < maxRejectedTxs+5; i++ {
+		w.recordRejectedTx(chainhash.Hash{byte(i)}, er.New("rejected"))
+	}
+	rejected = w.RejectedTxs()
+	if len(rejected) != maxRejectedTxs {
+		t.Fatalf("got %d rejected transactions, want %d", len(rejected),
+			maxRejectedTxs)
+	}
+	if rejected[0].Hash != (chainhash.Hash{byte(maxRejectedTxs + 4)}) {
+		t.Fatalf("got %v as the most recent rejection", rejected[0].Hash)
+	}
+	if rejected[maxRejectedTxs-1].Hash != (chainhash.Hash{5}) {
+		t.Fatalf("got %v as the oldest rejection, want %v",
+			rejected[maxRejectedTxs-1].Hash, chainhash.Hash{5})
+	}
+}
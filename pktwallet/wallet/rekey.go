@@ -0,0 +1,68 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+)
+
+// ErrUnsafeScryptParams is returned when rekeying the wallet with scrypt
+// parameters which are too weak to protect the keys, or which need so much
+// memory that unlocking the wallet may fail.
+var ErrUnsafeScryptParams = Err.CodeWithDetail("ErrUnsafeScryptParams",
+	"scrypt parameters are outside of the safe range")
+
+// The safe range of scrypt parameters for Rekey.  N must also be a power of
+// two, and the memory used, 128 * N * R bytes, at most maxScryptMemory.
+const (
+	minScryptN      = 1 << 14
+	minScryptR      = 8
+	maxScryptR      = 32
+	minScryptP      = 1
+	maxScryptP      = 16
+	maxScryptMemory = 1 << 30
+)
+
+// checkScryptOptions returns ErrUnsafeScryptParams if opts is outside of the
+// safe range.
+func checkScryptOptions(opts *waddrmgr.ScryptOptions) er.R {
+	switch {
+	case opts.N < minScryptN || opts.N&(opts.N-1) != 0:
+		return ErrUnsafeScryptParams.New(fmt.Sprintf("N [%d] must be a "+
+			"power of two of at least [%d]", opts.N, minScryptN), nil)
+	case opts.R < minScryptR || opts.R > maxScryptR:
+		return ErrUnsafeScryptParams.New(fmt.Sprintf("r [%d] must be "+
+			"between [%d] and [%d]", opts.R, minScryptR, maxScryptR), nil)
+	case opts.P < minScryptP || opts.P > maxScryptP:
+		return ErrUnsafeScryptParams.New(fmt.Sprintf("p [%d] must be "+
+			"between [%d] and [%d]", opts.P, minScryptP, maxScryptP), nil)
+	case int64(opts.N)*int64(opts.R)*128 > maxScryptMemory:
+		return ErrUnsafeScryptParams.New(fmt.Sprintf("N [%d] and r [%d] "+
+			"need more than [%d] bytes of memory", opts.N, opts.R,
+			maxScryptMemory), nil)
+	}
+	return nil
+}
+
+// Rekey re-encrypts the keys securing the private keys and seed of the wallet
+// under a key derived from the same private passphrase with the scrypt
+// parameters opts, which must be in the safe range.  The wallet must be
+// unlocked.
+func (w *Wallet) Rekey(passphrase []byte, opts waddrmgr.ScryptOptions) er.R {
+	if err := checkScryptOptions(&opts); err != nil {
+		return err
+	}
+	if w.Locked() {
+		return waddrmgr.ErrLocked.Default()
+	}
+	err := make(chan er.R, 1)
+	w.changePassphrase <- changePassphraseRequest{
+		oldPass: passphrase,
+		newPass: passphrase,
+		private: true,
+		err:     err,
+		scrypt:  &opts,
+	}
+	return <-err
+}
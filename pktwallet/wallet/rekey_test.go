@@ -0,0 +1,70 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+)
+
+// TestRekey checks that the wallet can still be unlocked with the same
+// passphrase and sign with its keys after rekeying, and that unsafe scrypt
+// parameters are refused.
+func TestRekey(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	privPass := []byte("world")
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+
+	for _, opts := range []waddrmgr.ScryptOptions{
+		{N: 1 << 10, R: 8, P: 1},
+		{N: 1<<14 + 1, R: 8, P: 1},
+		{N: 1 << 14, R: 1, P: 1},
+		{N: 1 << 14, R: 8, P: 0},
+		{N: 1 << 22, R: 8, P: 1},
+	} {
+		err := w.Rekey(privPass, opts)
+		if !ErrUnsafeScryptParams.Is(err) {
+			t.Fatalf("got error %v rekeying with %+v, want "+
+				"ErrUnsafeScryptParams", err, opts)
+		}
+	}
+
+	opts := waddrmgr.ScryptOptions{N: 1 << 14, R: 8, P: 2}
+	if err := w.Rekey([]byte("wrong"), opts); !waddrmgr.ErrWrongPassphrase.Is(err) {
+		t.Fatalf("got error %v rekeying with the wrong passphrase", err)
+	}
+	if err := w.Rekey(privPass, opts); err != nil {
+		t.Fatalf("unable to rekey: %v", err)
+	}
+	if got := w.Manager.PrivateScryptOptions(); got != opts {
+		t.Fatalf("got scrypt options %+v after rekeying, want %+v",
+			got, opts)
+	}
+
+	w.Lock()
+	if err := w.Rekey(privPass, opts); !waddrmgr.ErrLocked.Is(err) {
+		t.Fatalf("got error %v rekeying a locked wallet", err)
+	}
+	if err := w.Unlock(privPass, time.After(10*time.Minute)); err != nil {
+		t.Fatalf("unable to unlock wallet after rekeying: %v", err)
+	}
+
+	key, err := w.PrivKeyForAddress(addr)
+	if err != nil {
+		t.Fatalf("unable to get private key after rekeying: %v", err)
+	}
+	hash := chainhash.DoubleHashB([]byte("message"))
+	sig, err := key.Sign(hash)
+	if err != nil {
+		t.Fatalf("unable to sign after rekeying: %v", err)
+	}
+	if !sig.Verify(hash, key.PubKey()) {
+		t.Fatalf("signature made after rekeying does not verify")
+	}
+}
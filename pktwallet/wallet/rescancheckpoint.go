@@ -0,0 +1,91 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktlog/log"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/watcher"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// saveRescanCheckpoint records the progress of rj.  Failing to record it is
+// not fatal to the rescan so the error is only logged.
+func (w *Wallet) saveRescanCheckpoint(rj *rescanJob) {
+	if !w.cfg.ResumeRescan || rj.dropDb {
+		return
+	}
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		return w.TxStore.PutRescanCheckpoint(tx.ReadWriteBucket(wtxmgrNamespaceKey),
+			&wtxmgr.RescanCheckpoint{
+				Name:       rj.name,
+				Height:     rj.height,
+				StopHeight: rj.stopHeight,
+				Addresses:  rj.addresses,
+			})
+	})
+	if err != nil {
+		log.Warnf("Unable to record progress of resync [%s]: [%s]", rj.name,
+			err.String())
+	}
+}
+
+// deleteRescanCheckpoint removes the progress recorded by saveRescanCheckpoint
+// once the job is done with.
+func (w *Wallet) deleteRescanCheckpoint() {
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		return w.TxStore.DeleteRescanCheckpoint(tx.ReadWriteBucket(wtxmgrNamespaceKey))
+	})
+	if err != nil {
+		log.Warnf("Unable to remove resync progress: [%s]", err.String())
+	}
+}
+
+// loadRescanCheckpoint restores the rescan job which was running when the
+// wallet was last closed, if any, so that it continues from the recorded
+// height.  A checkpoint which cannot be read is logged and discarded rather
+// than preventing the wallet from opening.
+func (w *Wallet) loadRescanCheckpoint() {
+	if !w.cfg.ResumeRescan {
+		return
+	}
+	var rc *wtxmgr.RescanCheckpoint
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		var err er.R
+		rc, err = w.TxStore.FetchRescanCheckpoint(tx.ReadBucket(wtxmgrNamespaceKey))
+		return err
+	})
+	if err != nil {
+		log.Warnf("Unable to read resync progress, not resuming: [%s]",
+			err.String())
+		w.deleteRescanCheckpoint()
+		return
+	} else if rc == nil {
+		return
+	}
+	watch := &w.watch
+	if rc.Addresses != nil {
+		ww := watcher.New()
+		watch = &ww
+		for _, addrStr := range rc.Addresses {
+			addr, err := btcutil.DecodeAddress(addrStr, w.chainParams)
+			if err != nil {
+				log.Warnf("Unable to resume resync [%s]: [%s]", rc.Name,
+					err.String())
+				w.deleteRescanCheckpoint()
+				return
+			}
+			watch.WatchAddr(addr)
+		}
+	}
+	log.Infof("Resuming resync [%s] from height [%d]", rc.Name, rc.Height)
+	w.rescanJLock.Lock()
+	defer w.rescanJLock.Unlock()
+	w.rescanJ = &rescanJob{
+		name:       rc.Name,
+		height:     rc.Height,
+		stopHeight: rc.StopHeight,
+		watch:      watch,
+		addresses:  rc.Addresses,
+	}
+}
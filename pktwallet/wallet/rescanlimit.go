@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"sync"
+
+	"github.com/pkt-cash/pktd/pktlog/log"
+)
+
+// rescanSlots admits the resyncs of wallets up to the MaxConcurrentRescans of
+// their Config at a time, queuing the rest in the order they asked.
+type rescanSlots struct {
+	mtx     sync.Mutex
+	running map[*Wallet]struct{}
+	queue   []*Wallet
+}
+
+var rescanScheduler = rescanSlots{running: make(map[*Wallet]struct{})}
+
+// acquire returns true if w may scan the next blocks of its resync job name,
+// either because it is already running or because there is a free slot and w
+// is first in the queue.  Otherwise w is queued and acquire returns false.
+func (s *rescanSlots) acquire(w *Wallet, name string) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if w.cfg.MaxConcurrentRescans <= 0 {
+		return true
+	}
+	if _, ok := s.running[w]; ok {
+		return true
+	}
+	queued := -1
+	for i, qw := range s.queue {
+		if qw == w {
+			queued = i
+		}
+	}
+	first := queued == 0 || (queued < 0 && len(s.queue) == 0)
+	if len(s.running) < w.cfg.MaxConcurrentRescans && first {
+		s.running[w] = struct{}{}
+		if queued == 0 {
+			s.queue = s.queue[1:]
+			log.Infof("Resync job [%s] starting after waiting", name)
+		}
+		return true
+	}
+	if queued < 0 {
+		s.queue = append(s.queue, w)
+		log.Infof("Resync job [%s] waiting, [%d] resyncs are running, "+
+			"which is the most allowed by maxconcurrentrescans",
+			name, len(s.running))
+	}
+	return false
+}
+
+// release frees the slot or place in the queue held by w, if any.
+func (s *rescanSlots) release(w *Wallet) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	delete(s.running, w)
+	for i, qw := range s.queue {
+		if qw == w {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			break
+		}
+	}
+}
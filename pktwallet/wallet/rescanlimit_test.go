@@ -0,0 +1,82 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// TestMaxConcurrentRescans ensures that with a limit of one resync, the resync
+// of a second wallet waits for that of the first to finish.
+func TestMaxConcurrentRescans(t *testing.T) {
+	const chainHeight = 250
+	start := func() (*Wallet, *addrFilterChainClient, func()) {
+		w, cleanup := testWallet(t)
+		w.cfg.MaxConcurrentRescans = 1
+		addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+		if err != nil {
+			t.Fatalf("unable to get new address: %v", err)
+		}
+		c := &addrFilterChainClient{rescanChainClient: newRescanChainClient()}
+		c.addBlocks(0, chainHeight, 0)
+		w.chainClient = c
+		err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			return w.Manager.SetSyncedTo(ns, &waddrmgr.BlockStamp{
+				Height:    chainHeight,
+				Hash:      c.hashes[chainHeight],
+				Timestamp: c.headers[c.hashes[chainHeight]].Timestamp,
+			})
+		})
+		if err != nil {
+			t.Fatalf("unable to set synced to: %v", err)
+		}
+		if err := w.RescanAddress(addr, 10, 150); err != nil {
+			t.Fatalf("unable to start rescan: %v", err)
+		}
+		return w, c, func() {
+			rescanScheduler.release(w)
+			cleanup()
+		}
+	}
+	first, _, cleanup := start()
+	defer cleanup()
+	second, c, cleanup := start()
+	defer cleanup()
+
+	first.rescan()
+	if first.rescanJ == nil || first.rescanJ.height != 110 {
+		t.Fatalf("first rescan did not stop at height 110: %+v",
+			first.rescanJ)
+	}
+
+	// The second rescan waits while the first is running.
+	second.rescan()
+	if second.rescanJ == nil || second.rescanJ.height != 10 ||
+		len(c.filtered) != 0 {
+
+		t.Fatalf("second rescan ran with the first running: job %+v, "+
+			"filtered %v", second.rescanJ, c.filtered)
+	}
+	first.rescan()
+	second.rescan()
+	if second.rescanJ.height != 10 || len(c.filtered) != 0 {
+		t.Fatalf("second rescan ran with the first running: job %+v, "+
+			"filtered %v", second.rescanJ, c.filtered)
+	}
+
+	// Once the first rescan reaches its end the second one runs.
+	first.rescan()
+	if first.rescanJ != nil {
+		t.Fatalf("first rescan did not finish: %+v", first.rescanJ)
+	}
+	second.rescan()
+	if second.rescanJ == nil || second.rescanJ.height != 110 ||
+		len(c.filtered) == 0 {
+
+		t.Fatalf("second rescan did not run after the first finished: "+
+			"job %+v, filtered %v", second.rescanJ, c.filtered)
+	}
+}
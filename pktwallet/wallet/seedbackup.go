@@ -0,0 +1,29 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// SeedBackup returns the wallet seed encrypted under backupPassphrase, in the
+// form made by seedwords.Seed.EncryptBackup.  The seed is stored encrypted
+// under the private passphrase of the wallet, so that is needed too.
+func (w *Wallet) SeedBackup(privPassphrase, backupPassphrase []byte) (string, er.R) {
+	xseed := w.Manager.Seed()
+	if xseed == nil {
+		return "", er.New("No seed found, this is probably a legacy wallet")
+	}
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		return w.Manager.CheckPassphrase(tx.ReadBucket(waddrmgrNamespaceKey),
+			privPassphrase)
+	})
+	if err != nil {
+		return "", err
+	}
+	seed, err := xseed.Decrypt(privPassphrase, false)
+	if err != nil {
+		return "", err
+	}
+	defer seed.Zero()
+	return seed.EncryptBackup(backupPassphrase)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2020 Anode LLC
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package seedwords
+
+import (
+	"encoding/hex"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/internal/zero"
+	"github.com/pkt-cash/pktd/pktwallet/snacl"
+)
+
+/**
+ * Seed backup layout, hex encoded:
+ *
+ *    +-----+--------------------+--------------------------------------+
+ *    | Ver |    Key parameters  |          Encrypted seed              |
+ *    +-----+--------------------+--------------------------------------+
+ *       1           88                   24 + 21 + 16
+ *
+ * Ver: 1
+ * Key parameters: the marshalled parameters of a snacl.SecretKey, the 32 byte
+ *   salt, the sha256 digest of the derived key, then scrypt N, r and p as 8
+ *   byte little endian numbers.
+ * Encrypted seed: the 24 byte nonce and NaCl secretbox (XSalsa20 and Poly1305)
+ *   of the 21 byte seed layout above with the birthday and seed in clear,
+ *   under the 32 byte key derived from the passphrase with scrypt.
+ *
+ * Unlike the seed words, which are short enough to write down but cannot tell
+ * a wrong passphrase from a right one, a backup which is decrypted with the
+ * wrong passphrase always fails.
+ */
+const backupVersion = 1
+const backupKeyParamsLen = snacl.KeySize + 32 + 24
+const backupLen = 1 + backupKeyParamsLen + snacl.NonceSize + encByteLen +
+	snacl.Overhead
+
+// The scrypt parameters of a backup, the same as those protecting the wallet's
+// own keys.
+const backupScryptN = 262144 // 2^18
+const backupScryptR = 8
+const backupScryptP = 1
+
+// Backups which would need more memory than this to derive the key are
+// refused rather than risk exhausting the memory of the machine.
+const backupMaxScryptMemory = 1024 * 1024 * 1024
+
+// EncryptBackup encrypts the seed under a passphrase for backup, the result is
+// decrypted with DecryptBackup.
+func (s *Seed) EncryptBackup(passphrase []byte) (string, er.R) {
+	if len(passphrase) == 0 {
+		return "", er.New("A passphrase is required to encrypt a seed backup")
+	}
+	sk, err := snacl.NewSecretKey(&passphrase, backupScryptN, backupScryptR,
+		backupScryptP)
+	if err != nil {
+		return "", err
+	}
+	defer sk.Zero()
+	enc, err := sk.Encrypt(s.seedBin.Bytes[:])
+	if err != nil {
+		return "", err
+	}
+	out := make([]byte, 0, backupLen)
+	out = append(out, backupVersion)
+	out = append(out, sk.Marshal()...)
+	out = append(out, enc...)
+	return hex.EncodeToString(out), nil
+}
+
+// IsBackup returns true if the string has the form of a seed backup made with
+// EncryptBackup.
+func IsBackup(backup string) bool {
+	b, err := hex.DecodeString(backup)
+	return err == nil && len(b) == backupLen && b[0] == backupVersion
+}
+
+// DecryptBackup decrypts a seed backup made with EncryptBackup.
+func DecryptBackup(backup string, passphrase []byte) (*Seed, er.R) {
+	b, errr := hex.DecodeString(backup)
+	if errr != nil {
+		return nil, er.New("Invalid seed backup: not hex encoded")
+	}
+	if len(b) != backupLen {
+		return nil, er.New("Invalid seed backup: Unexpected byte length")
+	}
+	if b[0] != backupVersion {
+		return nil, er.Errorf("Invalid seed backup: Unknown version [%d]", b[0])
+	}
+	var sk snacl.SecretKey
+	if err := sk.Unmarshal(b[1 : 1+backupKeyParamsLen]); err != nil {
+		return nil, err
+	}
+	params := &sk.Parameters
+	if params.N <= 0 || params.R <= 0 || params.P <= 0 ||
+		params.N > backupMaxScryptMemory/128/params.R {
+		return nil, er.New("Invalid seed backup: Unreasonable key parameters")
+	}
+	if err := sk.DeriveKey(&passphrase); err != nil {
+		if snacl.ErrInvalidPassword.Is(err) {
+			return nil, er.New("Wrong passphrase for seed backup")
+		}
+		return nil, err
+	}
+	defer sk.Zero()
+	dec, err := sk.Decrypt(b[1+backupKeyParamsLen:])
+	if err != nil {
+		return nil, er.New("Invalid seed backup: Unable to decrypt")
+	}
+	defer zero.Bytes(dec)
+	if len(dec) != encByteLen {
+		return nil, er.New("Invalid seed backup: Unexpected seed length")
+	}
+	out := Seed{}
+	copy(out.seedBin.Bytes[:], dec)
+	if out.Version() != seedVersion {
+		out.Zero()
+		return nil, er.Errorf("Invalid seed backup: Unknown seed version [%d]",
+			out.Version())
+	}
+	return &out, nil
+}
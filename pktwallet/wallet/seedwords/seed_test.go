@@ -61,3 +61,25 @@ func TestReversibleEncrypt(t *testing.T) {
 		require.Equal(t, seed.Birthday(), decipheredSeed.Birthday())
 	})
 }
+
+// Test that a seed backup decrypts to the same seed with the right passphrase
+// and fails to decrypt with the wrong one
+func TestEncryptBackup(t *testing.T) {
+	seed, err := seedwords.RandomSeed()
+	util.RequireNoErr(t, err)
+
+	backup, err := seed.EncryptBackup([]byte("backup password"))
+	util.RequireNoErr(t, err)
+	require.True(t, seedwords.IsBackup(backup))
+
+	restored, err := seedwords.DecryptBackup(backup, []byte("backup password"))
+	util.RequireNoErr(t, err)
+	require.Equal(t, seed.Bytes(), restored.Bytes())
+	require.Equal(t, seed.Birthday(), restored.Birthday())
+
+	_, err = seedwords.DecryptBackup(backup, []byte("wrong password"))
+	require.NotNil(t, err)
+
+	_, err = seed.EncryptBackup(nil)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,86 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/txscript"
+)
+
+// SelectionPrivacy is what the transaction which a send would create reveals
+// about the wallet.
+type SelectionPrivacy struct {
+	Inputs int
+
+	// InputAddresses is the number of distinct addresses spent by the
+	// inputs, which the transaction reveals to belong to one wallet.  The
+	// fewer there are the more private the send.
+	InputAddresses int
+
+	HasChange bool
+
+	// ChangeRevealed is set when the change output can be told apart from
+	// the payments, which reveals another address of the wallet.
+	// ChangeReasons says how.
+	ChangeRevealed bool
+	ChangeReasons  []string
+}
+
+// CoinSelectionPrivacy selects the inputs and change of a send of txr in the
+// same way as SimulateSend, and reports what the transaction would reveal
+// about the wallet.
+func (w *Wallet) CoinSelectionPrivacy(txr CreateTxReq) (*SelectionPrivacy, er.R) {
+	sim, err := w.SimulateSend(txr)
+	if err != nil {
+		return nil, err
+	}
+	tx := sim.Tx
+
+	params := w.ChainParams()
+	inputAddrs := make(map[string]struct{})
+	for _, add := range tx.Tx.Additional {
+		addr := txscript.PkScriptToAddress(add.PkScript, params)
+		inputAddrs[addr.EncodeAddress()] = struct{}{}
+	}
+	res := &SelectionPrivacy{
+		Inputs:         len(tx.Tx.TxIn),
+		InputAddresses: len(inputAddrs),
+		HasChange:      tx.ChangeIndex >= 0,
+	}
+	if !res.HasChange {
+		return res, nil
+	}
+
+	change := tx.Tx.TxOut[tx.ChangeIndex]
+	changeClass := txscript.GetScriptClass(change.PkScript)
+	changeAddr := txscript.PkScriptToAddress(change.PkScript, params)
+	// Round amounts are multiples of the smallest common denomination.
+	unit := btcutil.UnitsPerCoin() / 1000
+	sameClass := false
+	roundPayments := true
+	for i, out := range tx.Tx.TxOut {
+		if i == tx.ChangeIndex {
+			continue
+		}
+		if txscript.GetScriptClass(out.PkScript) == changeClass {
+			sameClass = true
+		}
+		if btcutil.Amount(out.Value)%unit != 0 {
+			roundPayments = false
+		}
+	}
+
+	if _, ok := inputAddrs[changeAddr.EncodeAddress()]; ok {
+		res.ChangeReasons = append(res.ChangeReasons,
+			"change pays an address which is spent by an input")
+	}
+	if !sameClass {
+		res.ChangeReasons = append(res.ChangeReasons,
+			"change is the only output of its address type")
+	}
+	if roundPayments && btcutil.Amount(change.Value)%unit != 0 {
+		res.ChangeReasons = append(res.ChangeReasons,
+			"payments are round amounts and change is not")
+	}
+	res.ChangeRevealed = len(res.ChangeReasons) > 0
+	return res, nil
+}
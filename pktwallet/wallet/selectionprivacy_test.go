@@ -0,0 +1,83 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestCoinSelectionPrivacy checks that a send spending coins of fewer distinct
+// addresses is reported as linking fewer addresses, and that change which can
+// be told apart from the payment is reported as revealed.
+func TestCoinSelectionPrivacy(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	var addrs []btcutil.Address
+	for i := 0; i < 3; i++ {
+		addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+		if err != nil {
+			t.Fatalf("unable to get new address: %v", err)
+		}
+		addrs = append(addrs, addr)
+	}
+	// The first address has two coins and the others one each.
+	for i, addr := range []btcutil.Address{addrs[0], addrs[0], addrs[1], addrs[2]} {
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		insertTestTx(t, w, &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Index: uint32(i)}}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(1e8, pkScript)},
+		}, 100, 0)
+	}
+
+	privacy := func(from []btcutil.Address) *SelectionPrivacy {
+		t.Helper()
+		req := CreateTxReq{
+			Outputs:     []*wire.TxOut{wire.NewTxOut(15e7, []byte{0x51})},
+			Minconf:     1,
+			FeeSatPerKB: 1000,
+			MaxInputs:   -1,
+		}
+		if from != nil {
+			req.InputAddresses = &from
+		}
+		res, err := w.CoinSelectionPrivacy(req)
+		if err != nil {
+			t.Fatalf("unable to get coin selection privacy: %v", err)
+		}
+		return res
+	}
+
+	// Both spends need two coins, spending the coins of the first address
+	// links no other address to it.
+	linked := privacy([]btcutil.Address{addrs[1], addrs[2]})
+	private := privacy([]btcutil.Address{addrs[0]})
+	if private.Inputs != 2 || linked.Inputs != 2 {
+		t.Fatalf("got %d and %d inputs, want 2", private.Inputs,
+			linked.Inputs)
+	}
+	if private.InputAddresses != 1 {
+		t.Fatalf("got %d input addresses spending one address, want 1",
+			private.InputAddresses)
+	}
+	if linked.InputAddresses <= private.InputAddresses {
+		t.Fatalf("got %d input addresses spending two addresses, want "+
+			"more than %d", linked.InputAddresses, private.InputAddresses)
+	}
+
+	// The change goes back to an address spent, it is segwit and the
+	// payment is not, and the payment is round.
+	if !private.HasChange || !private.ChangeRevealed ||
+		len(private.ChangeReasons) != 3 {
+
+		t.Fatalf("got change %v revealed %v for %v, want revealed by "+
+			"address, type and amount", private.HasChange,
+			private.ChangeRevealed, private.ChangeReasons)
+	}
+}
@@ -0,0 +1,52 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/txscript"
+)
+
+// ErrSelfSend is returned by a send which pays an address of the wallet when
+// WarnSelfSend is set and the send does not allow it.
+var ErrSelfSend = Err.CodeWithDetail("ErrSelfSend",
+	"send pays an address of the wallet")
+
+// checkSelfSend returns ErrSelfSend naming the addresses of the wallet which
+// the outputs of txr pay, if any, unless WarnSelfSend is unset or txr allows
+// sending to the wallet.
+func (w *Wallet) checkSelfSend(txr *CreateTxReq) er.R {
+	if !w.cfg.WarnSelfSend || txr.AllowSelfSend {
+		return nil
+	}
+	var own []string
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		for _, out := range txr.Outputs {
+			_, addrs, _, _ := txscript.ExtractPkScriptAddrs(out.PkScript,
+				w.chainParams)
+			for _, addr := range addrs {
+				_, err := w.Manager.Address(addrmgrNs, addr)
+				if err == nil {
+					own = append(own, addr.EncodeAddress())
+					break
+				} else if !waddrmgr.ErrAddressNotFound.Is(err) {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(own) > 0 {
+		return ErrSelfSend.New(fmt.Sprintf("the send pays [%s] which "+
+			"belong to this wallet, it must explicitly allow sending "+
+			"to the wallet", strings.Join(own, ", ")), nil)
+	}
+	return nil
+}
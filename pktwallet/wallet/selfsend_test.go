@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestWarnSelfSend checks that with WarnSelfSend set a send to an address of
+// the wallet is refused unless it allows sending to the wallet.
+func TestWarnSelfSend(t *testing.T) {
+	w, _, _, cleanup := fundedTestWallet(t, 1e8, 1e8)
+	defer cleanup()
+
+	w.cfg.WarnSelfSend = true
+
+	own, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	ownScript, err := txscript.PayToAddrScript(own)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := func(allow bool) CreateTxReq {
+		return CreateTxReq{
+			Outputs:       []*wire.TxOut{wire.NewTxOut(5e7, ownScript)},
+			Minconf:       1,
+			FeeSatPerKB:   1000,
+			MaxInputs:     -1,
+			SendMode:      SendModeBcasted,
+			AllowSelfSend: allow,
+		}
+	}
+
+	if _, err := w.SendOutputs(req(false)); !ErrSelfSend.Is(err) {
+		t.Fatalf("got error %v sending to the wallet, want ErrSelfSend", err)
+	}
+	if _, err := w.SimulateSend(req(false)); !ErrSelfSend.Is(err) {
+		t.Fatalf("got error %v simulating a send to the wallet, want "+
+			"ErrSelfSend", err)
+	}
+	if _, err := w.SendOutputs(req(true)); err != nil {
+		t.Fatalf("unable to send to the wallet when allowed: %v", err)
+	}
+
+	// Other sends are not affected.
+	external := CreateTxReq{
+		Outputs:     []*wire.TxOut{wire.NewTxOut(5e7, []byte{0x51})},
+		Minconf:     1,
+		FeeSatPerKB: 1000,
+		MaxInputs:   -1,
+		SendMode:    SendModeBcasted,
+	}
+	if _, err := w.SendOutputs(external); err != nil {
+		t.Fatalf("unable to send to an external script: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/internal/txsizes"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txauthor"
+	"github.com/pkt-cash/pktd/txscript"
+)
+
+// SimulatedSend is the transaction which a send would create.
+type SimulatedSend struct {
+	// Tx is unsigned, the value and script of the output spent by each
+	// input are in Tx.Tx.Additional.
+	Tx  *txauthor.AuthoredTx
+	Fee btcutil.Amount
+
+	// VSize is the estimated virtual size of the transaction once it is
+	// signed, which is what the fee is paid for.
+	VSize int
+}
+
+// SimulateSend creates the transaction which SendOutputs would create for txr,
+// selecting inputs and change in the same way, but it does not sign, record or
+// broadcast it.  The send mode of txr is ignored.
+func (w *Wallet) SimulateSend(txr CreateTxReq) (*SimulatedSend, er.R) {
+	if err := w.checkSendOutputs(txr.Outputs); err != nil {
+		return nil, err
+	}
+	if err := w.checkSelfSend(&txr); err != nil {
+		return nil, err
+	}
+	txr.SendMode = SendModeUnsigned
+	tx, err := w.CreateSimpleTx(txr)
+	if err != nil {
+		return nil, err
+	}
+
+	var nested, p2wpkh, p2pkh int
+	for _, add := range tx.Tx.Additional {
+		switch {
+		case txscript.IsPayToScriptHash(add.PkScript):
+			nested++
+		case txscript.IsPayToWitnessPubKeyHash(add.PkScript):
+			p2wpkh++
+		default:
+			p2pkh++
+		}
+	}
+	fee := tx.TotalInput
+	for _, out := range tx.Tx.TxOut {
+		fee -= btcutil.Amount(out.Value)
+	}
+	return &SimulatedSend{
+		Tx:    tx,
+		Fee:   fee,
+		VSize: txsizes.EstimateVirtualSize(p2pkh, p2wpkh, nested, tx.Tx.TxOut, false),
+	}, nil
+}
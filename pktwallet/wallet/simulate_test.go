@@ -0,0 +1,74 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestSimulateSend ensures that a simulated send selects the same inputs as a
+// real send with the same parameters, and that it does not sign or broadcast
+// anything.
+func TestSimulateSend(t *testing.T) {
+	w, chainClient, _, cleanup := fundedTestWallet(t, 1e8, 2e8, 5e7, 3e8)
+	defer cleanup()
+
+	req := func() CreateTxReq {
+		return CreateTxReq{
+			Outputs:     []*wire.TxOut{wire.NewTxOut(25e7, []byte{0x51})},
+			Minconf:     1,
+			FeeSatPerKB: 1000,
+			MaxInputs:   -1,
+			SendMode:    SendModeBcasted,
+		}
+	}
+	sim, err := w.SimulateSend(req())
+	if err != nil {
+		t.Fatalf("unable to simulate send: %v", err)
+	}
+	if len(chainClient.sent) != 0 {
+		t.Fatalf("simulated send broadcast %d transactions",
+			len(chainClient.sent))
+	}
+	for i, in := range sim.Tx.Tx.TxIn {
+		if len(in.SignatureScript) != 0 || len(in.Witness) != 0 {
+			t.Fatalf("simulated input %d is signed", i)
+		}
+	}
+	var outputs btcutil.Amount
+	for _, out := range sim.Tx.Tx.TxOut {
+		outputs += btcutil.Amount(out.Value)
+	}
+	if sim.Fee <= 0 || sim.Fee != sim.Tx.TotalInput-outputs || sim.VSize <= 0 {
+		t.Fatalf("got fee %v and vsize %d for inputs of %v paying %v",
+			sim.Fee, sim.VSize, sim.Tx.TotalInput, outputs)
+	}
+
+	tx, err := w.SendOutputs(req())
+	if err != nil {
+		t.Fatalf("unable to send: %v", err)
+	}
+	if len(chainClient.sent) != 1 {
+		t.Fatalf("got %d broadcast transactions, want 1",
+			len(chainClient.sent))
+	}
+	if len(tx.Tx.TxIn) != len(sim.Tx.Tx.TxIn) {
+		t.Fatalf("send selected %d inputs, simulation selected %d",
+			len(tx.Tx.TxIn), len(sim.Tx.Tx.TxIn))
+	}
+	simulated := make(map[wire.OutPoint]struct{})
+	for _, in := range sim.Tx.Tx.TxIn {
+		simulated[in.PreviousOutPoint] = struct{}{}
+	}
+	for _, in := range tx.Tx.TxIn {
+		if _, ok := simulated[in.PreviousOutPoint]; !ok {
+			t.Fatalf("send selected input %v which the simulation "+
+				"did not", in.PreviousOutPoint)
+		}
+	}
+	if tx.TotalInput != sim.Tx.TotalInput {
+		t.Fatalf("send spent %v, simulation spent %v", tx.TotalInput,
+			sim.Tx.TotalInput)
+	}
+}
@@ -0,0 +1,62 @@
+package wallet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txauthor"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// ErrSpendLimit is returned when a send would take the total spent within the
+// spend limit window over the spend limit amount.
+var ErrSpendLimit = Err.CodeWithDetail("ErrSpendLimit",
+	"send would exceed the spending limit")
+
+// outgoingValue is the value which leaves the wallet in a transaction which it
+// authored, that is everything except the change.
+func outgoingValue(tx *txauthor.AuthoredTx) btcutil.Amount {
+	out := tx.TotalInput
+	for i, txOut := range tx.Tx.TxOut {
+		if tx.IsChange(i) {
+			out -= btcutil.Amount(txOut.Value)
+		}
+	}
+	return out
+}
+
+// reserveSpend records that amount is spent by a transaction at time now
+// unless it would take the total spent within the window over the limit, in
+// which case ErrSpendLimit is returned.  The check and the record are made in
+// a single database transaction so concurrent sends cannot both pass.
+func (w *Wallet) reserveSpend(txHash *chainhash.Hash, amount btcutil.Amount,
+	now time.Time) er.R {
+
+	if w.cfg.SpendLimitAmount <= 0 || w.cfg.SpendLimitWindow <= 0 {
+		return nil
+	}
+	return walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+		spent := w.TxStore.SpentSince(txmgrNs, now.Add(-w.cfg.SpendLimitWindow))
+		if spent+amount > w.cfg.SpendLimitAmount {
+			return ErrSpendLimit.New(fmt.Sprintf("sending [%s] would "+
+				"exceed the limit of [%s] per [%s], already spent [%s]",
+				amount, w.cfg.SpendLimitAmount, w.cfg.SpendLimitWindow, spent), nil)
+		}
+		return w.TxStore.PutSpend(txmgrNs, txHash, now, amount)
+	})
+}
+
+// releaseSpend removes a spend recorded by reserveSpend.
+func (w *Wallet) releaseSpend(txHash *chainhash.Hash, now time.Time) er.R {
+	if w.cfg.SpendLimitAmount <= 0 || w.cfg.SpendLimitWindow <= 0 {
+		return nil
+	}
+	return walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+		return w.TxStore.DeleteSpend(txmgrNs, txHash, now)
+	})
+}
@@ -0,0 +1,62 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+)
+
+// TestSpendLimit ensures that spends are limited to SpendLimitAmount within a
+// rolling SpendLimitWindow.
+func TestSpendLimit(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.cfg.SpendLimitAmount = 10e8
+	w.cfg.SpendLimitWindow = time.Hour
+
+	start := time.Unix(1600000000, 0)
+	tests := []struct {
+		name    string
+		after   time.Duration
+		amount  btcutil.Amount
+		allowed bool
+	}{
+		{"first send", 0, 4e8, true},
+		{"within limit", 10 * time.Minute, 5e8, true},
+		{"exceeds limit", 20 * time.Minute, 2e8, false},
+		{"up to limit", 30 * time.Minute, 1e8, true},
+		{"still at limit", 59 * time.Minute, 1, false},
+		// The first send has left the window.
+		{"window rolled", 61 * time.Minute, 4e8, true},
+		{"exceeds rolled limit", 62 * time.Minute, 1e8, false},
+		// Only the last send remains in the window.
+		{"second window", 2 * time.Hour, 6e8, true},
+	}
+	for i, test := range tests {
+		hash := chainhash.DoubleHashH([]byte{byte(i)})
+		err := w.reserveSpend(&hash, test.amount, start.Add(test.after))
+		if test.allowed && err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if !test.allowed && !ErrSpendLimit.Is(err) {
+			t.Fatalf("%s: got error %v, want ErrSpendLimit", test.name, err)
+		}
+	}
+
+	// A released spend no longer counts towards the limit.
+	now := start.Add(4 * time.Hour)
+	hash := chainhash.DoubleHashH([]byte("released"))
+	if err := w.reserveSpend(&hash, 10e8, now); err != nil {
+		t.Fatalf("unable to reserve spend: %v", err)
+	}
+	if err := w.releaseSpend(&hash, now); err != nil {
+		t.Fatalf("unable to release spend: %v", err)
+	}
+	hash = chainhash.DoubleHashH([]byte("after release"))
+	if err := w.reserveSpend(&hash, 10e8, now); err != nil {
+		t.Fatalf("spend after release was rejected: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// StorageStats describes the storage used by the wallet database.
+type StorageStats struct {
+	// FileSize is the size, in bytes, of the database file, it is zero
+	// if the database cannot report its size.
+	FileSize int64
+
+	// Buckets are the stats of the database buckets keyed by their path,
+	// it is nil if the database cannot report them.
+	Buckets map[string]walletdb.BucketStats
+
+	// Transactions is the number of transactions, mined and unmined,
+	// recorded by the wallet.
+	Transactions int
+
+	// Utxos is the number of unspent outputs belonging to the wallet.
+	Utxos int
+}
+
+// StorageStats returns the size of the wallet database and the number of
+// transactions and unspent outputs which it holds.
+func (w *Wallet) StorageStats() (*StorageStats, er.R) {
+	stats := &StorageStats{}
+	if sdb, ok := w.db.(walletdb.StatsDB); ok {
+		var err er.R
+		if stats.FileSize, err = sdb.FileSize(); err != nil {
+			return nil, err
+		}
+		if stats.Buckets, err = sdb.BucketStats(); err != nil {
+			return nil, err
+		}
+	}
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		var err er.R
+		stats.Transactions, stats.Utxos, err = w.TxStore.Counts(
+			tx.ReadBucket(wtxmgrNamespaceKey))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,163 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcec"
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestImportTaprootAddress imports a p2tr address and checks that a payment
+// to it is tracked in the imported account, watch only, apart from the
+// balance.
+func TestImportTaprootAddress(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputKey, err := txscript.ComputeTaprootKeyNoScript(key.PubKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := btcutil.NewAddressTaproot(
+		outputKey.SerializeCompressed()[1:], w.chainParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bs := &waddrmgr.BlockStamp{Height: 1, Timestamp: time.Now()}
+	if err := w.ImportTaprootAddress(addr, bs, false); err != nil {
+		t.Fatalf("unable to import address: %v", err)
+	}
+	if err := w.ImportTaprootAddress(addr, bs, false); !waddrmgr.ErrDuplicateAddress.Is(err) {
+		t.Fatalf("got error %v importing the address again, want "+
+			"ErrDuplicateAddress", err)
+	}
+	mainnetAddr, _ := btcutil.NewAddressTaproot(addr.WitnessProgram(),
+		&chaincfg.MainNetParams)
+	if err := w.ImportTaprootAddress(mainnetAddr, bs, false); !waddrmgr.ErrWrongNet.Is(err) {
+		t.Fatalf("got error %v importing a mainnet address, want "+
+			"ErrWrongNet", err)
+	}
+
+	// Pay the address, as the chain backend would notify it.
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := wire.NewMsgTx(1)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 0}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(1e8, pkScript))
+	var b bytes.Buffer
+	if err := tx.Serialize(&b); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := wtxmgr.NewTxRecord(b.Bytes(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: chainhash.Hash{1}, Height: 1},
+		Time:  time.Now(),
+	}
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		return w.addRelevantTx(dbtx, rec, block)
+	})
+	if err != nil {
+		t.Fatalf("unable to add transaction: %v", err)
+	}
+
+	// The coin is counted apart from the balance as it cannot be spent.
+	bal, err := w.CalculateWalletBalance(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bal.Balance != 0 || bal.WatchOnly != 1e8 {
+		t.Fatalf("got balance %v and watch only %v, want 0 and 1 coin",
+			bal.Balance, bal.WatchOnly)
+	}
+	bals, err := w.CalculateAddressBalances(0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for a, b := range bals {
+		if a.EncodeAddress() != addr.EncodeAddress() || b.Spendable != 0 ||
+			b.WatchOnly != 1e8 {
+			t.Fatalf("got balance %+v for address %v, want 1 coin watch "+
+				"only for %v", b, a, addr)
+		}
+	}
+
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+		ma, err := w.Manager.Address(addrmgrNs, addr)
+		if err != nil {
+			return err
+		}
+		if !ma.Imported() || ma.Account() != waddrmgr.ImportedAddrAccount {
+			t.Fatalf("address is not in the imported account")
+		}
+		if _, err := ma.(waddrmgr.ManagedPubKeyAddress).PrivKey(); err == nil {
+			t.Fatalf("watch only address has a private key")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unspent, err := w.ListUnspent(0, 999999, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unspent) != 1 || unspent[0].Address != addr.EncodeAddress() ||
+		unspent[0].Spendable {
+		t.Fatalf("got unspent outputs %+v, want one unspendable output to %v",
+			unspent, addr)
+	}
+}
+
+// TestSendToTaprootRefused ensures that a send to a p2tr address is refused,
+// as the output could be spent by anyone.
+func TestSendToTaprootRefused(t *testing.T) {
+	w, _, _, cleanup := fundedTestWallet(t, 1e8)
+	defer cleanup()
+
+	addr, err := btcutil.NewAddressTaproot(bytes.Repeat([]byte{1}, 32),
+		w.chainParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txr := CreateTxReq{
+		Outputs:     []*wire.TxOut{wire.NewTxOut(5e7, pkScript)},
+		Minconf:     1,
+		FeeSatPerKB: 1000,
+		MaxInputs:   -1,
+		SendMode:    SendModeSigned,
+	}
+	if _, err := w.SendOutputs(txr); !ErrTaprootOutput.Is(err) {
+		t.Fatalf("got error %v sending to taproot, want ErrTaprootOutput",
+			err)
+	}
+	if _, err := w.SimulateSend(txr); !ErrTaprootOutput.Is(err) {
+		t.Fatalf("got error %v simulating a send to taproot, want "+
+			"ErrTaprootOutput", err)
+	}
+}
@@ -0,0 +1,154 @@
+package wallet
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// TaxDisposal is the part of a lot of coins which was disposed of by an
+// outgoing transaction.  A transaction which disposes of more than one lot
+// gives a TaxDisposal for each.  The wallet knows nothing of prices so the
+// cost basis is left for tax software to fill in, proceeds are in coins.
+type TaxDisposal struct {
+	// AcquiredTx and Acquired are the transaction which received the lot
+	// and the time of its block.  They are zero if the coins were spent
+	// before the wallet knew them to be received.
+	AcquiredTx chainhash.Hash
+	Acquired   time.Time
+
+	DisposedTx chainhash.Hash
+	Disposed   time.Time
+
+	// Amount is how much of the lot was disposed of, including its share
+	// of the fee, and Proceeds is the part of Amount which was paid to
+	// others.
+	Amount   btcutil.Amount
+	Proceeds btcutil.Amount
+}
+
+// taxEvent is the net effect of a mined transaction on the wallet's balance.
+// Net is positive when coins are acquired and negative when they are
+// disposed of, in which case Proceeds is the part of the disposal which was
+// not paid as a fee.
+type taxEvent struct {
+	Hash     chainhash.Hash
+	Time     time.Time
+	Net      btcutil.Amount
+	Proceeds btcutil.Amount
+}
+
+// taxLot is the unspent remainder of an acquisition.
+type taxLot struct {
+	hash      chainhash.Hash
+	acquired  time.Time
+	remaining btcutil.Amount
+}
+
+// matchTaxLots matches each disposal of events against the oldest lots which
+// have not been disposed of yet, first in first out.  Events must be in the
+// order that they were mined.
+func matchTaxLots(events []taxEvent) []TaxDisposal {
+	var lots []taxLot
+	var out []TaxDisposal
+	for _, e := range events {
+		if e.Net > 0 {
+			lots = append(lots, taxLot{
+				hash:      e.Hash,
+				acquired:  e.Time,
+				remaining: e.Net,
+			})
+			continue
+		}
+		disposed := -e.Net
+		proceeds := e.Proceeds
+		for disposed > 0 {
+			d := TaxDisposal{
+				DisposedTx: e.Hash,
+				Disposed:   e.Time,
+				Amount:     disposed,
+			}
+			if len(lots) > 0 {
+				lot := &lots[0]
+				d.AcquiredTx = lot.hash
+				d.Acquired = lot.acquired
+				if lot.remaining < disposed {
+					d.Amount = lot.remaining
+				}
+				lot.remaining -= d.Amount
+				if lot.remaining == 0 {
+					lots = lots[1:]
+				}
+			}
+			// Proceeds are shared between the lots in proportion to
+			// the amount of each, the last lot takes the remainder
+			// so that none is lost to rounding.
+			d.Proceeds = proceeds
+			if d.Amount < disposed {
+				d.Proceeds = shareOf(proceeds, d.Amount, disposed)
+			}
+			proceeds -= d.Proceeds
+			disposed -= d.Amount
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// shareOf returns the part of total which amount of whole is, rounded down.
+// The product of two amounts of coins can overflow an int64 so it is done
+// with big integers.
+func shareOf(total, amount, whole btcutil.Amount) btcutil.Amount {
+	share := new(big.Int).Mul(big.NewInt(int64(total)), big.NewInt(int64(amount)))
+	share.Quo(share, big.NewInt(int64(whole)))
+	return btcutil.Amount(share.Int64())
+}
+
+// txTaxEvent returns the net effect of a mined transaction on the wallet's
+// balance.  A transaction which has inputs which do not belong to the wallet
+// has no known fee, all of what it disposes of is counted as proceeds.
+func txTaxEvent(details *wtxmgr.TxDetails) taxEvent {
+	e := taxEvent{Hash: details.Hash, Time: details.Block.Time}
+	for _, c := range details.Credits {
+		e.Net += c.Amount
+	}
+	for _, d := range details.Debits {
+		e.Net -= d.Amount
+	}
+	if e.Net < 0 {
+		e.Proceeds = -e.Net
+		if fee, ok := txFee(details); ok {
+			e.Proceeds -= fee
+		}
+	}
+	return e
+}
+
+// TaxReport returns a record of each disposal of coins by the wallet's mined
+// transactions, with the lots which were disposed of matched first in first
+// out over the history of the wallet.
+func (w *Wallet) TaxReport() ([]TaxDisposal, er.R) {
+	var events []taxEvent
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		tip := w.Manager.SyncedTo().Height
+		return w.TxStore.RangeTransactions(txmgrNs, 0, tip,
+			func(details []wtxmgr.TxDetails) (bool, er.R) {
+				for i := range details {
+					if e := txTaxEvent(&details[i]); e.Net != 0 {
+						events = append(events, e)
+					}
+				}
+				return false, nil
+			})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matchTaxLots(events), nil
+}
@@ -0,0 +1,84 @@
+package wallet
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+)
+
+// TestMatchTaxLots checks that disposals are matched against the oldest lots
+// first, that a disposal spanning lots is split between them and that a
+// disposal of coins which were never acquired is reported without a lot.
+func TestMatchTaxLots(t *testing.T) {
+	hash := func(s string) chainhash.Hash { return chainhash.DoubleHashH([]byte(s)) }
+	day := func(d int) time.Time { return time.Unix(1600000000+int64(d)*86400, 0) }
+
+	events := []taxEvent{
+		{Hash: hash("buy1"), Time: day(0), Net: 10e8},
+		{Hash: hash("buy2"), Time: day(1), Net: 5e8},
+		// 4 coins are disposed of, 3.9 paid out and 0.1 as a fee.
+		{Hash: hash("sell1"), Time: day(2), Net: -4e8, Proceeds: 39e7},
+		// 8 coins use the remaining 6 of the first lot and 2 of the
+		// second.
+		{Hash: hash("sell2"), Time: day(3), Net: -8e8, Proceeds: 8e8},
+		{Hash: hash("buy3"), Time: day(4), Net: 1e8},
+		// This spends the rest of the second lot, the third lot and 1
+		// coin which the wallet never saw arrive.
+		{Hash: hash("sell3"), Time: day(5), Net: -5e8, Proceeds: 5e8},
+	}
+	want := []TaxDisposal{
+		{AcquiredTx: hash("buy1"), Acquired: day(0), DisposedTx: hash("sell1"),
+			Disposed: day(2), Amount: 4e8, Proceeds: 39e7},
+		{AcquiredTx: hash("buy1"), Acquired: day(0), DisposedTx: hash("sell2"),
+			Disposed: day(3), Amount: 6e8, Proceeds: 6e8},
+		{AcquiredTx: hash("buy2"), Acquired: day(1), DisposedTx: hash("sell2"),
+			Disposed: day(3), Amount: 2e8, Proceeds: 2e8},
+		{AcquiredTx: hash("buy2"), Acquired: day(1), DisposedTx: hash("sell3"),
+			Disposed: day(5), Amount: 3e8, Proceeds: 3e8},
+		{AcquiredTx: hash("buy3"), Acquired: day(4), DisposedTx: hash("sell3"),
+			Disposed: day(5), Amount: 1e8, Proceeds: 1e8},
+		{DisposedTx: hash("sell3"), Disposed: day(5), Amount: 1e8, Proceeds: 1e8},
+	}
+	got := matchTaxLots(events)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got lots %+v, want %+v", got, want)
+	}
+}
+
+// TestMatchTaxLotsLargeSplit checks that proceeds are shared correctly
+// between lots when a disposal of millions of coins spans them, where the
+// product of the proceeds and the amount of a lot overflows an int64.
+func TestMatchTaxLotsLargeSplit(t *testing.T) {
+	hash := func(s string) chainhash.Hash { return chainhash.DoubleHashH([]byte(s)) }
+	day := func(d int) time.Time { return time.Unix(1600000000+int64(d)*86400, 0) }
+
+	const coin = btcutil.Amount(1 << 30)
+	events := []taxEvent{
+		{Hash: hash("buy1"), Time: day(0), Net: 3000000 * coin},
+		{Hash: hash("buy2"), Time: day(1), Net: 2000000 * coin},
+		// 4 million coins are disposed of, 1 coin of which is the fee.
+		{Hash: hash("sell"), Time: day(2), Net: -4000000 * coin,
+			Proceeds: 3999999 * coin},
+	}
+	got := matchTaxLots(events)
+	if len(got) != 2 {
+		t.Fatalf("got %d disposals, want 2", len(got))
+	}
+	// The first lot is three quarters of the disposal, the second one
+	// quarter and it takes the remainder of the proceeds.
+	wantFirst := 3999999 * coin / 4 * 3
+	if got[0].Amount != 3000000*coin || got[0].Proceeds != wantFirst {
+		t.Fatalf("got first disposal of %d with proceeds %d, want %d with "+
+			"proceeds %d", got[0].Amount, got[0].Proceeds, 3000000*coin,
+			wantFirst)
+	}
+	if got[1].Amount != 1000000*coin ||
+		got[1].Proceeds != 3999999*coin-wantFirst {
+		t.Fatalf("got second disposal of %d with proceeds %d, want %d "+
+			"with proceeds %d", got[1].Amount, got[1].Proceeds,
+			1000000*coin, 3999999*coin-wantFirst)
+	}
+}
@@ -0,0 +1,106 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txauthor"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/wire"
+	"github.com/pkt-cash/pktd/wire/constants"
+)
+
+// ErrTimeLockNotExpired is returned when signing an input which spends a time
+// locked script whose lock has not yet expired at the tip of the chain which
+// the wallet is synced to.
+var ErrTimeLockNotExpired = Err.CodeWithDetail("ErrTimeLockNotExpired",
+	"the input cannot be spent until its time lock expires")
+
+// ErrTimeLockUnknown is returned when signing an input which spends a script
+// with a relative time lock and the transaction which it spends is not in the
+// wallet, so that it is not known whether the lock has expired.
+var ErrTimeLockUnknown = Err.CodeWithDetail("ErrTimeLockUnknown",
+	"it is not known whether the time lock of the input has expired")
+
+// timeLockExpired returns whether lock has expired so that an output locked by
+// it, mined in block or unmined if block is nil, could be spent in the block
+// after bs.  Time based locks are compared with the time of bs rather than the
+// median time of the blocks before it, which is earlier, so a spend which
+// passes may still be refused by the network for a short while.
+func timeLockExpired(lock *txauthor.TimeLock, block *wtxmgr.BlockMeta,
+	bs *waddrmgr.BlockStamp) bool {
+
+	if !lock.Relative {
+		if lock.IsTime() {
+			return int64(lock.Value) < bs.Timestamp.Unix()
+		}
+		return int64(lock.Value) <= int64(bs.Height)
+	}
+	if block == nil || block.Height < 0 {
+		return false
+	}
+	n := int64(lock.Value & constants.SequenceLockTimeMask)
+	if lock.IsTime() {
+		return bs.Timestamp.Unix()-block.Time.Unix() >= n*512
+	}
+	return int64(bs.Height)+1-int64(block.Height) >= n
+}
+
+// creditTimeLockExpired returns false if the credit pays a time locked script
+// of the wallet whose lock has not expired at bs, so that it cannot yet be
+// spent.
+func (w *Wallet) creditTimeLockExpired(addrmgrNs walletdb.ReadBucket,
+	credit *wtxmgr.Credit, bs *waddrmgr.BlockStamp) bool {
+
+	lock, ok := txauthor.ScriptTimeLock(credit.PkScript,
+		secretSource{w.Manager, addrmgrNs}, w.chainParams)
+	if !ok {
+		return true
+	}
+	block := &credit.BlockMeta
+	if credit.Height < 0 {
+		block = nil
+	}
+	return timeLockExpired(&lock, block, bs)
+}
+
+// checkTimeLocks returns ErrTimeLockNotExpired unless the transaction could be
+// mined in the block after the synced tip, as far as the locks of its inputs,
+// as returned by txauthor.ApplyTimeLocks, are concerned.  A relative lock of
+// an input whose previous transaction is not in the wallet cannot be checked,
+// ErrTimeLockUnknown is returned for it.
+func (w *Wallet) checkTimeLocks(txmgrNs walletdb.ReadBucket, tx *wire.MsgTx,
+	locks []*txauthor.TimeLock) er.R {
+
+	bs := w.Manager.SyncedTo()
+	for i, lock := range locks {
+		if lock == nil {
+			continue
+		}
+		var block *wtxmgr.BlockMeta
+		if lock.Relative {
+			prevOut := tx.TxIn[i].PreviousOutPoint
+			details, err := w.TxStore.TxDetails(txmgrNs, &prevOut.Hash)
+			if err != nil {
+				return err
+			}
+			if details == nil {
+				return ErrTimeLockUnknown.New(fmt.Sprintf("input [%d] "+
+					"spending [%s] is locked until %s, the "+
+					"transaction which it spends is not in the wallet "+
+					"so whether the lock has expired is not known", i,
+					prevOut, lock), nil)
+			}
+			block = &details.Block
+		}
+		if !timeLockExpired(lock, block, &bs) {
+			return ErrTimeLockNotExpired.New(fmt.Sprintf("input [%d] "+
+				"spending [%s] is locked until %s, the wallet is synced "+
+				"to height [%d] at [%s]", i, tx.TxIn[i].PreviousOutPoint,
+				lock, bs.Height, bs.Timestamp.UTC().Format("2006-01-02 15:04:05")), nil)
+		}
+	}
+	return nil
+}
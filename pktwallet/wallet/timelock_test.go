@@ -0,0 +1,193 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/txauthor"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/txscript/opcode"
+	"github.com/pkt-cash/pktd/txscript/params"
+	"github.com/pkt-cash/pktd/txscript/scriptbuilder"
+	"github.com/pkt-cash/pktd/wire"
+	"github.com/pkt-cash/pktd/wire/constants"
+)
+
+// timeLockedOutput imports a script paying a key of the wallet which is locked
+// with CHECKLOCKTIMEVERIFY until lockHeight, and records an output of 1e8
+// paying it mined at height 100.  It returns the transaction of the output
+// and a script paying the key.
+func timeLockedOutput(t *testing.T, w *Wallet, lockHeight int64) (
+	*wire.MsgTx, []byte) {
+
+	addr, err := w.CurrentAddress(0, waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		t.Fatalf("unable to get current address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+	script, err := scriptbuilder.NewScriptBuilder().AddInt64(lockHeight).
+		AddOp(opcode.OP_CHECKLOCKTIMEVERIFY).AddOp(opcode.OP_DROP).
+		AddOps(pkScript).Script()
+	if err != nil {
+		t.Fatalf("unable to create time locked script: %v", err)
+	}
+	p2shAddr, err := w.ImportP2SHRedeemScript(script)
+	if err != nil {
+		t.Fatalf("unable to import time locked script: %v", err)
+	}
+	p2shScript, err := txscript.PayToAddrScript(p2shAddr)
+	if err != nil {
+		t.Fatalf("unable to create p2sh pkScript: %v", err)
+	}
+
+	fund := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Index: 1}}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, p2shScript)},
+	}
+	insertTestTx(t, w, fund, 100, 0)
+	return fund, pkScript
+}
+
+// TestSignTimeLocked ensures that signing a spend of an output paying a
+// CHECKLOCKTIMEVERIFY script sets the locktime the script needs once the lock
+// has expired, and fails with ErrTimeLockNotExpired before then.
+func TestSignTimeLocked(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	const lockHeight = 150
+	fund, pkScript := timeLockedOutput(t, w, lockHeight)
+	spend := func() *wire.MsgTx {
+		return &wire.MsgTx{
+			Version: 1,
+			TxIn: []*wire.TxIn{{
+				PreviousOutPoint: wire.OutPoint{Hash: fund.TxHash()},
+				Sequence:         constants.MaxTxInSequenceNum,
+			}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(9e7, pkScript)},
+		}
+	}
+
+	setSyncedTo(t, w, lockHeight-1)
+	_, err := w.SignTransaction(spend(), params.SigHashAll, nil, nil, nil)
+	if !ErrTimeLockNotExpired.Is(err) {
+		t.Fatalf("got error %v signing before the lock expired, want "+
+			"ErrTimeLockNotExpired", err)
+	}
+
+	setSyncedTo(t, w, lockHeight)
+	tx := spend()
+	signErrs, err := w.SignTransaction(tx, params.SigHashAll, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unable to sign time locked spend: %v", err)
+	}
+	if len(signErrs) != 0 {
+		t.Fatalf("got signature errors %v", signErrs[0].Error)
+	}
+	if tx.LockTime != lockHeight {
+		t.Fatalf("got locktime %d, want %d", tx.LockTime, lockHeight)
+	}
+	if tx.TxIn[0].Sequence == constants.MaxTxInSequenceNum {
+		t.Fatalf("input sequence is final, the locktime would be ignored")
+	}
+
+	// A relative lock of an input spending a transaction which the wallet
+	// does not know cannot be checked.
+	unknown := spend()
+	unknown.TxIn[0].PreviousOutPoint.Hash = chainhash.Hash{1}
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		return w.checkTimeLocks(dbtx.ReadBucket(wtxmgrNamespaceKey), unknown,
+			[]*txauthor.TimeLock{{Relative: true, Value: 10}})
+	})
+	if !ErrTimeLockUnknown.Is(err) {
+		t.Fatalf("got error %v for a relative lock of an unknown input, "+
+			"want ErrTimeLockUnknown", err)
+	}
+}
+
+// syncedChainClient is a mock chain client whose tip is the block which the
+// wallet is synced to, and which records the transactions which it is asked
+// to broadcast.
+type syncedChainClient struct {
+	broadcastChainClient
+	w *Wallet
+}
+
+func (c *syncedChainClient) BlockStamp() (*waddrmgr.BlockStamp, er.R) {
+	bs := c.w.Manager.SyncedTo()
+	return &bs, nil
+}
+
+// TestSendTimeLocked ensures that a send does not choose an output paying a
+// time locked script before the lock expires, that naming the output fails
+// with ErrTimeLockNotExpired and nothing is broadcast, and that once the lock
+// has expired the output is spent with the locktime which the script needs.
+// Unsigned sends are checked and given the locktime in the same way.
+func TestSendTimeLocked(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+	chainClient := &syncedChainClient{w: w}
+	w.chainClient = chainClient
+
+	const lockHeight = 150
+	fund, pkScript := timeLockedOutput(t, w, lockHeight)
+	sendMode := SendModeBcasted
+	send := func(inputs []wire.OutPoint) (*txauthor.AuthoredTx, er.R) {
+		return w.SendOutputs(CreateTxReq{
+			Outputs:        []*wire.TxOut{wire.NewTxOut(5e7, pkScript)},
+			Minconf:        1,
+			FeeSatPerKB:    1000,
+			MaxInputs:      -1,
+			SendMode:       sendMode,
+			InputOutPoints: inputs,
+		})
+	}
+
+	setSyncedTo(t, w, lockHeight-1)
+	if _, err := send(nil); !InsufficientFundsError.Is(err) {
+		t.Fatalf("got error %v sending with only a locked output, want "+
+			"InsufficientFundsError", err)
+	}
+	for _, sendMode = range []SendMode{SendModeUnsigned, SendModeBcasted} {
+		_, err := send([]wire.OutPoint{{Hash: fund.TxHash()}})
+		if !ErrTimeLockNotExpired.Is(err) {
+			t.Fatalf("got error %v spending the locked output with send "+
+				"mode %d, want ErrTimeLockNotExpired", err, sendMode)
+		}
+	}
+	if len(chainClient.sent) != 0 {
+		t.Fatalf("broadcast %d transactions before the lock expired",
+			len(chainClient.sent))
+	}
+
+	setSyncedTo(t, w, lockHeight)
+	sendMode = SendModeUnsigned
+	tx, err := send(nil)
+	if err != nil {
+		t.Fatalf("unable to create unsigned send once the lock expired: %v",
+			err)
+	}
+	if tx.Tx.LockTime != lockHeight {
+		t.Fatalf("got locktime %d for unsigned send, want %d",
+			tx.Tx.LockTime, lockHeight)
+	}
+	sendMode = SendModeBcasted
+	tx, err = send(nil)
+	if err != nil {
+		t.Fatalf("unable to send once the lock expired: %v", err)
+	}
+	if len(chainClient.sent) != 1 || chainClient.sent[0].TxHash() != tx.Tx.TxHash() {
+		t.Fatalf("got broadcasts %v, want only the spend", chainClient.sent)
+	}
+	if tx.Tx.LockTime != lockHeight || tx.Tx.TxIn[0].PreviousOutPoint.Hash != fund.TxHash() {
+		t.Fatalf("got locktime %d spending %v, want locktime %d spending "+
+			"the locked output", tx.Tx.LockTime,
+			tx.Tx.TxIn[0].PreviousOutPoint, lockHeight)
+	}
+}
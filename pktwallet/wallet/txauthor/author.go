@@ -42,12 +42,38 @@ var InputSourceError = er.NewErrorType("txauthor.InputSourceError")
 // ImpossbleTransactionError is the default implementation of InputSourceError.
 var ImpossibleTxError = InputSourceError.Code("ImpossibleTxError")
 
+// MaxTxVersion is the highest transaction version which is relayed as
+// standard, higher versions are reserved for future soft forks.
+const MaxTxVersion = 2
+
 // AuthoredTx holds the state of a newly-created transaction and the change
 // output (if one was added).
 type AuthoredTx struct {
 	Tx          *wire.MsgTx
 	TotalInput  btcutil.Amount
 	ChangeIndex int // negative if no change
+
+	// SplitChange holds the indexes of the outputs besides ChangeIndex
+	// which return change to the wallet, as when the change is split into
+	// several outputs.
+	SplitChange []int
+}
+
+// IsChange reports whether output i returns change to the wallet, either the
+// output at ChangeIndex or one of those in SplitChange.
+func (tx *AuthoredTx) IsChange(i int) bool {
+	if tx.ChangeIndex < 0 {
+		return false
+	}
+	if i == tx.ChangeIndex {
+		return true
+	}
+	for _, idx := range tx.SplitChange {
+		if i == idx {
+			return true
+		}
+	}
+	return false
 }
 
 // ChangeSource provides P2PKH change output scripts for transaction creation.
@@ -202,15 +228,20 @@ type SecretsSource interface {
 // scripts for each input.  Previous output scripts being redeemed by each input
 // are passed in prevPkScripts and the slice length must match the number of
 // inputs.  Private keys and redeem scripts are looked up using a SecretsSource
-// based on the previous output script.
+// based on the previous output script.  The locktime, version and sequences
+// which inputs spending time locked scripts need are set first, see
+// ApplyTimeLocks.
 func AddAllInputScripts(tx *wire.MsgTx, secrets SecretsSource) er.R {
 
-	hashCache := txscript.NewTxSigHashes(tx)
 	chainParams := secrets.ChainParams()
 
 	if len(tx.TxIn) != len(tx.Additional) {
 		return er.New("tx.TxIn and tx.Additional slices must have equal length")
 	}
+	if _, err := ApplyTimeLocks(tx, secrets, chainParams); err != nil {
+		return err
+	}
+	hashCache := txscript.NewTxSigHashes(tx)
 
 	for i := range tx.TxIn {
 		if len(tx.Additional[i].PkScript) == 0 {
@@ -244,6 +275,10 @@ func SignInputScript(
 	if len(pkScript) == 0 {
 		return er.New("Cannot sign transaction because it does not contain additional data")
 	}
+	if script, lock, ok := timeLockedScript(pkScript, sdb, chainParams); ok {
+		return spendTimeLocked(tx, inputNum, pkScript, script, lock, amt,
+			chainParams, kdb, hashCache, sigHashType)
+	}
 	if txscript.IsPayToScriptHash(pkScript) {
 		err := spendNestedWitnessPubKeyHash(tx.TxIn[inputNum], pkScript,
 			amt, chainParams, kdb,
@@ -0,0 +1,289 @@
+package txauthor
+
+import (
+	"fmt"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/txscript/opcode"
+	"github.com/pkt-cash/pktd/txscript/params"
+	"github.com/pkt-cash/pktd/txscript/scriptbuilder"
+	"github.com/pkt-cash/pktd/txscript/scriptnum"
+	"github.com/pkt-cash/pktd/wire"
+	"github.com/pkt-cash/pktd/wire/constants"
+)
+
+// TimeLockError describes why an input spending a time locked script cannot
+// be signed.
+var TimeLockError = er.NewErrorType("txauthor.TimeLockError")
+
+// ErrTimeLockConflict is returned when the time locks of the scripts spent by
+// a transaction cannot all be satisfied, such as when one needs a locktime
+// which is a block height and another a locktime which is a time.
+var ErrTimeLockConflict = TimeLockError.Code("ErrTimeLockConflict")
+
+// ErrTimeLockUnsatisfied is returned when signing an input spending a time
+// locked script of a transaction whose locktime or input sequence does not
+// satisfy the lock.
+var ErrTimeLockUnsatisfied = TimeLockError.Code("ErrTimeLockUnsatisfied")
+
+// TimeLock is the lock of a P2SH or P2WSH script which begins with
+// <n> OP_CHECKLOCKTIMEVERIFY OP_DROP, so that the locktime of a spending
+// transaction must be at least n, or with <n> OP_CHECKSEQUENCEVERIFY OP_DROP,
+// so that the spending input must be relatively locked for at least n.  The
+// rest of the script must pay a single key as P2PK or P2PKH do.
+type TimeLock struct {
+	// Relative is set for OP_CHECKSEQUENCEVERIFY, Value is then a
+	// sequence rather than a locktime.
+	Relative bool
+	Value    uint32
+}
+
+// IsTime returns whether the lock is a time, or for a relative lock a number
+// of 512 second intervals, rather than a block height or number of blocks.
+func (l TimeLock) IsTime() bool {
+	if l.Relative {
+		return l.Value&constants.SequenceLockTimeIsSeconds != 0
+	}
+	return l.Value >= params.LockTimeThreshold
+}
+
+func (l TimeLock) String() string {
+	switch {
+	case l.Relative && l.IsTime():
+		return fmt.Sprintf("[%d] seconds after the output confirms",
+			(l.Value&constants.SequenceLockTimeMask)*512)
+	case l.Relative:
+		return fmt.Sprintf("[%d] blocks after the output confirms",
+			l.Value&constants.SequenceLockTimeMask)
+	case l.IsTime():
+		return fmt.Sprintf("unix time [%d]", l.Value)
+	default:
+		return fmt.Sprintf("height [%d]", l.Value)
+	}
+}
+
+// ParseTimeLock returns the lock of a time locked script and the script which
+// follows it, ok is false if script is not time locked.  A relative lock with
+// the disable flag set does not lock and is not reported.
+func ParseTimeLock(script []byte) (lock TimeLock, rest []byte, ok bool) {
+	if len(script) < 3 {
+		return lock, nil, false
+	}
+	var n int64
+	var pushLen int
+	switch op := script[0]; {
+	case op >= opcode.OP_1 && op <= opcode.OP_16:
+		n = int64(op - opcode.OP_1 + 1)
+	case op >= opcode.OP_DATA_1 && op <= opcode.OP_DATA_5 && len(script) > int(op)+2:
+		num, err := scriptnum.MakeScriptNum(script[1:1+op], true, 5)
+		if err != nil {
+			return lock, nil, false
+		}
+		n = int64(num)
+		pushLen = int(op)
+	default:
+		return lock, nil, false
+	}
+	if n < 0 || n > int64(^uint32(0)) {
+		return lock, nil, false
+	}
+	switch script[1+pushLen] {
+	case opcode.OP_CHECKLOCKTIMEVERIFY:
+	case opcode.OP_CHECKSEQUENCEVERIFY:
+		if uint32(n)&constants.SequenceLockTimeDisabled != 0 {
+			return lock, nil, false
+		}
+		lock.Relative = true
+	default:
+		return lock, nil, false
+	}
+	if script[2+pushLen] != opcode.OP_DROP {
+		return lock, nil, false
+	}
+	lock.Value = uint32(n)
+	return lock, script[3+pushLen:], true
+}
+
+// timeLockedScript returns the time locked redeem or witness script which the
+// P2SH or P2WSH pkScript pays, ok is false if it pays some other script or the
+// script is not known.
+func timeLockedScript(pkScript []byte, sdb txscript.ScriptDB,
+	chainParams *chaincfg.Params) (script []byte, lock TimeLock, ok bool) {
+
+	if sdb == nil || !txscript.IsPayToScriptHash(pkScript) &&
+		!txscript.IsPayToWitnessScriptHash(pkScript) {
+		return nil, lock, false
+	}
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, chainParams)
+	if err != nil || len(addrs) != 1 {
+		return nil, lock, false
+	}
+	script, err = sdb.GetScript(addrs[0])
+	if err != nil {
+		return nil, lock, false
+	}
+	lock, _, ok = ParseTimeLock(script)
+	return script, lock, ok
+}
+
+// ScriptTimeLock returns the lock of the time locked script which the P2SH or
+// P2WSH pkScript pays, ok is false if it pays some other script or the script
+// is not known to sdb.
+func ScriptTimeLock(pkScript []byte, sdb txscript.ScriptDB,
+	chainParams *chaincfg.Params) (lock TimeLock, ok bool) {
+
+	_, lock, ok = timeLockedScript(pkScript, sdb, chainParams)
+	return lock, ok
+}
+
+// ApplyTimeLocks sets the locktime, version and input sequences of tx as the
+// time locked scripts which its inputs spend require, and returns the lock of
+// each input, nil for inputs which do not spend a time locked script.  This
+// must be done before any input is signed, as the signatures commit to these
+// fields.  The previous output scripts are taken from tx.Additional.
+func ApplyTimeLocks(tx *wire.MsgTx, sdb txscript.ScriptDB,
+	chainParams *chaincfg.Params) ([]*TimeLock, er.R) {
+
+	locks := make([]*TimeLock, len(tx.TxIn))
+	for i, txIn := range tx.TxIn {
+		if i >= len(tx.Additional) {
+			break
+		}
+		_, lock, ok := timeLockedScript(tx.Additional[i].PkScript, sdb, chainParams)
+		if !ok {
+			continue
+		}
+		locks[i] = &lock
+		if lock.Relative {
+			if tx.Version < 2 {
+				tx.Version = 2
+			}
+			if !sequenceSatisfies(txIn.Sequence, lock) {
+				txIn.Sequence = lock.Value & (constants.SequenceLockTimeIsSeconds |
+					constants.SequenceLockTimeMask)
+			}
+			continue
+		}
+		if tx.LockTime != 0 && (tx.LockTime >= params.LockTimeThreshold) != lock.IsTime() {
+			return nil, ErrTimeLockConflict.New(fmt.Sprintf("input [%d] "+
+				"is locked until %s but the transaction has locktime [%d]",
+				i, lock, tx.LockTime), nil)
+		}
+		if tx.LockTime < lock.Value {
+			tx.LockTime = lock.Value
+		}
+		if txIn.Sequence == constants.MaxTxInSequenceNum {
+			txIn.Sequence = constants.MaxTxInSequenceNum - 1
+		}
+	}
+	return locks, nil
+}
+
+// sequenceSatisfies returns whether an input sequence satisfies a relative
+// lock, as OP_CHECKSEQUENCEVERIFY checks.
+func sequenceSatisfies(sequence uint32, lock TimeLock) bool {
+	if sequence&constants.SequenceLockTimeDisabled != 0 {
+		return false
+	}
+	if (sequence&constants.SequenceLockTimeIsSeconds != 0) != lock.IsTime() {
+		return false
+	}
+	return sequence&constants.SequenceLockTimeMask >=
+		lock.Value&constants.SequenceLockTimeMask
+}
+
+// checkTimeLock returns ErrTimeLockUnsatisfied if the locktime, version or
+// sequence of input idx of tx do not satisfy lock.
+func checkTimeLock(tx *wire.MsgTx, idx int, lock TimeLock) er.R {
+	txIn := tx.TxIn[idx]
+	switch {
+	case lock.Relative && (tx.Version < 2 || !sequenceSatisfies(txIn.Sequence, lock)):
+	case !lock.Relative && (txIn.Sequence == constants.MaxTxInSequenceNum ||
+		(tx.LockTime >= params.LockTimeThreshold) != lock.IsTime() ||
+		tx.LockTime < lock.Value):
+	default:
+		return nil
+	}
+	return ErrTimeLockUnsatisfied.New(fmt.Sprintf("input [%d] is locked "+
+		"until %s which the transaction does not satisfy, the time locks "+
+		"must be applied before signing", idx, lock), nil)
+}
+
+// spendTimeLocked signs input idx of tx, which spends the time locked P2SH or
+// P2WSH script, with the key of the address which the script pays.
+func spendTimeLocked(tx *wire.MsgTx, idx int, pkScript, script []byte,
+	lock TimeLock, inputValueP *int64, chainParams *chaincfg.Params,
+	kdb txscript.KeyDB, hashCache *txscript.TxSigHashes,
+	hashType params.SigHashType) er.R {
+
+	if err := checkTimeLock(tx, idx, lock); err != nil {
+		return err
+	}
+	_, rest, _ := ParseTimeLock(script)
+	class, addrs, _, err := txscript.ExtractPkScriptAddrs(rest, chainParams)
+	if err != nil {
+		return err
+	}
+	// A key which does not parse is left out of addrs.
+	var addr btcutil.Address
+	switch {
+	case len(addrs) != 1:
+		return er.Errorf("cannot sign input [%d], the time locked script "+
+			"does not pay a single valid key", idx)
+	case class == txscript.PubKeyTy:
+		addr = addrs[0].(*btcutil.AddressPubKey).AddressPubKeyHash()
+	case class == txscript.PubKeyHashTy:
+		addr = addrs[0]
+	default:
+		return er.Errorf("cannot sign input [%d], the time locked script "+
+			"does not pay a single key", idx)
+	}
+	privKey, compressed, err := kdb.GetKey(addr)
+	if err != nil {
+		return err
+	}
+	var pubKey []byte
+	if class == txscript.PubKeyHashTy {
+		if compressed {
+			pubKey = privKey.PubKey().SerializeCompressed()
+		} else {
+			pubKey = privKey.PubKey().SerializeUncompressed()
+		}
+	}
+
+	if txscript.IsPayToWitnessScriptHash(pkScript) {
+		if inputValueP == nil {
+			return er.New("Unable to sign transaction because input amount is unknown")
+		}
+		sig, err := txscript.RawTxInWitnessSignature(tx, hashCache, idx,
+			*inputValueP, script, hashType, privKey)
+		if err != nil {
+			return err
+		}
+		witness := wire.TxWitness{sig}
+		if pubKey != nil {
+			witness = append(witness, pubKey)
+		}
+		tx.TxIn[idx].Witness = append(witness, script)
+		tx.TxIn[idx].SignatureScript = nil
+		return nil
+	}
+
+	sig, err := txscript.RawTxInSignature(tx, idx, script, hashType, privKey)
+	if err != nil {
+		return err
+	}
+	builder := scriptbuilder.NewScriptBuilder().AddData(sig)
+	if pubKey != nil {
+		builder.AddData(pubKey)
+	}
+	sigScript, err := builder.AddData(script).Script()
+	if err != nil {
+		return err
+	}
+	tx.TxIn[idx].SignatureScript = sigScript
+	return nil
+}
@@ -0,0 +1,130 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkt-cash/pktd/blockchain"
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktlog/log"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TxFetcher provides transactions which are not recorded in the wallet.  It
+// is implemented by chain.RPCClient, which can fetch any transaction if pktd
+// keeps a transaction index.
+type TxFetcher interface {
+	GetRawTransaction(txHash *chainhash.Hash) (*btcutil.Tx, er.R)
+}
+
+// ErrPrevOutsUnavailable is returned when the fee of a transaction cannot be
+// known because the values of some of the outputs which it spends cannot be
+// found.
+var ErrPrevOutsUnavailable = Err.CodeWithDetail("ErrPrevOutsUnavailable",
+	"values of the outputs spent by the transaction are not available")
+
+// TxFeeInfo is the fee paid by a wallet transaction.  FeeRate is in atomic
+// units per kilobyte of virtual size, as for FeeStats.
+type TxFeeInfo struct {
+	Fee     btcutil.Amount
+	FeeRate btcutil.Amount
+	Size    int
+	VSize   int64
+}
+
+// TxFee returns the fee paid by a wallet transaction, mined or not.  The value
+// of each output spent by the transaction is taken from the wallet's records
+// if it spends an output of the wallet or of another transaction which the
+// wallet recorded, otherwise the spent transaction is fetched with tf.  If tf
+// is nil or it cannot provide the transaction, ErrPrevOutsUnavailable is
+// returned naming the outputs whose values are not known.  Coinbase
+// transactions pay no fee.
+func (w *Wallet) TxFee(txHash *chainhash.Hash, tf TxFetcher) (*TxFeeInfo, er.R) {
+	var details *wtxmgr.TxDetails
+	var input btcutil.Amount
+	var missing []wire.OutPoint
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		var err er.R
+		details, err = w.TxStore.TxDetails(txmgrNs, txHash)
+		if err != nil || details == nil {
+			return err
+		}
+		debits := make(map[uint32]btcutil.Amount, len(details.Debits))
+		for _, deb := range details.Debits {
+			debits[deb.Index] = deb.Amount
+		}
+		for i, txIn := range details.MsgTx.TxIn {
+			if amount, ok := debits[uint32(i)]; ok {
+				input += amount
+				continue
+			}
+			op := txIn.PreviousOutPoint
+			prev, err := w.TxStore.TxDetails(txmgrNs, &op.Hash)
+			if err != nil {
+				return err
+			}
+			if prev != nil && int(op.Index) < len(prev.MsgTx.TxOut) {
+				input += btcutil.Amount(prev.MsgTx.TxOut[op.Index].Value)
+				continue
+			}
+			missing = append(missing, op)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, wtxmgr.ErrNoExists.New("transaction not found in wallet", nil)
+	}
+
+	info := &TxFeeInfo{
+		Size:  details.MsgTx.SerializeSize(),
+		VSize: int64(virtualSize(&details.MsgTx)),
+	}
+	if blockchain.IsCoinBaseTx(&details.MsgTx) {
+		return info, nil
+	}
+
+	var unavailable []string
+	for _, op := range missing {
+		var prev *btcutil.Tx
+		if tf != nil {
+			prev, err = tf.GetRawTransaction(&op.Hash)
+			if err != nil {
+				log.Debugf("Unable to fetch transaction [%s] spent by [%s]: %v",
+					op.Hash, txHash, err)
+			}
+		}
+		if prev == nil || int(op.Index) >= len(prev.MsgTx().TxOut) {
+			unavailable = append(unavailable, op.String())
+			continue
+		}
+		input += btcutil.Amount(prev.MsgTx().TxOut[op.Index].Value)
+	}
+	if len(unavailable) > 0 {
+		return nil, ErrPrevOutsUnavailable.New(fmt.Sprintf("transaction [%s] "+
+			"spends [%s] which are not in the wallet and could not be "+
+			"fetched", txHash, strings.Join(unavailable, ", ")), nil)
+	}
+
+	info.Fee = input
+	for _, out := range details.MsgTx.TxOut {
+		info.Fee -= btcutil.Amount(out.Value)
+	}
+	info.FeeRate = info.Fee * 1000 / btcutil.Amount(info.VSize)
+	return info, nil
+}
+
+// virtualSize returns the virtual size of tx, its weight divided by the
+// witness scale factor and rounded up.
+func virtualSize(tx *wire.MsgTx) int {
+	weight := blockchain.GetTransactionWeight(btcutil.NewTx(tx))
+	return int((weight + blockchain.WitnessScaleFactor - 1) /
+		blockchain.WitnessScaleFactor)
+}
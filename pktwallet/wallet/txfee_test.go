@@ -0,0 +1,125 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// mockTxFetcher serves a fixed set of transactions.
+type mockTxFetcher map[chainhash.Hash]*wire.MsgTx
+
+func (m mockTxFetcher) GetRawTransaction(txHash *chainhash.Hash) (*btcutil.Tx, er.R) {
+	if tx, ok := m[*txHash]; ok {
+		return btcutil.NewTx(tx), nil
+	}
+	return nil, er.Errorf("no transaction %s", txHash)
+}
+
+// TestTxFee checks the fee of a mined transaction which spends only wallet
+// outputs and of one which also spends an output which the wallet does not
+// know the value of.
+func TestTxFee(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	funding := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{
+			wire.NewTxOut(1e8, pkScript),
+			wire.NewTxOut(2e8, pkScript),
+			wire.NewTxOut(3e7, []byte{0x51}),
+		},
+	}
+	insertTestTx(t, w, funding, 100, 0, 1)
+	fundingHash := funding.TxHash()
+
+	// Spends only an output of the wallet, the fee rate is on the virtual
+	// size which discounts the witness.
+	owned := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: fundingHash, Index: 0},
+			Witness:          wire.TxWitness{make([]byte, 72), make([]byte, 33)},
+		}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8-1000, []byte{0x51})},
+	}
+	insertTestTx(t, w, owned, 101)
+	ownedHash := owned.TxHash()
+	info, err := w.TxFee(&ownedHash, nil)
+	if err != nil {
+		t.Fatalf("unable to get fee of owned transaction: %v", err)
+	}
+	vsize := virtualSize(owned)
+	if vsize >= owned.SerializeSize() {
+		t.Fatalf("virtual size %d is not below size %d", vsize,
+			owned.SerializeSize())
+	}
+	if info.Fee != 1000 || info.Size != owned.SerializeSize() ||
+		info.VSize != int64(vsize) ||
+		info.FeeRate != btcutil.Amount(1000*1000/vsize) {
+
+		t.Fatalf("got fee %v at %v/kB for size %d vsize %d, want 1000 "+
+			"for size %d vsize %d", info.Fee, info.FeeRate, info.Size,
+			info.VSize, owned.SerializeSize(), vsize)
+	}
+
+	// Also spends an output of the funding transaction which does not pay
+	// the wallet and an output of a transaction which the wallet does not
+	// have.
+	external := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Index: 7}}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(5e7, []byte{0x51})},
+	}
+	externalOp := wire.OutPoint{Hash: external.TxHash(), Index: 0}
+	mixed := &wire.MsgTx{
+		TxIn: []*wire.TxIn{
+			{PreviousOutPoint: wire.OutPoint{Hash: fundingHash, Index: 1}},
+			{PreviousOutPoint: wire.OutPoint{Hash: fundingHash, Index: 2}},
+			{PreviousOutPoint: externalOp},
+		},
+		TxOut: []*wire.TxOut{wire.NewTxOut(28e7-5000, []byte{0x51})},
+	}
+	insertTestTx(t, w, mixed, 102)
+	mixedHash := mixed.TxHash()
+
+	_, err = w.TxFee(&mixedHash, nil)
+	if !ErrPrevOutsUnavailable.Is(err) {
+		t.Fatalf("got error %v without a fetcher, want "+
+			"ErrPrevOutsUnavailable", err)
+	}
+	if !strings.Contains(err.Message(), externalOp.String()) {
+		t.Fatalf("error %v does not name the unavailable output %v",
+			err, externalOp)
+	}
+	_, err = w.TxFee(&mixedHash, mockTxFetcher{})
+	if !ErrPrevOutsUnavailable.Is(err) {
+		t.Fatalf("got error %v when the fetcher fails, want "+
+			"ErrPrevOutsUnavailable", err)
+	}
+	info, err = w.TxFee(&mixedHash, mockTxFetcher{externalOp.Hash: external})
+	if err != nil {
+		t.Fatalf("unable to get fee with fetched inputs: %v", err)
+	}
+	if info.Fee != 5000 {
+		t.Fatalf("got fee %v, want 5000", info.Fee)
+	}
+
+	var missing chainhash.Hash
+	if _, err := w.TxFee(&missing, nil); err == nil {
+		t.Fatalf("got fee of a transaction not in the wallet")
+	}
+}
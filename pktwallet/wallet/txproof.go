@@ -0,0 +1,104 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/blockchain"
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// ErrTxNotMined is returned when a merkle proof is requested for a transaction
+// which is not yet in a block.
+var ErrTxNotMined = Err.CodeWithDetail("ErrTxNotMined",
+	"transaction is not mined in a block")
+
+// TxProof is a merkle proof that a transaction is included in a block.  The
+// branch is ordered from the bottom of the merkle tree to the top and a nil
+// entry means that the node on the path was hashed with itself.
+type TxProof struct {
+	TxHash      chainhash.Hash
+	BlockHash   chainhash.Hash
+	BlockHeight int32
+	Index       int
+	Branch      []*chainhash.Hash
+}
+
+// TxProof builds a merkle proof of the inclusion of a mined wallet transaction
+// in its block.  The block is requested from the chain backend, in SPV mode
+// this means it is fetched from the network by neutrino.
+func (w *Wallet) TxProof(txHash *chainhash.Hash) (*TxProof, er.R) {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var details *wtxmgr.TxDetails
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		var err er.R
+		details, err = w.TxStore.TxDetails(txmgrNs, txHash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, wtxmgr.ErrNoExists.New("transaction not found in wallet", nil)
+	}
+	if details.Block.Height < 0 {
+		return nil, ErrTxNotMined.Default()
+	}
+
+	block, err := chainClient.GetBlock(&details.Block.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, er.Errorf("block [%s] not available from chain backend",
+			details.Block.Hash)
+	}
+	txns := btcutil.NewBlock(block).Transactions()
+	index := -1
+	for i, tx := range txns {
+		if tx.Hash().IsEqual(txHash) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, er.Errorf("transaction [%s] is not in block [%s]",
+			txHash, details.Block.Hash)
+	}
+
+	tree := blockchain.BuildMerkleTreeStore(txns, false)
+	branch := blockchain.GetMerkleBranch(index, tree)
+	return &TxProof{
+		TxHash:      *txHash,
+		BlockHash:   details.Block.Hash,
+		BlockHeight: details.Block.Height,
+		Index:       index,
+		Branch:      branch,
+	}, nil
+}
+
+// VerifyTxProof checks a merkle proof against the merkle root of the header of
+// the block which it claims to be from.  The header is requested from the
+// chain backend.
+func (w *Wallet) VerifyTxProof(proof *TxProof) (bool, er.R) {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		return false, err
+	}
+	header, err := chainClient.GetBlockHeader(&proof.BlockHash)
+	if err != nil {
+		return false, err
+	}
+	if header == nil {
+		return false, er.Errorf("block header [%s] not available from chain "+
+			"backend", proof.BlockHash)
+	}
+	root := blockchain.MerkleBranchRoot(&proof.TxHash, proof.Index, proof.Branch)
+	return root != nil && root.IsEqual(&header.MerkleRoot), nil
+}
@@ -0,0 +1,153 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/blockchain"
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// blockChainClient is a mock chain client which serves a single block.
+type blockChainClient struct {
+	mockChainClient
+	block *wire.MsgBlock
+}
+
+func (c *blockChainClient) GetBlock(hash *chainhash.Hash) (*wire.MsgBlock, er.R) {
+	if c.block.BlockHash() != *hash {
+		return nil, nil
+	}
+	return c.block, nil
+}
+
+func (c *blockChainClient) GetBlockHeader(hash *chainhash.Hash) (*wire.BlockHeader,
+	er.R) {
+	if c.block.BlockHash() != *hash {
+		return nil, nil
+	}
+	return &c.block.Header, nil
+}
+
+// TestTxProof constructs a merkle proof for a wallet transaction in a seeded
+// block and ensures that it verifies against the block header.
+func TestTxProof(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	// Seed a block with an odd number of transactions so the proof needs
+	// to cover a node which is hashed with itself.
+	block := &wire.MsgBlock{}
+	for i := 0; i < 5; i++ {
+		tx := wire.NewMsgTx(1)
+		tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: uint32(i)}, nil, nil))
+		tx.AddTxOut(wire.NewTxOut(int64(i+1)*1000, []byte{0x51}))
+		block.AddTransaction(tx)
+	}
+	txns := btcutil.NewBlock(block).Transactions()
+	tree := blockchain.BuildMerkleTreeStore(txns, false)
+	block.Header.MerkleRoot = *tree[len(tree)-1]
+	blockHash := block.BlockHash()
+	w.chainClient = &blockChainClient{block: block}
+
+	const txIndex = 4
+	walletTx := block.Transactions[txIndex]
+	var b bytes.Buffer
+	if err := walletTx.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+	rec, err := wtxmgr.NewTxRecord(b.Bytes(), time.Now())
+	if err != nil {
+		t.Fatalf("unable to create tx record: %v", err)
+	}
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		ns := tx.ReadWriteBucket(wtxmgrNamespaceKey)
+		return w.TxStore.InsertTx(ns, rec, &wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{Hash: blockHash, Height: 1000},
+			Time:  time.Unix(1387737310, 0),
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to insert tx: %v", err)
+	}
+
+	txHash := walletTx.TxHash()
+	proof, err := w.TxProof(&txHash)
+	if err != nil {
+		t.Fatalf("unable to build proof: %v", err)
+	}
+	if proof.BlockHash != blockHash || proof.BlockHeight != 1000 ||
+		proof.Index != txIndex {
+		t.Fatalf("unexpected proof position: %+v", proof)
+	}
+
+	ok, err := w.VerifyTxProof(proof)
+	if err != nil {
+		t.Fatalf("unable to verify proof: %v", err)
+	}
+	if !ok {
+		t.Fatalf("valid proof did not verify")
+	}
+
+	// A proof with the wrong position must not verify.
+	badIndex := *proof
+	badIndex.Index = txIndex - 1
+	if ok, _ := w.VerifyTxProof(&badIndex); ok {
+		t.Fatalf("proof with wrong index verified")
+	}
+
+	// Nor may a proof with a tampered branch.
+	badBranch := *proof
+	badBranch.Branch = append([]*chainhash.Hash(nil), proof.Branch...)
+	badBranch.Branch[len(badBranch.Branch)-1] = &chainhash.Hash{}
+	if ok, _ := w.VerifyTxProof(&badBranch); ok {
+		t.Fatalf("proof with tampered branch verified")
+	}
+
+	// Nor may a proof leaving out the sibling on the left of a node, as if
+	// it were hashed with itself.
+	badDup := *proof
+	badDup.Branch = append([]*chainhash.Hash(nil), proof.Branch...)
+	badDup.Branch[len(badDup.Branch)-1] = nil
+	if ok, _ := w.VerifyTxProof(&badDup); ok {
+		t.Fatalf("proof with a missing sibling verified")
+	}
+
+	// Transactions which the wallet does not know have no proof.
+	unknown := chainhash.DoubleHashH([]byte("unknown"))
+	if _, err := w.TxProof(&unknown); !wtxmgr.ErrNoExists.Is(err) {
+		t.Fatalf("got error %v for an unknown transaction, want "+
+			"ErrNoExists", err)
+	}
+
+	// Nor do unmined transactions.
+	unmined := wire.NewMsgTx(1)
+	unmined.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 9}, nil, nil))
+	unmined.AddTxOut(wire.NewTxOut(1000, []byte{0x51}))
+	b.Reset()
+	if err := unmined.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+	rec, err = wtxmgr.NewTxRecord(b.Bytes(), time.Now())
+	if err != nil {
+		t.Fatalf("unable to create tx record: %v", err)
+	}
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		return w.TxStore.InsertTx(tx.ReadWriteBucket(wtxmgrNamespaceKey),
+			rec, nil)
+	})
+	if err != nil {
+		t.Fatalf("unable to insert tx: %v", err)
+	}
+	unminedHash := unmined.TxHash()
+	if _, err := w.TxProof(&unminedHash); !ErrTxNotMined.Is(err) {
+		t.Fatalf("got error %v for an unmined transaction, want "+
+			"ErrTxNotMined", err)
+	}
+}
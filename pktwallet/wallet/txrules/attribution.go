@@ -0,0 +1,55 @@
+package txrules
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/pkt-cash/pktd/btcutil"
+)
+
+// AttributeFee splits fee between outputs in proportion to their values, so
+// that each recipient of a batch send can be told its share of the fee.  The
+// shares are rounded down and the atomic units left over are given to the
+// outputs with the largest remainders, so the shares always sum to fee.  If
+// the outputs have no value then the fee is split equally.
+func AttributeFee(fee btcutil.Amount, values []btcutil.Amount) []btcutil.Amount {
+	shares := make([]btcutil.Amount, len(values))
+	if len(values) == 0 {
+		return shares
+	}
+	total := new(big.Int)
+	for _, v := range values {
+		total.Add(total, big.NewInt(int64(v)))
+	}
+	if total.Sign() == 0 {
+		total.SetInt64(int64(len(values)))
+		values = make([]btcutil.Amount, len(values))
+		for i := range values {
+			values[i] = 1
+		}
+	}
+
+	// The products of fees and values may not fit in 64 bits.
+	remainders := make([]*big.Int, len(values))
+	left := fee
+	for i, v := range values {
+		share, rem := new(big.Int).QuoRem(
+			new(big.Int).Mul(big.NewInt(int64(fee)), big.NewInt(int64(v))),
+			total, new(big.Int))
+		shares[i] = btcutil.Amount(share.Int64())
+		remainders[i] = rem
+		left -= shares[i]
+	}
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+	for i := 0; left > 0; i++ {
+		shares[order[i%len(order)]]++
+		left--
+	}
+	return shares
+}
@@ -0,0 +1,43 @@
+package txrules
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil"
+)
+
+// TestAttributeFee ensures that attributed fees sum to the total fee and are
+// proportional to the output values, to within one atomic unit.
+func TestAttributeFee(t *testing.T) {
+	tests := []struct {
+		fee    btcutil.Amount
+		values []btcutil.Amount
+		shares []btcutil.Amount
+	}{
+		{1000, []btcutil.Amount{1e8, 3e8}, []btcutil.Amount{250, 750}},
+		{1000, []btcutil.Amount{1e8, 1e8, 1e8}, []btcutil.Amount{334, 333, 333}},
+		{7, []btcutil.Amount{10, 20, 40}, []btcutil.Amount{1, 2, 4}},
+		{10, []btcutil.Amount{1, 2, 2}, []btcutil.Amount{2, 4, 4}},
+		{5, []btcutil.Amount{0, 0}, []btcutil.Amount{3, 2}},
+		{2000, []btcutil.Amount{6e18, 2e18}, []btcutil.Amount{1500, 500}},
+	}
+	for _, test := range tests {
+		shares := AttributeFee(test.fee, test.values)
+		sum := btcutil.Amount(0)
+		for i, share := range shares {
+			sum += share
+			if share != test.shares[i] {
+				t.Errorf("fee %v of %v: got shares %v, want %v", test.fee,
+					test.values, shares, test.shares)
+				break
+			}
+		}
+		if sum != test.fee {
+			t.Errorf("fee %v of %v: shares sum to %v", test.fee,
+				test.values, sum)
+		}
+	}
+	if shares := AttributeFee(1000, nil); len(shares) != 0 {
+		t.Errorf("got shares %v for no outputs", shares)
+	}
+}
@@ -0,0 +1,97 @@
+package txrules
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/globalcfg"
+)
+
+// feeRateSizes are the sizes, in bytes, which a fee rate may be expressed per.
+var feeRateSizes = map[string]float64{
+	"kB":  1000,
+	"kvB": 1000,
+	"B":   1,
+	"vB":  1,
+}
+
+// SplitFeeRate splits a human readable fee rate into its quantity, its coin
+// unit and the size in bytes which it is per.  A fee rate is either a plain
+// number of coins per kilobyte or a number followed by a unit of the form
+// <coin unit>/<size>, e.g. "10 bit/vB" or "0.0001 PKT/kB".  The coin unit is
+// not resolved so this may be used before the chain parameters are known.
+func SplitFeeRate(s string) (float64, string, float64, er.R) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !strings.ContainsRune("0123456789.+-eE", r)
+	})
+	num, unit := s, ""
+	if i >= 0 {
+		num, unit = s[:i], strings.TrimSpace(s[i:])
+	}
+	quantity, errr := strconv.ParseFloat(num, 64)
+	if errr != nil || math.IsNaN(quantity) || math.IsInf(quantity, 0) {
+		return 0, "", 0, er.Errorf("invalid fee rate [%s]", s)
+	}
+	if unit == "" {
+		return quantity, "", 1000, nil
+	}
+	slash := strings.Index(unit, "/")
+	if slash < 0 {
+		return 0, "", 0, er.Errorf("invalid fee rate [%s], expected a unit "+
+			"such as bit/vB or PKT/kB", s)
+	}
+	size, ok := feeRateSizes[strings.TrimSpace(unit[slash+1:])]
+	if !ok {
+		return 0, "", 0, er.Errorf("invalid fee rate [%s], the rate must be "+
+			"per kB, kvB, B or vB", s)
+	}
+	return quantity, strings.TrimSpace(unit[:slash]), size, nil
+}
+
+// ParseFeeRate parses a human readable fee rate, as described by SplitFeeRate,
+// into atomic units per kilobyte.  A plain number is taken as coins per
+// kilobyte and "sat" is accepted as a name for the atomic unit.
+func ParseFeeRate(s string) (btcutil.Amount, er.R) {
+	quantity, unit, size, err := SplitFeeRate(s)
+	if err != nil {
+		return 0, err
+	}
+	units := float64(btcutil.UnitsPerCoin())
+	if unit == "sat" {
+		units = 1
+	} else if unit != "" {
+		units = 0
+		for _, u := range globalcfg.AmountUnits() {
+			if u.Name == unit {
+				units = float64(u.Units)
+				break
+			}
+		}
+		if units == 0 {
+			return 0, er.Errorf("invalid fee rate [%s], [%s] is not a "+
+				"known unit", s, unit)
+		}
+	}
+	return btcutil.Amount(math.Round(quantity * units * 1000 / size)), nil
+}
+
+// FormatFeeRate formats a fee rate in atomic units per kilobyte as atomic
+// units per virtual byte, e.g. "10 bit/vB".
+func FormatFeeRate(feePerKb btcutil.Amount) string {
+	name := "sat"
+	for _, u := range globalcfg.AmountUnits() {
+		if u.Units == 1 {
+			name = u.Name
+			if u.ProperName != "" {
+				name = u.ProperName
+			}
+			break
+		}
+	}
+	return strconv.FormatFloat(float64(feePerKb)/1000, 'f', -1, 64) +
+		" " + name + "/vB"
+}
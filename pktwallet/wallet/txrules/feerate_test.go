@@ -0,0 +1,59 @@
+package txrules
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil"
+)
+
+// TestFeeRateRoundTrip ensures that fee rates parse to the expected rate per
+// kilobyte and that formatted rates parse back to the same rate.
+func TestFeeRateRoundTrip(t *testing.T) {
+	tests := []struct {
+		in        string
+		feePerKb  btcutil.Amount
+		formatted string
+	}{
+		{"0.0001", 10000, "10 Satoshi/vB"},
+		{"0.00001234", 1234, "1.234 Satoshi/vB"},
+		{"1e-5", 1000, "1 Satoshi/vB"},
+		{"10 sat/vB", 10000, "10 Satoshi/vB"},
+		{"2.5sat/B", 2500, "2.5 Satoshi/vB"},
+		{"1500 satoshi/kvB", 1500, "1.5 Satoshi/vB"},
+		{"0.001 BTC/kB", 100000, "100 Satoshi/vB"},
+		{"0.2 mBTC/kB", 20000, "20 Satoshi/vB"},
+		{"3 μBTC/vB", 300000, "300 Satoshi/vB"},
+		{"0", 0, "0 Satoshi/vB"},
+	}
+	for _, test := range tests {
+		feePerKb, err := ParseFeeRate(test.in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.in, err)
+			continue
+		}
+		if feePerKb != test.feePerKb {
+			t.Errorf("%s: got %d/kB, want %d/kB", test.in, feePerKb,
+				test.feePerKb)
+		}
+		formatted := FormatFeeRate(feePerKb)
+		if formatted != test.formatted {
+			t.Errorf("%s: formatted as %q, want %q", test.in, formatted,
+				test.formatted)
+		}
+		reparsed, err := ParseFeeRate(formatted)
+		if err != nil {
+			t.Errorf("%s: unable to parse %q: %v", test.in, formatted, err)
+			continue
+		}
+		if reparsed != feePerKb {
+			t.Errorf("%s: %q parsed as %d/kB, want %d/kB", test.in,
+				formatted, reparsed, feePerKb)
+		}
+	}
+
+	for _, in := range []string{"", "abc", "10 sat", "10 sat/MB", "10 foo/vB"} {
+		if _, err := ParseFeeRate(in); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// TxState is the state of a transaction in the wallet's view of the chain and
+// mempool.
+type TxState int
+
+const (
+	// TxStateUnknown is a transaction which the wallet does not know.
+	TxStateUnknown TxState = iota
+
+	// TxStateUnconfirmed is a wallet transaction which is not yet mined.
+	TxStateUnconfirmed
+
+	// TxStateConfirmed is a wallet transaction which is mined.
+	TxStateConfirmed
+
+	// TxStateConflicted is an unmined wallet transaction which was removed
+	// because a mined transaction spends one of the same outputs.
+	TxStateConflicted
+)
+
+// String returns the name of the state.
+func (s TxState) String() string {
+	switch s {
+	case TxStateUnconfirmed:
+		return "unconfirmed"
+	case TxStateConfirmed:
+		return "confirmed"
+	case TxStateConflicted:
+		return "conflicted"
+	default:
+		return "unknown"
+	}
+}
+
+// TxStatus is the state of a transaction.  Confirmations and BlockHeight are
+// set for a confirmed transaction and ConflictedBy for a conflicted one.
+type TxStatus struct {
+	State         TxState
+	Confirmations int32
+	BlockHeight   int32
+	ConflictedBy  *chainhash.Hash
+}
+
+// TxStatus returns whether a transaction is unknown to the wallet, unconfirmed,
+// confirmed or conflicted by a mined transaction.
+func (w *Wallet) TxStatus(txHash *chainhash.Hash) (*TxStatus, er.R) {
+	status := &TxStatus{State: TxStateUnknown, BlockHeight: -1}
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		details, err := w.TxStore.TxDetails(txmgrNs, txHash)
+		if err != nil {
+			return err
+		}
+		if details != nil {
+			status.State = TxStateUnconfirmed
+			if height := details.Block.Height; height >= 0 {
+				status.State = TxStateConfirmed
+				status.BlockHeight = height
+				status.Confirmations = confirms(height,
+					w.Manager.SyncedTo().Height)
+			}
+			return nil
+		}
+		rec, conflictedBy, err := w.TxStore.ConflictedTx(txmgrNs, txHash)
+		if err != nil {
+			return err
+		}
+		if rec != nil {
+			status.State = TxStateConflicted
+			status.ConflictedBy = conflictedBy
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
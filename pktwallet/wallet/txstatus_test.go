@@ -0,0 +1,85 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestTxStatus seeds a confirmed transaction, an unconfirmed spend of it which
+// is then conflicted by a mined double spend, and checks the status of each
+// and of an unknown transaction.
+func TestTxStatus(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	setSyncedTo(t, w, 110)
+
+	incoming := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+	}
+	insertTestTx(t, w, incoming, 100, 0)
+	op := wire.OutPoint{Hash: incoming.TxHash()}
+	spend := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: op}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(9e7, []byte{0x51})},
+	}
+	insertTestTx(t, w, spend, -1, 0)
+
+	check := func(hash chainhash.Hash, want TxStatus) {
+		t.Helper()
+		status, err := w.TxStatus(&hash)
+		if err != nil {
+			t.Fatalf("unable to get status of %v: %v", hash, err)
+		}
+		if status.State != want.State ||
+			status.Confirmations != want.Confirmations ||
+			status.BlockHeight != want.BlockHeight ||
+			(status.ConflictedBy == nil) != (want.ConflictedBy == nil) ||
+			(want.ConflictedBy != nil && *status.ConflictedBy != *want.ConflictedBy) {
+
+			t.Fatalf("got status %+v of %v, want %+v", status, hash, want)
+		}
+	}
+	check(chainhash.Hash{9}, TxStatus{State: TxStateUnknown, BlockHeight: -1})
+	check(incoming.TxHash(), TxStatus{State: TxStateConfirmed,
+		Confirmations: 11, BlockHeight: 100})
+	check(spend.TxHash(), TxStatus{State: TxStateUnconfirmed, BlockHeight: -1})
+
+	// A block then confirms a different spend of the coin.
+	doubleSpend := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: op, Sequence: 1}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(9e7, []byte{0x52})},
+	}
+	var b bytes.Buffer
+	if err := doubleSpend.Serialize(&b); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := wtxmgr.NewTxRecord(b.Bytes(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: chainhash.Hash{1}, Height: 101},
+		Time:  time.Now(),
+	}
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		return w.addRelevantTx(dbtx, rec, block)
+	})
+	if err != nil {
+		t.Fatalf("unable to add double spend: %v", err)
+	}
+
+	by := doubleSpend.TxHash()
+	check(spend.TxHash(), TxStatus{State: TxStateConflicted, BlockHeight: -1,
+		ConflictedBy: &by})
+	check(by, TxStatus{State: TxStateConfirmed, Confirmations: 10,
+		BlockHeight: 101})
+}
@@ -35,6 +35,22 @@ func (u unstableAPI) TxDetails(txHash *chainhash.Hash) (*wtxmgr.TxDetails, er.R)
 	return details, err
 }
 
+// ConflictedTx calls wtxmgr.Store.ConflictedTx under a single database view
+// transaction.
+func (u unstableAPI) ConflictedTx(txHash *chainhash.Hash) (*wtxmgr.TxRecord, *chainhash.Hash, er.R) {
+	var (
+		rec          *wtxmgr.TxRecord
+		conflictedBy *chainhash.Hash
+	)
+	err := walletdb.View(u.w.db, func(dbtx walletdb.ReadTx) er.R {
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		var err er.R
+		rec, conflictedBy, err = u.w.TxStore.ConflictedTx(txmgrNs, txHash)
+		return err
+	})
+	return rec, conflictedBy, err
+}
+
 // RangeTransactions calls wtxmgr.Store.RangeTransactions under a single
 // database view tranasction.
 func (u unstableAPI) RangeTransactions(begin, end int32, f func([]wtxmgr.TxDetails) (bool, er.R)) er.R {
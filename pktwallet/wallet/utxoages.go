@@ -0,0 +1,89 @@
+package wallet
+
+import (
+	"sort"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+// utxoAgeBuckets are the fewest confirmations of each bucket of UtxoAges after
+// the first, which holds the unconfirmed outputs.  The last bucket has no
+// upper bound.
+var utxoAgeBuckets = []int32{1, 6, 100, 1000, 10000}
+
+// UtxoAgeBucket is the number and value of the unspent outputs with between
+// MinConfs and MaxConfs confirmations.  MaxConfs is -1 for the last bucket,
+// which has no upper bound.
+type UtxoAgeBucket struct {
+	MinConfs int32
+	MaxConfs int32
+	Count    int
+	Amount   btcutil.Amount
+}
+
+// UtxoAges is how many confirmations the unspent outputs of the wallet have,
+// unconfirmed outputs having none.  Oldest, Newest and Median are zero if the
+// wallet has no unspent outputs.
+type UtxoAges struct {
+	Count   int
+	Oldest  int32
+	Newest  int32
+	Median  int32
+	Buckets []UtxoAgeBucket
+}
+
+// UtxoAges returns the distribution of the ages, in confirmations at the
+// height which the wallet is synced to, of the unspent outputs of the wallet,
+// including those which are locked.
+func (w *Wallet) UtxoAges() (*UtxoAges, er.R) {
+	tip := w.Manager.SyncedTo().Height
+	ages := &UtxoAges{
+		Buckets: make([]UtxoAgeBucket, 0, len(utxoAgeBuckets)+1),
+	}
+	ages.Buckets = append(ages.Buckets, UtxoAgeBucket{MaxConfs: 0})
+	for i, min := range utxoAgeBuckets {
+		max := int32(-1)
+		if i+1 < len(utxoAgeBuckets) {
+			max = utxoAgeBuckets[i+1] - 1
+		}
+		ages.Buckets = append(ages.Buckets, UtxoAgeBucket{MinConfs: min, MaxConfs: max})
+	}
+
+	var confs []int32
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		return w.TxStore.ForEachUnspentOutputIncludingLocked(txmgrNs, nil,
+			func(_ []byte, c *wtxmgr.Credit) er.R {
+				conf := confirms(c.Height, tip)
+				confs = append(confs, conf)
+				for i := len(ages.Buckets) - 1; i >= 0; i-- {
+					if b := &ages.Buckets[i]; conf >= b.MinConfs {
+						b.Count++
+						b.Amount += c.Amount
+						break
+					}
+				}
+				return nil
+			})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(confs)
+	ages.Count = n
+	if n == 0 {
+		return ages, nil
+	}
+	sort.Slice(confs, func(i, j int) bool { return confs[i] < confs[j] })
+	ages.Newest = confs[0]
+	ages.Oldest = confs[n-1]
+	ages.Median = confs[n/2]
+	if n%2 == 0 {
+		ages.Median = (confs[n/2-1] + confs[n/2]) / 2
+	}
+	return ages, nil
+}
@@ -0,0 +1,69 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestUtxoAges checks the ages of unspent outputs received at known heights.
+func TestUtxoAges(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	ages, err := w.UtxoAges()
+	if err != nil {
+		t.Fatalf("unable to get utxo ages: %v", err)
+	}
+	if ages.Count != 0 || ages.Oldest != 0 || len(ages.Buckets) != 6 {
+		t.Fatalf("got ages %+v of an empty wallet", ages)
+	}
+
+	setSyncedTo(t, w, 1000)
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// With the wallet synced to height 1000 these have 1000, 100, 5, 1
+	// and no confirmations.
+	for i, height := range []int32{1, 901, 996, 1000, -1} {
+		insertTestTx(t, w, &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Index: uint32(i)}}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(int64(i+1)*1e8, pkScript)},
+		}, height, 0)
+	}
+
+	ages, err = w.UtxoAges()
+	if err != nil {
+		t.Fatalf("unable to get utxo ages: %v", err)
+	}
+	if ages.Count != 5 || ages.Oldest != 1000 || ages.Newest != 0 ||
+		ages.Median != 5 {
+
+		t.Fatalf("got %d outputs aged %d to %d, median %d, want 5 aged "+
+			"1000 to 0, median 5", ages.Count, ages.Oldest, ages.Newest,
+			ages.Median)
+	}
+	want := []UtxoAgeBucket{
+		{MinConfs: 0, MaxConfs: 0, Count: 1, Amount: 5e8},
+		{MinConfs: 1, MaxConfs: 5, Count: 2, Amount: 7e8},
+		{MinConfs: 6, MaxConfs: 99},
+		{MinConfs: 100, MaxConfs: 999, Count: 1, Amount: 2e8},
+		{MinConfs: 1000, MaxConfs: 9999, Count: 1, Amount: 1e8},
+		{MinConfs: 10000, MaxConfs: -1},
+	}
+	if len(ages.Buckets) != len(want) {
+		t.Fatalf("got %d buckets, want %d", len(ages.Buckets), len(want))
+	}
+	for i, b := range ages.Buckets {
+		if b != want[i] {
+			t.Fatalf("got bucket %d %+v, want %+v", i, b, want[i])
+		}
+	}
+}
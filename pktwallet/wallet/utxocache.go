@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"sync"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktlog/log"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+)
+
+type utxoCacheEntry struct {
+	key    []byte
+	credit wtxmgr.Credit
+}
+
+// utxoCache holds the unspent outputs of the wallet, in the order in which the
+// transaction store visits them, so that coin selection need not read them
+// from the database each time.  It is invalidated by every change to the
+// transaction store which adds or removes unspent outputs, and filled again
+// by the next coin selection.
+//
+// Outputs locked with wtxmgr.Store.LockOutput are cached as well, since their
+// locks expire with time, and are skipped as they are visited.
+type utxoCache struct {
+	mtx        sync.Mutex
+	generation uint64
+	valid      bool
+	tooLarge   bool
+	entries    []utxoCacheEntry
+}
+
+// invalidate drops the cached outputs.  It must be called by every update of
+// the transaction store which changes the set of unspent outputs, within the
+// same database transaction.
+func (c *utxoCache) invalidate() {
+	c.mtx.Lock()
+	c.generation++
+	c.valid = false
+	c.tooLarge = false
+	c.entries = nil
+	c.mtx.Unlock()
+}
+
+// load returns the cached outputs, reading them from the database first if the
+// cache is not valid.  It returns false if there are more than size outputs.
+func (c *utxoCache) load(store *wtxmgr.Store, ns walletdb.ReadBucket,
+	size int) ([]utxoCacheEntry, bool, er.R) {
+
+	c.mtx.Lock()
+	generation, valid, tooLarge := c.generation, c.valid, c.tooLarge
+	entries := c.entries
+	c.mtx.Unlock()
+	if tooLarge {
+		return nil, false, nil
+	}
+	if valid {
+		return entries, true, nil
+	}
+
+	// The lock is not held while reading, an invalidation in the meantime
+	// leaves the outputs which were read uncached.
+	entries = nil
+	tooLarge = false
+	err := store.ForEachUnspentOutputIncludingLocked(ns, nil,
+		func(key []byte, credit *wtxmgr.Credit) er.R {
+			if len(entries) == size {
+				tooLarge = true
+				return er.LoopBreak
+			}
+			entries = append(entries, utxoCacheEntry{
+				key:    append([]byte(nil), key...),
+				credit: *credit,
+			})
+			return nil
+		})
+	if err != nil && !er.IsLoopBreak(err) {
+		return nil, false, err
+	}
+	if tooLarge {
+		log.Debugf("More than [%d] unspent outputs, not caching them", size)
+		entries = nil
+	}
+
+	c.mtx.Lock()
+	if c.generation == generation {
+		c.valid = !tooLarge
+		c.tooLarge = tooLarge
+		c.entries = entries
+	}
+	c.mtx.Unlock()
+	return entries, !tooLarge, nil
+}
+
+// forEachUnspentOutput visits each unspent output of the wallet as
+// wtxmgr.Store.ForEachUnspentOutput does, from the cache of unspent outputs
+// if it is enabled and the outputs fit in it.
+func (w *Wallet) forEachUnspentOutput(ns walletdb.ReadBucket,
+	visitor func(key []byte, c *wtxmgr.Credit) er.R) er.R {
+
+	if w.cfg.UTXOCacheSize <= 0 {
+		return w.TxStore.ForEachUnspentOutput(ns, nil, visitor)
+	}
+	entries, ok, err := w.utxoCache.load(w.TxStore, ns, w.cfg.UTXOCacheSize)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return w.TxStore.ForEachUnspentOutput(ns, nil, visitor)
+	}
+	for i := range entries {
+		e := &entries[i]
+		if w.TxStore.IsLockedOutput(ns, e.credit.OutPoint) {
+			continue
+		}
+		// The visitor gets its own copy, which it may keep.
+		credit := e.credit
+		if err := visitor(e.key, &credit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
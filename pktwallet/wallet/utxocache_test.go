@@ -0,0 +1,235 @@
+package wallet
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/wallet/enough"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// countingBucket counts the keys read from a database bucket and the buckets
+// nested in it.
+type countingBucket struct {
+	walletdb.ReadBucket
+	reads *int
+}
+
+func (b countingBucket) NestedReadBucket(key []byte) walletdb.ReadBucket {
+	*b.reads++
+	nested := b.ReadBucket.NestedReadBucket(key)
+	if nested == nil {
+		return nil
+	}
+	return countingBucket{nested, b.reads}
+}
+
+func (b countingBucket) ForEach(f func(k, v []byte) er.R) er.R {
+	return b.ReadBucket.ForEach(func(k, v []byte) er.R {
+		*b.reads++
+		return f(k, v)
+	})
+}
+
+func (b countingBucket) ForEachBeginningWith(start []byte,
+	f func(k, v []byte) er.R) er.R {
+
+	return b.ReadBucket.ForEachBeginningWith(start, func(k, v []byte) er.R {
+		*b.reads++
+		return f(k, v)
+	})
+}
+
+func (b countingBucket) Get(key []byte) []byte {
+	*b.reads++
+	return b.ReadBucket.Get(key)
+}
+
+func (b countingBucket) ReadCursor() walletdb.ReadCursor {
+	return countingCursor{b.ReadBucket.ReadCursor(), b.reads}
+}
+
+type countingCursor struct {
+	walletdb.ReadCursor
+	reads *int
+}
+
+func (c countingCursor) First() ([]byte, []byte) {
+	*c.reads++
+	return c.ReadCursor.First()
+}
+
+func (c countingCursor) Last() ([]byte, []byte) {
+	*c.reads++
+	return c.ReadCursor.Last()
+}
+
+func (c countingCursor) Next() ([]byte, []byte) {
+	*c.reads++
+	return c.ReadCursor.Next()
+}
+
+func (c countingCursor) Prev() ([]byte, []byte) {
+	*c.reads++
+	return c.ReadCursor.Prev()
+}
+
+func (c countingCursor) Seek(seek []byte) ([]byte, []byte) {
+	*c.reads++
+	return c.ReadCursor.Seek(seek)
+}
+
+// TestUTXOCache checks that coin selection finds the same outputs with the
+// cache of unspent outputs as without it, reading fewer keys from the
+// database, and that spends and new outputs are seen by the cache.
+func TestUTXOCache(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+	addr, err := w.CurrentAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get current address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+	addTx := func(tx *wire.MsgTx, block *wtxmgr.BlockMeta) {
+		t.Helper()
+		rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+		if err != nil {
+			t.Fatalf("unable to create tx record: %v", err)
+		}
+		err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+			return w.addRelevantTx(dbtx, rec, block)
+		})
+		if err != nil {
+			t.Fatalf("unable to add tx: %v", err)
+		}
+	}
+	block := &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: *testBlockHash, Height: testBlockHeight},
+		Time:  time.Unix(1387737310, 0),
+	}
+	var received []*wire.MsgTx
+	for i := 0; i < 20; i++ {
+		tx := &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(int64(i+1)*1e6, pkScript)},
+		}
+		addTx(tx, block)
+		received = append(received, tx)
+	}
+
+	// selectAll returns the outpoints of all outputs eligible for coin
+	// selection.
+	selectAll := func() []wire.OutPoint {
+		t.Helper()
+		bs, err := w.chainClient.BlockStamp()
+		if err != nil {
+			t.Fatalf("unable to get blockstamp: %v", err)
+		}
+		isEnough := enough.MkIsEnough(
+			[]*wire.TxOut{wire.NewTxOut(1e12, pkScript)}, 1000)
+		var out eligibleOutputs
+		err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+			var err er.R
+			out, _, err = w.findEligibleOutputs(dbtx, isEnough, nil, 0,
+				bs, 0, nil, -1)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("findEligibleOutputs failed: %v", err)
+		}
+		ops := make([]wire.OutPoint, 0, len(out.credits))
+		for _, c := range out.credits {
+			ops = append(ops, c.OutPoint)
+		}
+		sort.Slice(ops, func(i, j int) bool {
+			return ops[i].String() < ops[j].String()
+		})
+		return ops
+	}
+	// countReads returns the number of keys read from the database to
+	// visit the unspent outputs.
+	countReads := func() int {
+		t.Helper()
+		reads := 0
+		err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+			ns := countingBucket{dbtx.ReadBucket(wtxmgrNamespaceKey), &reads}
+			return w.forEachUnspentOutput(ns,
+				func([]byte, *wtxmgr.Credit) er.R { return nil })
+		})
+		if err != nil {
+			t.Fatalf("unable to visit unspent outputs: %v", err)
+		}
+		return reads
+	}
+	// check compares coin selection with and without the cache.
+	check := func(want int) {
+		t.Helper()
+		w.cfg.UTXOCacheSize = 0
+		uncached := selectAll()
+		uncachedReads := countReads()
+		w.cfg.UTXOCacheSize = 100
+		for i := 0; i < 2; i++ {
+			cached := selectAll()
+			if len(cached) != want || len(uncached) != want {
+				t.Fatalf("selected %d outputs with the cache and %d "+
+					"without, want %d", len(cached), len(uncached), want)
+			}
+			for i := range cached {
+				if cached[i] != uncached[i] {
+					t.Fatalf("selected %v with the cache, %v without",
+						cached[i], uncached[i])
+				}
+			}
+		}
+		if cachedReads := countReads(); cachedReads >= uncachedReads {
+			t.Fatalf("read %d keys with the cache, %d without",
+				cachedReads, uncachedReads)
+		}
+	}
+	check(20)
+
+	// Spending an output and receiving change replaces it in the cache.
+	spend := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: received[0].TxHash()},
+		}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(5e5, pkScript)},
+	}
+	addTx(spend, nil)
+	check(20)
+	for _, op := range selectAll() {
+		if op.Hash == received[0].TxHash() {
+			t.Fatalf("spent output %v is still selected", op)
+		}
+	}
+
+	// A new output is added to it.
+	addTx(&wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, pkScript)},
+	}, block)
+	check(21)
+
+	// Locked outputs are skipped although they are cached.
+	op := wire.OutPoint{Hash: received[1].TxHash()}
+	if _, err := w.LeaseOutput(wtxmgr.LockID{1}, op); err != nil {
+		t.Fatalf("unable to lock output: %v", err)
+	}
+	check(20)
+
+	// With more outputs than fit in the cache they are read from the
+	// database.
+	w.cfg.UTXOCacheSize = 5
+	if n := len(selectAll()); n != 20 {
+		t.Fatalf("selected %d outputs with a cache too small, want 20", n)
+	}
+}
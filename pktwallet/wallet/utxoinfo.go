@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// UtxoInfo describes an output and, if the wallet owns it, the origin of the
+// key which can spend it.
+type UtxoInfo struct {
+	OutPoint wire.OutPoint
+
+	// Known is false if the transaction is not known to the wallet, in
+	// which case none of the other fields are set.
+	Known    bool
+	Amount   btcutil.Amount
+	PkScript []byte
+
+	// Address is the address paid by the output, it is nil if the script
+	// does not pay to an address.
+	Address btcutil.Address
+
+	// Owned is true if the wallet holds the key for Address.
+	Owned bool
+
+	// HasKeyOrigin is true if the key of an owned output was derived from
+	// the wallet's seed, rather than imported, in which case KeyScope and
+	// Path give its derivation and MasterFingerprint identifies the root
+	// of the derivation.
+	HasKeyOrigin      bool
+	KeyScope          waddrmgr.KeyScope
+	Path              waddrmgr.DerivationPath
+	MasterFingerprint uint32
+}
+
+// UtxoInfo looks up an output of a wallet transaction and the key origin of
+// the address which it pays, which an external signer needs to sign a spend
+// of it.  Outputs of transactions which the wallet does not know, or which pay
+// addresses that the wallet does not own, are not an error but are reported
+// as not known or not owned.
+func (w *Wallet) UtxoInfo(op wire.OutPoint) (*UtxoInfo, er.R) {
+	info := &UtxoInfo{OutPoint: op}
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+
+		details, err := w.TxStore.TxDetails(txmgrNs, &op.Hash)
+		if err != nil {
+			return err
+		}
+		if details == nil || int(op.Index) >= len(details.MsgTx.TxOut) {
+			return nil
+		}
+		txOut := details.MsgTx.TxOut[op.Index]
+		info.Known = true
+		info.Amount = btcutil.Amount(txOut.Value)
+		info.PkScript = txOut.PkScript
+
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript,
+			w.chainParams)
+		if err != nil || len(addrs) == 0 {
+			return nil
+		}
+		info.Address = addrs[0]
+		for _, addr := range addrs {
+			ma, err := w.Manager.Address(addrmgrNs, addr)
+			if err != nil {
+				continue
+			}
+			info.Address = addr
+			info.Owned = true
+			mpka, ok := ma.(waddrmgr.ManagedPubKeyAddress)
+			if !ok {
+				break
+			}
+			info.KeyScope, info.Path, info.HasKeyOrigin = mpka.DerivationInfo()
+			break
+		}
+		if !info.HasKeyOrigin {
+			return nil
+		}
+		// Without the master HD public key, as in some watching-only
+		// wallets, the origin of the key cannot be given.
+		fingerprint, err := w.Manager.MasterFingerprint(addrmgrNs)
+		if waddrmgr.ErrWatchingOnly.Is(err) {
+			info.HasKeyOrigin = false
+			return nil
+		} else if err != nil {
+			return err
+		}
+		info.MasterFingerprint = fingerprint
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/hdkeychain"
+	"github.com/pkt-cash/pktd/chaincfg"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// TestUtxoInfo ensures that the key origin of an owned output matches the key
+// derived from the wallet's seed, and that outputs which are not owned or not
+// known are reported as such.
+func TestUtxoInfo(t *testing.T) {
+	params := &chaincfg.TestNet3Params
+	seed := bytes.Repeat([]byte{0x2a}, hdkeychain.RecommendedSeedLen)
+	w, cleanup := testWalletWithSeed(t, params, seed)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := btcutil.NewAddressWitnessPubKeyHash(
+		bytes.Repeat([]byte{0x01}, 20), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherScript, err := txscript.PayToAddrScript(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{
+			wire.NewTxOut(1e8, pkScript),
+			wire.NewTxOut(2e8, otherScript),
+		},
+	}
+	insertTestTx(t, w, tx, 1, 0)
+
+	info, err := w.UtxoInfo(wire.OutPoint{Hash: tx.TxHash(), Index: 0})
+	if err != nil {
+		t.Fatalf("unable to get utxo info: %v", err)
+	}
+	if !info.Known || !info.Owned || !info.HasKeyOrigin ||
+		info.Amount != 1e8 || info.Address.EncodeAddress() != addr.EncodeAddress() {
+		t.Fatalf("got info %+v for owned output", info)
+	}
+	if info.KeyScope != waddrmgr.KeyScopeBIP0084 ||
+		info.Path != (waddrmgr.DerivationPath{}) {
+		t.Fatalf("got scope %v and path %+v, want the first external "+
+			"key of account 0", info.KeyScope, info.Path)
+	}
+	derived, err := waddrmgr.DeriveAddresses(seed, info.KeyScope,
+		info.Path.Account, 1, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if derived[0].Address != addr.EncodeAddress() {
+		t.Fatalf("key origin derives %s, want %s", derived[0].Address, addr)
+	}
+	master, err := hdkeychain.NewMaster(seed, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	masterPub, err := master.ECPubKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint := binary.BigEndian.Uint32(
+		btcutil.Hash160(masterPub.SerializeCompressed())[:4])
+	if info.MasterFingerprint != fingerprint {
+		t.Fatalf("got master fingerprint %08x, want %08x",
+			info.MasterFingerprint, fingerprint)
+	}
+
+	info, err = w.UtxoInfo(wire.OutPoint{Hash: tx.TxHash(), Index: 1})
+	if err != nil {
+		t.Fatalf("unable to get utxo info: %v", err)
+	}
+	if !info.Known || info.Owned || info.HasKeyOrigin || info.Address == nil {
+		t.Fatalf("got info %+v for an output which is not owned", info)
+	}
+
+	info, err = w.UtxoInfo(wire.OutPoint{Index: 5})
+	if err != nil {
+		t.Fatalf("unable to get utxo info: %v", err)
+	}
+	if info.Known || info.Owned {
+		t.Fatalf("got info %+v for an unknown transaction", info)
+	}
+}
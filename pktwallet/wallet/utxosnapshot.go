@@ -0,0 +1,116 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// SnapshotOutput is a spendable output in a snapshot of the wallet's UTXO set.
+type SnapshotOutput struct {
+	OutPoint      wire.OutPoint
+	Amount        btcutil.Amount
+	PkScript      []byte
+	ScriptClass   txscript.ScriptClass
+	Confirmations int32
+
+	// Address is nil if the script does not pay to exactly one address.
+	Address btcutil.Address
+}
+
+// snapshotPageSize is the most outputs which ForEachSnapshotOutput reads in
+// one database transaction.
+const snapshotPageSize = 1000
+
+// ForEachSnapshotOutput calls f with each of the wallet's spendable outputs,
+// skipping locked or frozen outputs, quarantined dust, outputs spent by
+// unmined transactions and immature coinbase outputs.  The outputs are read in
+// pages, each in its own database transaction, and f is only called once the
+// transaction of a page is closed so that a slow f, such as one writing out a
+// UTXO set of any size to a client, does not hold the database open.  The
+// outputs are therefore not a single consistent snapshot, one which is spent
+// or received while they are visited may or may not be visited.
+func (w *Wallet) ForEachSnapshotOutput(f func(*SnapshotOutput) er.R) er.R {
+	return w.forEachSnapshotOutput(snapshotPageSize, f)
+}
+
+func (w *Wallet) forEachSnapshotOutput(pageSize int, f func(*SnapshotOutput) er.R) er.R {
+	var beginKey []byte
+	for {
+		page, next, err := w.snapshotPage(beginKey, pageSize)
+		if err != nil {
+			return err
+		}
+		for i := range page {
+			if err := f(&page[i]); err != nil {
+				return err
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		beginKey = next
+	}
+}
+
+// snapshotPage reads the spendable outputs of the wallet from beginKey, up to
+// pageSize mined outputs.  It returns the key to read the next page from, or
+// nil if this is the last page.  The unmined outputs are visited after the
+// last mined output whatever the beginKey, so they all go in the last page.
+func (w *Wallet) snapshotPage(beginKey []byte, pageSize int) ([]SnapshotOutput, []byte, er.R) {
+	var (
+		page []SnapshotOutput
+		next []byte
+	)
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		syncHeight := w.Manager.SyncedTo().Height
+		maturity := int32(w.chainParams.CoinbaseMaturity)
+		mined := 0
+		return w.TxStore.ForEachUnspentOutput(txmgrNs, beginKey,
+			func(k []byte, c *wtxmgr.Credit) er.R {
+				if c.Height >= 0 {
+					// The page is full, the next one begins
+					// with this output.
+					if mined == pageSize {
+						next = append([]byte(nil), k...)
+						return er.LoopBreak
+					}
+					mined++
+				}
+				if c.FromCoinBase && !confirmed(maturity, c.Height, syncHeight) {
+					return nil
+				}
+				if w.LockedOutpoint(c.OutPoint) ||
+					w.TxStore.IsFrozenScript(txmgrNs, c.PkScript) {
+					return nil
+				}
+				if dust, err := w.isIncomingDust(txmgrNs, c); err != nil || dust {
+					return err
+				}
+				out := SnapshotOutput{
+					OutPoint:      c.OutPoint,
+					Amount:        c.Amount,
+					PkScript:      append([]byte(nil), c.PkScript...),
+					Confirmations: confirms(c.Height, syncHeight),
+				}
+				class, addrs, _, err := txscript.ExtractPkScriptAddrs(
+					c.PkScript, w.chainParams)
+				if err == nil {
+					out.ScriptClass = class
+					if len(addrs) == 1 {
+						out.Address = addrs[0]
+					}
+				}
+				page = append(page, out)
+				return nil
+			})
+	})
+	if err != nil && !er.IsLoopBreak(err) {
+		return nil, nil, err
+	}
+	return page, next, nil
+}
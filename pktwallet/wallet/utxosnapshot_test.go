@@ -0,0 +1,103 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
+	"github.com/pkt-cash/pktd/wire/constants"
+)
+
+// TestForEachSnapshotOutput seeds spendable, spent, locked and immature
+// outputs and checks that exactly the spendable ones are visited.
+func TestForEachSnapshotOutput(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	setSyncedTo(t, w, 100)
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newTx := func(index uint32, values ...int64) *wire.MsgTx {
+		tx := &wire.MsgTx{
+			TxIn: []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Index: index}}},
+		}
+		for _, v := range values {
+			tx.TxOut = append(tx.TxOut, wire.NewTxOut(v, pkScript))
+		}
+		return tx
+	}
+
+	// Spendable outputs, mined and unmined.
+	mined := newTx(1, 1e8, 2e8)
+	insertTestTx(t, w, mined, 90, 0, 1)
+	unmined := newTx(2, 3e8)
+	insertTestTx(t, w, unmined, -1, 0)
+
+	// An output spent by an unmined transaction.
+	spent := newTx(3, 4e8)
+	insertTestTx(t, w, spent, 95, 0)
+	spend := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: spent.TxHash()}}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+	}
+	insertTestTx(t, w, spend, -1)
+
+	// A locked output.
+	locked := newTx(4, 5e8)
+	insertTestTx(t, w, locked, 80, 0)
+	w.LockOutpoint(wire.OutPoint{Hash: locked.TxHash()}, "test")
+
+	// An immature coinbase output.
+	coinbase := newTx(constants.MaxPrevOutIndex, 6e8)
+	insertTestTx(t, w, coinbase, 99, 0)
+
+	want := map[wire.OutPoint]int32{
+		{Hash: mined.TxHash(), Index: 0}:   11,
+		{Hash: mined.TxHash(), Index: 1}:   11,
+		{Hash: unmined.TxHash(), Index: 0}: 0,
+	}
+
+	// The outputs are the same however many are read in each database
+	// transaction.
+	for _, pageSize := range []int{snapshotPageSize, 1, 2} {
+		got := make(map[wire.OutPoint]int32)
+		err = w.forEachSnapshotOutput(pageSize, func(out *SnapshotOutput) er.R {
+			if _, ok := got[out.OutPoint]; ok {
+				t.Fatalf("output %v visited twice", out.OutPoint)
+			}
+			got[out.OutPoint] = out.Confirmations
+			if out.Address == nil || out.Address.EncodeAddress() != addr.EncodeAddress() {
+				t.Fatalf("got address %v for output %v, want %v", out.Address,
+					out.OutPoint, addr)
+			}
+			if out.ScriptClass != txscript.WitnessV0PubKeyHashTy {
+				t.Fatalf("got script class %v for output %v", out.ScriptClass,
+					out.OutPoint)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("page size %d: unable to iterate outputs: %v",
+				pageSize, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("page size %d: got %d outputs, want %d: %v",
+				pageSize, len(got), len(want), got)
+		}
+		for op, confs := range want {
+			if c, ok := got[op]; !ok || c != confs {
+				t.Fatalf("output %v: got %d confirmations (found %v), want %d",
+					op, c, ok, confs)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// VerifyWallet checks the wallet database for inconsistencies and returns a
+// description of each one found.  An empty report means that the wallet is
+// consistent.  See wtxmgr.Store.Verify for the checks made on the transaction
+// store, additionally the block which the wallet is synced to must be recorded
+// in the address manager.
+func (w *Wallet) VerifyWallet() ([]string, er.R) {
+	var report []string
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+
+		st := w.Manager.SyncedTo()
+		hash, err := w.Manager.BlockHash(addrmgrNs, st.Height)
+		if waddrmgr.ErrBlockNotFound.Is(err) {
+			report = append(report, fmt.Sprintf("synced to block %v @ %d "+
+				"is not recorded", st.Hash, st.Height))
+		} else if err != nil {
+			return err
+		} else if *hash != st.Hash {
+			report = append(report, fmt.Sprintf("synced to block %v @ %d "+
+				"but block %v is recorded at that height", st.Hash,
+				st.Height, hash))
+		}
+
+		txReport, err := w.TxStore.Verify(txmgrNs)
+		report = append(report, txReport...)
+		return err
+	})
+	return report, err
+}
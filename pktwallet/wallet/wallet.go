@@ -99,19 +99,46 @@ type Wallet struct {
 	Manager *waddrmgr.Manager
 	TxStore *wtxmgr.Store
 
+	// utxoCache holds the unspent outputs for coin selection when
+	// UTXOCacheSize is set.
+	utxoCache utxoCache
+
 	chainClient        chain.Interface
 	chainClientLock    sync.Mutex
 	chainClientSynced  bool
 	chainClientSyncMtx sync.Mutex
 
+	maintenance    bool
+	maintenanceMtx sync.Mutex
+
 	lockedOutpoints    map[wire.OutPoint]string
 	lockedOutpointsMtx sync.Mutex
 
 	recoveryWindow uint32
 
+	// The last time that unmined transactions were checked for expiry.
+	lastMempoolExpiry time.Time
+
+	// When replaceable unmined transactions were first seen and whether
+	// they are automatic replacements, and the height of the last check,
+	// for automatic fee bumping.
+	autoBumpSeen   map[chainhash.Hash]autoBumpState
+	autoBumpHeight int32
+
+	// Transactions which were recently rejected by the backend.
+	rejectedTxs rejectedTxs
+
+	// The last fee rate estimated by pktd and the time it changed to it.
+	pktdFeeRate    btcutil.Amount
+	pktdFeeUpdated time.Time
+	pktdFeeMtx     sync.Mutex
+
 	// Channel for transaction creation requests.
 	createTxRequests chan createTxRequest
 
+	// Blocks for which the BlockNotify command is yet to be run.
+	blockNotifyRequests chan chainhash.Hash
+
 	// Channels for the manager locker.
 	unlockRequests     chan unlockRequest
 	lockRequests       chan struct{}
@@ -124,6 +151,7 @@ type Wallet struct {
 	NtfnServer *NotificationServer
 
 	chainParams *chaincfg.Params
+	cfg         Config
 	wg          sync.WaitGroup
 
 	started bool
@@ -137,6 +165,8 @@ type Wallet struct {
 
 	rescanJLock sync.Mutex
 	rescanJ     *rescanJob
+
+	recovery recoveryTracker
 }
 
 type rescanJob struct {
@@ -145,6 +175,10 @@ type rescanJob struct {
 	stopHeight int32
 	name       string
 	dropDb     bool
+
+	// addresses are the only addresses which are rescanned, nil if the
+	// job rescans all of the wallet's addresses.
+	addresses []string
 }
 
 type CoinbaseSelector int
@@ -180,6 +214,10 @@ func (w *Wallet) Start() {
 	w.wg.Add(2)
 	go w.txCreator()
 	go w.walletLocker()
+	if w.cfg.BlockNotify != "" {
+		w.wg.Add(1)
+		go w.blockNotifier()
+	}
 }
 
 // SynchronizeRPC associates the wallet with the consensus RPC client,
@@ -341,6 +379,27 @@ func (w *Wallet) SetChainSynced(synced bool) {
 	w.chainClientSyncMtx.Unlock()
 }
 
+// MaintenanceMode returns whether the wallet is in maintenance mode, in which
+// RPCs which move funds are refused.
+func (w *Wallet) MaintenanceMode() bool {
+	w.maintenanceMtx.Lock()
+	maintenance := w.maintenance
+	w.maintenanceMtx.Unlock()
+	return maintenance
+}
+
+// SetMaintenanceMode turns maintenance mode on or off.
+func (w *Wallet) SetMaintenanceMode(maintenance bool) {
+	w.maintenanceMtx.Lock()
+	w.maintenance = maintenance
+	w.maintenanceMtx.Unlock()
+	if maintenance {
+		log.Infof("Maintenance mode enabled, funds cannot be moved")
+	} else {
+		log.Infof("Maintenance mode disabled")
+	}
+}
+
 // activeData returns the currently-active receiving addresses and all unspent
 // outputs.  This is primarely intended to provide the parameters for a
 // rescan request.
@@ -391,6 +450,7 @@ func (w *Wallet) syncWithChain(birthdayStamp *waddrmgr.BlockStamp) er.R {
 		if txns, err := w.TxStore.UnminedTxs(b); err != nil {
 			return err
 		} else {
+			w.utxoCache.invalidate()
 			for _, tx := range txns {
 				log.Debugf("Dropping unconfirmed tx [%s] from db", tx.TxHash().String())
 				if txRec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now()); err != nil {
@@ -641,6 +701,22 @@ type (
 		InputComparator utils.Comparator
 		MaxInputs       int
 		Label           string
+
+		// InputOutPoints, if not nil, are the only outputs which are
+		// spent, all of them, rather than choosing inputs from the
+		// wallet's unspent outputs.  InputAddresses, Minconf,
+		// InputMinHeight, InputComparator and MaxInputs are then
+		// ignored.
+		InputOutPoints []wire.OutPoint
+
+		// AllowSelfSend allows outputs paying addresses of the wallet
+		// when WarnSelfSend is set.
+		AllowSelfSend bool
+
+		// Comment and CommentTo are recorded in the wallet once the
+		// transaction is broadcast, they are not part of it.
+		Comment   string
+		CommentTo string
 	}
 	createTxRequest struct {
 		req  CreateTxReq
@@ -719,6 +795,10 @@ type (
 		oldPass, newPass []byte
 		private          bool
 		err              chan er.R
+
+		// scrypt is the parameters to derive the new passphrase key
+		// with, the defaults if nil.
+		scrypt *waddrmgr.ScryptOptions
 	}
 
 	changePassphrasesRequest struct {
@@ -758,6 +838,9 @@ out:
 				req.err <- err
 				continue
 			}
+			if err := w.createExtraKeyScopes(); err != nil {
+				log.Errorf("Unable to create key scopes: %v", err)
+			}
 			timeout = req.lockAfter
 			if timeout == nil {
 				log.Info("The wallet has been unlocked without a time limit")
@@ -768,11 +851,15 @@ out:
 			continue
 
 		case req := <-w.changePassphrase:
+			scrypt := req.scrypt
+			if scrypt == nil {
+				scrypt = &waddrmgr.DefaultScryptOptions
+			}
 			err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
 				addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
 				return w.Manager.ChangePassphrase(
 					addrmgrNs, req.oldPass, req.newPass, req.private,
-					&waddrmgr.DefaultScryptOptions,
+					scrypt,
 				)
 			})
 			req.err <- err
@@ -989,25 +1076,113 @@ func (w *Wallet) GetSecret(name string) (*string, er.R) {
 // a UTXO must be in a block.  If confirmations is 1 or greater,
 // the balance will be calculated based on how many how many blocks
 // include a UTXO.
+//
+// Coins which have fewer than Config.FinalityDepth confirmations and coins
+// paid to watch only addresses are left out of the balance, see
+// CalculateWalletBalance.
 func (w *Wallet) CalculateBalance(confirms int32) (btcutil.Amount, er.R) {
-	var balance btcutil.Amount
+	bal, err := w.CalculateWalletBalance(confirms)
+	return bal.Balance, err
+}
+
+// WalletBalance is the balance of a wallet, as returned by CalculateBalance,
+// along with the amounts which are counted apart from it.  Maturing is the
+// amount of the coins which have enough confirmations to be counted but fewer
+// than Config.FinalityDepth, so may yet be undone by a reorg.  WatchOnly is
+// the amount paid to watch only taproot addresses, which cannot yet be spent.
+type WalletBalance struct {
+	Balance   btcutil.Amount
+	Maturing  btcutil.Amount
+	WatchOnly btcutil.Amount
+}
+
+// CalculateWalletBalance works as CalculateBalance but also returns the
+// maturing and watch only amounts which are not counted in the balance.
+func (w *Wallet) CalculateWalletBalance(confirms int32) (WalletBalance, er.R) {
+	var bal WalletBalance
 	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
 		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
-		var err er.R
 		blk := w.Manager.SyncedTo()
-		balance, err = w.TxStore.Balance(txmgrNs, confirms, blk.Height)
-		return err
+		distrust := confirms == 0 && w.cfg.DistrustReplaceable
+		coinbaseMaturity := int32(w.chainParams.CoinbaseMaturity)
+		return w.TxStore.ForEachUnspentOutput(txmgrNs, nil,
+			func(_ []byte, output *wtxmgr.Credit) er.R {
+				// Frozen outputs cannot be spent.
+				if w.TxStore.IsFrozenScript(txmgrNs, output.PkScript) ||
+					!confirmed(confirms, output.Height, blk.Height) ||
+					output.FromCoinBase && !confirmed(coinbaseMaturity,
+						output.Height, blk.Height) {
+
+					return nil
+				}
+				if txscript.GetScriptClass(output.PkScript) ==
+					txscript.WitnessV1TaprootTy {
+
+					bal.WatchOnly += output.Amount
+					return nil
+				}
+				if distrust && output.Replaceable {
+					return nil
+				}
+				if dust, err := w.isIncomingDust(txmgrNs, output); err != nil || dust {
+					return err
+				}
+				if w.cfg.FinalityDepth > confirms && !confirmed(
+					w.cfg.FinalityDepth, output.Height, blk.Height) {
+
+					bal.Maturing += output.Amount
+				} else {
+					bal.Balance += output.Amount
+				}
+				return nil
+			})
 	})
-	return balance, err
+	return bal, err
+}
+
+// BalanceAtHeight returns the confirmed balance of the wallet as of the block at
+// height by replaying the credits and debits of every transaction mined at or
+// before it.  Heights beyond the block which the wallet is synced to give the
+// current confirmed balance, the height which was used is returned along with
+// the balance.
+func (w *Wallet) BalanceAtHeight(height int32) (btcutil.Amount, int32, er.R) {
+	if height < 0 {
+		return 0, 0, er.Errorf("invalid height [%d]", height)
+	}
+	if synced := w.Manager.SyncedTo().Height; height > synced {
+		height = synced
+	}
+	var balance btcutil.Amount
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		return w.TxStore.RangeTransactions(txmgrNs, 0, height,
+			func(details []wtxmgr.TxDetails) (bool, er.R) {
+				for _, d := range details {
+					for _, c := range d.Credits {
+						balance += c.Amount
+					}
+					for _, deb := range d.Debits {
+						balance -= deb.Amount
+					}
+				}
+				return false, nil
+			})
+	})
+	return balance, height, err
 }
 
 // Balances records total, spendable (by policy), and immature coinbase
-// reward balance amounts.
+// reward balance amounts.  Maturing is the balance which would be spendable
+// but has fewer than FinalityDepth confirmations and WatchOnly is the balance
+// of watch only taproot addresses, which cannot yet be spent.
 type Balances struct {
 	Total          btcutil.Amount
 	Spendable      btcutil.Amount
 	ImmatureReward btcutil.Amount
 	Unconfirmed    btcutil.Amount
+	Maturing       btcutil.Amount
+	WatchOnly      btcutil.Amount
+	Dust           btcutil.Amount
 	OutputCount    int32
 }
 
@@ -1045,15 +1220,28 @@ func (w *Wallet) CalculateAddressBalances(
 					bals0[string(addrs[0].ScriptAddress())] = bal
 					bals[addrs[0]] = bal
 				}
+				dust, err := w.isIncomingDust(txmgrNs, output)
+				if err != nil {
+					return err
+				}
 				bal.Total += output.Amount
 				bal.OutputCount++
-				if output.FromCoinBase && !confirmed(int32(w.chainParams.CoinbaseMaturity),
+				if txscript.GetScriptClass(output.PkScript) ==
+					txscript.WitnessV1TaprootTy {
+
+					bal.WatchOnly += output.Amount
+				} else if dust {
+					bal.Dust += output.Amount
+				} else if output.FromCoinBase && !confirmed(int32(w.chainParams.CoinbaseMaturity),
 					output.Height, syncBlock.Height) {
 					bal.ImmatureReward += output.Amount
-				} else if confirmed(confirms, output.Height, syncBlock.Height) {
-					bal.Spendable += output.Amount
-				} else {
+				} else if !confirmed(confirms, output.Height, syncBlock.Height) {
 					bal.Unconfirmed += output.Amount
+				} else if w.cfg.FinalityDepth > 0 && !confirmed(w.cfg.FinalityDepth,
+					output.Height, syncBlock.Height) {
+					bal.Maturing += output.Amount
+				} else {
+					bal.Spendable += output.Amount
 				}
 			}
 			return nil
@@ -1245,6 +1433,96 @@ func (w *Wallet) AddressInfo(a btcutil.Address) (waddrmgr.ManagedAddress, er.R)
 	return managedAddress, err
 }
 
+// ErrGapLimit is returned when marking an address used or unused would leave a
+// gap between used addresses which is larger than AddressGapLimit.
+var ErrGapLimit = Err.CodeWithDetail("ErrGapLimit",
+	"change would exceed the address gap limit")
+
+// MarkAddressUsed sets or clears the used flag of a wallet address.  Marking
+// the most recent address as used causes CurrentAddress to derive a new one,
+// while clearing the flag makes it eligible to be handed out again.  For
+// addresses which are derived from the seed, the change is refused with
+// ErrGapLimit if it would leave more than AddressGapLimit addresses between
+// two used addresses on the branch.
+func (w *Wallet) MarkAddressUsed(a btcutil.Address, used bool) er.R {
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		maddr, err := w.Manager.Address(addrmgrNs, a)
+		if err != nil {
+			return err
+		}
+		if maddr.Used(addrmgrNs) == used {
+			return nil
+		}
+		if pka, ok := maddr.(waddrmgr.ManagedPubKeyAddress); ok {
+			if scope, path, ok := pka.DerivationInfo(); ok {
+				err := w.checkGapLimit(addrmgrNs, scope, path, used)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		if used {
+			return w.Manager.MarkUsed(addrmgrNs, a)
+		}
+		return w.Manager.MarkUnused(addrmgrNs, a)
+	})
+}
+
+// checkGapLimit verifies that setting the used flag of the address at path to
+// used will not leave a gap of more than AddressGapLimit between the used
+// addresses of its branch.
+func (w *Wallet) checkGapLimit(addrmgrNs walletdb.ReadBucket,
+	scope waddrmgr.KeyScope, path waddrmgr.DerivationPath, used bool) er.R {
+
+	manager, err := w.Manager.FetchScopedKeyManager(scope)
+	if err != nil {
+		return err
+	}
+
+	// Find the closest used addresses on either side of this one, -1
+	// stands for the start of the branch.
+	prev, next := int64(-1), int64(-1)
+	err = manager.ForEachAccountAddress(addrmgrNs, path.Account,
+		func(maddr waddrmgr.ManagedAddress) er.R {
+			pka, ok := maddr.(waddrmgr.ManagedPubKeyAddress)
+			if !ok {
+				return nil
+			}
+			_, p, ok := pka.DerivationInfo()
+			if !ok || p.Branch != path.Branch || p.Index == path.Index {
+				return nil
+			}
+			if !maddr.Used(addrmgrNs) {
+				return nil
+			}
+			idx := int64(p.Index)
+			if idx < int64(path.Index) && idx > prev {
+				prev = idx
+			} else if idx > int64(path.Index) && (next < 0 || idx < next) {
+				next = idx
+			}
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	limit := int64(w.cfg.AddressGapLimit)
+	if used {
+		if int64(path.Index)-prev > limit {
+			return ErrGapLimit.New(fmt.Sprintf("address index [%d] is more "+
+				"than [%d] beyond the previous used address", path.Index,
+				w.cfg.AddressGapLimit), nil)
+		}
+	} else if next >= 0 && next-prev > limit {
+		return ErrGapLimit.New(fmt.Sprintf("used address at index [%d] "+
+			"would be more than [%d] beyond the previous used address",
+			next, w.cfg.AddressGapLimit), nil)
+	}
+	return nil
+}
+
 // AccountName returns the name of an account.
 func (w *Wallet) AccountName(scope waddrmgr.KeyScope, accountNumber uint32) (string, er.R) {
 	manager, err := w.Manager.FetchScopedKeyManager(scope)
@@ -1262,6 +1540,204 @@ func (w *Wallet) AccountName(scope waddrmgr.KeyScope, accountNumber uint32) (str
 	return accountName, err
 }
 
+// NextAccount creates a new account named name in each of the default key
+// scopes, so that the account has the same number in all of them, and
+// returns its number.  If addrScope is non-nil then it is the key scope, and
+// so the address type, of addresses created for the account by default.
+func (w *Wallet) NextAccount(name string, addrScope *waddrmgr.KeyScope) (uint32, er.R) {
+	var account uint32
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		created := false
+		for _, scope := range waddrmgr.DefaultKeyScopes {
+			manager, err := w.Manager.FetchScopedKeyManager(scope)
+			if waddrmgr.ErrScopeNotFound.Is(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			n, err := manager.NewAccount(addrmgrNs, name)
+			if err != nil {
+				return err
+			}
+			if created && n != account {
+				return er.Errorf("account [%s] would be number [%d] in "+
+					"scope [%v] but [%d] in other scopes", name, n, scope,
+					account)
+			}
+			account, created = n, true
+		}
+		if !created {
+			return er.New("wallet has no key scopes to create accounts in")
+		}
+		if addrScope == nil {
+			return nil
+		}
+		return w.Manager.SetAccountAddrScope(addrmgrNs, account, *addrScope)
+	})
+	return account, err
+}
+
+// AccountNumber returns the number of the account named name.
+func (w *Wallet) AccountNumber(name string) (uint32, er.R) {
+	manager, err := w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		return 0, err
+	}
+	var account uint32
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		var err er.R
+		account, err = manager.LookupAccount(tx.ReadBucket(waddrmgrNamespaceKey), name)
+		return err
+	})
+	return account, err
+}
+
+// AccountAddressScope returns the key scope of addresses created for an
+// account by default, this is segwit (BIP0084) unless another scope was given
+// when the account was created.
+func (w *Wallet) AccountAddressScope(account uint32) (waddrmgr.KeyScope, er.R) {
+	scope := waddrmgr.KeyScopeBIP0084
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		addrScope, err := w.Manager.AccountAddrScope(
+			tx.ReadBucket(waddrmgrNamespaceKey), account)
+		if addrScope != nil {
+			scope = *addrScope
+		}
+		return err
+	})
+	return scope, err
+}
+
+// AccountXpub is the extended public key of an account within one key scope.
+type AccountXpub struct {
+	Scope       waddrmgr.KeyScope
+	AddressType waddrmgr.AddressType
+	Xpub        *hdkeychain.ExtendedKey
+}
+
+// AccountXpubs returns the extended public keys of an account for each of the
+// default key scopes.  If slip132 is set then each key is encoded with the
+// SLIP-0132 version bytes which match the external address type of its scope
+// (ypub/zpub style), and scopes whose address type has no SLIP-0132 version on
+// the wallet's network, such as the segwit scopes of PKT, are left out.
+// Otherwise the network's standard version is used.
+func (w *Wallet) AccountXpubs(account uint32, slip132 bool) ([]AccountXpub, er.R) {
+	var out []AccountXpub
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		for _, scope := range waddrmgr.DefaultKeyScopes {
+			manager, err := w.Manager.FetchScopedKeyManager(scope)
+			if waddrmgr.ErrScopeNotFound.Is(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			props, err := manager.AccountProperties(addrmgrNs, account)
+			if err != nil {
+				return err
+			}
+			if props.AccountPubKey == nil {
+				continue
+			}
+			axp := AccountXpub{
+				Scope:       scope,
+				AddressType: manager.AddrSchema().ExternalAddrType,
+				Xpub:        props.AccountPubKey,
+			}
+			if slip132 {
+				axp.Xpub, err = waddrmgr.Slip132PubKey(
+					props.AccountPubKey, axp.AddressType, w.chainParams)
+				if waddrmgr.ErrWrongNet.Is(err) {
+					continue
+				} else if err != nil {
+					return err
+				}
+			}
+			out = append(out, axp)
+		}
+		return nil
+	})
+	return out, err
+}
+
+// AccountSummary describes an account within one key scope together with the
+// balance of its unspent outputs.
+type AccountSummary struct {
+	waddrmgr.AccountProperties
+	Scope       waddrmgr.KeyScope
+	AddressType waddrmgr.AddressType
+	Balance     btcutil.Amount
+}
+
+// ListAccounts returns a summary of every account, including the imported
+// account, of each of the default key scopes.  Only outputs with at least
+// confirms confirmations are counted in the balances, and dust quarantined by
+// Config.IgnoreIncomingDust is left out.
+func (w *Wallet) ListAccounts(confirms int32) ([]AccountSummary, er.R) {
+	type scopedAccount struct {
+		scope   waddrmgr.KeyScope
+		account uint32
+	}
+	var out []AccountSummary
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+
+		index := make(map[scopedAccount]int)
+		for _, scope := range waddrmgr.DefaultKeyScopes {
+			manager, err := w.Manager.FetchScopedKeyManager(scope)
+			if waddrmgr.ErrScopeNotFound.Is(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			if err := manager.ForEachAccount(addrmgrNs, func(account uint32) er.R {
+				props, err := manager.AccountProperties(addrmgrNs, account)
+				if err != nil {
+					return err
+				}
+				index[scopedAccount{scope, account}] = len(out)
+				out = append(out, AccountSummary{
+					AccountProperties: *props,
+					Scope:             scope,
+					AddressType:       manager.AddrSchema().ExternalAddrType,
+				})
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+
+		syncHeight := w.Manager.SyncedTo().Height
+		return w.TxStore.ForEachUnspentOutput(txmgrNs, nil,
+			func(_ []byte, output *wtxmgr.Credit) er.R {
+				if confirms > 0 && !confirmed(confirms, output.Height, syncHeight) {
+					return nil
+				}
+				if dust, err := w.isIncomingDust(txmgrNs, output); err != nil || dust {
+					return err
+				}
+				_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+					output.PkScript, w.chainParams)
+				if err != nil || len(addrs) == 0 {
+					return nil
+				}
+				manager, account, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
+				if waddrmgr.ErrAddressNotFound.Is(err) {
+					return nil
+				} else if err != nil {
+					return err
+				}
+				if i, ok := index[scopedAccount{manager.Scope(), account}]; ok {
+					out[i].Balance += output.Amount
+				}
+				return nil
+			})
+	})
+	return out, err
+}
+
 // CreditCategory describes the type of wallet transaction output.  The category
 // of "sent transactions" (debits) is always "send", and is not expressed by
 // this type.
@@ -1310,11 +1786,12 @@ func RecvCategory(details *wtxmgr.TxDetails, syncHeight int32, net *chaincfg.Par
 }
 
 // listTransactions creates a object that may be marshalled to a response result
-// for a listtransactions RPC.
+// for a listtransactions RPC.  Transactions with at least trustedConfs
+// confirmations are reported as trusted.
 //
 // TODO: This should be moved to the legacyrpc package.
 func listTransactions(tx walletdb.ReadTx, details *wtxmgr.TxDetails, addrMgr *waddrmgr.Manager,
-	syncHeight int32, net *chaincfg.Params) []btcjson.ListTransactionsResult {
+	syncHeight int32, net *chaincfg.Params, trustedConfs int32) []btcjson.ListTransactionsResult {
 
 	addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
 
@@ -1329,6 +1806,13 @@ func listTransactions(tx walletdb.ReadTx, details *wtxmgr.TxDetails, addrMgr *wa
 		confirmations = int64(confirms(details.Block.Height, syncHeight))
 	}
 
+	comment, commentTo, err := wtxmgr.FetchTxComment(
+		tx.ReadBucket(wtxmgrNamespaceKey), details.Hash)
+	if err != nil {
+		log.Warnf("Unable to read comments of [%s]: [%s]", details.Hash,
+			err.String())
+	}
+
 	results := []btcjson.ListTransactionsResult{}
 	txHashStr := details.Hash.String()
 	received := details.Received.Unix()
@@ -1402,6 +1886,7 @@ outputs:
 			Address:         address,
 			Vout:            uint32(i),
 			Confirmations:   confirmations,
+			Trusted:         confirmations >= int64(trustedConfs),
 			Generated:       generated,
 			BlockHash:       blockHashStr,
 			BlockTime:       blockTime,
@@ -1409,6 +1894,8 @@ outputs:
 			WalletConflicts: []string{},
 			Time:            received,
 			TimeReceived:    received,
+			Comment:         comment,
+			CommentTo:       commentTo,
 		}
 
 		// Add a received/generated/immature result if this is a credit.
@@ -1449,7 +1936,7 @@ func (w *Wallet) ListSinceBlock(start, end, syncHeight int32) ([]btcjson.ListTra
 		rangeFn := func(details []wtxmgr.TxDetails) (bool, er.R) {
 			for _, detail := range details {
 				jsonResults := listTransactions(tx, &detail,
-					w.Manager, syncHeight, w.chainParams)
+					w.Manager, syncHeight, w.chainParams, w.cfg.TrustedConfs)
 				txList = append(txList, jsonResults...)
 			}
 			return false, nil
@@ -1495,7 +1982,8 @@ func (w *Wallet) ListTransactions(from, count int) ([]btcjson.ListTransactionsRe
 				}
 
 				jsonResults := listTransactions(tx, &details[i],
-					w.Manager, syncBlock.Height, w.chainParams)
+					w.Manager, syncBlock.Height, w.chainParams,
+					w.cfg.TrustedConfs)
 				txList = append(txList, jsonResults...)
 
 				if len(jsonResults) > 0 {
@@ -1546,7 +2034,8 @@ func (w *Wallet) ListAddressTransactions(pkHashes map[string]struct{}) ([]btcjso
 					}
 
 					jsonResults := listTransactions(tx, detail,
-						w.Manager, syncBlock.Height, w.chainParams)
+						w.Manager, syncBlock.Height, w.chainParams,
+						w.cfg.TrustedConfs)
 					if err != nil {
 						return false, err
 					}
@@ -1581,7 +2070,7 @@ func (w *Wallet) ListAllTransactions() ([]btcjson.ListTransactionsResult, er.R)
 			// reverse order they were marked mined.
 			for i := len(details) - 1; i >= 0; i-- {
 				jsonResults := listTransactions(tx, &details[i], w.Manager,
-					syncBlock.Height, w.chainParams)
+					syncBlock.Height, w.chainParams, w.cfg.TrustedConfs)
 				txList = append(txList, jsonResults...)
 			}
 			return false, nil
@@ -1869,6 +2358,8 @@ func (w *Wallet) ListUnspent(minconf, maxconf int32,
 				spendable = true
 			case txscript.WitnessV0PubKeyHashTy:
 				spendable = true
+			case txscript.WitnessV1TaprootTy:
+				// Taproot addresses are watch only.
 			case txscript.MultiSigTy:
 				for _, a := range addrs {
 					_, err := w.Manager.Address(addrmgrNs, a)
@@ -1882,7 +2373,12 @@ func (w *Wallet) ListUnspent(minconf, maxconf int32,
 				}
 				spendable = true
 			}
-			spendable = spendable && !immature
+			frozen := w.TxStore.IsFrozenScript(txmgrNs, output.PkScript)
+			dust, err := w.isIncomingDust(txmgrNs, output)
+			if err != nil {
+				return err
+			}
+			spendable = spendable && !immature && !frozen && !dust
 
 			result := &btcjson.ListUnspentResult{
 				TxID:          output.OutPoint.Hash.String(),
@@ -1894,6 +2390,8 @@ func (w *Wallet) ListUnspent(minconf, maxconf int32,
 				Height:        int64(output.Height),
 				BlockHash:     output.Block.Hash.String(),
 				Spendable:     spendable,
+				Frozen:        frozen,
+				Dust:          dust,
 			}
 
 			// BUG: this should be a JSON array so that all
@@ -1961,6 +2459,93 @@ func (w *Wallet) ImportPrivateKey(scope waddrmgr.KeyScope, wif *btcutil.WIF,
 		return "", err
 	}
 
+	bs, err = w.importBlockStamp(bs)
+	if err != nil {
+		return "", err
+	}
+
+	// Attempt to import private key into wallet.
+	var addr btcutil.Address
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		maddr, err := manager.ImportPrivateKey(addrmgrNs, wif, bs)
+		if err != nil {
+			return err
+		}
+		addr = maddr.Address()
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	w.watchImported(addr, bs, rescan)
+
+	addrStr := addr.EncodeAddress()
+	log.Infof("Imported payment address %s", addrStr)
+
+	// Return the payment address string of the imported private key.
+	return addrStr, nil
+}
+
+// ImportTaprootAddress imports a p2tr address to the wallet, watch only, so
+// that its outputs are tracked in the imported account although they cannot
+// yet be spent.  The BIP0086 key scope is created on the first import, which
+// requires the wallet to be unlocked.
+//
+// NOTE: If a block stamp is not provided, then the address is scanned for from
+// the second block of the chain.
+func (w *Wallet) ImportTaprootAddress(addr *btcutil.AddressTaproot,
+	bs *waddrmgr.BlockStamp, rescan bool) er.R {
+
+	if !addr.IsForNet(w.chainParams) {
+		return waddrmgr.ErrWrongNet.New(fmt.Sprintf("address [%s] is not "+
+			"for %s", addr.EncodeAddress(), w.chainParams.Name), nil)
+	}
+
+	if rescan {
+		w.rescanJLock.Lock()
+		defer w.rescanJLock.Unlock()
+		if w.rescanJ != nil {
+			return er.Errorf(
+				"You requested a rescan but there is already a rescan job"+
+					" ([%v]) running, use `stopresync` to stop it", w.rescanJ.name)
+		}
+	}
+
+	bs, err := w.importBlockStamp(bs)
+	if err != nil {
+		return err
+	}
+
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		manager, err := w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0086)
+		if waddrmgr.ErrScopeNotFound.Is(err) {
+			manager, err = w.Manager.NewScopedKeyManager(addrmgrNs,
+				waddrmgr.KeyScopeBIP0086,
+				waddrmgr.ScopeAddrMap[waddrmgr.KeyScopeBIP0086])
+		}
+		if err != nil {
+			return err
+		}
+		_, err = manager.ImportTaprootAddress(addrmgrNs, addr, bs)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	w.watchImported(addr, bs, rescan)
+
+	log.Infof("Imported watch-only address %s", addr.EncodeAddress())
+	return nil
+}
+
+// importBlockStamp returns the block stamp from which an imported key or
+// address is scanned for, bs if it is given or otherwise the second block of
+// the chain.
+func (w *Wallet) importBlockStamp(bs *waddrmgr.BlockStamp) (*waddrmgr.BlockStamp, er.R) {
 	// The starting block for the key is the genesis block unless otherwise
 	// specified.
 	if bs == nil {
@@ -1968,15 +2553,15 @@ func (w *Wallet) ImportPrivateKey(scope waddrmgr.KeyScope, wif *btcutil.WIF,
 
 		secondBlockHash, err := w.chainClient.GetBlockHash(secondBlockIndex)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		secondBlockHeader, err := w.chainClient.GetBlockHeader(secondBlockHash)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		secondBlockTimestamp := secondBlockHeader.Timestamp
 
-		log.Debugf("ImportPrivateKey() [1] second block -> height: %v; hash: %v; timestamp: %v", secondBlockIndex, secondBlockHash, secondBlockTimestamp)
+		log.Debugf("importBlockStamp() [1] second block -> height: %v; hash: %v; timestamp: %v", secondBlockIndex, secondBlockHash, secondBlockTimestamp)
 
 		bs = &waddrmgr.BlockStamp{
 			Hash:      *secondBlockHash,
@@ -1991,21 +2576,14 @@ func (w *Wallet) ImportPrivateKey(scope waddrmgr.KeyScope, wif *btcutil.WIF,
 			bs.Timestamp = header.Timestamp
 		}
 	}
+	return bs, nil
+}
 
-	// Attempt to import private key into wallet.
-	var addr btcutil.Address
-	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
-		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
-		maddr, err := manager.ImportPrivateKey(addrmgrNs, wif, bs)
-		if err != nil {
-			return err
-		}
-		addr = maddr.Address()
-		return nil
-	})
-	if err != nil {
-		return "", err
-	}
+// watchImported begins watching an imported address and, if rescan is set,
+// submits a job to rescan the chain for it from bs.  When rescan is set the
+// caller must hold rescanJLock.
+func (w *Wallet) watchImported(addr btcutil.Address, bs *waddrmgr.BlockStamp,
+	rescan bool) {
 
 	// Rescan blockchain for transactions with txout scripts paying to the
 	// imported address.
@@ -2025,12 +2603,6 @@ func (w *Wallet) ImportPrivateKey(scope waddrmgr.KeyScope, wif *btcutil.WIF,
 		}
 	}
 	w.watch.WatchAddr(addr)
-
-	addrStr := addr.EncodeAddress()
-	log.Infof("Imported payment address %s", addrStr)
-
-	// Return the payment address string of the imported private key.
-	return addrStr, nil
 }
 
 // LockedOutpoint returns whether an outpoint has been marked as locked and
@@ -2174,6 +2746,10 @@ func (w *Wallet) newAddress(addrmgrNs walletdb.ReadWriteBucket, account uint32,
 		return nil, nil, err
 	}
 
+	if err := w.checkAddressLimit(manager, addrmgrNs, account); err != nil {
+		return nil, nil, err
+	}
+
 	// Get next address from wallet.
 	addrs, err := manager.NextExternalAddresses(addrmgrNs, account, 1)
 	if err != nil {
@@ -2187,7 +2763,16 @@ func (w *Wallet) newAddress(addrmgrNs walletdb.ReadWriteBucket, account uint32,
 		return nil, nil, err
 	}
 
-	return addrs[0].Address(), props, nil
+	// Never hand out an address which is not valid on the active network,
+	// for segwit addresses this means the bech32 HRP must match.
+	addr := addrs[0].Address()
+	if !addr.IsForNet(w.chainParams) {
+		return nil, nil, waddrmgr.ErrWrongNet.New(fmt.Sprintf(
+			"derived address %s is not for network %s",
+			addr, w.chainParams.Name), nil)
+	}
+
+	return addr, props, nil
 }
 
 // confirmed checks whether a transaction at height txHeight has met minconf
@@ -2255,17 +2840,28 @@ func (w *Wallet) TotalReceivedForAddr(addr btcutil.Address, minConf int32) (btcu
 	return amount, err
 }
 
-// SendOutputs creates and sends payment transactions. It returns the
-// transaction upon success.
-func (w *Wallet) SendOutputs(txr CreateTxReq) (*txauthor.AuthoredTx, er.R) {
+// ErrOutputBelowMin is returned when a send pays less than MinOutput to an
+// output.
+var ErrOutputBelowMin = Err.CodeWithDetail("ErrOutputBelowMin",
+	"output is below the minimum output value")
 
-	// Ensure the outputs to be created adhere to the network's consensus
-	// rules.
+// ErrTaprootOutput is returned when a send pays to a taproot output, which
+// anyone can spend until taproot is a consensus rule of the chain.
+var ErrTaprootOutput = Err.CodeWithDetail("ErrTaprootOutput",
+	"sending to a taproot address is not supported")
+
+// checkSendOutputs ensures that the outputs of a send adhere to the network's
+// consensus rules and to MinOutput, and that none of them pays to taproot.
+func (w *Wallet) checkSendOutputs(outputs []*wire.TxOut) er.R {
 	hasSweep := false
-	for _, output := range txr.Outputs {
+	for i, output := range outputs {
+		if txscript.GetScriptClass(output.PkScript) == txscript.WitnessV1TaprootTy {
+			return ErrTaprootOutput.New(fmt.Sprintf("output [%d] pays "+
+				"to taproot", i), nil)
+		}
 		if output.Value == 0 {
 			if hasSweep {
-				return nil, er.New("Multiple outputs with zero value, a single output with zero value " +
+				return er.New("Multiple outputs with zero value, a single output with zero value " +
 					"will sweep the address(es) to this output, multiple zero value outputs are ambiguous")
 			}
 			hasSweep = true
@@ -2275,8 +2871,33 @@ func (w *Wallet) SendOutputs(txr CreateTxReq) (*txauthor.AuthoredTx, er.R) {
 			output, txrules.DefaultRelayFeePerKb,
 		)
 		if err != nil {
-			return nil, err
+			return err
 		}
+		if btcutil.Amount(output.Value) < w.cfg.MinOutput {
+			return ErrOutputBelowMin.New(fmt.Sprintf("output [%d] pays "+
+				"[%s] which is below the minimum output value of [%s]",
+				i, btcutil.Amount(output.Value), w.cfg.MinOutput), nil)
+		}
+	}
+	return nil
+}
+
+// SendOutputs creates and sends payment transactions. It returns the
+// transaction upon success.
+func (w *Wallet) SendOutputs(txr CreateTxReq) (*txauthor.AuthoredTx, er.R) {
+	if len(txr.Comment) > wtxmgr.TxCommentLimit ||
+		len(txr.CommentTo) > wtxmgr.TxCommentLimit {
+
+		return nil, wtxmgr.ErrCommentTooLong.Default()
+	}
+
+	// Ensure the outputs to be created adhere to the network's consensus
+	// rules.
+	if err := w.checkSendOutputs(txr.Outputs); err != nil {
+		return nil, err
+	}
+	if err := w.checkSelfSend(&txr); err != nil {
+		return nil, err
 	}
 
 	// Create the transaction and broadcast it to the network. The
@@ -2291,8 +2912,18 @@ func (w *Wallet) SendOutputs(txr CreateTxReq) (*txauthor.AuthoredTx, er.R) {
 		return createdTx, nil
 	}
 
+	now := time.Now()
+	createdHash := createdTx.Tx.TxHash()
+	if err := w.reserveSpend(&createdHash, outgoingValue(createdTx), now); err != nil {
+		return nil, err
+	}
+
 	txHash, err := w.ReliablyPublishTransaction(createdTx.Tx, txr.Label)
 	if err != nil {
+		if err := w.releaseSpend(&createdHash, now); err != nil {
+			log.Warnf("Unable to release spend of [%s]: [%s]",
+				createdHash, err.String())
+		}
 		return nil, err
 	}
 
@@ -2301,6 +2932,16 @@ func (w *Wallet) SendOutputs(txr CreateTxReq) (*txauthor.AuthoredTx, er.R) {
 		return nil, er.New("tx hash mismatch")
 	}
 
+	// The transaction is already broadcast so failing to record its
+	// comments is not an error.
+	if txr.Comment != "" || txr.CommentTo != "" {
+		err := w.CommentTransaction(*txHash, txr.Comment, txr.CommentTo)
+		if err != nil {
+			log.Warnf("Unable to record comments of [%s]: [%s]",
+				txHash, err.String())
+		}
+	}
+
 	return createdTx, nil
 }
 
@@ -2326,7 +2967,6 @@ func (w *Wallet) SignTransaction(tx *wire.MsgTx, hashType params.SigHashType,
 	p2shRedeemScriptsByAddress map[string][]byte,
 ) ([]SignatureError, er.R) {
 
-	hashCache := txscript.NewTxSigHashes(tx)
 	if len(tx.Additional) == 0 {
 		tx.Additional = make([]wire.TxInAdditional, len(tx.TxIn))
 	} else if len(tx.Additional) != len(tx.TxIn) {
@@ -2365,61 +3005,75 @@ func (w *Wallet) SignTransaction(tx *wire.MsgTx, hashType params.SigHashType,
 					tx.Additional[i].Value = &v
 				}
 			}
+		}
 
-			// Set up our callbacks that we pass to txscript so it can
-			// look up the appropriate keys and scripts by address.
-			getKey := txscript.KeyClosure(func(addr btcutil.Address) (*btcec.PrivateKey, bool, er.R) {
-				if len(additionalKeysByAddress) != 0 {
-					addrStr := addr.EncodeAddress()
-					wif, ok := additionalKeysByAddress[addrStr]
-					if !ok {
-						return nil, false,
-							er.New("no key for address")
-					}
-					return wif.PrivKey, wif.CompressPubKey, nil
-				}
-				address, err := w.Manager.Address(addrmgrNs, addr)
-				if err != nil {
-					return nil, false, err
-				}
-
-				pka, ok := address.(waddrmgr.ManagedPubKeyAddress)
+		// Set up our callbacks that we pass to txscript so it can
+		// look up the appropriate keys and scripts by address.
+		getKey := txscript.KeyClosure(func(addr btcutil.Address) (*btcec.PrivateKey, bool, er.R) {
+			if len(additionalKeysByAddress) != 0 {
+				addrStr := addr.EncodeAddress()
+				wif, ok := additionalKeysByAddress[addrStr]
 				if !ok {
-					return nil, false, er.Errorf("address %v is not "+
-						"a pubkey address", address.Address().EncodeAddress())
+					return nil, false,
+						er.New("no key for address")
 				}
+				return wif.PrivKey, wif.CompressPubKey, nil
+			}
+			address, err := w.Manager.Address(addrmgrNs, addr)
+			if err != nil {
+				return nil, false, err
+			}
 
-				key, err := pka.PrivKey()
-				if err != nil {
-					return nil, false, err
-				}
+			pka, ok := address.(waddrmgr.ManagedPubKeyAddress)
+			if !ok {
+				return nil, false, er.Errorf("address %v is not "+
+					"a pubkey address", address.Address().EncodeAddress())
+			}
 
-				return key, pka.Compressed(), nil
-			})
-			getScript := txscript.ScriptClosure(func(addr btcutil.Address) ([]byte, er.R) {
-				// If keys were provided then we can only use the
-				// redeem scripts provided with our inputs, too.
-				if len(additionalKeysByAddress) != 0 {
-					addrStr := addr.EncodeAddress()
-					script, ok := p2shRedeemScriptsByAddress[addrStr]
-					if !ok {
-						return nil, er.New("no script for address")
-					}
-					return script, nil
-				}
-				address, err := w.Manager.Address(addrmgrNs, addr)
-				if err != nil {
-					return nil, err
-				}
-				sa, ok := address.(waddrmgr.ManagedScriptAddress)
+			key, err := pka.PrivKey()
+			if err != nil {
+				return nil, false, err
+			}
+
+			return key, pka.Compressed(), nil
+		})
+		getScript := txscript.ScriptClosure(func(addr btcutil.Address) ([]byte, er.R) {
+			// If keys were provided then we can only use the
+			// redeem scripts provided with our inputs, too.
+			if len(additionalKeysByAddress) != 0 {
+				addrStr := addr.EncodeAddress()
+				script, ok := p2shRedeemScriptsByAddress[addrStr]
 				if !ok {
-					return nil, er.New("address is not a script" +
-						" address")
+					return nil, er.New("no script for address")
 				}
+				return script, nil
+			}
+			address, err := w.Manager.Address(addrmgrNs, addr)
+			if err != nil {
+				return nil, err
+			}
+			sa, ok := address.(waddrmgr.ManagedScriptAddress)
+			if !ok {
+				return nil, er.New("address is not a script" +
+					" address")
+			}
 
-				return sa.Script()
-			})
+			return sa.Script()
+		})
+
+		// Inputs spending time locked scripts need the locktime or their
+		// sequence set before anything is signed, and can only be signed
+		// once the lock has expired.
+		locks, err := txauthor.ApplyTimeLocks(tx, getScript, w.ChainParams())
+		if err != nil {
+			return err
+		}
+		if err := w.checkTimeLocks(txmgrNs, tx, locks); err != nil {
+			return err
+		}
+		hashCache := txscript.NewTxSigHashes(tx)
 
+		for i := range tx.TxIn {
 			// SigHashSingle inputs can only be signed if there's a
 			// corresponding output. However this could be already signed,
 			// so we always verify the output.
@@ -2583,6 +3237,7 @@ func (w *Wallet) publishTransaction(tx *wire.MsgTx) (*chainhash.Hash, er.R) {
 			if err != nil {
 				return err
 			}
+			w.utxoCache.invalidate()
 			return w.TxStore.RemoveUnminedTx(txmgrNs, txRec)
 		})
 		if dbErr != nil {
@@ -2603,6 +3258,7 @@ func (w *Wallet) publishTransaction(tx *wire.MsgTx) (*chainhash.Hash, er.R) {
 			if err != nil {
 				return err
 			}
+			w.utxoCache.invalidate()
 			return w.TxStore.RemoveUnminedTx(txmgrNs, txRec)
 		})
 		if dbErr != nil {
@@ -2613,6 +3269,7 @@ func (w *Wallet) publishTransaction(tx *wire.MsgTx) (*chainhash.Hash, er.R) {
 			//log.Infof("Removed invalid transaction: %v", spew.Sdump(tx))
 		}
 
+		w.recordRejectedTx(txid, err)
 		return nil, err
 	}
 }
@@ -2684,6 +3341,8 @@ func (w *Wallet) StopResync() (string, er.R) {
 		return "", er.Errorf("No stoppable resync currently in progress")
 	}
 	w.rescanJ = nil
+	w.deleteRescanCheckpoint()
+	w.recovery.stop(gj.name)
 
 	w.UpdateStats(func(ws *btcjson.WalletStats) {
 		ws.MaintenanceInProgress = false
@@ -2696,6 +3355,33 @@ func (w *Wallet) StopResync() (string, er.R) {
 	return gj.name, nil
 }
 
+// CancelRescan stops the running rescan job, if any, after the blocks it is
+// currently scanning and returns its name.  Unlike StopResync the progress of
+// the job is kept, so it resumes from where it stopped when the wallet is next
+// opened.  If no rescan is running this does nothing and returns the empty
+// string.
+func (w *Wallet) CancelRescan() string {
+	w.rescanJLock.Lock()
+	defer w.rescanJLock.Unlock()
+	rj := w.rescanJ
+	if rj == nil {
+		return ""
+	}
+	w.rescanJ = nil
+	w.saveRescanCheckpoint(rj)
+	w.recovery.stop(rj.name)
+
+	w.UpdateStats(func(ws *btcjson.WalletStats) {
+		ws.MaintenanceInProgress = false
+		ws.MaintenanceName = ""
+		ws.MaintenanceCycles = 0
+		ws.MaintenanceLastBlockVisited = 0
+	})
+	log.Infof("Resync job [%s] canceled at height [%d]", rj.name, rj.height)
+
+	return rj.name
+}
+
 // ResyncChain re-synchronizes the wallet from the very first block
 func (w *Wallet) ResyncChain(fromHeight, toHeight int32, addresses []string, dropDb bool) er.R {
 	w.rescanJLock.Lock()
@@ -2761,9 +3447,36 @@ func (w *Wallet) ResyncChain(fromHeight, toHeight int32, addresses []string, dro
 		watch:      watch,
 		dropDb:     dropDb,
 	}
+	if !dropDb {
+		w.rescanJ.addresses = addresses
+	}
+	w.saveRescanCheckpoint(w.rescanJ)
 	return nil
 }
 
+// RescanAddress starts a rescan of the blocks from fromHeight to toHeight which
+// only looks for transactions involving addr, a negative fromHeight means the
+// wallet birthday and a negative toHeight means the block the wallet is synced
+// to.  Because only one address is watched, far fewer blocks match the neutrino
+// filters and need to be downloaded than in a full resync.
+func (w *Wallet) RescanAddress(addr btcutil.Address, fromHeight, toHeight int32) er.R {
+	if !addr.IsForNet(w.chainParams) {
+		return waddrmgr.ErrWrongNet.New(fmt.Sprintf("address [%s] is not "+
+			"for %s", addr.EncodeAddress(), w.chainParams.Name), nil)
+	}
+	if toHeight > -1 && fromHeight > toHeight {
+		return er.Errorf("fromheight [%d] is after toheight [%d]",
+			fromHeight, toHeight)
+	}
+	if err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		_, err := w.Manager.Address(tx.ReadBucket(waddrmgrNamespaceKey), addr)
+		return err
+	}); err != nil {
+		return err
+	}
+	return w.ResyncChain(fromHeight, toHeight, []string{addr.EncodeAddress()}, false)
+}
+
 func (w *Wallet) WalletMempool() ([]wtxmgr.TxDetails, er.R) {
 	var unminedTxDetails []wtxmgr.TxDetails
 	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
@@ -3004,7 +3717,8 @@ func (w *Wallet) connectBlocks(blks []SyncerResp, isRescan bool) er.R {
 		}
 	}
 	bs := w.Manager.SyncedTo()
-	return walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+	var attached []chainhash.Hash
+	err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
 		for _, b := range blks {
 			if b.height > bs.Height+1 {
 				// This happens if we get a resync/dropdb triggered while we're syncing
@@ -3014,6 +3728,7 @@ func (w *Wallet) connectBlocks(blks []SyncerResp, isRescan bool) er.R {
 				txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
 				log.Infof("Invalid block detected at [%d] replacing [%s] -> [%s]",
 					b.height, b.rollbackHash, b.header.BlockHash())
+				w.utxoCache.invalidate()
 				if err := w.TxStore.RollbackOne(txmgrNs, b.height); err != nil {
 					return err
 				}
@@ -3043,9 +3758,17 @@ func (w *Wallet) connectBlocks(blks []SyncerResp, isRescan bool) er.R {
 				},
 				Time: b.header.Timestamp,
 			})
+			attached = append(attached, hash)
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	for i := range attached {
+		w.queueBlockNotify(&attached[i])
+	}
+	return nil
 }
 
 const syncerBatchSz = 8
@@ -3053,18 +3776,18 @@ const syncerBatchSz = 8
 func (w *Wallet) rescan2(
 	blockMin, blockMax int32,
 	isRescan bool,
+	watch *watcher.Watcher,
 ) er.R {
 	chainClient, err := w.requireChainClient()
 	if err != nil {
 		return err
 	}
 	txStore := w.TxStore
-	watch := &w.watch
 	db := w.db
 	responses := make(map[int32]SyncerResp)
 	var respLock sync.Mutex
 	q := workqueue.New(
-		workqueue.DefaultWorkerCount,
+		w.cfg.RecoveryWorkers,
 		workqueue.DefaultBacklog*5,
 		uint64(blockMin),
 		uint64(blockMax),
@@ -3127,7 +3850,44 @@ func (w *Wallet) rescan2(
 	}
 }
 
+// ErrReorgTooDeep is returned when the chain backend diverges from the wallet
+// further back than MaxReorgDepth blocks.
+var ErrReorgTooDeep = Err.CodeWithDetail("ErrReorgTooDeep",
+	"chain reorganization is deeper than the maximum reorg depth")
+
+// reorgDepth returns the number of blocks which must be rolled back before the
+// wallet is synced to a block which is also in the backend's chain.  It gives
+// up and returns limit+1 once the fork is known to be deeper than limit.
+func (w *Wallet) reorgDepth(limit int32) (int32, er.R) {
+	st := w.Manager.SyncedTo()
+	depth := int32(0)
+	for ; depth <= limit && depth <= st.Height; depth++ {
+		var ours *chainhash.Hash
+		err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+			var err er.R
+			ours, err = w.Manager.BlockHash(
+				dbtx.ReadBucket(waddrmgrNamespaceKey), st.Height-depth)
+			return err
+		})
+		if waddrmgr.ErrBlockNotFound.Is(err) {
+			// We have forgotten this block so we cannot go back any further.
+			return limit + 1, nil
+		} else if err != nil {
+			return 0, err
+		}
+		theirs, err := w.chainClient.GetBlockHash(int64(st.Height - depth))
+		if err != nil {
+			return 0, err
+		}
+		if ours.IsEqual(theirs) {
+			return depth, nil
+		}
+	}
+	return depth, nil
+}
+
 func (w *Wallet) rollbackIfNeeded() er.R {
+	checkedDepth := false
 	for {
 		st := w.Manager.SyncedTo()
 		if nextHash, err := w.chainClient.GetBlockHash(int64(st.Height + 1)); err != nil {
@@ -3137,6 +3897,19 @@ func (w *Wallet) rollbackIfNeeded() er.R {
 		} else if nextHdr.PrevBlock.IsEqual(&st.Hash) {
 			return nil
 		} else {
+			if !checkedDepth {
+				depth, err := w.reorgDepth(w.cfg.MaxReorgDepth)
+				if err != nil {
+					return err
+				}
+				if depth > w.cfg.MaxReorgDepth {
+					return ErrReorgTooDeep.New(fmt.Sprintf("wallet is synced "+
+						"to [%s @ %d] but the chain backend diverges more "+
+						"than [%d] blocks back", st.Hash, st.Height,
+						w.cfg.MaxReorgDepth), nil)
+				}
+				checkedDepth = true
+			}
 			if err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
 				return w._rollbackBlock(dbtx, st)
 			}); err != nil {
@@ -3192,20 +3965,43 @@ func (w *Wallet) block(bm wtxmgr.Block) er.R {
 	if top > st.Height+100 {
 		top = st.Height + 100
 	}
-	if err := w.rescan2(st.Height+1, top, false); err != nil {
+	// Catching up a long way is reported as a recovery, unless a resync is
+	// already being reported.
+	recovering := bm.Height-st.Height > recoveryMinBlocks
+	if name, ok := w.recovery.running(); ok {
+		recovering = name == "sync"
+	}
+	if recovering {
+		w.recovery.begin("sync", st.Height, bm.Height)
+	}
+	if err := w.rescan2(st.Height+1, top, false, &w.watch); err != nil {
 		return err
 	}
+	if recovering {
+		w.recovery.advance("sync", st.Height, w.Manager.SyncedTo().Height,
+			bm.Height)
+	}
 	return nil
 }
 
 func (w *Wallet) rescan() {
 	w.rescanJLock.Lock()
 	defer w.rescanJLock.Unlock()
+	// Once the job is done, stopped or canceled another wallet may resync.
+	defer func() {
+		if w.rescanJ == nil {
+			rescanScheduler.release(w)
+		}
+	}()
 	rj := w.rescanJ
 	w.rescanJ = nil
 	if rj == nil {
 		return
 	}
+	if !rescanScheduler.acquire(w, rj.name) {
+		w.rescanJ = rj
+		return
+	}
 
 	// Process dropdb requests
 	if !rj.dropDb {
@@ -3215,6 +4011,7 @@ func (w *Wallet) rescan() {
 	} else if err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
 		txNs := tx.ReadWriteBucket(wtxmgrNamespaceKey)
 		log.Infof("Dropping transaction db")
+		w.utxoCache.invalidate()
 		if err := wtxmgr.DropTransactionHistory(txNs); err != nil {
 			return err
 		}
@@ -3236,6 +4033,7 @@ func (w *Wallet) rescan() {
 	}
 	if rj.height >= limit {
 		log.Info("Resync job reached the chain tip! 👍")
+		w.deleteRescanCheckpoint()
 
 		w.UpdateStats(func(ws *btcjson.WalletStats) {
 			ws.MaintenanceInProgress = false
@@ -3249,12 +4047,17 @@ func (w *Wallet) rescan() {
 	if limit < top {
 		top = limit
 	}
-	if err := w.rescan2(rj.height, top, true); err != nil {
+	w.recovery.begin(rj.name, rj.height, limit)
+	if err := w.rescan2(rj.height, top, true, rj.watch); err != nil {
 		log.Warnf("Error while running resync [%s] resync stopped", err.String())
+		w.deleteRescanCheckpoint()
+		w.recovery.stop(rj.name)
 		return
 	}
+	w.recovery.advance(rj.name, rj.height, top, limit)
 	rj.height = top
 	w.rescanJ = rj
+	w.saveRescanCheckpoint(rj)
 	w.UpdateStats(func(ws *btcjson.WalletStats) {
 		if !ws.MaintenanceInProgress {
 			ws.MaintenanceInProgress = true
@@ -3287,7 +4090,10 @@ func (w *Wallet) checkBlock() {
 	} else if err := w.block(wtxmgr.Block{
 		Hash:   *bestH,
 		Height: bestHeight,
-	}); err != nil {
+	}); ErrReorgTooDeep.Is(err) {
+		log.Errorf("Halting wallet, refusing to roll back: [%s]", err.String())
+		w.Stop()
+	} else if err != nil {
 		log.Warnf("Error registering block [%s]", err.String())
 	}
 }
@@ -3324,17 +4130,67 @@ func (w *Wallet) goMainLoop() {
 	for {
 		w.rescan()
 		w.checkBlock()
+		w.checkMempoolExpiry()
+		w.checkAutoBump()
 		if w.ShuttingDown() {
 			break
 		}
 		time.Sleep(time.Duration(500) * time.Millisecond)
 	}
+	rescanScheduler.release(w)
 	w.wg.Done()
 }
 
-// Open loads an already-created wallet from the passed database and namespaces.
+// ErrWrongNetwork is returned when opening a wallet which was created for a
+// different network than the selected one.
+var ErrWrongNetwork = Err.CodeWithDetail("ErrWrongNetwork",
+	"wallet was created for a different network")
+
+// ErrUpgradeRequired is returned when opening a wallet whose database is in an
+// older format unless Config.AllowUpgrade is set.
+var ErrUpgradeRequired = Err.CodeWithDetail("ErrUpgradeRequired",
+	"wallet database must be upgraded")
+
+// checkNetwork returns ErrWrongNetwork if the genesis block recorded by the
+// address manager is not the genesis block of params, unless
+// ignoreMismatch is set.  Wallets which do not record the genesis block cannot
+// be checked.
+func checkNetwork(addrMgr *waddrmgr.Manager, ns walletdb.ReadBucket,
+	params *chaincfg.Params, ignoreMismatch bool) er.R {
+
+	genesis, err := addrMgr.BlockHash(ns, 0)
+	if waddrmgr.ErrBlockNotFound.Is(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if *genesis == *params.GenesisHash {
+		return nil
+	}
+	network := genesis.String()
+	for _, p := range []*chaincfg.Params{&chaincfg.PktMainNetParams,
+		&chaincfg.PktTestNetParams, &chaincfg.MainNetParams,
+		&chaincfg.TestNet3Params, &chaincfg.RegressionNetParams,
+		&chaincfg.SimNetParams} {
+
+		if *genesis == *p.GenesisHash {
+			network = p.Name
+		}
+	}
+	if ignoreMismatch {
+		log.Warnf("Opening wallet for network [%s] on [%s], this will "+
+			"likely corrupt the wallet", network, params.Name)
+		return nil
+	}
+	return ErrWrongNetwork.New(fmt.Sprintf("wallet is for network [%s] "+
+		"but [%s] is selected, use --force to open it anyway", network,
+		params.Name), nil)
+}
+
+// Open loads an already-created wallet from the passed database and namespaces,
+// with the settings of cfg.
 func Open(db walletdb.DB, pubPass []byte, cbs *waddrmgr.OpenCallbacks,
-	params *chaincfg.Params, recoveryWindow uint32) (*Wallet, er.R) {
+	params *chaincfg.Params, recoveryWindow uint32, cfg Config) (*Wallet, er.R) {
 
 	var (
 		addrMgr *waddrmgr.Manager
@@ -3357,6 +4213,24 @@ func Open(db walletdb.DB, pubPass []byte, cbs *waddrmgr.OpenCallbacks,
 
 		addrMgrUpgrader := waddrmgr.NewMigrationManager(addrMgrBucket)
 		txMgrUpgrader := wtxmgr.NewMigrationManager(txMgrBucket)
+
+		// A database written by a newer version of the wallet is refused
+		// before anything else, it may not be safe even to read it.
+		if err := migration.CheckReversion(txMgrUpgrader, addrMgrUpgrader); err != nil {
+			return err
+		}
+		if !cfg.AllowUpgrade {
+			needed, err := migration.NeedsUpgrade(txMgrUpgrader, addrMgrUpgrader)
+			if err != nil {
+				return err
+			}
+			if needed {
+				return ErrUpgradeRequired.New("the wallet database is in "+
+					"an older format, use --walletupgrade to upgrade it, "+
+					"after which older versions of pktwallet may not be "+
+					"able to open it", nil)
+			}
+		}
 		err := migration.Upgrade(txMgrUpgrader, addrMgrUpgrader)
 		if err != nil {
 			return err
@@ -3366,6 +4240,10 @@ func Open(db walletdb.DB, pubPass []byte, cbs *waddrmgr.OpenCallbacks,
 		if err != nil {
 			return err
 		}
+		if err := checkNetwork(addrMgr, addrMgrBucket, params,
+			cfg.IgnoreNetworkMismatch); err != nil {
+			return err
+		}
 		txMgr, err = wtxmgr.Open(txMgrBucket, params)
 		if err != nil {
 			return err
@@ -3395,11 +4273,17 @@ func Open(db walletdb.DB, pubPass []byte, cbs *waddrmgr.OpenCallbacks,
 		changePassphrases:  make(chan changePassphrasesRequest),
 		checkPassphrase:    make(chan checkPassphrasesRequest),
 		chainParams:        params,
+		cfg:                cfg,
 		quit:               make(chan struct{}),
 		watch:              watcher.New(),
 	}
 
+	w.recovery.interval = cfg.RecoveryProgressInterval
+	w.blockNotifyRequests = make(chan chainhash.Hash, blockNotifyBacklog)
 	w.NtfnServer = newNotificationServer(w)
+	txMgr.NotifyConflicted = w.NtfnServer.addConflictedTransaction
+
+	w.loadRescanCheckpoint()
 
 	return w, nil
 }
@@ -1,15 +1,25 @@
 package wallet
 
 import (
+	"bytes"
 	"encoding/hex"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/pkt-cash/pktd/btcec"
 	"github.com/pkt-cash/pktd/btcutil"
 	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
 	"github.com/pkt-cash/pktd/chaincfg/genesis"
+	"github.com/pkt-cash/pktd/pktwallet/chain"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
 	"github.com/pkt-cash/pktd/pktwallet/walletdb"
 	"github.com/pkt-cash/pktd/pktwallet/wtxmgr"
+	"github.com/pkt-cash/pktd/txscript"
+	"github.com/pkt-cash/pktd/wire"
 )
 
 var (
@@ -203,3 +213,1203 @@ func TestLabelTransaction(t *testing.T) {
 		})
 	}
 }
+
+// TestAccountXpubsSlip132 checks that account extended public keys are
+// exported with the SLIP-0132 version bytes matching the script type of their
+// scope, and with the standard version when SLIP-0132 is not requested.
+func TestAccountXpubsSlip132(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	// testWallet uses testnet3 so the standard prefix is tpub, SLIP-0132
+	// uses upub for p2sh-p2wpkh and vpub for p2wpkh.
+	wantSlip132 := map[waddrmgr.KeyScope]string{
+		waddrmgr.KeyScopeBIP0044:     "tpub",
+		waddrmgr.KeyScopeBIP0049Plus: "upub",
+		waddrmgr.KeyScopeBIP0084:     "vpub",
+	}
+	wantVersion := map[waddrmgr.KeyScope][]byte{
+		waddrmgr.KeyScopeBIP0044:     {0x04, 0x35, 0x87, 0xcf},
+		waddrmgr.KeyScopeBIP0049Plus: {0x04, 0x4a, 0x52, 0x62},
+		waddrmgr.KeyScopeBIP0084:     {0x04, 0x5f, 0x1c, 0xf6},
+	}
+
+	plain, err := w.AccountXpubs(waddrmgr.DefaultAccountNum, false)
+	if err != nil {
+		t.Fatalf("unable to get account xpubs: %v", err)
+	}
+	slip132, err := w.AccountXpubs(waddrmgr.DefaultAccountNum, true)
+	if err != nil {
+		t.Fatalf("unable to get slip132 account xpubs: %v", err)
+	}
+	if len(plain) != len(wantSlip132) || len(slip132) != len(wantSlip132) {
+		t.Fatalf("expected %d xpubs, got %d and %d", len(wantSlip132),
+			len(plain), len(slip132))
+	}
+
+	for i, x := range slip132 {
+		xpub := x.Xpub.String()
+		if !strings.HasPrefix(xpub, wantSlip132[x.Scope]) {
+			t.Fatalf("scope %v: expected %s prefix, got %s",
+				x.Scope, wantSlip132[x.Scope], xpub)
+		}
+		if !bytes.Equal(x.Xpub.Version(), wantVersion[x.Scope]) {
+			t.Fatalf("scope %v: expected version %x, got %x",
+				x.Scope, wantVersion[x.Scope], x.Xpub.Version())
+		}
+
+		p := plain[i]
+		if !strings.HasPrefix(p.Xpub.String(), "tpub") {
+			t.Fatalf("scope %v: expected standard tpub prefix, got %s",
+				p.Scope, p.Xpub.String())
+		}
+
+		// Only the version bytes differ, the key material is the same.
+		xpk, err := x.Xpub.ECPubKey()
+		if err != nil {
+			t.Fatalf("unable to get pubkey: %v", err)
+		}
+		ppk, err := p.Xpub.ECPubKey()
+		if err != nil {
+			t.Fatalf("unable to get pubkey: %v", err)
+		}
+		if !xpk.IsEqual(ppk) {
+			t.Fatalf("scope %v: SLIP-0132 key differs from standard key",
+				x.Scope)
+		}
+	}
+}
+
+// TestAccountXpubsSlip132PKT checks that on a network which has no SLIP-0132
+// version bytes for the segwit scopes, those scopes are left out rather than
+// failing the request.
+func TestAccountXpubsSlip132PKT(t *testing.T) {
+	w, cleanup := testWalletWithParams(t, &chaincfg.PktMainNetParams)
+	defer cleanup()
+
+	xpubs, err := w.AccountXpubs(waddrmgr.DefaultAccountNum, true)
+	if err != nil {
+		t.Fatalf("unable to get slip132 account xpubs: %v", err)
+	}
+	if len(xpubs) != 1 || xpubs[0].Scope != waddrmgr.KeyScopeBIP0044 {
+		t.Fatalf("got xpubs %v, want only that of the BIP0044 scope", xpubs)
+	}
+	if !bytes.Equal(xpubs[0].Xpub.Version(),
+		chaincfg.PktMainNetParams.HDPublicKeyID[:]) {
+
+		t.Fatalf("got version %x, want the network's standard version",
+			xpubs[0].Xpub.Version())
+	}
+}
+
+// TestMarkAddressUsed checks that marking an address as used advances the
+// current address and that the gap limit guard refuses changes which would
+// leave too many unused addresses between used ones.
+func TestMarkAddressUsed(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	scope := waddrmgr.KeyScopeBIP0084
+	addr0, err := w.CurrentAddress(waddrmgr.DefaultAccountNum, scope)
+	if err != nil {
+		t.Fatalf("unable to get current address: %v", err)
+	}
+	if err := w.MarkAddressUsed(addr0, true); err != nil {
+		t.Fatalf("unable to mark address used: %v", err)
+	}
+	addr1, err := w.CurrentAddress(waddrmgr.DefaultAccountNum, scope)
+	if err != nil {
+		t.Fatalf("unable to get current address: %v", err)
+	}
+	if addr1.EncodeAddress() == addr0.EncodeAddress() {
+		t.Fatalf("current address did not advance after marking used")
+	}
+
+	// Derive enough addresses to go beyond the gap limit, addrs[i] is the
+	// address at index i.
+	addrs := []btcutil.Address{addr0, addr1}
+	for uint32(len(addrs)) <= w.cfg.AddressGapLimit+1 {
+		a, err := w.NewAddress(waddrmgr.DefaultAccountNum, scope)
+		if err != nil {
+			t.Fatalf("unable to get new address: %v", err)
+		}
+		addrs = append(addrs, a)
+	}
+
+	tooFar := addrs[w.cfg.AddressGapLimit+1]
+	if err := w.MarkAddressUsed(tooFar, true); !ErrGapLimit.Is(err) {
+		t.Fatalf("expected gap limit error, got %v", err)
+	}
+	atLimit := addrs[w.cfg.AddressGapLimit]
+	if err := w.MarkAddressUsed(atLimit, true); err != nil {
+		t.Fatalf("unable to mark address at gap limit used: %v", err)
+	}
+
+	// Clearing index 0 would leave the address at the gap limit more than
+	// the limit beyond the start of the branch.
+	if err := w.MarkAddressUsed(addr0, false); !ErrGapLimit.Is(err) {
+		t.Fatalf("expected gap limit error, got %v", err)
+	}
+
+	// The last used address can be cleared and the one before it along with
+	// it.
+	if err := w.MarkAddressUsed(atLimit, false); err != nil {
+		t.Fatalf("unable to mark address unused: %v", err)
+	}
+	if err := w.MarkAddressUsed(addr0, false); err != nil {
+		t.Fatalf("unable to mark address unused: %v", err)
+	}
+	info, err := w.AddressInfo(addr0)
+	if err != nil {
+		t.Fatalf("unable to get address info: %v", err)
+	}
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		if info.Used(tx.ReadBucket(waddrmgrNamespaceKey)) {
+			t.Fatalf("address still marked used")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to read used flag: %v", err)
+	}
+}
+
+// TestNewAddressBech32HRP ensures that segwit addresses handed out by the
+// wallet carry the bech32 HRP of the network which the wallet is for.
+func TestNewAddressBech32HRP(t *testing.T) {
+	tests := []struct {
+		params *chaincfg.Params
+		hrp    string
+	}{
+		{&chaincfg.PktMainNetParams, "pkt"},
+		{&chaincfg.PktTestNetParams, "tpk"},
+	}
+	for _, test := range tests {
+		w, cleanup := testWalletWithParams(t, test.params)
+
+		addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+		if err != nil {
+			cleanup()
+			t.Fatalf("%s: unable to get new address: %v",
+				test.params.Name, err)
+		}
+		cleanup()
+
+		wpkh, ok := addr.(*btcutil.AddressWitnessPubKeyHash)
+		if !ok {
+			t.Fatalf("%s: expected witness address, got %T",
+				test.params.Name, addr)
+		}
+		if wpkh.Hrp() != test.hrp {
+			t.Fatalf("%s: got HRP %q, want %q", test.params.Name,
+				wpkh.Hrp(), test.hrp)
+		}
+		if !strings.HasPrefix(addr.EncodeAddress(), test.hrp+"1") {
+			t.Fatalf("%s: address %s does not start with %s1",
+				test.params.Name, addr, test.hrp)
+		}
+		if !addr.IsForNet(test.params) {
+			t.Fatalf("%s: address %s is not for network",
+				test.params.Name, addr)
+		}
+	}
+}
+
+// rescanChainClient is a mock chain client serving a chain of block headers
+// where some blocks contain transactions which are relevant to the wallet.
+type rescanChainClient struct {
+	mockChainClient
+	headers map[chainhash.Hash]*wire.BlockHeader
+	hashes  map[int64]chainhash.Hash
+	txns    map[int32][]*wire.MsgTx
+	tip     int32
+}
+
+func newRescanChainClient() *rescanChainClient {
+	return &rescanChainClient{
+		headers: make(map[chainhash.Hash]*wire.BlockHeader),
+		hashes:  make(map[int64]chainhash.Hash),
+		txns:    make(map[int32][]*wire.MsgTx),
+	}
+}
+
+// addBlocks builds a chain of headers from height from to height to on top of
+// the block at from-1, replacing any blocks which are already at those
+// heights.  Chains built with a different salt have different block hashes.
+func (c *rescanChainClient) addBlocks(from, to int32, salt uint32) {
+	prevHash := c.hashes[int64(from-1)]
+	for height := from; height <= to; height++ {
+		header := &wire.BlockHeader{
+			PrevBlock: prevHash,
+			Timestamp: time.Unix(1600000000+int64(height)*60, 0),
+			Nonce:     uint32(height) + salt,
+		}
+		prevHash = header.BlockHash()
+		c.headers[prevHash] = header
+		c.hashes[int64(height)] = prevHash
+	}
+	c.tip = to
+}
+
+func (c *rescanChainClient) GetBestBlock() (*chainhash.Hash, int32, er.R) {
+	hash := c.hashes[int64(c.tip)]
+	return &hash, c.tip, nil
+}
+
+func (c *rescanChainClient) GetBlockHash(height int64) (*chainhash.Hash, er.R) {
+	hash, ok := c.hashes[height]
+	if !ok {
+		return nil, er.Errorf("no block at height %d", height)
+	}
+	return &hash, nil
+}
+
+func (c *rescanChainClient) GetBlockHeader(hash *chainhash.Hash) (*wire.BlockHeader,
+	er.R) {
+	return c.headers[*hash], nil
+}
+
+func (c *rescanChainClient) FilterBlocks(req *chain.FilterBlocksRequest) (
+	*chain.FilterBlocksResponse, er.R) {
+
+	height := req.Blocks[0].Height
+
+	// Vary how long each block takes so that concurrent workers complete
+	// out of order.
+	time.Sleep(time.Duration(height%4) * time.Millisecond)
+
+	txns := c.txns[height]
+	if len(txns) == 0 {
+		return nil, nil
+	}
+	return &chain.FilterBlocksResponse{
+		BlockMeta:    req.Blocks[0],
+		RelevantTxns: txns,
+	}, nil
+}
+
+// recoverWithWorkers rescans a chain in which the wallet was paid several
+// times, and later spent some of those coins, using the given number of
+// workers.  It returns the wallet's balance and the amounts of its unspent
+// outputs.
+func recoverWithWorkers(t *testing.T, workers int) (btcutil.Amount, []float64) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.cfg.RecoveryWorkers = workers
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+
+	const chainHeight = 60
+	c := newRescanChainClient()
+	c.addBlocks(0, chainHeight, 0)
+	var payments []*wire.MsgTx
+	for height := int32(3); height < chainHeight; height += 5 {
+		tx := &wire.MsgTx{
+			Version: 1,
+			TxIn: []*wire.TxIn{{
+				PreviousOutPoint: wire.OutPoint{Index: uint32(height)},
+			}},
+			TxOut: []*wire.TxOut{
+				wire.NewTxOut(int64(height)*100000, pkScript),
+			},
+		}
+		c.txns[height] = append(c.txns[height], tx)
+		payments = append(payments, tx)
+	}
+
+	// Spend some of the earlier payments in later blocks, these must be
+	// applied after the payments which they spend.
+	for i, height := range []int32{9, 31, 47} {
+		spend := &wire.MsgTx{
+			Version: 1,
+			TxIn: []*wire.TxIn{{
+				PreviousOutPoint: wire.OutPoint{Hash: payments[i].TxHash()},
+			}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(1000, []byte{0x51})},
+		}
+		c.txns[height] = append(c.txns[height], spend)
+	}
+	w.chainClient = c
+
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return w.Manager.SetSyncedTo(ns, &waddrmgr.BlockStamp{
+			Height:    chainHeight,
+			Hash:      c.hashes[chainHeight],
+			Timestamp: c.headers[c.hashes[chainHeight]].Timestamp,
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to set synced to: %v", err)
+	}
+
+	if err := w.rescan2(0, chainHeight+1, true, &w.watch); err != nil {
+		t.Fatalf("rescan failed: %v", err)
+	}
+
+	balance, err := w.CalculateBalance(1)
+	if err != nil {
+		t.Fatalf("unable to calculate balance: %v", err)
+	}
+	unspent, err := w.ListUnspent(0, 999999, nil)
+	if err != nil {
+		t.Fatalf("unable to list unspent: %v", err)
+	}
+	amounts := make([]float64, 0, len(unspent))
+	for _, u := range unspent {
+		amounts = append(amounts, u.Amount)
+	}
+	sort.Float64s(amounts)
+	return balance, amounts
+}
+
+// TestRecoveryWorkers ensures that a recovery rescan using several workers
+// finds the same funds as a recovery using a single worker.
+func TestRecoveryWorkers(t *testing.T) {
+	wantBalance, wantUnspent := recoverWithWorkers(t, 1)
+	if len(wantUnspent) != 9 {
+		t.Fatalf("single worker recovery found %d unspent outputs, "+
+			"want 9", len(wantUnspent))
+	}
+	for _, workers := range []int{2, 8} {
+		balance, unspent := recoverWithWorkers(t, workers)
+		if balance != wantBalance {
+			t.Fatalf("%d workers: got balance %v, want %v", workers,
+				balance, wantBalance)
+		}
+		if len(unspent) != len(wantUnspent) {
+			t.Fatalf("%d workers: got %d unspent outputs, want %d",
+				workers, len(unspent), len(wantUnspent))
+		}
+		for i := range unspent {
+			if unspent[i] != wantUnspent[i] {
+				t.Fatalf("%d workers: got unspent %v, want %v",
+					workers, unspent, wantUnspent)
+			}
+		}
+	}
+}
+
+// reorgWallet returns a wallet which is synced to height 20 of a chain and a
+// chain client whose chain forks from the wallet's after forkHeight.
+func reorgWallet(t *testing.T, forkHeight int32) (*Wallet, *rescanChainClient,
+	func()) {
+
+	w, cleanup := testWallet(t)
+
+	const syncedHeight = 20
+	c := newRescanChainClient()
+	c.addBlocks(0, syncedHeight, 0)
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		for height := int32(0); height <= syncedHeight; height++ {
+			hash := c.hashes[int64(height)]
+			err := w.Manager.SetSyncedTo(ns, &waddrmgr.BlockStamp{
+				Height:    height,
+				Hash:      hash,
+				Timestamp: c.headers[hash].Timestamp,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		cleanup()
+		t.Fatalf("unable to set synced to: %v", err)
+	}
+
+	c.addBlocks(forkHeight+1, syncedHeight+2, 1000)
+	w.chainClient = c
+	return w, c, cleanup
+}
+
+// TestMaxReorgDepth ensures that a reorg within MaxReorgDepth is rolled back
+// and the wallet synced to the new chain, while a deeper one halts the wallet
+// without rewinding it.
+func TestMaxReorgDepth(t *testing.T) {
+	// A reorg of 3 blocks is within the limit.
+	w, c, cleanup := reorgWallet(t, 17)
+	defer cleanup()
+	w.cfg.MaxReorgDepth = 5
+	w.checkBlock()
+	if w.ShuttingDown() {
+		t.Fatalf("wallet halted on a reorg within the limit")
+	}
+	st := w.Manager.SyncedTo()
+	if st.Height != c.tip || st.Hash != c.hashes[int64(c.tip)] {
+		t.Fatalf("wallet synced to %v @ %d, want %v @ %d", st.Hash,
+			st.Height, c.hashes[int64(c.tip)], c.tip)
+	}
+
+	// A reorg of 10 blocks is beyond the limit.
+	w2, _, cleanup2 := reorgWallet(t, 10)
+	defer cleanup2()
+	w2.cfg.MaxReorgDepth = 5
+	wantHash := w2.Manager.SyncedTo().Hash
+	if err := w2.rollbackIfNeeded(); !ErrReorgTooDeep.Is(err) {
+		t.Fatalf("got error %v, want ErrReorgTooDeep", err)
+	}
+	w2.checkBlock()
+	if !w2.ShuttingDown() {
+		t.Fatalf("wallet did not halt on a reorg beyond the limit")
+	}
+	st = w2.Manager.SyncedTo()
+	if st.Height != 20 || st.Hash != wantHash {
+		t.Fatalf("wallet rolled back to %v @ %d, want %v @ 20", st.Hash,
+			st.Height, wantHash)
+	}
+}
+
+// TestListAccounts ensures that every account, including the default and
+// imported accounts, is listed with its address count and balance.
+func TestListAccounts(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	scope := waddrmgr.KeyScopeBIP0084
+	manager, err := w.Manager.FetchScopedKeyManager(scope)
+	if err != nil {
+		t.Fatalf("unable to fetch scoped manager: %v", err)
+	}
+	var savings uint32
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		var err er.R
+		savings, err = manager.NewAccount(
+			tx.ReadWriteBucket(waddrmgrNamespaceKey), "savings")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to create account: %v", err)
+	}
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to create private key: %v", err)
+	}
+	wif, err := btcutil.NewWIF(privKey, w.chainParams, true)
+	if err != nil {
+		t.Fatalf("unable to create wif: %v", err)
+	}
+	imported, err := w.ImportPrivateKey(scope, wif, &waddrmgr.BlockStamp{
+		Height:    1,
+		Timestamp: time.Now(),
+	}, false)
+	if err != nil {
+		t.Fatalf("unable to import private key: %v", err)
+	}
+	importedAddr, err := btcutil.DecodeAddress(imported, w.chainParams)
+	if err != nil {
+		t.Fatalf("unable to decode imported address: %v", err)
+	}
+
+	var addrs []btcutil.Address
+	for _, account := range []uint32{0, savings, savings} {
+		addr, err := w.NewAddress(account, scope)
+		if err != nil {
+			t.Fatalf("unable to get new address: %v", err)
+		}
+		addrs = append(addrs, addr)
+	}
+	addrs = append(addrs, importedAddr)
+	for i, addr := range addrs {
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			t.Fatalf("unable to create pkScript: %v", err)
+		}
+		addUtxo(t, w, &wire.MsgTx{
+			TxIn: []*wire.TxIn{{
+				PreviousOutPoint: wire.OutPoint{Index: uint32(i)},
+			}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(int64(i+1)*1e8, pkScript)},
+		})
+	}
+
+	accounts, err := w.ListAccounts(0)
+	if err != nil {
+		t.Fatalf("unable to list accounts: %v", err)
+	}
+	type want struct {
+		name      string
+		addresses uint32
+		balance   btcutil.Amount
+	}
+	wants := map[uint32]want{
+		0:                            {"default", 1, 1e8},
+		savings:                      {"savings", 2, 2e8 + 3e8},
+		waddrmgr.ImportedAddrAccount: {waddrmgr.ImportedAddrAccountName, 1, 4e8},
+	}
+	found := 0
+	for _, a := range accounts {
+		if a.Scope != scope {
+			if a.Balance != 0 {
+				t.Fatalf("account %v/%d has balance %v, want 0",
+					a.Scope, a.AccountNumber, a.Balance)
+			}
+			continue
+		}
+		wa, ok := wants[a.AccountNumber]
+		if !ok {
+			t.Fatalf("unexpected account %d", a.AccountNumber)
+		}
+		found++
+		addresses := a.ExternalKeyCount + a.ImportedKeyCount
+		if a.AccountName != wa.name || addresses != wa.addresses ||
+			a.Balance != wa.balance {
+			t.Fatalf("account %d: got %s with %d addresses and balance "+
+				"%v, want %s with %d addresses and balance %v",
+				a.AccountNumber, a.AccountName, addresses, a.Balance,
+				wa.name, wa.addresses, wa.balance)
+		}
+		if a.AddressType != waddrmgr.WitnessPubKey {
+			t.Fatalf("account %d: got address type %v, want %v",
+				a.AccountNumber, a.AddressType, waddrmgr.WitnessPubKey)
+		}
+	}
+	if found != len(wants) {
+		t.Fatalf("found %d accounts in scope %v, want %d", found, scope,
+			len(wants))
+	}
+}
+
+// TestVerifyWallet ensures that a wallet with transactions passes
+// verification.  Detection of corruption is tested by wtxmgr.
+func TestVerifyWallet(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addUtxo(t, w, &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+	})
+	report, err := w.VerifyWallet()
+	if err != nil {
+		t.Fatalf("unable to verify wallet: %v", err)
+	}
+	if len(report) != 0 {
+		t.Fatalf("clean wallet reported inconsistencies: %v", report)
+	}
+}
+
+// insertTestTx records tx in the wallet, mined at height unless it is negative,
+// with the given outputs credited to the wallet.
+func insertTestTx(t *testing.T, w *Wallet, tx *wire.MsgTx, height int32,
+	credits ...uint32) {
+
+	insertTestTxAt(t, w, tx, height, time.Now(), time.Unix(1387737310, 0),
+		credits...)
+}
+
+// insertTestTxAt is insertTestTx with the time at which the transaction was
+// received and the time of the block which it is mined in.
+func insertTestTxAt(t *testing.T, w *Wallet, tx *wire.MsgTx, height int32,
+	received, blockTime time.Time, credits ...uint32) {
+
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, received)
+	if err != nil {
+		t.Fatalf("unable to create tx record: %v", err)
+	}
+	var block *wtxmgr.BlockMeta
+	if height >= 0 {
+		block = &wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{
+				Hash:   chainhash.DoubleHashH([]byte{byte(height), byte(height >> 8)}),
+				Height: height,
+			},
+			Time: blockTime,
+		}
+	}
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		ns := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+		if err := w.TxStore.InsertTx(ns, rec, block); err != nil {
+			return err
+		}
+		for _, index := range credits {
+			err := w.TxStore.AddCredit(ns, rec, block, index, false)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to insert tx: %v", err)
+	}
+}
+
+// setSyncedTo marks the wallet as synced to a block at height.
+func setSyncedTo(t *testing.T, w *Wallet, height int32) {
+	err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		return w.Manager.SetSyncedTo(dbtx.ReadWriteBucket(waddrmgrNamespaceKey),
+			&waddrmgr.BlockStamp{
+				Height:    height,
+				Hash:      chainhash.DoubleHashH([]byte{byte(height)}),
+				Timestamp: time.Now(),
+			})
+	})
+	if err != nil {
+		t.Fatalf("unable to set synced to: %v", err)
+	}
+}
+
+// broadcastChainClient is a mock chain client which records the transactions
+// which it is asked to broadcast.
+type broadcastChainClient struct {
+	mockChainClient
+	sent []*wire.MsgTx
+}
+
+func (c *broadcastChainClient) SendRawTransaction(tx *wire.MsgTx, _ bool) (
+	*chainhash.Hash, er.R) {
+	c.sent = append(c.sent, tx)
+	txid := tx.TxHash()
+	return &txid, nil
+}
+
+// fundedTestWallet creates a test wallet which broadcasts through the returned
+// chain client, recording an output paying a new address of the wallet mined
+// at height 100 for each of values.  The transactions of the outputs are
+// returned in the order of values.
+func fundedTestWallet(t *testing.T, values ...int64) (*Wallet,
+	*broadcastChainClient, []*wire.MsgTx, func()) {
+
+	w, cleanup := testWallet(t)
+	chainClient := &broadcastChainClient{}
+	w.chainClient = chainClient
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txs := make([]*wire.MsgTx, 0, len(values))
+	for i, value := range values {
+		tx := &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Index: uint32(i)}}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(value, pkScript)},
+		}
+		insertTestTx(t, w, tx, 100, 0)
+		txs = append(txs, tx)
+	}
+	return w, chainClient, txs, cleanup
+}
+
+// TestBalanceAtHeight replays a crafted history and checks the balance at
+// heights before, during and after it.
+func TestBalanceAtHeight(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	setSyncedTo(t, w, 300)
+
+	// Receive 5 coins at height 100.
+	receive := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(5e8, []byte{0x51})},
+	}
+	insertTestTx(t, w, receive, 100, 0)
+
+	// Spend them at height 200, paying 1.9 coins away with 3 coins change.
+	spend := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: receive.TxHash()},
+		}},
+		TxOut: []*wire.TxOut{
+			wire.NewTxOut(3e8, []byte{0x51}),
+			wire.NewTxOut(1.9e8, []byte{0x52}),
+		},
+	}
+	insertTestTx(t, w, spend, 200, 0)
+
+	// Receive 1 coin at height 250 and 2 coins which are unmined.
+	insertTestTx(t, w, &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{Sequence: 1}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+	}, 250, 0)
+	insertTestTx(t, w, &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{Sequence: 2}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(2e8, []byte{0x51})},
+	}, -1, 0)
+
+	tests := []struct {
+		height     int32
+		wantHeight int32
+		balance    btcutil.Amount
+	}{
+		{0, 0, 0},
+		{99, 99, 0},
+		{100, 100, 5e8},
+		{199, 199, 5e8},
+		{200, 200, 3e8},
+		{250, 250, 4e8},
+		{300, 300, 4e8},
+		{1000, 300, 4e8},
+	}
+	for _, test := range tests {
+		balance, height, err := w.BalanceAtHeight(test.height)
+		if err != nil {
+			t.Fatalf("height %d: unexpected error: %v", test.height, err)
+		}
+		if height != test.wantHeight || balance != test.balance {
+			t.Errorf("height %d: got %v at height %d, want %v at height %d",
+				test.height, balance, height, test.balance,
+				test.wantHeight)
+		}
+	}
+
+	// The balance at the tip is the current confirmed balance.
+	confirmed, err := w.CalculateBalance(1)
+	if err != nil {
+		t.Fatalf("unable to calculate balance: %v", err)
+	}
+	if confirmed != 4e8 {
+		t.Fatalf("got confirmed balance %v, want %v", confirmed,
+			btcutil.Amount(4e8))
+	}
+}
+
+// TestAccountAddressType ensures that accounts created with a default address
+// type give addresses of that type.
+func TestAccountAddressType(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	segwit := waddrmgr.KeyScopeBIP0084
+	legacy := waddrmgr.KeyScopeBIP0044
+	tests := []struct {
+		name  string
+		scope *waddrmgr.KeyScope
+		check func(btcutil.Address) bool
+	}{
+		{"segwit", &segwit, func(a btcutil.Address) bool {
+			_, ok := a.(*btcutil.AddressWitnessPubKeyHash)
+			return ok
+		}},
+		{"legacy", &legacy, func(a btcutil.Address) bool {
+			_, ok := a.(*btcutil.AddressPubKeyHash)
+			return ok
+		}},
+		// Accounts without a default are segwit.
+		{"nodefault", nil, func(a btcutil.Address) bool {
+			_, ok := a.(*btcutil.AddressWitnessPubKeyHash)
+			return ok
+		}},
+	}
+	for _, test := range tests {
+		account, err := w.NextAccount(test.name, test.scope)
+		if err != nil {
+			t.Fatalf("%s: unable to create account: %v", test.name, err)
+		}
+		if n, err := w.AccountNumber(test.name); err != nil || n != account {
+			t.Fatalf("%s: got account number %d (%v), want %d", test.name,
+				n, err, account)
+		}
+		scope, err := w.AccountAddressScope(account)
+		if err != nil {
+			t.Fatalf("%s: unable to fetch address scope: %v", test.name, err)
+		}
+		addr, err := w.NewAddress(account, scope)
+		if err != nil {
+			t.Fatalf("%s: unable to create address: %v", test.name, err)
+		}
+		if !test.check(addr) {
+			t.Fatalf("%s: address %v is of the wrong type %T", test.name,
+				addr, addr)
+		}
+		if a, err := w.AccountOfAddress(addr); err != nil || a != account {
+			t.Fatalf("%s: address is in account %d (%v), want %d",
+				test.name, a, err, account)
+		}
+	}
+}
+
+// TestStorageStats ensures that the storage stats report the transactions and
+// unspent outputs of the wallet.
+func TestStorageStats(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	// Receive two outputs at height 100, then spend one of them with an
+	// unmined transaction which pays change back to the wallet.
+	receive := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{}},
+		TxOut: []*wire.TxOut{
+			wire.NewTxOut(5e8, []byte{0x51}),
+			wire.NewTxOut(2e8, []byte{0x51}),
+		},
+	}
+	insertTestTx(t, w, receive, 100, 0, 1)
+	insertTestTx(t, w, &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: receive.TxHash()},
+		}},
+		TxOut: []*wire.TxOut{
+			wire.NewTxOut(3e8, []byte{0x51}),
+			wire.NewTxOut(1.9e8, []byte{0x52}),
+		},
+	}, -1, 0)
+
+	// And receive one more output at height 150.
+	insertTestTx(t, w, &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{Sequence: 1}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+	}, 150, 0)
+
+	stats, err := w.StorageStats()
+	if err != nil {
+		t.Fatalf("unable to fetch storage stats: %v", err)
+	}
+	if stats.Transactions != 3 {
+		t.Fatalf("got %d transactions, want 3", stats.Transactions)
+	}
+	if stats.Utxos != 3 {
+		t.Fatalf("got %d unspent outputs, want 3", stats.Utxos)
+	}
+	if stats.FileSize <= 0 {
+		t.Fatalf("got file size %d, want a positive size", stats.FileSize)
+	}
+	if records, ok := stats.Buckets["wtxmgr/t"]; !ok || records.Keys != 2 {
+		t.Fatalf("got mined transaction records %+v, want 2 keys", records)
+	}
+	if stats.Buckets["wtxmgr"].Size <= 0 {
+		t.Fatalf("got transaction store stats %+v, want a positive size",
+			stats.Buckets["wtxmgr"])
+	}
+}
+
+// TestTrustedConfs ensures that transactions are listed as trusted once they
+// have TrustedConfs confirmations and not before.
+func TestTrustedConfs(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	w.cfg.TrustedConfs = 3
+	setSyncedTo(t, w, 300)
+
+	trusted := make(map[string]bool)
+	for i, height := range []int32{250, 298, 299, -1} {
+		tx := &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{Sequence: uint32(i)}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(1e8, []byte{0x51})},
+		}
+		insertTestTx(t, w, tx, height, 0)
+		trusted[tx.TxHash().String()] = height >= 0 && 300-height+1 >= 3
+	}
+
+	results, err := w.ListAllTransactions()
+	if err != nil {
+		t.Fatalf("unable to list transactions: %v", err)
+	}
+	if len(results) != len(trusted) {
+		t.Fatalf("got %d results, want %d", len(results), len(trusted))
+	}
+	for _, r := range results {
+		if r.Trusted != trusted[r.TxID] {
+			t.Errorf("transaction with %d confirmations: got trusted %v, "+
+				"want %v", r.Confirmations, r.Trusted, trusted[r.TxID])
+		}
+	}
+}
+
+// addrFilterChainClient is a rescanChainClient which, like the neutrino
+// filters, only returns the transactions paying to the requested addresses.
+type addrFilterChainClient struct {
+	*rescanChainClient
+	filtered []int32
+}
+
+func (c *addrFilterChainClient) FilterBlocks(req *chain.FilterBlocksRequest) (
+	*chain.FilterBlocksResponse, er.R) {
+
+	height := req.Blocks[0].Height
+	c.filtered = append(c.filtered, height)
+	var relevant []*wire.MsgTx
+	for _, tx := range c.txns[height] {
+		for _, out := range tx.TxOut {
+			_, addrs, _, _ := txscript.ExtractPkScriptAddrs(out.PkScript,
+				&chaincfg.TestNet3Params)
+			for _, a := range req.ImportedAddrs {
+				if len(addrs) == 1 && addrs[0].EncodeAddress() == a.EncodeAddress() {
+					relevant = append(relevant, tx)
+				}
+			}
+		}
+	}
+	if len(relevant) == 0 {
+		return nil, nil
+	}
+	return &chain.FilterBlocksResponse{
+		BlockMeta:    req.Blocks[0],
+		RelevantTxns: relevant,
+	}, nil
+}
+
+// TestRescanAddress ensures that rescanning a single address finds only the
+// transactions paying to that address within the requested heights.
+func TestRescanAddress(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	var addrs []btcutil.Address
+	for i := 0; i < 2; i++ {
+		addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+		if err != nil {
+			t.Fatalf("unable to get new address: %v", err)
+		}
+		addrs = append(addrs, addr)
+	}
+
+	const chainHeight = 40
+	c := &addrFilterChainClient{rescanChainClient: newRescanChainClient()}
+	c.addBlocks(0, chainHeight, 0)
+	payments := make(map[int32]*wire.MsgTx)
+	for i, height := range []int32{5, 12, 15, 18, 25} {
+		pkScript, err := txscript.PayToAddrScript(addrs[i%2])
+		if err != nil {
+			t.Fatalf("unable to create pkScript: %v", err)
+		}
+		tx := &wire.MsgTx{
+			Version: 1,
+			TxIn: []*wire.TxIn{{
+				PreviousOutPoint: wire.OutPoint{Index: uint32(height)},
+			}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(int64(height)*100000, pkScript)},
+		}
+		c.txns[height] = append(c.txns[height], tx)
+		payments[height] = tx
+	}
+	w.chainClient = c
+
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return w.Manager.SetSyncedTo(ns, &waddrmgr.BlockStamp{
+			Height:    chainHeight,
+			Hash:      c.hashes[chainHeight],
+			Timestamp: c.headers[c.hashes[chainHeight]].Timestamp,
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to set synced to: %v", err)
+	}
+
+	if err := w.RescanAddress(addrs[0], 10, 20); err != nil {
+		t.Fatalf("unable to start rescan: %v", err)
+	}
+	for w.rescanJ != nil {
+		w.rescan()
+	}
+
+	// Only the payments to the first address between heights 10 and 20
+	// are found.
+	found := map[int32]bool{15: true}
+	for height, tx := range payments {
+		hash := tx.TxHash()
+		var details *wtxmgr.TxDetails
+		err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+			var err er.R
+			details, err = w.TxStore.TxDetails(
+				dbtx.ReadBucket(wtxmgrNamespaceKey), &hash)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("unable to fetch transaction: %v", err)
+		}
+		if (details != nil) != found[height] {
+			t.Errorf("payment at height %d: got found %v, want %v",
+				height, details != nil, found[height])
+		}
+	}
+	for _, height := range c.filtered {
+		if height < 10 || height > 20 {
+			t.Errorf("rescan filtered block %d outside of 10 to 20", height)
+		}
+	}
+
+	if err := w.RescanAddress(addrs[0], 20, 10); err == nil {
+		t.Fatalf("rescan with fromheight after toheight succeeded")
+	}
+}
+
+// TestResumeRescan ensures that a rescan which is interrupted by closing the
+// wallet resumes from the last height it scanned when the wallet is reopened.
+func TestResumeRescan(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create pkScript: %v", err)
+	}
+
+	const chainHeight = 250
+	c := &addrFilterChainClient{rescanChainClient: newRescanChainClient()}
+	c.addBlocks(0, chainHeight, 0)
+	payments := make(map[int32]*wire.MsgTx)
+	for _, height := range []int32{50, 150} {
+		tx := &wire.MsgTx{
+			Version: 1,
+			TxIn: []*wire.TxIn{{
+				PreviousOutPoint: wire.OutPoint{Index: uint32(height)},
+			}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(int64(height)*100000, pkScript)},
+		}
+		c.txns[height] = append(c.txns[height], tx)
+		payments[height] = tx
+	}
+	w.chainClient = c
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return w.Manager.SetSyncedTo(ns, &waddrmgr.BlockStamp{
+			Height:    chainHeight,
+			Hash:      c.hashes[chainHeight],
+			Timestamp: c.headers[c.hashes[chainHeight]].Timestamp,
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to set synced to: %v", err)
+	}
+
+	// Scan the first 100 blocks and then reopen the wallet, as if it had
+	// been restarted while rescanning.
+	if err := w.RescanAddress(addr, 10, -1); err != nil {
+		t.Fatalf("unable to start rescan: %v", err)
+	}
+	w.rescan()
+	if w.rescanJ == nil || w.rescanJ.height != 110 {
+		t.Fatalf("rescan did not stop at height 110: %+v", w.rescanJ)
+	}
+	reopened, err := Open(w.db, []byte("hello"), nil, w.chainParams, 250, w.cfg)
+	if err != nil {
+		t.Fatalf("unable to reopen wallet: %v", err)
+	}
+	if reopened.rescanJ == nil || reopened.rescanJ.height != 110 ||
+		reopened.rescanJ.name != w.rescanJ.name {
+		t.Fatalf("got resumed rescan %+v, want %s from height 110",
+			reopened.rescanJ, w.rescanJ.name)
+	}
+	reopened.chainClient = c
+	c.filtered = nil
+	for reopened.rescanJ != nil {
+		reopened.rescan()
+	}
+	for _, height := range c.filtered {
+		if height < 110 {
+			t.Fatalf("resumed rescan filtered block %d before height 110",
+				height)
+		}
+	}
+	if len(c.filtered) == 0 {
+		t.Fatalf("resumed rescan did not filter any blocks")
+	}
+
+	// Only the payment after the checkpoint is found by the resumed rescan,
+	// the one before it was found before the restart.
+	for height, tx := range payments {
+		hash := tx.TxHash()
+		var details *wtxmgr.TxDetails
+		err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+			var err er.R
+			details, err = w.TxStore.TxDetails(
+				dbtx.ReadBucket(wtxmgrNamespaceKey), &hash)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("unable to fetch transaction: %v", err)
+		}
+		if details == nil {
+			t.Fatalf("payment at height %d was not found", height)
+		}
+	}
+
+	// The checkpoint is removed once the rescan completes.
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) er.R {
+		rc, err := w.TxStore.FetchRescanCheckpoint(
+			dbtx.ReadBucket(wtxmgrNamespaceKey))
+		if err == nil && rc != nil {
+			t.Fatalf("checkpoint %+v left after the rescan completed", rc)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to fetch checkpoint: %v", err)
+	}
+}
+
+// TestCancelRescan ensures that a canceled rescan scans no further blocks and
+// that its progress is kept for it to resume when the wallet is reopened.
+func TestCancelRescan(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	if name := w.CancelRescan(); name != "" {
+		t.Fatalf("canceled rescan %s when none was running", name)
+	}
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to get new address: %v", err)
+	}
+	const chainHeight = 250
+	c := &addrFilterChainClient{rescanChainClient: newRescanChainClient()}
+	c.addBlocks(0, chainHeight, 0)
+	w.chainClient = c
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return w.Manager.SetSyncedTo(ns, &waddrmgr.BlockStamp{
+			Height:    chainHeight,
+			Hash:      c.hashes[chainHeight],
+			Timestamp: c.headers[c.hashes[chainHeight]].Timestamp,
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to set synced to: %v", err)
+	}
+
+	if err := w.RescanAddress(addr, 10, -1); err != nil {
+		t.Fatalf("unable to start rescan: %v", err)
+	}
+	w.rescan()
+	if w.rescanJ == nil || w.rescanJ.height != 110 {
+		t.Fatalf("rescan did not stop at height 110: %+v", w.rescanJ)
+	}
+	jobName := w.rescanJ.name
+	if name := w.CancelRescan(); name != jobName {
+		t.Fatalf("canceled rescan %q, want %q", name, jobName)
+	}
+
+	// No more blocks are scanned after the cancel.
+	c.filtered = nil
+	w.rescan()
+	if w.rescanJ != nil || len(c.filtered) != 0 {
+		t.Fatalf("canceled rescan continued: job %+v, filtered %v",
+			w.rescanJ, c.filtered)
+	}
+
+	// The progress is kept and the rescan resumes when the wallet is
+	// reopened.
+	reopened, err := Open(w.db, []byte("hello"), nil, w.chainParams, 250, w.cfg)
+	if err != nil {
+		t.Fatalf("unable to reopen wallet: %v", err)
+	}
+	if reopened.rescanJ == nil || reopened.rescanJ.height != 110 ||
+		reopened.rescanJ.name != jobName {
+		t.Fatalf("got resumed rescan %+v, want %s from height 110",
+			reopened.rescanJ, jobName)
+	}
+}
@@ -0,0 +1,121 @@
+package wallet
+
+import (
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/btcutil/hdkeychain"
+	"github.com/pkt-cash/pktd/pktlog/log"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// AccountDescriptor describes an account within one key scope well enough for
+// a watch-only wallet to derive the same addresses: the extended public key
+// and how many external and internal addresses have been issued.
+type AccountDescriptor struct {
+	Scope            waddrmgr.KeyScope
+	AddressType      waddrmgr.AddressType
+	Xpub             *hdkeychain.ExtendedKey
+	ExternalKeyCount uint32
+	InternalKeyCount uint32
+}
+
+// ExportAccountWatchOnly returns the name of an account and a descriptor of it
+// for each of the default key scopes, which ImportAccountWatchOnly accepts.
+func (w *Wallet) ExportAccountWatchOnly(account uint32) (string, []AccountDescriptor, er.R) {
+	var name string
+	var out []AccountDescriptor
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) er.R {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		for _, scope := range waddrmgr.DefaultKeyScopes {
+			manager, err := w.Manager.FetchScopedKeyManager(scope)
+			if waddrmgr.ErrScopeNotFound.Is(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			props, err := manager.AccountProperties(addrmgrNs, account)
+			if err != nil {
+				return err
+			}
+			if props.AccountPubKey == nil {
+				continue
+			}
+			name = props.AccountName
+			out = append(out, AccountDescriptor{
+				Scope:            scope,
+				AddressType:      manager.AddrSchema().ExternalAddrType,
+				Xpub:             props.AccountPubKey,
+				ExternalKeyCount: props.ExternalKeyCount,
+				InternalKeyCount: props.InternalKeyCount,
+			})
+		}
+		return nil
+	})
+	return name, out, err
+}
+
+// ImportAccountWatchOnly creates an account with the given name in each key
+// scope of descs from its extended public key, derives as many addresses as
+// were issued by the exported account and watches them.  The wallet must be
+// watching-only.  The addresses derived are returned so that they may be
+// rescanned for.
+func (w *Wallet) ImportAccountWatchOnly(name string,
+	descs []AccountDescriptor) ([]btcutil.Address, er.R) {
+
+	var addrs []btcutil.Address
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) er.R {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		for _, d := range descs {
+			if !d.Xpub.IsForNet(w.chainParams) {
+				return er.Errorf("extended key for scope %v is not for "+
+					"the %s network", d.Scope, w.chainParams.Name)
+			}
+			manager, err := w.Manager.FetchScopedKeyManager(d.Scope)
+			if err != nil {
+				return err
+			}
+			if manager.AddrSchema().ExternalAddrType != d.AddressType {
+				return er.Errorf("scope %v does not derive addresses "+
+					"of the type given", d.Scope)
+			}
+			account, err := manager.NewAccountWatchingOnly(addrmgrNs,
+				name, d.Xpub)
+			if err != nil {
+				return err
+			}
+			if d.ExternalKeyCount > 0 {
+				err := manager.ExtendExternalAddresses(addrmgrNs,
+					account, d.ExternalKeyCount-1)
+				if err != nil {
+					return err
+				}
+			}
+			if d.InternalKeyCount > 0 {
+				err := manager.ExtendInternalAddresses(addrmgrNs,
+					account, d.InternalKeyCount-1)
+				if err != nil {
+					return err
+				}
+			}
+			err = manager.ForEachAccountAddress(addrmgrNs, account,
+				func(ma waddrmgr.ManagedAddress) er.R {
+					addrs = append(addrs, ma.Address())
+					return nil
+				})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		w.watch.WatchAddr(addr)
+	}
+	log.Infof("Imported watch-only account [%s] with %d addresses",
+		name, len(addrs))
+	return addrs, nil
+}
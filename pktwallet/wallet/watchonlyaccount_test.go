@@ -0,0 +1,97 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/waddrmgr"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// TestAccountWatchOnlyRoundTrip exports an account of a full wallet, imports
+// it into a watch-only wallet and checks that the same addresses are derived.
+func TestAccountWatchOnlyRoundTrip(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	for _, scope := range []waddrmgr.KeyScope{
+		waddrmgr.KeyScopeBIP0084, waddrmgr.KeyScopeBIP0084,
+		waddrmgr.KeyScopeBIP0084, waddrmgr.KeyScopeBIP0044,
+	} {
+		if _, err := w.NewAddress(0, scope); err != nil {
+			t.Fatalf("unable to get new address: %v", err)
+		}
+	}
+	err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		manager, err := w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0084)
+		if err != nil {
+			return err
+		}
+		_, err = manager.NextInternalAddresses(
+			dbtx.ReadWriteBucket(waddrmgrNamespaceKey), 0, 2)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to get change addresses: %v", err)
+	}
+
+	name, descs, err := w.ExportAccountWatchOnly(0)
+	if err != nil {
+		t.Fatalf("unable to export account: %v", err)
+	}
+	if name != "default" || len(descs) != len(waddrmgr.DefaultKeyScopes) {
+		t.Fatalf("got account %q with %d descriptors", name, len(descs))
+	}
+	for _, d := range descs {
+		if d.Xpub.IsPrivate() {
+			t.Fatalf("exported a private key for scope %v", d.Scope)
+		}
+	}
+
+	// The account cannot be imported into a wallet holding private keys.
+	watchOnly, cleanup2 := testWallet(t)
+	defer cleanup2()
+	if _, err := watchOnly.ImportAccountWatchOnly("watched", descs); !waddrmgr.ErrInvalidAccount.Is(err) {
+		t.Fatalf("got error %v importing into a full wallet, want "+
+			"ErrInvalidAccount", err)
+	}
+	err = walletdb.Update(watchOnly.db, func(dbtx walletdb.ReadWriteTx) er.R {
+		return watchOnly.Manager.ConvertToWatchingOnly(
+			dbtx.ReadWriteBucket(waddrmgrNamespaceKey))
+	})
+	if err != nil {
+		t.Fatalf("unable to convert to watching-only: %v", err)
+	}
+
+	imported, err := watchOnly.ImportAccountWatchOnly("watched", descs)
+	if err != nil {
+		t.Fatalf("unable to import account: %v", err)
+	}
+	want, err := w.AccountAddresses(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := watchOnly.AccountAddresses(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(want) != 6 || len(got) != len(want) || len(imported) != len(want) {
+		t.Fatalf("got %d addresses (%d imported), want %d", len(got),
+			len(imported), len(want))
+	}
+	addrs := make(map[string]bool)
+	for _, a := range want {
+		addrs[a.EncodeAddress()] = true
+	}
+	for _, a := range got {
+		if !addrs[a.EncodeAddress()] {
+			t.Fatalf("address %v was not derived by the exported account", a)
+		}
+	}
+	for _, a := range imported {
+		if !addrs[a.EncodeAddress()] {
+			t.Fatalf("imported address %v was not derived by the "+
+				"exported account", a)
+		}
+	}
+}
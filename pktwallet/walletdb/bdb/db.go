@@ -5,6 +5,7 @@
 package bdb
 
 import (
+	"encoding/hex"
 	"io"
 	"os"
 
@@ -24,7 +25,7 @@ func convertErr0(err error) *er.ErrorCode {
 		return walletdb.ErrInvalid
 
 	// Transaction errors.
-	case bbolt.ErrTxNotWritable:
+	case bbolt.ErrTxNotWritable, bbolt.ErrDatabaseReadOnly:
 		return walletdb.ErrTxNotWritable
 	case bbolt.ErrTxClosed:
 		return walletdb.ErrTxClosed
@@ -346,6 +347,9 @@ type db bbolt.DB
 // Enforce db implements the walletdb.Db interface.
 var _ walletdb.DB = (*db)(nil)
 
+// Enforce db implements the walletdb.StatsDB interface.
+var _ walletdb.StatsDB = (*db)(nil)
+
 func (db *db) beginTx(writable bool) (*transaction, er.R) {
 	boltTx, err := (*bbolt.DB)(db).Begin(writable)
 	if err != nil {
@@ -391,6 +395,55 @@ func (db *db) Batch(f func(tx walletdb.ReadWriteTx) er.R) er.R {
 	}))
 }
 
+// FileSize returns the size, in bytes, of the database file.
+//
+// This function is part of the walletdb.StatsDB interface implementation.
+func (db *db) FileSize() (int64, er.R) {
+	fi, err := os.Stat((*bbolt.DB)(db).Path())
+	if err != nil {
+		return 0, er.E(err)
+	}
+	return fi.Size(), nil
+}
+
+// BucketStats returns the stats of each top level bucket and each bucket
+// nested directly in one, keyed by the path of the bucket.
+//
+// This function is part of the walletdb.StatsDB interface implementation.
+func (db *db) BucketStats() (map[string]walletdb.BucketStats, er.R) {
+	stats := make(map[string]walletdb.BucketStats)
+	add := func(path string, b *bbolt.Bucket) {
+		s := b.Stats()
+		stats[path] = walletdb.BucketStats{
+			Keys: s.KeyN,
+			Size: int64(s.BranchInuse + s.LeafInuse + s.InlineBucketInuse),
+		}
+	}
+	return stats, convertErr((*bbolt.DB)(db).View(func(tx *bbolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bbolt.Bucket) error {
+			add(bucketName(name), b)
+			return b.ForEach(func(k, v []byte) error {
+				// Nested buckets have no value.
+				if v == nil {
+					add(bucketName(name)+"/"+bucketName(k), b.Bucket(k))
+				}
+				return nil
+			})
+		})
+	}))
+}
+
+// bucketName returns the name of a bucket for display, names which are not
+// printable are hex encoded.
+func bucketName(name []byte) string {
+	for _, c := range name {
+		if c < 0x21 || c > 0x7e || c == '/' {
+			return hex.EncodeToString(name)
+		}
+	}
+	return string(name)
+}
+
 // filesExists reports whether the named file or directory exists.
 func fileExists(name string) bool {
 	if _, err := os.Stat(name); err != nil {
@@ -401,13 +454,49 @@ func fileExists(name string) bool {
 	return true
 }
 
-// openDB opens the database at the provided path.  walletdb.ErrDbDoesNotExist
-// is returned if the database doesn't exist and the create flag is not set.
-func openDB(dbPath string, create, noFreeListSync bool) (walletdb.DB, er.R) {
+// locker opens a bolt database, locking its file as a walletdb.LockMode says.
+type locker interface {
+	open(path string) (*bbolt.DB, error)
+}
+
+// flockLocker opens a database with bolt's exclusive flock, so that no other
+// process can open it until it is closed.
+type flockLocker struct{}
+
+func (flockLocker) open(path string) (*bbolt.DB, error) {
+	return bbolt.Open(path, 0600, nil)
+}
+
+// noLocker opens a database without the exclusive flock.  Bolt takes the lock
+// on every database which it opens for writing, so the database is opened read
+// only, which bolt takes only a shared lock for and which is all that a file on
+// a read-only mount allows.
+type noLocker struct{}
+
+func (noLocker) open(path string) (*bbolt.DB, error) {
+	return bbolt.Open(path, 0600, &bbolt.Options{ReadOnly: true})
+}
+
+// lockers is the locker of each lock mode.
+var lockers = map[walletdb.LockMode]locker{
+	walletdb.LockFlock: flockLocker{},
+	walletdb.LockNone:  noLocker{},
+}
+
+// openDB opens the database at the provided path, locking it as mode says.
+// walletdb.ErrDbDoesNotExist is returned if the database doesn't exist and the
+// create flag is not set.
+func openDB(dbPath string, create, noFreeListSync bool,
+	mode walletdb.LockMode) (walletdb.DB, er.R) {
+
+	l, ok := lockers[mode]
+	if !ok || create && mode != walletdb.LockFlock {
+		return nil, walletdb.ErrLockMode.New(string(mode), nil)
+	}
 	if !create && !fileExists(dbPath) {
 		return nil, walletdb.ErrDbDoesNotExist.Default()
 	}
 
-	boltDB, err := bbolt.Open(dbPath, 0600, nil)
+	boltDB, err := l.open(dbPath)
 	return (*db)(boltDB), convertErr(err)
 }
@@ -21,5 +21,12 @@ database path as a string:
 	if err != nil {
 		// Handle error
 	}
+
+Locking
+
+Bolt locks the database file exclusively with flock while it is open.  A
+database which flock does not work for, such as one on a read-only mount, can
+be opened with walletdb.OpenLockMode and walletdb.LockNone, which opens it read
+only without the exclusive lock.
 */
 package bdb
@@ -35,13 +35,21 @@ func parseArgs(funcName string, args ...interface{}) (string, er.R) {
 // openDBDriver is the callback provided during driver registration that opens
 // an existing database for use.
 func openDBDriver(dbPath string, noFreeListSync bool) (walletdb.DB, er.R) {
-	return openDB(dbPath, false, noFreeListSync)
+	return openDB(dbPath, false, noFreeListSync, walletdb.LockFlock)
+}
+
+// openLockModeDBDriver is the callback provided during driver registration that
+// opens an existing database for use, locking it as mode says.
+func openLockModeDBDriver(dbPath string, noFreeListSync bool,
+	mode walletdb.LockMode) (walletdb.DB, er.R) {
+
+	return openDB(dbPath, false, noFreeListSync, mode)
 }
 
 // createDBDriver is the callback provided during driver registration that
 // creates, initializes, and opens a database for use.
 func createDBDriver(dbPath string, noFreeListSync bool) (walletdb.DB, er.R) {
-	return openDB(dbPath, true, noFreeListSync)
+	return openDB(dbPath, true, noFreeListSync, walletdb.LockFlock)
 }
 
 func init() {
@@ -50,6 +58,8 @@ func init() {
 		DbType: dbType,
 		Create: createDBDriver,
 		Open:   openDBDriver,
+
+		OpenLockMode: openLockModeDBDriver,
 	}
 	if err := walletdb.RegisterDriver(driver); err != nil {
 		panic(fmt.Sprintf("Failed to regiser database driver '%s': %v",
@@ -0,0 +1,85 @@
+package bdb
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"go.etcd.io/bbolt"
+)
+
+// failingLocker fails to lock every database, as flock does on a filesystem
+// which does not support it.
+type failingLocker struct{}
+
+func (failingLocker) open(path string) (*bbolt.DB, error) {
+	return nil, errors.New("no locks available")
+}
+
+// TestLockModeNone ensures that a database which cannot be locked with flock
+// can still be opened, read only, with the none lock mode.
+func TestLockModeNone(t *testing.T) {
+	dir, errr := ioutil.TempDir("", "bdblock")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer os.RemoveAll(dir)
+	dbPath := filepath.Join(dir, "lock.db")
+
+	db, err := openDB(dbPath, true, true, walletdb.LockFlock)
+	if err != nil {
+		t.Fatalf("unable to create database: %v", err)
+	}
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) er.R {
+		_, err := tx.CreateTopLevelBucket([]byte("lock"))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to create bucket: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("unable to close database: %v", err)
+	}
+
+	defer func(l locker) { lockers[walletdb.LockFlock] = l }(lockers[walletdb.LockFlock])
+	lockers[walletdb.LockFlock] = failingLocker{}
+
+	if _, err := openDB(dbPath, false, true, walletdb.LockFlock); err == nil {
+		t.Fatalf("opened database which cannot be locked with flock")
+	}
+	if _, err := openDB(dbPath, false, true, "fcntl"); !walletdb.ErrLockMode.Is(err) {
+		t.Fatalf("got error %v opening with an unknown lock mode, want "+
+			"ErrLockMode", err)
+	}
+	if _, err := openDB(dbPath, true, true, walletdb.LockNone); !walletdb.ErrLockMode.Is(err) {
+		t.Fatalf("got error %v creating without a lock, want ErrLockMode",
+			err)
+	}
+
+	db, err = openDB(dbPath, false, true, walletdb.LockNone)
+	if err != nil {
+		t.Fatalf("unable to open database without a lock: %v", err)
+	}
+	defer db.Close()
+	err = walletdb.View(db, func(tx walletdb.ReadTx) er.R {
+		if tx.ReadBucket([]byte("lock")) == nil {
+			t.Fatalf("bucket not found opening without a lock")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) er.R {
+		_, err := tx.CreateTopLevelBucket([]byte("write"))
+		return err
+	})
+	if !walletdb.ErrTxNotWritable.Is(err) {
+		t.Fatalf("got error %v writing a database opened without a "+
+			"lock, want ErrTxNotWritable", err)
+	}
+}
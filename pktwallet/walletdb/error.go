@@ -35,6 +35,11 @@ var (
 	ErrDbNotOpen = Err.CodeWithDetail("ErrDbNotOpen",
 		"database not open")
 
+	// ErrLockMode is returned when open is called with a lock mode which
+	// the database does not support.
+	ErrLockMode = Err.CodeWithDetail("ErrLockMode",
+		"unsupported database lock mode")
+
 	// ErrInvalid is returned if the specified database is not valid.
 	ErrInvalid = Err.CodeWithDetail("ErrInvalid",
 		"invalid database")
@@ -0,0 +1,192 @@
+package walletdb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+)
+
+// DefaultFlushInterval is how long a FlushBatcher which is not given an
+// interval waits for more updates before committing a batch.
+const DefaultFlushInterval = 10 * time.Millisecond
+
+// FlushBatcher is a DB which commits the updates made with the package-level
+// Update method in batches, several of them in one transaction, rather than
+// one transaction each.  A batch is committed once it holds maxUpdates updates
+// or once interval has passed since its first update, whichever is sooner.
+// Each commit is synced to disk as usual, Update returns once the batch which
+// it joined is committed, so an update which has returned is never lost.  The
+// cost is the wait for the batch, which is at most interval.
+//
+// If an update fails, the batch is rolled back and each of its updates is run
+// again in a transaction of its own, so that only the update which failed is
+// lost.  As with bolt's Batch, the functions given to Update must therefore be
+// safe to run more than once.  Transactions begun directly, with
+// BeginReadWriteTx, are not batched.
+//
+// Closing the FlushBatcher commits the updates which are still waiting for
+// their batch before the database is closed.
+type FlushBatcher struct {
+	DB
+
+	maxUpdates int
+	interval   time.Duration
+
+	mtx    sync.Mutex
+	batch  *updateBatch
+	closed bool
+
+	wg sync.WaitGroup
+}
+
+// Enforce FlushBatcher implements the StatsDB, BatchDB and UpdateDB
+// interfaces.
+var _ StatsDB = (*FlushBatcher)(nil)
+var _ BatchDB = (*FlushBatcher)(nil)
+var _ UpdateDB = (*FlushBatcher)(nil)
+
+// NewFlushBatcher returns a FlushBatcher which commits the updates to db in
+// batches of up to maxUpdates, or of any size if it is zero, each collected for
+// at most interval, or DefaultFlushInterval if it is zero.
+func NewFlushBatcher(db DB, maxUpdates int, interval time.Duration) *FlushBatcher {
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+	return &FlushBatcher{
+		DB:         db,
+		maxUpdates: maxUpdates,
+		interval:   interval,
+	}
+}
+
+// updateBatch is the updates which are committed together in one transaction.
+type updateBatch struct {
+	b     *FlushBatcher
+	timer *time.Timer
+	start sync.Once
+	calls []updateCall
+}
+
+// updateCall is an update waiting for its batch to be committed.
+type updateCall struct {
+	f   func(tx ReadWriteTx) er.R
+	err chan er.R
+}
+
+// Update runs f in the batch which is being collected, returning once the
+// batch is committed.
+//
+// This function is part of the UpdateDB interface implementation.
+func (b *FlushBatcher) Update(f func(tx ReadWriteTx) er.R) er.R {
+	errc := make(chan er.R, 1)
+
+	b.mtx.Lock()
+	if b.closed {
+		b.mtx.Unlock()
+		return ErrDbNotOpen.Default()
+	}
+	if b.batch == nil {
+		ub := &updateBatch{b: b}
+		ub.timer = time.AfterFunc(b.interval, ub.trigger)
+		b.batch = ub
+		b.wg.Add(1)
+	}
+	ub := b.batch
+	ub.calls = append(ub.calls, updateCall{f: f, err: errc})
+	if b.maxUpdates > 0 && len(ub.calls) >= b.maxUpdates {
+		// The batch is full, it is committed now and the next update
+		// starts another.
+		b.batch = nil
+		go ub.trigger()
+	}
+	b.mtx.Unlock()
+
+	return <-errc
+}
+
+// trigger commits the batch, if it has not already been committed.
+func (ub *updateBatch) trigger() {
+	ub.start.Do(ub.run)
+}
+
+// run commits the updates of the batch in one transaction, or each in its own
+// if one of them fails.
+func (ub *updateBatch) run() {
+	b := ub.b
+	defer b.wg.Done()
+
+	b.mtx.Lock()
+	ub.timer.Stop()
+	if b.batch == ub {
+		b.batch = nil
+	}
+	b.mtx.Unlock()
+
+	failed := -1
+	err := Update(b.DB, func(tx ReadWriteTx) er.R {
+		for i, c := range ub.calls {
+			if err := c.f(tx); err != nil {
+				failed = i
+				return err
+			}
+		}
+		return nil
+	})
+	switch {
+	case failed >= 0 && len(ub.calls) > 1:
+		for _, c := range ub.calls {
+			c.err <- Update(b.DB, c.f)
+		}
+	default:
+		for _, c := range ub.calls {
+			c.err <- err
+		}
+	}
+}
+
+// Batch runs f in the batch which is being collected, as Update does.
+//
+// This function is part of the BatchDB interface implementation.
+func (b *FlushBatcher) Batch(f func(tx ReadWriteTx) er.R) er.R {
+	return b.Update(f)
+}
+
+// Close commits the updates which are waiting for their batch and closes the
+// database.
+//
+// This function is part of the DB interface implementation.
+func (b *FlushBatcher) Close() er.R {
+	b.mtx.Lock()
+	b.closed = true
+	ub := b.batch
+	b.mtx.Unlock()
+	if ub != nil {
+		ub.trigger()
+	}
+	b.wg.Wait()
+	return b.DB.Close()
+}
+
+// FileSize returns the size, in bytes, of the database file.
+//
+// This function is part of the StatsDB interface implementation.
+func (b *FlushBatcher) FileSize() (int64, er.R) {
+	sdb, ok := b.DB.(StatsDB)
+	if !ok {
+		return 0, er.New("database does not report storage stats")
+	}
+	return sdb.FileSize()
+}
+
+// BucketStats returns the stats of each top level bucket and each bucket
+// nested directly in one.
+//
+// This function is part of the StatsDB interface implementation.
+func (b *FlushBatcher) BucketStats() (map[string]BucketStats, er.R) {
+	sdb, ok := b.DB.(StatsDB)
+	if !ok {
+		return nil, er.New("database does not report storage stats")
+	}
+	return sdb.BucketStats()
+}
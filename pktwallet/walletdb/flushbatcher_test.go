@@ -0,0 +1,263 @@
+package walletdb_test
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	_ "github.com/pkt-cash/pktd/pktwallet/walletdb/bdb"
+)
+
+// countingDB counts the read/write transactions committed to a database.
+type countingDB struct {
+	walletdb.DB
+	commits int32
+}
+
+func (c *countingDB) BeginReadWriteTx() (walletdb.ReadWriteTx, er.R) {
+	tx, err := c.DB.BeginReadWriteTx()
+	if err != nil {
+		return nil, err
+	}
+	return &countingTx{ReadWriteTx: tx, c: c}, nil
+}
+
+// countingTx is a transaction of a countingDB.
+type countingTx struct {
+	walletdb.ReadWriteTx
+	c *countingDB
+}
+
+func (tx *countingTx) Commit() er.R {
+	if err := tx.ReadWriteTx.Commit(); err != nil {
+		return err
+	}
+	atomic.AddInt32(&tx.c.commits, 1)
+	return nil
+}
+
+var flushBucket = []byte("flush")
+
+// putKey writes the key i to the flush bucket in one update.
+func putKey(db walletdb.DB, i int) er.R {
+	return walletdb.Update(db, func(tx walletdb.ReadWriteTx) er.R {
+		b, err := tx.CreateTopLevelBucket(flushBucket)
+		if err != nil {
+			return err
+		}
+		var k [4]byte
+		binary.BigEndian.PutUint32(k[:], uint32(i))
+		return b.Put(k[:], k[:])
+	})
+}
+
+// putKeys writes the keys [from, to) to the flush bucket, each in an update
+// of its own made at the same time as the others.
+func putKeys(db walletdb.DB, from, to int) er.R {
+	errs := make(chan er.R, to-from)
+	for i := from; i < to; i++ {
+		go func(i int) {
+			errs <- putKey(db, i)
+		}(i)
+	}
+	for i := from; i < to; i++ {
+		if err := <-errs; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createDB creates a bolt database in dir.
+func createDB(t testing.TB, dir string) walletdb.DB {
+	db, err := walletdb.Create("bdb", filepath.Join(dir, "flush.db"), true)
+	if err != nil {
+		t.Fatalf("unable to create database: %v", err)
+	}
+	return db
+}
+
+// countKeys returns the number of keys in the flush bucket of the database in
+// dir, reopening it.
+func countKeys(t *testing.T, dir string) int {
+	db, err := walletdb.Open("bdb", filepath.Join(dir, "flush.db"), true)
+	if err != nil {
+		t.Fatalf("unable to reopen database: %v", err)
+	}
+	defer db.Close()
+	n := 0
+	err = walletdb.View(db, func(tx walletdb.ReadTx) er.R {
+		b := tx.ReadBucket(flushBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) er.R {
+			n++
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+// TestFlushBatcher checks that updates are committed in transactions of a
+// batch each rather than one each, and that all of them are found in the
+// database after it is closed and reopened.
+func TestFlushBatcher(t *testing.T) {
+	dir, errr := ioutil.TempDir("", "flushbatcher")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer os.RemoveAll(dir)
+
+	counter := &countingDB{DB: createDB(t, dir)}
+	db := walletdb.NewFlushBatcher(counter, 5, time.Hour)
+	if err := putKeys(db, 0, 25); err != nil {
+		t.Fatalf("unable to put keys: %v", err)
+	}
+	if n := atomic.LoadInt32(&counter.commits); n != 5 {
+		t.Fatalf("got %d transactions for 25 updates in batches of 5, "+
+			"want 5", n)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("unable to close database: %v", err)
+	}
+	if n := countKeys(t, dir); n != 25 {
+		t.Fatalf("found %d keys after reopening, want 25", n)
+	}
+}
+
+// TestFlushBatcherFailedUpdate checks that an update which fails is rolled
+// back on its own while the others of its batch are committed.
+func TestFlushBatcherFailedUpdate(t *testing.T) {
+	dir, errr := ioutil.TempDir("", "flushbatcher")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer os.RemoveAll(dir)
+
+	db := walletdb.NewFlushBatcher(createDB(t, dir), 4, time.Hour)
+	errs := make(chan er.R, 1)
+	go func() {
+		errs <- walletdb.Update(db, func(tx walletdb.ReadWriteTx) er.R {
+			b, err := tx.CreateTopLevelBucket(flushBucket)
+			if err != nil {
+				return err
+			}
+			if err := b.Put([]byte("rolledback"), []byte{1}); err != nil {
+				return err
+			}
+			return er.New("failed update")
+		})
+	}()
+	if err := putKeys(db, 0, 3); err != nil {
+		t.Fatalf("unable to put keys: %v", err)
+	}
+	if err := <-errs; err == nil {
+		t.Fatalf("failed update was committed")
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("unable to close database: %v", err)
+	}
+	if n := countKeys(t, dir); n != 3 {
+		t.Fatalf("found %d keys after reopening, want the 3 which "+
+			"did not fail", n)
+	}
+}
+
+// TestFlushBatcherInterval checks that a batch which is not filled is
+// committed once the interval has passed, and that updates waiting for their
+// batch are committed when the FlushBatcher is closed.
+func TestFlushBatcherInterval(t *testing.T) {
+	dir, errr := ioutil.TempDir("", "flushbatcher")
+	if errr != nil {
+		t.Fatal(errr)
+	}
+	defer os.RemoveAll(dir)
+
+	counter := &countingDB{DB: createDB(t, dir)}
+	db := walletdb.NewFlushBatcher(counter, 0, 10*time.Millisecond)
+	if err := putKey(db, 0); err != nil {
+		t.Fatalf("unable to put key: %v", err)
+	}
+	if n := atomic.LoadInt32(&counter.commits); n != 1 {
+		t.Fatalf("got %d transactions after the interval, want 1", n)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("unable to close database: %v", err)
+	}
+
+	counter = &countingDB{DB: func() walletdb.DB {
+		db, err := walletdb.Open("bdb", filepath.Join(dir, "flush.db"), true)
+		if err != nil {
+			t.Fatalf("unable to reopen database: %v", err)
+		}
+		return db
+	}()}
+	db = walletdb.NewFlushBatcher(counter, 0, time.Hour)
+	var wg sync.WaitGroup
+	for i := 1; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := putKey(db, i); err != nil {
+				t.Errorf("unable to put key: %v", err)
+			}
+		}(i)
+	}
+	// Give the updates time to join the batch, which is not otherwise
+	// committed for an hour.
+	time.Sleep(100 * time.Millisecond)
+	if n := atomic.LoadInt32(&counter.commits); n != 0 {
+		t.Fatalf("got %d transactions before the interval, want 0", n)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("unable to close database: %v", err)
+	}
+	wg.Wait()
+	if n := atomic.LoadInt32(&counter.commits); n != 1 {
+		t.Fatalf("got %d transactions after close, want 1", n)
+	}
+	if n := countKeys(t, dir); n != 4 {
+		t.Fatalf("found %d keys after reopening, want 4", n)
+	}
+}
+
+// benchmarkUpdates benchmarks single key updates made by parallel callers,
+// committed in batches of up to maxUpdates.
+func benchmarkUpdates(b *testing.B, maxUpdates int) {
+	dir, errr := ioutil.TempDir("", "flushbatcher")
+	if errr != nil {
+		b.Fatal(errr)
+	}
+	defer os.RemoveAll(dir)
+
+	var db walletdb.DB = createDB(b, dir)
+	if maxUpdates > 1 {
+		db = walletdb.NewFlushBatcher(db, maxUpdates, 0)
+	}
+	defer db.Close()
+	var next int32
+	b.SetParallelism(maxUpdates)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := putKey(db, int(atomic.AddInt32(&next, 1))); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+func BenchmarkUpdatesUnbatched(b *testing.B) { benchmarkUpdates(b, 1) }
+func BenchmarkUpdatesBatched(b *testing.B)   { benchmarkUpdates(b, 100) }
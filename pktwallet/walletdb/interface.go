@@ -218,6 +218,43 @@ type BatchDB interface {
 	Batch(func(tx ReadWriteTx) er.R) er.R
 }
 
+// BucketStats describes the storage used by a bucket, including the buckets
+// which are nested in it.
+type BucketStats struct {
+	// Keys is the number of keys in the bucket and its nested buckets.
+	Keys int
+
+	// Size is the number of bytes in use by the bucket and its nested
+	// buckets.
+	Size int64
+}
+
+// StatsDB is a special version of the main DB interface for databases which
+// are able to report on the storage they use.
+type StatsDB interface {
+	DB
+
+	// FileSize returns the size, in bytes, of the database file.
+	FileSize() (int64, er.R)
+
+	// BucketStats returns the stats of each top level bucket and each
+	// bucket nested directly in one, keyed by the path of the bucket with
+	// the names separated by "/".
+	BucketStats() (map[string]BucketStats, er.R)
+}
+
+// UpdateDB is a special version of the main DB interface for databases which
+// run the updates made with the package-level Update method in a way of their
+// own, such as by committing several of them in one transaction.
+type UpdateDB interface {
+	DB
+
+	// Update runs f in a read/write transaction as the package-level
+	// Update method does, returning once the transaction is committed or
+	// f fails.
+	Update(f func(tx ReadWriteTx) er.R) er.R
+}
+
 // View opens a database read transaction and executes the function f with the
 // transaction passed as a parameter.  After f exits, the transaction is rolled
 // back.  If f errors, its er.R is returned, not a rollback er.R (if any
@@ -252,8 +289,11 @@ func View(db DB, f func(tx ReadTx) er.R) er.R {
 // error, the transaction is committed.  Otherwise, if f did error, the
 // transaction is rolled back.  If the rollback fails, the original error
 // returned by f is still returned.  If the commit fails, the commit error is
-// returned.
+// returned.  A database which implements UpdateDB runs f itself.
 func Update(db DB, f func(tx ReadWriteTx) er.R) er.R {
+	if udb, ok := db.(UpdateDB); ok {
+		return udb.Update(f)
+	}
 	tx, err := db.BeginReadWriteTx()
 	if err != nil {
 		return err
@@ -307,8 +347,27 @@ type Driver struct {
 	// arguments to open the database.  This function must return
 	// ErrDbDoesNotExist if the database has not already been created.
 	Open func(path string, noFreeListSync bool) (DB, er.R)
+
+	// OpenLockMode opens the database as Open does, locking it as mode
+	// says.  It is nil for drivers which only lock in the default way.
+	OpenLockMode func(path string, noFreeListSync bool, mode LockMode) (DB, er.R)
 }
 
+// LockMode is how a database locks its file so that no other process uses it
+// at the same time.
+type LockMode string
+
+const (
+	// LockFlock locks the database file exclusively with flock, it is the
+	// default.
+	LockFlock LockMode = "flock"
+
+	// LockNone takes no exclusive lock on the database file, for files on
+	// read-only mounts or networked filesystems where flock does not work.
+	// Nothing stops another process writing the database meanwhile.
+	LockNone LockMode = "none"
+)
+
 // driverList holds all of the registered database backends.
 var drivers = make(map[string]*Driver)
 
@@ -361,3 +420,22 @@ func Open(dbType, path string, noFreeListSync bool) (DB, er.R) {
 
 	return drv.Open(path, noFreeListSync)
 }
+
+// OpenLockMode opens an existing database for the specified type as Open does,
+// locking it as mode says.
+//
+// ErrDbUnknownType will be returned if the the database type is not registered
+// and ErrLockMode if it cannot be locked as mode says.
+func OpenLockMode(dbType, path string, noFreeListSync bool, mode LockMode) (DB, er.R) {
+	drv, exists := drivers[dbType]
+	if !exists {
+		return nil, ErrDbUnknownType.Default()
+	}
+	if drv.OpenLockMode != nil {
+		return drv.OpenLockMode(path, noFreeListSync, mode)
+	}
+	if mode != LockFlock {
+		return nil, ErrLockMode.New(string(mode), nil)
+	}
+	return drv.Open(path, noFreeListSync)
+}
@@ -1,6 +1,7 @@
 package migration
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/pkt-cash/pktd/btcutil/er"
@@ -103,6 +104,46 @@ func Upgrade(mgrs ...Manager) er.R {
 	return nil
 }
 
+// CheckReversion returns ErrReversion if any of the services exposed through
+// their implementation of the Manager interface has a database version newer
+// than the latest one, as written by a newer version of the software.  The
+// error names the service and the version which is needed to open it.
+func CheckReversion(mgrs ...Manager) er.R {
+	for _, mgr := range mgrs {
+		currentVersion, err := mgr.CurrentVersion(mgr.Namespace())
+		if err != nil {
+			return err
+		}
+		latestVersion := GetLatestVersion(mgr.Versions())
+		if currentVersion > latestVersion {
+			return ErrReversion.New(fmt.Sprintf("the %s database is at "+
+				"version [%d] but only versions up to [%d] are "+
+				"understood, a newer version which understands version [%d] "+
+				"is required to open it", mgr.Name(), currentVersion,
+				latestVersion, currentVersion), nil)
+		}
+	}
+
+	return nil
+}
+
+// NeedsUpgrade returns whether any of the services exposed through their
+// implementation of the Manager interface has a database version older than
+// the latest one, so Upgrade would apply migrations to it.
+func NeedsUpgrade(mgrs ...Manager) (bool, er.R) {
+	for _, mgr := range mgrs {
+		currentVersion, err := mgr.CurrentVersion(mgr.Namespace())
+		if err != nil {
+			return false, err
+		}
+		if currentVersion < GetLatestVersion(mgr.Versions()) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // upgrade attempts to upgrade a service expose through its implementation of
 // the Manager interface. This function will determine whether any new versions
 // need to be applied based on the service's current version and latest
@@ -123,7 +164,7 @@ func upgrade(mgr Manager) er.R {
 	// backwards-incompatible. To prevent this, we'll return an error
 	// indicating so.
 	case currentVersion > latestVersion:
-		return ErrReversion.Default()
+		return CheckReversion(mgr)
 
 	// If the current version is behind the latest version, we'll need to
 	// apply all of the newer versions in order to catch up to the latest.
@@ -119,6 +119,7 @@ func createWallet(cfg *config) er.R {
 	dbDir := networkDir(cfg.AppDataDir.Value, activeNet.Params)
 	// TODO(cjd): noFreelistSync ?
 	loader := wallet.NewLoader(activeNet.Params, dbDir, cfg.Wallet, false, 250)
+	loader.SetConfig(cfg.walletConfig)
 
 	// When there is a legacy keystore, open it now to ensure any errors
 	// don't end up exiting the process after the user has spent time
@@ -164,7 +165,17 @@ func createWallet(cfg *config) er.R {
 			pubPass = []byte(*setupCfg.PublicPassphrase)
 		}
 		if setupCfg.Seed != nil {
-			if decoded, err := hex.DecodeString(*setupCfg.Seed); err == nil {
+			if seedwords.IsBackup(*setupCfg.Seed) {
+				if setupCfg.SeedPassphrase == nil {
+					return er.New("The provided seed backup requires a passphrase")
+				}
+				s, err := seedwords.DecryptBackup(*setupCfg.Seed,
+					[]byte(*setupCfg.SeedPassphrase))
+				if err != nil {
+					return err
+				}
+				seed = s
+			} else if decoded, err := hex.DecodeString(*setupCfg.Seed); err == nil {
 				zero.Bytes(decoded)
 				seedInput = []byte(*setupCfg.Seed)
 			} else {
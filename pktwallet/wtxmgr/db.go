@@ -72,12 +72,18 @@ var (
 	bucketUnminedCredits = []byte("mc")
 	bucketUnminedInputs  = []byte("mi")
 	bucketLockedOutputs  = []byte("lo")
+	bucketSpends         = []byte("sp")
+	bucketConflicted     = []byte("cf")
+	bucketFrozenScripts  = []byte("fz")
+	bucketTxComments     = []byte("cm")
+	bucketRescan         = []byte("rs")
 )
 
 // Root (namespace) bucket keys
 var (
 	rootCreateDate = []byte("date")
 	rootVersion    = []byte("vers")
+	rootChange     = []byte("chng")
 )
 
 // Several data structures are given canonical serialization formats as either
@@ -1305,6 +1311,357 @@ func forEachLockedOutput(ns walletdb.ReadBucket,
 	})
 }
 
+// Spends are keyed by the time of the spend followed by the transaction hash
+// so that they are ordered by time.  The value is the amount spent.
+//
+// The spends bucket is not removed when the transaction history is dropped
+// because it records what was sent rather than what is on chain.
+func keySpend(txHash *chainhash.Hash, t time.Time) []byte {
+	k := make([]byte, 8+32)
+	byteOrder.PutUint64(k, uint64(t.UnixNano()))
+	copy(k[8:], txHash[:])
+	return k
+}
+
+func putSpend(ns walletdb.ReadWriteBucket, txHash *chainhash.Hash,
+	t time.Time, amount btcutil.Amount) er.R {
+
+	spends, err := ns.CreateBucketIfNotExists(bucketSpends)
+	if err != nil {
+		str := "failed to create spends bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	var v [8]byte
+	byteOrder.PutUint64(v[:], uint64(amount))
+	if err := spends.Put(keySpend(txHash, t), v[:]); err != nil {
+		str := fmt.Sprintf("%s: put failed for %v", bucketSpends, txHash)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func deleteSpend(ns walletdb.ReadWriteBucket, txHash *chainhash.Hash,
+	t time.Time) er.R {
+
+	spends := ns.NestedReadWriteBucket(bucketSpends)
+	if spends == nil {
+		return nil
+	}
+	if err := spends.Delete(keySpend(txHash, t)); err != nil {
+		str := fmt.Sprintf("%s: delete failed for %v", bucketSpends, txHash)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// sumSpendsSince totals the amounts of all spends made at or after time t.
+func sumSpendsSince(ns walletdb.ReadBucket, t time.Time) btcutil.Amount {
+	spends := ns.NestedReadBucket(bucketSpends)
+	if spends == nil {
+		return 0
+	}
+	var seek [8]byte
+	byteOrder.PutUint64(seek[:], uint64(t.UnixNano()))
+	var total btcutil.Amount
+	c := spends.ReadCursor()
+	for k, v := c.Seek(seek[:]); k != nil; k, v = c.Next() {
+		total += btcutil.Amount(byteOrder.Uint64(v))
+	}
+	return total
+}
+
+// Transaction comments are keyed by transaction hash and the value is:
+//
+//   [0:2]     Comment length
+//   [2:n]     Comment
+//   [n:n+2]   Comment to length
+//   [n+2:]    Comment to
+//
+// The comments bucket is created when the first comment is recorded and, as
+// with labels, it is not removed when the transaction history is dropped.
+
+func putTxComment(ns walletdb.ReadWriteBucket, txHash *chainhash.Hash,
+	comment, commentTo string) er.R {
+
+	comments, err := ns.CreateBucketIfNotExists(bucketTxComments)
+	if err != nil {
+		str := "failed to create comments bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	v := make([]byte, 0, 4+len(comment)+len(commentTo))
+	for _, c := range []string{comment, commentTo} {
+		var l [2]byte
+		byteOrder.PutUint16(l[:], uint16(len(c)))
+		v = append(v, l[:]...)
+		v = append(v, c...)
+	}
+	if err := comments.Put(txHash[:], v); err != nil {
+		str := fmt.Sprintf("%s: put failed for %v", bucketTxComments, txHash)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func fetchTxComment(ns walletdb.ReadBucket, txHash *chainhash.Hash) (string,
+	string, er.R) {
+
+	comments := ns.NestedReadBucket(bucketTxComments)
+	if comments == nil {
+		return "", "", nil
+	}
+	v := comments.Get(txHash[:])
+	if v == nil {
+		return "", "", nil
+	}
+	var c [2]string
+	for i := range c {
+		if len(v) < 2 || len(v) < 2+int(byteOrder.Uint16(v)) {
+			str := fmt.Sprintf("%s: short read for %v", bucketTxComments, txHash)
+			return "", "", storeError(ErrData, str, nil)
+		}
+		n := 2 + int(byteOrder.Uint16(v))
+		c[i] = string(v[2:n])
+		v = v[n:]
+	}
+	return c[0], c[1], nil
+}
+
+// The rescan checkpoint records the progress of the rescan job which is
+// running, if any, so that it can be resumed after a restart.  It is stored
+// under a single key and the value is:
+//
+//   [0:4]     Next height to scan
+//   [4:8]     Stop height, negative for the sync height
+//   [8]       1 if the job only looks for some addresses, otherwise 0
+//   [9:]      Length prefixed name followed by each length prefixed address
+//
+// As with comments, the rescan bucket is not removed when the transaction
+// history is dropped.
+
+var keyRescanCheckpoint = []byte("checkpoint")
+
+func putRescanCheckpoint(ns walletdb.ReadWriteBucket, rc *RescanCheckpoint) er.R {
+	rescan, err := ns.CreateBucketIfNotExists(bucketRescan)
+	if err != nil {
+		str := "failed to create rescan bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	v := make([]byte, 9, 11+len(rc.Name))
+	byteOrder.PutUint32(v[0:4], uint32(rc.Height))
+	byteOrder.PutUint32(v[4:8], uint32(rc.StopHeight))
+	if rc.Addresses != nil {
+		v[8] = 1
+	}
+	for _, str := range append([]string{rc.Name}, rc.Addresses...) {
+		var l [2]byte
+		byteOrder.PutUint16(l[:], uint16(len(str)))
+		v = append(v, l[:]...)
+		v = append(v, str...)
+	}
+	if err := rescan.Put(keyRescanCheckpoint, v); err != nil {
+		str := fmt.Sprintf("%s: put failed", bucketRescan)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func fetchRescanCheckpoint(ns walletdb.ReadBucket) (*RescanCheckpoint, er.R) {
+	rescan := ns.NestedReadBucket(bucketRescan)
+	if rescan == nil {
+		return nil, nil
+	}
+	v := rescan.Get(keyRescanCheckpoint)
+	if v == nil {
+		return nil, nil
+	}
+	if len(v) < 9 {
+		str := fmt.Sprintf("%s: short read", bucketRescan)
+		return nil, storeError(ErrData, str, nil)
+	}
+	rc := &RescanCheckpoint{
+		Height:     int32(byteOrder.Uint32(v[0:4])),
+		StopHeight: int32(byteOrder.Uint32(v[4:8])),
+	}
+	if v[8] == 1 {
+		rc.Addresses = []string{}
+	}
+	var strs []string
+	for v = v[9:]; len(v) > 0; {
+		if len(v) < 2 || len(v) < 2+int(byteOrder.Uint16(v)) {
+			str := fmt.Sprintf("%s: short read", bucketRescan)
+			return nil, storeError(ErrData, str, nil)
+		}
+		n := 2 + int(byteOrder.Uint16(v))
+		strs = append(strs, string(v[2:n]))
+		v = v[n:]
+	}
+	if len(strs) == 0 {
+		str := fmt.Sprintf("%s: missing name", bucketRescan)
+		return nil, storeError(ErrData, str, nil)
+	}
+	rc.Name = strs[0]
+	if rc.Addresses != nil {
+		rc.Addresses = append(rc.Addresses, strs[1:]...)
+	}
+	return rc, nil
+}
+
+func deleteRescanCheckpoint(ns walletdb.ReadWriteBucket) er.R {
+	rescan := ns.NestedReadWriteBucket(bucketRescan)
+	if rescan == nil {
+		return nil
+	}
+	if err := rescan.Delete(keyRescanCheckpoint); err != nil {
+		str := fmt.Sprintf("%s: delete failed", bucketRescan)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// Frozen scripts are the output scripts, usually paying to a single address,
+// whose outputs must not be spent.  They are keyed by the script and have an
+// empty value.
+//
+// The frozen scripts bucket is created when the first script is frozen and, as
+// with the spends bucket, it is not removed when the transaction history is
+// dropped because it is set by the user rather than learned from the chain.
+
+func putFrozenScript(ns walletdb.ReadWriteBucket, pkScript []byte) er.R {
+	frozen, err := ns.CreateBucketIfNotExists(bucketFrozenScripts)
+	if err != nil {
+		str := "failed to create frozen scripts bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	if err := frozen.Put(pkScript, []byte{}); err != nil {
+		str := fmt.Sprintf("%s: put failed for %x", bucketFrozenScripts, pkScript)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func deleteFrozenScript(ns walletdb.ReadWriteBucket, pkScript []byte) er.R {
+	frozen := ns.NestedReadWriteBucket(bucketFrozenScripts)
+	if frozen == nil {
+		return nil
+	}
+	if err := frozen.Delete(pkScript); err != nil {
+		str := fmt.Sprintf("%s: delete failed for %x", bucketFrozenScripts, pkScript)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func isFrozenScript(ns walletdb.ReadBucket, pkScript []byte) bool {
+	frozen := ns.NestedReadBucket(bucketFrozenScripts)
+	return frozen != nil && frozen.Get(pkScript) != nil
+}
+
+func forEachFrozenScript(ns walletdb.ReadBucket, f func([]byte) er.R) er.R {
+	frozen := ns.NestedReadBucket(bucketFrozenScripts)
+	if frozen == nil {
+		return nil
+	}
+	return frozen.ForEach(func(k, _ []byte) er.R {
+		return f(k)
+	})
+}
+
+// The pinned change script is the output script which the change of new
+// transactions pays to in place of a fresh change address.  It is kept under
+// the root of the namespace and is absent unless a change address is pinned.
+
+func putChangeScript(ns walletdb.ReadWriteBucket, pkScript []byte) er.R {
+	if err := ns.Put(rootChange, pkScript); err != nil {
+		str := "failed to store pinned change script"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func fetchChangeScript(ns walletdb.ReadBucket) []byte {
+	v := ns.Get(rootChange)
+	if len(v) == 0 {
+		return nil
+	}
+	return append([]byte(nil), v...)
+}
+
+func deleteChangeScript(ns walletdb.ReadWriteBucket) er.R {
+	if err := ns.Delete(rootChange); err != nil {
+		str := "failed to delete pinned change script"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// Conflicted transactions are unmined transactions which were removed because
+// a mined transaction double spent one of their inputs, or spent from such a
+// transaction.  They are keyed by transaction hash and the value is:
+//
+//   [0:32]  Hash of the mined transaction (32 bytes)
+//   [32:]   Transaction record value, as for unmined transactions (varies)
+//
+// The conflicted bucket is created when the first conflict is recorded.
+
+func putConflicted(ns walletdb.ReadWriteBucket, rec *TxRecord,
+	conflictedBy *chainhash.Hash) er.R {
+
+	conflicted, err := ns.CreateBucketIfNotExists(bucketConflicted)
+	if err != nil {
+		str := "failed to create conflicted bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	recValue, err := valueTxRecord(rec)
+	if err != nil {
+		return err
+	}
+	v := make([]byte, 32, 32+len(recValue))
+	copy(v, conflictedBy[:])
+	v = append(v, recValue...)
+	if err := conflicted.Put(rec.Hash[:], v); err != nil {
+		str := fmt.Sprintf("%s: put failed for %v", bucketConflicted, rec.Hash)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func fetchConflicted(ns walletdb.ReadBucket, txHash *chainhash.Hash) (*TxRecord,
+	*chainhash.Hash, er.R) {
+
+	conflicted := ns.NestedReadBucket(bucketConflicted)
+	if conflicted == nil {
+		return nil, nil, nil
+	}
+	v := conflicted.Get(txHash[:])
+	if v == nil {
+		return nil, nil, nil
+	}
+	if len(v) < 32 {
+		str := fmt.Sprintf("%s: short read (expected %d bytes, read %d)",
+			bucketConflicted, 32, len(v))
+		return nil, nil, storeError(ErrData, str, nil)
+	}
+	var conflictedBy chainhash.Hash
+	copy(conflictedBy[:], v)
+	rec := new(TxRecord)
+	if err := readRawTxRecord(txHash, v[32:], rec); err != nil {
+		return nil, nil, err
+	}
+	return rec, &conflictedBy, nil
+}
+
+func deleteConflicted(ns walletdb.ReadWriteBucket, txHash *chainhash.Hash) er.R {
+	conflicted := ns.NestedReadWriteBucket(bucketConflicted)
+	if conflicted == nil {
+		return nil
+	}
+	if err := conflicted.Delete(txHash[:]); err != nil {
+		str := fmt.Sprintf("%s: delete failed for %v", bucketConflicted, txHash)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
 // openStore opens an existing transaction store from the passed namespace.
 func openStore(ns walletdb.ReadBucket) er.R {
 	version, err := fetchVersion(ns)
@@ -1439,6 +1796,12 @@ func deleteBuckets(ns walletdb.ReadWriteBucket) er.R {
 		str := "failed to delete locked outputs bucket"
 		return storeError(ErrDatabase, str, err)
 	}
+	if ns.NestedReadWriteBucket(bucketConflicted) != nil {
+		if err := ns.DeleteNestedBucket(bucketConflicted); err != nil {
+			str := "failed to delete conflicted bucket"
+			return storeError(ErrDatabase, str, err)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,35 @@
+package wtxmgr
+
+import (
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+)
+
+// Counts returns the number of transactions, mined and unmined, which are
+// recorded in the store and the number of unspent outputs.  Outputs which are
+// spent by an unmined transaction are not counted as unspent but locked
+// outputs are.
+func (s *Store) Counts(ns walletdb.ReadBucket) (int, int, er.R) {
+	txs, utxos := 0, 0
+	count := func(n *int, skipSpent bool) func(k, v []byte) er.R {
+		return func(k, v []byte) er.R {
+			if !skipSpent || existsRawUnminedInput(ns, k) == nil {
+				*n++
+			}
+			return nil
+		}
+	}
+	if err := ns.NestedReadBucket(bucketTxRecords).ForEach(count(&txs, false)); err != nil {
+		return 0, 0, storeError(ErrDatabase, "failed iterating transactions", err)
+	}
+	if err := ns.NestedReadBucket(bucketUnmined).ForEach(count(&txs, false)); err != nil {
+		return 0, 0, storeError(ErrDatabase, "failed iterating unmined transactions", err)
+	}
+	if err := ns.NestedReadBucket(bucketUnspent).ForEach(count(&utxos, true)); err != nil {
+		return 0, 0, storeError(ErrDatabase, "failed iterating unspent outputs", err)
+	}
+	if err := ns.NestedReadBucket(bucketUnminedCredits).ForEach(count(&utxos, true)); err != nil {
+		return 0, 0, storeError(ErrDatabase, "failed iterating unmined credits", err)
+	}
+	return txs, utxos, nil
+}
@@ -27,6 +27,10 @@ const (
 	// TxLabelLimit is the length limit we impose on transaction labels.
 	TxLabelLimit = 500
 
+	// TxCommentLimit is the length limit we impose on each of the comments
+	// of a transaction.
+	TxCommentLimit = 500
+
 	// DefaultLockDuration is the default duration used to lock outputs.
 	DefaultLockDuration = 10 * time.Minute
 )
@@ -50,6 +54,11 @@ var (
 	ErrTxLabelNotFound = Err.CodeWithDetail("ErrTxLabelNotFound",
 		"label for transaction not found")
 
+	// ErrCommentTooLong is returned when an attempt to write a comment that
+	// is too long is made.
+	ErrCommentTooLong = Err.CodeWithDetail("ErrCommentTooLong",
+		"transaction comment exceeds limit")
+
 	// ErrUnknownOutput is an error returned when an output not known to the
 	// wallet is attempted to be locked.
 	ErrUnknownOutput = Err.CodeWithDetail("ErrUnknownOutput", "unknown output")
@@ -167,6 +176,15 @@ type Credit struct {
 	PkScript     []byte
 	Received     time.Time
 	FromCoinBase bool
+
+	// OwnChange is set for unmined credits which are change outputs of a
+	// transaction that spends the wallet's own outputs.  It is never set
+	// for mined credits.
+	OwnChange bool
+
+	// Replaceable is set for unmined credits of a transaction which signals
+	// BIP125 replaceability.  It is never set for mined credits.
+	Replaceable bool
 }
 
 // LockID represents a unique context-specific ID assigned to an output lock.
@@ -183,6 +201,10 @@ type Store struct {
 	// Event callbacks.  These execute in the same goroutine as the wtxmgr
 	// caller.
 	NotifyUnspent func(hash *chainhash.Hash, index uint32)
+
+	// NotifyConflicted is called for each unmined transaction which is
+	// removed because it conflicts with the mined transaction conflictedBy.
+	NotifyConflicted func(hash, conflictedBy *chainhash.Hash)
 }
 
 // Open opens the wallet transaction store from a walletdb namespace.  If the
@@ -193,7 +215,7 @@ func Open(ns walletdb.ReadBucket, chainParams *chaincfg.Params) (*Store, er.R) {
 	if err != nil {
 		return nil, err
 	}
-	s := &Store{chainParams, clock.NewDefaultClock(), nil} // TODO: set callbacks
+	s := &Store{chainParams, clock.NewDefaultClock(), nil, nil} // TODO: set callbacks
 	return s, nil
 }
 
@@ -336,7 +358,7 @@ func (s *Store) RemoveUnminedTx(ns walletdb.ReadWriteBucket, rec *TxRecord) er.R
 	// As we already have a tx record, we can directly call the
 	// removeConflict method. This will do the job of recursively removing
 	// this unmined transaction, and any transactions that depend on it.
-	return removeConflict(ns, rec)
+	return removeConflict(ns, rec, nil)
 }
 
 // insertMinedTx inserts a new transaction record for a mined transaction into
@@ -411,6 +433,11 @@ func (s *Store) insertMinedTx(ns walletdb.ReadWriteBucket, rec *TxRecord,
 		}
 	}
 
+	// A transaction which was conflicted may still be mined after a reorg.
+	if err := deleteConflicted(ns, &rec.Hash); err != nil {
+		return err
+	}
+
 	// As there may be unconfirmed transactions that are invalidated by this
 	// transaction (either being duplicates, or double spends), remove them
 	// from the unconfirmed set.  This also handles removing unconfirmed
@@ -585,7 +612,7 @@ func rollbackTransaction(
 
 				log.Debugf("Transaction %v spends a removed coinbase "+
 					"output -- removing as well", unminedRec.Hash)
-				err = removeConflict(ns, &unminedRec)
+				err = removeConflict(ns, &unminedRec, nil)
 				if err != nil {
 					return
 				}
@@ -758,6 +785,33 @@ func (s *Store) ForEachUnspentOutput(
 	ns walletdb.ReadBucket,
 	beginKey []byte,
 	visitor func(key []byte, c *Credit) er.R,
+) er.R {
+	return s.forEachUnspentOutput(ns, beginKey, false, visitor)
+}
+
+// ForEachUnspentOutputIncludingLocked is ForEachUnspentOutput without skipping
+// the outputs which are locked with LockOutput, IsLockedOutput tells them
+// apart.
+func (s *Store) ForEachUnspentOutputIncludingLocked(
+	ns walletdb.ReadBucket,
+	beginKey []byte,
+	visitor func(key []byte, c *Credit) er.R,
+) er.R {
+	return s.forEachUnspentOutput(ns, beginKey, true, visitor)
+}
+
+// IsLockedOutput returns true if the output is locked with LockOutput and the
+// lock has not expired.
+func (s *Store) IsLockedOutput(ns walletdb.ReadBucket, op wire.OutPoint) bool {
+	_, _, isLocked := isLockedOutput(ns, op, s.clock.Now())
+	return isLocked
+}
+
+func (s *Store) forEachUnspentOutput(
+	ns walletdb.ReadBucket,
+	beginKey []byte,
+	includeLocked bool,
+	visitor func(key []byte, c *Credit) er.R,
 ) er.R {
 	var op wire.OutPoint
 	var block Block
@@ -771,8 +825,7 @@ func (s *Store) ForEachUnspentOutput(
 		}
 
 		// Skip the output if it's locked.
-		_, _, isLocked := isLockedOutput(ns, op, s.clock.Now())
-		if isLocked {
+		if !includeLocked && s.IsLockedOutput(ns, op) {
 			return nil
 		}
 
@@ -843,8 +896,7 @@ func (s *Store) ForEachUnspentOutput(
 		}
 
 		// Skip the output if it's locked.
-		_, _, isLocked := isLockedOutput(ns, op, s.clock.Now())
-		if isLocked {
+		if !includeLocked && s.IsLockedOutput(ns, op) {
 			return nil
 		}
 
@@ -857,6 +909,11 @@ func (s *Store) ForEachUnspentOutput(
 			return err
 		}
 
+		_, change, err := fetchRawUnminedCreditAmountChange(v)
+		if err != nil {
+			return err
+		}
+
 		txOut := rec.MsgTx.TxOut[op.Index]
 		cred := Credit{
 			OutPoint: op,
@@ -867,6 +924,8 @@ func (s *Store) ForEachUnspentOutput(
 			PkScript:     txOut.PkScript,
 			Received:     rec.Received,
 			FromCoinBase: blockchain.IsCoinBaseTx(&rec.MsgTx),
+			OwnChange:    change && spendsWalletOutputs(ns, &rec.MsgTx),
+			Replaceable:  SignalsReplacement(&rec.MsgTx),
 		}
 		// Use the final key to come from the main search loop so that further calls
 		// will arrive here as quickly as possible.
@@ -881,6 +940,33 @@ func (s *Store) ForEachUnspentOutput(
 	return nil
 }
 
+// spendsWalletOutputs returns true if any input of the transaction spends a
+// credit which is known to the wallet, either mined or unmined.
+func spendsWalletOutputs(ns walletdb.ReadBucket, tx *wire.MsgTx) bool {
+	for _, in := range tx.TxIn {
+		k := canonicalOutPoint(&in.PreviousOutPoint.Hash, in.PreviousOutPoint.Index)
+		if existsRawUnspent(ns, k) != nil || existsRawUnminedCredit(ns, k) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// maxRBFSequence is the highest input sequence number which signals that a
+// transaction may be replaced, as defined by BIP125.
+const maxRBFSequence = 0xfffffffd
+
+// SignalsReplacement returns true if any input of the transaction opts in to
+// replacement under BIP125.
+func SignalsReplacement(tx *wire.MsgTx) bool {
+	for _, in := range tx.TxIn {
+		if in.Sequence <= maxRBFSequence {
+			return true
+		}
+	}
+	return false
+}
+
 // GetUnspentOutputs returns all unspent received transaction outputs.
 // The order is undefined.
 func (s *Store) GetUnspentOutputs(ns walletdb.ReadBucket) ([]Credit, er.R) {
@@ -898,6 +984,10 @@ func (s *Store) Balance(ns walletdb.ReadBucket, minConf int32, syncHeight int32)
 	coinbaseMaturity := int32(s.chainParams.CoinbaseMaturity)
 	bal := btcutil.Amount(0)
 	return bal, s.ForEachUnspentOutput(ns, nil, func(_ []byte, output *Credit) er.R {
+		if isFrozenScript(ns, output.PkScript) {
+			// Frozen outputs cannot be spent
+			return nil
+		}
 		if output.Height == -1 {
 			// Not yet mined
 			if minConf == 0 {
@@ -1069,6 +1159,128 @@ func (s *Store) UnlockOutput(ns walletdb.ReadWriteBucket, id LockID,
 	return unlockOutput(ns, op)
 }
 
+// PutTxComment records a comment about a transaction and a comment about who it
+// was sent to, as with the comment and comment_to of the reference client.
+// Comments are only kept in the wallet, they are not part of the transaction.
+// Either comment may be empty.
+func (s *Store) PutTxComment(ns walletdb.ReadWriteBucket, txid chainhash.Hash,
+	comment, commentTo string) er.R {
+
+	if len(comment) > TxCommentLimit || len(commentTo) > TxCommentLimit {
+		return ErrCommentTooLong.Default()
+	}
+	return putTxComment(ns, &txid, comment, commentTo)
+}
+
+// FetchTxComment returns the comments recorded for a transaction by
+// PutTxComment, both are empty if none were recorded.
+func FetchTxComment(ns walletdb.ReadBucket, txid chainhash.Hash) (string,
+	string, er.R) {
+
+	return fetchTxComment(ns, &txid)
+}
+
+// RescanCheckpoint is the progress of a rescan job, it is persisted so that an
+// interrupted rescan resumes where it stopped rather than from the start.
+type RescanCheckpoint struct {
+	Name string
+
+	// Height is the next block to be scanned.
+	Height int32
+
+	// StopHeight is the last block to be scanned, a negative height means
+	// the block the wallet is synced to.
+	StopHeight int32
+
+	// Addresses are the only addresses which the rescan looks for, nil
+	// means that it looks for all of the wallet's addresses.
+	Addresses []string
+}
+
+// PutRescanCheckpoint records the progress of the running rescan job,
+// replacing any checkpoint which was recorded before.
+func (s *Store) PutRescanCheckpoint(ns walletdb.ReadWriteBucket, rc *RescanCheckpoint) er.R {
+	return putRescanCheckpoint(ns, rc)
+}
+
+// FetchRescanCheckpoint returns the checkpoint recorded by PutRescanCheckpoint,
+// or nil if there is none.
+func (s *Store) FetchRescanCheckpoint(ns walletdb.ReadBucket) (*RescanCheckpoint, er.R) {
+	return fetchRescanCheckpoint(ns)
+}
+
+// DeleteRescanCheckpoint removes the rescan checkpoint once the job has
+// completed or been stopped.
+func (s *Store) DeleteRescanCheckpoint(ns walletdb.ReadWriteBucket) er.R {
+	return deleteRescanCheckpoint(ns)
+}
+
+// FreezeScript freezes the outputs paying to pkScript, they are not available
+// for coin selection and are not counted in the balance until the script is
+// unfrozen with UnfreezeScript.  Outputs which are received later are frozen
+// as well.
+func (s *Store) FreezeScript(ns walletdb.ReadWriteBucket, pkScript []byte) er.R {
+	return putFrozenScript(ns, pkScript)
+}
+
+// UnfreezeScript unfreezes the outputs paying to pkScript.  Unfreezing a script
+// which is not frozen is not an error.
+func (s *Store) UnfreezeScript(ns walletdb.ReadWriteBucket, pkScript []byte) er.R {
+	return deleteFrozenScript(ns, pkScript)
+}
+
+// IsFrozenScript returns whether the outputs paying to pkScript are frozen.
+func (s *Store) IsFrozenScript(ns walletdb.ReadBucket, pkScript []byte) bool {
+	return isFrozenScript(ns, pkScript)
+}
+
+// ForEachFrozenScript calls f with each of the frozen scripts, in the order of
+// their serialization.
+func (s *Store) ForEachFrozenScript(ns walletdb.ReadBucket,
+	f func(pkScript []byte) er.R) er.R {
+
+	return forEachFrozenScript(ns, f)
+}
+
+// PutChangeScript pins the change of new transactions to pkScript until it is
+// removed with DeleteChangeScript.
+func (s *Store) PutChangeScript(ns walletdb.ReadWriteBucket, pkScript []byte) er.R {
+	return putChangeScript(ns, pkScript)
+}
+
+// ChangeScript returns the pinned change script, or nil if there is none.
+func (s *Store) ChangeScript(ns walletdb.ReadBucket) []byte {
+	return fetchChangeScript(ns)
+}
+
+// DeleteChangeScript unpins the change script.  Unpinning when no change
+// script is pinned is not an error.
+func (s *Store) DeleteChangeScript(ns walletdb.ReadWriteBucket) er.R {
+	return deleteChangeScript(ns)
+}
+
+// PutSpend records that amount was sent out of the wallet by a transaction at
+// time t.  It is used to enforce spending limits.
+func (s *Store) PutSpend(ns walletdb.ReadWriteBucket, txHash *chainhash.Hash,
+	t time.Time, amount btcutil.Amount) er.R {
+
+	return putSpend(ns, txHash, t, amount)
+}
+
+// DeleteSpend removes the spend which was recorded by PutSpend for a
+// transaction at time t, for example because the transaction failed to
+// publish.
+func (s *Store) DeleteSpend(ns walletdb.ReadWriteBucket, txHash *chainhash.Hash,
+	t time.Time) er.R {
+
+	return deleteSpend(ns, txHash, t)
+}
+
+// SpentSince returns the total amount recorded by PutSpend at or after time t.
+func (s *Store) SpentSince(ns walletdb.ReadBucket, t time.Time) btcutil.Amount {
+	return sumSpendsSince(ns, t)
+}
+
 // DeleteExpiredLockedOutputs iterates through all existing locked outputs and
 // deletes those which have already expired.
 func (s *Store) DeleteExpiredLockedOutputs(ns walletdb.ReadWriteBucket) er.R {
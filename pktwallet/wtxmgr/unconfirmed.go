@@ -6,6 +6,8 @@
 package wtxmgr
 
 import (
+	"time"
+
 	"github.com/pkt-cash/pktd/btcutil/er"
 	"github.com/pkt-cash/pktd/chaincfg/chainhash"
 	"github.com/pkt-cash/pktd/pktlog/log"
@@ -47,6 +49,9 @@ func (s *Store) insertMemPoolTx(ns walletdb.ReadWriteBucket, rec *TxRecord) er.R
 	if err != nil {
 		return err
 	}
+	if err := deleteConflicted(ns, &rec.Hash); err != nil {
+		return err
+	}
 
 	for _, input := range rec.MsgTx.TxIn {
 		prevOut := &input.PreviousOutPoint
@@ -66,8 +71,20 @@ func (s *Store) insertMemPoolTx(ns walletdb.ReadWriteBucket, rec *TxRecord) er.R
 // removeDoubleSpends checks for any unmined transactions which would introduce
 // a double spend if tx was added to the store (either as a confirmed or unmined
 // transaction).  Each conflicting transaction and all transactions which spend
-// it are recursively removed.
+// it are recursively removed and recorded as conflicted by tx.
 func (s *Store) removeDoubleSpends(ns walletdb.ReadWriteBucket, rec *TxRecord) er.R {
+	markConflicted := func(conflicted *TxRecord) er.R {
+		log.Infof("Unconfirmed transaction [%s] conflicts with mined "+
+			"transaction [%s]", conflicted.Hash, rec.Hash)
+		if err := putConflicted(ns, conflicted, &rec.Hash); err != nil {
+			return err
+		}
+		if s.NotifyConflicted != nil {
+			s.NotifyConflicted(&conflicted.Hash, &rec.Hash)
+		}
+		return nil
+	}
+
 	for _, input := range rec.MsgTx.TxIn {
 		prevOut := &input.PreviousOutPoint
 		prevOutKey := canonicalOutPoint(&prevOut.Hash, prevOut.Index)
@@ -103,7 +120,8 @@ func (s *Store) removeDoubleSpends(ns walletdb.ReadWriteBucket, rec *TxRecord) e
 			log.Debugf("Removing double spending transaction %v",
 				doubleSpend.Hash)
 
-			if err := removeConflict(ns, &doubleSpend); err != nil {
+			err = removeConflict(ns, &doubleSpend, markConflicted)
+			if err != nil {
 				return err
 			}
 		}
@@ -115,8 +133,17 @@ func (s *Store) removeDoubleSpends(ns walletdb.ReadWriteBucket, rec *TxRecord) e
 // removeConflict removes an unmined transaction record and all spend chains
 // deriving from it from the store.  This is designed to remove transactions
 // that would otherwise result in double spend conflicts if left in the store,
-// and to remove transactions that spend coinbase transactions on reorgs.
-func removeConflict(ns walletdb.ReadWriteBucket, rec *TxRecord) er.R {
+// and to remove transactions that spend coinbase transactions on reorgs.  If
+// removed is not nil, it is called with each transaction before it is removed.
+func removeConflict(ns walletdb.ReadWriteBucket, rec *TxRecord,
+	removed func(*TxRecord) er.R) er.R {
+
+	if removed != nil {
+		if err := removed(rec); err != nil {
+			return err
+		}
+	}
+
 	// For each potential credit for this record, each spender (if any) must
 	// be recursively removed as well.  Once the spenders are removed, the
 	// credit is deleted.
@@ -143,7 +170,7 @@ func removeConflict(ns walletdb.ReadWriteBucket, rec *TxRecord) er.R {
 
 			log.Debugf("Transaction %v is part of a removed conflict "+
 				"chain -- removing as well", spender.Hash)
-			if err := removeConflict(ns, &spender); err != nil {
+			if err := removeConflict(ns, &spender, removed); err != nil {
 				return err
 			}
 		}
@@ -167,6 +194,49 @@ func removeConflict(ns walletdb.ReadWriteBucket, rec *TxRecord) er.R {
 	return deleteRawUnmined(ns, rec.Hash[:])
 }
 
+// ExpireUnminedTxs removes every unmined transaction which was received before
+// t along with all spend chains deriving from it, so that the outputs which
+// they spent become unspent again.  The hashes of all removed transactions are
+// returned.
+func (s *Store) ExpireUnminedTxs(ns walletdb.ReadWriteBucket, t time.Time) ([]chainhash.Hash, er.R) {
+	recSet, err := s.unminedTxRecords(ns)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range recSet {
+		if !rec.Received.Before(t) {
+			continue
+		}
+		// Already removed as part of the spend chain of another
+		// expired transaction.
+		if existsRawUnmined(ns, rec.Hash[:]) == nil {
+			continue
+		}
+		log.Infof("Expiring unmined transaction [%s] received at [%s]",
+			rec.Hash, rec.Received)
+		if err := removeConflict(ns, rec, nil); err != nil {
+			return nil, err
+		}
+	}
+	var removed []chainhash.Hash
+	for txHash := range recSet {
+		if existsRawUnmined(ns, txHash[:]) == nil {
+			removed = append(removed, txHash)
+		}
+	}
+	return removed, nil
+}
+
+// ConflictedTx returns the record of an unmined transaction which was removed
+// because it conflicted with a mined transaction, along with the hash of the
+// mined transaction.  A nil record is returned if the transaction is not known
+// to have been conflicted.
+func (s *Store) ConflictedTx(ns walletdb.ReadBucket, txHash *chainhash.Hash) (*TxRecord,
+	*chainhash.Hash, er.R) {
+
+	return fetchConflicted(ns, txHash)
+}
+
 // UnminedTxs returns the underlying transactions for all unmined transactions
 // which are not known to have been mined in a block.  Transactions are
 // guaranteed to be sorted by their dependency order.
@@ -0,0 +1,192 @@
+package wtxmgr
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/chaincfg/chainhash"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// Verify walks the buckets of the transaction store checking that the records
+// are consistent with one another.  Each inconsistency which is found is
+// described in the returned report, an error is only returned if the database
+// cannot be read.  The following are checked:
+//
+//   - every block record references transactions which are recorded in it
+//   - every unspent output references an unspent credit of the same value as
+//     the output of its transaction
+//   - every credit references a known transaction, unspent credits are in the
+//     unspent index and spent credits reference their debit
+//   - every debit references a known transaction and credit of the same value
+//   - every unmined credit references a known unmined transaction
+//   - the balances reconcile: the unspent outputs are worth all credits less
+//     the spent ones, and the spent credits are worth all debits
+func (s *Store) Verify(ns walletdb.ReadBucket) ([]string, er.R) {
+	var report []string
+	problem := func(format string, args ...interface{}) {
+		report = append(report, fmt.Sprintf(format, args...))
+	}
+	var unspentTotal, creditTotal, spentTotal, debitTotal btcutil.Amount
+
+	err := ns.NestedReadBucket(bucketBlocks).ForEach(func(k, v []byte) er.R {
+		var br blockRecord
+		if err := readRawBlockRecord(k, v, &br); err != nil {
+			problem("block record %x is malformed: %v", k, err)
+			return nil
+		}
+		for i := range br.transactions {
+			if _, v := existsTxRecord(ns, &br.transactions[i], &br.Block); v == nil {
+				problem("block %v @ %d references unknown transaction %v",
+					br.Hash, br.Height, br.transactions[i])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = ns.NestedReadBucket(bucketUnspent).ForEach(func(k, v []byte) er.R {
+		var op wire.OutPoint
+		if err := readCanonicalOutPoint(k, &op); err != nil || len(v) < 36 {
+			problem("unspent output %x is malformed", k)
+			return nil
+		}
+		credKey := existsRawUnspent(ns, k)
+		cv := existsRawCredit(ns, credKey)
+		if cv == nil {
+			problem("unspent output %v has no credit", op)
+			return nil
+		}
+		if len(cv) < 9 {
+			problem("credit for unspent output %v is malformed", op)
+			return nil
+		}
+		if cv[8]&(1<<0) != 0 {
+			problem("unspent output %v is marked spent in its credit", op)
+		}
+		rv := existsRawTxRecord(ns, extractRawCreditTxRecordKey(credKey))
+		if rv == nil {
+			problem("unspent output %v references unknown transaction", op)
+			return nil
+		}
+		var rec TxRecord
+		if err := readRawTxRecord(&op.Hash, rv, &rec); err != nil {
+			problem("transaction %v is malformed: %v", op.Hash, err)
+			return nil
+		}
+		if int(op.Index) >= len(rec.MsgTx.TxOut) {
+			problem("unspent output %v is beyond the outputs of its "+
+				"transaction", op)
+			return nil
+		}
+		amount, _ := fetchRawCreditAmount(cv)
+		unspentTotal += amount
+		if int64(amount) != rec.MsgTx.TxOut[op.Index].Value {
+			problem("credit for unspent output %v has value %v but the "+
+				"output has value %v", op, amount,
+				rec.MsgTx.TxOut[op.Index].Value)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = ns.NestedReadBucket(bucketCredits).ForEach(func(k, v []byte) er.R {
+		if len(k) < 72 || len(v) < 9 {
+			problem("credit %x is malformed", k)
+			return nil
+		}
+		var txHash chainhash.Hash
+		copy(txHash[:], k[:32])
+		index := extractRawCreditIndex(k)
+		if existsRawTxRecord(ns, extractRawCreditTxRecordKey(k)) == nil {
+			problem("credit %v:%d references unknown transaction", txHash,
+				index)
+		}
+		amount, _ := fetchRawCreditAmount(v)
+		creditTotal += amount
+		if v[8]&(1<<0) == 0 {
+			unspentKey := canonicalOutPoint(&txHash, index)
+			if !bytes.Equal(existsRawUnspent(ns, unspentKey), k) {
+				problem("unspent credit %v:%d is missing from the unspent "+
+					"index", txHash, index)
+			}
+			return nil
+		}
+		spentTotal += amount
+		if len(v) < 81 {
+			problem("spent credit %v:%d does not reference its debit",
+				txHash, index)
+		} else if ns.NestedReadBucket(bucketDebits).Get(v[9:81]) == nil {
+			problem("spent credit %v:%d references unknown debit", txHash,
+				index)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = ns.NestedReadBucket(bucketDebits).ForEach(func(k, v []byte) er.R {
+		if len(k) < 72 || len(v) < 80 {
+			problem("debit %x is malformed", k)
+			return nil
+		}
+		var txHash chainhash.Hash
+		copy(txHash[:], k[:32])
+		index := byteOrder.Uint32(k[68:72])
+		if existsRawTxRecord(ns, k[:68]) == nil {
+			problem("debit %v:%d references unknown transaction", txHash,
+				index)
+		}
+		amount := btcutil.Amount(byteOrder.Uint64(v))
+		debitTotal += amount
+		cv := existsRawCredit(ns, extractRawDebitCreditKey(v))
+		if cv == nil {
+			problem("debit %v:%d references unknown credit", txHash, index)
+		} else if credit, err := fetchRawCreditAmount(cv); err == nil &&
+			credit != amount {
+
+			problem("debit %v:%d has value %v but its credit has value %v",
+				txHash, index, amount, credit)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = ns.NestedReadBucket(bucketUnminedCredits).ForEach(func(k, v []byte) er.R {
+		var op wire.OutPoint
+		if err := readCanonicalOutPoint(k, &op); err != nil {
+			problem("unmined credit %x is malformed", k)
+			return nil
+		}
+		if existsRawUnmined(ns, op.Hash[:]) == nil {
+			problem("unmined credit %v references unknown unmined "+
+				"transaction", op)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if unspentTotal != creditTotal-spentTotal {
+		problem("balances do not reconcile: unspent outputs are worth %v "+
+			"but credits less spent credits are worth %v", unspentTotal,
+			creditTotal-spentTotal)
+	}
+	if spentTotal != debitTotal {
+		problem("balances do not reconcile: spent credits are worth %v "+
+			"but debits are worth %v", spentTotal, debitTotal)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,156 @@
+package wtxmgr
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkt-cash/pktd/btcutil/er"
+	"github.com/pkt-cash/pktd/pktwallet/walletdb"
+	"github.com/pkt-cash/pktd/wire"
+)
+
+// verifyFixture creates a store holding mined and unmined credits and debits.
+// It returns the mined transaction whose output is unspent.
+func verifyFixture(t *testing.T) (*Store, walletdb.DB, *wire.MsgTx, func()) {
+	s, db, teardown, err := testStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b100 := makeBlockMeta(100)
+	b101 := makeBlockMeta(101)
+	txA := newCoinBase(4e8, 5e8)
+	hashA := txA.TxHash()
+	txB := spendOutput(&hashA, 0, 3e8)
+	txC := spendOutput(&hashA, 1, 4e8)
+
+	commitDBTx(t, s, db, func(ns walletdb.ReadWriteBucket) {
+		for _, ins := range []struct {
+			tx      *wire.MsgTx
+			block   *BlockMeta
+			credits []uint32
+		}{
+			{txA, &b100, []uint32{0, 1}},
+			{txB, &b101, []uint32{0}},
+			{txC, nil, []uint32{0}},
+		} {
+			rec, err := NewTxRecordFromMsgTx(ins.tx, time.Now())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.InsertTx(ns, rec, ins.block); err != nil {
+				t.Fatal(err)
+			}
+			for _, idx := range ins.credits {
+				if err := s.AddCredit(ns, rec, ins.block, idx, false); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	})
+	return s, db, txB, teardown
+}
+
+func verifyReport(t *testing.T, s *Store, db walletdb.DB) []string {
+	var report []string
+	err := walletdb.View(db, func(tx walletdb.ReadTx) er.R {
+		var err er.R
+		report, err = s.Verify(tx.ReadBucket(namespaceKey))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+// TestVerify ensures that a consistent store passes verification and that
+// deliberately corrupted stores are reported.
+func TestVerify(t *testing.T) {
+	s, db, _, teardown := verifyFixture(t)
+	report := verifyReport(t, s, db)
+	teardown()
+	if len(report) != 0 {
+		t.Fatalf("clean store reported inconsistencies: %v", report)
+	}
+
+	tests := []struct {
+		name    string
+		corrupt func(ns walletdb.ReadWriteBucket, txB *wire.MsgTx)
+		want    string
+	}{
+		{
+			name: "missing transaction record",
+			corrupt: func(ns walletdb.ReadWriteBucket, txB *wire.MsgTx) {
+				b101 := makeBlockMeta(101)
+				hash := txB.TxHash()
+				k := keyTxRecord(&hash, &b101.Block)
+				if err := ns.NestedReadWriteBucket(bucketTxRecords).Delete(k); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "references unknown transaction",
+		},
+		{
+			name: "credit value mismatch",
+			corrupt: func(ns walletdb.ReadWriteBucket, txB *wire.MsgTx) {
+				b101 := makeBlockMeta(101)
+				hash := txB.TxHash()
+				k := keyCredit(&hash, 0, &b101.Block)
+				v := append([]byte{}, existsRawCredit(ns, k)...)
+				byteOrder.PutUint64(v, 1)
+				if err := putRawCredit(ns, k, v); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "has value",
+		},
+		{
+			name: "missing unspent index entry",
+			corrupt: func(ns walletdb.ReadWriteBucket, txB *wire.MsgTx) {
+				hash := txB.TxHash()
+				if err := DeleteRawUnspent(ns, canonicalOutPoint(&hash, 0)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "missing from the unspent index",
+		},
+		{
+			name: "debit value mismatch",
+			corrupt: func(ns walletdb.ReadWriteBucket, txB *wire.MsgTx) {
+				b101 := makeBlockMeta(101)
+				hash := txB.TxHash()
+				k, _, err := existsDebit(ns, &hash, 0, &b101.Block)
+				if err != nil || k == nil {
+					t.Fatalf("no debit for %v: %v", hash, err)
+				}
+				bucket := ns.NestedReadWriteBucket(bucketDebits)
+				v := append([]byte{}, bucket.Get(k)...)
+				byteOrder.PutUint64(v, 1)
+				if err := bucket.Put(k, v); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "balances do not reconcile",
+		},
+	}
+	for _, test := range tests {
+		s, db, txB, teardown := verifyFixture(t)
+		commitDBTx(t, s, db, func(ns walletdb.ReadWriteBucket) {
+			test.corrupt(ns, txB)
+		})
+		report := verifyReport(t, s, db)
+		teardown()
+		found := false
+		for _, r := range report {
+			if strings.Contains(r, test.want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("%s: report %v does not contain %q", test.name,
+				report, test.want)
+		}
+	}
+}
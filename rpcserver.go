@@ -1400,6 +1400,8 @@ func handleGetNetworkInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct
 		Incrementalfee: cfg.minRelayTxFee.ToBTC(),
 
 		Localaddresses: []string{}, // TODO populate
+
+		Bech32HRP: s.cfg.ChainParams.Bech32HRPSegwit,
 	}, nil
 }
 
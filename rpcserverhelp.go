@@ -160,6 +160,7 @@ var helpDescsEnUS = map[string]string{
 	"getnetworkinforesult-relayfee":           "Lowest transaction fee that is allowed for relaying",
 	"getnetworkinforesult-incrementalfee":     "Minimum fee increment for BIP 125 replacement",
 	"getnetworkinforesult-localaddresses":     "TODO Always empty for now",
+	"getnetworkinforesult-bech32hrp":          "The bech32 human-readable part used for segwit addresses on the active network",
 
 	"getnetworkinfonetworks-reachable":                   "If the network is externally reachable",
 	"getnetworkinfonetworks-limited":                     "True if this is the only allowed network",
@@ -97,6 +97,25 @@ func IsPayToWitnessScriptHash(script []byte) bool {
 	return isWitnessScriptHash(pops)
 }
 
+// isWitnessTaproot returns true if the passed script is a pay-to-taproot
+// output, a version 1 witness program of a 32 byte output key, and false
+// otherwise.
+func isWitnessTaproot(pops []parsescript.ParsedOpcode) bool {
+	return len(pops) == 2 &&
+		pops[0].Opcode.Value == opcode.OP_1 &&
+		pops[1].Opcode.Value == opcode.OP_DATA_32
+}
+
+// IsPayToTaproot returns true if the script is in the standard
+// pay-to-taproot (P2TR) format, false otherwise.
+func IsPayToTaproot(script []byte) bool {
+	pops, err := parsescript.ParseScript(script)
+	if err != nil {
+		return false
+	}
+	return isWitnessTaproot(pops)
+}
+
 // IsPayToWitnessPubKeyHash returns true if the is in the standard
 // pay-to-witness-pubkey-hash (P2WKH) format, false otherwise.
 func IsPayToWitnessPubKeyHash(script []byte) bool {
@@ -66,6 +66,7 @@ const (
 	WitnessV0ScriptHashTy                    // Pay to witness script hash.
 	MultiSigTy                               // Multi signature.
 	NullDataTy                               // Empty data-only (provably prunable).
+	WitnessV1TaprootTy                       // Pay to taproot output key.
 )
 
 // scriptClassToName houses the human-readable strings which describe each
@@ -79,6 +80,7 @@ var scriptClassToName = []string{
 	WitnessV0ScriptHashTy: "witness_v0_scripthash",
 	MultiSigTy:            "multisig",
 	NullDataTy:            "nulldata",
+	WitnessV1TaprootTy:    "witness_v1_taproot",
 }
 
 // String implements the Stringer interface by returning the name of
@@ -93,7 +95,8 @@ func (t ScriptClass) String() string {
 
 // IsSegwit returns true if the script is a known segwit type.
 func (t ScriptClass) IsSegwit() bool {
-	return t == WitnessV0PubKeyHashTy || t == WitnessV0ScriptHashTy
+	return t == WitnessV0PubKeyHashTy || t == WitnessV0ScriptHashTy ||
+		t == WitnessV1TaprootTy
 }
 
 // isPubkey returns true if the script passed is a pay-to-pubkey transaction,
@@ -182,6 +185,8 @@ func typeOfScript(pops []parsescript.ParsedOpcode) ScriptClass {
 		return ScriptHashTy
 	} else if isWitnessScriptHash(pops) {
 		return WitnessV0ScriptHashTy
+	} else if isWitnessTaproot(pops) {
+		return WitnessV1TaprootTy
 	} else if isMultiSig(pops) {
 		return MultiSigTy
 	} else if isNullData(pops) {
@@ -226,6 +231,10 @@ func expectedInputs(pops []parsescript.ParsedOpcode, class ScriptClass) int {
 		// Not including script.  That is handled by the caller.
 		return 1
 
+	case WitnessV1TaprootTy:
+		// A key path spend, the signature.
+		return 1
+
 	case MultiSigTy:
 		// Standard multisig has a push a small number for the number
 		// of sigs and number of keys.  Check the first push instruction
@@ -435,6 +444,12 @@ func payToWitnessScriptHashScript(scriptHash []byte) ([]byte, er.R) {
 	return payToWitnessScriptHashScriptBuilder(scriptHash).Script()
 }
 
+// payToTaprootScript creates a new script to pay to a version 1 witness
+// program, the taproot output key. The passed key is expected to be valid.
+func payToTaprootScript(outputKey []byte) ([]byte, er.R) {
+	return scriptbuilder.NewScriptBuilder().AddOp(opcode.OP_1).AddData(outputKey).Script()
+}
+
 // payToPubKeyScriptBuilder creates a new script to pay a transaction output to a
 // public key. It is expected that the input is a valid pubkey.
 func payToPubKeyScriptBuilder(serializedPubKey []byte) *scriptbuilder.ScriptBuilder {
@@ -515,6 +530,9 @@ func PayToAddrScriptWithVote(addr btcutil.Address, voteFor, voteAgainst []byte)
 	case *btcutil.AddressWitnessScriptHash:
 		return payToWitnessScriptHashScript(addr.ScriptAddress())
 
+	case *btcutil.AddressTaproot:
+		return payToTaprootScript(addr.ScriptAddress())
+
 	case *btcutil.AddressNonStandard:
 		return payToNonStandardScriptBuilder(addr.ScriptAddress(), voteFor, voteAgainst)
 	}
@@ -688,6 +706,17 @@ func ExtractPkScriptAddrs(pkScript []byte, chainParams *chaincfg.Params) (Script
 			addrs = append(addrs, addr)
 		}
 
+	case WitnessV1TaprootTy:
+		// A pay-to-taproot script is of the form:
+		//  OP_1 <32-byte x-only key>
+		// Therefore, the output key is the second item on the stack.
+		requiredSigs = 1
+		addr, err := btcutil.NewAddressTaproot(pops[1].Data,
+			chainParams)
+		if err == nil {
+			addrs = append(addrs, addr)
+		}
+
 	case MultiSigTy:
 		// A multi-signature script is of the form:
 		//  <numsigs> <pubkey> <pubkey> <pubkey>... <numpubkeys> OP_CHECKMULTISIG
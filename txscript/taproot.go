@@ -0,0 +1,47 @@
+package txscript
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/pkt-cash/pktd/btcec"
+	"github.com/pkt-cash/pktd/btcutil/er"
+)
+
+// taggedHash computes the BIP 340 tagged hash of msg,
+// sha256(sha256(tag) || sha256(tag) || msg).
+func taggedHash(tag string, msg []byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	h.Write(msg)
+	return h.Sum(nil)
+}
+
+// ComputeTaprootKeyNoScript computes the BIP 341 output key of a taproot
+// output which commits to no script tree, as used for single key outputs by
+// BIP 86.  The output key is returned with its y coordinate as computed, its
+// x coordinate is the witness program of the output.
+func ComputeTaprootKeyNoScript(internalKey *btcec.PublicKey) (*btcec.PublicKey, er.R) {
+	curve := btcec.S256()
+
+	// The internal key is taken as the point with an even y coordinate
+	// which has its x coordinate.
+	x := internalKey.SerializeCompressed()[1:]
+	y := new(big.Int).Set(internalKey.Y)
+	if y.Bit(0) == 1 {
+		y.Sub(curve.P, y)
+	}
+
+	tweak := taggedHash("TapTweak", x)
+	if new(big.Int).SetBytes(tweak).Cmp(curve.N) >= 0 {
+		return nil, er.New("taproot tweak exceeds the curve order")
+	}
+	tx, ty := curve.ScalarBaseMult(tweak)
+	qx, qy := curve.Add(internalKey.X, y, tx, ty)
+	if qx.Sign() == 0 && qy.Sign() == 0 {
+		return nil, er.New("taproot output key is the point at infinity")
+	}
+	return &btcec.PublicKey{Curve: curve, X: qx, Y: qy}, nil
+}
@@ -0,0 +1,74 @@
+package txscript
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/pkt-cash/pktd/btcec"
+	"github.com/pkt-cash/pktd/btcutil"
+	"github.com/pkt-cash/pktd/chaincfg"
+)
+
+// TestComputeTaprootKeyNoScript checks the output key and address of the
+// first BIP 86 test vector, and that the address round trips through its
+// pkScript.
+func TestComputeTaprootKeyNoScript(t *testing.T) {
+	internalKey, _ := hex.DecodeString(
+		"03cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+	wantOutputKey := "a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c"
+	wantAddr := "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"
+
+	pubKey, err := btcec.ParsePubKey(internalKey, btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputKey, err := ComputeTaprootKeyNoScript(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xOnly := outputKey.SerializeCompressed()[1:]
+	if hex.EncodeToString(xOnly) != wantOutputKey {
+		t.Fatalf("got output key %x, want %s", xOnly, wantOutputKey)
+	}
+
+	// The parity of the internal key does not matter.
+	internalKey[0] ^= 1
+	pubKey, err = btcec.ParsePubKey(internalKey, btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputKey, err = ComputeTaprootKeyNoScript(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(outputKey.SerializeCompressed()[1:], xOnly) {
+		t.Fatalf("output key depends on the parity of the internal key")
+	}
+
+	addr, err := btcutil.NewAddressTaproot(xOnly, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr.EncodeAddress() != wantAddr {
+		t.Fatalf("got address %s, want %s", addr.EncodeAddress(), wantAddr)
+	}
+
+	pkScript, err := PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsPayToTaproot(pkScript) {
+		t.Fatalf("pkScript %x is not pay-to-taproot", pkScript)
+	}
+	class, addrs, reqSigs, err := ExtractPkScriptAddrs(pkScript,
+		&chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if class != WitnessV1TaprootTy || reqSigs != 1 || len(addrs) != 1 ||
+		addrs[0].EncodeAddress() != wantAddr {
+		t.Fatalf("got class %v, addresses %v, required signatures %d",
+			class, addrs, reqSigs)
+	}
+}
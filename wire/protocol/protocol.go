@@ -54,6 +54,17 @@ const (
 	FeeFilterVersion uint32 = 70013
 )
 
+// IsKnownVersion returns whether v is one of the protocol versions above.
+func IsKnownVersion(v uint32) bool {
+	switch v {
+	case MultipleAddressVersion, NetAddressTimeVersion, BIP0031Version,
+		BIP0035Version, BIP0037Version, RejectVersion, BIP0111Version,
+		SendHeadersVersion, FeeFilterVersion:
+		return true
+	}
+	return false
+}
+
 // ServiceFlag identifies services supported by a bitcoin peer.
 type ServiceFlag uint64
 
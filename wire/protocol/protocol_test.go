@@ -62,3 +62,24 @@ func TestBitcoinNetStringer(t *testing.T) {
 		}
 	}
 }
+
+// TestIsKnownVersion tests that only the defined protocol versions are known.
+func TestIsKnownVersion(t *testing.T) {
+	tests := []struct {
+		in   uint32
+		want bool
+	}{
+		{protocol.MultipleAddressVersion, true},
+		{protocol.RejectVersion, true},
+		{protocol.ProtocolVersion, true},
+		{0, false},
+		{70003, false},
+		{protocol.ProtocolVersion + 1, false},
+	}
+	for _, test := range tests {
+		if got := protocol.IsKnownVersion(test.in); got != test.want {
+			t.Errorf("IsKnownVersion(%d): got %v, want %v", test.in, got,
+				test.want)
+		}
+	}
+}